@@ -2,29 +2,60 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"time"
 
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
 	"github.com/mapserver2007/ipat-aggregator/app/usecase/aggregation_usecase"
+	"github.com/sirupsen/logrus"
 )
 
 type Aggregation struct {
 	aggregationSummaryUseCase       aggregation_usecase.Summary
 	aggregationTicketSummaryUseCase aggregation_usecase.TicketSummary
 	aggregationListUseCase          aggregation_usecase.List
+	aggregationReportUseCase        aggregation_usecase.Report
+	aggregationSnapshotDiffUseCase  aggregation_usecase.SnapshotDiff
+	logger                          *logrus.Logger
 }
 
 type AggregationInput struct {
-	Master *MasterOutput
+	Master  *MasterOutput
+	Account types.Account
+}
+
+type ReportInput struct {
+	Master   *MasterOutput
+	Account  types.Account
+	Period   types.ReportPeriod
+	BaseDate time.Time
+	Bankroll int
+}
+
+type SnapshotDiffInput struct {
+	Sheet         types.SnapshotSheet
+	From          string
+	To            string
+	RateThreshold float64
+	MinCount      int
 }
 
 func NewAggregation(
 	aggregationSummaryUseCase aggregation_usecase.Summary,
 	aggregationTicketSummaryUseCase aggregation_usecase.TicketSummary,
 	aggregationListUseCase aggregation_usecase.List,
+	aggregationReportUseCase aggregation_usecase.Report,
+	aggregationSnapshotDiffUseCase aggregation_usecase.SnapshotDiff,
+	logger *logrus.Logger,
 ) *Aggregation {
 	return &Aggregation{
 		aggregationSummaryUseCase:       aggregationSummaryUseCase,
 		aggregationTicketSummaryUseCase: aggregationTicketSummaryUseCase,
 		aggregationListUseCase:          aggregationListUseCase,
+		aggregationReportUseCase:        aggregationReportUseCase,
+		aggregationSnapshotDiffUseCase:  aggregationSnapshotDiffUseCase,
+		logger:                          logger,
 	}
 }
 
@@ -32,6 +63,7 @@ func (a *Aggregation) Execute(ctx context.Context, input *AggregationInput) erro
 	err := a.aggregationSummaryUseCase.ExecuteV2(ctx, &aggregation_usecase.SummaryInput{
 		Tickets: input.Master.Tickets,
 		Races:   input.Master.Races,
+		Account: input.Account,
 	})
 	if err != nil {
 		return err
@@ -39,6 +71,7 @@ func (a *Aggregation) Execute(ctx context.Context, input *AggregationInput) erro
 
 	err = a.aggregationTicketSummaryUseCase.Execute(ctx, &aggregation_usecase.TicketSummaryInput{
 		Tickets: input.Master.Tickets,
+		Account: input.Account,
 	})
 	if err != nil {
 		return err
@@ -48,10 +81,46 @@ func (a *Aggregation) Execute(ctx context.Context, input *AggregationInput) erro
 		Tickets: input.Master.Tickets,
 		Races:   input.Master.Races,
 		Jockeys: input.Master.Jockeys,
+		Account: input.Account,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *Aggregation) Report(ctx context.Context, input *ReportInput) error {
+	dir, err := a.aggregationReportUseCase.Execute(ctx, &aggregation_usecase.ReportInput{
+		Tickets:  input.Master.Tickets,
+		Races:    input.Master.Races,
+		Jockeys:  input.Master.Jockeys,
+		Markers:  input.Master.AnalysisMarkers,
+		Account:  input.Account,
+		Period:   input.Period,
+		BaseDate: input.BaseDate,
+		Bankroll: input.Bankroll,
+	})
+	if err != nil {
+		return err
+	}
+	a.logger.Infof("report written: %s", dir)
+
+	return nil
+}
+
+func (a *Aggregation) SnapshotDiff(ctx context.Context, input *SnapshotDiffInput) error {
+	diff, err := a.aggregationSnapshotDiffUseCase.Execute(ctx, &aggregation_usecase.SnapshotDiffInput{
+		Sheet:         input.Sheet,
+		From:          input.From,
+		To:            input.To,
+		RateThreshold: input.RateThreshold,
+		MinCount:      input.MinCount,
 	})
 	if err != nil {
 		return err
 	}
+	fmt.Fprint(os.Stdout, diff)
 
 	return nil
 }
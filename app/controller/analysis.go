@@ -97,6 +97,84 @@ func (a *Analysis) RaceTime(ctx context.Context, input *AnalysisInput) {
 	a.logger.Info("fetching analysis race time end")
 }
 
+func (a *Analysis) Pedigree(ctx context.Context, input *AnalysisInput) {
+	a.logger.Info("fetching analysis pedigree start")
+	if err := a.analysisUseCase.Pedigree(ctx, &analysis_usecase.AnalysisInput{
+		Markers: input.Master.AnalysisMarkers,
+		Races:   input.Master.Races,
+	}); err != nil {
+		a.logger.Errorf("analysis pedigree error: %v", err)
+	}
+	a.logger.Info("fetching analysis pedigree end")
+}
+
+func (a *Analysis) Trainer(ctx context.Context, input *AnalysisInput) {
+	a.logger.Info("fetching analysis trainer start")
+	if err := a.analysisUseCase.Trainer(ctx, &analysis_usecase.AnalysisInput{
+		Races:    input.Master.Races,
+		Jockeys:  input.Master.Jockeys,
+		Trainers: input.Master.Trainers,
+	}); err != nil {
+		a.logger.Errorf("analysis trainer error: %v", err)
+	}
+	a.logger.Info("fetching analysis trainer end")
+}
+
+func (a *Analysis) MarkerTicket(ctx context.Context, input *AnalysisInput) {
+	a.logger.Info("fetching analysis marker ticket start")
+	if err := a.analysisUseCase.MarkerTicket(ctx, &analysis_usecase.AnalysisInput{
+		Markers: input.Master.AnalysisMarkers,
+		Tickets: input.Master.Tickets,
+	}); err != nil {
+		a.logger.Errorf("analysis marker ticket error: %v", err)
+	}
+	a.logger.Info("fetching analysis marker ticket end")
+}
+
+func (a *Analysis) PlacePaddock(ctx context.Context, input *AnalysisInput) {
+	a.logger.Info("fetching analysis place paddock start")
+	if err := a.analysisUseCase.PlacePaddock(ctx, &analysis_usecase.AnalysisInput{
+		Markers: input.Master.AnalysisMarkers,
+		Races:   input.Master.Races,
+	}); err != nil {
+		a.logger.Errorf("analysis place paddock error: %v", err)
+	}
+	a.logger.Info("fetching analysis place paddock end")
+}
+
+func (a *Analysis) CommentScore(ctx context.Context, input *AnalysisInput) {
+	a.logger.Info("fetching analysis comment score start")
+	if err := a.analysisUseCase.CommentScore(ctx, &analysis_usecase.AnalysisInput{
+		Markers: input.Master.AnalysisMarkers,
+		Races:   input.Master.Races,
+	}); err != nil {
+		a.logger.Errorf("analysis comment score error: %v", err)
+	}
+	a.logger.Info("fetching analysis comment score end")
+}
+
+func (a *Analysis) MarkerConsensus(ctx context.Context, input *AnalysisInput) {
+	a.logger.Info("fetching analysis marker consensus start")
+	if err := a.analysisUseCase.MarkerConsensus(ctx, &analysis_usecase.AnalysisInput{
+		Markers: input.Master.AnalysisMarkers,
+		Races:   input.Master.Races,
+	}); err != nil {
+		a.logger.Errorf("analysis marker consensus error: %v", err)
+	}
+	a.logger.Info("fetching analysis marker consensus end")
+}
+
+func (a *Analysis) TicketReprice(ctx context.Context, input *AnalysisInput) {
+	a.logger.Info("fetching analysis ticket reprice start")
+	if err := a.analysisUseCase.TicketReprice(ctx, &analysis_usecase.AnalysisInput{
+		Races:   input.Master.Races,
+		Tickets: input.Master.Tickets,
+	}); err != nil {
+		a.logger.Errorf("analysis ticket reprice error: %v", err)
+	}
+	a.logger.Info("fetching analysis ticket reprice end")
+}
+
 func (a *Analysis) Beta(ctx context.Context, input *AnalysisInput) {
 	a.logger.Info("fetching analysis beta start")
 	if err := a.analysisUseCase.Beta(ctx, &analysis_usecase.AnalysisInput{
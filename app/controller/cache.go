@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/usecase/cache_usecase"
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
+)
+
+type Cache struct {
+	cacheUseCase cache_usecase.Cache
+	logger       *logrus.Logger
+}
+
+type CacheVerifyOutput struct {
+	RemovedCount int
+}
+
+type CacheRefetchInput struct {
+	RaceDate types.RaceDate
+	RaceId   types.RaceId
+}
+
+func NewCache(
+	cacheUseCase cache_usecase.Cache,
+	logger *logrus.Logger,
+) *Cache {
+	return &Cache{
+		cacheUseCase: cacheUseCase,
+		logger:       logger,
+	}
+}
+
+func (c *Cache) Verify(ctx context.Context) (*CacheVerifyOutput, error) {
+	output, err := c.cacheUseCase.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Infof("cache verified: valid %d, checksum added %d, removed %d",
+		len(output.Valid), len(output.NoChecksum), len(output.Corrupt))
+
+	return &CacheVerifyOutput{
+		RemovedCount: len(output.Corrupt),
+	}, nil
+}
+
+func (c *Cache) Stats(ctx context.Context) error {
+	cacheStats, err := c.cacheUseCase.Stats(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		totalFiles int
+		totalSize  int64
+	)
+	for _, cacheStat := range cacheStats {
+		dateRange := "-"
+		if cacheStat.Entries() > 0 {
+			dateRange = fmt.Sprintf("%d-%d", cacheStat.StartDate(), cacheStat.EndDate())
+		}
+		c.logger.Infof("%-16s files %5d (corrupt %d), size %9s, races %6d, dates %s",
+			cacheStat.CacheType(), cacheStat.Files(), cacheStat.CorruptFiles(), formatSize(cacheStat.Size()), cacheStat.Entries(), dateRange)
+		totalFiles += cacheStat.Files()
+		totalSize += cacheStat.Size()
+	}
+	c.logger.Infof("%-16s files %5d, size %9s", "total", totalFiles, formatSize(totalSize))
+
+	return nil
+}
+
+// Show キャッシュ済みのレースをJSONで標準出力に書き出す
+func (c *Cache) Show(ctx context.Context, raceId types.RaceId) error {
+	cacheRace, err := c.cacheUseCase.Show(ctx, raceId)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cacheRace, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+
+	return err
+}
+
+func (c *Cache) Refetch(ctx context.Context, input *CacheRefetchInput) error {
+	refetchCount, err := c.cacheUseCase.Refetch(ctx, &cache_usecase.CacheRefetchInput{
+		RaceDate: input.RaceDate,
+		RaceId:   input.RaceId,
+	})
+	if err != nil {
+		return err
+	}
+	c.logger.Infof("cache refetched: races %d", refetchCount)
+
+	return nil
+}
+
+func (c *Cache) Prune(ctx context.Context, before types.RaceDate) error {
+	removedCount, err := c.cacheUseCase.Prune(ctx, before)
+	if err != nil {
+		return err
+	}
+	c.logger.Infof("cache pruned before %d: removed files %d", before, removedCount)
+
+	// マスタの取得期間内を消した場合は次のマスタ更新で取り直すことになる
+	raceStartDate, err := types.NewRaceDate(config.RaceStartDate)
+	if err != nil {
+		return err
+	}
+	if before > raceStartDate {
+		c.logger.Warnf("pruned dates from %d are refetched by the next master update, update config.RaceStartDate to keep them pruned", raceStartDate)
+	}
+
+	return nil
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(size)/(1<<10))
+	}
+
+	return fmt.Sprintf("%dB", size)
+}
@@ -0,0 +1,490 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/mapserver2007/ipat-aggregator/app/usecase/dashboard_usecase"
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
+)
+
+// 外部のCDNを使わずオフラインで表示できるようにテンプレートとJS、CSSはバイナリに埋め込む
+//
+//go:embed dashboard
+var dashboardFS embed.FS
+
+const (
+	dashboardChartWidth   = 960
+	dashboardChartHeight  = 320
+	dashboardChartPadding = 40
+	// チェック項目の該当数がこれ以上の行を強調する(スプレッドシートと同じ)
+	dashboardCheckListHighlightCount = 10
+)
+
+var dashboardTemplateFuncs = template.FuncMap{
+	"cellColor": func(colorType types.CellColorType) string {
+		switch colorType {
+		case types.FirstColor:
+			return "color-first"
+		case types.SecondColor:
+			return "color-second"
+		case types.ThirdColor:
+			return "color-third"
+		}
+		return ""
+	},
+	"join": strings.Join,
+	"inc": func(i int) int {
+		return i + 1
+	},
+}
+
+type dashboardPage struct {
+	Title   string
+	Path    string
+	Account string
+	Content any
+}
+
+type dashboardSummary struct {
+	Terms      []*dashboardTicketResult
+	Categories []*dashboardTicketResultTable
+	Periods    []*dashboardTicketResultTable
+	Charts     []*dashboardChart
+}
+
+type dashboardTicketResultTable struct {
+	Title string
+	Rows  []*dashboardTicketResult
+}
+
+type dashboardTicketResult struct {
+	Label string
+	*spreadsheet_entity.TicketResult
+}
+
+type dashboardChart struct {
+	Title          string
+	Width          int
+	Height         int
+	Left           int
+	Right          int
+	BaseY          float64
+	RateLine       string
+	CumulativeLine string
+	Points         []*dashboardChartPoint
+	YTicks         []*dashboardChartTick
+}
+
+type dashboardChartPoint struct {
+	X, Y, CumulativeY float64
+	Label             string
+	ShowLabel         bool
+	Rate              string
+	CumulativeRate    string
+}
+
+type dashboardChartTick struct {
+	Y     float64
+	Label string
+}
+
+type dashboardPlace struct {
+	OddsRanges []string
+	Tables     []*dashboardPlaceTable
+}
+
+type dashboardPlaceTable struct {
+	Marker string
+	Rows   []*dashboardPlaceRow
+}
+
+type dashboardPlaceRow struct {
+	Name      string
+	RaceCount int
+	Rates     []*dashboardPlaceRate
+}
+
+type dashboardPlaceRate struct {
+	HitRate    string
+	OddsRanges []*dashboardCell
+}
+
+type dashboardCell struct {
+	Value string
+	Color types.CellColorType
+}
+
+type dashboardCheckList struct {
+	UpdatedAt      string
+	CheckListNames []string
+	Rows           []*dashboardCheckListRow
+}
+
+type dashboardCheckListRow struct {
+	*raw_entity.PredictionCheckList
+	Checks      []string
+	CheckCount  int
+	Highlighted bool
+}
+
+// registerDashboard 集計、分析、予想チェックリストをHTMLで表示するページを登録する
+func (s *Server) registerDashboard(mux *http.ServeMux, input *ServerInput) error {
+	templates := map[string]*template.Template{}
+	for _, name := range []string{"summary", "list", "place", "check_list"} {
+		tmpl, err := template.New(name).Funcs(dashboardTemplateFuncs).ParseFS(dashboardFS,
+			"dashboard/templates/layout.html",
+			fmt.Sprintf("dashboard/templates/%s.html", name),
+		)
+		if err != nil {
+			return err
+		}
+		templates[name] = tmpl
+	}
+	staticFS, err := fs.Sub(dashboardFS, "dashboard/static")
+	if err != nil {
+		return err
+	}
+
+	mux.Handle("GET /dashboard/static/", http.StripPrefix("/dashboard/static/", http.FileServerFS(staticFS)))
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/dashboard", http.StatusFound)
+	})
+	mux.HandleFunc("GET /dashboard", s.handleDashboard(templates["summary"], "回収率", func(ctx context.Context, dashboardInput *dashboard_usecase.DashboardInput) (any, error) {
+		return s.createDashboardSummary(s.dashboardUseCase.Summary(ctx, dashboardInput)), nil
+	}, input))
+	mux.HandleFunc("GET /dashboard/list", s.handleDashboard(templates["list"], "購入レース", func(ctx context.Context, dashboardInput *dashboard_usecase.DashboardInput) (any, error) {
+		return s.dashboardUseCase.List(ctx, dashboardInput)
+	}, input))
+	mux.HandleFunc("GET /dashboard/analysis/place", s.handleDashboard(templates["place"], "印別着順率", func(ctx context.Context, dashboardInput *dashboard_usecase.DashboardInput) (any, error) {
+		dashboardPlace, err := s.dashboardUseCase.Place(ctx, dashboardInput)
+		if err != nil {
+			return nil, err
+		}
+		return s.createDashboardPlace(dashboardPlace), nil
+	}, input))
+	mux.HandleFunc("GET /dashboard/prediction/check-list", s.handleDashboard(templates["check_list"], "予想チェックリスト", func(ctx context.Context, dashboardInput *dashboard_usecase.DashboardInput) (any, error) {
+		predictionCheckListInfo, err := s.dashboardUseCase.CheckList(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return s.createDashboardCheckList(predictionCheckListInfo), nil
+	}, input))
+
+	return nil
+}
+
+func (s *Server) handleDashboard(
+	tmpl *template.Template,
+	title string,
+	f func(ctx context.Context, dashboardInput *dashboard_usecase.DashboardInput) (any, error),
+	input *ServerInput,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		account := types.NewAccount(r.URL.Query().Get("account"))
+		content, err := f(r.Context(), &dashboard_usecase.DashboardInput{
+			Markers: input.Master.AnalysisMarkers,
+			Races:   input.Master.Races,
+			Jockeys: input.Master.Jockeys,
+			Tickets: input.Master.Tickets,
+			Account: account,
+		})
+		if err != nil {
+			s.logger.Errorf("dashboard %s error: %v", r.URL.Path, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := tmpl.ExecuteTemplate(w, "layout", &dashboardPage{
+			Title:   title,
+			Path:    r.URL.Path,
+			Account: account.Value(),
+			Content: content,
+		}); err != nil {
+			s.logger.Errorf("failed to render dashboard: %v", err)
+			return
+		}
+		s.logger.WithFields(logrus.Fields{
+			config.LogFieldUrl:      r.URL.String(),
+			config.LogFieldDuration: time.Since(startTime).Milliseconds(),
+		}).Infof("dashboard %s", r.URL.Path)
+	}
+}
+
+func (s *Server) createDashboardSummary(summary *spreadsheet_entity.Summary) *dashboardSummary {
+	return &dashboardSummary{
+		Terms: []*dashboardTicketResult{
+			{Label: "全期間", TicketResult: summary.AllTermResult()},
+			{Label: "年間", TicketResult: summary.YearTermResult()},
+			{Label: "月間", TicketResult: summary.MonthTermResult()},
+			{Label: "週間", TicketResult: summary.WeekTermResult()},
+		},
+		Categories: []*dashboardTicketResultTable{
+			{Title: "券種別", Rows: newDashboardTicketResults(summary.TicketResultMap(), types.TicketType.Name)},
+			{Title: "クラス別", Rows: newDashboardTicketResults(summary.GradeClassResultMap(), types.GradeClass.String)},
+			{Title: "コース種別", Rows: newDashboardTicketResults(summary.CourseCategoryResultMap(), types.CourseCategory.String)},
+			{Title: "距離別", Rows: newDashboardTicketResults(summary.DistanceCategoryResultMap(), types.DistanceCategory.String)},
+			{Title: "開催場所別", Rows: newDashboardTicketResults(summary.RaceCourseResultMap(), types.RaceCourse.Name)},
+			{Title: "購入元別", Rows: newDashboardTicketResults(summary.TicketSourceResultMap(), types.TicketSource.Name)},
+			{Title: "アカウント別", Rows: newDashboardTicketResults(summary.AccountResultMap(), types.Account.Value)},
+		},
+		Periods: []*dashboardTicketResultTable{
+			{Title: "年別", Rows: newDashboardPeriodResults(summary.YearlyResults(), "2006")},
+			{Title: "月別", Rows: newDashboardPeriodResults(summary.MonthlyResults(), "2006/01")},
+			{Title: "週別", Rows: newDashboardPeriodResults(summary.WeeklyResults(), "2006/01/02")},
+		},
+		Charts: []*dashboardChart{
+			newDashboardChart("月別回収率", summary.MonthlyResults(), "2006/01"),
+			newDashboardChart("年別回収率", summary.YearlyResults(), "2006"),
+		},
+	}
+}
+
+func newDashboardTicketResults[T cmp.Ordered](
+	resultMap map[T]*spreadsheet_entity.TicketResult,
+	name func(T) string,
+) []*dashboardTicketResult {
+	keys := make([]T, 0, len(resultMap))
+	for key := range resultMap {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	rows := make([]*dashboardTicketResult, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, &dashboardTicketResult{
+			Label:        name(key),
+			TicketResult: resultMap[key],
+		})
+	}
+
+	return rows
+}
+
+// newDashboardPeriodResults 新しい期間を上にする
+func newDashboardPeriodResults(
+	resultMap map[time.Time]*spreadsheet_entity.TicketResult,
+	layout string,
+) []*dashboardTicketResult {
+	periods := sortedPeriods(resultMap)
+	slices.Reverse(periods)
+
+	rows := make([]*dashboardTicketResult, 0, len(periods))
+	for _, period := range periods {
+		rows = append(rows, &dashboardTicketResult{
+			Label:        period.Format(layout),
+			TicketResult: resultMap[period],
+		})
+	}
+
+	return rows
+}
+
+func sortedPeriods(resultMap map[time.Time]*spreadsheet_entity.TicketResult) []time.Time {
+	periods := make([]time.Time, 0, len(resultMap))
+	for period := range resultMap {
+		periods = append(periods, period)
+	}
+	slices.SortFunc(periods, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+
+	return periods
+}
+
+// newDashboardChart 期間ごとの回収率と、期間の始めからの累積回収率を折れ線にする
+func newDashboardChart(
+	title string,
+	resultMap map[time.Time]*spreadsheet_entity.TicketResult,
+	layout string,
+) *dashboardChart {
+	chart := &dashboardChart{
+		Title:  title,
+		Width:  dashboardChartWidth,
+		Height: dashboardChartHeight,
+		Left:   dashboardChartPadding,
+		Right:  dashboardChartWidth - dashboardChartPadding,
+	}
+	periods := sortedPeriods(resultMap)
+	if len(periods) == 0 {
+		return chart
+	}
+
+	rates := make([]float64, 0, len(periods))
+	cumulativeRates := make([]float64, 0, len(periods))
+	var totalPayment, totalPayout int
+	maxRate := 100.0
+	for _, period := range periods {
+		result := resultMap[period]
+		totalPayment += result.Payment()
+		totalPayout += result.Payout()
+		rate, cumulativeRate := payoutRate(result.Payout(), result.Payment()), payoutRate(totalPayout, totalPayment)
+		rates = append(rates, rate)
+		cumulativeRates = append(cumulativeRates, cumulativeRate)
+		maxRate = math.Max(maxRate, math.Max(rate, cumulativeRate))
+	}
+	// 目盛りは50%刻みにする
+	maxRate = math.Ceil(maxRate/50) * 50
+
+	plotWidth := float64(dashboardChartWidth - dashboardChartPadding*2)
+	plotHeight := float64(dashboardChartHeight - dashboardChartPadding*2)
+	y := func(rate float64) float64 {
+		return float64(dashboardChartPadding) + plotHeight*(1-rate/maxRate)
+	}
+	step := 0.0
+	if len(periods) > 1 {
+		step = plotWidth / float64(len(periods)-1)
+	}
+	// ラベルが重ならないように最大12個まで間引く
+	labelInterval := (len(periods) + 11) / 12
+
+	rateLine := make([]string, 0, len(periods))
+	cumulativeLine := make([]string, 0, len(periods))
+	for idx, period := range periods {
+		point := &dashboardChartPoint{
+			X:              float64(dashboardChartPadding) + step*float64(idx),
+			Y:              y(rates[idx]),
+			CumulativeY:    y(cumulativeRates[idx]),
+			Label:          period.Format(layout),
+			ShowLabel:      idx%labelInterval == 0,
+			Rate:           fmt.Sprintf("%.2f%%", rates[idx]),
+			CumulativeRate: fmt.Sprintf("%.2f%%", cumulativeRates[idx]),
+		}
+		chart.Points = append(chart.Points, point)
+		rateLine = append(rateLine, fmt.Sprintf("%.1f,%.1f", point.X, point.Y))
+		cumulativeLine = append(cumulativeLine, fmt.Sprintf("%.1f,%.1f", point.X, point.CumulativeY))
+	}
+	chart.RateLine = strings.Join(rateLine, " ")
+	chart.CumulativeLine = strings.Join(cumulativeLine, " ")
+	chart.BaseY = y(100)
+	for rate := 0.0; rate <= maxRate; rate += 50 {
+		chart.YTicks = append(chart.YTicks, &dashboardChartTick{
+			Y:     y(rate),
+			Label: fmt.Sprintf("%.0f%%", rate),
+		})
+	}
+
+	return chart
+}
+
+func payoutRate(payout, payment int) float64 {
+	if payment == 0 {
+		return 0
+	}
+	return float64(payout) * 100 / float64(payment)
+}
+
+func (s *Server) createDashboardPlace(input *dashboard_usecase.DashboardPlace) *dashboardPlace {
+	markers := []types.Marker{
+		types.Favorite, types.Rival, types.BrackTriangle, types.WhiteTriangle, types.Star, types.Check,
+	}
+	placeTables := make([]*dashboardPlaceTable, 0, len(markers))
+	for _, marker := range markers {
+		placeTable := &dashboardPlaceTable{
+			Marker: marker.String(),
+		}
+		for _, attributeFilter := range input.AttributeFilters {
+			firstPlace, ok := input.FirstPlaceMap[marker][attributeFilter]
+			if !ok {
+				continue
+			}
+			placeTable.Rows = append(placeTable.Rows, &dashboardPlaceRow{
+				Name:      dashboardFilterName(attributeFilter),
+				RaceCount: firstPlace.RateData().RaceCount(),
+				Rates: []*dashboardPlaceRate{
+					newDashboardPlaceRate(firstPlace),
+					newDashboardPlaceRate(input.SecondPlaceMap[marker][attributeFilter]),
+					newDashboardPlaceRate(input.ThirdPlaceMap[marker][attributeFilter]),
+				},
+			})
+		}
+		placeTables = append(placeTables, placeTable)
+	}
+
+	return &dashboardPlace{
+		OddsRanges: []string{
+			types.WinOddsRange1.String(), types.WinOddsRange2.String(), types.WinOddsRange3.String(),
+			types.WinOddsRange4.String(), types.WinOddsRange5.String(), types.WinOddsRange6.String(),
+			types.WinOddsRange7.String(), types.WinOddsRange8.String(), types.WinOddsRange9.String(),
+		},
+		Tables: placeTables,
+	}
+}
+
+func newDashboardPlaceRate(analysisPlace *spreadsheet_entity.AnalysisPlace) *dashboardPlaceRate {
+	rateData, rateStyle := analysisPlace.RateData(), analysisPlace.RateStyle()
+	return &dashboardPlaceRate{
+		HitRate: rateData.HitRateFormat(),
+		OddsRanges: []*dashboardCell{
+			{Value: rateData.OddsRange1RateFormat(), Color: rateStyle.OddsRange1CellColorType()},
+			{Value: rateData.OddsRange2RateFormat(), Color: rateStyle.OddsRange2CellColorType()},
+			{Value: rateData.OddsRange3RateFormat(), Color: rateStyle.OddsRange3CellColorType()},
+			{Value: rateData.OddsRange4RateFormat(), Color: rateStyle.OddsRange4CellColorType()},
+			{Value: rateData.OddsRange5RateFormat(), Color: rateStyle.OddsRange5CellColorType()},
+			{Value: rateData.OddsRange6RateFormat(), Color: rateStyle.OddsRange6CellColorType()},
+			{Value: rateData.OddsRange7RateFormat(), Color: rateStyle.OddsRange7CellColorType()},
+			{Value: rateData.OddsRange8RateFormat(), Color: rateStyle.OddsRange8CellColorType()},
+			{Value: rateData.OddsRange9RateFormat(), Color: rateStyle.OddsRange9CellColorType()},
+		},
+	}
+}
+
+// dashboardFilterName スプレッドシートと同じく元の属性名をつなげて表示する
+func dashboardFilterName(attributeFilter filter.AttributeId) string {
+	var filterName string
+	for _, f := range attributeFilter.OriginFilters() {
+		filterName += f.String()
+	}
+	return filterName
+}
+
+func (s *Server) createDashboardCheckList(predictionCheckListInfo *raw_entity.PredictionCheckListInfo) *dashboardCheckList {
+	if predictionCheckListInfo == nil {
+		return nil
+	}
+
+	dashboardCheckList := &dashboardCheckList{
+		UpdatedAt: predictionCheckListInfo.UpdatedAt,
+	}
+	// 全行同じルールで判定しているので先頭行の項目名を使う
+	if len(predictionCheckListInfo.PredictionCheckLists) > 0 {
+		dashboardCheckList.CheckListNames = predictionCheckListInfo.PredictionCheckLists[0].CheckListNames
+	}
+	for _, predictionCheckList := range predictionCheckListInfo.PredictionCheckLists {
+		checks := make([]string, 0, len(predictionCheckList.CheckList))
+		checkCount := 0
+		for idx, check := range predictionCheckList.CheckList {
+			if check == "◯" {
+				checkCount++
+			}
+			if idx < len(predictionCheckList.Contributions) && predictionCheckList.Contributions[idx] != "" {
+				check = fmt.Sprintf("%s %s", check, predictionCheckList.Contributions[idx])
+			}
+			checks = append(checks, check)
+		}
+		dashboardCheckList.Rows = append(dashboardCheckList.Rows, &dashboardCheckListRow{
+			PredictionCheckList: predictionCheckList,
+			Checks:              checks,
+			CheckCount:          checkCount,
+			Highlighted:         checkCount >= dashboardCheckListHighlightCount,
+		})
+	}
+
+	return dashboardCheckList
+}
@@ -0,0 +1,57 @@
+// 見出しをクリックすると列の値で並び替える。数値として読める列は数値順にする
+(function () {
+  "use strict";
+
+  function parseNumber(text) {
+    var value = text.replace(/[,%円\s]/g, "");
+    if (value === "" || value === "-") {
+      return null;
+    }
+    var number = Number(value);
+    return isNaN(number) ? null : number;
+  }
+
+  function compare(a, b) {
+    var numberA = parseNumber(a);
+    var numberB = parseNumber(b);
+    if (numberA !== null && numberB !== null) {
+      return numberA - numberB;
+    }
+    if (numberA !== null) {
+      return -1;
+    }
+    if (numberB !== null) {
+      return 1;
+    }
+    return a.localeCompare(b, "ja");
+  }
+
+  function sortTable(table, th, index) {
+    var tbody = table.tBodies[0];
+    var descending = th.classList.contains("asc");
+    var rows = Array.prototype.slice.call(tbody.rows);
+    rows.sort(function (rowA, rowB) {
+      var result = compare(rowA.cells[index].textContent.trim(), rowB.cells[index].textContent.trim());
+      return descending ? -result : result;
+    });
+    rows.forEach(function (row) {
+      tbody.appendChild(row);
+    });
+
+    Array.prototype.forEach.call(table.tHead.rows[0].cells, function (cell) {
+      cell.classList.remove("asc", "desc");
+    });
+    th.classList.add(descending ? "desc" : "asc");
+  }
+
+  document.querySelectorAll("table.sortable").forEach(function (table) {
+    if (!table.tHead || table.tBodies.length === 0) {
+      return;
+    }
+    Array.prototype.forEach.call(table.tHead.rows[0].cells, function (th, index) {
+      th.addEventListener("click", function () {
+        sortTable(table, th, index);
+      });
+    });
+  });
+})();
@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+)
+
+func newTestDashboardTicketResult(payment, payout int) *spreadsheet_entity.TicketResult {
+	return spreadsheet_entity.NewTicketResult(1, 1, 1, types.Payment(payment), types.Payout(payout), types.Payout(payout), types.Payout(payout), types.Payout(payout))
+}
+
+func TestPayoutRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payout  int
+		payment int
+		want    float64
+	}{
+		{
+			name:    "回収率",
+			payout:  1500,
+			payment: 1000,
+			want:    150,
+		},
+		{
+			name:    "購入なし",
+			payout:  0,
+			payment: 0,
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := payoutRate(tt.payout, tt.payment); got != tt.want {
+				t.Errorf("payoutRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDashboardPeriodResults(t *testing.T) {
+	resultMap := map[time.Time]*spreadsheet_entity.TicketResult{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local): newTestDashboardTicketResult(100, 0),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local): newTestDashboardTicketResult(100, 0),
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local): newTestDashboardTicketResult(100, 0),
+	}
+
+	got := make([]string, 0, len(resultMap))
+	for _, row := range newDashboardPeriodResults(resultMap, "2006/01") {
+		got = append(got, row.Label)
+	}
+	if want := []string{"2024/03", "2024/02", "2024/01"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("newDashboardPeriodResults() = %v, want %v", got, want)
+	}
+}
+
+func TestNewDashboardChart(t *testing.T) {
+	tests := []struct {
+		name               string
+		resultMap          map[time.Time]*spreadsheet_entity.TicketResult
+		wantRateLine       string
+		wantCumulativeLine string
+		wantBaseY          float64
+		wantRates          []string
+		wantCumulativeRate []string
+		wantYTicks         []string
+	}{
+		{
+			name:      "期間なし",
+			resultMap: map[time.Time]*spreadsheet_entity.TicketResult{},
+		},
+		{
+			name: "目盛りは最大の回収率を50%刻みで切り上げる",
+			resultMap: map[time.Time]*spreadsheet_entity.TicketResult{
+				time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local): newTestDashboardTicketResult(1000, 2500),
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local): newTestDashboardTicketResult(1000, 500),
+			},
+			wantRateLine:       "40.0,232.0 920.0,40.0",
+			wantCumulativeLine: "40.0,232.0 920.0,136.0",
+			wantBaseY:          184,
+			wantRates:          []string{"50.00%", "250.00%"},
+			wantCumulativeRate: []string{"50.00%", "150.00%"},
+			wantYTicks:         []string{"0%", "50%", "100%", "150%", "200%", "250%"},
+		},
+		{
+			name: "回収率が低くても100%までは表示する",
+			resultMap: map[time.Time]*spreadsheet_entity.TicketResult{
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local): newTestDashboardTicketResult(1000, 0),
+			},
+			wantRateLine:       "40.0,280.0",
+			wantCumulativeLine: "40.0,280.0",
+			wantBaseY:          40,
+			wantRates:          []string{"0.00%"},
+			wantCumulativeRate: []string{"0.00%"},
+			wantYTicks:         []string{"0%", "50%", "100%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart := newDashboardChart("月別", tt.resultMap, "2006/01")
+			if chart.Title != "月別" || chart.RateLine != tt.wantRateLine || chart.CumulativeLine != tt.wantCumulativeLine || chart.BaseY != tt.wantBaseY {
+				t.Errorf("newDashboardChart() = %+v, want rate line %q cumulative line %q base %v",
+					chart, tt.wantRateLine, tt.wantCumulativeLine, tt.wantBaseY)
+			}
+			var rates, cumulativeRates, yTicks []string
+			for _, point := range chart.Points {
+				rates = append(rates, point.Rate)
+				cumulativeRates = append(cumulativeRates, point.CumulativeRate)
+			}
+			for _, tick := range chart.YTicks {
+				yTicks = append(yTicks, tick.Label)
+			}
+			if !reflect.DeepEqual(rates, tt.wantRates) || !reflect.DeepEqual(cumulativeRates, tt.wantCumulativeRate) {
+				t.Errorf("rates = %v, %v, want %v, %v", rates, cumulativeRates, tt.wantRates, tt.wantCumulativeRate)
+			}
+			if !reflect.DeepEqual(yTicks, tt.wantYTicks) {
+				t.Errorf("YTicks = %v, want %v", yTicks, tt.wantYTicks)
+			}
+		})
+	}
+}
+
+func TestDashboardFilterName(t *testing.T) {
+	tests := []struct {
+		name            string
+		attributeFilter filter.AttributeId
+		want            string
+	}{
+		{
+			name:            "全レース",
+			attributeFilter: filter.All,
+			want:            "全レース",
+		},
+		{
+			name:            "属性名をつなげる",
+			attributeFilter: filter.Turf | filter.Tokyo,
+			want:            "芝東京",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dashboardFilterName(tt.attributeFilter); got != tt.want {
+				t.Errorf("dashboardFilterName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerCreateDashboardCheckList(t *testing.T) {
+	allChecked := make([]string, dashboardCheckListHighlightCount)
+	for idx := range allChecked {
+		allChecked[idx] = "◯"
+	}
+
+	tests := []struct {
+		name                 string
+		input                *raw_entity.PredictionCheckListInfo
+		wantNil              bool
+		wantCheckListNames   []string
+		wantChecks           [][]string
+		wantCheckCounts      []int
+		wantHighlightedCount int
+	}{
+		{
+			name:    "チェックリスト未作成",
+			input:   nil,
+			wantNil: true,
+		},
+		{
+			name: "寄与度を付けて◯の数を数える",
+			input: &raw_entity.PredictionCheckListInfo{
+				UpdatedAt: "2024-10-20 09:00:00",
+				PredictionCheckLists: []*raw_entity.PredictionCheckList{
+					{
+						CheckList:      []string{"◯", "×", "◯"},
+						CheckListNames: []string{"a", "b", "c"},
+						Contributions:  []string{"+1.2", "", "+0.5"},
+					},
+					{
+						CheckList: allChecked,
+					},
+				},
+			},
+			wantCheckListNames:   []string{"a", "b", "c"},
+			wantChecks:           [][]string{{"◯ +1.2", "×", "◯ +0.5"}, allChecked},
+			wantCheckCounts:      []int{2, dashboardCheckListHighlightCount},
+			wantHighlightedCount: 1,
+		},
+	}
+
+	s := &Server{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.createDashboardCheckList(tt.input)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("createDashboardCheckList() = %+v, want nil", got)
+				}
+				return
+			}
+			if got.UpdatedAt != tt.input.UpdatedAt || !reflect.DeepEqual(got.CheckListNames, tt.wantCheckListNames) {
+				t.Errorf("createDashboardCheckList() = %+v", got)
+			}
+			var checks [][]string
+			var checkCounts []int
+			highlightedCount := 0
+			for _, row := range got.Rows {
+				checks = append(checks, row.Checks)
+				checkCounts = append(checkCounts, row.CheckCount)
+				if row.Highlighted {
+					highlightedCount++
+				}
+			}
+			if !reflect.DeepEqual(checks, tt.wantChecks) || !reflect.DeepEqual(checkCounts, tt.wantCheckCounts) {
+				t.Errorf("rows = %v, %v, want %v, %v", checks, checkCounts, tt.wantChecks, tt.wantCheckCounts)
+			}
+			if highlightedCount != tt.wantHighlightedCount {
+				t.Errorf("highlighted rows = %d, want %d", highlightedCount, tt.wantHighlightedCount)
+			}
+		})
+	}
+}
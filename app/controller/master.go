@@ -20,6 +20,7 @@ type MasterOutput struct {
 	Races             []*data_cache_entity.Race
 	RaceTimes         []*data_cache_entity.RaceTime
 	Jockeys           []*data_cache_entity.Jockey
+	Trainers          []*data_cache_entity.Trainer
 	WinOdds           []*data_cache_entity.Odds
 	PlaceOdds         []*data_cache_entity.Odds
 	TrioOdds          []*data_cache_entity.Odds
@@ -60,6 +61,7 @@ func (m *Master) Execute(
 		Races:             output.Races,
 		RaceTimes:         output.RaceTimes,
 		Jockeys:           output.Jockeys,
+		Trainers:          output.Trainers,
 		WinOdds:           output.WinOdds,
 		PlaceOdds:         output.PlaceOdds,
 		TrioOdds:          output.TrioOdds,
@@ -2,6 +2,8 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"sync"
 
 	"github.com/mapserver2007/ipat-aggregator/app/usecase/prediction_usecase"
@@ -14,7 +16,14 @@ type Prediction struct {
 }
 
 type PredictionInput struct {
-	Master *MasterOutput
+	Master   *MasterOutput
+	Bankroll int
+}
+
+type BetSlipInput struct {
+	Master       *MasterOutput
+	PlanFileName string
+	DryRun       bool
 }
 
 func NewPrediction(
@@ -44,6 +53,12 @@ func (p *Prediction) Prediction(ctx context.Context, input *PredictionInput) {
 					PredictionMarkers: input.Master.PredictionMarkers,
 					Races:             input.Master.Races,
 					RaceTimes:         input.Master.RaceTimes,
+					Odds: &prediction_usecase.PredictionOddsInput{
+						Win:      input.Master.WinOdds,
+						Trio:     input.Master.TrioOdds,
+						Quinella: input.Master.QuinellaOdds,
+					},
+					Bankroll: input.Bankroll,
 				}); err != nil {
 					errors <- err
 				}
@@ -76,3 +91,19 @@ func (p *Prediction) SyncMarker(ctx context.Context) {
 	}
 	p.logger.Info("fetching prediction marker sync end")
 }
+
+func (p *Prediction) BetSlip(ctx context.Context, input *BetSlipInput) {
+	p.logger.Info("creating bet slip start")
+	slip, err := p.predictionUseCase.BetSlip(ctx, &prediction_usecase.BetSlipInput{
+		PredictionMarkers: input.Master.PredictionMarkers,
+		PlanFileName:      input.PlanFileName,
+		DryRun:            input.DryRun,
+	})
+	if slip != "" {
+		fmt.Fprint(os.Stdout, slip)
+	}
+	if err != nil {
+		p.logger.Errorf("bet slip error: %v", err)
+	}
+	p.logger.Info("creating bet slip end")
+}
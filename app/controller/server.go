@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/api_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/mapserver2007/ipat-aggregator/app/usecase/api_usecase"
+	"github.com/mapserver2007/ipat-aggregator/app/usecase/dashboard_usecase"
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+type Server struct {
+	apiUseCase       api_usecase.Api
+	dashboardUseCase dashboard_usecase.Dashboard
+	logger           *logrus.Logger
+}
+
+type ServerInput struct {
+	Master *MasterOutput
+	Addr   string
+}
+
+func NewServer(
+	apiUseCase api_usecase.Api,
+	dashboardUseCase dashboard_usecase.Dashboard,
+	logger *logrus.Logger,
+) *Server {
+	return &Server{
+		apiUseCase:       apiUseCase,
+		dashboardUseCase: dashboardUseCase,
+		logger:           logger,
+	}
+}
+
+// Serve マスタデータと分析結果をJSONで返すAPIとHTMLのダッシュボードをctxがキャンセルされるまで動かす
+func (s *Server) Serve(ctx context.Context, input *ServerInput) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/attributes", func(w http.ResponseWriter, r *http.Request) {
+		s.writeJson(w, http.StatusOK, s.apiUseCase.Attributes(r.Context()))
+	})
+	mux.HandleFunc("GET /api/races", s.handle(input, func(ctx context.Context, apiInput *api_usecase.ApiInput) (any, error) {
+		return s.apiUseCase.Races(ctx, apiInput), nil
+	}))
+	mux.HandleFunc("GET /api/odds", s.handle(input, func(ctx context.Context, apiInput *api_usecase.ApiInput) (any, error) {
+		return s.apiUseCase.Odds(ctx, apiInput), nil
+	}))
+	mux.HandleFunc("GET /api/analysis/place", s.handle(input, func(ctx context.Context, apiInput *api_usecase.ApiInput) (any, error) {
+		return s.apiUseCase.Place(ctx, apiInput)
+	}))
+	mux.HandleFunc("GET /api/analysis/place-all-in", s.handle(input, func(ctx context.Context, apiInput *api_usecase.ApiInput) (any, error) {
+		return s.apiUseCase.PlaceAllIn(ctx, apiInput)
+	}))
+	mux.HandleFunc("GET /api/analysis/race-time", s.handle(input, func(ctx context.Context, apiInput *api_usecase.ApiInput) (any, error) {
+		return s.apiUseCase.RaceTime(ctx, apiInput)
+	}))
+	mux.HandleFunc("GET /api/summary", s.handle(input, func(ctx context.Context, apiInput *api_usecase.ApiInput) (any, error) {
+		return s.apiUseCase.Summary(ctx, apiInput), nil
+	}))
+	if err := s.registerDashboard(mux, input); err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:    input.Addr,
+		Handler: mux,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Infof("api server listening on %s, dashboard: http://%s/dashboard", input.Addr, input.Addr)
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		s.logger.Infof("api server shutting down: %v", ctx.Err())
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+func (s *Server) handle(
+	input *ServerInput,
+	f func(ctx context.Context, apiInput *api_usecase.ApiInput) (any, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		apiFilter, err := s.createFilter(r)
+		if err != nil {
+			s.writeJson(w, http.StatusBadRequest, &api_entity.Error{Error: err.Error()})
+			return
+		}
+
+		response, err := f(r.Context(), &api_usecase.ApiInput{
+			Markers:   input.Master.AnalysisMarkers,
+			Races:     input.Master.Races,
+			RaceTimes: input.Master.RaceTimes,
+			Odds: &api_usecase.ApiOddsInput{
+				Win:      input.Master.WinOdds,
+				Place:    input.Master.PlaceOdds,
+				Trio:     input.Master.TrioOdds,
+				Quinella: input.Master.QuinellaOdds,
+			},
+			Tickets: input.Master.Tickets,
+			Filter:  apiFilter,
+		})
+		if err != nil {
+			s.logger.Errorf("api %s error: %v", r.URL.Path, err)
+			s.writeJson(w, http.StatusInternalServerError, &api_entity.Error{Error: err.Error()})
+			return
+		}
+
+		s.writeJson(w, http.StatusOK, response)
+		s.logger.WithFields(logrus.Fields{
+			config.LogFieldUrl:      r.URL.String(),
+			config.LogFieldDuration: time.Since(startTime).Milliseconds(),
+		}).Infof("api %s", r.URL.Path)
+	}
+}
+
+// createFilter from、toは開催日(yyyymmdd)、race_courseは開催場所のIDか名前、attributeは属性の値か名前で絞り込む
+func (s *Server) createFilter(r *http.Request) (*api_usecase.ApiFilter, error) {
+	query := r.URL.Query()
+	apiFilter := &api_usecase.ApiFilter{
+		Account: types.NewAccount(query.Get("account")),
+	}
+
+	if from := query.Get("from"); from != "" {
+		startDate, err := types.NewRaceDate(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from: %s", from)
+		}
+		apiFilter.StartDate = startDate
+	}
+	if to := query.Get("to"); to != "" {
+		endDate, err := types.NewRaceDate(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to: %s", to)
+		}
+		apiFilter.EndDate = endDate
+	}
+	if raceCourseParam := query.Get("race_course"); raceCourseParam != "" {
+		raceCourse := types.RaceCourse(raceCourseParam)
+		if raceCourse.Name() == "" {
+			raceCourse = types.NewRaceCourse(raceCourseParam)
+		}
+		if raceCourse == types.UnknownPlace {
+			return nil, fmt.Errorf("invalid race_course: %s", raceCourseParam)
+		}
+		apiFilter.RaceCourse = raceCourse
+	}
+	if attribute := query.Get("attribute"); attribute != "" {
+		attributeId, err := filter.NewAttributeId(attribute)
+		if err != nil {
+			return nil, err
+		}
+		apiFilter.AttributeId = attributeId
+	}
+
+	return apiFilter, nil
+}
+
+func (s *Server) writeJson(w http.ResponseWriter, status int, response any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.logger.Errorf("failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/mapserver2007/ipat-aggregator/app/usecase/api_usecase"
+)
+
+func TestServerCreateFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    *api_usecase.ApiFilter
+		wantErr bool
+	}{
+		{
+			name:  "指定なし",
+			query: "",
+			want:  &api_usecase.ApiFilter{},
+		},
+		{
+			name:  "期間と口座",
+			query: "from=20240101&to=20241231&account=main",
+			want: &api_usecase.ApiFilter{
+				StartDate: 20240101,
+				EndDate:   20241231,
+				Account:   types.NewAccount("main"),
+			},
+		},
+		{
+			name:  "開催場所はIDで指定",
+			query: "race_course=05",
+			want:  &api_usecase.ApiFilter{RaceCourse: types.Tokyo},
+		},
+		{
+			name:  "開催場所は名前でも指定できる",
+			query: "race_course=東京",
+			want:  &api_usecase.ApiFilter{RaceCourse: types.Tokyo},
+		},
+		{
+			name:  "属性",
+			query: "attribute=芝,東京",
+			want:  &api_usecase.ApiFilter{AttributeId: filter.Turf | filter.Tokyo},
+		},
+		{
+			name:    "不正な開始日",
+			query:   "from=2024-01-01",
+			wantErr: true,
+		},
+		{
+			name:    "不正な終了日",
+			query:   "to=abc",
+			wantErr: true,
+		},
+		{
+			name:    "不明な開催場所",
+			query:   "race_course=月面",
+			wantErr: true,
+		},
+		{
+			name:    "不明な属性",
+			query:   "attribute=月面",
+			wantErr: true,
+		},
+	}
+
+	s := &Server{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/summary?"+tt.query, nil)
+			got, err := s.createFilter(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("createFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("createFilter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package analysis_entity
+
+type CommentLexicon struct {
+	keywords              []*CommentKeyword
+	negations             []string
+	negationWindow        int
+	previousCommentWeight float64
+}
+
+func NewCommentLexicon(
+	keywords []*CommentKeyword,
+	negations []string,
+	negationWindow int,
+	previousCommentWeight float64,
+) *CommentLexicon {
+	return &CommentLexicon{
+		keywords:              keywords,
+		negations:             negations,
+		negationWindow:        negationWindow,
+		previousCommentWeight: previousCommentWeight,
+	}
+}
+
+// Keywords 辞書のキーワード、辞書ファイルの定義順
+func (c *CommentLexicon) Keywords() []*CommentKeyword {
+	return c.keywords
+}
+
+// Negations キーワードの直後にあると点数の符号を反転させる否定語
+func (c *CommentLexicon) Negations() []string {
+	return c.negations
+}
+
+// NegationWindow キーワードの直後何文字までを否定語の検索対象にするか
+func (c *CommentLexicon) NegationWindow() int {
+	return c.negationWindow
+}
+
+// PreviousCommentWeight 前走時の調教コメントの点数に掛ける重み
+func (c *CommentLexicon) PreviousCommentWeight() float64 {
+	return c.previousCommentWeight
+}
+
+type CommentKeyword struct {
+	word  string
+	point float64
+}
+
+func NewCommentKeyword(
+	word string,
+	point float64,
+) *CommentKeyword {
+	return &CommentKeyword{
+		word:  word,
+		point: point,
+	}
+}
+
+func (c *CommentKeyword) Word() string {
+	return c.word
+}
+
+func (c *CommentKeyword) Point() float64 {
+	return c.point
+}
@@ -0,0 +1,34 @@
+package analysis_entity
+
+type CommentScore struct {
+	score      float64
+	keywords   []string
+	hasComment bool
+}
+
+func NewCommentScore(
+	score float64,
+	keywords []string,
+	hasComment bool,
+) *CommentScore {
+	return &CommentScore{
+		score:      score,
+		keywords:   keywords,
+		hasComment: hasComment,
+	}
+}
+
+// Score 調教コメントと前走時の調教コメントの点数の合計、プラスほど好評価
+func (c *CommentScore) Score() float64 {
+	return c.score
+}
+
+// Keywords 点数に使ったキーワード、否定語で符号を反転したものは末尾に(否定)を付ける
+func (c *CommentScore) Keywords() []string {
+	return c.keywords
+}
+
+// HasComment コメントがどちらも空の場合はスコアを評価できない
+func (c *CommentScore) HasComment() bool {
+	return c.hasComment
+}
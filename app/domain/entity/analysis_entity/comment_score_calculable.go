@@ -0,0 +1,54 @@
+package analysis_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+type CommentScoreCalculable struct {
+	raceId       types.RaceId
+	raceDate     types.RaceDate
+	horseNumber  types.HorseNumber
+	marker       types.Marker
+	orderNo      int
+	commentScore *CommentScore
+}
+
+func NewCommentScoreCalculable(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	horseNumber types.HorseNumber,
+	marker types.Marker,
+	orderNo int,
+	commentScore *CommentScore,
+) *CommentScoreCalculable {
+	return &CommentScoreCalculable{
+		raceId:       raceId,
+		raceDate:     raceDate,
+		horseNumber:  horseNumber,
+		marker:       marker,
+		orderNo:      orderNo,
+		commentScore: commentScore,
+	}
+}
+
+func (c *CommentScoreCalculable) RaceId() types.RaceId {
+	return c.raceId
+}
+
+func (c *CommentScoreCalculable) RaceDate() types.RaceDate {
+	return c.raceDate
+}
+
+func (c *CommentScoreCalculable) HorseNumber() types.HorseNumber {
+	return c.horseNumber
+}
+
+func (c *CommentScoreCalculable) Marker() types.Marker {
+	return c.marker
+}
+
+func (c *CommentScoreCalculable) OrderNo() int {
+	return c.orderNo
+}
+
+func (c *CommentScoreCalculable) CommentScore() *CommentScore {
+	return c.commentScore
+}
@@ -0,0 +1,89 @@
+package analysis_entity
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/shopspring/decimal"
+)
+
+type MarkerConsensusCalculable struct {
+	raceId      types.RaceId
+	raceDate    types.RaceDate
+	horseNumber types.HorseNumber
+	consensus   types.MarkerConsensus
+	favoriteNum int
+	markerNum   int
+	orderNo     int
+	odds        decimal.Decimal
+	placeOdds   decimal.Decimal
+	filters     []filter.AttributeId
+}
+
+func NewMarkerConsensusCalculable(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	horseNumber types.HorseNumber,
+	consensus types.MarkerConsensus,
+	favoriteNum int,
+	markerNum int,
+	orderNo int,
+	odds decimal.Decimal,
+	placeOdds decimal.Decimal,
+	filters []filter.AttributeId,
+) *MarkerConsensusCalculable {
+	return &MarkerConsensusCalculable{
+		raceId:      raceId,
+		raceDate:    raceDate,
+		horseNumber: horseNumber,
+		consensus:   consensus,
+		favoriteNum: favoriteNum,
+		markerNum:   markerNum,
+		orderNo:     orderNo,
+		odds:        odds,
+		placeOdds:   placeOdds,
+		filters:     filters,
+	}
+}
+
+func (m *MarkerConsensusCalculable) RaceId() types.RaceId {
+	return m.raceId
+}
+
+func (m *MarkerConsensusCalculable) RaceDate() types.RaceDate {
+	return m.raceDate
+}
+
+// HorseNumber 自分が◎を付けた馬の馬番
+func (m *MarkerConsensusCalculable) HorseNumber() types.HorseNumber {
+	return m.horseNumber
+}
+
+func (m *MarkerConsensusCalculable) Consensus() types.MarkerConsensus {
+	return m.consensus
+}
+
+// FavoriteNum 自分の◎に◎を付けた東スポ予想者の数
+func (m *MarkerConsensusCalculable) FavoriteNum() int {
+	return m.favoriteNum
+}
+
+// MarkerNum 自分の◎に何かしらの印を付けた東スポ予想者の数
+func (m *MarkerConsensusCalculable) MarkerNum() int {
+	return m.markerNum
+}
+
+func (m *MarkerConsensusCalculable) OrderNo() int {
+	return m.orderNo
+}
+
+func (m *MarkerConsensusCalculable) Odds() decimal.Decimal {
+	return m.odds
+}
+
+func (m *MarkerConsensusCalculable) PlaceOdds() decimal.Decimal {
+	return m.placeOdds
+}
+
+func (m *MarkerConsensusCalculable) Filters() []filter.AttributeId {
+	return m.filters
+}
@@ -0,0 +1,68 @@
+package analysis_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+type MarkerTicket struct {
+	raceId              types.RaceId
+	raceDate            types.RaceDate
+	ticketType          types.TicketType
+	markerCombinationId types.MarkerCombinationId
+	markers             []types.Marker
+	ticketResult        types.TicketResult
+	payment             types.Payment
+	payout              types.Payout
+}
+
+func NewMarkerTicket(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	ticketType types.TicketType,
+	markerCombinationId types.MarkerCombinationId,
+	markers []types.Marker,
+	ticketResult types.TicketResult,
+	payment types.Payment,
+	payout types.Payout,
+) *MarkerTicket {
+	return &MarkerTicket{
+		raceId:              raceId,
+		raceDate:            raceDate,
+		ticketType:          ticketType,
+		markerCombinationId: markerCombinationId,
+		markers:             markers,
+		ticketResult:        ticketResult,
+		payment:             payment,
+		payout:              payout,
+	}
+}
+
+func (m *MarkerTicket) RaceId() types.RaceId {
+	return m.raceId
+}
+
+func (m *MarkerTicket) RaceDate() types.RaceDate {
+	return m.raceDate
+}
+
+func (m *MarkerTicket) TicketType() types.TicketType {
+	return m.ticketType
+}
+
+func (m *MarkerTicket) MarkerCombinationId() types.MarkerCombinationId {
+	return m.markerCombinationId
+}
+
+func (m *MarkerTicket) Markers() []types.Marker {
+	return m.markers
+}
+
+func (m *MarkerTicket) TicketResult() types.TicketResult {
+	return m.ticketResult
+}
+
+func (m *MarkerTicket) Payment() types.Payment {
+	return m.payment
+}
+
+func (m *MarkerTicket) Payout() types.Payout {
+	return m.payout
+}
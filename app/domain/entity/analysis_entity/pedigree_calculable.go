@@ -0,0 +1,100 @@
+package analysis_entity
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/shopspring/decimal"
+)
+
+type PedigreeCalculable struct {
+	raceId            types.RaceId
+	raceDate          types.RaceDate
+	horseId           types.HorseId
+	sireId            types.HorseId
+	sireName          string
+	broodmareSireId   types.HorseId
+	broodmareSireName string
+	orderNo           int
+	odds              decimal.Decimal
+	placeOdds         decimal.Decimal
+	isFirstDirt       bool
+	filters           []filter.AttributeId
+}
+
+func NewPedigreeCalculable(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	horseId types.HorseId,
+	sireId types.HorseId,
+	sireName string,
+	broodmareSireId types.HorseId,
+	broodmareSireName string,
+	orderNo int,
+	odds decimal.Decimal,
+	placeOdds decimal.Decimal,
+	isFirstDirt bool,
+	filters []filter.AttributeId,
+) *PedigreeCalculable {
+	return &PedigreeCalculable{
+		raceId:            raceId,
+		raceDate:          raceDate,
+		horseId:           horseId,
+		sireId:            sireId,
+		sireName:          sireName,
+		broodmareSireId:   broodmareSireId,
+		broodmareSireName: broodmareSireName,
+		orderNo:           orderNo,
+		odds:              odds,
+		placeOdds:         placeOdds,
+		isFirstDirt:       isFirstDirt,
+		filters:           filters,
+	}
+}
+
+func (p *PedigreeCalculable) RaceId() types.RaceId {
+	return p.raceId
+}
+
+func (p *PedigreeCalculable) RaceDate() types.RaceDate {
+	return p.raceDate
+}
+
+func (p *PedigreeCalculable) HorseId() types.HorseId {
+	return p.horseId
+}
+
+func (p *PedigreeCalculable) SireId() types.HorseId {
+	return p.sireId
+}
+
+func (p *PedigreeCalculable) SireName() string {
+	return p.sireName
+}
+
+func (p *PedigreeCalculable) BroodmareSireId() types.HorseId {
+	return p.broodmareSireId
+}
+
+func (p *PedigreeCalculable) BroodmareSireName() string {
+	return p.broodmareSireName
+}
+
+func (p *PedigreeCalculable) OrderNo() int {
+	return p.orderNo
+}
+
+func (p *PedigreeCalculable) Odds() decimal.Decimal {
+	return p.odds
+}
+
+func (p *PedigreeCalculable) PlaceOdds() decimal.Decimal {
+	return p.placeOdds
+}
+
+func (p *PedigreeCalculable) IsFirstDirt() bool {
+	return p.isFirstDirt
+}
+
+func (p *PedigreeCalculable) Filters() []filter.AttributeId {
+	return p.filters
+}
@@ -17,6 +17,7 @@ type PlaceCalculable struct {
 	orderNo             int
 	entries             int
 	jockeyId            types.JockeyId
+	runningStyle        types.RunningStyle
 	filters             []filter.AttributeId
 }
 
@@ -30,6 +31,7 @@ func NewPlaceCalculable(
 	orderNo int,
 	entries int,
 	jockeyId types.JockeyId,
+	runningStyle types.RunningStyle,
 	filters []filter.AttributeId,
 ) *PlaceCalculable {
 	marker, _ := types.NewMarker(markerCombinationId.Value() % 10)
@@ -44,6 +46,7 @@ func NewPlaceCalculable(
 		orderNo:             orderNo,
 		entries:             entries,
 		jockeyId:            jockeyId,
+		runningStyle:        runningStyle,
 		filters:             filters,
 	}
 }
@@ -88,6 +91,10 @@ func (n *PlaceCalculable) JockeyId() types.JockeyId {
 	return n.jockeyId
 }
 
+func (n *PlaceCalculable) RunningStyle() types.RunningStyle {
+	return n.runningStyle
+}
+
 func (n *PlaceCalculable) Filters() []filter.AttributeId {
 	return n.filters
 }
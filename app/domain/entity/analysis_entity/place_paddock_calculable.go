@@ -0,0 +1,61 @@
+package analysis_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+type PlacePaddockCalculable struct {
+	raceId            types.RaceId
+	raceDate          types.RaceDate
+	horseNumber       types.HorseNumber
+	marker            types.Marker
+	orderNo           int
+	paddockEvaluation types.PaddockEvaluation
+	hasReporterMemo   bool
+}
+
+func NewPlacePaddockCalculable(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	horseNumber types.HorseNumber,
+	marker types.Marker,
+	orderNo int,
+	paddockEvaluation types.PaddockEvaluation,
+	hasReporterMemo bool,
+) *PlacePaddockCalculable {
+	return &PlacePaddockCalculable{
+		raceId:            raceId,
+		raceDate:          raceDate,
+		horseNumber:       horseNumber,
+		marker:            marker,
+		orderNo:           orderNo,
+		paddockEvaluation: paddockEvaluation,
+		hasReporterMemo:   hasReporterMemo,
+	}
+}
+
+func (p *PlacePaddockCalculable) RaceId() types.RaceId {
+	return p.raceId
+}
+
+func (p *PlacePaddockCalculable) RaceDate() types.RaceDate {
+	return p.raceDate
+}
+
+func (p *PlacePaddockCalculable) HorseNumber() types.HorseNumber {
+	return p.horseNumber
+}
+
+func (p *PlacePaddockCalculable) Marker() types.Marker {
+	return p.marker
+}
+
+func (p *PlacePaddockCalculable) OrderNo() int {
+	return p.orderNo
+}
+
+func (p *PlacePaddockCalculable) PaddockEvaluation() types.PaddockEvaluation {
+	return p.paddockEvaluation
+}
+
+func (p *PlacePaddockCalculable) HasReporterMemo() bool {
+	return p.hasReporterMemo
+}
@@ -0,0 +1,60 @@
+package analysis_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+type PlaceRule struct {
+	name   string
+	kind   types.PlaceRuleKind
+	params map[string]float64
+	values []string
+	negate bool
+	point  int
+}
+
+func NewPlaceRule(
+	name string,
+	rawKind string,
+	params map[string]float64,
+	values []string,
+	negate bool,
+	point int,
+) (*PlaceRule, error) {
+	kind, err := types.NewPlaceRuleKind(rawKind, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaceRule{
+		name:   name,
+		kind:   kind,
+		params: params,
+		values: values,
+		negate: negate,
+		point:  point,
+	}, nil
+}
+
+func (p *PlaceRule) Name() string {
+	return p.name
+}
+
+func (p *PlaceRule) Kind() types.PlaceRuleKind {
+	return p.kind
+}
+
+func (p *PlaceRule) Param(key string) float64 {
+	return p.params[key]
+}
+
+func (p *PlaceRule) Values() []string {
+	return p.values
+}
+
+// Negate 判定結果を反転する(「〜でないこと」を表す)
+func (p *PlaceRule) Negate() bool {
+	return p.negate
+}
+
+func (p *PlaceRule) Point() int {
+	return p.point
+}
@@ -0,0 +1,244 @@
+package analysis_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+// PlaceRuleFact ルール判定に使うレース・馬の情報
+// 予想(prediction_entity)と分析(analysis_entity)のどちらからでも同じルールで判定できるようにまとめる
+type PlaceRuleFact struct {
+	raceId                   types.RaceId
+	raceDate                 types.RaceDate
+	entries                  int
+	distance                 int
+	class                    types.GradeClass
+	courseCategory           types.CourseCategory
+	trackCondition           types.TrackCondition
+	raceCourse               types.RaceCourse
+	winOdds                  float64
+	jockeyId                 types.JockeyId
+	raceWeight               float64
+	horseResults             []*PlaceRuleHorseResult
+	forecast                 *PlaceRuleForecast
+	trainerPerformance       *TrainerPerformance
+	jockeyTrainerPerformance *TrainerPerformance
+}
+
+func NewPlaceRuleFact(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	entries int,
+	distance int,
+	class types.GradeClass,
+	courseCategory types.CourseCategory,
+	trackCondition types.TrackCondition,
+	raceCourse types.RaceCourse,
+	winOdds float64,
+	jockeyId types.JockeyId,
+	raceWeight float64,
+	horseResults []*PlaceRuleHorseResult,
+	forecast *PlaceRuleForecast,
+	trainerPerformance *TrainerPerformance,
+	jockeyTrainerPerformance *TrainerPerformance,
+) *PlaceRuleFact {
+	return &PlaceRuleFact{
+		raceId:                   raceId,
+		raceDate:                 raceDate,
+		entries:                  entries,
+		distance:                 distance,
+		class:                    class,
+		courseCategory:           courseCategory,
+		trackCondition:           trackCondition,
+		raceCourse:               raceCourse,
+		winOdds:                  winOdds,
+		jockeyId:                 jockeyId,
+		raceWeight:               raceWeight,
+		horseResults:             horseResults,
+		forecast:                 forecast,
+		trainerPerformance:       trainerPerformance,
+		jockeyTrainerPerformance: jockeyTrainerPerformance,
+	}
+}
+
+func (p *PlaceRuleFact) RaceId() types.RaceId {
+	return p.raceId
+}
+
+func (p *PlaceRuleFact) RaceDate() types.RaceDate {
+	return p.raceDate
+}
+
+func (p *PlaceRuleFact) Entries() int {
+	return p.entries
+}
+
+func (p *PlaceRuleFact) Distance() int {
+	return p.distance
+}
+
+func (p *PlaceRuleFact) Class() types.GradeClass {
+	return p.class
+}
+
+func (p *PlaceRuleFact) CourseCategory() types.CourseCategory {
+	return p.courseCategory
+}
+
+func (p *PlaceRuleFact) TrackCondition() types.TrackCondition {
+	return p.trackCondition
+}
+
+func (p *PlaceRuleFact) RaceCourse() types.RaceCourse {
+	return p.raceCourse
+}
+
+func (p *PlaceRuleFact) WinOdds() float64 {
+	return p.winOdds
+}
+
+func (p *PlaceRuleFact) JockeyId() types.JockeyId {
+	return p.jockeyId
+}
+
+func (p *PlaceRuleFact) RaceWeight() float64 {
+	return p.raceWeight
+}
+
+// HorseResults 今走より前の戦績(新しい順)
+func (p *PlaceRuleFact) HorseResults() []*PlaceRuleHorseResult {
+	return p.horseResults
+}
+
+// Forecast 東スポ予想、取得できていない場合はnil
+func (p *PlaceRuleFact) Forecast() *PlaceRuleForecast {
+	return p.forecast
+}
+
+func (p *PlaceRuleFact) TrainerPerformance() *TrainerPerformance {
+	return p.trainerPerformance
+}
+
+func (p *PlaceRuleFact) JockeyTrainerPerformance() *TrainerPerformance {
+	return p.jockeyTrainerPerformance
+}
+
+type PlaceRuleHorseResult struct {
+	raceId         types.RaceId
+	raceDate       types.RaceDate
+	orderNo        int
+	distance       int
+	class          types.GradeClass
+	courseCategory types.CourseCategory
+	trackCondition types.TrackCondition
+	raceCourse     types.RaceCourse
+	jockeyId       types.JockeyId
+	raceWeight     float64
+	comment        string
+}
+
+func NewPlaceRuleHorseResult(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	orderNo int,
+	distance int,
+	class types.GradeClass,
+	courseCategory types.CourseCategory,
+	trackCondition types.TrackCondition,
+	raceCourse types.RaceCourse,
+	jockeyId types.JockeyId,
+	raceWeight float64,
+	comment string,
+) *PlaceRuleHorseResult {
+	return &PlaceRuleHorseResult{
+		raceId:         raceId,
+		raceDate:       raceDate,
+		orderNo:        orderNo,
+		distance:       distance,
+		class:          class,
+		courseCategory: courseCategory,
+		trackCondition: trackCondition,
+		raceCourse:     raceCourse,
+		jockeyId:       jockeyId,
+		raceWeight:     raceWeight,
+		comment:        comment,
+	}
+}
+
+func (p *PlaceRuleHorseResult) RaceId() types.RaceId {
+	return p.raceId
+}
+
+func (p *PlaceRuleHorseResult) RaceDate() types.RaceDate {
+	return p.raceDate
+}
+
+func (p *PlaceRuleHorseResult) OrderNo() int {
+	return p.orderNo
+}
+
+func (p *PlaceRuleHorseResult) Distance() int {
+	return p.distance
+}
+
+func (p *PlaceRuleHorseResult) Class() types.GradeClass {
+	return p.class
+}
+
+func (p *PlaceRuleHorseResult) CourseCategory() types.CourseCategory {
+	return p.courseCategory
+}
+
+func (p *PlaceRuleHorseResult) TrackCondition() types.TrackCondition {
+	return p.trackCondition
+}
+
+func (p *PlaceRuleHorseResult) RaceCourse() types.RaceCourse {
+	return p.raceCourse
+}
+
+func (p *PlaceRuleHorseResult) JockeyId() types.JockeyId {
+	return p.jockeyId
+}
+
+func (p *PlaceRuleHorseResult) RaceWeight() float64 {
+	return p.raceWeight
+}
+
+func (p *PlaceRuleHorseResult) Comment() string {
+	return p.comment
+}
+
+type PlaceRuleForecast struct {
+	favoriteNum       int
+	rivalNum          int
+	markerNum         int
+	highlyRecommended bool
+}
+
+func NewPlaceRuleForecast(
+	favoriteNum int,
+	rivalNum int,
+	markerNum int,
+	highlyRecommended bool,
+) *PlaceRuleForecast {
+	return &PlaceRuleForecast{
+		favoriteNum:       favoriteNum,
+		rivalNum:          rivalNum,
+		markerNum:         markerNum,
+		highlyRecommended: highlyRecommended,
+	}
+}
+
+func (p *PlaceRuleForecast) FavoriteNum() int {
+	return p.favoriteNum
+}
+
+func (p *PlaceRuleForecast) RivalNum() int {
+	return p.rivalNum
+}
+
+func (p *PlaceRuleForecast) MarkerNum() int {
+	return p.markerNum
+}
+
+func (p *PlaceRuleForecast) HighlyRecommended() bool {
+	return p.highlyRecommended
+}
@@ -0,0 +1,35 @@
+package analysis_entity
+
+type PlaceRuleResult struct {
+	name  string
+	ok    bool
+	point int
+}
+
+func NewPlaceRuleResult(
+	name string,
+	ok bool,
+	point int,
+) *PlaceRuleResult {
+	return &PlaceRuleResult{
+		name:  name,
+		ok:    ok,
+		point: point,
+	}
+}
+
+func (p *PlaceRuleResult) Name() string {
+	return p.name
+}
+
+func (p *PlaceRuleResult) Ok() bool {
+	return p.ok
+}
+
+// Point 該当した場合のみルールの点数、該当しない場合は0
+func (p *PlaceRuleResult) Point() int {
+	if !p.ok {
+		return 0
+	}
+	return p.point
+}
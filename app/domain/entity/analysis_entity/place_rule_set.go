@@ -0,0 +1,26 @@
+package analysis_entity
+
+type PlaceRuleSet struct {
+	checkList []*PlaceRule
+	danger    []*PlaceRule
+}
+
+func NewPlaceRuleSet(
+	checkList []*PlaceRule,
+	danger []*PlaceRule,
+) *PlaceRuleSet {
+	return &PlaceRuleSet{
+		checkList: checkList,
+		danger:    danger,
+	}
+}
+
+// CheckList 複勝圏内の好条件チェックリスト
+func (p *PlaceRuleSet) CheckList() []*PlaceRule {
+	return p.checkList
+}
+
+// Danger 危険レース・危険馬のチェックポイント、合計点数が高いほど危険
+func (p *PlaceRuleSet) Danger() []*PlaceRule {
+	return p.danger
+}
@@ -0,0 +1,41 @@
+package analysis_entity
+
+type PlaceScore struct {
+	baseScore     float64
+	contributions []float64
+	probability   float64
+}
+
+func NewPlaceScore(
+	baseScore float64,
+	contributions []float64,
+	probability float64,
+) *PlaceScore {
+	return &PlaceScore{
+		baseScore:     baseScore,
+		contributions: contributions,
+		probability:   probability,
+	}
+}
+
+// BaseScore 全項目が平均的な馬のスコア(対数オッズ)
+func (p *PlaceScore) BaseScore() float64 {
+	return p.baseScore
+}
+
+// Contributions 項目ごとのスコアへの寄与度(対数オッズ)
+func (p *PlaceScore) Contributions() []float64 {
+	return p.contributions
+}
+
+func (p *PlaceScore) Score() float64 {
+	score := p.baseScore
+	for _, contribution := range p.contributions {
+		score += contribution
+	}
+	return score
+}
+
+func (p *PlaceScore) Probability() float64 {
+	return p.probability
+}
@@ -0,0 +1,54 @@
+package analysis_entity
+
+type PlaceScoreModel struct {
+	bias        float64
+	weights     []float64
+	means       []float64
+	sampleCount int
+	placeCount  int
+	validation  *PlaceScoreValidation
+}
+
+func NewPlaceScoreModel(
+	bias float64,
+	weights []float64,
+	means []float64,
+	sampleCount int,
+	placeCount int,
+	validation *PlaceScoreValidation,
+) *PlaceScoreModel {
+	return &PlaceScoreModel{
+		bias:        bias,
+		weights:     weights,
+		means:       means,
+		sampleCount: sampleCount,
+		placeCount:  placeCount,
+		validation:  validation,
+	}
+}
+
+func (p *PlaceScoreModel) Bias() float64 {
+	return p.bias
+}
+
+func (p *PlaceScoreModel) Weights() []float64 {
+	return p.weights
+}
+
+// Means 各項目の学習データ上の該当率、寄与度の基準点に使う
+func (p *PlaceScoreModel) Means() []float64 {
+	return p.means
+}
+
+func (p *PlaceScoreModel) SampleCount() int {
+	return p.sampleCount
+}
+
+func (p *PlaceScoreModel) PlaceCount() int {
+	return p.placeCount
+}
+
+// Validation 直近のレースを除いて学習したモデルの検証結果、全件で学習し直す前の当たり具合
+func (p *PlaceScoreModel) Validation() *PlaceScoreValidation {
+	return p.validation
+}
@@ -0,0 +1,47 @@
+package analysis_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+type PlaceScoreSample struct {
+	raceId    types.RaceId
+	raceDate  types.RaceDate
+	horseId   types.HorseId
+	checkList []bool
+	isPlace   bool
+}
+
+func NewPlaceScoreSample(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	horseId types.HorseId,
+	checkList []bool,
+	isPlace bool,
+) *PlaceScoreSample {
+	return &PlaceScoreSample{
+		raceId:    raceId,
+		raceDate:  raceDate,
+		horseId:   horseId,
+		checkList: checkList,
+		isPlace:   isPlace,
+	}
+}
+
+func (p *PlaceScoreSample) RaceId() types.RaceId {
+	return p.raceId
+}
+
+func (p *PlaceScoreSample) RaceDate() types.RaceDate {
+	return p.raceDate
+}
+
+func (p *PlaceScoreSample) HorseId() types.HorseId {
+	return p.horseId
+}
+
+func (p *PlaceScoreSample) CheckList() []bool {
+	return p.checkList
+}
+
+func (p *PlaceScoreSample) IsPlace() bool {
+	return p.isPlace
+}
@@ -0,0 +1,114 @@
+package analysis_entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PlaceScoreValidation 学習に使っていない直近のレースで複勝圏内確率の当たり具合を検証した結果
+type PlaceScoreValidation struct {
+	sampleCount     int
+	brierScore      float64
+	baseBrierScore  float64
+	reliabilityBins []*PlaceScoreReliabilityBin
+}
+
+func NewPlaceScoreValidation(
+	sampleCount int,
+	brierScore float64,
+	baseBrierScore float64,
+	reliabilityBins []*PlaceScoreReliabilityBin,
+) *PlaceScoreValidation {
+	return &PlaceScoreValidation{
+		sampleCount:     sampleCount,
+		brierScore:      brierScore,
+		baseBrierScore:  baseBrierScore,
+		reliabilityBins: reliabilityBins,
+	}
+}
+
+func (p *PlaceScoreValidation) SampleCount() int {
+	return p.sampleCount
+}
+
+// BrierScore 予測確率と結果(0/1)の二乗誤差の平均、小さいほど確率が当たっている
+func (p *PlaceScoreValidation) BrierScore() float64 {
+	return p.brierScore
+}
+
+// BaseBrierScore 学習データの複勝圏内率を全馬に予測した場合のBrierスコア、これより小さくないとモデルの意味がない
+func (p *PlaceScoreValidation) BaseBrierScore() float64 {
+	return p.baseBrierScore
+}
+
+func (p *PlaceScoreValidation) ReliabilityBins() []*PlaceScoreReliabilityBin {
+	return p.reliabilityBins
+}
+
+func (p *PlaceScoreValidation) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("検証 %d頭, Brier %.4f(平均予測 %.4f)", p.sampleCount, p.brierScore, p.baseBrierScore))
+	for _, bin := range p.reliabilityBins {
+		if bin.Count() == 0 {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf(", %.0f-%.0f%% 予測%.1f%%/実際%.1f%%(%d頭)",
+			bin.From()*100, bin.To()*100, bin.PredictedRate()*100, bin.ActualRate()*100, bin.Count()))
+	}
+	return builder.String()
+}
+
+// PlaceScoreReliabilityBin 予測確率の区間ごとの予測の平均と実際の複勝圏内率
+type PlaceScoreReliabilityBin struct {
+	from           float64
+	to             float64
+	count          int
+	placeCount     int
+	predictedTotal float64
+}
+
+func NewPlaceScoreReliabilityBin(
+	from float64,
+	to float64,
+	count int,
+	placeCount int,
+	predictedTotal float64,
+) *PlaceScoreReliabilityBin {
+	return &PlaceScoreReliabilityBin{
+		from:           from,
+		to:             to,
+		count:          count,
+		placeCount:     placeCount,
+		predictedTotal: predictedTotal,
+	}
+}
+
+func (p *PlaceScoreReliabilityBin) From() float64 {
+	return p.from
+}
+
+func (p *PlaceScoreReliabilityBin) To() float64 {
+	return p.to
+}
+
+func (p *PlaceScoreReliabilityBin) Count() int {
+	return p.count
+}
+
+func (p *PlaceScoreReliabilityBin) PlaceCount() int {
+	return p.placeCount
+}
+
+func (p *PlaceScoreReliabilityBin) PredictedRate() float64 {
+	if p.count == 0 {
+		return 0
+	}
+	return p.predictedTotal / float64(p.count)
+}
+
+func (p *PlaceScoreReliabilityBin) ActualRate() float64 {
+	if p.count == 0 {
+		return 0
+	}
+	return float64(p.placeCount) / float64(p.count)
+}
@@ -17,6 +17,7 @@ type RaceResult struct {
 	jockeyWeight   string
 	horseWeight    int
 	horseWeightAdd int
+	runningStyle   types.RunningStyle
 }
 
 func NewRaceResult(
@@ -31,6 +32,7 @@ func NewRaceResult(
 	jockeyWeight string,
 	horseWeight int,
 	horseWeightAdd int,
+	runningStyle types.RunningStyle,
 ) *RaceResult {
 	return &RaceResult{
 		orderNo:        orderNo,
@@ -44,6 +46,7 @@ func NewRaceResult(
 		jockeyWeight:   jockeyWeight,
 		horseWeight:    horseWeight,
 		horseWeightAdd: horseWeightAdd,
+		runningStyle:   runningStyle,
 	}
 }
 
@@ -90,3 +93,7 @@ func (r *RaceResult) HorseWeight() int {
 func (r *RaceResult) HorseWeightAdd() int {
 	return r.horseWeightAdd
 }
+
+func (r *RaceResult) RunningStyle() types.RunningStyle {
+	return r.runningStyle
+}
@@ -0,0 +1,66 @@
+package analysis_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+type RaceRisk struct {
+	rating      types.RaceRiskRating
+	probability float64
+	signals     []*RaceRiskSignal
+}
+
+func NewRaceRisk(
+	rating types.RaceRiskRating,
+	probability float64,
+	signals []*RaceRiskSignal,
+) *RaceRisk {
+	return &RaceRisk{
+		rating:      rating,
+		probability: probability,
+		signals:     signals,
+	}
+}
+
+func (r *RaceRisk) Rating() types.RaceRiskRating {
+	return r.rating
+}
+
+// Probability 1番人気が複勝圏外になる確率
+func (r *RaceRisk) Probability() float64 {
+	return r.probability
+}
+
+// Signals 判定の方向に効いたシグナル、寄与度の大きい順
+func (r *RaceRisk) Signals() []*RaceRiskSignal {
+	return r.signals
+}
+
+type RaceRiskSignal struct {
+	name         string
+	value        float64
+	contribution float64
+}
+
+func NewRaceRiskSignal(
+	name string,
+	value float64,
+	contribution float64,
+) *RaceRiskSignal {
+	return &RaceRiskSignal{
+		name:         name,
+		value:        value,
+		contribution: contribution,
+	}
+}
+
+func (r *RaceRiskSignal) Name() string {
+	return r.name
+}
+
+func (r *RaceRiskSignal) Value() float64 {
+	return r.value
+}
+
+// Contribution 平均的なレースと比べたスコアへの寄与度(対数オッズ)、正なら荒れる方向
+func (r *RaceRiskSignal) Contribution() float64 {
+	return r.contribution
+}
@@ -0,0 +1,68 @@
+package analysis_entity
+
+type RaceRiskModel struct {
+	bias        float64
+	weights     []float64
+	means       []float64
+	stds        []float64
+	threshold   float64
+	sampleCount int
+	unHitCount  int
+	validation  *RaceRiskValidation
+}
+
+func NewRaceRiskModel(
+	bias float64,
+	weights []float64,
+	means []float64,
+	stds []float64,
+	threshold float64,
+	sampleCount int,
+	unHitCount int,
+	validation *RaceRiskValidation,
+) *RaceRiskModel {
+	return &RaceRiskModel{
+		bias:        bias,
+		weights:     weights,
+		means:       means,
+		stds:        stds,
+		threshold:   threshold,
+		sampleCount: sampleCount,
+		unHitCount:  unHitCount,
+		validation:  validation,
+	}
+}
+
+func (r *RaceRiskModel) Bias() float64 {
+	return r.bias
+}
+
+func (r *RaceRiskModel) Weights() []float64 {
+	return r.weights
+}
+
+// Means 各シグナルの学習データ上の平均、標準化と寄与度の基準点に使う
+func (r *RaceRiskModel) Means() []float64 {
+	return r.means
+}
+
+func (r *RaceRiskModel) Stds() []float64 {
+	return r.stds
+}
+
+// Threshold この確率以上を荒れると判定する、学習データの1番人気複勝圏外率
+func (r *RaceRiskModel) Threshold() float64 {
+	return r.threshold
+}
+
+func (r *RaceRiskModel) SampleCount() int {
+	return r.sampleCount
+}
+
+func (r *RaceRiskModel) UnHitCount() int {
+	return r.unHitCount
+}
+
+func (r *RaceRiskModel) Validation() *RaceRiskValidation {
+	return r.validation
+}
@@ -0,0 +1,41 @@
+package analysis_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+type RaceRiskSample struct {
+	raceId   types.RaceId
+	raceDate types.RaceDate
+	features []float64
+	isUnHit  bool
+}
+
+func NewRaceRiskSample(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	features []float64,
+	isUnHit bool,
+) *RaceRiskSample {
+	return &RaceRiskSample{
+		raceId:   raceId,
+		raceDate: raceDate,
+		features: features,
+		isUnHit:  isUnHit,
+	}
+}
+
+func (r *RaceRiskSample) RaceId() types.RaceId {
+	return r.raceId
+}
+
+func (r *RaceRiskSample) RaceDate() types.RaceDate {
+	return r.raceDate
+}
+
+func (r *RaceRiskSample) Features() []float64 {
+	return r.features
+}
+
+// IsUnHit 1番人気が複勝圏外だったか
+func (r *RaceRiskSample) IsUnHit() bool {
+	return r.isUnHit
+}
@@ -0,0 +1,63 @@
+package analysis_entity
+
+import "fmt"
+
+// RaceRiskValidation 学習に使っていない直近のレースで判定の当たり具合を検証した結果
+type RaceRiskValidation struct {
+	solidCount      int
+	solidUnHitCount int
+	roughCount      int
+	roughUnHitCount int
+}
+
+func NewRaceRiskValidation(
+	solidCount int,
+	solidUnHitCount int,
+	roughCount int,
+	roughUnHitCount int,
+) *RaceRiskValidation {
+	return &RaceRiskValidation{
+		solidCount:      solidCount,
+		solidUnHitCount: solidUnHitCount,
+		roughCount:      roughCount,
+		roughUnHitCount: roughUnHitCount,
+	}
+}
+
+func (r *RaceRiskValidation) SolidCount() int {
+	return r.solidCount
+}
+
+func (r *RaceRiskValidation) RoughCount() int {
+	return r.roughCount
+}
+
+// SolidUnHitRate 堅いと判定したレースで1番人気が複勝圏外だった率
+func (r *RaceRiskValidation) SolidUnHitRate() float64 {
+	if r.solidCount == 0 {
+		return 0
+	}
+	return float64(r.solidUnHitCount) / float64(r.solidCount)
+}
+
+// RoughUnHitRate 荒れると判定したレースで1番人気が複勝圏外だった率
+func (r *RaceRiskValidation) RoughUnHitRate() float64 {
+	if r.roughCount == 0 {
+		return 0
+	}
+	return float64(r.roughUnHitCount) / float64(r.roughCount)
+}
+
+// Accuracy 堅いレースで1番人気が複勝圏内、荒れるレースで複勝圏外だった率
+func (r *RaceRiskValidation) Accuracy() float64 {
+	total := r.solidCount + r.roughCount
+	if total == 0 {
+		return 0
+	}
+	return float64(r.solidCount-r.solidUnHitCount+r.roughUnHitCount) / float64(total)
+}
+
+func (r *RaceRiskValidation) String() string {
+	return fmt.Sprintf("堅い %d件(1番人気複勝圏外率 %.1f%%), 荒れる %d件(1番人気複勝圏外率 %.1f%%), 正解率 %.1f%%",
+		r.solidCount, r.SolidUnHitRate()*100, r.roughCount, r.RoughUnHitRate()*100, r.Accuracy()*100)
+}
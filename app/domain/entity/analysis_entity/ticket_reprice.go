@@ -0,0 +1,102 @@
+package analysis_entity
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+// TicketReprice レース×券種単位の購入を他の券種で買い直した場合の結果
+type TicketReprice struct {
+	raceId       types.RaceId
+	raceDate     types.RaceDate
+	ticketType   types.TicketType
+	points       int
+	payment      types.Payment
+	payout       types.Payout
+	alternatives []*TicketRepriceAlternative
+}
+
+func NewTicketReprice(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	ticketType types.TicketType,
+	points int,
+	payment types.Payment,
+	payout types.Payout,
+	alternatives []*TicketRepriceAlternative,
+) *TicketReprice {
+	return &TicketReprice{
+		raceId:       raceId,
+		raceDate:     raceDate,
+		ticketType:   ticketType,
+		points:       points,
+		payment:      payment,
+		payout:       payout,
+		alternatives: alternatives,
+	}
+}
+
+func (t *TicketReprice) RaceId() types.RaceId {
+	return t.raceId
+}
+
+func (t *TicketReprice) RaceDate() types.RaceDate {
+	return t.raceDate
+}
+
+func (t *TicketReprice) TicketType() types.TicketType {
+	return t.ticketType
+}
+
+func (t *TicketReprice) Points() int {
+	return t.points
+}
+
+func (t *TicketReprice) Payment() types.Payment {
+	return t.payment
+}
+
+func (t *TicketReprice) Payout() types.Payout {
+	return t.payout
+}
+
+func (t *TicketReprice) Alternatives() []*TicketRepriceAlternative {
+	return t.alternatives
+}
+
+// TicketRepriceAlternative 同じ馬の組み合わせを別の券種で同じ金額だけ買った場合の結果
+type TicketRepriceAlternative struct {
+	ticketType types.TicketType
+	points     int
+	payment    types.Payment
+	payout     types.Payout
+}
+
+func NewTicketRepriceAlternative(
+	ticketType types.TicketType,
+	points int,
+	payment types.Payment,
+	payout types.Payout,
+) *TicketRepriceAlternative {
+	return &TicketRepriceAlternative{
+		ticketType: ticketType,
+		points:     points,
+		payment:    payment,
+		payout:     payout,
+	}
+}
+
+func (t *TicketRepriceAlternative) TicketType() types.TicketType {
+	return t.ticketType
+}
+
+func (t *TicketRepriceAlternative) Points() int {
+	return t.points
+}
+
+func (t *TicketRepriceAlternative) Payment() types.Payment {
+	return t.payment
+}
+
+func (t *TicketRepriceAlternative) Payout() types.Payout {
+	return t.payout
+}
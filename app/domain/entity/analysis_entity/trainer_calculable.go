@@ -0,0 +1,78 @@
+package analysis_entity
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/shopspring/decimal"
+)
+
+type TrainerCalculable struct {
+	raceId        types.RaceId
+	raceDate      types.RaceDate
+	raceCourse    types.RaceCourse
+	trainerId     types.TrainerId
+	jockeyId      types.JockeyId
+	orderNo       int
+	popularNumber int
+	odds          decimal.Decimal
+	placeOdds     decimal.Decimal
+}
+
+func NewTrainerCalculable(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	raceCourse types.RaceCourse,
+	trainerId types.TrainerId,
+	jockeyId types.JockeyId,
+	orderNo int,
+	popularNumber int,
+	odds decimal.Decimal,
+	placeOdds decimal.Decimal,
+) *TrainerCalculable {
+	return &TrainerCalculable{
+		raceId:        raceId,
+		raceDate:      raceDate,
+		raceCourse:    raceCourse,
+		trainerId:     trainerId,
+		jockeyId:      jockeyId,
+		orderNo:       orderNo,
+		popularNumber: popularNumber,
+		odds:          odds,
+		placeOdds:     placeOdds,
+	}
+}
+
+func (t *TrainerCalculable) RaceId() types.RaceId {
+	return t.raceId
+}
+
+func (t *TrainerCalculable) RaceDate() types.RaceDate {
+	return t.raceDate
+}
+
+func (t *TrainerCalculable) RaceCourse() types.RaceCourse {
+	return t.raceCourse
+}
+
+func (t *TrainerCalculable) TrainerId() types.TrainerId {
+	return t.trainerId
+}
+
+func (t *TrainerCalculable) JockeyId() types.JockeyId {
+	return t.jockeyId
+}
+
+func (t *TrainerCalculable) OrderNo() int {
+	return t.orderNo
+}
+
+func (t *TrainerCalculable) PopularNumber() int {
+	return t.popularNumber
+}
+
+func (t *TrainerCalculable) Odds() decimal.Decimal {
+	return t.odds
+}
+
+func (t *TrainerCalculable) PlaceOdds() decimal.Decimal {
+	return t.placeOdds
+}
@@ -0,0 +1,69 @@
+package analysis_entity
+
+import "github.com/shopspring/decimal"
+
+type TrainerPerformance struct {
+	raceCount   int
+	winCount    int
+	placeCount  int
+	winPayout   decimal.Decimal
+	placePayout decimal.Decimal
+}
+
+func NewTrainerPerformance(
+	raceCount int,
+	winCount int,
+	placeCount int,
+	winPayout decimal.Decimal,
+	placePayout decimal.Decimal,
+) *TrainerPerformance {
+	return &TrainerPerformance{
+		raceCount:   raceCount,
+		winCount:    winCount,
+		placeCount:  placeCount,
+		winPayout:   winPayout,
+		placePayout: placePayout,
+	}
+}
+
+func (t *TrainerPerformance) RaceCount() int {
+	return t.raceCount
+}
+
+func (t *TrainerPerformance) WinCount() int {
+	return t.winCount
+}
+
+func (t *TrainerPerformance) PlaceCount() int {
+	return t.placeCount
+}
+
+func (t *TrainerPerformance) WinRate() float64 {
+	if t.raceCount == 0 {
+		return 0
+	}
+	return float64(t.winCount) / float64(t.raceCount)
+}
+
+func (t *TrainerPerformance) PlaceRate() float64 {
+	if t.raceCount == 0 {
+		return 0
+	}
+	return float64(t.placeCount) / float64(t.raceCount)
+}
+
+// WinPayoutRate 単勝を100円ずつ買った場合の回収率
+func (t *TrainerPerformance) WinPayoutRate() float64 {
+	if t.raceCount == 0 {
+		return 0
+	}
+	return t.winPayout.InexactFloat64() / float64(t.raceCount)
+}
+
+// PlacePayoutRate 複勝を100円ずつ買った場合の回収率
+func (t *TrainerPerformance) PlacePayoutRate() float64 {
+	if t.raceCount == 0 {
+		return 0
+	}
+	return t.placePayout.InexactFloat64() / float64(t.raceCount)
+}
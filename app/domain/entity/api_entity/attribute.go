@@ -0,0 +1,7 @@
+package api_entity
+
+type Attribute struct {
+	Id   uint64 `json:"id"`
+	Hex  string `json:"hex"`
+	Name string `json:"name"`
+}
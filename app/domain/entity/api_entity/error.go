@@ -0,0 +1,5 @@
+package api_entity
+
+type Error struct {
+	Error string `json:"error"`
+}
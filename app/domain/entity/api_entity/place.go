@@ -0,0 +1,16 @@
+package api_entity
+
+type Place struct {
+	Marker      string            `json:"marker"`
+	OrderNo     int               `json:"order_no"` // 1:1着、2:2着以内、3:3着以内
+	AttributeId uint64            `json:"attribute_id"`
+	Attribute   string            `json:"attribute"`
+	RaceCount   int               `json:"race_count"`
+	HitRate     *float64          `json:"hit_rate"`
+	OddsRanges  []*PlaceOddsRange `json:"odds_ranges"`
+}
+
+type PlaceOddsRange struct {
+	WinOddsRange string   `json:"win_odds_range"`
+	HitRate      *float64 `json:"hit_rate"`
+}
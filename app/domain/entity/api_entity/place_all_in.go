@@ -0,0 +1,15 @@
+package api_entity
+
+type PlaceAllIn struct {
+	AttributeId       uint64               `json:"attribute_id,omitempty"`
+	Attribute         string               `json:"attribute,omitempty"`
+	MarkerCombination string               `json:"marker_combination,omitempty"`
+	WinOdds           []*PlaceAllInWinOdds `json:"win_odds"`
+}
+
+type PlaceAllInWinOdds struct {
+	WinOdds    string   `json:"win_odds"`
+	HitCount   int      `json:"hit_count"`
+	UnHitCount int      `json:"unhit_count"`
+	HitRate    *float64 `json:"hit_rate"`
+}
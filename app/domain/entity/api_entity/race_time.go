@@ -0,0 +1,23 @@
+package api_entity
+
+type RaceTime struct {
+	AttributeId       uint64 `json:"attribute_id"`
+	Attribute         string `json:"attribute"`
+	RaceCount         int    `json:"race_count"`
+	AverageRaceTime   string `json:"average_race_time"`
+	MedianRaceTime    string `json:"median_race_time"`
+	AverageFirst3f    string `json:"average_first_3f"`
+	MedianFirst3f     string `json:"median_first_3f"`
+	AverageFirst4f    string `json:"average_first_4f"`
+	MedianFirst4f     string `json:"median_first_4f"`
+	AverageLast3f     string `json:"average_last_3f"`
+	MedianLast3f      string `json:"median_last_3f"`
+	AverageLast4f     string `json:"average_last_4f"`
+	MedianLast4f      string `json:"median_last_4f"`
+	AverageRap5f      string `json:"average_rap_5f"`
+	MedianRap5f       string `json:"median_rap_5f"`
+	AverageTrackIndex int    `json:"average_track_index"`
+	MaxTrackIndex     int    `json:"max_track_index"`
+	MinTrackIndex     int    `json:"min_track_index"`
+	AverageTimeIndex  int    `json:"average_time_index"`
+}
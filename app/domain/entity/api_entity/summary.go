@@ -0,0 +1,26 @@
+package api_entity
+
+type Summary struct {
+	Term             map[string]*TicketResult `json:"term"`
+	TicketType       map[string]*TicketResult `json:"ticket_type"`
+	GradeClass       map[string]*TicketResult `json:"grade_class"`
+	CourseCategory   map[string]*TicketResult `json:"course_category"`
+	DistanceCategory map[string]*TicketResult `json:"distance_category"`
+	RaceCourse       map[string]*TicketResult `json:"race_course"`
+	TicketSource     map[string]*TicketResult `json:"ticket_source"`
+	Account          map[string]*TicketResult `json:"account"`
+}
+
+type TicketResult struct {
+	RaceCount     int    `json:"race_count"`
+	BetCount      int    `json:"bet_count"`
+	HitCount      int    `json:"hit_count"`
+	HitRate       string `json:"hit_rate"`
+	Payment       int    `json:"payment"`
+	Payout        int    `json:"payout"`
+	Profit        int    `json:"profit"`
+	AveragePayout int    `json:"average_payout"`
+	MaxPayout     int    `json:"max_payout"`
+	MinPayout     int    `json:"min_payout"`
+	PayoutRate    string `json:"payout_rate"`
+}
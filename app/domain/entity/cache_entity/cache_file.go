@@ -0,0 +1,53 @@
+package cache_entity
+
+import (
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+type CacheFile struct {
+	path       string
+	size       int64
+	modifiedAt time.Time
+	status     types.CacheFileStatus
+	reason     string
+}
+
+func NewCacheFile(
+	path string,
+	size int64,
+	modifiedAt time.Time,
+	status types.CacheFileStatus,
+	reason string,
+) *CacheFile {
+	return &CacheFile{
+		path:       path,
+		size:       size,
+		modifiedAt: modifiedAt,
+		status:     status,
+		reason:     reason,
+	}
+}
+
+// Path cache/races/race_20250504.jsonのようなcache配下の相対パス
+func (c *CacheFile) Path() string {
+	return c.path
+}
+
+func (c *CacheFile) Size() int64 {
+	return c.size
+}
+
+func (c *CacheFile) ModifiedAt() time.Time {
+	return c.modifiedAt
+}
+
+func (c *CacheFile) Status() types.CacheFileStatus {
+	return c.status
+}
+
+// Reason 壊れていると判定した理由
+func (c *CacheFile) Reason() string {
+	return c.reason
+}
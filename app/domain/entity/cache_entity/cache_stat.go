@@ -0,0 +1,63 @@
+package cache_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+type CacheStat struct {
+	cacheType    string
+	files        int
+	corruptFiles int
+	size         int64
+	entries      int
+	startDate    types.RaceDate
+	endDate      types.RaceDate
+}
+
+func NewCacheStat(
+	cacheType string,
+	files int,
+	corruptFiles int,
+	size int64,
+	entries int,
+	startDate types.RaceDate,
+	endDate types.RaceDate,
+) *CacheStat {
+	return &CacheStat{
+		cacheType:    cacheType,
+		files:        files,
+		corruptFiles: corruptFiles,
+		size:         size,
+		entries:      entries,
+		startDate:    startDate,
+		endDate:      endDate,
+	}
+}
+
+// CacheType racesやodds/winのようなcache配下のディレクトリ名、直下のファイルは拡張子を除いたファイル名
+func (c *CacheStat) CacheType() string {
+	return c.cacheType
+}
+
+func (c *CacheStat) Files() int {
+	return c.files
+}
+
+func (c *CacheStat) CorruptFiles() int {
+	return c.corruptFiles
+}
+
+func (c *CacheStat) Size() int64 {
+	return c.size
+}
+
+// Entries レース単位の件数。レース単位で数えられない種別は0
+func (c *CacheStat) Entries() int {
+	return c.entries
+}
+
+func (c *CacheStat) StartDate() types.RaceDate {
+	return c.startDate
+}
+
+func (c *CacheStat) EndDate() types.RaceDate {
+	return c.endDate
+}
@@ -3,17 +3,26 @@ package data_cache_entity
 import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
 
 type HorseBlood struct {
-	sireId          types.HorseId
-	broodmareSireId types.HorseId
+	sireId            types.HorseId
+	sireName          string
+	broodmareSireId   types.HorseId
+	broodmareSireName string
+	nameFetched       bool
 }
 
 func NewHorseBlood(
 	rawSireId string,
+	sireName string,
 	rawBroodmareSireId string,
+	broodmareSireName string,
+	nameFetched bool,
 ) *HorseBlood {
 	return &HorseBlood{
-		sireId:          types.HorseId(rawSireId),
-		broodmareSireId: types.HorseId(rawBroodmareSireId),
+		sireId:            types.HorseId(rawSireId),
+		sireName:          sireName,
+		broodmareSireId:   types.HorseId(rawBroodmareSireId),
+		broodmareSireName: broodmareSireName,
+		nameFetched:       nameFetched,
 	}
 }
 
@@ -21,6 +30,19 @@ func (h *HorseBlood) SireId() types.HorseId {
 	return h.sireId
 }
 
+func (h *HorseBlood) SireName() string {
+	return h.sireName
+}
+
 func (h *HorseBlood) BroodmareSireId() types.HorseId {
 	return h.broodmareSireId
 }
+
+func (h *HorseBlood) BroodmareSireName() string {
+	return h.broodmareSireName
+}
+
+// NameFetched 種牡馬名を保存するようになってから取得したか。取得済みなら名前が空でも取得し直さない
+func (h *HorseBlood) NameFetched() bool {
+	return h.nameFetched
+}
@@ -25,6 +25,7 @@ type Race struct {
 	raceAgeCondition      types.RaceAgeCondition
 	raceResults           []*RaceResult
 	payoutResults         []*PayoutResult
+	trainerFetched        bool
 }
 
 func NewRace(
@@ -48,6 +49,7 @@ func NewRace(
 	raceAgeCondition int,
 	raceResults []*RaceResult,
 	payoutResults []*PayoutResult,
+	trainerFetched bool,
 ) *Race {
 	return &Race{
 		raceId:                types.RaceId(raceId),
@@ -70,6 +72,7 @@ func NewRace(
 		raceAgeCondition:      types.RaceAgeCondition(raceAgeCondition),
 		raceResults:           raceResults,
 		payoutResults:         payoutResults,
+		trainerFetched:        trainerFetched,
 	}
 }
 
@@ -152,3 +155,8 @@ func (r *Race) RaceResults() []*RaceResult {
 func (r *Race) PayoutResults() []*PayoutResult {
 	return r.payoutResults
 }
+
+// TrainerFetched 通過順、上り3F、着差、調教師IDを取得するようになってから取得したか。古いキャッシュはfalseになる
+func (r *Race) TrainerFetched() bool {
+	return r.trainerFetched
+}
@@ -1,22 +1,29 @@
 package data_cache_entity
 
-import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+import (
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
 
 type RaceForecast struct {
-	raceId    types.RaceId
-	raceDate  types.RaceDate
-	forecasts []*Forecast
+	raceId         types.RaceId
+	raceDate       types.RaceDate
+	forecasts      []*Forecast
+	paddockFetched bool
 }
 
 func NewRaceForecast(
 	rawRaceId string,
 	rawRaceDate int,
 	forecasts []*Forecast,
+	paddockFetched bool,
 ) *RaceForecast {
 	return &RaceForecast{
-		raceId:    types.RaceId(rawRaceId),
-		raceDate:  types.RaceDate(rawRaceDate),
-		forecasts: forecasts,
+		raceId:         types.RaceId(rawRaceId),
+		raceDate:       types.RaceDate(rawRaceDate),
+		forecasts:      forecasts,
+		paddockFetched: paddockFetched,
 	}
 }
 
@@ -32,6 +39,11 @@ func (r *RaceForecast) Forecasts() []*Forecast {
 	return r.forecasts
 }
 
+// PaddockFetched 記者メモとパドック情報を取得済みか。取得前のキャッシュは記者メモもパドック評価も空になっている
+func (r *RaceForecast) PaddockFetched() bool {
+	return r.paddockFetched
+}
+
 type Forecast struct {
 	horseNumber             types.HorseNumber
 	trainingComment         string
@@ -40,6 +52,9 @@ type Forecast struct {
 	favoriteNum             int
 	rivalNum                int
 	markerNum               int
+	reporterMemos           []*ReporterMemo
+	paddockComment          string
+	paddockEvaluation       types.PaddockEvaluation
 }
 
 func NewForecast(
@@ -50,6 +65,9 @@ func NewForecast(
 	favoriteNum int,
 	rivalNum int,
 	markerNum int,
+	reporterMemos []*ReporterMemo,
+	paddockComment string,
+	paddockEvaluation int,
 ) *Forecast {
 	return &Forecast{
 		horseNumber:             types.HorseNumber(horseNumber),
@@ -59,6 +77,9 @@ func NewForecast(
 		favoriteNum:             favoriteNum,
 		rivalNum:                rivalNum,
 		markerNum:               markerNum,
+		reporterMemos:           reporterMemos,
+		paddockComment:          paddockComment,
+		paddockEvaluation:       types.PaddockEvaluation(paddockEvaluation),
 	}
 }
 
@@ -89,3 +110,51 @@ func (f *Forecast) RivalNum() int {
 func (f *Forecast) MarkerNum() int {
 	return f.markerNum
 }
+
+func (f *Forecast) ReporterMemos() []*ReporterMemo {
+	return f.reporterMemos
+}
+
+func (f *Forecast) PaddockComment() string {
+	return f.paddockComment
+}
+
+func (f *Forecast) PaddockEvaluation() types.PaddockEvaluation {
+	return f.paddockEvaluation
+}
+
+// HasReporterMemo レース日の前days日以内に記者メモがあるか
+func (f *Forecast) HasReporterMemo(raceDate types.RaceDate, days int) bool {
+	raceTime := raceDate.Date()
+	from := raceTime.AddDate(0, 0, -days)
+	for _, memo := range f.reporterMemos {
+		if !memo.Date().Before(from) && !memo.Date().After(raceTime) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type ReporterMemo struct {
+	comment string
+	date    time.Time
+}
+
+func NewReporterMemo(
+	comment string,
+	date time.Time,
+) *ReporterMemo {
+	return &ReporterMemo{
+		comment: comment,
+		date:    date,
+	}
+}
+
+func (r *ReporterMemo) Comment() string {
+	return r.comment
+}
+
+func (r *ReporterMemo) Date() time.Time {
+	return r.date
+}
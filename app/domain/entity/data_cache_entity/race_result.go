@@ -6,17 +6,22 @@ import (
 )
 
 type RaceResult struct {
-	orderNo        int
-	horseId        types.HorseId
-	horseName      string
-	bracketNumber  int
-	horseNumber    types.HorseNumber
-	jockeyId       types.JockeyId
-	odds           decimal.Decimal
-	popularNumber  int
-	jockeyWeight   string
-	horseWeight    int
-	horseWeightAdd int
+	orderNo            int
+	horseId            types.HorseId
+	horseName          string
+	bracketNumber      int
+	horseNumber        types.HorseNumber
+	jockeyId           types.JockeyId
+	odds               decimal.Decimal
+	popularNumber      int
+	jockeyWeight       string
+	horseWeight        int
+	horseWeightAdd     int
+	cornerPassingOrder string
+	lastThreeFurlong   decimal.Decimal
+	margin             string
+	trainerId          types.TrainerId
+	runningStyle       types.RunningStyle
 }
 
 func NewRaceResult(
@@ -31,20 +36,31 @@ func NewRaceResult(
 	jockeyWeight string,
 	horseWeight int,
 	horseWeightAdd int,
+	cornerPassingOrder string,
+	lastThreeFurlong string,
+	margin string,
+	trainerId string,
+	runningStyle int,
 ) *RaceResult {
 	decimalOdds, _ := decimal.NewFromString(odds)
+	decimalLastThreeFurlong, _ := decimal.NewFromString(lastThreeFurlong)
 	return &RaceResult{
-		orderNo:        orderNo,
-		horseId:        types.HorseId(horseId),
-		horseName:      horseName,
-		bracketNumber:  bracketNumber,
-		horseNumber:    types.HorseNumber(horseNumber),
-		jockeyId:       types.JockeyId(jockeyId),
-		odds:           decimalOdds,
-		popularNumber:  popularNumber,
-		jockeyWeight:   jockeyWeight,
-		horseWeight:    horseWeight,
-		horseWeightAdd: horseWeightAdd,
+		orderNo:            orderNo,
+		horseId:            types.HorseId(horseId),
+		horseName:          horseName,
+		bracketNumber:      bracketNumber,
+		horseNumber:        types.HorseNumber(horseNumber),
+		jockeyId:           types.JockeyId(jockeyId),
+		odds:               decimalOdds,
+		popularNumber:      popularNumber,
+		jockeyWeight:       jockeyWeight,
+		horseWeight:        horseWeight,
+		horseWeightAdd:     horseWeightAdd,
+		cornerPassingOrder: cornerPassingOrder,
+		lastThreeFurlong:   decimalLastThreeFurlong,
+		margin:             margin,
+		trainerId:          types.TrainerId(trainerId),
+		runningStyle:       types.RunningStyle(runningStyle),
 	}
 }
 
@@ -91,3 +107,23 @@ func (r *RaceResult) HorseWeight() int {
 func (r *RaceResult) HorseWeightAdd() int {
 	return r.horseWeightAdd
 }
+
+func (r *RaceResult) CornerPassingOrder() string {
+	return r.cornerPassingOrder
+}
+
+func (r *RaceResult) LastThreeFurlong() decimal.Decimal {
+	return r.lastThreeFurlong
+}
+
+func (r *RaceResult) Margin() string {
+	return r.margin
+}
+
+func (r *RaceResult) TrainerId() types.TrainerId {
+	return r.trainerId
+}
+
+func (r *RaceResult) RunningStyle() types.RunningStyle {
+	return r.runningStyle
+}
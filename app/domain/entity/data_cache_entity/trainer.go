@@ -0,0 +1,33 @@
+package data_cache_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+type Trainer struct {
+	trainerId    types.TrainerId
+	trainerName  string
+	locationName string
+}
+
+func NewTrainer(
+	trainerId string,
+	trainerName string,
+	locationName string,
+) *Trainer {
+	return &Trainer{
+		trainerId:    types.TrainerId(trainerId),
+		trainerName:  trainerName,
+		locationName: locationName,
+	}
+}
+
+func (t *Trainer) TrainerId() types.TrainerId {
+	return t.trainerId
+}
+
+func (t *Trainer) TrainerName() string {
+	return t.trainerName
+}
+
+func (t *Trainer) LocationName() string {
+	return t.locationName
+}
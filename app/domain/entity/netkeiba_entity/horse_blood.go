@@ -1,17 +1,23 @@
 package netkeiba_entity
 
 type HorseBlood struct {
-	sireId          string
-	broodmareSireId string
+	sireId            string
+	sireName          string
+	broodmareSireId   string
+	broodmareSireName string
 }
 
 func NewHorseBlood(
 	sireId string,
+	sireName string,
 	broodmareSireId string,
+	broodmareSireName string,
 ) *HorseBlood {
 	return &HorseBlood{
-		sireId:          sireId,
-		broodmareSireId: broodmareSireId,
+		sireId:            sireId,
+		sireName:          sireName,
+		broodmareSireId:   broodmareSireId,
+		broodmareSireName: broodmareSireName,
 	}
 }
 
@@ -19,6 +25,14 @@ func (h *HorseBlood) SireId() string {
 	return h.sireId
 }
 
+func (h *HorseBlood) SireName() string {
+	return h.sireName
+}
+
 func (h *HorseBlood) BroodmareSireId() string {
 	return h.broodmareSireId
 }
+
+func (h *HorseBlood) BroodmareSireName() string {
+	return h.broodmareSireName
+}
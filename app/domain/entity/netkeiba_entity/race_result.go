@@ -1,17 +1,21 @@
 package netkeiba_entity
 
 type RaceResult struct {
-	orderNo        int
-	horseId        string
-	horseName      string
-	bracketNumber  int
-	horseNumber    int
-	jockeyId       string
-	odds           string
-	popularNumber  int
-	jockeyWeight   string
-	horseWeight    int
-	horseWeightAdd int
+	orderNo            int
+	horseId            string
+	horseName          string
+	bracketNumber      int
+	horseNumber        int
+	jockeyId           string
+	odds               string
+	popularNumber      int
+	jockeyWeight       string
+	horseWeight        int
+	horseWeightAdd     int
+	cornerPassingOrder string
+	lastThreeFurlong   string
+	margin             string
+	trainerId          string
 }
 
 func NewRaceResult(
@@ -26,19 +30,27 @@ func NewRaceResult(
 	jockeyWeight string,
 	horseWeight int,
 	horseWeightAdd int,
+	cornerPassingOrder string,
+	lastThreeFurlong string,
+	margin string,
+	trainerId string,
 ) *RaceResult {
 	return &RaceResult{
-		orderNo:        orderNo,
-		horseId:        horseId,
-		horseName:      horseName,
-		bracketNumber:  bracketNumber,
-		horseNumber:    horseNumber,
-		jockeyId:       jockeyId,
-		odds:           odds,
-		popularNumber:  popularNumber,
-		jockeyWeight:   jockeyWeight,
-		horseWeight:    horseWeight,
-		horseWeightAdd: horseWeightAdd,
+		orderNo:            orderNo,
+		horseId:            horseId,
+		horseName:          horseName,
+		bracketNumber:      bracketNumber,
+		horseNumber:        horseNumber,
+		jockeyId:           jockeyId,
+		odds:               odds,
+		popularNumber:      popularNumber,
+		jockeyWeight:       jockeyWeight,
+		horseWeight:        horseWeight,
+		horseWeightAdd:     horseWeightAdd,
+		cornerPassingOrder: cornerPassingOrder,
+		lastThreeFurlong:   lastThreeFurlong,
+		margin:             margin,
+		trainerId:          trainerId,
 	}
 }
 
@@ -85,3 +97,19 @@ func (r *RaceResult) HorseWeight() int {
 func (r *RaceResult) HorseWeightAdd() int {
 	return r.horseWeightAdd
 }
+
+func (r *RaceResult) CornerPassingOrder() string {
+	return r.cornerPassingOrder
+}
+
+func (r *RaceResult) LastThreeFurlong() string {
+	return r.lastThreeFurlong
+}
+
+func (r *RaceResult) Margin() string {
+	return r.margin
+}
+
+func (r *RaceResult) TrainerId() string {
+	return r.trainerId
+}
@@ -0,0 +1,55 @@
+package prediction_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+// BetPlan 買い目ファイルの1行。馬／組番は印(◎など)でも書ける
+type BetPlan struct {
+	rowNum        int
+	raceId        types.RaceId
+	ticketType    types.TicketType
+	rawTicketType string
+	rawBetNumber  string
+	payment       int
+}
+
+func NewBetPlan(
+	rowNum int,
+	raceId types.RaceId,
+	rawTicketType string,
+	rawBetNumber string,
+	payment int,
+) *BetPlan {
+	return &BetPlan{
+		rowNum:        rowNum,
+		raceId:        raceId,
+		ticketType:    types.NewTicketType(rawTicketType),
+		rawTicketType: rawTicketType,
+		rawBetNumber:  rawBetNumber,
+		payment:       payment,
+	}
+}
+
+func (b *BetPlan) RowNum() int {
+	return b.rowNum
+}
+
+func (b *BetPlan) RaceId() types.RaceId {
+	return b.raceId
+}
+
+func (b *BetPlan) TicketType() types.TicketType {
+	return b.ticketType
+}
+
+func (b *BetPlan) RawTicketType() string {
+	return b.rawTicketType
+}
+
+func (b *BetPlan) RawBetNumber() string {
+	return b.rawBetNumber
+}
+
+// Payment 1点あたりの購入金額
+func (b *BetPlan) Payment() int {
+	return b.payment
+}
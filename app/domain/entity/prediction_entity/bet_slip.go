@@ -0,0 +1,102 @@
+package prediction_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+// BetSlip 出馬表で検証した投票予定の買い目。投票はせずファイルに書き出すだけ
+type BetSlip struct {
+	plan       *BetPlan
+	raceDate   types.RaceDate
+	raceCourse types.RaceCourse
+	raceNumber int
+	raceName   string
+	startTime  string
+	betNumber  string
+	betNumbers []types.BetNumber
+	problems   []string
+}
+
+func NewBetSlip(
+	plan *BetPlan,
+	raceDate types.RaceDate,
+	raceCourse types.RaceCourse,
+	raceNumber int,
+	raceName string,
+	startTime string,
+	betNumber string,
+	betNumbers []types.BetNumber,
+	problems []string,
+) *BetSlip {
+	return &BetSlip{
+		plan:       plan,
+		raceDate:   raceDate,
+		raceCourse: raceCourse,
+		raceNumber: raceNumber,
+		raceName:   raceName,
+		startTime:  startTime,
+		betNumber:  betNumber,
+		betNumbers: betNumbers,
+		problems:   problems,
+	}
+}
+
+func (b *BetSlip) Plan() *BetPlan {
+	return b.plan
+}
+
+func (b *BetSlip) RaceId() types.RaceId {
+	return b.plan.RaceId()
+}
+
+func (b *BetSlip) RaceDate() types.RaceDate {
+	return b.raceDate
+}
+
+func (b *BetSlip) RaceCourse() types.RaceCourse {
+	return b.raceCourse
+}
+
+func (b *BetSlip) RaceNumber() int {
+	return b.raceNumber
+}
+
+func (b *BetSlip) RaceName() string {
+	return b.raceName
+}
+
+func (b *BetSlip) StartTime() string {
+	return b.startTime
+}
+
+func (b *BetSlip) TicketType() types.TicketType {
+	return b.plan.TicketType()
+}
+
+// BetNumber IPATの馬／組番の書式(例: 05／01；03；07)
+func (b *BetSlip) BetNumber() string {
+	return b.betNumber
+}
+
+// BetNumbers ながし、フォーメーションをバラした1点ずつの買い目
+func (b *BetSlip) BetNumbers() []types.BetNumber {
+	return b.betNumbers
+}
+
+func (b *BetSlip) Payment() int {
+	return b.plan.Payment()
+}
+
+func (b *BetSlip) Points() int {
+	return len(b.betNumbers)
+}
+
+func (b *BetSlip) TotalPayment() int {
+	return b.plan.Payment() * len(b.betNumbers)
+}
+
+func (b *BetSlip) Problems() []string {
+	return b.problems
+}
+
+func (b *BetSlip) Valid() bool {
+	return len(b.problems) == 0
+}
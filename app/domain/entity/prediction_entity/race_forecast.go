@@ -3,15 +3,16 @@ package prediction_entity
 import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
 
 type RaceForecast struct {
-	horseNumber         types.HorseNumber
-	favoriteNum         int
-	rivalNum            int
-	markerNum           int
-	trainingComment     string
-	isHighlyRecommended bool
-	reporterMemos       []string
-	paddockComment      string
-	paddockEvaluation   int
+	horseNumber             types.HorseNumber
+	favoriteNum             int
+	rivalNum                int
+	markerNum               int
+	trainingComment         string
+	previousTrainingComment string
+	isHighlyRecommended     bool
+	reporterMemos           []string
+	paddockComment          string
+	paddockEvaluation       int
 }
 
 func NewRaceForecast(
@@ -20,21 +21,23 @@ func NewRaceForecast(
 	rivalNum int,
 	markerNum int,
 	trainingComment string,
+	previousTrainingComment string,
 	isHighlyRecommended bool,
 	reporterMemos []string,
 	paddockComment string,
 	paddockEvaluation int,
 ) *RaceForecast {
 	return &RaceForecast{
-		horseNumber:         horseNumber,
-		favoriteNum:         favoriteNum,
-		rivalNum:            rivalNum,
-		markerNum:           markerNum,
-		trainingComment:     trainingComment,
-		isHighlyRecommended: isHighlyRecommended,
-		reporterMemos:       reporterMemos,
-		paddockComment:      paddockComment,
-		paddockEvaluation:   paddockEvaluation,
+		horseNumber:             horseNumber,
+		favoriteNum:             favoriteNum,
+		rivalNum:                rivalNum,
+		markerNum:               markerNum,
+		trainingComment:         trainingComment,
+		previousTrainingComment: previousTrainingComment,
+		isHighlyRecommended:     isHighlyRecommended,
+		reporterMemos:           reporterMemos,
+		paddockComment:          paddockComment,
+		paddockEvaluation:       paddockEvaluation,
 	}
 }
 
@@ -58,6 +61,10 @@ func (r *RaceForecast) TrainingComment() string {
 	return r.trainingComment
 }
 
+func (r *RaceForecast) PreviousTrainingComment() string {
+	return r.previousTrainingComment
+}
+
 func (r *RaceForecast) IsHighlyRecommended() bool {
 	return r.isHighlyRecommended
 }
@@ -0,0 +1,114 @@
+package prediction_entity
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/shopspring/decimal"
+)
+
+// Stake 買い目候補1点ごとの推奨賭け金。券種は単勝のみ
+type Stake struct {
+	raceId      types.RaceId
+	raceDate    types.RaceDate
+	raceCourse  types.RaceCourse
+	raceNumber  int
+	raceName    string
+	ticketType  types.TicketType
+	marker      types.Marker
+	horseNumber types.HorseNumber
+	odds        decimal.Decimal
+	hitRate     float64
+	sampleCount int
+	kelly       float64
+	amount      int
+}
+
+func NewStake(
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+	raceCourse types.RaceCourse,
+	raceNumber int,
+	raceName string,
+	ticketType types.TicketType,
+	marker types.Marker,
+	horseNumber types.HorseNumber,
+	odds decimal.Decimal,
+	hitRate float64,
+	sampleCount int,
+	kelly float64,
+	amount int,
+) *Stake {
+	return &Stake{
+		raceId:      raceId,
+		raceDate:    raceDate,
+		raceCourse:  raceCourse,
+		raceNumber:  raceNumber,
+		raceName:    raceName,
+		ticketType:  ticketType,
+		marker:      marker,
+		horseNumber: horseNumber,
+		odds:        odds,
+		hitRate:     hitRate,
+		sampleCount: sampleCount,
+		kelly:       kelly,
+		amount:      amount,
+	}
+}
+
+func (s *Stake) RaceId() types.RaceId {
+	return s.raceId
+}
+
+func (s *Stake) RaceDate() types.RaceDate {
+	return s.raceDate
+}
+
+func (s *Stake) RaceCourse() types.RaceCourse {
+	return s.raceCourse
+}
+
+func (s *Stake) RaceNumber() int {
+	return s.raceNumber
+}
+
+func (s *Stake) RaceName() string {
+	return s.raceName
+}
+
+func (s *Stake) TicketType() types.TicketType {
+	return s.ticketType
+}
+
+func (s *Stake) Marker() types.Marker {
+	return s.marker
+}
+
+func (s *Stake) HorseNumber() types.HorseNumber {
+	return s.horseNumber
+}
+
+func (s *Stake) Odds() decimal.Decimal {
+	return s.odds
+}
+
+// HitRate 過去の同じ印・レース条件・オッズ帯での1着率
+func (s *Stake) HitRate() float64 {
+	return s.hitRate
+}
+
+func (s *Stake) SampleCount() int {
+	return s.sampleCount
+}
+
+// ExpectedValue 1着率×オッズ、1を超えれば期待値がプラス
+func (s *Stake) ExpectedValue() float64 {
+	return s.hitRate * s.odds.InexactFloat64()
+}
+
+// Kelly ケリー基準の資金に対する割合
+func (s *Stake) Kelly() float64 {
+	return s.kelly
+}
+
+func (s *Stake) Amount() int {
+	return s.amount
+}
@@ -0,0 +1,75 @@
+package prediction_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+type StakeRule struct {
+	bankroll      int
+	method        types.StakeMethod
+	kellyFraction float64
+	fixedFraction float64
+	flatStake     int
+	raceCap       int
+	dayCap        int
+	minSamples    int
+	unit          int
+}
+
+func NewStakeRule(
+	bankroll int,
+	method types.StakeMethod,
+	kellyFraction float64,
+	fixedFraction float64,
+	flatStake int,
+	raceCap int,
+	dayCap int,
+	minSamples int,
+	unit int,
+) *StakeRule {
+	return &StakeRule{
+		bankroll:      bankroll,
+		method:        method,
+		kellyFraction: kellyFraction,
+		fixedFraction: fixedFraction,
+		flatStake:     flatStake,
+		raceCap:       raceCap,
+		dayCap:        dayCap,
+		minSamples:    minSamples,
+		unit:          unit,
+	}
+}
+
+func (s *StakeRule) Bankroll() int {
+	return s.bankroll
+}
+
+func (s *StakeRule) Method() types.StakeMethod {
+	return s.method
+}
+
+func (s *StakeRule) KellyFraction() float64 {
+	return s.kellyFraction
+}
+
+func (s *StakeRule) FixedFraction() float64 {
+	return s.fixedFraction
+}
+
+func (s *StakeRule) FlatStake() int {
+	return s.flatStake
+}
+
+func (s *StakeRule) RaceCap() int {
+	return s.raceCap
+}
+
+func (s *StakeRule) DayCap() int {
+	return s.dayCap
+}
+
+func (s *StakeRule) MinSamples() int {
+	return s.minSamples
+}
+
+func (s *StakeRule) Unit() int {
+	return s.unit
+}
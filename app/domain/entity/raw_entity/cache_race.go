@@ -0,0 +1,13 @@
+package raw_entity
+
+type CacheRace struct {
+	RaceId       string        `json:"race_id"`
+	RaceDate     int           `json:"race_date"`
+	Race         *Race         `json:"race"`
+	WinOdds      *RaceOdds     `json:"win_odds"`
+	PlaceOdds    *RaceOdds     `json:"place_odds"`
+	QuinellaOdds *RaceOdds     `json:"quinella_odds"`
+	TrioOdds     *RaceOdds     `json:"trio_odds"`
+	RaceTime     *RaceTime     `json:"race_time"`
+	RaceForecast *RaceForecast `json:"race_forecast"`
+}
@@ -0,0 +1,21 @@
+package raw_entity
+
+import "encoding/json"
+
+// CheckpointItem マスタ取得中に取得できた1URL分の結果。ステージごとのjsonlに1行ずつ追記する
+type CheckpointItem struct {
+	Url  string          `json:"url"`
+	Data json.RawMessage `json:"data"`
+}
+
+type RetryQueue struct {
+	Entries []*RetryEntry `json:"entries"`
+}
+
+type RetryEntry struct {
+	Stage    string `json:"stage"`
+	Url      string `json:"url"`
+	Error    string `json:"error"`
+	Attempts int    `json:"attempts"`
+	FailedAt string `json:"failed_at"`
+}
@@ -0,0 +1,13 @@
+package raw_entity
+
+type CommentLexiconInfo struct {
+	Keywords              []*CommentKeyword `json:"keywords"`
+	Negations             []string          `json:"negations"`
+	NegationWindow        int               `json:"negation_window"`
+	PreviousCommentWeight float64           `json:"previous_comment_weight"`
+}
+
+type CommentKeyword struct {
+	Word  string  `json:"word"`
+	Point float64 `json:"point"`
+}
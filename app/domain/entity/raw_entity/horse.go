@@ -17,8 +17,11 @@ type Horse struct {
 }
 
 type HorseBlood struct {
-	SireId          string `json:"sire_id"`
-	BroodmareSireId string `json:"broodmare_sire_id"`
+	SireId            string `json:"sire_id"`
+	SireName          string `json:"sire_name"`
+	BroodmareSireId   string `json:"broodmare_sire_id"`
+	BroodmareSireName string `json:"broodmare_sire_name"`
+	NameFetched       bool   `json:"name_fetched"`
 }
 
 type HorseResult struct {
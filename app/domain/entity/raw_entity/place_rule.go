@@ -0,0 +1,15 @@
+package raw_entity
+
+type PlaceRuleInfo struct {
+	CheckList []*PlaceRule `json:"check_list"`
+	Danger    []*PlaceRule `json:"danger"`
+}
+
+type PlaceRule struct {
+	Name   string             `json:"name"`
+	Kind   string             `json:"kind"`
+	Params map[string]float64 `json:"params"`
+	Values []string           `json:"values"`
+	Negate bool               `json:"negate"`
+	Point  int                `json:"point"`
+}
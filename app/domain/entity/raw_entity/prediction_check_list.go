@@ -0,0 +1,42 @@
+package raw_entity
+
+type PredictionCheckListInfo struct {
+	UpdatedAt            string                 `json:"updated_at"`
+	PredictionCheckLists []*PredictionCheckList `json:"prediction_check_lists"`
+}
+
+type PredictionCheckList struct {
+	RaceId            string   `json:"race_id"`
+	RaceDate          string   `json:"race_date"`
+	RaceName          string   `json:"race_name"`
+	RaceCourse        string   `json:"race_course"`
+	RaceUrl           string   `json:"race_url"`
+	HorseName         string   `json:"horse_name"`
+	HorseUrl          string   `json:"horse_url"`
+	JockeyName        string   `json:"jockey_name"`
+	JockeyUrl         string   `json:"jockey_url"`
+	TrainerName       string   `json:"trainer_name"`
+	TrainerUrl        string   `json:"trainer_url"`
+	LocationName      string   `json:"location_name"`
+	WinOdds           string   `json:"win_odds"`
+	Marker            string   `json:"marker"`
+	FirstPlaceRate    string   `json:"first_place_rate"`
+	SecondPlaceRate   string   `json:"second_place_rate"`
+	ThirdPlaceRate    string   `json:"third_place_rate"`
+	CheckList         []string `json:"check_list"`
+	CheckListNames    []string `json:"check_list_names"`
+	Contributions     []string `json:"contributions"`
+	PlaceProbability  string   `json:"place_probability"`
+	PositivePoint     string   `json:"positive_point"`
+	NegativePoint     string   `json:"negative_point"`
+	FavoriteNum       int      `json:"favorite_num"`
+	RivalNum          int      `json:"rival_num"`
+	MarkerNum         int      `json:"marker_num"`
+	HighlyRecommended string   `json:"highly_recommended"`
+	CommentScore      string   `json:"comment_score"`
+	TrainingComment   string   `json:"training_comment"`
+	ReporterMemo      string   `json:"reporter_memo"`
+	PaddockComment    string   `json:"paddock_comment"`
+	PaddockEvaluation string   `json:"paddock_evaluation"`
+	PaperUrl          string   `json:"paper_url"`
+}
@@ -25,20 +25,25 @@ type Race struct {
 	RaceAgeCondition      int             `json:"race_age_condition"`
 	RaceResults           []*RaceResult   `json:"race_results"`
 	PayoutResults         []*PayoutResult `json:"payout_results"`
+	TrainerFetched        bool            `json:"trainer_fetched"`
 }
 
 type RaceResult struct {
-	OrderNo        int    `json:"order_no"`
-	HorseId        string `json:"horse_id"`
-	HorseName      string `json:"horse_name"`
-	BracketNumber  int    `json:"bracket_number"`
-	HorseNumber    int    `json:"horse_number"`
-	JockeyId       string `json:"jockey_id"`
-	Odds           string `json:"odds"`
-	PopularNumber  int    `json:"popular_number"`
-	JockeyWeight   string `json:"jockey_weight"`
-	HorseWeight    int    `json:"horse_weight"`
-	HorseWeightAdd int    `json:"horse_weight_add"`
+	OrderNo            int    `json:"order_no"`
+	HorseId            string `json:"horse_id"`
+	HorseName          string `json:"horse_name"`
+	BracketNumber      int    `json:"bracket_number"`
+	HorseNumber        int    `json:"horse_number"`
+	JockeyId           string `json:"jockey_id"`
+	Odds               string `json:"odds"`
+	PopularNumber      int    `json:"popular_number"`
+	JockeyWeight       string `json:"jockey_weight"`
+	HorseWeight        int    `json:"horse_weight"`
+	HorseWeightAdd     int    `json:"horse_weight_add"`
+	CornerPassingOrder string `json:"corner_passing_order"`
+	LastThreeFurlong   string `json:"last_three_furlong"`
+	Margin             string `json:"margin"`
+	TrainerId          string `json:"trainer_id"`
 }
 
 type PayoutResult struct {
@@ -5,19 +5,28 @@ type RaceForecastInfo struct {
 }
 
 type RaceForecast struct {
-	RaceId    string      `json:"race_id"`
-	RaceDate  int         `json:"race_date"`
-	Forecasts []*Forecast `json:"forecasts"`
+	RaceId         string      `json:"race_id"`
+	RaceDate       int         `json:"race_date"`
+	Forecasts      []*Forecast `json:"forecasts"`
+	PaddockFetched bool        `json:"paddock_fetched"`
 }
 
 type Forecast struct {
-	HorseNumber             int    `json:"horse_number"`
-	TrainingComment         string `json:"training_comment"`
-	PreviousTrainingComment string `json:"previous_training_comment"`
-	HighlyRecommended       bool   `json:"highly_recommended"`
-	FavoriteNum             int    `json:"favorite_num"`
-	RivalNum                int    `json:"rival_num"`
-	MarkerNum               int    `json:"marker_num"`
+	HorseNumber             int                     `json:"horse_number"`
+	TrainingComment         string                  `json:"training_comment"`
+	PreviousTrainingComment string                  `json:"previous_training_comment"`
+	HighlyRecommended       bool                    `json:"highly_recommended"`
+	FavoriteNum             int                     `json:"favorite_num"`
+	RivalNum                int                     `json:"rival_num"`
+	MarkerNum               int                     `json:"marker_num"`
+	ReporterMemos           []*ForecastReporterMemo `json:"reporter_memos,omitempty"`
+	PaddockComment          string                  `json:"paddock_comment,omitempty"`
+	PaddockEvaluation       int                     `json:"paddock_evaluation,omitempty"`
+}
+
+type ForecastReporterMemo struct {
+	Date    string `json:"date"`
+	Comment string `json:"comment"`
 }
 
 type ForecastInfo struct {
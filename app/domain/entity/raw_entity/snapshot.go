@@ -0,0 +1,21 @@
+package raw_entity
+
+// Snapshot スプレッドシートに書き出した集計結果を実行ごとに保存したもの
+type Snapshot struct {
+	Sheet     string            `json:"sheet"`
+	CreatedAt string            `json:"created_at"`
+	Buckets   []*SnapshotBucket `json:"buckets"`
+}
+
+// SnapshotBucket シートの1行(印×フィルタなど)分の数値
+type SnapshotBucket struct {
+	Key     string            `json:"key"`
+	Metrics []*SnapshotMetric `json:"metrics"`
+}
+
+type SnapshotMetric struct {
+	Name  string  `json:"name"`
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+	Count int     `json:"count"`
+}
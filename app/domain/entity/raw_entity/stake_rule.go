@@ -0,0 +1,13 @@
+package raw_entity
+
+type StakeRuleInfo struct {
+	Bankroll      int     `json:"bankroll"`
+	Method        string  `json:"method"`
+	KellyFraction float64 `json:"kelly_fraction"`
+	FixedFraction float64 `json:"fixed_fraction"`
+	FlatStake     int     `json:"flat_stake"`
+	RaceCap       int     `json:"race_cap"`
+	DayCap        int     `json:"day_cap"`
+	MinSamples    int     `json:"min_samples"`
+	Unit          int     `json:"unit"`
+}
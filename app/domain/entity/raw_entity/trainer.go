@@ -0,0 +1,11 @@
+package raw_entity
+
+type TrainerInfo struct {
+	Trainers []*Trainer `json:"trainers"`
+}
+
+type Trainer struct {
+	TrainerId    string `json:"trainer_id"`
+	TrainerName  string `json:"trainer_name"`
+	LocationName string `json:"location_name"`
+}
@@ -0,0 +1,94 @@
+package report_entity
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+// Bankroll 期間中の資金の推移
+type Bankroll struct {
+	startBalance int
+	days         []*BankrollDay
+}
+
+func NewBankroll(
+	startBalance int,
+	days []*BankrollDay,
+) *Bankroll {
+	return &Bankroll{
+		startBalance: startBalance,
+		days:         days,
+	}
+}
+
+// StartBalance 期間の開始時点の残高(初期資金+それまでの通算収支)
+func (b *Bankroll) StartBalance() int {
+	return b.startBalance
+}
+
+func (b *Bankroll) EndBalance() int {
+	if len(b.days) == 0 {
+		return b.startBalance
+	}
+	return b.days[len(b.days)-1].balance
+}
+
+func (b *Bankroll) Days() []*BankrollDay {
+	return b.days
+}
+
+// MaxDrawdown 期間中の残高の最高値からの最大の落ち込み
+func (b *Bankroll) MaxDrawdown() int {
+	peak := b.startBalance
+	var maxDrawdown int
+	for _, day := range b.days {
+		if day.balance > peak {
+			peak = day.balance
+		}
+		if peak-day.balance > maxDrawdown {
+			maxDrawdown = peak - day.balance
+		}
+	}
+	return maxDrawdown
+}
+
+// BankrollDay 開催日ごとの収支と終了時点の残高
+type BankrollDay struct {
+	raceDate types.RaceDate
+	payment  int
+	payout   int
+	balance  int
+}
+
+func NewBankrollDay(
+	raceDate types.RaceDate,
+	payment int,
+	payout int,
+	balance int,
+) *BankrollDay {
+	return &BankrollDay{
+		raceDate: raceDate,
+		payment:  payment,
+		payout:   payout,
+		balance:  balance,
+	}
+}
+
+func (b *BankrollDay) RaceDate() types.RaceDate {
+	return b.raceDate
+}
+
+func (b *BankrollDay) Payment() int {
+	return b.payment
+}
+
+func (b *BankrollDay) Payout() int {
+	return b.payout
+}
+
+func (b *BankrollDay) Profit() int {
+	return b.payout - b.payment
+}
+
+func (b *BankrollDay) Balance() int {
+	return b.balance
+}
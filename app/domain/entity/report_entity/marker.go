@@ -0,0 +1,109 @@
+package report_entity
+
+import (
+	"fmt"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+// Marker 期間内の印ごとの成績と全期間の成績
+type Marker struct {
+	marker        types.Marker
+	raceCount     int
+	winCount      int
+	placeCount    int
+	winPayout     int
+	allRaceCount  int
+	allWinCount   int
+	allPlaceCount int
+	allWinPayout  int
+}
+
+func NewMarker(
+	marker types.Marker,
+	raceCount int,
+	winCount int,
+	placeCount int,
+	winPayout int,
+	allRaceCount int,
+	allWinCount int,
+	allPlaceCount int,
+	allWinPayout int,
+) *Marker {
+	return &Marker{
+		marker:        marker,
+		raceCount:     raceCount,
+		winCount:      winCount,
+		placeCount:    placeCount,
+		winPayout:     winPayout,
+		allRaceCount:  allRaceCount,
+		allWinCount:   allWinCount,
+		allPlaceCount: allPlaceCount,
+		allWinPayout:  allWinPayout,
+	}
+}
+
+func (m *Marker) Marker() types.Marker {
+	return m.marker
+}
+
+func (m *Marker) RaceCount() int {
+	return m.raceCount
+}
+
+func (m *Marker) WinCount() int {
+	return m.winCount
+}
+
+func (m *Marker) PlaceCount() int {
+	return m.placeCount
+}
+
+func (m *Marker) WinRate() string {
+	return rateFormat(m.winCount, m.raceCount)
+}
+
+func (m *Marker) PlaceRate() string {
+	return rateFormat(m.placeCount, m.raceCount)
+}
+
+// WinPayoutRate 印の馬の単勝を100円ずつ買った場合の回収率
+func (m *Marker) WinPayoutRate() string {
+	return rateFormat(m.winPayout, m.raceCount*100)
+}
+
+func (m *Marker) AllWinRate() string {
+	return rateFormat(m.allWinCount, m.allRaceCount)
+}
+
+func (m *Marker) AllPlaceRate() string {
+	return rateFormat(m.allPlaceCount, m.allRaceCount)
+}
+
+func (m *Marker) AllWinPayoutRate() string {
+	return rateFormat(m.allWinPayout, m.allRaceCount*100)
+}
+
+// PlaceRateDiff 期間の複勝率と全期間の複勝率の差(ポイント)
+func (m *Marker) PlaceRateDiff() string {
+	if m.raceCount == 0 || m.allRaceCount == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f", (float64(m.placeCount)/float64(m.raceCount)-float64(m.allPlaceCount)/float64(m.allRaceCount))*100)
+}
+
+// Highlighted 期間の複勝率が全期間より10ポイント以上離れている
+func (m *Marker) Highlighted() bool {
+	if m.raceCount == 0 || m.allRaceCount == 0 {
+		return false
+	}
+	diff := float64(m.placeCount)/float64(m.raceCount) - float64(m.allPlaceCount)/float64(m.allRaceCount)
+	return diff >= 0.1 || diff <= -0.1
+}
+
+func rateFormat(numerator, denominator int) string {
+	if denominator == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(numerator)*100/float64(denominator))
+}
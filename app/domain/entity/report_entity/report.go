@@ -0,0 +1,141 @@
+package report_entity
+
+import (
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+// Report 週間・月間レポートに載せる内容
+type Report struct {
+	period            types.ReportPeriod
+	dateFrom          time.Time
+	dateTo            time.Time
+	account           types.Account
+	generatedAt       time.Time
+	result            *spreadsheet_entity.TicketResult
+	previousResult    *spreadsheet_entity.TicketResult
+	ticketTypeResults []*Result
+	raceCourseResults []*Result
+	bestRaces         []*spreadsheet_entity.ListData
+	worstRaces        []*spreadsheet_entity.ListData
+	markers           []*Marker
+	bankroll          *Bankroll
+}
+
+func NewReport(
+	period types.ReportPeriod,
+	dateFrom time.Time,
+	dateTo time.Time,
+	account types.Account,
+	generatedAt time.Time,
+	result *spreadsheet_entity.TicketResult,
+	previousResult *spreadsheet_entity.TicketResult,
+	ticketTypeResults []*Result,
+	raceCourseResults []*Result,
+	bestRaces []*spreadsheet_entity.ListData,
+	worstRaces []*spreadsheet_entity.ListData,
+	markers []*Marker,
+	bankroll *Bankroll,
+) *Report {
+	return &Report{
+		period:            period,
+		dateFrom:          dateFrom,
+		dateTo:            dateTo,
+		account:           account,
+		generatedAt:       generatedAt,
+		result:            result,
+		previousResult:    previousResult,
+		ticketTypeResults: ticketTypeResults,
+		raceCourseResults: raceCourseResults,
+		bestRaces:         bestRaces,
+		worstRaces:        worstRaces,
+		markers:           markers,
+		bankroll:          bankroll,
+	}
+}
+
+func (r *Report) Period() types.ReportPeriod {
+	return r.period
+}
+
+// DateFrom 集計期間の初日
+func (r *Report) DateFrom() time.Time {
+	return r.dateFrom
+}
+
+// DateTo 集計期間の翌日(この日は含まない)
+func (r *Report) DateTo() time.Time {
+	return r.dateTo
+}
+
+// LastDate 集計期間の最終日
+func (r *Report) LastDate() time.Time {
+	return r.dateTo.AddDate(0, 0, -1)
+}
+
+func (r *Report) Account() types.Account {
+	return r.account
+}
+
+func (r *Report) GeneratedAt() time.Time {
+	return r.generatedAt
+}
+
+func (r *Report) Result() *spreadsheet_entity.TicketResult {
+	return r.result
+}
+
+// PreviousResult 直前の同じ長さの期間の結果
+func (r *Report) PreviousResult() *spreadsheet_entity.TicketResult {
+	return r.previousResult
+}
+
+func (r *Report) TicketTypeResults() []*Result {
+	return r.ticketTypeResults
+}
+
+func (r *Report) RaceCourseResults() []*Result {
+	return r.raceCourseResults
+}
+
+func (r *Report) BestRaces() []*spreadsheet_entity.ListData {
+	return r.bestRaces
+}
+
+func (r *Report) WorstRaces() []*spreadsheet_entity.ListData {
+	return r.worstRaces
+}
+
+func (r *Report) Markers() []*Marker {
+	return r.markers
+}
+
+func (r *Report) Bankroll() *Bankroll {
+	return r.bankroll
+}
+
+// Result 区分ごとの収支
+type Result struct {
+	label  string
+	result *spreadsheet_entity.TicketResult
+}
+
+func NewResult(
+	label string,
+	result *spreadsheet_entity.TicketResult,
+) *Result {
+	return &Result{
+		label:  label,
+		result: result,
+	}
+}
+
+func (r *Result) Label() string {
+	return r.label
+}
+
+func (r *Result) Result() *spreadsheet_entity.TicketResult {
+	return r.result
+}
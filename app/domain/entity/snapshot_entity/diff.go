@@ -0,0 +1,112 @@
+package snapshot_entity
+
+import (
+	"math"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+// SheetDiff シートごとに比較したスナップショットと大きく動いた数値
+type SheetDiff struct {
+	sheet types.SnapshotSheet
+	from  string
+	to    string
+	diffs []*Diff
+}
+
+func NewSheetDiff(
+	sheet types.SnapshotSheet,
+	from string,
+	to string,
+	diffs []*Diff,
+) *SheetDiff {
+	return &SheetDiff{
+		sheet: sheet,
+		from:  from,
+		to:    to,
+		diffs: diffs,
+	}
+}
+
+func (s *SheetDiff) Sheet() types.SnapshotSheet {
+	return s.sheet
+}
+
+func (s *SheetDiff) From() string {
+	return s.from
+}
+
+func (s *SheetDiff) To() string {
+	return s.to
+}
+
+func (s *SheetDiff) Diffs() []*Diff {
+	return s.diffs
+}
+
+// Diff バケット(シートの1行)の1項目分の変化
+type Diff struct {
+	key       string
+	metric    string
+	unit      string
+	fromValue float64
+	toValue   float64
+	fromCount int
+	toCount   int
+}
+
+func NewDiff(
+	key string,
+	metric string,
+	unit string,
+	fromValue float64,
+	toValue float64,
+	fromCount int,
+	toCount int,
+) *Diff {
+	return &Diff{
+		key:       key,
+		metric:    metric,
+		unit:      unit,
+		fromValue: fromValue,
+		toValue:   toValue,
+		fromCount: fromCount,
+		toCount:   toCount,
+	}
+}
+
+func (d *Diff) Key() string {
+	return d.key
+}
+
+func (d *Diff) Metric() string {
+	return d.metric
+}
+
+func (d *Diff) Unit() string {
+	return d.unit
+}
+
+func (d *Diff) FromValue() float64 {
+	return d.fromValue
+}
+
+func (d *Diff) ToValue() float64 {
+	return d.toValue
+}
+
+func (d *Diff) FromCount() int {
+	return d.fromCount
+}
+
+func (d *Diff) ToCount() int {
+	return d.toCount
+}
+
+func (d *Diff) Delta() float64 {
+	return d.toValue - d.fromValue
+}
+
+func (d *Diff) AbsDelta() float64 {
+	return math.Abs(d.Delta())
+}
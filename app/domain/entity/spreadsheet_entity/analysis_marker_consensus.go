@@ -0,0 +1,94 @@
+package spreadsheet_entity
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+)
+
+type AnalysisMarkerConsensus struct {
+	attributeId     filter.AttributeId
+	consensus       types.MarkerConsensus
+	raceCount       int
+	raceRate        string
+	winCount        int
+	placeCount      int
+	winRate         string
+	placeRate       string
+	winPayoutRate   string
+	placePayoutRate string
+	isProfitable    bool
+}
+
+func NewAnalysisMarkerConsensus(
+	attributeId filter.AttributeId,
+	consensus types.MarkerConsensus,
+	raceCount int,
+	raceRate string,
+	winCount int,
+	placeCount int,
+	winRate string,
+	placeRate string,
+	winPayoutRate string,
+	placePayoutRate string,
+	isProfitable bool,
+) *AnalysisMarkerConsensus {
+	return &AnalysisMarkerConsensus{
+		attributeId:     attributeId,
+		consensus:       consensus,
+		raceCount:       raceCount,
+		raceRate:        raceRate,
+		winCount:        winCount,
+		placeCount:      placeCount,
+		winRate:         winRate,
+		placeRate:       placeRate,
+		winPayoutRate:   winPayoutRate,
+		placePayoutRate: placePayoutRate,
+		isProfitable:    isProfitable,
+	}
+}
+
+func (a *AnalysisMarkerConsensus) AttributeId() filter.AttributeId {
+	return a.attributeId
+}
+
+func (a *AnalysisMarkerConsensus) Consensus() types.MarkerConsensus {
+	return a.consensus
+}
+
+func (a *AnalysisMarkerConsensus) RaceCount() int {
+	return a.raceCount
+}
+
+// RaceRate 同じ条件のレースに占める割合、一致の場合は一致率になる
+func (a *AnalysisMarkerConsensus) RaceRate() string {
+	return a.raceRate
+}
+
+func (a *AnalysisMarkerConsensus) WinCount() int {
+	return a.winCount
+}
+
+func (a *AnalysisMarkerConsensus) PlaceCount() int {
+	return a.placeCount
+}
+
+func (a *AnalysisMarkerConsensus) WinRate() string {
+	return a.winRate
+}
+
+func (a *AnalysisMarkerConsensus) PlaceRate() string {
+	return a.placeRate
+}
+
+func (a *AnalysisMarkerConsensus) WinPayoutRate() string {
+	return a.winPayoutRate
+}
+
+func (a *AnalysisMarkerConsensus) PlacePayoutRate() string {
+	return a.placePayoutRate
+}
+
+// IsProfitable 単勝か複勝の回収率が100%を超えている
+func (a *AnalysisMarkerConsensus) IsProfitable() bool {
+	return a.isProfitable
+}
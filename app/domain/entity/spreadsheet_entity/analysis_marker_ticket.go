@@ -0,0 +1,73 @@
+package spreadsheet_entity
+
+type AnalysisMarkerTicket struct {
+	category       string
+	ticketTypeName string
+	markerName     string
+	betCount       int
+	hitCount       int
+	payment        int
+	payout         int
+	hitRate        string
+	payoutRate     string
+}
+
+func NewAnalysisMarkerTicket(
+	category string,
+	ticketTypeName string,
+	markerName string,
+	betCount int,
+	hitCount int,
+	payment int,
+	payout int,
+	hitRate string,
+	payoutRate string,
+) *AnalysisMarkerTicket {
+	return &AnalysisMarkerTicket{
+		category:       category,
+		ticketTypeName: ticketTypeName,
+		markerName:     markerName,
+		betCount:       betCount,
+		hitCount:       hitCount,
+		payment:        payment,
+		payout:         payout,
+		hitRate:        hitRate,
+		payoutRate:     payoutRate,
+	}
+}
+
+func (a *AnalysisMarkerTicket) Category() string {
+	return a.category
+}
+
+func (a *AnalysisMarkerTicket) TicketTypeName() string {
+	return a.ticketTypeName
+}
+
+func (a *AnalysisMarkerTicket) MarkerName() string {
+	return a.markerName
+}
+
+func (a *AnalysisMarkerTicket) BetCount() int {
+	return a.betCount
+}
+
+func (a *AnalysisMarkerTicket) HitCount() int {
+	return a.hitCount
+}
+
+func (a *AnalysisMarkerTicket) Payment() int {
+	return a.payment
+}
+
+func (a *AnalysisMarkerTicket) Payout() int {
+	return a.payout
+}
+
+func (a *AnalysisMarkerTicket) HitRate() string {
+	return a.hitRate
+}
+
+func (a *AnalysisMarkerTicket) PayoutRate() string {
+	return a.payoutRate
+}
@@ -0,0 +1,106 @@
+package spreadsheet_entity
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+)
+
+type AnalysisPedigree struct {
+	pedigreeLine    types.PedigreeLine
+	sireId          types.HorseId
+	sireName        string
+	attributeId     filter.AttributeId
+	isFirstDirt     bool
+	raceCount       int
+	winCount        int
+	placeCount      int
+	winRate         string
+	placeRate       string
+	winPayoutRate   string
+	placePayoutRate string
+	isStrong        bool
+}
+
+func NewAnalysisPedigree(
+	pedigreeLine types.PedigreeLine,
+	sireId types.HorseId,
+	sireName string,
+	attributeId filter.AttributeId,
+	isFirstDirt bool,
+	raceCount int,
+	winCount int,
+	placeCount int,
+	winRate string,
+	placeRate string,
+	winPayoutRate string,
+	placePayoutRate string,
+	isStrong bool,
+) *AnalysisPedigree {
+	return &AnalysisPedigree{
+		pedigreeLine:    pedigreeLine,
+		sireId:          sireId,
+		sireName:        sireName,
+		attributeId:     attributeId,
+		isFirstDirt:     isFirstDirt,
+		raceCount:       raceCount,
+		winCount:        winCount,
+		placeCount:      placeCount,
+		winRate:         winRate,
+		placeRate:       placeRate,
+		winPayoutRate:   winPayoutRate,
+		placePayoutRate: placePayoutRate,
+		isStrong:        isStrong,
+	}
+}
+
+func (a *AnalysisPedigree) PedigreeLine() types.PedigreeLine {
+	return a.pedigreeLine
+}
+
+func (a *AnalysisPedigree) SireId() types.HorseId {
+	return a.sireId
+}
+
+func (a *AnalysisPedigree) SireName() string {
+	return a.sireName
+}
+
+func (a *AnalysisPedigree) AttributeId() filter.AttributeId {
+	return a.attributeId
+}
+
+func (a *AnalysisPedigree) IsFirstDirt() bool {
+	return a.isFirstDirt
+}
+
+func (a *AnalysisPedigree) RaceCount() int {
+	return a.raceCount
+}
+
+func (a *AnalysisPedigree) WinCount() int {
+	return a.winCount
+}
+
+func (a *AnalysisPedigree) PlaceCount() int {
+	return a.placeCount
+}
+
+func (a *AnalysisPedigree) WinRate() string {
+	return a.winRate
+}
+
+func (a *AnalysisPedigree) PlaceRate() string {
+	return a.placeRate
+}
+
+func (a *AnalysisPedigree) WinPayoutRate() string {
+	return a.winPayoutRate
+}
+
+func (a *AnalysisPedigree) PlacePayoutRate() string {
+	return a.placePayoutRate
+}
+
+func (a *AnalysisPedigree) IsStrong() bool {
+	return a.isStrong
+}
@@ -0,0 +1,67 @@
+package spreadsheet_entity
+
+// AnalysisPlaceRate 区分×値×印ごとの勝率と複勝率。パドック評価やコメントスコアのシートで使う
+type AnalysisPlaceRate struct {
+	category   string
+	value      string
+	markerName string
+	raceCount  int
+	winCount   int
+	placeCount int
+	winRate    string
+	placeRate  string
+}
+
+func NewAnalysisPlaceRate(
+	category string,
+	value string,
+	markerName string,
+	raceCount int,
+	winCount int,
+	placeCount int,
+	winRate string,
+	placeRate string,
+) *AnalysisPlaceRate {
+	return &AnalysisPlaceRate{
+		category:   category,
+		value:      value,
+		markerName: markerName,
+		raceCount:  raceCount,
+		winCount:   winCount,
+		placeCount: placeCount,
+		winRate:    winRate,
+		placeRate:  placeRate,
+	}
+}
+
+func (a *AnalysisPlaceRate) Category() string {
+	return a.category
+}
+
+func (a *AnalysisPlaceRate) Value() string {
+	return a.value
+}
+
+func (a *AnalysisPlaceRate) MarkerName() string {
+	return a.markerName
+}
+
+func (a *AnalysisPlaceRate) RaceCount() int {
+	return a.raceCount
+}
+
+func (a *AnalysisPlaceRate) WinCount() int {
+	return a.winCount
+}
+
+func (a *AnalysisPlaceRate) PlaceCount() int {
+	return a.placeCount
+}
+
+func (a *AnalysisPlaceRate) WinRate() string {
+	return a.winRate
+}
+
+func (a *AnalysisPlaceRate) PlaceRate() string {
+	return a.placeRate
+}
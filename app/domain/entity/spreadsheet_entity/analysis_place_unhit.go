@@ -40,6 +40,9 @@ type AnalysisPlaceUnhit struct {
 	quinellaWheelAverageOdds  decimal.Decimal
 	trioFavoriteCount         int
 	trainingComment           string
+	runningStyle              types.RunningStyle
+	dangerPoint               int
+	dangerItems               []string
 }
 
 func NewAnalysisPlaceUnhit(
@@ -74,7 +77,16 @@ func NewAnalysisPlaceUnhit(
 	quinellaCombinationTotalOdds decimal.Decimal,
 	trioFavoriteCount int,
 	trainingComment string,
+	runningStyle types.RunningStyle,
+	dangerCheckPoints []*AnalysisPlaceCheckPoint,
 ) *AnalysisPlaceUnhit {
+	dangerPoint := 0
+	dangerItems := make([]string, 0, len(dangerCheckPoints))
+	for _, checkPoint := range dangerCheckPoints {
+		dangerPoint += checkPoint.Point()
+		dangerItems = append(dangerItems, checkPoint.ItemName())
+	}
+
 	return &AnalysisPlaceUnhit{
 		raceId:              raceId,
 		raceUrl:             fmt.Sprintf("https://race.netkeiba.com/race/shutuba.html?race_id=%s", raceId.String()),
@@ -113,6 +125,9 @@ func NewAnalysisPlaceUnhit(
 		quinellaWheelAverageOdds:  quinellaCombinationTotalOdds.Div(decimal.NewFromInt(int64(entries - 1))),
 		trioFavoriteCount:         trioFavoriteCount,
 		trainingComment:           trainingComment,
+		runningStyle:              runningStyle,
+		dangerPoint:               dangerPoint,
+		dangerItems:               dangerItems,
 	}
 }
 
@@ -243,3 +258,15 @@ func (a *AnalysisPlaceUnhit) TrioFavoriteCount() int {
 func (a *AnalysisPlaceUnhit) TrainingComment() string {
 	return a.trainingComment
 }
+
+func (a *AnalysisPlaceUnhit) RunningStyle() types.RunningStyle {
+	return a.runningStyle
+}
+
+func (a *AnalysisPlaceUnhit) DangerPoint() int {
+	return a.dangerPoint
+}
+
+func (a *AnalysisPlaceUnhit) DangerItems() []string {
+	return a.dangerItems
+}
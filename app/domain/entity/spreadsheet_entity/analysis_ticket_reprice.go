@@ -0,0 +1,115 @@
+package spreadsheet_entity
+
+type AnalysisTicketReprice struct {
+	periodName            string
+	ticketTypeName        string
+	raceCount             int
+	payment               int
+	payout                int
+	payoutRate            string
+	alternativeTicketName string
+	alternativeRaceCount  int
+	alternativePoints     int
+	alternativePayment    int
+	alternativePayout     int
+	alternativeHitRate    string
+	alternativePayoutRate string
+	payoutRateDiff        string
+	isImproved            bool
+}
+
+func NewAnalysisTicketReprice(
+	periodName string,
+	ticketTypeName string,
+	raceCount int,
+	payment int,
+	payout int,
+	payoutRate string,
+	alternativeTicketName string,
+	alternativeRaceCount int,
+	alternativePoints int,
+	alternativePayment int,
+	alternativePayout int,
+	alternativeHitRate string,
+	alternativePayoutRate string,
+	payoutRateDiff string,
+	isImproved bool,
+) *AnalysisTicketReprice {
+	return &AnalysisTicketReprice{
+		periodName:            periodName,
+		ticketTypeName:        ticketTypeName,
+		raceCount:             raceCount,
+		payment:               payment,
+		payout:                payout,
+		payoutRate:            payoutRate,
+		alternativeTicketName: alternativeTicketName,
+		alternativeRaceCount:  alternativeRaceCount,
+		alternativePoints:     alternativePoints,
+		alternativePayment:    alternativePayment,
+		alternativePayout:     alternativePayout,
+		alternativeHitRate:    alternativeHitRate,
+		alternativePayoutRate: alternativePayoutRate,
+		payoutRateDiff:        payoutRateDiff,
+		isImproved:            isImproved,
+	}
+}
+
+func (a *AnalysisTicketReprice) PeriodName() string {
+	return a.periodName
+}
+
+func (a *AnalysisTicketReprice) TicketTypeName() string {
+	return a.ticketTypeName
+}
+
+func (a *AnalysisTicketReprice) RaceCount() int {
+	return a.raceCount
+}
+
+func (a *AnalysisTicketReprice) Payment() int {
+	return a.payment
+}
+
+func (a *AnalysisTicketReprice) Payout() int {
+	return a.payout
+}
+
+func (a *AnalysisTicketReprice) PayoutRate() string {
+	return a.payoutRate
+}
+
+func (a *AnalysisTicketReprice) AlternativeTicketName() string {
+	return a.alternativeTicketName
+}
+
+func (a *AnalysisTicketReprice) AlternativeRaceCount() int {
+	return a.alternativeRaceCount
+}
+
+func (a *AnalysisTicketReprice) AlternativePoints() int {
+	return a.alternativePoints
+}
+
+func (a *AnalysisTicketReprice) AlternativePayment() int {
+	return a.alternativePayment
+}
+
+func (a *AnalysisTicketReprice) AlternativePayout() int {
+	return a.alternativePayout
+}
+
+func (a *AnalysisTicketReprice) AlternativeHitRate() string {
+	return a.alternativeHitRate
+}
+
+func (a *AnalysisTicketReprice) AlternativePayoutRate() string {
+	return a.alternativePayoutRate
+}
+
+func (a *AnalysisTicketReprice) PayoutRateDiff() string {
+	return a.payoutRateDiff
+}
+
+func (a *AnalysisTicketReprice) IsImproved() bool {
+	return a.isImproved
+}
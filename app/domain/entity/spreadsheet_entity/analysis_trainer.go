@@ -0,0 +1,91 @@
+package spreadsheet_entity
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+type AnalysisTrainer struct {
+	trainerId       types.TrainerId
+	trainerName     string
+	locationId      types.LocationId
+	condition       string
+	raceCount       int
+	winCount        int
+	placeCount      int
+	winRate         string
+	placeRate       string
+	winPayoutRate   string
+	placePayoutRate string
+}
+
+func NewAnalysisTrainer(
+	trainerId types.TrainerId,
+	trainerName string,
+	locationId types.LocationId,
+	condition string,
+	raceCount int,
+	winCount int,
+	placeCount int,
+	winRate string,
+	placeRate string,
+	winPayoutRate string,
+	placePayoutRate string,
+) *AnalysisTrainer {
+	return &AnalysisTrainer{
+		trainerId:       trainerId,
+		trainerName:     trainerName,
+		locationId:      locationId,
+		condition:       condition,
+		raceCount:       raceCount,
+		winCount:        winCount,
+		placeCount:      placeCount,
+		winRate:         winRate,
+		placeRate:       placeRate,
+		winPayoutRate:   winPayoutRate,
+		placePayoutRate: placePayoutRate,
+	}
+}
+
+func (a *AnalysisTrainer) TrainerId() types.TrainerId {
+	return a.trainerId
+}
+
+func (a *AnalysisTrainer) TrainerName() string {
+	return a.trainerName
+}
+
+func (a *AnalysisTrainer) LocationId() types.LocationId {
+	return a.locationId
+}
+
+func (a *AnalysisTrainer) Condition() string {
+	return a.condition
+}
+
+func (a *AnalysisTrainer) RaceCount() int {
+	return a.raceCount
+}
+
+func (a *AnalysisTrainer) WinCount() int {
+	return a.winCount
+}
+
+func (a *AnalysisTrainer) PlaceCount() int {
+	return a.placeCount
+}
+
+func (a *AnalysisTrainer) WinRate() string {
+	return a.winRate
+}
+
+func (a *AnalysisTrainer) PlaceRate() string {
+	return a.placeRate
+}
+
+func (a *AnalysisTrainer) WinPayoutRate() string {
+	return a.winPayoutRate
+}
+
+func (a *AnalysisTrainer) PlacePayoutRate() string {
+	return a.placePayoutRate
+}
@@ -27,10 +27,16 @@ type PredictionCheckList struct {
 	secondPlaceRate   string
 	thirdPlaceRate    string
 	checkList         []string
+	checkListNames    []string
+	contributions     []string
+	placeProbability  string
+	positivePoint     string
+	negativePoint     string
 	favoriteNum       int
 	rivalNum          int
 	markerNum         int
 	highlyRecommended string
+	commentScore      string
 	trainingComment   string
 	reporterMemo      string
 	paddockComment    string
@@ -57,10 +63,17 @@ func NewPredictionCheckList(
 	secondPlaceRate string,
 	thirdPlaceRate string,
 	checkList []bool,
+	checkListNames []string,
+	contributions []float64,
+	placeProbability float64,
+	positivePoint float64,
+	negativePoint float64,
 	favoriteNum int,
 	rivalNum int,
 	markerNum int,
 	highlyRecommended bool,
+	commentScore float64,
+	hasComment bool,
 	trainingComment string,
 	reporterMemos []string,
 	paddockComment string,
@@ -75,6 +88,18 @@ func NewPredictionCheckList(
 		}
 	}
 
+	// 重みが学習できていない場合は寄与度を出さない
+	contributionsFormat := make([]string, len(checkList))
+	placeProbabilityFormat, positivePointFormat, negativePointFormat := "-", "-", "-"
+	if len(contributions) == len(checkList) {
+		for idx, contribution := range contributions {
+			contributionsFormat[idx] = fmt.Sprintf("%+.2f", contribution)
+		}
+		placeProbabilityFormat = fmt.Sprintf("%.2f%%", placeProbability*100)
+		positivePointFormat = fmt.Sprintf("%+.2f", positivePoint)
+		negativePointFormat = fmt.Sprintf("%+.2f", negativePoint)
+	}
+
 	var highlyRecommendedFormat string
 	if highlyRecommended {
 		highlyRecommendedFormat = "◯"
@@ -82,6 +107,11 @@ func NewPredictionCheckList(
 		highlyRecommendedFormat = "-"
 	}
 
+	commentScoreFormat := "-"
+	if hasComment {
+		commentScoreFormat = fmt.Sprintf("%+.1f", commentScore)
+	}
+
 	reporterMemo := ""
 	if len(reporterMemos) > 0 {
 		reporterMemo = strings.Join(reporterMemos, "\n")
@@ -118,10 +148,16 @@ func NewPredictionCheckList(
 		secondPlaceRate:   secondPlaceRate,
 		thirdPlaceRate:    thirdPlaceRate,
 		checkList:         checkListFormat,
+		checkListNames:    checkListNames,
+		contributions:     contributionsFormat,
+		placeProbability:  placeProbabilityFormat,
+		positivePoint:     positivePointFormat,
+		negativePoint:     negativePointFormat,
 		favoriteNum:       favoriteNum,
 		rivalNum:          rivalNum,
 		markerNum:         markerNum,
 		highlyRecommended: highlyRecommendedFormat,
+		commentScore:      commentScoreFormat,
 		trainingComment:   trainingComment,
 		reporterMemo:      reporterMemo,
 		paddockComment:    paddockComment,
@@ -202,6 +238,28 @@ func (p *PredictionCheckList) CheckList() []string {
 	return p.checkList
 }
 
+// CheckListNames ルールファイルに定義されたチェック項目名、CheckListと同じ並び
+func (p *PredictionCheckList) CheckListNames() []string {
+	return p.checkListNames
+}
+
+// Contributions チェック項目ごとの複勝圏内スコアへの寄与度
+func (p *PredictionCheckList) Contributions() []string {
+	return p.contributions
+}
+
+func (p *PredictionCheckList) PlaceProbability() string {
+	return p.placeProbability
+}
+
+func (p *PredictionCheckList) PositivePoint() string {
+	return p.positivePoint
+}
+
+func (p *PredictionCheckList) NegativePoint() string {
+	return p.negativePoint
+}
+
 func (p *PredictionCheckList) FavoriteNum() int {
 	return p.favoriteNum
 }
@@ -218,6 +276,11 @@ func (p *PredictionCheckList) HighlyRecommended() string {
 	return p.highlyRecommended
 }
 
+// CommentScore 調教コメントを辞書で採点したスコア、コメントが無い場合は-
+func (p *PredictionCheckList) CommentScore() string {
+	return p.commentScore
+}
+
 func (p *PredictionCheckList) TrainingComment() string {
 	return p.trainingComment
 }
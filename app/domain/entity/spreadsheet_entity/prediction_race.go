@@ -14,6 +14,7 @@ type PredictionRace struct {
 	url            string
 	filterName     string
 	raceTime       *PredictionRaceTime
+	raceRisk       *PredictionRaceRisk
 }
 
 func NewPredictionRace(
@@ -25,6 +26,7 @@ func NewPredictionRace(
 	url string,
 	filters []filter.AttributeId,
 	raceTime *PredictionRaceTime,
+	raceRisk *PredictionRaceRisk,
 ) *PredictionRace {
 	var filterName string
 	for _, f := range filters {
@@ -40,6 +42,7 @@ func NewPredictionRace(
 		url:            url,
 		filterName:     filterName,
 		raceTime:       raceTime,
+		raceRisk:       raceRisk,
 	}
 }
 
@@ -74,3 +77,7 @@ func (p *PredictionRace) FilterName() string {
 func (p *PredictionRace) RaceTime() *PredictionRaceTime {
 	return p.raceTime
 }
+
+func (p *PredictionRace) RaceRisk() *PredictionRaceRisk {
+	return p.raceRisk
+}
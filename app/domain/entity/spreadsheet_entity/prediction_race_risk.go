@@ -0,0 +1,46 @@
+package spreadsheet_entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+type PredictionRaceRisk struct {
+	rating      types.RaceRiskRating
+	probability float64
+	signals     []string
+}
+
+func NewPredictionRaceRisk(
+	rating types.RaceRiskRating,
+	probability float64,
+	signals []string,
+) *PredictionRaceRisk {
+	return &PredictionRaceRisk{
+		rating:      rating,
+		probability: probability,
+		signals:     signals,
+	}
+}
+
+func (p *PredictionRaceRisk) Rating() types.RaceRiskRating {
+	return p.rating
+}
+
+func (p *PredictionRaceRisk) Probability() float64 {
+	return p.probability
+}
+
+func (p *PredictionRaceRisk) Signals() []string {
+	return p.signals
+}
+
+// Format シートのレース名の後ろに付ける表記、判定できない場合は空文字
+func (p *PredictionRaceRisk) Format() string {
+	if p == nil || p.rating == types.UnknownRaceRiskRating {
+		return ""
+	}
+	return fmt.Sprintf("【%s %.0f%%】 %s", p.rating.String(), p.probability*100, strings.Join(p.signals, ", "))
+}
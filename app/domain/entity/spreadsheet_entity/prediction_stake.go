@@ -0,0 +1,33 @@
+package spreadsheet_entity
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+type PredictionStake struct {
+	marker        types.Marker
+	amount        int
+	expectedValue float64
+}
+
+func NewPredictionStake(
+	marker types.Marker,
+	amount int,
+	expectedValue float64,
+) *PredictionStake {
+	return &PredictionStake{
+		marker:        marker,
+		amount:        amount,
+		expectedValue: expectedValue,
+	}
+}
+
+func (p *PredictionStake) Marker() types.Marker {
+	return p.marker
+}
+
+func (p *PredictionStake) Amount() int {
+	return p.amount
+}
+
+func (p *PredictionStake) ExpectedValue() float64 {
+	return p.expectedValue
+}
@@ -24,6 +24,12 @@ type Summary struct {
 	raceCourseResultMap              map[types.RaceCourse]*TicketResult
 	raceCourseYearlyResultMap        map[types.RaceCourse]*TicketResult
 	raceCourseMonthlyResultMap       map[types.RaceCourse]*TicketResult
+	ticketSourceResultMap            map[types.TicketSource]*TicketResult
+	ticketSourceYearlyResultMap      map[types.TicketSource]*TicketResult
+	ticketSourceMonthlyResultMap     map[types.TicketSource]*TicketResult
+	accountResultMap                 map[types.Account]*TicketResult
+	accountYearlyResultMap           map[types.Account]*TicketResult
+	accountMonthlyResultMap          map[types.Account]*TicketResult
 	yearlyResults                    map[time.Time]*TicketResult
 	monthlyResults                   map[time.Time]*TicketResult
 	weeklyResults                    map[time.Time]*TicketResult
@@ -47,6 +53,12 @@ func NewSummary(
 	raceCourseResultMap map[types.RaceCourse]*TicketResult,
 	raceCourseYearlyResultMap map[types.RaceCourse]*TicketResult,
 	raceCourseMonthlyResultMap map[types.RaceCourse]*TicketResult,
+	ticketSourceResultMap map[types.TicketSource]*TicketResult,
+	ticketSourceYearlyResultMap map[types.TicketSource]*TicketResult,
+	ticketSourceMonthlyResultMap map[types.TicketSource]*TicketResult,
+	accountResultMap map[types.Account]*TicketResult,
+	accountYearlyResultMap map[types.Account]*TicketResult,
+	accountMonthlyResultMap map[types.Account]*TicketResult,
 	yearlyResults map[time.Time]*TicketResult,
 	monthlyResults map[time.Time]*TicketResult,
 	weeklyResults map[time.Time]*TicketResult,
@@ -69,6 +81,12 @@ func NewSummary(
 		raceCourseResultMap:              raceCourseResultMap,
 		raceCourseYearlyResultMap:        raceCourseYearlyResultMap,
 		raceCourseMonthlyResultMap:       raceCourseMonthlyResultMap,
+		ticketSourceResultMap:            ticketSourceResultMap,
+		ticketSourceYearlyResultMap:      ticketSourceYearlyResultMap,
+		ticketSourceMonthlyResultMap:     ticketSourceMonthlyResultMap,
+		accountResultMap:                 accountResultMap,
+		accountYearlyResultMap:           accountYearlyResultMap,
+		accountMonthlyResultMap:          accountMonthlyResultMap,
 		yearlyResults:                    yearlyResults,
 		monthlyResults:                   monthlyResults,
 		weeklyResults:                    weeklyResults,
@@ -143,6 +161,30 @@ func (s *Summary) RaceCourseMonthlyResultMap() map[types.RaceCourse]*TicketResul
 	return s.raceCourseMonthlyResultMap
 }
 
+func (s *Summary) TicketSourceResultMap() map[types.TicketSource]*TicketResult {
+	return s.ticketSourceResultMap
+}
+
+func (s *Summary) TicketSourceYearlyResultMap() map[types.TicketSource]*TicketResult {
+	return s.ticketSourceYearlyResultMap
+}
+
+func (s *Summary) TicketSourceMonthlyResultMap() map[types.TicketSource]*TicketResult {
+	return s.ticketSourceMonthlyResultMap
+}
+
+func (s *Summary) AccountResultMap() map[types.Account]*TicketResult {
+	return s.accountResultMap
+}
+
+func (s *Summary) AccountYearlyResultMap() map[types.Account]*TicketResult {
+	return s.accountYearlyResultMap
+}
+
+func (s *Summary) AccountMonthlyResultMap() map[types.Account]*TicketResult {
+	return s.accountMonthlyResultMap
+}
+
 func (s *Summary) YearlyResults() map[time.Time]*TicketResult {
 	return s.yearlyResults
 }
@@ -15,6 +15,8 @@ type Ticket struct {
 	ticketResult types.TicketResult
 	payment      types.Payment
 	payout       types.Payout
+	source       types.TicketSource
+	account      types.Account
 }
 
 func NewTicket(
@@ -26,6 +28,8 @@ func NewTicket(
 	rawTicketResult bool,
 	rawPayment,
 	rawPayout string,
+	source types.TicketSource,
+	account types.Account,
 ) (*Ticket, error) {
 	raceDate, err := types.NewRaceDate(rawRaceDate)
 	if err != nil {
@@ -68,9 +72,36 @@ func NewTicket(
 		ticketResult: ticketResult,
 		payment:      types.Payment(payment),
 		payout:       types.Payout(payout),
+		source:       source,
+		account:      account,
 	}, nil
 }
 
+// Split まとめ買いの馬券を1点ずつの馬券にする。レース、式別、購入元は元の馬券のまま
+func (t *Ticket) Split(
+	betNumber types.BetNumber,
+	payment types.Payment,
+	payout types.Payout,
+) *Ticket {
+	ticketResult := types.TicketUnHit
+	if payout > 0 {
+		ticketResult = types.TicketHit
+	}
+
+	return &Ticket{
+		raceDate:     t.raceDate,
+		raceCourse:   t.raceCourse,
+		raceNo:       t.raceNo,
+		betNumber:    betNumber,
+		ticketType:   t.ticketType,
+		ticketResult: ticketResult,
+		payment:      payment,
+		payout:       payout,
+		source:       t.source,
+		account:      t.account,
+	}
+}
+
 func (t *Ticket) RaceDate() types.RaceDate {
 	return t.raceDate
 }
@@ -102,3 +133,12 @@ func (t *Ticket) Payment() types.Payment {
 func (t *Ticket) Payout() types.Payout {
 	return t.payout
 }
+
+// Source 購入したサービス(IPAT, UMACA, 地方競馬の各投票サイト)
+func (t *Ticket) Source() types.TicketSource {
+	return t.source
+}
+
+func (t *Ticket) Account() types.Account {
+	return t.account
+}
@@ -8,4 +8,6 @@ import (
 
 type AnalysisMarkerRepository interface {
 	Read(ctx context.Context, path string) ([]*marker_csv_entity.AnalysisMarker, error)
+	Write(ctx context.Context, path string, markers []*marker_csv_entity.AnalysisMarker) error
+	Append(ctx context.Context, path string, markers []*marker_csv_entity.AnalysisMarker, version string) error
 }
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
+)
+
+type BetListRepository interface {
+	Write(ctx context.Context, path string, stakes []*prediction_entity.Stake) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
+)
+
+type BetSlipRepository interface {
+	ReadPlan(ctx context.Context, path string) ([]*prediction_entity.BetPlan, error)
+	Write(ctx context.Context, path string, betSlips []*prediction_entity.BetSlip) error
+	WriteText(ctx context.Context, path string, text string) error
+}
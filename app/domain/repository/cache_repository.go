@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/cache_entity"
+)
+
+type CacheRepository interface {
+	List(ctx context.Context, path string) ([]*cache_entity.CacheFile, error)
+	WriteChecksum(ctx context.Context, path string) error
+	Remove(ctx context.Context, path string) error
+	Quarantine(ctx context.Context, path string) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+)
+
+type CheckpointRepository interface {
+	Read(ctx context.Context, path string) ([]*raw_entity.CheckpointItem, error)
+	Write(ctx context.Context, path string, items []*raw_entity.CheckpointItem) error
+	Append(ctx context.Context, path string, item *raw_entity.CheckpointItem) error
+	Remove(ctx context.Context, path string) error
+	ReadRetryQueue(ctx context.Context, path string) (*raw_entity.RetryQueue, error)
+	WriteRetryQueue(ctx context.Context, path string, data *raw_entity.RetryQueue) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+type NarTicketRepository interface {
+	List(ctx context.Context, path string) ([]string, error)
+	Read(ctx context.Context, path string, account types.Account) ([]*ticket_csv_entity.Ticket, error)
+}
@@ -1,5 +1,5 @@
 package repository
 
 type PathOptimizer interface {
-	GetProjectRoot() (string, error)
+	GetAbsPath(path string) (string, error)
 }
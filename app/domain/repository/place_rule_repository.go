@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+)
+
+type PlaceRuleRepository interface {
+	Read(ctx context.Context, path string) (*raw_entity.PlaceRuleInfo, error)
+}
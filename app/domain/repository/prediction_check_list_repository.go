@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+)
+
+type PredictionCheckListRepository interface {
+	Read(ctx context.Context, path string) (*raw_entity.PredictionCheckListInfo, error)
+	Write(ctx context.Context, path string, predictionCheckListInfo *raw_entity.PredictionCheckListInfo) error
+}
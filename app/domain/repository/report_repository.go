@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/report_entity"
+)
+
+type ReportRepository interface {
+	Write(ctx context.Context, dir string, report *report_entity.Report) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+)
+
+type SnapshotRepository interface {
+	List(ctx context.Context, dir string) ([]string, error)
+	Read(ctx context.Context, path string) (*raw_entity.Snapshot, error)
+	Write(ctx context.Context, path string, snapshot *raw_entity.Snapshot) error
+}
@@ -28,9 +28,17 @@ type SpreadSheetRepository interface {
 		attributeFilters []filter.AttributeId,
 		conditionFilters []filter.AttributeId,
 	) error
+	WriteAnalysisPedigree(ctx context.Context, analysisPedigrees []*spreadsheet_entity.AnalysisPedigree) error
+	WriteAnalysisTrainer(ctx context.Context, analysisTrainers []*spreadsheet_entity.AnalysisTrainer) error
+	WriteAnalysisMarkerTicket(ctx context.Context, analysisMarkerTickets []*spreadsheet_entity.AnalysisMarkerTicket) error
+	WriteAnalysisPlacePaddock(ctx context.Context, analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate) error
+	WriteAnalysisCommentScore(ctx context.Context, analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate) error
+	WriteAnalysisMarkerConsensus(ctx context.Context, analysisMarkerConsensuses []*spreadsheet_entity.AnalysisMarkerConsensus) error
+	WriteAnalysisTicketReprice(ctx context.Context, analysisTicketReprices []*spreadsheet_entity.AnalysisTicketReprice) error
 	WritePredictionOdds(ctx context.Context,
 		firstPlaceMap, secondPlaceMap, thirdPlaceMap map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace,
 		raceCourseMap map[types.RaceCourse][]types.RaceId,
+		stakeMap map[types.RaceId][]*spreadsheet_entity.PredictionStake,
 	) error
 	WritePredictionCheckList(ctx context.Context, predictionCheckLists []*spreadsheet_entity.PredictionCheckList) error
 	WritePredictionMarker(ctx context.Context, predictionMarkers []*spreadsheet_entity.PredictionMarker) error
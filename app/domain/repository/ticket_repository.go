@@ -4,9 +4,11 @@ import (
 	"context"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
 )
 
 type TicketRepository interface {
 	List(ctx context.Context, path string) ([]string, error)
-	Read(ctx context.Context, path string) ([]*ticket_csv_entity.Ticket, error)
+	ListAccounts(ctx context.Context, path string) ([]types.Account, error)
+	Read(ctx context.Context, path string, account types.Account) ([]*ticket_csv_entity.Ticket, error)
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 )
 
 type TrainerRepository interface {
+	Read(ctx context.Context, path string) (*raw_entity.TrainerInfo, error)
+	Write(ctx context.Context, path string, data *raw_entity.TrainerInfo) error
 	Fetch(ctx context.Context, url string) (*netkeiba_entity.Trainer, error)
 }
@@ -0,0 +1,25 @@
+package aggregation_service
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+// filterAccountTickets 指定アカウントの馬券だけにする、AllAccountの場合は全アカウント合算
+func filterAccountTickets(
+	tickets []*ticket_csv_entity.RaceTicket,
+	account types.Account,
+) []*ticket_csv_entity.RaceTicket {
+	if account == types.AllAccount {
+		return tickets
+	}
+
+	accountTickets := make([]*ticket_csv_entity.RaceTicket, 0, len(tickets))
+	for _, raceTicket := range tickets {
+		if raceTicket.Ticket().Account() == account {
+			accountTickets = append(accountTickets, raceTicket)
+		}
+	}
+
+	return accountTickets
+}
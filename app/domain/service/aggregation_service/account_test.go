@@ -0,0 +1,119 @@
+package aggregation_service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/summary_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func newTestAccountRaceTicket(t *testing.T, raceId types.RaceId, account types.Account, payment, payout string) *ticket_csv_entity.RaceTicket {
+	t.Helper()
+	ticket, err := ticket_csv_entity.NewTicket("01", "20241020", "東京", "11", "単勝", payout != "0", payment, payout, types.Ipat, account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ticket_csv_entity.NewRaceTicket(raceId, ticket)
+}
+
+func TestFilterAccountTickets(t *testing.T) {
+	tickets := []*ticket_csv_entity.RaceTicket{
+		newTestAccountRaceTicket(t, "202405040811", types.DefaultAccount, "100", "0"),
+		newTestAccountRaceTicket(t, "202405040811", "team-a", "200", "0"),
+		newTestAccountRaceTicket(t, "202405040812", "team-b", "300", "0"),
+		newTestAccountRaceTicket(t, "202405040812", "team-a", "400", "0"),
+	}
+
+	tests := []struct {
+		name        string
+		account     types.Account
+		wantPayment []types.Payment
+	}{
+		{
+			name:        "全アカウント合算",
+			account:     types.AllAccount,
+			wantPayment: []types.Payment{100, 200, 300, 400},
+		},
+		{
+			name:        "csv直下の投票データ",
+			account:     types.DefaultAccount,
+			wantPayment: []types.Payment{100},
+		},
+		{
+			name:        "ディレクトリのアカウント",
+			account:     "team-a",
+			wantPayment: []types.Payment{200, 400},
+		},
+		{
+			name:        "存在しないアカウント",
+			account:     "team-c",
+			wantPayment: []types.Payment{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]types.Payment, 0)
+			for _, raceTicket := range filterAccountTickets(tickets, tt.account) {
+				got = append(got, raceTicket.Ticket().Payment())
+			}
+			if !reflect.DeepEqual(got, tt.wantPayment) {
+				t.Errorf("filterAccountTickets() = %v, want %v", got, tt.wantPayment)
+			}
+		})
+	}
+}
+
+func TestSummaryGetAccountResultMap(t *testing.T) {
+	type result struct {
+		raceCount int
+		betCount  int
+		hitCount  int
+		payment   int
+		payout    int
+	}
+	tests := []struct {
+		name    string
+		tickets []*ticket_csv_entity.RaceTicket
+		want    map[types.Account]result
+	}{
+		{
+			name: "馬券なし",
+			want: map[types.Account]result{},
+		},
+		{
+			name: "アカウントごとに集計する",
+			tickets: []*ticket_csv_entity.RaceTicket{
+				newTestAccountRaceTicket(t, "202405040811", types.DefaultAccount, "100", "0"),
+				newTestAccountRaceTicket(t, "202405040811", "team-a", "200", "500"),
+				newTestAccountRaceTicket(t, "202405040812", "team-a", "400", "0"),
+			},
+			want: map[types.Account]result{
+				types.DefaultAccount: {raceCount: 1, betCount: 1, hitCount: 0, payment: 100, payout: 0},
+				"team-a":             {raceCount: 2, betCount: 2, hitCount: 1, payment: 600, payout: 500},
+			},
+		},
+	}
+
+	s := &summaryService{termService: summary_service.NewTerm()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[types.Account]result{}
+			for account, ticketResult := range s.getAccountResultMap(context.Background(), tt.tickets) {
+				got[account] = result{
+					raceCount: ticketResult.RaceCount(),
+					betCount:  ticketResult.BetCount(),
+					hitCount:  ticketResult.HitCount(),
+					payment:   ticketResult.Payment(),
+					payout:    ticketResult.Payout(),
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getAccountResultMap() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
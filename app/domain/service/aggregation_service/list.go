@@ -53,6 +53,7 @@ type List interface {
 		tickets []*ticket_csv_entity.RaceTicket,
 		races []*data_cache_entity.Race,
 		jockeys []*data_cache_entity.Jockey,
+		account types.Account,
 	) ([]*spreadsheet_entity.ListRow, error)
 	Write(ctx context.Context, listRows []*spreadsheet_entity.ListRow) error
 }
@@ -80,8 +81,10 @@ func (l *listService) Create(
 	tickets []*ticket_csv_entity.RaceTicket,
 	races []*data_cache_entity.Race,
 	jockeys []*data_cache_entity.Jockey,
+	account types.Account,
 ) ([]*spreadsheet_entity.ListRow, error) {
 	var listRows []*spreadsheet_entity.ListRow
+	tickets = filterAccountTickets(tickets, account)
 	raceMap := converter.ConvertToMap(races, func(race *data_cache_entity.Race) types.RaceId {
 		return race.RaceId()
 	})
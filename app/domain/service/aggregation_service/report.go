@@ -0,0 +1,372 @@
+package aggregation_service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/report_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/summary_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	reportRaceCount = 5
+)
+
+var reportMarkers = []types.Marker{
+	types.Favorite,
+	types.Rival,
+	types.BrackTriangle,
+	types.WhiteTriangle,
+	types.Star,
+	types.Check,
+}
+
+var reportTicketTypes = []types.TicketType{
+	types.Win,
+	types.Place,
+	types.Quinella,
+	types.Exacta,
+	types.QuinellaPlace,
+	types.Trio,
+	types.Trifecta,
+	types.AllTicketType,
+}
+
+type Report interface {
+	Create(ctx context.Context, input *ReportInput) (*report_entity.Report, error)
+	Write(ctx context.Context, report *report_entity.Report) (string, error)
+}
+
+type ReportInput struct {
+	Tickets  []*ticket_csv_entity.RaceTicket
+	Races    []*data_cache_entity.Race
+	Jockeys  []*data_cache_entity.Jockey
+	Markers  []*marker_csv_entity.AnalysisMarker
+	Account  types.Account
+	Period   types.ReportPeriod
+	BaseDate time.Time // 集計期間に含まれる日。ゼロ値なら週間は先週、月間は今月
+	Bankroll int       // 初期資金。0なら残高は通算収支になる
+}
+
+type reportService struct {
+	termService      summary_service.Term
+	summaryService   Summary
+	listService      List
+	reportRepository repository.ReportRepository
+}
+
+func NewReport(
+	termService summary_service.Term,
+	summaryService Summary,
+	listService List,
+	reportRepository repository.ReportRepository,
+) Report {
+	return &reportService{
+		termService:      termService,
+		summaryService:   summaryService,
+		listService:      listService,
+		reportRepository: reportRepository,
+	}
+}
+
+// Create 集計シートと同じ集計を指定した週・月に絞ってレポートにまとめる
+func (r *reportService) Create(ctx context.Context, input *ReportInput) (*report_entity.Report, error) {
+	dateFrom, dateTo := r.getTerm(input.Period, input.BaseDate)
+	previousDateFrom := dateFrom.AddDate(0, 0, -7)
+	if input.Period == types.MonthReport {
+		previousDateFrom = dateFrom.AddDate(0, -1, 0)
+	}
+
+	tickets := filterAccountTickets(input.Tickets, input.Account)
+	termTickets := r.filterTickets(tickets, dateFrom, dateTo)
+
+	result := r.createTermResult(ctx, tickets, dateFrom, dateTo)
+	previousResult := r.createTermResult(ctx, tickets, previousDateFrom, dateFrom)
+
+	summary := r.summaryService.Create(ctx, termTickets, input.Races, types.AllAccount)
+	ticketTypeResults := make([]*report_entity.Result, 0, len(reportTicketTypes))
+	for _, ticketType := range reportTicketTypes {
+		ticketResult, ok := summary.TicketResultMap()[ticketType]
+		if !ok || ticketResult.BetCount() == 0 {
+			continue
+		}
+		ticketTypeResults = append(ticketTypeResults, report_entity.NewResult(ticketType.Name(), ticketResult))
+	}
+
+	raceCourses := make([]types.RaceCourse, 0, len(summary.RaceCourseResultMap()))
+	for raceCourse, raceCourseResult := range summary.RaceCourseResultMap() {
+		if raceCourseResult.BetCount() > 0 {
+			raceCourses = append(raceCourses, raceCourse)
+		}
+	}
+	sort.Slice(raceCourses, func(i, j int) bool {
+		return raceCourses[i] < raceCourses[j]
+	})
+	raceCourseResults := make([]*report_entity.Result, 0, len(raceCourses))
+	for _, raceCourse := range raceCourses {
+		raceCourseResults = append(raceCourseResults, report_entity.NewResult(raceCourse.Name(), summary.RaceCourseResultMap()[raceCourse]))
+	}
+
+	listRows, err := r.listService.Create(ctx, termTickets, input.Races, input.Jockeys, types.AllAccount)
+	if err != nil {
+		return nil, err
+	}
+	bestRaces, worstRaces := r.getBestAndWorstRaces(listRows)
+
+	return report_entity.NewReport(
+		input.Period,
+		dateFrom,
+		dateTo,
+		input.Account,
+		time.Now(),
+		result,
+		previousResult,
+		ticketTypeResults,
+		raceCourseResults,
+		bestRaces,
+		worstRaces,
+		r.createMarkers(input.Markers, input.Races, dateFrom, dateTo),
+		r.createBankroll(tickets, dateFrom, dateTo, input.Bankroll),
+	), nil
+}
+
+// Write 期間と作成日時ごとのディレクトリに書き出し、書き出したディレクトリを返す。過去のレポートは上書きしない
+func (r *reportService) Write(ctx context.Context, report *report_entity.Report) (string, error) {
+	dir := fmt.Sprintf("%s/%s_%s/%s", config.ReportDir, report.Period().Value(), report.DateFrom().Format("20060102"), report.GeneratedAt().Format("20060102-150405"))
+	if err := r.reportRepository.Write(ctx, dir, report); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// getTerm 週は月曜始まり。終了日は翌日(含まない)で返す
+func (r *reportService) getTerm(period types.ReportPeriod, baseDate time.Time) (time.Time, time.Time) {
+	if baseDate.IsZero() {
+		baseDate = time.Now()
+		// 集計シートの週間と同じく、週間は先週を対象にする
+		if period == types.WeekReport {
+			baseDate = baseDate.AddDate(0, 0, -7)
+		}
+	}
+	// 馬券の開催日はUTCの0時で持っているので合わせる
+	date := time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	if period == types.MonthReport {
+		dateFrom := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return dateFrom, dateFrom.AddDate(0, 1, 0)
+	}
+
+	offset := (int(date.Weekday()) + 6) % 7
+	dateFrom := date.AddDate(0, 0, -offset)
+	return dateFrom, dateFrom.AddDate(0, 0, 7)
+}
+
+func (r *reportService) createTermResult(
+	ctx context.Context,
+	tickets []*ticket_csv_entity.RaceTicket,
+	from time.Time,
+	to time.Time,
+) *spreadsheet_entity.TicketResult {
+	output := r.termService.Create(ctx, &summary_service.TermInput{
+		Tickets:  tickets,
+		DateFrom: from,
+		DateTo:   to,
+	})
+	return spreadsheet_entity.NewTicketResult(
+		output.RaceCount,
+		output.BetCount,
+		output.HitCount,
+		output.Payment,
+		output.Payout,
+		output.AveragePayout,
+		output.MaxPayout,
+		output.MinPayout,
+	)
+}
+
+func (r *reportService) filterTickets(
+	tickets []*ticket_csv_entity.RaceTicket,
+	from time.Time,
+	to time.Time,
+) []*ticket_csv_entity.RaceTicket {
+	var termTickets []*ticket_csv_entity.RaceTicket
+	for _, raceTicket := range tickets {
+		if r.inTerm(raceTicket.Ticket().RaceDate(), from, to) {
+			termTickets = append(termTickets, raceTicket)
+		}
+	}
+	return termTickets
+}
+
+func (r *reportService) inTerm(raceDate types.RaceDate, from time.Time, to time.Time) bool {
+	date := raceDate.Date()
+	return !date.Before(from) && date.Before(to)
+}
+
+// getBestAndWorstRaces 収支の良いレースと悪いレースをそれぞれ上位から返す
+func (r *reportService) getBestAndWorstRaces(
+	listRows []*spreadsheet_entity.ListRow,
+) ([]*spreadsheet_entity.ListData, []*spreadsheet_entity.ListData) {
+	listData := make([]*spreadsheet_entity.ListData, 0, len(listRows))
+	for _, listRow := range listRows {
+		listData = append(listData, listRow.Data())
+	}
+	profit := func(data *spreadsheet_entity.ListData) int {
+		return data.Payout() - data.Payment()
+	}
+	sort.SliceStable(listData, func(i, j int) bool {
+		if profit(listData[i]) != profit(listData[j]) {
+			return profit(listData[i]) > profit(listData[j])
+		}
+		return listData[i].RaceDate() < listData[j].RaceDate()
+	})
+
+	var bestRaces, worstRaces []*spreadsheet_entity.ListData
+	for _, data := range listData {
+		if len(bestRaces) >= reportRaceCount || profit(data) <= 0 {
+			break
+		}
+		bestRaces = append(bestRaces, data)
+	}
+	for i := len(listData) - 1; i >= 0; i-- {
+		if len(worstRaces) >= reportRaceCount || profit(listData[i]) >= 0 {
+			break
+		}
+		worstRaces = append(worstRaces, listData[i])
+	}
+
+	return bestRaces, worstRaces
+}
+
+// createMarkers 印ごとに期間内と全期間の勝率、複勝率、単勝回収率を求める
+func (r *reportService) createMarkers(
+	markers []*marker_csv_entity.AnalysisMarker,
+	races []*data_cache_entity.Race,
+	from time.Time,
+	to time.Time,
+) []*report_entity.Marker {
+	raceMap := converter.ConvertToMap(races, func(race *data_cache_entity.Race) types.RaceId {
+		return race.RaceId()
+	})
+
+	type markerCount struct {
+		raceCount, winCount, placeCount, winPayout int
+	}
+	termCountMap := map[types.Marker]*markerCount{}
+	allCountMap := map[types.Marker]*markerCount{}
+	for _, marker := range reportMarkers {
+		termCountMap[marker] = &markerCount{}
+		allCountMap[marker] = &markerCount{}
+	}
+
+	for _, analysisMarker := range markers {
+		race, ok := raceMap[analysisMarker.RaceId()]
+		if !ok || len(race.RaceResults()) == 0 {
+			continue
+		}
+		inTerm := r.inTerm(race.RaceDate(), from, to)
+		for _, marker := range reportMarkers {
+			horseNumber := analysisMarker.MarkerMap()[marker]
+			if horseNumber == 0 {
+				continue
+			}
+			counts := []*markerCount{allCountMap[marker]}
+			if inTerm {
+				counts = append(counts, termCountMap[marker])
+			}
+			for _, raceResult := range race.RaceResults() {
+				if raceResult.HorseNumber() != horseNumber {
+					continue
+				}
+				for _, count := range counts {
+					count.raceCount++
+					if raceResult.OrderNo() == 1 {
+						count.winCount++
+						count.winPayout += int(raceResult.Odds().Mul(decimal.NewFromInt(100)).IntPart())
+					}
+					if raceResult.OrderNo() >= 1 && raceResult.OrderNo() <= 3 {
+						count.placeCount++
+					}
+				}
+			}
+		}
+	}
+
+	reportMarkerResults := make([]*report_entity.Marker, 0, len(reportMarkers))
+	for _, marker := range reportMarkers {
+		termCount, allCount := termCountMap[marker], allCountMap[marker]
+		if termCount.raceCount == 0 {
+			continue
+		}
+		reportMarkerResults = append(reportMarkerResults, report_entity.NewMarker(
+			marker,
+			termCount.raceCount,
+			termCount.winCount,
+			termCount.placeCount,
+			termCount.winPayout,
+			allCount.raceCount,
+			allCount.winCount,
+			allCount.placeCount,
+			allCount.winPayout,
+		))
+	}
+
+	return reportMarkerResults
+}
+
+// createBankroll 期間の開始時点の残高から開催日ごとの残高の推移を求める
+func (r *reportService) createBankroll(
+	tickets []*ticket_csv_entity.RaceTicket,
+	from time.Time,
+	to time.Time,
+	bankroll int,
+) *report_entity.Bankroll {
+	type dayCount struct {
+		payment, payout int
+	}
+
+	startBalance := bankroll
+	dayCountMap := map[types.RaceDate]*dayCount{}
+	var raceDates []types.RaceDate
+	for _, raceTicket := range tickets {
+		ticket := raceTicket.Ticket()
+		if ticket.RaceDate().Date().Before(from) {
+			startBalance += ticket.Payout().Value() - ticket.Payment().Value()
+			continue
+		}
+		if !r.inTerm(ticket.RaceDate(), from, to) {
+			continue
+		}
+		if _, ok := dayCountMap[ticket.RaceDate()]; !ok {
+			raceDates = append(raceDates, ticket.RaceDate())
+			dayCountMap[ticket.RaceDate()] = &dayCount{}
+		}
+		dayCountMap[ticket.RaceDate()].payment += ticket.Payment().Value()
+		dayCountMap[ticket.RaceDate()].payout += ticket.Payout().Value()
+	}
+	sort.Slice(raceDates, func(i, j int) bool {
+		return raceDates[i] < raceDates[j]
+	})
+
+	balance := startBalance
+	days := make([]*report_entity.BankrollDay, 0, len(raceDates))
+	for _, raceDate := range raceDates {
+		count := dayCountMap[raceDate]
+		balance += count.payout - count.payment
+		days = append(days, report_entity.NewBankrollDay(raceDate, count.payment, count.payout, balance))
+	}
+
+	return report_entity.NewBankroll(startBalance, days)
+}
@@ -0,0 +1,203 @@
+package aggregation_service
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func TestReportGetTerm(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name         string
+		period       types.ReportPeriod
+		baseDate     time.Time
+		wantDateFrom time.Time
+		wantDateTo   time.Time
+	}{
+		{
+			name:         "週間は月曜始まり",
+			period:       types.WeekReport,
+			baseDate:     time.Date(2024, 10, 16, 0, 0, 0, 0, time.UTC),
+			wantDateFrom: time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC),
+			wantDateTo:   time.Date(2024, 10, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "日曜は前の月曜からの週",
+			period:       types.WeekReport,
+			baseDate:     time.Date(2024, 10, 20, 0, 0, 0, 0, time.UTC),
+			wantDateFrom: time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC),
+			wantDateTo:   time.Date(2024, 10, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "時刻とタイムゾーンは見ずに日付で決める",
+			period:       types.WeekReport,
+			baseDate:     time.Date(2024, 10, 14, 1, 30, 0, 0, jst),
+			wantDateFrom: time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC),
+			wantDateTo:   time.Date(2024, 10, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "月間",
+			period:       types.MonthReport,
+			baseDate:     time.Date(2024, 10, 20, 0, 0, 0, 0, time.UTC),
+			wantDateFrom: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+			wantDateTo:   time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "12月は翌年の1月1日まで",
+			period:       types.MonthReport,
+			baseDate:     time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+			wantDateFrom: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+			wantDateTo:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	r := &reportService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dateFrom, dateTo := r.getTerm(tt.period, tt.baseDate)
+			if !dateFrom.Equal(tt.wantDateFrom) || !dateTo.Equal(tt.wantDateTo) {
+				t.Errorf("getTerm() = %v, %v, want %v, %v", dateFrom, dateTo, tt.wantDateFrom, tt.wantDateTo)
+			}
+		})
+	}
+}
+
+func newTestReportRaceTicket(t *testing.T, raceDate, payment, payout string) *ticket_csv_entity.RaceTicket {
+	t.Helper()
+	ticket, err := ticket_csv_entity.NewTicket("01", raceDate, "東京", "11", "単勝", payout != "0", payment, payout, types.Ipat, types.DefaultAccount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ticket_csv_entity.NewRaceTicket(types.RaceId(fmt.Sprintf("%s11", raceDate)), ticket)
+}
+
+func TestReportCreateBankroll(t *testing.T) {
+	type day struct {
+		raceDate types.RaceDate
+		payment  int
+		payout   int
+		balance  int
+	}
+	dateFrom, dateTo := time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC), time.Date(2024, 10, 21, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		tickets          []*ticket_csv_entity.RaceTicket
+		bankroll         int
+		wantStartBalance int
+		wantEndBalance   int
+		wantMaxDrawdown  int
+		wantDays         []day
+	}{
+		{
+			name:             "馬券なし",
+			bankroll:         10000,
+			wantStartBalance: 10000,
+			wantEndBalance:   10000,
+			wantDays:         []day{},
+		},
+		{
+			name: "期間前の収支を開始時点の残高に含め、期間後は含めない",
+			tickets: []*ticket_csv_entity.RaceTicket{
+				newTestReportRaceTicket(t, "20241013", "1000", "0"),
+				newTestReportRaceTicket(t, "20241020", "200", "1000"),
+				newTestReportRaceTicket(t, "20241019", "500", "0"),
+				newTestReportRaceTicket(t, "20241019", "100", "300"),
+				newTestReportRaceTicket(t, "20241021", "5000", "0"),
+			},
+			bankroll:         10000,
+			wantStartBalance: 9000,
+			wantEndBalance:   9500,
+			wantMaxDrawdown:  300,
+			wantDays: []day{
+				{raceDate: 20241019, payment: 600, payout: 300, balance: 8700},
+				{raceDate: 20241020, payment: 200, payout: 1000, balance: 9500},
+			},
+		},
+	}
+
+	r := &reportService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bankroll := r.createBankroll(tt.tickets, dateFrom, dateTo, tt.bankroll)
+			if bankroll.StartBalance() != tt.wantStartBalance || bankroll.EndBalance() != tt.wantEndBalance || bankroll.MaxDrawdown() != tt.wantMaxDrawdown {
+				t.Errorf("createBankroll() = start %d end %d drawdown %d, want %d %d %d", bankroll.StartBalance(), bankroll.EndBalance(),
+					bankroll.MaxDrawdown(), tt.wantStartBalance, tt.wantEndBalance, tt.wantMaxDrawdown)
+			}
+			days := make([]day, 0, len(bankroll.Days()))
+			for _, d := range bankroll.Days() {
+				days = append(days, day{raceDate: d.RaceDate(), payment: d.Payment(), payout: d.Payout(), balance: d.Balance()})
+			}
+			if !reflect.DeepEqual(days, tt.wantDays) {
+				t.Errorf("Days() = %+v, want %+v", days, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestReportCreateMarkers(t *testing.T) {
+	newRace := func(raceId string, raceDate int, raceResults ...*data_cache_entity.RaceResult) *data_cache_entity.Race {
+		return data_cache_entity.NewRace(raceId, raceDate, 11, types.Tokyo, "テスト", 1, "", "", "15:40", len(raceResults), 1600, 0, 0, 0, 0, 0, 0, 0, raceResults, nil, true)
+	}
+	newRaceResult := func(orderNo, horseNumber int, odds string) *data_cache_entity.RaceResult {
+		return data_cache_entity.NewRaceResult(orderNo, fmt.Sprintf("h%d", horseNumber), "", 1, horseNumber, "", odds, orderNo, "", 0, 0, "", "", "", "", 0)
+	}
+	newMarker := func(raceDate, raceId string) *marker_csv_entity.AnalysisMarker {
+		marker, err := marker_csv_entity.NewAnalysisMarker(raceDate, raceId, "1", "2", "0", "0", "0", "0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return marker
+	}
+
+	markers := []*marker_csv_entity.AnalysisMarker{
+		newMarker("20241020", "202405040811"),
+		newMarker("20241006", "202404020811"),
+		// レース結果が無い
+		newMarker("20241019", "202405030811"),
+	}
+	races := []*data_cache_entity.Race{
+		newRace("202405040811", 20241020, newRaceResult(1, 1, "3.5"), newRaceResult(2, 2, "4.0")),
+		newRace("202404020811", 20241006, newRaceResult(4, 1, "2.0"), newRaceResult(1, 2, "5.0")),
+	}
+
+	type row struct {
+		marker           types.Marker
+		winRate          string
+		placeRate        string
+		winPayoutRate    string
+		allWinRate       string
+		allPlaceRate     string
+		allWinPayoutRate string
+	}
+	want := []row{
+		{marker: types.Favorite, winRate: "100.0%", placeRate: "100.0%", winPayoutRate: "350.0%", allWinRate: "50.0%", allPlaceRate: "50.0%", allWinPayoutRate: "175.0%"},
+		{marker: types.Rival, winRate: "0.0%", placeRate: "100.0%", winPayoutRate: "0.0%", allWinRate: "50.0%", allPlaceRate: "100.0%", allWinPayoutRate: "250.0%"},
+	}
+
+	r := &reportService{}
+	var got []row
+	for _, m := range r.createMarkers(markers, races, time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC), time.Date(2024, 10, 21, 0, 0, 0, 0, time.UTC)) {
+		got = append(got, row{
+			marker:           m.Marker(),
+			winRate:          m.WinRate(),
+			placeRate:        m.PlaceRate(),
+			winPayoutRate:    m.WinPayoutRate(),
+			allWinRate:       m.AllWinRate(),
+			allPlaceRate:     m.AllPlaceRate(),
+			allWinPayoutRate: m.AllWinPayoutRate(),
+		})
+	}
+	// 期間内に印の無い▲以下は出さない
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("createMarkers() = %+v, want %+v", got, want)
+	}
+}
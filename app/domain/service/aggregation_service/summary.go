@@ -18,6 +18,7 @@ type Summary interface {
 	Create(ctx context.Context,
 		tickets []*ticket_csv_entity.RaceTicket,
 		races []*data_cache_entity.Race,
+		account types.Account,
 	) *spreadsheet_entity.Summary
 	WriteV2(ctx context.Context, data *spreadsheet_entity.Summary) error
 }
@@ -56,7 +57,9 @@ func (s *summaryService) Create(
 	ctx context.Context,
 	tickets []*ticket_csv_entity.RaceTicket,
 	races []*data_cache_entity.Race,
+	account types.Account,
 ) *spreadsheet_entity.Summary {
+	tickets = filterAccountTickets(tickets, account)
 	allTermResult := s.getAllTermResult(ctx, tickets)
 	yearTermResult := s.getYearTermResult(ctx, tickets)
 	monthTermResult := s.getMonthTermResult(ctx, tickets)
@@ -74,6 +77,12 @@ func (s *summaryService) Create(
 	raceCourseResultMap := s.getRaceCourseResultMap(ctx, tickets, races)
 	raceCourseYearlyResultMap := s.getRaceCourseYearlyResultMap(ctx, tickets, races)
 	raceCourseMonthlyResultMap := s.getRaceCourseMonthlyResultMap(ctx, tickets, races)
+	ticketSourceResultMap := s.getTicketSourceResultMap(ctx, tickets)
+	ticketSourceYearlyResultMap := s.getTicketSourceYearlyResultMap(ctx, tickets)
+	ticketSourceMonthlyResultMap := s.getTicketSourceMonthlyResultMap(ctx, tickets)
+	accountResultMap := s.getAccountResultMap(ctx, tickets)
+	accountYearlyResultMap := s.getAccountYearlyResultMap(ctx, tickets)
+	accountMonthlyResultMap := s.getAccountMonthlyResultMap(ctx, tickets)
 	yearlyResultMap := s.getYearlyResultMap(ctx, tickets)
 	monthlyResultMap := s.getMonthlyResultMap(ctx, tickets)
 	weeklyResultMap := s.getWeeklyResultMap(ctx, tickets)
@@ -96,6 +105,12 @@ func (s *summaryService) Create(
 		raceCourseResultMap,
 		raceCourseYearlyResultMap,
 		raceCourseMonthlyResultMap,
+		ticketSourceResultMap,
+		ticketSourceYearlyResultMap,
+		ticketSourceMonthlyResultMap,
+		accountResultMap,
+		accountYearlyResultMap,
+		accountMonthlyResultMap,
 		yearlyResultMap,
 		monthlyResultMap,
 		weeklyResultMap,
@@ -407,6 +422,100 @@ func (s *summaryService) getRaceCourseMonthlyResultMap(
 	return raceCourseMonthlyResultMap
 }
 
+// getTicketSourceResultMap 購入したサービスごとの成績、購入実績のないサービスは出さない
+func (s *summaryService) getTicketSourceResultMap(
+	ctx context.Context,
+	tickets []*ticket_csv_entity.RaceTicket,
+) map[types.TicketSource]*spreadsheet_entity.TicketResult {
+	sourceTicketMap := map[types.TicketSource][]*ticket_csv_entity.RaceTicket{}
+	for _, raceTicket := range tickets {
+		source := raceTicket.Ticket().Source()
+		sourceTicketMap[source] = append(sourceTicketMap[source], raceTicket)
+	}
+
+	now := time.Now()
+	allFrom := time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local)
+	ticketSourceResultMap := map[types.TicketSource]*spreadsheet_entity.TicketResult{}
+	for source, sourceTickets := range sourceTicketMap {
+		ticketSourceResultMap[source] = s.createTermResult(ctx, sourceTickets, allFrom, now)
+	}
+
+	return ticketSourceResultMap
+}
+
+func (s *summaryService) getTicketSourceYearlyResultMap(
+	ctx context.Context,
+	tickets []*ticket_csv_entity.RaceTicket,
+) map[types.TicketSource]*spreadsheet_entity.TicketResult {
+	now := time.Now()
+	yearFrom := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.Local)
+	nextYear := now.AddDate(1, 0, 0)
+	yearTo := time.Date(nextYear.Year(), 1, 1, 0, 0, 0, 0, time.Local)
+	ticketSourceYearlyResultMap := s.getTicketSourceResultMap(ctx, s.getTermRaceTicket(tickets, yearFrom, yearTo))
+
+	return ticketSourceYearlyResultMap
+}
+
+func (s *summaryService) getTicketSourceMonthlyResultMap(
+	ctx context.Context,
+	tickets []*ticket_csv_entity.RaceTicket,
+) map[types.TicketSource]*spreadsheet_entity.TicketResult {
+	now := time.Now()
+	monthFrom := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	nextMonth := now.AddDate(0, 1, 0)
+	monthTo := time.Date(nextMonth.Year(), nextMonth.Month(), 1, 0, 0, 0, 0, time.Local)
+	ticketSourceMonthlyResultMap := s.getTicketSourceResultMap(ctx, s.getTermRaceTicket(tickets, monthFrom, monthTo))
+
+	return ticketSourceMonthlyResultMap
+}
+
+// getAccountResultMap アカウントごとの成績
+func (s *summaryService) getAccountResultMap(
+	ctx context.Context,
+	tickets []*ticket_csv_entity.RaceTicket,
+) map[types.Account]*spreadsheet_entity.TicketResult {
+	accountTicketMap := map[types.Account][]*ticket_csv_entity.RaceTicket{}
+	for _, raceTicket := range tickets {
+		account := raceTicket.Ticket().Account()
+		accountTicketMap[account] = append(accountTicketMap[account], raceTicket)
+	}
+
+	now := time.Now()
+	allFrom := time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local)
+	accountResultMap := map[types.Account]*spreadsheet_entity.TicketResult{}
+	for account, accountTickets := range accountTicketMap {
+		accountResultMap[account] = s.createTermResult(ctx, accountTickets, allFrom, now)
+	}
+
+	return accountResultMap
+}
+
+func (s *summaryService) getAccountYearlyResultMap(
+	ctx context.Context,
+	tickets []*ticket_csv_entity.RaceTicket,
+) map[types.Account]*spreadsheet_entity.TicketResult {
+	now := time.Now()
+	yearFrom := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.Local)
+	nextYear := now.AddDate(1, 0, 0)
+	yearTo := time.Date(nextYear.Year(), 1, 1, 0, 0, 0, 0, time.Local)
+	accountYearlyResultMap := s.getAccountResultMap(ctx, s.getTermRaceTicket(tickets, yearFrom, yearTo))
+
+	return accountYearlyResultMap
+}
+
+func (s *summaryService) getAccountMonthlyResultMap(
+	ctx context.Context,
+	tickets []*ticket_csv_entity.RaceTicket,
+) map[types.Account]*spreadsheet_entity.TicketResult {
+	now := time.Now()
+	monthFrom := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	nextMonth := now.AddDate(0, 1, 0)
+	monthTo := time.Date(nextMonth.Year(), nextMonth.Month(), 1, 0, 0, 0, 0, time.Local)
+	accountMonthlyResultMap := s.getAccountResultMap(ctx, s.getTermRaceTicket(tickets, monthFrom, monthTo))
+
+	return accountMonthlyResultMap
+}
+
 func (s *summaryService) getYearlyResultMap(
 	ctx context.Context,
 	tickets []*ticket_csv_entity.RaceTicket,
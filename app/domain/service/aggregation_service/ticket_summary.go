@@ -15,7 +15,7 @@ import (
 )
 
 type TicketSummary interface {
-	Create(ctx context.Context, tickets []*ticket_csv_entity.RaceTicket) map[int]*spreadsheet_entity.TicketSummary
+	Create(ctx context.Context, tickets []*ticket_csv_entity.RaceTicket, account types.Account) map[int]*spreadsheet_entity.TicketSummary
 	Write(ctx context.Context, ticketSummaryMap map[int]*spreadsheet_entity.TicketSummary) error
 }
 
@@ -40,9 +40,10 @@ func NewTicketSummary(
 func (t *ticketSummaryService) Create(
 	ctx context.Context,
 	tickets []*ticket_csv_entity.RaceTicket,
+	account types.Account,
 ) map[int]*spreadsheet_entity.TicketSummary {
 	dateTimeTicketMap := map[time.Time][]*ticket_csv_entity.RaceTicket{}
-	for _, raceTicket := range tickets {
+	for _, raceTicket := range filterAccountTickets(tickets, account) {
 		dateStr := fmt.Sprintf("%d", raceTicket.Ticket().RaceDate().Value())
 		dateTime, _ := time.Parse("20060102", dateStr)
 		month := time.Date(dateTime.Year(), dateTime.Month(), 1, 0, 0, 0, 0, time.Local)
@@ -0,0 +1,329 @@
+package analysis_service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/config"
+)
+
+const (
+	commentLexiconFileName = "comment_lexicon.json"
+
+	commentScoreBandCategory    = "スコア"
+	commentScoreKeywordCategory = "キーワード"
+	commentScoreNoComment       = "コメントなし"
+	commentScoreAllMarker       = "全体"
+	commentScoreNegationSuffix  = "(否定)"
+)
+
+// commentScoreBands スコア帯、プラスが好評価
+var commentScoreBands = []string{
+	"+3以上",
+	"+1〜+3",
+	"±1未満",
+	"-1〜-3",
+	"-3以下",
+}
+
+type CommentScore interface {
+	Get(ctx context.Context) (*analysis_entity.CommentLexicon, error)
+	Score(ctx context.Context, lexicon *analysis_entity.CommentLexicon, trainingComment, previousTrainingComment string) *analysis_entity.CommentScore
+	Create(ctx context.Context,
+		lexicon *analysis_entity.CommentLexicon,
+		markers []*marker_csv_entity.AnalysisMarker,
+		races []*data_cache_entity.Race,
+		raceForecasts []*data_cache_entity.RaceForecast,
+	) []*analysis_entity.CommentScoreCalculable
+	Convert(ctx context.Context, lexicon *analysis_entity.CommentLexicon, calculables []*analysis_entity.CommentScoreCalculable) []*spreadsheet_entity.AnalysisPlaceRate
+	Write(ctx context.Context, analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate) error
+}
+
+type commentScoreService struct {
+	commentLexiconRepository repository.CommentLexiconRepository
+	spreadSheetRepository    repository.SpreadSheetRepository
+}
+
+func NewCommentScore(
+	commentLexiconRepository repository.CommentLexiconRepository,
+	spreadSheetRepository repository.SpreadSheetRepository,
+) CommentScore {
+	return &commentScoreService{
+		commentLexiconRepository: commentLexiconRepository,
+		spreadSheetRepository:    spreadSheetRepository,
+	}
+}
+
+func (c *commentScoreService) Get(ctx context.Context) (*analysis_entity.CommentLexicon, error) {
+	rawCommentLexiconInfo, err := c.commentLexiconRepository.Read(ctx, fmt.Sprintf("%s/%s", config.RuleDir, commentLexiconFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	keywords := make([]*analysis_entity.CommentKeyword, 0, len(rawCommentLexiconInfo.Keywords))
+	for _, rawKeyword := range rawCommentLexiconInfo.Keywords {
+		if rawKeyword.Word == "" {
+			return nil, fmt.Errorf("comment lexicon has empty word")
+		}
+		keywords = append(keywords, analysis_entity.NewCommentKeyword(rawKeyword.Word, rawKeyword.Point))
+	}
+
+	return analysis_entity.NewCommentLexicon(
+		keywords,
+		rawCommentLexiconInfo.Negations,
+		rawCommentLexiconInfo.NegationWindow,
+		rawCommentLexiconInfo.PreviousCommentWeight,
+	), nil
+}
+
+func (c *commentScoreService) Score(
+	ctx context.Context,
+	lexicon *analysis_entity.CommentLexicon,
+	trainingComment,
+	previousTrainingComment string,
+) *analysis_entity.CommentScore {
+	if trainingComment == "" && previousTrainingComment == "" {
+		return analysis_entity.NewCommentScore(0, nil, false)
+	}
+
+	score, keywords := c.scoreComment(lexicon, trainingComment)
+	previousScore, previousKeywords := c.scoreComment(lexicon, previousTrainingComment)
+
+	return analysis_entity.NewCommentScore(
+		score+previousScore*lexicon.PreviousCommentWeight(),
+		append(keywords, previousKeywords...),
+		true,
+	)
+}
+
+// scoreComment 長いキーワードから順に一致させ、一致済みの箇所は短いキーワードで二重に数えない
+// キーワードの直後に否定語があれば点数の符号を反転する(例: 不安はない)
+func (c *commentScoreService) scoreComment(
+	lexicon *analysis_entity.CommentLexicon,
+	comment string,
+) (float64, []string) {
+	if comment == "" {
+		return 0, nil
+	}
+
+	keywords := make([]*analysis_entity.CommentKeyword, len(lexicon.Keywords()))
+	copy(keywords, lexicon.Keywords())
+	sort.SliceStable(keywords, func(i, j int) bool {
+		return utf8.RuneCountInString(keywords[i].Word()) > utf8.RuneCountInString(keywords[j].Word())
+	})
+
+	runes := []rune(comment)
+	used := make([]bool, len(runes))
+
+	var (
+		score        float64
+		matchedWords []string
+	)
+	for _, keyword := range keywords {
+		word := []rune(keyword.Word())
+		for i := 0; i+len(word) <= len(runes); i++ {
+			if string(runes[i:i+len(word)]) != keyword.Word() || c.isUsed(used[i:i+len(word)]) {
+				continue
+			}
+			for j := i; j < i+len(word); j++ {
+				used[j] = true
+			}
+
+			end := i + len(word)
+			// 0点のキーワードは短いキーワードに誤って一致させないためのもの(例: 好調時)なので数えない
+			if keyword.Point() == 0 {
+				i = end - 1
+				continue
+			}
+			windowEnd := end + lexicon.NegationWindow()
+			if windowEnd > len(runes) {
+				windowEnd = len(runes)
+			}
+			if c.isNegated(lexicon, string(runes[end:windowEnd])) {
+				score -= keyword.Point()
+				matchedWords = append(matchedWords, keyword.Word()+commentScoreNegationSuffix)
+			} else {
+				score += keyword.Point()
+				matchedWords = append(matchedWords, keyword.Word())
+			}
+			i = end - 1
+		}
+	}
+
+	return score, matchedWords
+}
+
+func (c *commentScoreService) isUsed(used []bool) bool {
+	for _, u := range used {
+		if u {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *commentScoreService) isNegated(
+	lexicon *analysis_entity.CommentLexicon,
+	text string,
+) bool {
+	for _, negation := range lexicon.Negations() {
+		if negation != "" && strings.Contains(text, negation) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *commentScoreService) Create(
+	ctx context.Context,
+	lexicon *analysis_entity.CommentLexicon,
+	markers []*marker_csv_entity.AnalysisMarker,
+	races []*data_cache_entity.Race,
+	raceForecasts []*data_cache_entity.RaceForecast,
+) []*analysis_entity.CommentScoreCalculable {
+	markerMap := converter.ConvertToMap(markers, func(marker *marker_csv_entity.AnalysisMarker) types.RaceId {
+		return marker.RaceId()
+	})
+	raceForecastMap := converter.ConvertToMap(raceForecasts, func(raceForecast *data_cache_entity.RaceForecast) types.RaceId {
+		return raceForecast.RaceId()
+	})
+
+	var calculables []*analysis_entity.CommentScoreCalculable
+	for _, race := range races {
+		marker, ok := markerMap[race.RaceId()]
+		if !ok {
+			continue
+		}
+		raceForecast, ok := raceForecastMap[race.RaceId()]
+		if !ok {
+			continue
+		}
+
+		horseNumberMarkerMap := map[types.HorseNumber]types.Marker{}
+		for markerType, horseNumber := range marker.MarkerMap() {
+			horseNumberMarkerMap[horseNumber] = markerType
+		}
+		forecastMap := converter.ConvertToMap(raceForecast.Forecasts(), func(forecast *data_cache_entity.Forecast) types.HorseNumber {
+			return forecast.HorseNumber()
+		})
+
+		for _, raceResult := range race.RaceResults() {
+			// 取り消し・除外の馬は集計対象外
+			if raceResult.Odds().IsZero() {
+				continue
+			}
+			markerType, ok := horseNumberMarkerMap[raceResult.HorseNumber()]
+			if !ok {
+				markerType = types.NoMarker
+			}
+
+			var trainingComment, previousTrainingComment string
+			if forecast, ok := forecastMap[raceResult.HorseNumber()]; ok {
+				trainingComment = forecast.TrainingComment()
+				previousTrainingComment = forecast.PreviousTrainingComment()
+			}
+
+			calculables = append(calculables, analysis_entity.NewCommentScoreCalculable(
+				race.RaceId(),
+				race.RaceDate(),
+				raceResult.HorseNumber(),
+				markerType,
+				raceResult.OrderNo(),
+				c.Score(ctx, lexicon, trainingComment, previousTrainingComment),
+			))
+		}
+	}
+
+	return calculables
+}
+
+func (c *commentScoreService) Convert(
+	ctx context.Context,
+	lexicon *analysis_entity.CommentLexicon,
+	calculables []*analysis_entity.CommentScoreCalculable,
+) []*spreadsheet_entity.AnalysisPlaceRate {
+	counter := newPlaceRateCounter()
+	for _, calculable := range calculables {
+		counter.add(placeRateKey{
+			category: commentScoreBandCategory,
+			value:    c.band(calculable.CommentScore()),
+			marker:   calculable.Marker(),
+		}, calculable.OrderNo())
+		// 同じ馬で同じキーワードが複数回出ても1頭として数える
+		keywordMap := map[string]bool{}
+		for _, keyword := range calculable.CommentScore().Keywords() {
+			if keywordMap[keyword] {
+				continue
+			}
+			keywordMap[keyword] = true
+			counter.add(placeRateKey{
+				category: commentScoreKeywordCategory,
+				value:    keyword,
+				marker:   types.AnyMarker,
+			}, calculable.OrderNo())
+		}
+	}
+
+	keys := make([]placeRateKey, 0, (len(commentScoreBands)+1)*len(placeRateMarkers)+2*len(lexicon.Keywords()))
+	for _, bandName := range slices.Concat(commentScoreBands, []string{commentScoreNoComment}) {
+		for _, marker := range placeRateMarkers {
+			keys = append(keys, placeRateKey{
+				category: commentScoreBandCategory,
+				value:    bandName,
+				marker:   marker,
+			})
+		}
+	}
+	for _, keyword := range lexicon.Keywords() {
+		for _, word := range []string{keyword.Word(), keyword.Word() + commentScoreNegationSuffix} {
+			keys = append(keys, placeRateKey{
+				category: commentScoreKeywordCategory,
+				value:    word,
+				marker:   types.AnyMarker,
+			})
+		}
+	}
+
+	return counter.convert(keys, func(marker types.Marker) string {
+		if marker == types.AnyMarker {
+			return commentScoreAllMarker
+		}
+		return marker.String()
+	})
+}
+
+func (c *commentScoreService) Write(
+	ctx context.Context,
+	analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate,
+) error {
+	return c.spreadSheetRepository.WriteAnalysisCommentScore(ctx, analysisPlaceRates)
+}
+
+func (c *commentScoreService) band(commentScore *analysis_entity.CommentScore) string {
+	if !commentScore.HasComment() {
+		return commentScoreNoComment
+	}
+	switch score := commentScore.Score(); {
+	case score >= 3:
+		return commentScoreBands[0]
+	case score >= 1:
+		return commentScoreBands[1]
+	case score > -1:
+		return commentScoreBands[2]
+	case score > -3:
+		return commentScoreBands[3]
+	default:
+		return commentScoreBands[4]
+	}
+}
@@ -0,0 +1,165 @@
+package analysis_service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func TestCommentScoreConvert(t *testing.T) {
+	lexicon := analysis_entity.NewCommentLexicon([]*analysis_entity.CommentKeyword{
+		analysis_entity.NewCommentKeyword("好調", 2),
+		analysis_entity.NewCommentKeyword("疲れ", -1.5),
+	}, []string{"ない"}, 3, 0.5)
+	newCalculable := func(marker types.Marker, orderNo int, score float64, keywords []string, hasComment bool) *analysis_entity.CommentScoreCalculable {
+		return analysis_entity.NewCommentScoreCalculable("202405040811", 20241020, 1, marker, orderNo, analysis_entity.NewCommentScore(score, keywords, hasComment))
+	}
+
+	type row struct {
+		category   string
+		value      string
+		markerName string
+		raceCount  int
+		placeCount int
+	}
+	tests := []struct {
+		name        string
+		calculables []*analysis_entity.CommentScoreCalculable
+		want        []row
+	}{
+		{
+			name: "集計なし",
+		},
+		{
+			name: "スコア帯は印ごと、キーワードは印を問わず全体で数える",
+			calculables: []*analysis_entity.CommentScoreCalculable{
+				newCalculable(types.Favorite, 1, 4, []string{"好調", "好調"}, true),
+				newCalculable(types.NoMarker, 5, -1.5, []string{"疲れ"}, true),
+				newCalculable(types.NoMarker, 2, 1.5, []string{"疲れ(否定)"}, true),
+				newCalculable(types.Favorite, 3, 0, nil, false),
+			},
+			want: []row{
+				{category: "スコア", value: "+3以上", markerName: "◎", raceCount: 1, placeCount: 1},
+				{category: "スコア", value: "+1〜+3", markerName: "無", raceCount: 1, placeCount: 1},
+				{category: "スコア", value: "-1〜-3", markerName: "無", raceCount: 1, placeCount: 0},
+				{category: "スコア", value: "コメントなし", markerName: "◎", raceCount: 1, placeCount: 1},
+				// 同じ馬で同じキーワードが2回出ても1頭
+				{category: "キーワード", value: "好調", markerName: "全体", raceCount: 1, placeCount: 1},
+				{category: "キーワード", value: "疲れ", markerName: "全体", raceCount: 1, placeCount: 0},
+				{category: "キーワード", value: "疲れ(否定)", markerName: "全体", raceCount: 1, placeCount: 1},
+			},
+		},
+	}
+
+	c := &commentScoreService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []row
+			for _, r := range c.Convert(context.Background(), lexicon, tt.calculables) {
+				got = append(got, row{
+					category:   r.Category(),
+					value:      r.Value(),
+					markerName: r.MarkerName(),
+					raceCount:  r.RaceCount(),
+					placeCount: r.PlaceCount(),
+				})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Convert() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentScoreScore(t *testing.T) {
+	lexicon := analysis_entity.NewCommentLexicon([]*analysis_entity.CommentKeyword{
+		analysis_entity.NewCommentKeyword("好調", 2),
+		analysis_entity.NewCommentKeyword("好調時", 0),
+		analysis_entity.NewCommentKeyword("絶好調", 3),
+		analysis_entity.NewCommentKeyword("疲れ", -1.5),
+		analysis_entity.NewCommentKeyword("不安", -1),
+	}, []string{"ない"}, 3, 0.5)
+
+	tests := []struct {
+		name                    string
+		trainingComment         string
+		previousTrainingComment string
+		wantScore               float64
+		wantKeywords            []string
+		wantHasComment          bool
+	}{
+		{
+			name:           "コメントなし",
+			wantHasComment: false,
+		},
+		{
+			name:            "キーワードに一致",
+			trainingComment: "好調をキープ",
+			wantScore:       2,
+			wantKeywords:    []string{"好調"},
+			wantHasComment:  true,
+		},
+		{
+			name:            "同じキーワードは出現ごとに数える",
+			trainingComment: "好調、好調",
+			wantScore:       4,
+			wantKeywords:    []string{"好調", "好調"},
+			wantHasComment:  true,
+		},
+		{
+			name:            "長いキーワードを優先し、短いキーワードで二重に数えない",
+			trainingComment: "絶好調",
+			wantScore:       3,
+			wantKeywords:    []string{"絶好調"},
+			wantHasComment:  true,
+		},
+		{
+			name:            "0点のキーワードは短いキーワードへの一致を防ぐだけで数えない",
+			trainingComment: "好調時の動き",
+			wantScore:       0,
+			wantHasComment:  true,
+		},
+		{
+			name:            "直後の否定語で符号を反転する",
+			trainingComment: "不安はない",
+			wantScore:       1,
+			wantKeywords:    []string{"不安(否定)"},
+			wantHasComment:  true,
+		},
+		{
+			name:            "否定語が範囲外なら反転しない",
+			trainingComment: "不安は全くない",
+			wantScore:       -1,
+			wantKeywords:    []string{"不安"},
+			wantHasComment:  true,
+		},
+		{
+			name:                    "前走のコメントは重みを掛けて足す",
+			trainingComment:         "好調",
+			previousTrainingComment: "疲れ",
+			wantScore:               1.25,
+			wantKeywords:            []string{"好調", "疲れ"},
+			wantHasComment:          true,
+		},
+		{
+			name:                    "前走のコメントだけ",
+			previousTrainingComment: "好調",
+			wantScore:               1,
+			wantKeywords:            []string{"好調"},
+			wantHasComment:          true,
+		},
+	}
+
+	c := &commentScoreService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.Score(context.Background(), lexicon, tt.trainingComment, tt.previousTrainingComment)
+			if got.Score() != tt.wantScore || !reflect.DeepEqual(got.Keywords(), tt.wantKeywords) || got.HasComment() != tt.wantHasComment {
+				t.Errorf("Score() = %v %v %v, want %v %v %v", got.Score(), got.Keywords(), got.HasComment(), tt.wantScore, tt.wantKeywords, tt.wantHasComment)
+			}
+		})
+	}
+}
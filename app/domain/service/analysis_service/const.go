@@ -1,7 +1,5 @@
 package analysis_service
 
 const (
-	horseUrl               = "https://db.netkeiba.com/horse/%s?cache=false"
-	raceForecastUrl        = "https://tospo-keiba.jp/race/detail/%s/card"
-	raceTrainingCommentUrl = "https://tospo-keiba.jp/race/detail/%s/comment"
+	horseUrl = "https://db.netkeiba.com/horse/%s?cache=false"
 )
@@ -0,0 +1,22 @@
+package analysis_service
+
+import "github.com/mapserver2007/ipat-aggregator/app/domain/types"
+
+// holdoutIndex 日付順に並べたサンプルのうち、検証に回す先頭の位置を求める
+// 同じ日のレースが学習と検証の両方に入らないように日付の境目で分ける。検証の割合を下回らないように前の境目を優先し、
+// 前に無ければ後ろの境目を使う。全て同じ日付の場合は検証に回すサンプルが無いのでlen(raceDates)を返す
+func holdoutIndex(raceDates []types.RaceDate, validationRate float64) int {
+	index := int(float64(len(raceDates)) * (1 - validationRate))
+	for i := min(index, len(raceDates)-1); i > 0; i-- {
+		if raceDates[i-1] != raceDates[i] {
+			return i
+		}
+	}
+	for i := index + 1; i < len(raceDates); i++ {
+		if raceDates[i-1] != raceDates[i] {
+			return i
+		}
+	}
+
+	return len(raceDates)
+}
@@ -0,0 +1,48 @@
+package analysis_service
+
+import (
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func TestHoldoutIndex(t *testing.T) {
+	tests := []struct {
+		name      string
+		raceDates []types.RaceDate
+		want      int
+	}{
+		{
+			name:      "割合の位置が日付の境目",
+			raceDates: []types.RaceDate{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			want:      8,
+		},
+		{
+			name:      "同じ日付の途中なら前の境目まで検証に回す",
+			raceDates: []types.RaceDate{1, 1, 1, 2, 2, 2, 3, 3, 3, 3},
+			want:      6,
+		},
+		{
+			name:      "前に境目が無ければ後ろの境目",
+			raceDates: []types.RaceDate{1, 1, 1, 1, 1, 1, 1, 1, 1, 2},
+			want:      9,
+		},
+		{
+			name:      "全て同じ日付なら検証しない",
+			raceDates: []types.RaceDate{1, 1, 1, 1, 1},
+			want:      5,
+		},
+		{
+			name: "サンプルなし",
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := holdoutIndex(tt.raceDates, 0.2); got != tt.want {
+				t.Errorf("holdoutIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
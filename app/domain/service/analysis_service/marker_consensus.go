@@ -0,0 +1,255 @@
+package analysis_service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/filter_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	markerConsensusMinRaceCount = 10 // 回収率100%超えを強調するのに必要な最低レース数
+)
+
+var markerConsensuses = []types.MarkerConsensus{
+	types.ConsensusAgree,
+	types.ConsensusDisagree,
+	types.ConsensusUnmarked,
+}
+
+type MarkerConsensus interface {
+	Create(ctx context.Context,
+		markers []*marker_csv_entity.AnalysisMarker,
+		races []*data_cache_entity.Race,
+		raceForecasts []*data_cache_entity.RaceForecast,
+	) ([]*analysis_entity.MarkerConsensusCalculable, error)
+	Convert(ctx context.Context, calculables []*analysis_entity.MarkerConsensusCalculable) []*spreadsheet_entity.AnalysisMarkerConsensus
+	Write(ctx context.Context, analysisMarkerConsensuses []*spreadsheet_entity.AnalysisMarkerConsensus) error
+}
+
+type markerConsensusService struct {
+	spreadSheetRepository repository.SpreadSheetRepository
+	filterService         filter_service.AnalysisFilter
+}
+
+func NewMarkerConsensus(
+	spreadSheetRepository repository.SpreadSheetRepository,
+	filterService filter_service.AnalysisFilter,
+) MarkerConsensus {
+	return &markerConsensusService{
+		spreadSheetRepository: spreadSheetRepository,
+		filterService:         filterService,
+	}
+}
+
+// markerConsensusKey レース条件と一致状況の集計単位
+type markerConsensusKey struct {
+	attributeId filter.AttributeId
+	consensus   types.MarkerConsensus
+}
+
+type markerConsensusCount struct {
+	raceCount   int
+	winCount    int
+	placeCount  int
+	winPayout   decimal.Decimal
+	placePayout decimal.Decimal
+}
+
+func (c *markerConsensusCount) add(calculable *analysis_entity.MarkerConsensusCalculable) {
+	c.raceCount++
+	if calculable.OrderNo() == 1 {
+		c.winCount++
+		c.winPayout = c.winPayout.Add(calculable.Odds())
+	}
+	if calculable.OrderNo() >= 1 && calculable.OrderNo() <= 3 {
+		c.placeCount++
+		c.placePayout = c.placePayout.Add(calculable.PlaceOdds())
+	}
+}
+
+func (m *markerConsensusService) Create(
+	ctx context.Context,
+	markers []*marker_csv_entity.AnalysisMarker,
+	races []*data_cache_entity.Race,
+	raceForecasts []*data_cache_entity.RaceForecast,
+) ([]*analysis_entity.MarkerConsensusCalculable, error) {
+	markerMap := converter.ConvertToMap(markers, func(marker *marker_csv_entity.AnalysisMarker) types.RaceId {
+		return marker.RaceId()
+	})
+	raceForecastMap := converter.ConvertToMap(raceForecasts, func(raceForecast *data_cache_entity.RaceForecast) types.RaceId {
+		return raceForecast.RaceId()
+	})
+
+	var calculables []*analysis_entity.MarkerConsensusCalculable
+	for _, race := range races {
+		marker, ok := markerMap[race.RaceId()]
+		if !ok || marker.Favorite() == 0 {
+			continue
+		}
+		raceForecast, ok := raceForecastMap[race.RaceId()]
+		if !ok {
+			continue
+		}
+
+		consensus, favoriteForecast := m.getConsensus(marker.Favorite(), raceForecast)
+		if consensus == types.UnknownConsensus {
+			continue
+		}
+
+		raceResultMap := converter.ConvertToMap(race.RaceResults(), func(raceResult *data_cache_entity.RaceResult) types.HorseNumber {
+			return raceResult.HorseNumber()
+		})
+		raceResult, ok := raceResultMap[marker.Favorite()]
+		if !ok {
+			return nil, fmt.Errorf("horseNumber %v not found in raceId %v", marker.Favorite(), race.RaceId())
+		}
+		// 取り消し・除外の馬は集計対象外
+		if raceResult.Odds().IsZero() {
+			continue
+		}
+
+		placeOddsMap, err := createPlaceOddsMap(race)
+		if err != nil {
+			return nil, err
+		}
+
+		calculables = append(calculables, analysis_entity.NewMarkerConsensusCalculable(
+			race.RaceId(),
+			race.RaceDate(),
+			marker.Favorite(),
+			consensus,
+			favoriteForecast.FavoriteNum(),
+			favoriteForecast.MarkerNum(),
+			raceResult.OrderNo(),
+			raceResult.Odds(),
+			placeOddsMap[marker.Favorite().Value()],
+			m.filterService.CreateMarkerConsensusFilters(ctx, race),
+		))
+	}
+
+	return calculables, nil
+}
+
+func (m *markerConsensusService) Convert(
+	ctx context.Context,
+	calculables []*analysis_entity.MarkerConsensusCalculable,
+) []*spreadsheet_entity.AnalysisMarkerConsensus {
+	countMap := map[markerConsensusKey]*markerConsensusCount{}
+	attributeRaceCountMap := map[filter.AttributeId]int{}
+
+	for _, calculable := range calculables {
+		attributeIds := append([]filter.AttributeId{filter.All}, calculable.Filters()...)
+		for _, attributeId := range attributeIds {
+			attributeRaceCountMap[attributeId]++
+			key := markerConsensusKey{
+				attributeId: attributeId,
+				consensus:   calculable.Consensus(),
+			}
+			if _, ok := countMap[key]; !ok {
+				countMap[key] = &markerConsensusCount{}
+			}
+			countMap[key].add(calculable)
+		}
+	}
+
+	// 全レースを先頭に、以降は属性の値の大きい順(馬場、距離、開催場所、馬場状態、クラス)に並べる
+	attributeIds := make([]filter.AttributeId, 0, len(attributeRaceCountMap))
+	for attributeId := range attributeRaceCountMap {
+		attributeIds = append(attributeIds, attributeId)
+	}
+	sort.Slice(attributeIds, func(i, j int) bool {
+		if attributeIds[i] == filter.All || attributeIds[j] == filter.All {
+			return attributeIds[i] == filter.All
+		}
+		return attributeIds[i] > attributeIds[j]
+	})
+
+	analysisMarkerConsensuses := make([]*spreadsheet_entity.AnalysisMarkerConsensus, 0, len(countMap))
+	for _, attributeId := range attributeIds {
+		for _, consensus := range markerConsensuses {
+			count, ok := countMap[markerConsensusKey{
+				attributeId: attributeId,
+				consensus:   consensus,
+			}]
+			if !ok {
+				continue
+			}
+
+			winPayoutRate := count.winPayout.InexactFloat64() * 100 / float64(count.raceCount)
+			placePayoutRate := count.placePayout.InexactFloat64() * 100 / float64(count.raceCount)
+			isProfitable := count.raceCount >= markerConsensusMinRaceCount && (winPayoutRate >= 100 || placePayoutRate >= 100)
+
+			analysisMarkerConsensuses = append(analysisMarkerConsensuses, spreadsheet_entity.NewAnalysisMarkerConsensus(
+				attributeId,
+				consensus,
+				count.raceCount,
+				m.rateFormat(float64(count.raceCount), attributeRaceCountMap[attributeId]),
+				count.winCount,
+				count.placeCount,
+				m.rateFormat(float64(count.winCount), count.raceCount),
+				m.rateFormat(float64(count.placeCount), count.raceCount),
+				m.rateFormat(count.winPayout.InexactFloat64()*100, count.raceCount*100),
+				m.rateFormat(count.placePayout.InexactFloat64()*100, count.raceCount*100),
+				isProfitable,
+			))
+		}
+	}
+
+	return analysisMarkerConsensuses
+}
+
+func (m *markerConsensusService) Write(
+	ctx context.Context,
+	analysisMarkerConsensuses []*spreadsheet_entity.AnalysisMarkerConsensus,
+) error {
+	return m.spreadSheetRepository.WriteAnalysisMarkerConsensus(ctx, analysisMarkerConsensuses)
+}
+
+// getConsensus 自分の◎が東スポ予想陣の◎最多の馬(同数を含む)なら一致、印が1つも無ければ無印、それ以外は不一致とする
+// 予想陣の◎が取れていないレースは判定できない
+func (m *markerConsensusService) getConsensus(
+	favorite types.HorseNumber,
+	raceForecast *data_cache_entity.RaceForecast,
+) (types.MarkerConsensus, *data_cache_entity.Forecast) {
+	var (
+		maxFavoriteNum   int
+		favoriteForecast *data_cache_entity.Forecast
+	)
+	for _, forecast := range raceForecast.Forecasts() {
+		if forecast.FavoriteNum() > maxFavoriteNum {
+			maxFavoriteNum = forecast.FavoriteNum()
+		}
+		if forecast.HorseNumber() == favorite {
+			favoriteForecast = forecast
+		}
+	}
+
+	switch {
+	case maxFavoriteNum == 0 || favoriteForecast == nil:
+		return types.UnknownConsensus, nil
+	case favoriteForecast.MarkerNum() == 0:
+		return types.ConsensusUnmarked, favoriteForecast
+	case favoriteForecast.FavoriteNum() == maxFavoriteNum:
+		return types.ConsensusAgree, favoriteForecast
+	}
+
+	return types.ConsensusDisagree, favoriteForecast
+}
+
+func (m *markerConsensusService) rateFormat(numerator float64, denominator int) string {
+	if denominator == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", numerator*100/float64(denominator))
+}
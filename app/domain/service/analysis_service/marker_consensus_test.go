@@ -0,0 +1,261 @@
+package analysis_service
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/filter_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/shopspring/decimal"
+)
+
+func newTestConsensusForecast(horseNumber, favoriteNum, markerNum int) *data_cache_entity.Forecast {
+	return data_cache_entity.NewForecast(horseNumber, "", "", false, favoriteNum, 0, markerNum, nil, "", 0)
+}
+
+func TestMarkerConsensusGetConsensus(t *testing.T) {
+	raceForecast := data_cache_entity.NewRaceForecast("202405040811", 20241020, []*data_cache_entity.Forecast{
+		newTestConsensusForecast(1, 3, 5),
+		newTestConsensusForecast(2, 3, 4),
+		newTestConsensusForecast(3, 1, 2),
+		newTestConsensusForecast(4, 0, 0),
+	}, true)
+
+	tests := []struct {
+		name         string
+		favorite     types.HorseNumber
+		raceForecast *data_cache_entity.RaceForecast
+		want         types.MarkerConsensus
+	}{
+		{
+			name:         "予想陣の◎最多の馬",
+			favorite:     1,
+			raceForecast: raceForecast,
+			want:         types.ConsensusAgree,
+		},
+		{
+			name:         "◎最多と同数の馬も一致",
+			favorite:     2,
+			raceForecast: raceForecast,
+			want:         types.ConsensusAgree,
+		},
+		{
+			name:         "◎最多でない馬",
+			favorite:     3,
+			raceForecast: raceForecast,
+			want:         types.ConsensusDisagree,
+		},
+		{
+			name:         "予想陣の印が1つも無い馬",
+			favorite:     4,
+			raceForecast: raceForecast,
+			want:         types.ConsensusUnmarked,
+		},
+		{
+			name:         "予想が無い馬",
+			favorite:     5,
+			raceForecast: raceForecast,
+			want:         types.UnknownConsensus,
+		},
+		{
+			name:     "予想陣の◎が取れていないレース",
+			favorite: 1,
+			raceForecast: data_cache_entity.NewRaceForecast("202405040811", 20241020, []*data_cache_entity.Forecast{
+				newTestConsensusForecast(1, 0, 0),
+			}, true),
+			want: types.UnknownConsensus,
+		},
+	}
+
+	m := &markerConsensusService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, forecast := m.getConsensus(tt.favorite, tt.raceForecast)
+			if got != tt.want {
+				t.Errorf("getConsensus() = %v, want %v", got, tt.want)
+			}
+			if (forecast == nil) != (tt.want == types.UnknownConsensus) {
+				t.Errorf("getConsensus() forecast = %v", forecast)
+			}
+		})
+	}
+}
+
+func TestMarkerConsensusCreate(t *testing.T) {
+	newRace := func(raceId string, favoriteOdds string) *data_cache_entity.Race {
+		return data_cache_entity.NewRace(raceId, 20241020, 11, types.Tokyo, "テスト", 1, "", "", "15:40", 2, 1600, 0, 1, 1, 0, 0, 0, 0,
+			[]*data_cache_entity.RaceResult{
+				data_cache_entity.NewRaceResult(2, "h1", "", 1, 1, "j1", favoriteOdds, 2, "", 0, 0, "", "", "", "t1", 0),
+				data_cache_entity.NewRaceResult(1, "h2", "", 2, 2, "j2", "2.0", 1, "", 0, 0, "", "", "", "t2", 0),
+			},
+			[]*data_cache_entity.PayoutResult{
+				data_cache_entity.NewPayoutResult(types.Place.Value(), []string{"01", "02"}, []string{"1.2", "1.8"}, []int{2, 1}),
+			}, true)
+	}
+	newMarker := func(raceId string, favorite string) *marker_csv_entity.AnalysisMarker {
+		marker, err := marker_csv_entity.NewAnalysisMarker("20241020", raceId, favorite, "2", "0", "0", "0", "0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return marker
+	}
+	newRaceForecast := func(raceId string) *data_cache_entity.RaceForecast {
+		return data_cache_entity.NewRaceForecast(raceId, 20241020, []*data_cache_entity.Forecast{
+			newTestConsensusForecast(1, 2, 3),
+			newTestConsensusForecast(2, 1, 3),
+		}, true)
+	}
+
+	tests := []struct {
+		name          string
+		markers       []*marker_csv_entity.AnalysisMarker
+		races         []*data_cache_entity.Race
+		raceForecasts []*data_cache_entity.RaceForecast
+		wantRaceIds   []types.RaceId
+		wantErr       bool
+	}{
+		{
+			name: "印と予想が揃ったレースだけを対象にする",
+			markers: []*marker_csv_entity.AnalysisMarker{
+				newMarker("202405040801", "1"),
+				newMarker("202405040802", "1"),
+				newMarker("202405040804", "1"),
+			},
+			races: []*data_cache_entity.Race{
+				newRace("202405040801", "3.0"),
+				// 予想が無い
+				newRace("202405040802", "3.0"),
+				// 印が無い
+				newRace("202405040803", "3.0"),
+				// ◎が取り消し
+				newRace("202405040804", "0"),
+			},
+			raceForecasts: []*data_cache_entity.RaceForecast{
+				newRaceForecast("202405040801"),
+				newRaceForecast("202405040803"),
+				newRaceForecast("202405040804"),
+			},
+			wantRaceIds: []types.RaceId{"202405040801"},
+		},
+		{
+			name:          "◎の馬がレース結果に無い",
+			markers:       []*marker_csv_entity.AnalysisMarker{newMarker("202405040801", "9")},
+			races:         []*data_cache_entity.Race{newRace("202405040801", "3.0")},
+			raceForecasts: []*data_cache_entity.RaceForecast{data_cache_entity.NewRaceForecast("202405040801", 20241020, []*data_cache_entity.Forecast{newTestConsensusForecast(9, 1, 1)}, true)},
+			wantErr:       true,
+		},
+	}
+
+	m := &markerConsensusService{filterService: filter_service.NewAnalysisFilter()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculables, err := m.Create(context.Background(), tt.markers, tt.races, tt.raceForecasts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var raceIds []types.RaceId
+			for _, calculable := range calculables {
+				raceIds = append(raceIds, calculable.RaceId())
+			}
+			if !reflect.DeepEqual(raceIds, tt.wantRaceIds) {
+				t.Fatalf("Create() race ids = %v, want %v", raceIds, tt.wantRaceIds)
+			}
+
+			calculable := calculables[0]
+			if calculable.Consensus() != types.ConsensusAgree || calculable.FavoriteNum() != 2 || calculable.MarkerNum() != 3 ||
+				calculable.OrderNo() != 2 || !calculable.Odds().Equal(decimal.RequireFromString("3.0")) ||
+				!calculable.PlaceOdds().Equal(decimal.RequireFromString("1.2")) {
+				t.Errorf("Create() = %+v", calculable)
+			}
+			if !slices.Contains(calculable.Filters(), filter.Tokyo) {
+				t.Errorf("Create() filters = %v, want to contain %v", calculable.Filters(), filter.Tokyo)
+			}
+		})
+	}
+}
+
+func TestMarkerConsensusConvert(t *testing.T) {
+	type row struct {
+		attributeId     filter.AttributeId
+		consensus       types.MarkerConsensus
+		raceCount       int
+		raceRate        string
+		winRate         string
+		placeRate       string
+		winPayoutRate   string
+		placePayoutRate string
+		isProfitable    bool
+	}
+	newCalculable := func(consensus types.MarkerConsensus, orderNo int, odds, placeOdds string, filters ...filter.AttributeId) *analysis_entity.MarkerConsensusCalculable {
+		return analysis_entity.NewMarkerConsensusCalculable("202405040811", 20241020, 1, consensus, 1, 1, orderNo,
+			decimal.RequireFromString(odds), decimal.RequireFromString(placeOdds), filters)
+	}
+
+	profitableCalculables := make([]*analysis_entity.MarkerConsensusCalculable, 0, markerConsensusMinRaceCount)
+	for range markerConsensusMinRaceCount {
+		profitableCalculables = append(profitableCalculables, newCalculable(types.ConsensusAgree, 3, "5.0", "1.1"))
+	}
+
+	tests := []struct {
+		name        string
+		calculables []*analysis_entity.MarkerConsensusCalculable
+		want        []row
+	}{
+		{
+			name: "集計なし",
+		},
+		{
+			name: "全レースを先頭に属性の値の大きい順、一致状況の順に並べる",
+			calculables: []*analysis_entity.MarkerConsensusCalculable{
+				newCalculable(types.ConsensusDisagree, 2, "4.0", "2.0", filter.Dirt),
+				newCalculable(types.ConsensusAgree, 1, "3.0", "1.5", filter.Turf),
+				newCalculable(types.ConsensusAgree, 4, "5.0", "0", filter.Turf),
+			},
+			want: []row{
+				{attributeId: filter.All, consensus: types.ConsensusAgree, raceCount: 2, raceRate: "66.67%", winRate: "50.00%", placeRate: "50.00%", winPayoutRate: "150.00%", placePayoutRate: "75.00%"},
+				{attributeId: filter.All, consensus: types.ConsensusDisagree, raceCount: 1, raceRate: "33.33%", winRate: "0.00%", placeRate: "100.00%", winPayoutRate: "0.00%", placePayoutRate: "200.00%"},
+				{attributeId: filter.Turf, consensus: types.ConsensusAgree, raceCount: 2, raceRate: "100.00%", winRate: "50.00%", placeRate: "50.00%", winPayoutRate: "150.00%", placePayoutRate: "75.00%"},
+				{attributeId: filter.Dirt, consensus: types.ConsensusDisagree, raceCount: 1, raceRate: "100.00%", winRate: "0.00%", placeRate: "100.00%", winPayoutRate: "0.00%", placePayoutRate: "200.00%"},
+			},
+		},
+		{
+			name:        "最低レース数以上で回収率100%超えを強調する",
+			calculables: profitableCalculables,
+			want: []row{
+				{attributeId: filter.All, consensus: types.ConsensusAgree, raceCount: markerConsensusMinRaceCount, raceRate: "100.00%", winRate: "0.00%", placeRate: "100.00%", winPayoutRate: "0.00%", placePayoutRate: "110.00%", isProfitable: true},
+			},
+		},
+	}
+
+	m := &markerConsensusService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []row
+			for _, r := range m.Convert(context.Background(), tt.calculables) {
+				got = append(got, row{
+					attributeId:     r.AttributeId(),
+					consensus:       r.Consensus(),
+					raceCount:       r.RaceCount(),
+					raceRate:        r.RaceRate(),
+					winRate:         r.WinRate(),
+					placeRate:       r.PlaceRate(),
+					winPayoutRate:   r.WinPayoutRate(),
+					placePayoutRate: r.PlacePayoutRate(),
+					isProfitable:    r.IsProfitable(),
+				})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Convert() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
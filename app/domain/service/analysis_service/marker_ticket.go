@@ -0,0 +1,273 @@
+package analysis_service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+const (
+	markerTicketCombinationCategory = "組み合わせ"
+	markerTicketPatternCategory     = "買い方"
+)
+
+type MarkerTicket interface {
+	Create(ctx context.Context,
+		tickets []*ticket_csv_entity.RaceTicket,
+		markers []*marker_csv_entity.AnalysisMarker,
+	) ([]*analysis_entity.MarkerTicket, error)
+	Convert(ctx context.Context, markerTickets []*analysis_entity.MarkerTicket) []*spreadsheet_entity.AnalysisMarkerTicket
+	Write(ctx context.Context, analysisMarkerTickets []*spreadsheet_entity.AnalysisMarkerTicket) error
+}
+
+type markerTicketService struct {
+	spreadSheetRepository repository.SpreadSheetRepository
+}
+
+func NewMarkerTicket(
+	spreadSheetRepository repository.SpreadSheetRepository,
+) MarkerTicket {
+	return &markerTicketService{
+		spreadSheetRepository: spreadSheetRepository,
+	}
+}
+
+type markerTicketCount struct {
+	betCount int
+	hitCount int
+	payment  int
+	payout   int
+}
+
+// markerTicketPatternKey レース単位の買い方(券種×購入した印の集合)
+type markerTicketPatternKey struct {
+	ticketType types.TicketType
+	markerName string
+}
+
+func (m *markerTicketService) Create(
+	ctx context.Context,
+	tickets []*ticket_csv_entity.RaceTicket,
+	markers []*marker_csv_entity.AnalysisMarker,
+) ([]*analysis_entity.MarkerTicket, error) {
+	markerMap := converter.ConvertToMap(markers, func(marker *marker_csv_entity.AnalysisMarker) types.RaceId {
+		return marker.RaceId()
+	})
+
+	var markerTickets []*analysis_entity.MarkerTicket
+	for _, raceTicket := range tickets {
+		ticket := raceTicket.Ticket()
+		// 枠連は馬番と印を対応付けられないので対象外
+		if ticket.TicketType().OriginTicketType() == types.BracketQuinella {
+			continue
+		}
+		// 払戻結果が無く1点ずつにバラせなかった地方競馬のまとめ買いは的中した買い目が分からないので対象外
+		if ticket.BetNumber().Grouped() {
+			continue
+		}
+		// 印を打っていないレースは対象外
+		marker, ok := markerMap[raceTicket.RaceId()]
+		if !ok {
+			continue
+		}
+
+		horseNumberMarkerMap := map[int]types.Marker{}
+		for markerType, horseNumber := range marker.MarkerMap() {
+			horseNumberMarkerMap[horseNumber.Value()] = markerType
+		}
+
+		betNumbers := ticket.BetNumber().List()
+		ticketMarkers := make([]types.Marker, 0, len(betNumbers))
+		for _, betNumber := range betNumbers {
+			markerType, ok := horseNumberMarkerMap[betNumber]
+			if !ok {
+				markerType = types.NoMarker
+			}
+			ticketMarkers = append(ticketMarkers, markerType)
+		}
+
+		markerCombinationId, err := types.NewMarkerCombinationIdByMarkers(ticket.TicketType(), ticketMarkers)
+		if err != nil {
+			return nil, fmt.Errorf("raceId %v: %w", raceTicket.RaceId(), err)
+		}
+
+		markerTickets = append(markerTickets, analysis_entity.NewMarkerTicket(
+			raceTicket.RaceId(),
+			ticket.RaceDate(),
+			ticket.TicketType(),
+			markerCombinationId,
+			ticketMarkers,
+			ticket.TicketResult(),
+			ticket.Payment(),
+			ticket.Payout(),
+		))
+	}
+
+	return markerTickets, nil
+}
+
+func (m *markerTicketService) Convert(
+	ctx context.Context,
+	markerTickets []*analysis_entity.MarkerTicket,
+) []*spreadsheet_entity.AnalysisMarkerTicket {
+	combinationCountMap := map[types.MarkerCombinationId]*markerTicketCount{}
+	raceTicketMap := map[types.RaceId]map[types.TicketType][]*analysis_entity.MarkerTicket{}
+	for _, markerTicket := range markerTickets {
+		if _, ok := combinationCountMap[markerTicket.MarkerCombinationId()]; !ok {
+			combinationCountMap[markerTicket.MarkerCombinationId()] = &markerTicketCount{}
+		}
+		count := combinationCountMap[markerTicket.MarkerCombinationId()]
+		count.betCount++
+		count.payment += markerTicket.Payment().Value()
+		count.payout += markerTicket.Payout().Value()
+		if markerTicket.TicketResult() == types.TicketHit {
+			count.hitCount++
+		}
+
+		if _, ok := raceTicketMap[markerTicket.RaceId()]; !ok {
+			raceTicketMap[markerTicket.RaceId()] = map[types.TicketType][]*analysis_entity.MarkerTicket{}
+		}
+		raceTicketMap[markerTicket.RaceId()][markerTicket.TicketType()] = append(raceTicketMap[markerTicket.RaceId()][markerTicket.TicketType()], markerTicket)
+	}
+
+	patternCountMap := map[markerTicketPatternKey]*markerTicketCount{}
+	for _, ticketTypeMap := range raceTicketMap {
+		for ticketType, raceMarkerTickets := range ticketTypeMap {
+			key := markerTicketPatternKey{
+				ticketType: ticketType,
+				markerName: m.getPatternMarkerName(raceMarkerTickets),
+			}
+			if _, ok := patternCountMap[key]; !ok {
+				patternCountMap[key] = &markerTicketCount{}
+			}
+			count := patternCountMap[key]
+			count.betCount++
+			isHit := false
+			for _, markerTicket := range raceMarkerTickets {
+				count.payment += markerTicket.Payment().Value()
+				count.payout += markerTicket.Payout().Value()
+				if markerTicket.TicketResult() == types.TicketHit {
+					isHit = true
+				}
+			}
+			if isHit {
+				count.hitCount++
+			}
+		}
+	}
+
+	combinationIds := make([]types.MarkerCombinationId, 0, len(combinationCountMap))
+	for combinationId := range combinationCountMap {
+		combinationIds = append(combinationIds, combinationId)
+	}
+	sort.Slice(combinationIds, func(i, j int) bool {
+		ticketTypeI, ticketTypeJ := combinationIds[i].TicketType(), combinationIds[j].TicketType()
+		if ticketTypeI != ticketTypeJ {
+			return ticketTypeI < ticketTypeJ
+		}
+		return combinationIds[i] < combinationIds[j]
+	})
+
+	patternKeys := make([]markerTicketPatternKey, 0, len(patternCountMap))
+	for key := range patternCountMap {
+		patternKeys = append(patternKeys, key)
+	}
+	sort.Slice(patternKeys, func(i, j int) bool {
+		if patternKeys[i].ticketType != patternKeys[j].ticketType {
+			return patternKeys[i].ticketType < patternKeys[j].ticketType
+		}
+		countI, countJ := patternCountMap[patternKeys[i]].betCount, patternCountMap[patternKeys[j]].betCount
+		if countI != countJ {
+			return countI > countJ
+		}
+		return patternKeys[i].markerName < patternKeys[j].markerName
+	})
+
+	analysisMarkerTickets := make([]*spreadsheet_entity.AnalysisMarkerTicket, 0, len(combinationIds)+len(patternKeys))
+	for _, combinationId := range combinationIds {
+		analysisMarkerTickets = append(analysisMarkerTickets, m.createAnalysisMarkerTicket(
+			markerTicketCombinationCategory,
+			combinationId.TicketType().Name(),
+			combinationId.String(),
+			combinationCountMap[combinationId],
+		))
+	}
+	for _, key := range patternKeys {
+		analysisMarkerTickets = append(analysisMarkerTickets, m.createAnalysisMarkerTicket(
+			markerTicketPatternCategory,
+			key.ticketType.Name(),
+			key.markerName,
+			patternCountMap[key],
+		))
+	}
+
+	return analysisMarkerTickets
+}
+
+func (m *markerTicketService) Write(
+	ctx context.Context,
+	analysisMarkerTickets []*spreadsheet_entity.AnalysisMarkerTicket,
+) error {
+	return m.spreadSheetRepository.WriteAnalysisMarkerTicket(ctx, analysisMarkerTickets)
+}
+
+// getPatternMarkerName レース内で同じ券種で購入した印の集合を返す(例: ◎◯▲)
+func (m *markerTicketService) getPatternMarkerName(markerTickets []*analysis_entity.MarkerTicket) string {
+	markerSet := map[types.Marker]struct{}{}
+	for _, markerTicket := range markerTickets {
+		for _, marker := range markerTicket.Markers() {
+			markerSet[marker] = struct{}{}
+		}
+	}
+
+	sortedMarkers := make([]types.Marker, 0, len(markerSet))
+	for marker := range markerSet {
+		sortedMarkers = append(sortedMarkers, marker)
+	}
+	sort.Slice(sortedMarkers, func(i, j int) bool {
+		return sortedMarkers[i] < sortedMarkers[j]
+	})
+
+	var builder strings.Builder
+	for _, marker := range sortedMarkers {
+		builder.WriteString(marker.String())
+	}
+
+	return builder.String()
+}
+
+func (m *markerTicketService) createAnalysisMarkerTicket(
+	category string,
+	ticketTypeName string,
+	markerName string,
+	count *markerTicketCount,
+) *spreadsheet_entity.AnalysisMarkerTicket {
+	hitRate, payoutRate := "-", "-"
+	if count.betCount > 0 {
+		hitRate = fmt.Sprintf("%.2f%%", float64(count.hitCount)*100/float64(count.betCount))
+	}
+	if count.payment > 0 {
+		payoutRate = fmt.Sprintf("%.2f%%", float64(count.payout)*100/float64(count.payment))
+	}
+
+	return spreadsheet_entity.NewAnalysisMarkerTicket(
+		category,
+		ticketTypeName,
+		markerName,
+		count.betCount,
+		count.hitCount,
+		count.payment,
+		count.payout,
+		hitRate,
+		payoutRate,
+	)
+}
@@ -0,0 +1,160 @@
+package analysis_service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func newTestMarkerRaceTicket(t *testing.T, raceId types.RaceId, betNumber, ticketType, payment, payout string) *ticket_csv_entity.RaceTicket {
+	t.Helper()
+	ticket, err := ticket_csv_entity.NewTicket(types.BetNumber(betNumber), "20241020", "東京", "11", ticketType, payout != "0", payment, payout, types.Ipat, types.DefaultAccount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ticket_csv_entity.NewRaceTicket(raceId, ticket)
+}
+
+func TestMarkerTicketCreate(t *testing.T) {
+	// ◎1 ◯2 ▲3 △4 ☆5 ✓6
+	marker, err := marker_csv_entity.NewAnalysisMarker("20241020", "202405040811", "1", "2", "3", "4", "5", "6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	markers := []*marker_csv_entity.AnalysisMarker{marker}
+
+	type markerTicket struct {
+		markerCombinationId string
+		markers             []types.Marker
+		ticketResult        types.TicketResult
+	}
+	tests := []struct {
+		name    string
+		tickets []*ticket_csv_entity.RaceTicket
+		want    []markerTicket
+	}{
+		{
+			name: "馬券なし",
+			want: []markerTicket{},
+		},
+		{
+			name: "着順を問わない券種は印の順に揃える",
+			tickets: []*ticket_csv_entity.RaceTicket{
+				newTestMarkerRaceTicket(t, "202405040811", "03-01", "馬連", "100", "0"),
+				newTestMarkerRaceTicket(t, "202405040811", "01-02-03", "3連複", "100", "1200"),
+			},
+			want: []markerTicket{
+				{markerCombinationId: "◎-▲", markers: []types.Marker{types.BrackTriangle, types.Favorite}, ticketResult: types.TicketUnHit},
+				{markerCombinationId: "◎-◯-▲", markers: []types.Marker{types.Favorite, types.Rival, types.BrackTriangle}, ticketResult: types.TicketHit},
+			},
+		},
+		{
+			name: "着順のある券種は並びのまま、印の無い馬は無印",
+			tickets: []*ticket_csv_entity.RaceTicket{
+				newTestMarkerRaceTicket(t, "202405040811", "07→01", "馬単", "100", "0"),
+			},
+			want: []markerTicket{
+				{markerCombinationId: "無→◎", markers: []types.Marker{types.NoMarker, types.Favorite}, ticketResult: types.TicketUnHit},
+			},
+		},
+		{
+			name: "印の無いレース、枠連、まとめたままの買い目は対象外",
+			tickets: []*ticket_csv_entity.RaceTicket{
+				newTestMarkerRaceTicket(t, "202405040812", "01", "単勝", "100", "0"),
+				newTestMarkerRaceTicket(t, "202405040811", "1-2", "枠連", "100", "0"),
+				newTestMarkerRaceTicket(t, "202405040811", "01；02；03", "3連複ＢＯＸ", "300", "0"),
+			},
+			want: []markerTicket{},
+		},
+	}
+
+	m := &markerTicketService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markerTickets, err := m.Create(context.Background(), tt.tickets, markers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]markerTicket, 0, len(markerTickets))
+			for _, mt := range markerTickets {
+				got = append(got, markerTicket{
+					markerCombinationId: mt.MarkerCombinationId().String(),
+					markers:             mt.Markers(),
+					ticketResult:        mt.TicketResult(),
+				})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Create() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkerTicketConvert(t *testing.T) {
+	newMarkerTicket := func(raceId types.RaceId, ticketType types.TicketType, markers []types.Marker, payment, payout int) *analysis_entity.MarkerTicket {
+		markerCombinationId, err := types.NewMarkerCombinationIdByMarkers(ticketType, markers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ticketResult := types.TicketUnHit
+		if payout > 0 {
+			ticketResult = types.TicketHit
+		}
+		return analysis_entity.NewMarkerTicket(raceId, 20241020, ticketType, markerCombinationId, markers, ticketResult, types.Payment(payment), types.Payout(payout))
+	}
+
+	type row struct {
+		category   string
+		ticketType string
+		markerName string
+		betCount   int
+		hitCount   int
+		hitRate    string
+		payoutRate string
+	}
+	tests := []struct {
+		name          string
+		markerTickets []*analysis_entity.MarkerTicket
+		want          []row
+	}{
+		{
+			name: "データなし",
+			want: []row{},
+		},
+		{
+			name: "組み合わせは買い目ごと、買い方はレースごとに数え、同数の買い方は印の順に並べる",
+			markerTickets: []*analysis_entity.MarkerTicket{
+				newMarkerTicket("202405040811", types.Quinella, []types.Marker{types.Favorite, types.Rival}, 100, 500),
+				newMarkerTicket("202405040811", types.Quinella, []types.Marker{types.Favorite, types.BrackTriangle}, 100, 0),
+				newMarkerTicket("202405040812", types.Quinella, []types.Marker{types.Favorite, types.Rival}, 200, 0),
+				newMarkerTicket("202405040812", types.Win, []types.Marker{types.Favorite}, 100, 0),
+			},
+			want: []row{
+				{markerTicketCombinationCategory, "単勝", "◎", 1, 0, "0.00%", "0.00%"},
+				{markerTicketCombinationCategory, "馬連", "◎-◯", 2, 1, "50.00%", "166.67%"},
+				{markerTicketCombinationCategory, "馬連", "◎-▲", 1, 0, "0.00%", "0.00%"},
+				{markerTicketPatternCategory, "単勝", "◎", 1, 0, "0.00%", "0.00%"},
+				{markerTicketPatternCategory, "馬連", "◎◯", 1, 0, "0.00%", "0.00%"},
+				{markerTicketPatternCategory, "馬連", "◎◯▲", 1, 1, "100.00%", "250.00%"},
+			},
+		},
+	}
+
+	m := &markerTicketService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]row, 0)
+			for _, a := range m.Convert(context.Background(), tt.markerTickets) {
+				got = append(got, row{a.Category(), a.TicketTypeName(), a.MarkerName(), a.BetCount(), a.HitCount(), a.HitRate(), a.PayoutRate()})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Convert() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package analysis_service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/filter_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	pedigreeMinRaceCount = 10   // 得意判定に必要な最低出走数
+	pedigreeStrongZScore = 1.96 // 得意判定の閾値(片側2.5%)
+)
+
+type Pedigree interface {
+	Create(ctx context.Context,
+		markers []*marker_csv_entity.AnalysisMarker,
+		races []*data_cache_entity.Race,
+		horseMap map[types.HorseId]*data_cache_entity.Horse,
+	) ([]*analysis_entity.PedigreeCalculable, error)
+	Convert(ctx context.Context,
+		calculables []*analysis_entity.PedigreeCalculable,
+	) []*spreadsheet_entity.AnalysisPedigree
+	FetchHorse(ctx context.Context, horseId types.HorseId) (*netkeiba_entity.Horse, error)
+	Write(ctx context.Context, analysisPedigrees []*spreadsheet_entity.AnalysisPedigree) error
+}
+
+type pedigreeService struct {
+	horseRepository       repository.HorseRepository
+	spreadSheetRepository repository.SpreadSheetRepository
+	filterService         filter_service.AnalysisFilter
+}
+
+func NewPedigree(
+	horseRepository repository.HorseRepository,
+	spreadSheetRepository repository.SpreadSheetRepository,
+	filterService filter_service.AnalysisFilter,
+) Pedigree {
+	return &pedigreeService{
+		horseRepository:       horseRepository,
+		spreadSheetRepository: spreadSheetRepository,
+		filterService:         filterService,
+	}
+}
+
+// pedigreeKey 種牡馬とレース条件の集計単位
+type pedigreeKey struct {
+	pedigreeLine types.PedigreeLine
+	sireId       types.HorseId
+	attributeId  filter.AttributeId
+	isFirstDirt  bool
+}
+
+// conditionKey 種牡馬を問わないレース条件の集計単位(得意判定の基準値に使う)
+type conditionKey struct {
+	attributeId filter.AttributeId
+	isFirstDirt bool
+}
+
+type pedigreeCount struct {
+	raceCount   int
+	winCount    int
+	placeCount  int
+	winPayout   decimal.Decimal
+	placePayout decimal.Decimal
+}
+
+func (c *pedigreeCount) add(calculable *analysis_entity.PedigreeCalculable) {
+	c.raceCount++
+	if calculable.OrderNo() == 1 {
+		c.winCount++
+		c.winPayout = c.winPayout.Add(calculable.Odds())
+	}
+	if calculable.OrderNo() >= 1 && calculable.OrderNo() <= 3 {
+		c.placeCount++
+		c.placePayout = c.placePayout.Add(calculable.PlaceOdds())
+	}
+}
+
+// Create 印の付いた馬だけを集計対象にする。無印の馬は血統を取得していないため含まない
+func (p *pedigreeService) Create(
+	ctx context.Context,
+	markers []*marker_csv_entity.AnalysisMarker,
+	races []*data_cache_entity.Race,
+	horseMap map[types.HorseId]*data_cache_entity.Horse,
+) ([]*analysis_entity.PedigreeCalculable, error) {
+	markerMap := converter.ConvertToMap(markers, func(marker *marker_csv_entity.AnalysisMarker) types.RaceId {
+		return marker.RaceId()
+	})
+
+	var calculables []*analysis_entity.PedigreeCalculable
+	for _, race := range races {
+		marker, ok := markerMap[race.RaceId()]
+		if !ok || race.CourseCategory() == types.Jump {
+			continue
+		}
+
+		raceResultMap := converter.ConvertToMap(race.RaceResults(), func(raceResult *data_cache_entity.RaceResult) types.HorseNumber {
+			return raceResult.HorseNumber()
+		})
+
+		placeOddsMap, err := createPlaceOddsMap(race)
+		if err != nil {
+			return nil, err
+		}
+
+		filters := p.filterService.CreatePedigreeFilters(ctx, race)
+		for _, horseNumber := range marker.MarkerMap() {
+			raceResult, ok := raceResultMap[horseNumber]
+			if !ok {
+				return nil, fmt.Errorf("horseNumber %v not found in raceId %v", horseNumber, race.RaceId())
+			}
+			// 取り消し・除外の馬は集計対象外
+			if raceResult.Odds().IsZero() {
+				continue
+			}
+			horse, ok := horseMap[raceResult.HorseId()]
+			if !ok || horse.HorseBlood() == nil || horse.HorseBlood().SireId() == "" {
+				continue
+			}
+
+			calculables = append(calculables, analysis_entity.NewPedigreeCalculable(
+				race.RaceId(),
+				race.RaceDate(),
+				horse.HorseId(),
+				horse.HorseBlood().SireId(),
+				horse.HorseBlood().SireName(),
+				horse.HorseBlood().BroodmareSireId(),
+				horse.HorseBlood().BroodmareSireName(),
+				raceResult.OrderNo(),
+				raceResult.Odds(),
+				placeOddsMap[horseNumber.Value()],
+				race.CourseCategory() == types.Dirt && p.isFirstDirt(horse, race.RaceDate()),
+				filters,
+			))
+		}
+	}
+
+	return calculables, nil
+}
+
+func (p *pedigreeService) Convert(
+	ctx context.Context,
+	calculables []*analysis_entity.PedigreeCalculable,
+) []*spreadsheet_entity.AnalysisPedigree {
+	pedigreeCountMap := map[pedigreeKey]*pedigreeCount{}
+	conditionCountMap := map[conditionKey]*pedigreeCount{}
+	sireNameMap := map[types.HorseId]string{}
+	sireTotalMap := map[types.PedigreeLine]map[types.HorseId]int{
+		types.SireLine:          {},
+		types.BroodmareSireLine: {},
+	}
+
+	for _, calculable := range calculables {
+		sires := []struct {
+			pedigreeLine types.PedigreeLine
+			sireId       types.HorseId
+			sireName     string
+		}{
+			{types.SireLine, calculable.SireId(), calculable.SireName()},
+			{types.BroodmareSireLine, calculable.BroodmareSireId(), calculable.BroodmareSireName()},
+		}
+
+		conditionKeys := p.getConditionKeys(calculable)
+		for _, key := range conditionKeys {
+			if _, ok := conditionCountMap[key]; !ok {
+				conditionCountMap[key] = &pedigreeCount{}
+			}
+			conditionCountMap[key].add(calculable)
+		}
+
+		for _, sire := range sires {
+			if sire.sireId == "" {
+				continue
+			}
+			if sire.sireName != "" {
+				sireNameMap[sire.sireId] = sire.sireName
+			}
+			sireTotalMap[sire.pedigreeLine][sire.sireId]++
+			for _, key := range conditionKeys {
+				pk := pedigreeKey{
+					pedigreeLine: sire.pedigreeLine,
+					sireId:       sire.sireId,
+					attributeId:  key.attributeId,
+					isFirstDirt:  key.isFirstDirt,
+				}
+				if _, ok := pedigreeCountMap[pk]; !ok {
+					pedigreeCountMap[pk] = &pedigreeCount{}
+				}
+				pedigreeCountMap[pk].add(calculable)
+			}
+		}
+	}
+
+	keys := make([]pedigreeKey, 0, len(pedigreeCountMap))
+	for key := range pedigreeCountMap {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].pedigreeLine != keys[j].pedigreeLine {
+			return keys[i].pedigreeLine < keys[j].pedigreeLine
+		}
+		if keys[i].sireId != keys[j].sireId {
+			totalI := sireTotalMap[keys[i].pedigreeLine][keys[i].sireId]
+			totalJ := sireTotalMap[keys[j].pedigreeLine][keys[j].sireId]
+			if totalI != totalJ {
+				return totalI > totalJ
+			}
+			return keys[i].sireId < keys[j].sireId
+		}
+		orderI, orderJ := p.getKeyOrder(keys[i]), p.getKeyOrder(keys[j])
+		if orderI != orderJ {
+			return orderI < orderJ
+		}
+		return keys[i].attributeId > keys[j].attributeId
+	})
+
+	analysisPedigrees := make([]*spreadsheet_entity.AnalysisPedigree, 0, len(keys))
+	for _, key := range keys {
+		count := pedigreeCountMap[key]
+		sireName, ok := sireNameMap[key.sireId]
+		if !ok {
+			sireName = key.sireId.Value()
+		}
+
+		isStrong := false
+		if key.isFirstDirt || p.isHeavyTrack(key.attributeId) {
+			isStrong = p.isStrong(count, conditionCountMap[conditionKey{
+				attributeId: key.attributeId,
+				isFirstDirt: key.isFirstDirt,
+			}])
+		}
+
+		analysisPedigrees = append(analysisPedigrees, spreadsheet_entity.NewAnalysisPedigree(
+			key.pedigreeLine,
+			key.sireId,
+			sireName,
+			key.attributeId,
+			key.isFirstDirt,
+			count.raceCount,
+			count.winCount,
+			count.placeCount,
+			p.rateFormat(float64(count.winCount), count.raceCount),
+			p.rateFormat(float64(count.placeCount), count.raceCount),
+			p.rateFormat(count.winPayout.InexactFloat64()*100, count.raceCount*100),
+			p.rateFormat(count.placePayout.InexactFloat64()*100, count.raceCount*100),
+			isStrong,
+		))
+	}
+
+	return analysisPedigrees
+}
+
+func (p *pedigreeService) FetchHorse(
+	ctx context.Context,
+	horseId types.HorseId,
+) (*netkeiba_entity.Horse, error) {
+	horse, err := p.horseRepository.Fetch(ctx, fmt.Sprintf(horseUrl, horseId))
+	if err != nil {
+		return nil, err
+	}
+
+	return horse, nil
+}
+
+func (p *pedigreeService) Write(
+	ctx context.Context,
+	analysisPedigrees []*spreadsheet_entity.AnalysisPedigree,
+) error {
+	return p.spreadSheetRepository.WriteAnalysisPedigree(ctx, analysisPedigrees)
+}
+
+// getConditionKeys 全体、馬場、馬場×馬場状態、馬場×道悪、馬場×距離、初ダートの集計キーを返す
+func (p *pedigreeService) getConditionKeys(calculable *analysis_entity.PedigreeCalculable) []conditionKey {
+	var surface, distance, trackCondition filter.AttributeId
+	for _, f := range calculable.Filters() {
+		switch {
+		case f&(filter.Turf|filter.Dirt) != 0:
+			surface = f
+		case f&(filter.GoodToFirm|filter.Good|filter.Yielding|filter.Soft) != 0:
+			trackCondition = f
+		default:
+			distance = f
+		}
+	}
+
+	keys := []conditionKey{{attributeId: filter.All}}
+	if surface == 0 {
+		return keys
+	}
+	keys = append(keys, conditionKey{attributeId: surface})
+	if trackCondition != 0 {
+		keys = append(keys, conditionKey{attributeId: surface | trackCondition})
+		if trackCondition == filter.Yielding || trackCondition == filter.Soft {
+			keys = append(keys, conditionKey{attributeId: surface | filter.Yielding | filter.Soft})
+		}
+	}
+	if distance != 0 {
+		keys = append(keys, conditionKey{attributeId: surface | distance})
+	}
+	if calculable.IsFirstDirt() {
+		keys = append(keys, conditionKey{attributeId: filter.Dirt, isFirstDirt: true})
+	}
+
+	return keys
+}
+
+func (p *pedigreeService) getKeyOrder(key pedigreeKey) int {
+	switch {
+	case key.attributeId == filter.All:
+		return 0
+	case key.isFirstDirt:
+		return 5
+	case key.attributeId == filter.Turf || key.attributeId == filter.Dirt:
+		return 1
+	case p.isHeavyTrack(key.attributeId):
+		return 3
+	case key.attributeId&(filter.GoodToFirm|filter.Good|filter.Yielding|filter.Soft) != 0:
+		return 2
+	}
+	return 4
+}
+
+func (p *pedigreeService) isHeavyTrack(attributeId filter.AttributeId) bool {
+	heavyTrack := filter.Yielding | filter.Soft
+	return attributeId != filter.All && attributeId&heavyTrack == heavyTrack
+}
+
+// isStrong 同条件の全体複勝率に対して、複勝率が有意に高いかを片側z検定で判定する
+func (p *pedigreeService) isStrong(count, baseline *pedigreeCount) bool {
+	if count == nil || baseline == nil || count.raceCount < pedigreeMinRaceCount || baseline.raceCount == 0 {
+		return false
+	}
+
+	baseRate := float64(baseline.placeCount) / float64(baseline.raceCount)
+	if baseRate <= 0 || baseRate >= 1 {
+		return false
+	}
+
+	rate := float64(count.placeCount) / float64(count.raceCount)
+	zScore := (rate - baseRate) / math.Sqrt(baseRate*(1-baseRate)/float64(count.raceCount))
+
+	return zScore >= pedigreeStrongZScore
+}
+
+// isFirstDirt レース日より前に出走歴があり、その中にダートの出走がなければ初ダートとみなす
+func (p *pedigreeService) isFirstDirt(
+	horse *data_cache_entity.Horse,
+	raceDate types.RaceDate,
+) bool {
+	hasPreviousRace := false
+	for _, horseResult := range horse.HorseResults() {
+		if horseResult.RaceDate() >= raceDate {
+			continue
+		}
+		if horseResult.CourseCategory() == types.Dirt {
+			return false
+		}
+		hasPreviousRace = true
+	}
+	return hasPreviousRace
+}
+
+func (p *pedigreeService) rateFormat(numerator float64, denominator int) string {
+	if denominator == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", numerator*100/float64(denominator))
+}
@@ -0,0 +1,228 @@
+package analysis_service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/shopspring/decimal"
+)
+
+func newTestPedigreeCalculable(sireId, broodmareSireId string, orderNo int, odds, placeOdds string, isFirstDirt bool, filters ...filter.AttributeId) *analysis_entity.PedigreeCalculable {
+	return analysis_entity.NewPedigreeCalculable(
+		"202405040811", 20241020, "2020100001",
+		types.HorseId(sireId), "父"+sireId,
+		types.HorseId(broodmareSireId), "母父"+broodmareSireId,
+		orderNo, decimal.RequireFromString(odds), decimal.RequireFromString(placeOdds), isFirstDirt, filters,
+	)
+}
+
+func TestPedigreeGetConditionKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		calculable *analysis_entity.PedigreeCalculable
+		want       []conditionKey
+	}{
+		{
+			name:       "芝・良・1600m",
+			calculable: newTestPedigreeCalculable("s", "b", 1, "2.0", "1.2", false, filter.Turf, filter.Good, filter.Distance1600m),
+			want: []conditionKey{
+				{attributeId: filter.All},
+				{attributeId: filter.Turf},
+				{attributeId: filter.Turf | filter.Good},
+				{attributeId: filter.Turf | filter.Distance1600m},
+			},
+		},
+		{
+			name:       "重は道悪にも集計する",
+			calculable: newTestPedigreeCalculable("s", "b", 1, "2.0", "1.2", false, filter.Dirt, filter.Soft),
+			want: []conditionKey{
+				{attributeId: filter.All},
+				{attributeId: filter.Dirt},
+				{attributeId: filter.Dirt | filter.Soft},
+				{attributeId: filter.Dirt | filter.Yielding | filter.Soft},
+			},
+		},
+		{
+			name:       "初ダート",
+			calculable: newTestPedigreeCalculable("s", "b", 1, "2.0", "1.2", true, filter.Dirt),
+			want: []conditionKey{
+				{attributeId: filter.All},
+				{attributeId: filter.Dirt},
+				{attributeId: filter.Dirt, isFirstDirt: true},
+			},
+		},
+		{
+			name:       "馬場が無ければ全体のみ",
+			calculable: newTestPedigreeCalculable("s", "b", 1, "2.0", "1.2", false),
+			want:       []conditionKey{{attributeId: filter.All}},
+		},
+	}
+
+	p := &pedigreeService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.getConditionKeys(tt.calculable); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getConditionKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPedigreeIsStrong(t *testing.T) {
+	// 同条件の全体の複勝率は30%
+	baseline := &pedigreeCount{raceCount: 100, placeCount: 30}
+
+	tests := []struct {
+		name     string
+		count    *pedigreeCount
+		baseline *pedigreeCount
+		want     bool
+	}{
+		{
+			name:     "複勝率が有意に高い",
+			count:    &pedigreeCount{raceCount: 10, placeCount: 7},
+			baseline: baseline,
+			want:     true,
+		},
+		{
+			name:     "複勝率は高いが有意ではない",
+			count:    &pedigreeCount{raceCount: 10, placeCount: 5},
+			baseline: baseline,
+			want:     false,
+		},
+		{
+			name:     "出走数が最低出走数未満",
+			count:    &pedigreeCount{raceCount: pedigreeMinRaceCount - 1, placeCount: pedigreeMinRaceCount - 1},
+			baseline: baseline,
+			want:     false,
+		},
+		{
+			name:     "全体の複勝率が0",
+			count:    &pedigreeCount{raceCount: 10, placeCount: 7},
+			baseline: &pedigreeCount{raceCount: 100},
+			want:     false,
+		},
+		{
+			name:  "全体の集計が無い",
+			count: &pedigreeCount{raceCount: 10, placeCount: 7},
+			want:  false,
+		},
+	}
+
+	p := &pedigreeService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.isStrong(tt.count, tt.baseline); got != tt.want {
+				t.Errorf("isStrong() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPedigreeIsFirstDirt(t *testing.T) {
+	newHorse := func(results ...[2]int) *data_cache_entity.Horse {
+		horseResults := make([]*data_cache_entity.HorseResult, 0, len(results))
+		for _, result := range results {
+			horseResult, err := data_cache_entity.NewHorseResult("", result[0], "", "", 1, 1, 1, "2.0", 0, 16, 1600, "05", result[1], 0, 0, "", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			horseResults = append(horseResults, horseResult)
+		}
+		return data_cache_entity.NewHorse("2020100001", "", 0, "", "", "", nil, horseResults, 0)
+	}
+
+	tests := []struct {
+		name  string
+		horse *data_cache_entity.Horse
+		want  bool
+	}{
+		{
+			name:  "芝のみ出走",
+			horse: newHorse([2]int{20240901, int(types.Turf)}, [2]int{20240601, int(types.Turf)}),
+			want:  true,
+		},
+		{
+			name:  "ダートの出走歴あり",
+			horse: newHorse([2]int{20240901, int(types.Turf)}, [2]int{20240601, int(types.Dirt)}),
+			want:  false,
+		},
+		{
+			name:  "レース日以降のダートは見ない",
+			horse: newHorse([2]int{20241020, int(types.Dirt)}, [2]int{20240601, int(types.Turf)}),
+			want:  true,
+		},
+		{
+			name:  "出走歴なし",
+			horse: newHorse(),
+			want:  false,
+		},
+	}
+
+	p := &pedigreeService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.isFirstDirt(tt.horse, 20241020); got != tt.want {
+				t.Errorf("isFirstDirt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPedigreeConvert(t *testing.T) {
+	type row struct {
+		pedigreeLine    types.PedigreeLine
+		sireName        string
+		attributeId     filter.AttributeId
+		raceCount       int
+		winRate         string
+		placeRate       string
+		winPayoutRate   string
+		placePayoutRate string
+	}
+	tests := []struct {
+		name        string
+		calculables []*analysis_entity.PedigreeCalculable
+		want        []row
+	}{
+		{
+			name: "データなし",
+			want: []row{},
+		},
+		{
+			name: "父と母父を条件別に集計し、母父が無い馬は父だけに数える",
+			calculables: []*analysis_entity.PedigreeCalculable{
+				newTestPedigreeCalculable("s1", "b1", 1, "3.0", "1.5", false, filter.Turf, filter.Good, filter.Distance1600m),
+				newTestPedigreeCalculable("s1", "", 5, "10.0", "0", false, filter.Turf, filter.Good, filter.Distance1600m),
+			},
+			want: []row{
+				{types.SireLine, "父s1", filter.All, 2, "50.00%", "50.00%", "150.00%", "75.00%"},
+				{types.SireLine, "父s1", filter.Turf, 2, "50.00%", "50.00%", "150.00%", "75.00%"},
+				{types.SireLine, "父s1", filter.Turf | filter.Good, 2, "50.00%", "50.00%", "150.00%", "75.00%"},
+				{types.SireLine, "父s1", filter.Turf | filter.Distance1600m, 2, "50.00%", "50.00%", "150.00%", "75.00%"},
+				{types.BroodmareSireLine, "母父b1", filter.All, 1, "100.00%", "100.00%", "300.00%", "150.00%"},
+				{types.BroodmareSireLine, "母父b1", filter.Turf, 1, "100.00%", "100.00%", "300.00%", "150.00%"},
+				{types.BroodmareSireLine, "母父b1", filter.Turf | filter.Good, 1, "100.00%", "100.00%", "300.00%", "150.00%"},
+				{types.BroodmareSireLine, "母父b1", filter.Turf | filter.Distance1600m, 1, "100.00%", "100.00%", "300.00%", "150.00%"},
+			},
+		},
+	}
+
+	p := &pedigreeService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]row, 0)
+			for _, a := range p.Convert(context.Background(), tt.calculables) {
+				got = append(got, row{a.PedigreeLine(), a.SireName(), a.AttributeId(), a.RaceCount(), a.WinRate(), a.PlaceRate(), a.WinPayoutRate(), a.PlacePayoutRate()})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Convert() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -129,6 +129,7 @@ func (p *placeService) Create(
 				raceResult.OrderNo(),
 				race.Entries(),
 				raceResult.JockeyId(),
+				raceResult.RunningStyle(),
 				filters,
 			))
 		}
@@ -178,6 +179,10 @@ func (p *placeService) Convert(
 				for _, f := range calculable.Filters() {
 					calcFilter |= f
 				}
+				// 脚質は馬単位の属性なので、レース条件のフィルタとは別に集計時だけ付与する
+				for _, f := range filter_service.RunningStyleFilters(calculable.RunningStyle()) {
+					calcFilter |= f
+				}
 
 				if analysisFilter == filter.All || analysisFilter&calcFilter == analysisFilter {
 					if _, ok := raceIdMap[calculable.RaceId()]; !ok {
@@ -618,5 +623,17 @@ func (p *placeService) getFilters() []filter.AttributeId {
 		filter.Dirt | filter.Kokura | filter.Distance2400m,
 		filter.Dirt | filter.Nakayama | filter.Distance2500m,
 		filter.Dirt | filter.Niigata | filter.Distance2500m,
+		filter.Escape,
+		filter.Leading,
+		filter.Stalker,
+		filter.Closer,
+		filter.Turf | filter.Escape,
+		filter.Turf | filter.Leading,
+		filter.Turf | filter.Stalker,
+		filter.Turf | filter.Closer,
+		filter.Dirt | filter.Escape,
+		filter.Dirt | filter.Leading,
+		filter.Dirt | filter.Stalker,
+		filter.Dirt | filter.Closer,
 	}
 }
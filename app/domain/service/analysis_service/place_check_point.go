@@ -4,44 +4,56 @@ import (
 	"context"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
 )
 
 type PlaceCheckPoint interface {
-	GetPositivePoint(ctx context.Context, race *analysis_entity.Race) error
-	GetNegativePoint(ctx context.Context, race *analysis_entity.Race) error
+	GetPositivePoint(ctx context.Context, rules []*analysis_entity.PlaceRule, fact *analysis_entity.PlaceRuleFact) []*spreadsheet_entity.AnalysisPlaceCheckPoint
+	GetNegativePoint(ctx context.Context, rules []*analysis_entity.PlaceRule, fact *analysis_entity.PlaceRuleFact) []*spreadsheet_entity.AnalysisPlaceCheckPoint
 }
 
 type placeCheckPointService struct {
-	placeNegativeCheckService PlaceNegativeCheck
+	placeRuleService PlaceRule
 }
 
 func NewPlaceCheckPoint(
-	placeNegativeCheckService PlaceNegativeCheck,
+	placeRuleService PlaceRule,
 ) PlaceCheckPoint {
 	return &placeCheckPointService{
-		placeNegativeCheckService: placeNegativeCheckService,
+		placeRuleService: placeRuleService,
 	}
 }
 
+// GetPositivePoint チェックリストのうち該当した項目と点数を返す
 func (s *placeCheckPointService) GetPositivePoint(
 	ctx context.Context,
-	race *analysis_entity.Race,
-) error {
-
-	// 実装を追加
-	return nil
+	rules []*analysis_entity.PlaceRule,
+	fact *analysis_entity.PlaceRuleFact,
+) []*spreadsheet_entity.AnalysisPlaceCheckPoint {
+	return s.getPoint(ctx, rules, fact)
 }
 
+// GetNegativePoint 危険チェックポイントのうち該当した項目と点数を返す、合計点数が高いほど危険
 func (s *placeCheckPointService) GetNegativePoint(
 	ctx context.Context,
-	race *analysis_entity.Race,
-) error {
-	input := &PlaceNegativeCheckInput{
-		Race: race,
-	}
+	rules []*analysis_entity.PlaceRule,
+	fact *analysis_entity.PlaceRuleFact,
+) []*spreadsheet_entity.AnalysisPlaceCheckPoint {
+	return s.getPoint(ctx, rules, fact)
+}
 
-	s.placeNegativeCheckService.DirtShortDistanceRace(ctx, input)
+func (s *placeCheckPointService) getPoint(
+	ctx context.Context,
+	rules []*analysis_entity.PlaceRule,
+	fact *analysis_entity.PlaceRuleFact,
+) []*spreadsheet_entity.AnalysisPlaceCheckPoint {
+	var checkPoints []*spreadsheet_entity.AnalysisPlaceCheckPoint
+	for _, result := range s.placeRuleService.Evaluate(ctx, rules, fact) {
+		if !result.Ok() {
+			continue
+		}
+		checkPoints = append(checkPoints, spreadsheet_entity.NewAnalysisPlaceCheckPoint(result.Name(), result.Point()))
+	}
 
-	// 実装を追加
-	return nil
+	return checkPoints
 }
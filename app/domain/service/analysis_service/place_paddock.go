@@ -0,0 +1,173 @@
+package analysis_service
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+const (
+	placePaddockEvaluationCategory   = "パドック評価"
+	placePaddockReporterMemoCategory = "記者メモ"
+	reporterMemoDays                 = 14 // 予想時と同じくレース2週間前以降の記者メモだけを見る
+)
+
+var placePaddockEvaluations = []types.PaddockEvaluation{
+	types.PaddockEvaluationS,
+	types.PaddockEvaluationA,
+	types.PaddockEvaluationB,
+	types.PaddockEvaluationDoubt,
+	types.NoPaddockEvaluation,
+}
+
+type PlacePaddock interface {
+	Create(ctx context.Context,
+		markers []*marker_csv_entity.AnalysisMarker,
+		races []*data_cache_entity.Race,
+		raceForecasts []*data_cache_entity.RaceForecast,
+	) []*analysis_entity.PlacePaddockCalculable
+	Convert(ctx context.Context, calculables []*analysis_entity.PlacePaddockCalculable) []*spreadsheet_entity.AnalysisPlaceRate
+	Write(ctx context.Context, analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate) error
+}
+
+type placePaddockService struct {
+	spreadSheetRepository repository.SpreadSheetRepository
+}
+
+func NewPlacePaddock(
+	spreadSheetRepository repository.SpreadSheetRepository,
+) PlacePaddock {
+	return &placePaddockService{
+		spreadSheetRepository: spreadSheetRepository,
+	}
+}
+
+func (p *placePaddockService) Create(
+	ctx context.Context,
+	markers []*marker_csv_entity.AnalysisMarker,
+	races []*data_cache_entity.Race,
+	raceForecasts []*data_cache_entity.RaceForecast,
+) []*analysis_entity.PlacePaddockCalculable {
+	markerMap := converter.ConvertToMap(markers, func(marker *marker_csv_entity.AnalysisMarker) types.RaceId {
+		return marker.RaceId()
+	})
+	raceForecastMap := converter.ConvertToMap(raceForecasts, func(raceForecast *data_cache_entity.RaceForecast) types.RaceId {
+		return raceForecast.RaceId()
+	})
+
+	var calculables []*analysis_entity.PlacePaddockCalculable
+	for _, race := range races {
+		marker, ok := markerMap[race.RaceId()]
+		if !ok {
+			continue
+		}
+		// 記者メモとパドック情報を取得する前のキャッシュは、評価なしと区別できないので対象外
+		raceForecast, ok := raceForecastMap[race.RaceId()]
+		if !ok || !raceForecast.PaddockFetched() {
+			continue
+		}
+
+		horseNumberMarkerMap := map[types.HorseNumber]types.Marker{}
+		for markerType, horseNumber := range marker.MarkerMap() {
+			horseNumberMarkerMap[horseNumber] = markerType
+		}
+		forecastMap := converter.ConvertToMap(raceForecast.Forecasts(), func(forecast *data_cache_entity.Forecast) types.HorseNumber {
+			return forecast.HorseNumber()
+		})
+
+		for _, raceResult := range race.RaceResults() {
+			// 取り消し・除外の馬は集計対象外
+			if raceResult.Odds().IsZero() {
+				continue
+			}
+			markerType, ok := horseNumberMarkerMap[raceResult.HorseNumber()]
+			if !ok {
+				markerType = types.NoMarker
+			}
+
+			var (
+				paddockEvaluation types.PaddockEvaluation
+				hasReporterMemo   bool
+			)
+			if forecast, ok := forecastMap[raceResult.HorseNumber()]; ok {
+				paddockEvaluation = forecast.PaddockEvaluation()
+				hasReporterMemo = forecast.HasReporterMemo(race.RaceDate(), reporterMemoDays)
+			}
+
+			calculables = append(calculables, analysis_entity.NewPlacePaddockCalculable(
+				race.RaceId(),
+				race.RaceDate(),
+				raceResult.HorseNumber(),
+				markerType,
+				raceResult.OrderNo(),
+				paddockEvaluation,
+				hasReporterMemo,
+			))
+		}
+	}
+
+	return calculables
+}
+
+func (p *placePaddockService) Convert(
+	ctx context.Context,
+	calculables []*analysis_entity.PlacePaddockCalculable,
+) []*spreadsheet_entity.AnalysisPlaceRate {
+	counter := newPlaceRateCounter()
+	for _, calculable := range calculables {
+		counter.add(placeRateKey{
+			category: placePaddockEvaluationCategory,
+			value:    calculable.PaddockEvaluation().String(),
+			marker:   calculable.Marker(),
+		}, calculable.OrderNo())
+		counter.add(placeRateKey{
+			category: placePaddockReporterMemoCategory,
+			value:    p.reporterMemoValue(calculable.HasReporterMemo()),
+			marker:   calculable.Marker(),
+		}, calculable.OrderNo())
+	}
+
+	keys := make([]placeRateKey, 0, (len(placePaddockEvaluations)+2)*len(placeRateMarkers))
+	for _, paddockEvaluation := range placePaddockEvaluations {
+		for _, marker := range placeRateMarkers {
+			keys = append(keys, placeRateKey{
+				category: placePaddockEvaluationCategory,
+				value:    paddockEvaluation.String(),
+				marker:   marker,
+			})
+		}
+	}
+	for _, hasReporterMemo := range []bool{true, false} {
+		for _, marker := range placeRateMarkers {
+			keys = append(keys, placeRateKey{
+				category: placePaddockReporterMemoCategory,
+				value:    p.reporterMemoValue(hasReporterMemo),
+				marker:   marker,
+			})
+		}
+	}
+
+	return counter.convert(keys, func(marker types.Marker) string {
+		return marker.String()
+	})
+}
+
+func (p *placePaddockService) Write(
+	ctx context.Context,
+	analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate,
+) error {
+	return p.spreadSheetRepository.WriteAnalysisPlacePaddock(ctx, analysisPlaceRates)
+}
+
+func (p *placePaddockService) reporterMemoValue(hasReporterMemo bool) string {
+	if hasReporterMemo {
+		return "あり"
+	}
+	return "なし"
+}
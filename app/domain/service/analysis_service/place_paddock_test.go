@@ -0,0 +1,142 @@
+package analysis_service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func TestPlacePaddockCreate(t *testing.T) {
+	newRace := func(raceId string, odds ...string) *data_cache_entity.Race {
+		raceResults := make([]*data_cache_entity.RaceResult, 0, len(odds))
+		for idx, o := range odds {
+			raceResults = append(raceResults, data_cache_entity.NewRaceResult(idx+1, fmt.Sprintf("h%d", idx+1), "", 1, idx+1, "", o, idx+1, "", 0, 0, "", "", "", "", 0))
+		}
+		return data_cache_entity.NewRace(raceId, 20241020, 1, types.Tokyo, "テスト", 1, "", "", "10:00", len(odds), 1600, 0, 0, 0, 0, 0, 0, 0, raceResults, nil, true)
+	}
+	newMarker := func(raceId string) *marker_csv_entity.AnalysisMarker {
+		marker, err := marker_csv_entity.NewAnalysisMarker("20241020", raceId, "1", "2", "3", "4", "5", "6")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return marker
+	}
+	raceDate := types.RaceDate(20241020).Date()
+
+	markers := []*marker_csv_entity.AnalysisMarker{newMarker("202405040801"), newMarker("202405040802")}
+	races := []*data_cache_entity.Race{
+		newRace("202405040801", "2.0", "3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "0"),
+		newRace("202405040802", "2.0", "3.0", "4.0", "5.0", "6.0", "7.0"),
+		newRace("202405040803", "2.0", "3.0", "4.0", "5.0", "6.0", "7.0"),
+	}
+	raceForecasts := []*data_cache_entity.RaceForecast{
+		data_cache_entity.NewRaceForecast("202405040801", 20241020, []*data_cache_entity.Forecast{
+			data_cache_entity.NewForecast(1, "", "", false, 0, 0, 0, []*data_cache_entity.ReporterMemo{
+				data_cache_entity.NewReporterMemo("仕上がり良好", raceDate.AddDate(0, 0, -3)),
+			}, "", types.PaddockEvaluationS.Value()),
+			data_cache_entity.NewForecast(2, "", "", false, 0, 0, 0, []*data_cache_entity.ReporterMemo{
+				data_cache_entity.NewReporterMemo("前走は不利", raceDate.AddDate(0, 0, -(reporterMemoDays+1))),
+			}, "", types.PaddockEvaluationA.Value()),
+		}, true),
+		// パドック情報の取得前のキャッシュ
+		data_cache_entity.NewRaceForecast("202405040802", 20241020, nil, false),
+	}
+
+	type row struct {
+		raceId            types.RaceId
+		horseNumber       types.HorseNumber
+		marker            types.Marker
+		paddockEvaluation types.PaddockEvaluation
+		hasReporterMemo   bool
+	}
+	want := []row{
+		{raceId: "202405040801", horseNumber: 1, marker: types.Favorite, paddockEvaluation: types.PaddockEvaluationS, hasReporterMemo: true},
+		{raceId: "202405040801", horseNumber: 2, marker: types.Rival, paddockEvaluation: types.PaddockEvaluationA},
+		{raceId: "202405040801", horseNumber: 3, marker: types.BrackTriangle},
+		{raceId: "202405040801", horseNumber: 4, marker: types.WhiteTriangle},
+		{raceId: "202405040801", horseNumber: 5, marker: types.Star},
+		{raceId: "202405040801", horseNumber: 6, marker: types.Check},
+		{raceId: "202405040801", horseNumber: 7, marker: types.NoMarker},
+	}
+
+	p := &placePaddockService{}
+	var got []row
+	for _, calculable := range p.Create(context.Background(), markers, races, raceForecasts) {
+		got = append(got, row{
+			raceId:            calculable.RaceId(),
+			horseNumber:       calculable.HorseNumber(),
+			marker:            calculable.Marker(),
+			paddockEvaluation: calculable.PaddockEvaluation(),
+			hasReporterMemo:   calculable.HasReporterMemo(),
+		})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Create() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPlacePaddockConvert(t *testing.T) {
+	type row struct {
+		category   string
+		value      string
+		markerName string
+		raceCount  int
+		placeCount int
+	}
+	newCalculable := func(marker types.Marker, orderNo int, paddockEvaluation types.PaddockEvaluation, hasReporterMemo bool) *analysis_entity.PlacePaddockCalculable {
+		return analysis_entity.NewPlacePaddockCalculable("202405040801", 20241020, 1, marker, orderNo, paddockEvaluation, hasReporterMemo)
+	}
+
+	tests := []struct {
+		name        string
+		calculables []*analysis_entity.PlacePaddockCalculable
+		want        []row
+	}{
+		{
+			name: "集計なし",
+		},
+		{
+			name: "パドック評価、記者メモの有無の順に印ごとに集計する",
+			calculables: []*analysis_entity.PlacePaddockCalculable{
+				newCalculable(types.Favorite, 1, types.PaddockEvaluationS, true),
+				newCalculable(types.Favorite, 4, types.PaddockEvaluationS, false),
+				newCalculable(types.NoMarker, 2, types.NoPaddockEvaluation, false),
+				newCalculable(types.Rival, 3, types.PaddockEvaluationDoubt, false),
+			},
+			want: []row{
+				{category: placePaddockEvaluationCategory, value: "S", markerName: "◎", raceCount: 2, placeCount: 1},
+				{category: placePaddockEvaluationCategory, value: "疑", markerName: "◯", raceCount: 1, placeCount: 1},
+				{category: placePaddockEvaluationCategory, value: "なし", markerName: "無", raceCount: 1, placeCount: 1},
+				{category: placePaddockReporterMemoCategory, value: "あり", markerName: "◎", raceCount: 1, placeCount: 1},
+				{category: placePaddockReporterMemoCategory, value: "なし", markerName: "◎", raceCount: 1, placeCount: 0},
+				{category: placePaddockReporterMemoCategory, value: "なし", markerName: "◯", raceCount: 1, placeCount: 1},
+				{category: placePaddockReporterMemoCategory, value: "なし", markerName: "無", raceCount: 1, placeCount: 1},
+			},
+		},
+	}
+
+	p := &placePaddockService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []row
+			for _, r := range p.Convert(context.Background(), tt.calculables) {
+				got = append(got, row{
+					category:   r.Category(),
+					value:      r.Value(),
+					markerName: r.MarkerName(),
+					raceCount:  r.RaceCount(),
+					placeCount: r.PlaceCount(),
+				})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Convert() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
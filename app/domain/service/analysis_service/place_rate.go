@@ -0,0 +1,87 @@
+package analysis_service
+
+import (
+	"fmt"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+var placeRateMarkers = []types.Marker{
+	types.Favorite,
+	types.Rival,
+	types.BrackTriangle,
+	types.WhiteTriangle,
+	types.Star,
+	types.Check,
+	types.NoMarker,
+}
+
+// placeRateKey 区分×値×印の集計単位
+type placeRateKey struct {
+	category string
+	value    string
+	marker   types.Marker
+}
+
+type placeRateCount struct {
+	raceCount  int
+	winCount   int
+	placeCount int
+}
+
+// placeRateCounter 区分×値×印ごとに頭数、1着数、複勝圏数を数える
+type placeRateCounter struct {
+	countMap map[placeRateKey]*placeRateCount
+}
+
+func newPlaceRateCounter() *placeRateCounter {
+	return &placeRateCounter{
+		countMap: map[placeRateKey]*placeRateCount{},
+	}
+}
+
+func (p *placeRateCounter) add(key placeRateKey, orderNo int) {
+	if _, ok := p.countMap[key]; !ok {
+		p.countMap[key] = &placeRateCount{}
+	}
+	count := p.countMap[key]
+	count.raceCount++
+	if orderNo == 1 {
+		count.winCount++
+	}
+	if orderNo >= 1 && orderNo <= 3 {
+		count.placeCount++
+	}
+}
+
+// convert keysの順にシートの行にする、該当する馬がいない集計単位は出さない
+func (p *placeRateCounter) convert(
+	keys []placeRateKey,
+	markerName func(marker types.Marker) string,
+) []*spreadsheet_entity.AnalysisPlaceRate {
+	analysisPlaceRates := make([]*spreadsheet_entity.AnalysisPlaceRate, 0, len(p.countMap))
+	for _, key := range keys {
+		count, ok := p.countMap[key]
+		if !ok {
+			continue
+		}
+		winRate, placeRate := "-", "-"
+		if count.raceCount > 0 {
+			winRate = fmt.Sprintf("%.2f%%", float64(count.winCount)*100/float64(count.raceCount))
+			placeRate = fmt.Sprintf("%.2f%%", float64(count.placeCount)*100/float64(count.raceCount))
+		}
+		analysisPlaceRates = append(analysisPlaceRates, spreadsheet_entity.NewAnalysisPlaceRate(
+			key.category,
+			key.value,
+			markerName(key.marker),
+			count.raceCount,
+			count.winCount,
+			count.placeCount,
+			winRate,
+			placeRate,
+		))
+	}
+
+	return analysisPlaceRates
+}
@@ -0,0 +1,94 @@
+package analysis_service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func TestPlaceRateCounterConvert(t *testing.T) {
+	type row struct {
+		category   string
+		value      string
+		markerName string
+		raceCount  int
+		winCount   int
+		placeCount int
+		winRate    string
+		placeRate  string
+	}
+	type add struct {
+		key     placeRateKey
+		orderNo int
+	}
+	favoriteKey := placeRateKey{category: "区分", value: "A", marker: types.Favorite}
+	noMarkerKey := placeRateKey{category: "区分", value: "A", marker: types.NoMarker}
+	otherKey := placeRateKey{category: "区分", value: "B", marker: types.Favorite}
+
+	tests := []struct {
+		name string
+		adds []add
+		keys []placeRateKey
+		want []row
+	}{
+		{
+			name: "集計なし",
+			keys: []placeRateKey{favoriteKey},
+		},
+		{
+			name: "1着は複勝圏にも数え、中止などの着順0は頭数だけ数える",
+			adds: []add{
+				{key: favoriteKey, orderNo: 1},
+				{key: favoriteKey, orderNo: 3},
+				{key: favoriteKey, orderNo: 4},
+				{key: favoriteKey, orderNo: 0},
+			},
+			keys: []placeRateKey{favoriteKey},
+			want: []row{
+				{category: "区分", value: "A", markerName: "◎", raceCount: 4, winCount: 1, placeCount: 2, winRate: "25.00%", placeRate: "50.00%"},
+			},
+		},
+		{
+			name: "keysの順に並べ、keysに無い集計単位と該当なしの集計単位は出さない",
+			adds: []add{
+				{key: favoriteKey, orderNo: 2},
+				{key: noMarkerKey, orderNo: 1},
+				{key: noMarkerKey, orderNo: 5},
+				{key: placeRateKey{category: "区分", value: "C", marker: types.Favorite}, orderNo: 1},
+			},
+			keys: []placeRateKey{noMarkerKey, otherKey, favoriteKey},
+			want: []row{
+				{category: "区分", value: "A", markerName: "無", raceCount: 2, winCount: 1, placeCount: 1, winRate: "50.00%", placeRate: "50.00%"},
+				{category: "区分", value: "A", markerName: "◎", raceCount: 1, winCount: 0, placeCount: 1, winRate: "0.00%", placeRate: "100.00%"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := newPlaceRateCounter()
+			for _, a := range tt.adds {
+				counter.add(a.key, a.orderNo)
+			}
+			var got []row
+			for _, r := range counter.convert(tt.keys, func(marker types.Marker) string {
+				return marker.String()
+			}) {
+				got = append(got, row{
+					category:   r.Category(),
+					value:      r.Value(),
+					markerName: r.MarkerName(),
+					raceCount:  r.RaceCount(),
+					winCount:   r.WinCount(),
+					placeCount: r.PlaceCount(),
+					winRate:    r.WinRate(),
+					placeRate:  r.PlaceRate(),
+				})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convert() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
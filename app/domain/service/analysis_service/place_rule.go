@@ -0,0 +1,368 @@
+package analysis_service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/config"
+)
+
+const (
+	placeRuleFileName = "place_rule.json"
+)
+
+// placeRuleClassMap 昇級判定用のクラスの序列
+var placeRuleClassMap = map[types.GradeClass]int{
+	types.NonGrade:      0,
+	types.MakeDebut:     1,
+	types.Maiden:        1,
+	types.OneWinClass:   2,
+	types.TwoWinClass:   3,
+	types.ThreeWinClass: 4,
+	types.Grade1:        5,
+	types.Grade2:        5,
+	types.Grade3:        5,
+	types.OpenClass:     5,
+	types.ListedClass:   5,
+}
+
+type PlaceRule interface {
+	Get(ctx context.Context) (*analysis_entity.PlaceRuleSet, error)
+	Evaluate(ctx context.Context, rules []*analysis_entity.PlaceRule, fact *analysis_entity.PlaceRuleFact) []*analysis_entity.PlaceRuleResult
+	CreatePredictionFact(ctx context.Context, input *PlaceCheckListInput) *analysis_entity.PlaceRuleFact
+	CreateAnalysisFact(ctx context.Context, race *analysis_entity.Race, raceResult *analysis_entity.RaceResult, horse *analysis_entity.Horse) *analysis_entity.PlaceRuleFact
+}
+
+type placeRuleService struct {
+	placeRuleRepository repository.PlaceRuleRepository
+}
+
+func NewPlaceRule(
+	placeRuleRepository repository.PlaceRuleRepository,
+) PlaceRule {
+	return &placeRuleService{
+		placeRuleRepository: placeRuleRepository,
+	}
+}
+
+func (p *placeRuleService) Get(ctx context.Context) (*analysis_entity.PlaceRuleSet, error) {
+	rawPlaceRuleInfo, err := p.placeRuleRepository.Read(ctx, fmt.Sprintf("%s/%s", config.RuleDir, placeRuleFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	checkList, err := p.convert(rawPlaceRuleInfo.CheckList)
+	if err != nil {
+		return nil, err
+	}
+
+	danger, err := p.convert(rawPlaceRuleInfo.Danger)
+	if err != nil {
+		return nil, err
+	}
+
+	return analysis_entity.NewPlaceRuleSet(checkList, danger), nil
+}
+
+func (p *placeRuleService) Evaluate(
+	ctx context.Context,
+	rules []*analysis_entity.PlaceRule,
+	fact *analysis_entity.PlaceRuleFact,
+) []*analysis_entity.PlaceRuleResult {
+	results := make([]*analysis_entity.PlaceRuleResult, 0, len(rules))
+	for _, rule := range rules {
+		ok := p.match(rule, fact)
+		if rule.Negate() {
+			ok = !ok
+		}
+		results = append(results, analysis_entity.NewPlaceRuleResult(rule.Name(), ok, rule.Point()))
+	}
+
+	return results
+}
+
+func (p *placeRuleService) CreatePredictionFact(
+	ctx context.Context,
+	input *PlaceCheckListInput,
+) *analysis_entity.PlaceRuleFact {
+	var (
+		entryHorse *prediction_entity.RaceEntryHorse
+		winOdds    float64
+	)
+	for _, raceEntryHorse := range input.Race.RaceEntryHorses() {
+		if raceEntryHorse.HorseId() == input.Horse.HorseId() {
+			entryHorse = raceEntryHorse
+		}
+	}
+
+	var (
+		jockeyId   types.JockeyId
+		raceWeight float64
+	)
+	if entryHorse != nil {
+		jockeyId = entryHorse.JockeyId()
+		raceWeight = entryHorse.RaceWeight()
+		for _, odds := range input.Race.Odds() {
+			if odds.HorseNumber() == entryHorse.HorseNumber() {
+				winOdds = odds.Odds().InexactFloat64()
+			}
+		}
+	}
+
+	horseResults := make([]*analysis_entity.PlaceRuleHorseResult, 0, len(input.Horse.HorseResults()))
+	for _, horseResult := range input.Horse.HorseResults() {
+		// 履歴を取るタイミングで今走が履歴に含まれる場合はスキップする
+		if horseResult.RaceId() == input.Race.RaceId() {
+			continue
+		}
+		horseResults = append(horseResults, analysis_entity.NewPlaceRuleHorseResult(
+			horseResult.RaceId(),
+			horseResult.RaceDate(),
+			horseResult.OrderNo(),
+			horseResult.Distance(),
+			horseResult.Class(),
+			horseResult.CourseCategory(),
+			horseResult.TrackCondition(),
+			horseResult.RaceCourse(),
+			horseResult.JockeyId(),
+			horseResult.RaceWeight(),
+			horseResult.Comment(),
+		))
+	}
+
+	var forecast *analysis_entity.PlaceRuleForecast
+	if input.Forecast != nil {
+		forecast = analysis_entity.NewPlaceRuleForecast(
+			input.Forecast.FavoriteNum(),
+			input.Forecast.RivalNum(),
+			input.Forecast.MarkerNum(),
+			input.Forecast.IsHighlyRecommended(),
+		)
+	}
+
+	return analysis_entity.NewPlaceRuleFact(
+		input.Race.RaceId(),
+		input.Race.RaceDate(),
+		input.Race.Entries(),
+		input.Race.Distance(),
+		input.Race.Class(),
+		input.Race.CourseCategory(),
+		input.Race.TrackCondition(),
+		input.Race.RaceCourse(),
+		winOdds,
+		jockeyId,
+		raceWeight,
+		horseResults,
+		forecast,
+		input.TrainerPerformance,
+		input.JockeyTrainerPerformance,
+	)
+}
+
+func (p *placeRuleService) CreateAnalysisFact(
+	ctx context.Context,
+	race *analysis_entity.Race,
+	raceResult *analysis_entity.RaceResult,
+	horse *analysis_entity.Horse,
+) *analysis_entity.PlaceRuleFact {
+	var horseResults []*analysis_entity.PlaceRuleHorseResult
+	if horse != nil {
+		horseResults = make([]*analysis_entity.PlaceRuleHorseResult, 0, len(horse.HorseResults()))
+		for _, horseResult := range horse.HorseResults() {
+			// キャッシュ取得後の戦績が混ざらないように今走より前の戦績だけにする
+			if horseResult.RaceDate() >= race.RaceDate() {
+				continue
+			}
+			horseResults = append(horseResults, analysis_entity.NewPlaceRuleHorseResult(
+				horseResult.RaceId(),
+				horseResult.RaceDate(),
+				horseResult.OrderNo(),
+				horseResult.Distance(),
+				horseResult.Class(),
+				horseResult.CourseCategory(),
+				horseResult.TrackCondition(),
+				horseResult.RaceCourse(),
+				horseResult.JockeyId(),
+				horseResult.RaceWeight(),
+				horseResult.Comment(),
+			))
+		}
+	}
+
+	raceWeight, _ := strconv.ParseFloat(raceResult.JockeyWeight(), 64)
+
+	return analysis_entity.NewPlaceRuleFact(
+		race.RaceId(),
+		race.RaceDate(),
+		race.Entries(),
+		race.Distance(),
+		race.Class(),
+		race.CourseCategory(),
+		race.TrackCondition(),
+		race.RaceCourse(),
+		raceResult.Odds().InexactFloat64(),
+		raceResult.JockeyId(),
+		raceWeight,
+		horseResults,
+		nil,
+		nil,
+		nil,
+	)
+}
+
+func (p *placeRuleService) convert(rawPlaceRules []*raw_entity.PlaceRule) ([]*analysis_entity.PlaceRule, error) {
+	placeRules := make([]*analysis_entity.PlaceRule, 0, len(rawPlaceRules))
+	for _, rawPlaceRule := range rawPlaceRules {
+		placeRule, err := analysis_entity.NewPlaceRule(
+			rawPlaceRule.Name,
+			rawPlaceRule.Kind,
+			rawPlaceRule.Params,
+			rawPlaceRule.Values,
+			rawPlaceRule.Negate,
+			rawPlaceRule.Point,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid place rule %s: %w", rawPlaceRule.Name, err)
+		}
+		placeRules = append(placeRules, placeRule)
+	}
+
+	return placeRules, nil
+}
+
+func (p *placeRuleService) match(
+	rule *analysis_entity.PlaceRule,
+	fact *analysis_entity.PlaceRuleFact,
+) bool {
+	horseResults := fact.HorseResults()
+	var previous *analysis_entity.PlaceRuleHorseResult
+	if len(horseResults) > 0 {
+		previous = horseResults[0]
+	}
+	recent := func(runs float64) []*analysis_entity.PlaceRuleHorseResult {
+		if int(runs) < len(horseResults) {
+			return horseResults[:int(runs)]
+		}
+		return horseResults
+	}
+
+	switch rule.Kind() {
+	case types.EntriesMaxRule:
+		return float64(fact.Entries()) <= rule.Param("max")
+	case types.EntriesMinRule:
+		return float64(fact.Entries()) >= rule.Param("min")
+	case types.CourseCategoryDistanceRule:
+		if len(rule.Values()) > 0 && !slices.Contains(rule.Values(), fact.CourseCategory().String()) {
+			return false
+		}
+		distance := float64(fact.Distance())
+		return distance >= rule.Param("min") && distance <= rule.Param("max")
+	case types.TrackConditionRule:
+		return slices.Contains(rule.Values(), fact.TrackCondition().String())
+	case types.WinOddsUnderRule:
+		return fact.WinOdds() > 0 && fact.WinOdds() < rule.Param("max")
+	case types.PlaceRatioRule:
+		if len(horseResults) == 0 {
+			return false
+		}
+		placed := 0
+		for _, horseResult := range horseResults {
+			if horseResult.OrderNo() <= 3 {
+				placed++
+			}
+		}
+		return float64(placed)/float64(len(horseResults)) >= rule.Param("min")
+	case types.SameCourseCategoryPreviousRule:
+		return previous != nil && previous.CourseCategory() == fact.CourseCategory()
+	case types.SameDistanceRecentRule:
+		for _, horseResult := range recent(rule.Param("runs")) {
+			if horseResult.Distance() == fact.Distance() {
+				return true
+			}
+		}
+		return false
+	case types.SameRaceCourseRecentRule:
+		for _, horseResult := range recent(rule.Param("runs")) {
+			if horseResult.RaceCourse() == fact.RaceCourse() {
+				return true
+			}
+		}
+		return false
+	case types.PlaceRecentRule:
+		for _, horseResult := range recent(rule.Param("runs")) {
+			if float64(horseResult.OrderNo()) <= rule.Param("order") {
+				return true
+			}
+		}
+		return false
+	case types.TrackConditionPlaceExperienceRule:
+		for _, horseResult := range horseResults {
+			if horseResult.TrackCondition() == fact.TrackCondition() && horseResult.OrderNo() <= 3 {
+				return true
+			}
+		}
+		return false
+	case types.RaceWeightUpRule:
+		return previous != nil && previous.RaceWeight() < fact.RaceWeight()
+	case types.ClassUpRule:
+		return previous != nil && placeRuleClassMap[fact.Class()] > placeRuleClassMap[previous.Class()]
+	case types.ContinueOrTopJockeyRule:
+		// 初出走は判定できないので該当扱いにする
+		if previous == nil {
+			return true
+		}
+		return previous.JockeyId() == fact.JockeyId() || slices.Contains(rule.Values(), fact.JockeyId().Value())
+	case types.ChangeJockeyRule:
+		return previous != nil && previous.JockeyId() != fact.JockeyId()
+	case types.SlowStartRecentRule:
+		for _, horseResult := range recent(rule.Param("runs")) {
+			if horseResult.Comment() == "出遅れ" {
+				return true
+			}
+		}
+		return false
+	case types.PreviousOrderRule:
+		return previous != nil && float64(previous.OrderNo()) >= rule.Param("min")
+	case types.RestDaysRule:
+		if previous == nil {
+			return false
+		}
+		restDays := fact.RaceDate().Date().Sub(previous.RaceDate().Date()).Hours() / 24
+		return restDays >= rule.Param("min")
+	case types.DistanceChangeRule:
+		return previous != nil && math.Abs(float64(fact.Distance()-previous.Distance())) >= rule.Param("min")
+	case types.TospoFavoriteRatioRule:
+		forecast := fact.Forecast()
+		if forecast == nil || forecast.MarkerNum() == 0 {
+			return false
+		}
+		return float64(forecast.FavoriteNum())/float64(forecast.MarkerNum()) >= rule.Param("min")
+	case types.TospoOnlyFavoriteAndRivalRule:
+		forecast := fact.Forecast()
+		return forecast != nil && forecast.FavoriteNum()+forecast.RivalNum() == forecast.MarkerNum()
+	case types.HighlyRecommendedRule:
+		return fact.Forecast() != nil && fact.Forecast().HighlyRecommended()
+	case types.TrainerPlaceRateRule:
+		performance := fact.TrainerPerformance()
+		if performance == nil || float64(performance.RaceCount()) < rule.Param("min_races") {
+			return false
+		}
+		return performance.PlaceRate() >= rule.Param("min")
+	case types.JockeyTrainerPlaceRateRule:
+		performance := fact.JockeyTrainerPerformance()
+		if performance == nil || float64(performance.RaceCount()) < rule.Param("min_races") {
+			return false
+		}
+		return performance.PlaceRate() >= rule.Param("min")
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package analysis_service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/shopspring/decimal"
+)
+
+func newTestPlaceRuleHorseResult(orderNo, distance int, raceCourse types.RaceCourse, jockeyId types.JockeyId, comment string) *analysis_entity.PlaceRuleHorseResult {
+	return analysis_entity.NewPlaceRuleHorseResult("", 20240101, orderNo, distance, types.OneWinClass, types.Turf, types.GoodToFirm, raceCourse, jockeyId, 55, comment)
+}
+
+func newTestPlaceRuleFact(horseResults []*analysis_entity.PlaceRuleHorseResult, trainerPerformance *analysis_entity.TrainerPerformance) *analysis_entity.PlaceRuleFact {
+	return analysis_entity.NewPlaceRuleFact("", 20240201, 12, 1600, types.TwoWinClass, types.Turf, types.GoodToFirm, types.Tokyo, 1.8, "01000", 56,
+		horseResults, nil, trainerPerformance, nil)
+}
+
+func TestPlaceRuleEvaluate(t *testing.T) {
+	// 今走より前の戦績を新しい順に並べる。3走前だけ距離・コースが同じで馬券内、出遅れ
+	horseResults := []*analysis_entity.PlaceRuleHorseResult{
+		newTestPlaceRuleHorseResult(5, 2000, types.Nakayama, "01000", ""),
+		newTestPlaceRuleHorseResult(6, 1800, types.Nakayama, "01000", ""),
+		newTestPlaceRuleHorseResult(2, 1600, types.Tokyo, "01000", "出遅れ"),
+	}
+	trainerPerformance := analysis_entity.NewTrainerPerformance(30, 5, 10, decimal.Zero, decimal.Zero)
+
+	tests := []struct {
+		name   string
+		kind   string
+		params map[string]float64
+		negate bool
+		fact   *analysis_entity.PlaceRuleFact
+		want   bool
+	}{
+		{name: "頭数上限", kind: "entries_max", params: map[string]float64{"max": 13}, fact: newTestPlaceRuleFact(horseResults, nil), want: true},
+		{name: "単勝オッズ上限", kind: "win_odds_under", params: map[string]float64{"max": 2.0}, fact: newTestPlaceRuleFact(horseResults, nil), want: true},
+		{name: "2走前までに同距離なし", kind: "same_distance_recent", params: map[string]float64{"runs": 2}, fact: newTestPlaceRuleFact(horseResults, nil), want: false},
+		{name: "3走前に同距離", kind: "same_distance_recent", params: map[string]float64{"runs": 3}, fact: newTestPlaceRuleFact(horseResults, nil), want: true},
+		{name: "3走前に同コース", kind: "same_race_course_recent", params: map[string]float64{"runs": 3}, fact: newTestPlaceRuleFact(horseResults, nil), want: true},
+		{name: "2走前までに馬券内なし", kind: "place_recent", params: map[string]float64{"runs": 2, "order": 3}, fact: newTestPlaceRuleFact(horseResults, nil), want: false},
+		{name: "3走前に馬券内", kind: "place_recent", params: map[string]float64{"runs": 3, "order": 3}, fact: newTestPlaceRuleFact(horseResults, nil), want: true},
+		{name: "3走前の出遅れを反転", kind: "slow_start_recent", params: map[string]float64{"runs": 3}, negate: true, fact: newTestPlaceRuleFact(horseResults, nil), want: false},
+		{name: "初出走は出遅れなし", kind: "slow_start_recent", params: map[string]float64{"runs": 3}, negate: true, fact: newTestPlaceRuleFact(nil, nil), want: true},
+		{name: "初出走は継続騎乗扱い", kind: "continue_or_top_jockey", fact: newTestPlaceRuleFact(nil, nil), want: true},
+		{name: "初出走は複勝率なし", kind: "place_ratio", params: map[string]float64{"min": 0.8}, fact: newTestPlaceRuleFact(nil, nil), want: false},
+		{name: "前走4着以下", kind: "previous_order", params: map[string]float64{"min": 4}, fact: newTestPlaceRuleFact(horseResults, nil), want: true},
+		{name: "前走から2F以上の距離変更", kind: "distance_change", params: map[string]float64{"min": 400}, fact: newTestPlaceRuleFact(horseResults, nil), want: true},
+		{name: "調教師の複勝率", kind: "trainer_place_rate", params: map[string]float64{"min": 0.3, "min_races": 20}, fact: newTestPlaceRuleFact(nil, trainerPerformance), want: true},
+		{name: "調教師の出走数不足", kind: "trainer_place_rate", params: map[string]float64{"min": 0.3, "min_races": 40}, fact: newTestPlaceRuleFact(nil, trainerPerformance), want: false},
+		{name: "調教師の成績なし", kind: "trainer_place_rate", params: map[string]float64{"min": 0.3, "min_races": 20}, fact: newTestPlaceRuleFact(nil, nil), want: false},
+	}
+
+	service := &placeRuleService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := analysis_entity.NewPlaceRule(tt.name, tt.kind, tt.params, nil, tt.negate, 2)
+			if err != nil {
+				t.Fatalf("NewPlaceRule() error = %v", err)
+			}
+			results := service.Evaluate(context.Background(), []*analysis_entity.PlaceRule{rule}, tt.fact)
+			if len(results) != 1 {
+				t.Fatalf("results = %d, want 1", len(results))
+			}
+			if results[0].Ok() != tt.want {
+				t.Errorf("Ok() = %v, want %v", results[0].Ok(), tt.want)
+			}
+			wantPoint := 0
+			if tt.want {
+				wantPoint = 2
+			}
+			if results[0].Point() != wantPoint {
+				t.Errorf("Point() = %d, want %d", results[0].Point(), wantPoint)
+			}
+		})
+	}
+}
+
+func TestPlaceRuleEvaluateEmpty(t *testing.T) {
+	results := (&placeRuleService{}).Evaluate(context.Background(), nil, newTestPlaceRuleFact(nil, nil))
+	if len(results) != 0 {
+		t.Errorf("results = %d, want 0", len(results))
+	}
+}
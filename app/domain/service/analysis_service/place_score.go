@@ -0,0 +1,333 @@
+package analysis_service
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/config"
+)
+
+const (
+	placeScoreMinSampleCount = 100
+	placeScoreIterations     = 3000
+	placeScoreLearningRate   = 0.5
+	placeScoreL2Lambda       = 0.01
+	placeScoreValidationRate = 0.2 // 直近2割ほどの開催日の馬を検証に使う
+	placeScoreReliabilityBin = 10  // 予測確率を10%刻みで実際の複勝圏内率と比べる
+)
+
+type PlaceScore interface {
+	CreateSamples(ctx context.Context,
+		rules []*analysis_entity.PlaceRule,
+		races []*data_cache_entity.Race,
+		horses []*data_cache_entity.Horse,
+		raceForecasts []*data_cache_entity.RaceForecast,
+		trainerCalculables []*analysis_entity.TrainerCalculable,
+	) ([]*analysis_entity.PlaceScoreSample, error)
+	Fit(ctx context.Context, samples []*analysis_entity.PlaceScoreSample) *analysis_entity.PlaceScoreModel
+	Calculate(ctx context.Context, model *analysis_entity.PlaceScoreModel, checkList []*analysis_entity.PlaceRuleResult) *analysis_entity.PlaceScore
+}
+
+type placeScoreService struct {
+	placeCheckListService       PlaceCheckList
+	raceEntityConverter         converter.RaceEntityConverter
+	horseEntityConverter        converter.HorseEntityConverter
+	raceForecastEntityConverter converter.RaceForecastEntityConverter
+}
+
+func NewPlaceScore(
+	placeCheckListService PlaceCheckList,
+	raceEntityConverter converter.RaceEntityConverter,
+	horseEntityConverter converter.HorseEntityConverter,
+	raceForecastEntityConverter converter.RaceForecastEntityConverter,
+) PlaceScore {
+	return &placeScoreService{
+		placeCheckListService:       placeCheckListService,
+		raceEntityConverter:         raceEntityConverter,
+		horseEntityConverter:        horseEntityConverter,
+		raceForecastEntityConverter: raceForecastEntityConverter,
+	}
+}
+
+// CreateSamples 馬・予想のキャッシュがある過去レースからチェックリストと複勝圏内の結果を学習データとして作る
+// 予想の対象と揃えるため単勝オッズがチェックリスト対象の範囲内の馬に限定する
+// 調教師・騎手×調教師の成績は結果が漏れないように対象レースの前日までのレースだけで集計する
+func (p *placeScoreService) CreateSamples(
+	ctx context.Context,
+	rules []*analysis_entity.PlaceRule,
+	races []*data_cache_entity.Race,
+	horses []*data_cache_entity.Horse,
+	raceForecasts []*data_cache_entity.RaceForecast,
+	trainerCalculables []*analysis_entity.TrainerCalculable,
+) ([]*analysis_entity.PlaceScoreSample, error) {
+	horseMap := converter.ConvertToMap(horses, func(horse *data_cache_entity.Horse) types.HorseId {
+		return horse.HorseId()
+	})
+	raceForecastMap := converter.ConvertToMap(raceForecasts, func(raceForecast *data_cache_entity.RaceForecast) types.RaceId {
+		return raceForecast.RaceId()
+	})
+
+	sortedRaces := slices.Clone(races)
+	sort.SliceStable(sortedRaces, func(i, j int) bool {
+		return sortedRaces[i].RaceDate() < sortedRaces[j].RaceDate()
+	})
+	trainerHistory := newTrainerHistory(trainerCalculables)
+
+	var samples []*analysis_entity.PlaceScoreSample
+	for _, race := range sortedRaces {
+		trainerHistory.advance(race.RaceDate())
+
+		raceForecast, ok := raceForecastMap[race.RaceId()]
+		if !ok {
+			continue
+		}
+		forecastMap := converter.ConvertToMap(raceForecast.Forecasts(), func(forecast *data_cache_entity.Forecast) types.HorseNumber {
+			return forecast.HorseNumber()
+		})
+
+		predictionRace := p.raceEntityConverter.DataCacheToPrediction(race)
+		for _, raceResult := range race.RaceResults() {
+			// 取消・除外の馬は対象外
+			odds := raceResult.Odds().InexactFloat64()
+			if odds == 0 || odds > config.PredictionCheckListWinLowerOdds {
+				continue
+			}
+			cacheHorse, ok := horseMap[raceResult.HorseId()]
+			if !ok {
+				continue
+			}
+			forecast, ok := forecastMap[raceResult.HorseNumber()]
+			if !ok {
+				continue
+			}
+
+			predictionHorse, err := p.horseEntityConverter.DataCacheToPrediction(cacheHorse)
+			if err != nil {
+				return nil, err
+			}
+
+			results := p.placeCheckListService.CreateCheckList(ctx, rules, &PlaceCheckListInput{
+				Race:                     predictionRace,
+				Horse:                    p.getHorseBeforeRace(predictionHorse, race.RaceDate()),
+				Forecast:                 p.raceForecastEntityConverter.DataCacheToPrediction(forecast),
+				TrainerPerformance:       trainerHistory.performance(raceResult.TrainerId()),
+				JockeyTrainerPerformance: trainerHistory.jockeyTrainerPerformance(raceResult.TrainerId(), raceResult.JockeyId()),
+			})
+			checkList := make([]bool, 0, len(results))
+			for _, result := range results {
+				checkList = append(checkList, result.Ok())
+			}
+
+			samples = append(samples, analysis_entity.NewPlaceScoreSample(
+				race.RaceId(),
+				race.RaceDate(),
+				raceResult.HorseId(),
+				checkList,
+				raceResult.OrderNo() >= 1 && raceResult.OrderNo() <= 3,
+			))
+		}
+	}
+
+	return samples, nil
+}
+
+// Fit チェックリストを説明変数、複勝圏内を目的変数としてL2正則化付きロジスティック回帰で重みを求める
+// 日付順に並べて直近の開催日の馬を検証に回し、予測確率の当たり具合(Brierスコアと区間ごとの実際の率)を確認してから全件で学習し直す
+func (p *placeScoreService) Fit(
+	ctx context.Context,
+	samples []*analysis_entity.PlaceScoreSample,
+) *analysis_entity.PlaceScoreModel {
+	if len(samples) < placeScoreMinSampleCount {
+		return nil
+	}
+
+	sortedSamples := slices.Clone(samples)
+	sort.SliceStable(sortedSamples, func(i, j int) bool {
+		return sortedSamples[i].RaceDate() < sortedSamples[j].RaceDate()
+	})
+
+	raceDates := make([]types.RaceDate, 0, len(sortedSamples))
+	for _, sample := range sortedSamples {
+		raceDates = append(raceDates, sample.RaceDate())
+	}
+	trainCount := holdoutIndex(raceDates, placeScoreValidationRate)
+	validationModel := p.fit(sortedSamples[:trainCount], nil)
+
+	return p.fit(sortedSamples, p.validate(ctx, validationModel, sortedSamples[trainCount:]))
+}
+
+// validate 検証用の馬で予測確率と結果を比べる
+func (p *placeScoreService) validate(
+	ctx context.Context,
+	model *analysis_entity.PlaceScoreModel,
+	samples []*analysis_entity.PlaceScoreSample,
+) *analysis_entity.PlaceScoreValidation {
+	baseRate := float64(model.PlaceCount()) / float64(model.SampleCount())
+	counts := make([]int, placeScoreReliabilityBin)
+	placeCounts := make([]int, placeScoreReliabilityBin)
+	predictedTotals := make([]float64, placeScoreReliabilityBin)
+
+	var brierScore, baseBrierScore float64
+	for _, sample := range samples {
+		score := model.Bias()
+		for i, check := range sample.CheckList() {
+			score += model.Weights()[i] * (p.boolToFloat(check) - model.Means()[i])
+		}
+		probability := p.sigmoid(score)
+		label := p.boolToFloat(sample.IsPlace())
+		brierScore += (probability - label) * (probability - label)
+		baseBrierScore += (baseRate - label) * (baseRate - label)
+
+		bin := min(int(probability*placeScoreReliabilityBin), placeScoreReliabilityBin-1)
+		counts[bin]++
+		predictedTotals[bin] += probability
+		if sample.IsPlace() {
+			placeCounts[bin]++
+		}
+	}
+	if len(samples) > 0 {
+		brierScore /= float64(len(samples))
+		baseBrierScore /= float64(len(samples))
+	}
+
+	reliabilityBins := make([]*analysis_entity.PlaceScoreReliabilityBin, 0, placeScoreReliabilityBin)
+	for i := 0; i < placeScoreReliabilityBin; i++ {
+		reliabilityBins = append(reliabilityBins, analysis_entity.NewPlaceScoreReliabilityBin(
+			float64(i)/placeScoreReliabilityBin,
+			float64(i+1)/placeScoreReliabilityBin,
+			counts[i],
+			placeCounts[i],
+			predictedTotals[i],
+		))
+	}
+
+	return analysis_entity.NewPlaceScoreValidation(len(samples), brierScore, baseBrierScore, reliabilityBins)
+}
+
+// fit 説明変数は学習データの平均で中心化しておき、各項目の寄与度が平均的な馬との差として読めるようにする
+func (p *placeScoreService) fit(
+	samples []*analysis_entity.PlaceScoreSample,
+	validation *analysis_entity.PlaceScoreValidation,
+) *analysis_entity.PlaceScoreModel {
+	featureNum := len(samples[0].CheckList())
+	sampleNum := float64(len(samples))
+	means := make([]float64, featureNum)
+	placeCount := 0
+	for _, sample := range samples {
+		for i, check := range sample.CheckList() {
+			if check {
+				means[i]++
+			}
+		}
+		if sample.IsPlace() {
+			placeCount++
+		}
+	}
+	for i := range means {
+		means[i] /= sampleNum
+	}
+
+	features := make([][]float64, 0, len(samples))
+	labels := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		feature := make([]float64, featureNum)
+		for i, check := range sample.CheckList() {
+			feature[i] = p.boolToFloat(check) - means[i]
+		}
+		features = append(features, feature)
+		labels = append(labels, p.boolToFloat(sample.IsPlace()))
+	}
+
+	bias := 0.0
+	weights := make([]float64, featureNum)
+	for iteration := 0; iteration < placeScoreIterations; iteration++ {
+		biasGradient := 0.0
+		weightGradients := make([]float64, featureNum)
+		for idx, feature := range features {
+			diff := p.sigmoid(p.linear(bias, weights, feature)) - labels[idx]
+			biasGradient += diff
+			for i, x := range feature {
+				weightGradients[i] += diff * x
+			}
+		}
+		bias -= placeScoreLearningRate * biasGradient / sampleNum
+		for i := range weights {
+			weights[i] -= placeScoreLearningRate * (weightGradients[i]/sampleNum + placeScoreL2Lambda*weights[i])
+		}
+	}
+
+	return analysis_entity.NewPlaceScoreModel(bias, weights, means, len(samples), placeCount, validation)
+}
+
+// Calculate 学習済みの重みからスコアと複勝圏内確率、項目ごとの寄与度を求める
+func (p *placeScoreService) Calculate(
+	ctx context.Context,
+	model *analysis_entity.PlaceScoreModel,
+	checkList []*analysis_entity.PlaceRuleResult,
+) *analysis_entity.PlaceScore {
+	if model == nil || len(model.Weights()) != len(checkList) {
+		return nil
+	}
+
+	contributions := make([]float64, len(checkList))
+	for i, result := range checkList {
+		contributions[i] = model.Weights()[i] * (p.boolToFloat(result.Ok()) - model.Means()[i])
+	}
+
+	score := model.Bias()
+	for _, contribution := range contributions {
+		score += contribution
+	}
+
+	return analysis_entity.NewPlaceScore(model.Bias(), contributions, p.sigmoid(score))
+}
+
+// getHorseBeforeRace キャッシュ取得後の戦績が混ざらないように対象レースより前の戦績だけにする
+func (p *placeScoreService) getHorseBeforeRace(
+	horse *prediction_entity.Horse,
+	raceDate types.RaceDate,
+) *prediction_entity.Horse {
+	horseResults := make([]*prediction_entity.HorseResult, 0, len(horse.HorseResults()))
+	for _, horseResult := range horse.HorseResults() {
+		if horseResult.RaceDate() < raceDate {
+			horseResults = append(horseResults, horseResult)
+		}
+	}
+
+	return prediction_entity.NewHorse(
+		horse.HorseId().Value(),
+		horse.HorseName(),
+		horse.HorseBirthDay().Value(),
+		horse.TrainerId().Value(),
+		horse.OwnerId().Value(),
+		horse.BreederId().Value(),
+		horse.HorseBlood(),
+		horseResults,
+	)
+}
+
+func (p *placeScoreService) linear(bias float64, weights []float64, feature []float64) float64 {
+	value := bias
+	for i, x := range feature {
+		value += weights[i] * x
+	}
+	return value
+}
+
+func (p *placeScoreService) sigmoid(value float64) float64 {
+	return 1.0 / (1.0 + math.Exp(-value))
+}
+
+func (p *placeScoreService) boolToFloat(value bool) float64 {
+	if value {
+		return 1.0
+	}
+	return 0.0
+}
@@ -0,0 +1,176 @@
+package analysis_service
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/shopspring/decimal"
+)
+
+func newTestTrainerCalculable(raceDate types.RaceDate, trainerId types.TrainerId, jockeyId types.JockeyId, orderNo int) *analysis_entity.TrainerCalculable {
+	return analysis_entity.NewTrainerCalculable("", raceDate, "", trainerId, jockeyId, orderNo, 1, decimal.NewFromInt(2), decimal.NewFromInt(1))
+}
+
+func TestTrainerHistory(t *testing.T) {
+	calculables := []*analysis_entity.TrainerCalculable{
+		newTestTrainerCalculable(20240107, "t1", "j1", 1),
+		newTestTrainerCalculable(20240106, "t1", "j1", 4),
+		newTestTrainerCalculable(20240106, "t1", "j2", 2),
+		newTestTrainerCalculable(20240113, "t1", "j1", 3),
+		newTestTrainerCalculable(20240113, "t2", "j1", 1),
+	}
+
+	tests := []struct {
+		name                  string
+		raceDate              types.RaceDate
+		wantTrainerRaceCount  int
+		wantTrainerPlaceCount int
+		wantComboRaceCount    int
+		wantTrainer2          bool
+	}{
+		{name: "最初の開催日は成績なし", raceDate: 20240106},
+		{name: "同じ日のレースは含めない", raceDate: 20240107, wantTrainerRaceCount: 2, wantTrainerPlaceCount: 1, wantComboRaceCount: 1},
+		{name: "前日までのレースを含める", raceDate: 20240113, wantTrainerRaceCount: 3, wantTrainerPlaceCount: 2, wantComboRaceCount: 2},
+		{name: "全レース後", raceDate: 20240120, wantTrainerRaceCount: 4, wantTrainerPlaceCount: 3, wantComboRaceCount: 3, wantTrainer2: true},
+	}
+
+	history := newTrainerHistory(calculables)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history.advance(tt.raceDate)
+
+			performance := history.performance("t1")
+			comboPerformance := history.jockeyTrainerPerformance("t1", "j1")
+			if tt.wantTrainerRaceCount == 0 {
+				if performance != nil || comboPerformance != nil {
+					t.Fatalf("performance = %v, %v, want nil", performance, comboPerformance)
+				}
+				return
+			}
+			if performance.RaceCount() != tt.wantTrainerRaceCount || performance.PlaceCount() != tt.wantTrainerPlaceCount {
+				t.Errorf("trainer race %d place %d, want %d %d", performance.RaceCount(), performance.PlaceCount(), tt.wantTrainerRaceCount, tt.wantTrainerPlaceCount)
+			}
+			if comboPerformance.RaceCount() != tt.wantComboRaceCount {
+				t.Errorf("combo race %d, want %d", comboPerformance.RaceCount(), tt.wantComboRaceCount)
+			}
+			if got := history.performance("t2") != nil; got != tt.wantTrainer2 {
+				t.Errorf("trainer2 exists %v, want %v", got, tt.wantTrainer2)
+			}
+		})
+	}
+}
+
+// newTestPlaceScoreSamples 1項目目に該当した馬は4頭に3頭、該当しない馬は4頭に1頭が複勝圏内になるデータ
+func newTestPlaceScoreSamples(num, perDate int) []*analysis_entity.PlaceScoreSample {
+	samples := make([]*analysis_entity.PlaceScoreSample, 0, num)
+	for i := 0; i < num; i++ {
+		check := i%2 == 0
+		isPlace := (i/2)%4 != 0
+		if !check {
+			isPlace = (i/2)%4 == 0
+		}
+		samples = append(samples, analysis_entity.NewPlaceScoreSample(
+			"", types.RaceDate(20240101+i/perDate), "", []bool{check, i%3 == 0}, isPlace,
+		))
+	}
+	return samples
+}
+
+func TestPlaceScoreFit(t *testing.T) {
+	tests := []struct {
+		name                string
+		samples             []*analysis_entity.PlaceScoreSample
+		wantNil             bool
+		wantPlaceCount      int
+		wantValidationCount int
+		wantWeight0         float64
+		wantBrierScore      float64
+	}{
+		{name: "学習データなし", samples: nil, wantNil: true},
+		{name: "学習データ不足", samples: newTestPlaceScoreSamples(99, 10), wantNil: true},
+		{name: "直近2割で検証", samples: newTestPlaceScoreSamples(200, 10), wantPlaceCount: 100, wantValidationCount: 40, wantWeight0: 1.826, wantBrierScore: 0.188},
+		{name: "同じ日の馬は学習と検証に分けない", samples: newTestPlaceScoreSamples(200, 15), wantPlaceCount: 100, wantValidationCount: 50, wantWeight0: 1.826, wantBrierScore: 0.185},
+	}
+
+	service := &placeScoreService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := service.Fit(context.Background(), tt.samples)
+			if tt.wantNil {
+				if model != nil {
+					t.Fatalf("Fit() = %v, want nil", model)
+				}
+				return
+			}
+			if model.SampleCount() != len(tt.samples) || model.PlaceCount() != tt.wantPlaceCount {
+				t.Errorf("sample %d place %d, want %d %d", model.SampleCount(), model.PlaceCount(), len(tt.samples), tt.wantPlaceCount)
+			}
+			if math.Abs(model.Weights()[0]-tt.wantWeight0) > 0.001 {
+				t.Errorf("weight0 = %.4f, want %.3f", model.Weights()[0], tt.wantWeight0)
+			}
+
+			validation := model.Validation()
+			if validation.SampleCount() != tt.wantValidationCount {
+				t.Errorf("validation count = %d, want %d", validation.SampleCount(), tt.wantValidationCount)
+			}
+			if math.Abs(validation.BrierScore()-tt.wantBrierScore) > 0.001 {
+				t.Errorf("brier = %.4f, want %.3f", validation.BrierScore(), tt.wantBrierScore)
+			}
+			if validation.BrierScore() >= validation.BaseBrierScore() {
+				t.Errorf("brier %.4f is not better than base %.4f", validation.BrierScore(), validation.BaseBrierScore())
+			}
+			binCount := 0
+			for _, bin := range validation.ReliabilityBins() {
+				binCount += bin.Count()
+			}
+			if binCount != tt.wantValidationCount {
+				t.Errorf("bin count = %d, want %d", binCount, tt.wantValidationCount)
+			}
+		})
+	}
+}
+
+func TestPlaceScoreCalculate(t *testing.T) {
+	model := analysis_entity.NewPlaceScoreModel(0, []float64{2, -1}, []float64{0.5, 0.5}, 100, 50, nil)
+	tests := []struct {
+		name              string
+		model             *analysis_entity.PlaceScoreModel
+		checks            []bool
+		wantNil           bool
+		wantContributions []float64
+		wantProbability   float64
+	}{
+		{name: "モデルなし", model: nil, checks: []bool{true, true}, wantNil: true},
+		{name: "項目数が違う", model: model, checks: []bool{true}, wantNil: true},
+		{name: "平均的な馬", model: model, checks: []bool{true, true}, wantContributions: []float64{1, -0.5}, wantProbability: 0.6225},
+		{name: "該当なし", model: model, checks: []bool{false, false}, wantContributions: []float64{-1, 0.5}, wantProbability: 0.3775},
+	}
+
+	service := &placeScoreService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkList := make([]*analysis_entity.PlaceRuleResult, 0, len(tt.checks))
+			for _, check := range tt.checks {
+				checkList = append(checkList, analysis_entity.NewPlaceRuleResult("", check, 0))
+			}
+			placeScore := service.Calculate(context.Background(), tt.model, checkList)
+			if tt.wantNil {
+				if placeScore != nil {
+					t.Fatalf("Calculate() = %v, want nil", placeScore)
+				}
+				return
+			}
+			for i, want := range tt.wantContributions {
+				if math.Abs(placeScore.Contributions()[i]-want) > 1e-9 {
+					t.Errorf("contribution[%d] = %f, want %f", i, placeScore.Contributions()[i], want)
+				}
+			}
+			if math.Abs(placeScore.Probability()-tt.wantProbability) > 0.0001 {
+				t.Errorf("probability = %.4f, want %.4f", placeScore.Probability(), tt.wantProbability)
+			}
+		})
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
-	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/tospo_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/filter_service"
@@ -59,9 +58,10 @@ type PlaceUnHit interface {
 		winOddsMap map[types.RaceId][]*analysis_entity.Odds,
 		raceMap map[types.RaceId]*analysis_entity.Race,
 	) ([]*analysis_entity.Odds, error)
+	GetQuinellaWheelSummaries(ctx context.Context,
+		wheelCombinations []*analysis_entity.Odds,
+	) (map[types.RaceId]int, map[types.RaceId]decimal.Decimal)
 	FetchHorse(ctx context.Context, horseId types.HorseId) (*netkeiba_entity.Horse, error)
-	FetchRaceForecasts(ctx context.Context, raceId types.RaceId) ([]*tospo_entity.Forecast, error)
-	FetchTrainingComments(ctx context.Context, raceId types.RaceId) ([]*tospo_entity.TrainingComment, error)
 	CreateUnhitRaces(ctx context.Context,
 		races []*analysis_entity.Race,
 		raceRateMap map[types.RaceId]map[types.HorseId][]float64,
@@ -73,37 +73,34 @@ type PlaceUnHit interface {
 		quinellaConsecutiveNumberMap map[types.RaceId]int,
 		quinellaCombinationTotalOddsMap map[types.RaceId]decimal.Decimal,
 		trioFavoriteCountMap map[types.RaceId]int,
+		dangerRules []*analysis_entity.PlaceRule,
 	) ([]*spreadsheet_entity.AnalysisPlaceUnhit, error)
-	CreateCheckPoints(ctx context.Context, race *analysis_entity.Race) error
 	Write(ctx context.Context, analysisPlaceUnhits []*spreadsheet_entity.AnalysisPlaceUnhit) error
 }
 
 type placeUnHitService struct {
 	horseRepository        repository.HorseRepository
-	raceForecastRepository repository.RaceForecastRepository
 	spreadSheetRepository  repository.SpreadSheetRepository
 	horseEntityConverter   converter.HorseEntityConverter
 	filterService          filter_service.AnalysisFilter
-	placeCheckListService  PlaceCheckList
+	placeRuleService       PlaceRule
 	placeCheckPointService PlaceCheckPoint
 }
 
 func NewPlaceUnHit(
 	horseRepository repository.HorseRepository,
-	raceForecastRepository repository.RaceForecastRepository,
 	spreadSheetRepository repository.SpreadSheetRepository,
 	horseEntityConverter converter.HorseEntityConverter,
 	filterService filter_service.AnalysisFilter,
-	placeCheckListService PlaceCheckList,
+	placeRuleService PlaceRule,
 	placeCheckPointService PlaceCheckPoint,
 ) PlaceUnHit {
 	return &placeUnHitService{
 		horseRepository:        horseRepository,
-		raceForecastRepository: raceForecastRepository,
 		spreadSheetRepository:  spreadSheetRepository,
 		horseEntityConverter:   horseEntityConverter,
 		filterService:          filterService,
-		placeCheckListService:  placeCheckListService,
+		placeRuleService:       placeRuleService,
 		placeCheckPointService: placeCheckPointService,
 	}
 }
@@ -158,6 +155,7 @@ func (p *placeUnHitService) GetUnHitRaces(
 					raceResult.JockeyWeight(),
 					raceResult.HorseWeight(),
 					raceResult.HorseWeightAdd(),
+					raceResult.RunningStyle(),
 				))
 
 				marker := types.NoMarker
@@ -695,40 +693,42 @@ func (p *placeUnHitService) GetQuinellaOddsWheelCombinations(
 	return quinellaWheelCombinations, nil
 }
 
-func (p *placeUnHitService) FetchHorse(
+// GetQuinellaWheelSummaries 1番人気軸の馬連の組み合わせから、人気順に連続している数と合計オッズをレースごとに求める
+// 組み合わせは人気順に並んでいる前提
+func (p *placeUnHitService) GetQuinellaWheelSummaries(
 	ctx context.Context,
-	horseId types.HorseId,
-) (*netkeiba_entity.Horse, error) {
-	horse, err := p.horseRepository.Fetch(ctx, fmt.Sprintf(horseUrl, horseId))
-	if err != nil {
-		return nil, err
-	}
-
-	return horse, nil
-}
+	wheelCombinations []*analysis_entity.Odds,
+) (map[types.RaceId]int, map[types.RaceId]decimal.Decimal) {
+	quinellaCombinationOddsMap := converter.ConvertToSliceMap(wheelCombinations, func(odds *analysis_entity.Odds) types.RaceId {
+		return odds.RaceId()
+	})
 
-func (p *placeUnHitService) FetchRaceForecasts(
-	ctx context.Context,
-	raceId types.RaceId,
-) ([]*tospo_entity.Forecast, error) {
-	forecasts, err := p.raceForecastRepository.FetchRaceForecast(ctx, fmt.Sprintf(raceForecastUrl, raceId))
-	if err != nil {
-		return nil, err
+	quinellaConsecutiveNumberMap := make(map[types.RaceId]int)
+	quinellaCombinationTotalOddsMap := make(map[types.RaceId]decimal.Decimal)
+	for raceId, oddsList := range quinellaCombinationOddsMap {
+		quinellaConsecutiveNumberMap[raceId] = 0
+		quinellaCombinationTotalOddsMap[raceId] = decimal.Zero
+		for i, odds := range oddsList {
+			quinellaCombinationTotalOddsMap[raceId] = quinellaCombinationTotalOddsMap[raceId].Add(odds.Odds())
+			if i+1 == odds.PopularNumber() {
+				quinellaConsecutiveNumberMap[raceId]++
+			}
+		}
 	}
 
-	return forecasts, nil
+	return quinellaConsecutiveNumberMap, quinellaCombinationTotalOddsMap
 }
 
-func (p *placeUnHitService) FetchTrainingComments(
+func (p *placeUnHitService) FetchHorse(
 	ctx context.Context,
-	raceId types.RaceId,
-) ([]*tospo_entity.TrainingComment, error) {
-	trainingComments, err := p.raceForecastRepository.FetchTrainingComment(ctx, fmt.Sprintf(raceTrainingCommentUrl, raceId))
+	horseId types.HorseId,
+) (*netkeiba_entity.Horse, error) {
+	horse, err := p.horseRepository.Fetch(ctx, fmt.Sprintf(horseUrl, horseId))
 	if err != nil {
 		return nil, err
 	}
 
-	return trainingComments, nil
+	return horse, nil
 }
 
 func (p *placeUnHitService) CreateUnhitRaces(ctx context.Context,
@@ -742,6 +742,7 @@ func (p *placeUnHitService) CreateUnhitRaces(ctx context.Context,
 	quinellaConsecutiveNumberMap map[types.RaceId]int,
 	quinellaCombinationTotalOddsMap map[types.RaceId]decimal.Decimal,
 	trioFavoriteCountMap map[types.RaceId]int,
+	dangerRules []*analysis_entity.PlaceRule,
 ) ([]*spreadsheet_entity.AnalysisPlaceUnhit, error) {
 	placeUnHitEntites := make([]*spreadsheet_entity.AnalysisPlaceUnhit, 0, len(races))
 	for _, race := range races {
@@ -777,6 +778,16 @@ func (p *placeUnHitService) CreateUnhitRaces(ctx context.Context,
 				}
 			}
 
+			var horse *analysis_entity.Horse
+			if cacheHorse, ok := horseMap[raceResult.HorseId()]; ok {
+				var err error
+				horse, err = p.horseEntityConverter.DataCacheToAnalysis(cacheHorse)
+				if err != nil {
+					return nil, err
+				}
+			}
+			dangerCheckPoints := p.placeCheckPointService.GetNegativePoint(ctx, dangerRules, p.placeRuleService.CreateAnalysisFact(ctx, race, raceResult, horse))
+
 			placeUnHitEntites = append(placeUnHitEntites, spreadsheet_entity.NewAnalysisPlaceUnhit(
 				race.RaceId(),
 				race.RaceDate(),
@@ -815,6 +826,8 @@ func (p *placeUnHitService) CreateUnhitRaces(ctx context.Context,
 				quinellaCombinationTotalOdds,
 				trioFavoriteCount,
 				raceForecast.TrainingComment(),
+				raceResult.RunningStyle(),
+				dangerCheckPoints,
 			))
 		}
 	}
@@ -822,17 +835,6 @@ func (p *placeUnHitService) CreateUnhitRaces(ctx context.Context,
 	return placeUnHitEntites, nil
 }
 
-func (p *placeUnHitService) CreateCheckPoints(
-	ctx context.Context,
-	race *analysis_entity.Race,
-) error {
-
-	p.placeCheckPointService.GetNegativePoint(ctx, race)
-
-	// 実装を追加
-	return nil
-}
-
 func (p *placeUnHitService) Write(
 	ctx context.Context,
 	analysisPlaceUnhits []*spreadsheet_entity.AnalysisPlaceUnhit,
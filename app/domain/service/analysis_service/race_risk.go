@@ -0,0 +1,403 @@
+package analysis_service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	raceRiskMinSampleCount     = 200
+	raceRiskIterations         = 3000
+	raceRiskLearningRate       = 0.5
+	raceRiskL2Lambda           = 0.01
+	raceRiskValidationRate     = 0.2 // 直近2割ほどの開催日のレースを検証に使う
+	raceRiskRedOdds            = 10.0
+	raceRiskTrioPopularNumber  = 100
+	raceRiskSignalDisplayCount = 3
+)
+
+// raceRiskSignalNames 1番人気複勝圏外レースの分析シートで見ているシグナル
+var raceRiskSignalNames = []string{
+	"1番人気単勝オッズ",
+	"1-2番人気オッズ差",
+	"2-3番人気オッズ差",
+	"単勝10倍未満頭数",
+	"三連複100番人気オッズ",
+	"三連複100番人気内1番人気含有数",
+	"馬連1番人気軸人気順連続数",
+	"馬連1番人気軸合計オッズ",
+}
+
+type RaceRisk interface {
+	CreateSamples(ctx context.Context,
+		races []*data_cache_entity.Race,
+		input *RaceRiskOddsInput,
+	) ([]*analysis_entity.RaceRiskSample, error)
+	CreateFeatures(ctx context.Context, input *RaceRiskOddsInput) ([]float64, error)
+	Fit(ctx context.Context, samples []*analysis_entity.RaceRiskSample) *analysis_entity.RaceRiskModel
+	Calculate(ctx context.Context, model *analysis_entity.RaceRiskModel, features []float64) *analysis_entity.RaceRisk
+}
+
+// RaceRiskOddsInput 1レース分、もしくは複数レース分のオッズ
+type RaceRiskOddsInput struct {
+	Win      []*data_cache_entity.Odds
+	Trio     []*data_cache_entity.Odds
+	Quinella []*data_cache_entity.Odds
+}
+
+type raceRiskService struct {
+	placeUnHitService PlaceUnHit
+}
+
+func NewRaceRisk(
+	placeUnHitService PlaceUnHit,
+) RaceRisk {
+	return &raceRiskService{
+		placeUnHitService: placeUnHitService,
+	}
+}
+
+// CreateSamples オッズのキャッシュがある過去レースからシグナルと1番人気の複勝圏外を学習データとして作る
+func (r *raceRiskService) CreateSamples(
+	ctx context.Context,
+	races []*data_cache_entity.Race,
+	input *RaceRiskOddsInput,
+) ([]*analysis_entity.RaceRiskSample, error) {
+	getRaceId := func(odds *data_cache_entity.Odds) types.RaceId {
+		return odds.RaceId()
+	}
+	winOddsMap := converter.ConvertToSliceMap(input.Win, getRaceId)
+	trioOddsMap := converter.ConvertToSliceMap(input.Trio, getRaceId)
+	quinellaOddsMap := converter.ConvertToSliceMap(input.Quinella, getRaceId)
+
+	var samples []*analysis_entity.RaceRiskSample
+	for _, race := range races {
+		winOdds, ok := winOddsMap[race.RaceId()]
+		if !ok {
+			continue
+		}
+		trioOdds, ok := trioOddsMap[race.RaceId()]
+		if !ok {
+			continue
+		}
+		quinellaOdds, ok := quinellaOddsMap[race.RaceId()]
+		if !ok {
+			continue
+		}
+
+		var favoriteRaceResult *data_cache_entity.RaceResult
+		for _, raceResult := range race.RaceResults() {
+			if raceResult.PopularNumber() == 1 {
+				favoriteRaceResult = raceResult
+				break
+			}
+		}
+		if favoriteRaceResult == nil {
+			continue
+		}
+
+		features, err := r.CreateFeatures(ctx, &RaceRiskOddsInput{
+			Win:      winOdds,
+			Trio:     trioOdds,
+			Quinella: quinellaOdds,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if features == nil {
+			continue
+		}
+
+		samples = append(samples, analysis_entity.NewRaceRiskSample(
+			race.RaceId(),
+			race.RaceDate(),
+			features,
+			favoriteRaceResult.OrderNo() < 1 || favoriteRaceResult.OrderNo() > 3,
+		))
+	}
+
+	return samples, nil
+}
+
+// CreateFeatures 1レース分のオッズからシグナルを求める、オッズが揃っていない場合はnil
+// シグナルは1番人気複勝圏外レースの分析シートと同じ求め方にする
+func (r *raceRiskService) CreateFeatures(
+	ctx context.Context,
+	input *RaceRiskOddsInput,
+) ([]float64, error) {
+	winOdds := r.sortByPopular(input.Win)
+	trioOdds := r.sortByPopular(input.Trio)
+	quinellaOdds := r.sortByPopular(input.Quinella)
+	if len(winOdds) < 3 || len(trioOdds) == 0 || len(quinellaOdds) == 0 || winOdds[0].PopularNumber() != 1 {
+		return nil, nil
+	}
+
+	favoriteOdds, err := r.parseOdds(winOdds[0])
+	if err != nil {
+		return nil, err
+	}
+	// 取消・除外の馬は0倍
+	if favoriteOdds == 0 {
+		return nil, nil
+	}
+
+	// 1レース分のオッズしか渡さないので、分析対象のレースはそのレースだけにする
+	raceId := winOdds[0].RaceId()
+	raceMap := map[types.RaceId]*analysis_entity.Race{raceId: nil}
+
+	winOddsFaults, err := r.placeUnHitService.GetWinOddsFaults(ctx, winOdds, raceMap)
+	if err != nil {
+		return nil, err
+	}
+
+	winRedOdds, err := r.placeUnHitService.GetWinRedOdds(ctx, winOdds, decimal.NewFromFloat(raceRiskRedOdds), raceMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// 少頭数で100番人気まで無い場合は最も人気の無い組み合わせ
+	trioPopularNumber := trioOdds[min(raceRiskTrioPopularNumber, len(trioOdds))-1].PopularNumber()
+	trioOdds100, err := r.placeUnHitService.GetTrioOdds100(ctx, trioOdds, trioPopularNumber, raceMap)
+	if err != nil {
+		return nil, err
+	}
+	if len(trioOdds100) == 0 {
+		return nil, nil
+	}
+
+	// 1番人気の単勝オッズが赤オッズでなくても軸を決められるように、1番人気だけを渡す
+	favoriteWinOdds, err := analysis_entity.NewOdds(raceId, winOdds[0].RaceDate(), winOdds[0].TicketType(), winOdds[0].Number(), winOdds[0].PopularNumber(), winOdds[0].Odds())
+	if err != nil {
+		return nil, err
+	}
+	winOddsMap := map[types.RaceId][]*analysis_entity.Odds{raceId: {favoriteWinOdds}}
+
+	trioFavoriteContains, err := r.placeUnHitService.GetTrioFavoriteContains(ctx, trioOdds, winOddsMap, raceMap)
+	if err != nil {
+		return nil, err
+	}
+
+	quinellaWheelCombinations, err := r.placeUnHitService.GetQuinellaOddsWheelCombinations(ctx, quinellaOdds, winOddsMap, raceMap)
+	if err != nil {
+		return nil, err
+	}
+	quinellaConsecutiveNumberMap, quinellaTotalOddsMap := r.placeUnHitService.GetQuinellaWheelSummaries(ctx, quinellaWheelCombinations)
+
+	return []float64{
+		favoriteOdds,
+		winOddsFaults[0].OddsFault().InexactFloat64(),
+		winOddsFaults[1].OddsFault().InexactFloat64(),
+		float64(len(winRedOdds)),
+		trioOdds100[0].Odds().InexactFloat64(),
+		float64(trioFavoriteContains[raceId]),
+		float64(quinellaConsecutiveNumberMap[raceId]),
+		quinellaTotalOddsMap[raceId].InexactFloat64(),
+	}, nil
+}
+
+// Fit シグナルを標準化してL2正則化付きロジスティック回帰で1番人気の複勝圏外を学習する
+// 日付順に並べて直近の開催日のレースを検証に回し、判定の当たり具合を確認してから全件で学習し直す
+func (r *raceRiskService) Fit(
+	ctx context.Context,
+	samples []*analysis_entity.RaceRiskSample,
+) *analysis_entity.RaceRiskModel {
+	if len(samples) < raceRiskMinSampleCount {
+		return nil
+	}
+
+	sortedSamples := slices.Clone(samples)
+	sort.SliceStable(sortedSamples, func(i, j int) bool {
+		return sortedSamples[i].RaceDate() < sortedSamples[j].RaceDate()
+	})
+
+	raceDates := make([]types.RaceDate, 0, len(sortedSamples))
+	for _, sample := range sortedSamples {
+		raceDates = append(raceDates, sample.RaceDate())
+	}
+	trainCount := holdoutIndex(raceDates, raceRiskValidationRate)
+	validationModel := r.fit(sortedSamples[:trainCount], nil)
+
+	solidCount, solidUnHitCount, roughCount, roughUnHitCount := 0, 0, 0, 0
+	for _, sample := range sortedSamples[trainCount:] {
+		raceRisk := r.Calculate(ctx, validationModel, sample.Features())
+		switch raceRisk.Rating() {
+		case types.SolidRace:
+			solidCount++
+			if sample.IsUnHit() {
+				solidUnHitCount++
+			}
+		case types.RoughRace:
+			roughCount++
+			if sample.IsUnHit() {
+				roughUnHitCount++
+			}
+		}
+	}
+
+	return r.fit(sortedSamples, analysis_entity.NewRaceRiskValidation(solidCount, solidUnHitCount, roughCount, roughUnHitCount))
+}
+
+// Calculate 学習済みの重みから1番人気が複勝圏外になる確率を求め、判定に効いたシグナルを添える
+func (r *raceRiskService) Calculate(
+	ctx context.Context,
+	model *analysis_entity.RaceRiskModel,
+	features []float64,
+) *analysis_entity.RaceRisk {
+	if model == nil || len(model.Weights()) != len(features) {
+		return analysis_entity.NewRaceRisk(types.UnknownRaceRiskRating, 0, nil)
+	}
+
+	score := model.Bias()
+	signals := make([]*analysis_entity.RaceRiskSignal, 0, len(features))
+	for i, value := range features {
+		contribution := model.Weights()[i] * (value - model.Means()[i]) / model.Stds()[i]
+		score += contribution
+		signals = append(signals, analysis_entity.NewRaceRiskSignal(raceRiskSignalNames[i], value, contribution))
+	}
+
+	probability := r.sigmoid(score)
+	rating := types.SolidRace
+	if probability >= model.Threshold() {
+		rating = types.RoughRace
+	}
+
+	// 判定と同じ方向に効いたシグナルだけを寄与度の大きい順に残す
+	drivers := make([]*analysis_entity.RaceRiskSignal, 0, len(signals))
+	for _, signal := range signals {
+		if (rating == types.RoughRace && signal.Contribution() > 0) || (rating == types.SolidRace && signal.Contribution() < 0) {
+			drivers = append(drivers, signal)
+		}
+	}
+	sort.SliceStable(drivers, func(i, j int) bool {
+		return math.Abs(drivers[i].Contribution()) > math.Abs(drivers[j].Contribution())
+	})
+	if len(drivers) > raceRiskSignalDisplayCount {
+		drivers = drivers[:raceRiskSignalDisplayCount]
+	}
+
+	return analysis_entity.NewRaceRisk(rating, probability, drivers)
+}
+
+func (r *raceRiskService) fit(
+	samples []*analysis_entity.RaceRiskSample,
+	validation *analysis_entity.RaceRiskValidation,
+) *analysis_entity.RaceRiskModel {
+	featureNum := len(raceRiskSignalNames)
+	sampleNum := float64(len(samples))
+
+	means := make([]float64, featureNum)
+	unHitCount := 0
+	for _, sample := range samples {
+		for i, value := range sample.Features() {
+			means[i] += value
+		}
+		if sample.IsUnHit() {
+			unHitCount++
+		}
+	}
+	for i := range means {
+		means[i] /= sampleNum
+	}
+
+	stds := make([]float64, featureNum)
+	for _, sample := range samples {
+		for i, value := range sample.Features() {
+			stds[i] += (value - means[i]) * (value - means[i])
+		}
+	}
+	for i := range stds {
+		stds[i] = math.Sqrt(stds[i] / sampleNum)
+		// 全レース同じ値のシグナルは判定に使えないので寄与しないようにする
+		if stds[i] == 0 {
+			stds[i] = 1
+		}
+	}
+
+	features := make([][]float64, 0, len(samples))
+	labels := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		feature := make([]float64, featureNum)
+		for i, value := range sample.Features() {
+			feature[i] = (value - means[i]) / stds[i]
+		}
+		features = append(features, feature)
+		label := 0.0
+		if sample.IsUnHit() {
+			label = 1.0
+		}
+		labels = append(labels, label)
+	}
+
+	bias := 0.0
+	weights := make([]float64, featureNum)
+	for iteration := 0; iteration < raceRiskIterations; iteration++ {
+		biasGradient := 0.0
+		weightGradients := make([]float64, featureNum)
+		for idx, feature := range features {
+			diff := r.sigmoid(r.linear(bias, weights, feature)) - labels[idx]
+			biasGradient += diff
+			for i, x := range feature {
+				weightGradients[i] += diff * x
+			}
+		}
+		bias -= raceRiskLearningRate * biasGradient / sampleNum
+		for i := range weights {
+			weights[i] -= raceRiskLearningRate * (weightGradients[i]/sampleNum + raceRiskL2Lambda*weights[i])
+		}
+	}
+
+	return analysis_entity.NewRaceRiskModel(
+		bias,
+		weights,
+		means,
+		stds,
+		float64(unHitCount)/sampleNum,
+		len(samples),
+		unHitCount,
+		validation,
+	)
+}
+
+func (r *raceRiskService) sortByPopular(oddsList []*data_cache_entity.Odds) []*data_cache_entity.Odds {
+	sortedOddsList := make([]*data_cache_entity.Odds, 0, len(oddsList))
+	for _, odds := range oddsList {
+		// 取消・除外で人気が付いていないものは除く
+		if odds.PopularNumber() > 0 && len(odds.Odds()) > 0 && len(odds.Number().List()) > 0 {
+			sortedOddsList = append(sortedOddsList, odds)
+		}
+	}
+	sort.SliceStable(sortedOddsList, func(i, j int) bool {
+		return sortedOddsList[i].PopularNumber() < sortedOddsList[j].PopularNumber()
+	})
+	return sortedOddsList
+}
+
+func (r *raceRiskService) parseOdds(odds *data_cache_entity.Odds) (float64, error) {
+	value, err := strconv.ParseFloat(odds.Odds()[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid odds %s: %s", odds.RaceId(), odds.Odds()[0])
+	}
+	return value, nil
+}
+
+func (r *raceRiskService) linear(bias float64, weights []float64, feature []float64) float64 {
+	value := bias
+	for i, x := range feature {
+		value += weights[i] * x
+	}
+	return value
+}
+
+func (r *raceRiskService) sigmoid(value float64) float64 {
+	return 1.0 / (1.0 + math.Exp(-value))
+}
@@ -0,0 +1,167 @@
+package analysis_service
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func TestRaceRiskCreateFeatures(t *testing.T) {
+	newOdds := func(ticketType types.TicketType, number string, popularNumber int, odds string) *data_cache_entity.Odds {
+		return data_cache_entity.NewOdds("202405040811", 20241020, ticketType, types.BetNumber(number), popularNumber, []string{odds})
+	}
+	// 人気順に並んでいなくても人気順に並べ直す。人気の付いていない取消馬は除く
+	winOdds := []*data_cache_entity.Odds{
+		newOdds(types.Win, "05", 3, "8.0"),
+		newOdds(types.Win, "03", 1, "2.0"),
+		newOdds(types.Win, "06", 0, "0"),
+		newOdds(types.Win, "04", 5, "30.0"),
+		newOdds(types.Win, "01", 2, "3.5"),
+		newOdds(types.Win, "02", 4, "12.0"),
+	}
+	// 100番人気まで無いので最も人気の無い組み合わせを使う
+	trioOdds := []*data_cache_entity.Odds{
+		newOdds(types.Trio, "01-03-05", 1, "5.0"),
+		newOdds(types.Trio, "01-02-03", 2, "8.0"),
+		newOdds(types.Trio, "01-02-05", 3, "20.0"),
+		newOdds(types.Trio, "02-04-05", 4, "90.0"),
+	}
+	quinellaOdds := []*data_cache_entity.Odds{
+		newOdds(types.Quinella, "01-03", 1, "3.0"),
+		newOdds(types.Quinella, "03-05", 2, "5.0"),
+		newOdds(types.Quinella, "01-05", 3, "9.0"),
+		newOdds(types.Quinella, "02-03", 4, "15.0"),
+	}
+
+	tests := []struct {
+		name  string
+		input *RaceRiskOddsInput
+		want  []float64
+	}{
+		{
+			name:  "シグナル",
+			input: &RaceRiskOddsInput{Win: winOdds, Trio: trioOdds, Quinella: quinellaOdds},
+			want: []float64{
+				2.0,  // 1番人気単勝オッズ
+				1.5,  // 1-2番人気オッズ差
+				4.5,  // 2-3番人気オッズ差
+				3,    // 単勝10倍未満頭数
+				90.0, // 三連複100番人気オッズ
+				2,    // 三連複100番人気内1番人気含有数
+				2,    // 馬連1番人気軸人気順連続数
+				23.0, // 馬連1番人気軸合計オッズ
+			},
+		},
+		{
+			name:  "単勝が3頭未満",
+			input: &RaceRiskOddsInput{Win: winOdds[1:3], Trio: trioOdds, Quinella: quinellaOdds},
+		},
+		{
+			name:  "三連複オッズが無い",
+			input: &RaceRiskOddsInput{Win: winOdds, Quinella: quinellaOdds},
+		},
+		{
+			name: "1番人気が0倍",
+			input: &RaceRiskOddsInput{
+				Win:      []*data_cache_entity.Odds{newOdds(types.Win, "03", 1, "0"), newOdds(types.Win, "01", 2, "3.5"), newOdds(types.Win, "05", 3, "8.0")},
+				Trio:     trioOdds,
+				Quinella: quinellaOdds,
+			},
+		},
+	}
+
+	r := &raceRiskService{placeUnHitService: &placeUnHitService{}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.CreateFeatures(context.Background(), tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("CreateFeatures() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("%s = %v, want %v", raceRiskSignalNames[i], got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRaceRiskFit(t *testing.T) {
+	// 1つ目のシグナルが1なら1番人気が複勝圏外。直近の2割は全て複勝圏外
+	newSamples := func(count int) []*analysis_entity.RaceRiskSample {
+		samples := make([]*analysis_entity.RaceRiskSample, 0, count)
+		for i := 0; i < count; i++ {
+			value := float64(i % 2)
+			if i >= count*4/5 {
+				value = 1
+			}
+			features := make([]float64, len(raceRiskSignalNames))
+			features[0] = value
+			samples = append(samples, analysis_entity.NewRaceRiskSample("", types.RaceDate(20230101+i), features, value == 1))
+		}
+		// 日付の新しい順に渡しても日付で並べ直して直近を検証に使う
+		for i, j := 0, len(samples)-1; i < j; i, j = i+1, j-1 {
+			samples[i], samples[j] = samples[j], samples[i]
+		}
+		return samples
+	}
+
+	tests := []struct {
+		name           string
+		samples        []*analysis_entity.RaceRiskSample
+		wantNil        bool
+		wantSolidCount int
+		wantRoughCount int
+	}{
+		{
+			name:    "サンプル数が足りない",
+			samples: newSamples(raceRiskMinSampleCount - 1),
+			wantNil: true,
+		},
+		{
+			name:           "直近のレースで検証する",
+			samples:        newSamples(250),
+			wantSolidCount: 0,
+			wantRoughCount: 50,
+		},
+	}
+
+	r := &raceRiskService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := r.Fit(context.Background(), tt.samples)
+			if tt.wantNil {
+				if model != nil {
+					t.Fatalf("Fit() = %v, want nil", model)
+				}
+				return
+			}
+			if model == nil {
+				t.Fatal("Fit() = nil")
+			}
+			if model.SampleCount() != len(tt.samples) {
+				t.Errorf("SampleCount() = %d, want %d", model.SampleCount(), len(tt.samples))
+			}
+			validation := model.Validation()
+			if validation.SolidCount() != tt.wantSolidCount || validation.RoughCount() != tt.wantRoughCount {
+				t.Errorf("validation solid %d rough %d, want %d %d", validation.SolidCount(), validation.RoughCount(), tt.wantSolidCount, tt.wantRoughCount)
+			}
+			if validation.RoughUnHitRate() != 1 {
+				t.Errorf("RoughUnHitRate() = %v, want 1", validation.RoughUnHitRate())
+			}
+
+			rough := r.Calculate(context.Background(), model, []float64{1, 0, 0, 0, 0, 0, 0, 0})
+			solid := r.Calculate(context.Background(), model, []float64{0, 0, 0, 0, 0, 0, 0, 0})
+			if rough.Rating() != types.RoughRace || solid.Rating() != types.SolidRace {
+				t.Errorf("Calculate() = %s, %s, want %s, %s", rough.Rating(), solid.Rating(), types.RoughRace, types.SolidRace)
+			}
+		})
+	}
+}
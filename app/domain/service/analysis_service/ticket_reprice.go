@@ -0,0 +1,465 @@
+package analysis_service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ticketRepriceUnit        = 100
+	ticketRepriceAllPeriod   = "全期間"
+	ticketRepriceYearFormat  = "%d年"
+	ticketRepriceMonthFormat = "%d年%02d月"
+)
+
+// ticketRepriceTicketTypes 買い直しの対象券種。枠連は馬番と対応付けられないので対象外
+var ticketRepriceTicketTypes = []types.TicketType{
+	types.Win,
+	types.Place,
+	types.Quinella,
+	types.Exacta,
+	types.QuinellaPlace,
+	types.Trio,
+	types.Trifecta,
+}
+
+// ticketRepriceArityMap 券種ごとの1点あたりの頭数
+var ticketRepriceArityMap = map[types.TicketType]int{
+	types.Win:           1,
+	types.Place:         1,
+	types.Quinella:      2,
+	types.Exacta:        2,
+	types.QuinellaPlace: 2,
+	types.Trio:          3,
+	types.Trifecta:      3,
+}
+
+// ticketRepriceOrderedMap 着順を問う券種
+var ticketRepriceOrderedMap = map[types.TicketType]bool{
+	types.Exacta:   true,
+	types.Trifecta: true,
+}
+
+type TicketReprice interface {
+	Create(ctx context.Context,
+		tickets []*ticket_csv_entity.RaceTicket,
+		races []*data_cache_entity.Race,
+	) ([]*analysis_entity.TicketReprice, error)
+	Convert(ctx context.Context, ticketReprices []*analysis_entity.TicketReprice) []*spreadsheet_entity.AnalysisTicketReprice
+	Write(ctx context.Context, analysisTicketReprices []*spreadsheet_entity.AnalysisTicketReprice) error
+}
+
+type ticketRepriceService struct {
+	spreadSheetRepository repository.SpreadSheetRepository
+}
+
+func NewTicketReprice(
+	spreadSheetRepository repository.SpreadSheetRepository,
+) TicketReprice {
+	return &ticketRepriceService{
+		spreadSheetRepository: spreadSheetRepository,
+	}
+}
+
+// ticketRepricePurchaseKey レース×券種単位の購入
+type ticketRepricePurchaseKey struct {
+	raceId     types.RaceId
+	ticketType types.TicketType
+}
+
+type ticketRepriceCount struct {
+	raceCount int
+	payment   int
+	payout    int
+}
+
+type ticketRepriceAlternativeCount struct {
+	raceCount int
+	hitCount  int
+	points    int
+	payment   int
+	payout    int
+}
+
+// ticketRepriceGroupKey 期間×購入券種
+type ticketRepriceGroupKey struct {
+	periodName string
+	ticketType types.TicketType
+}
+
+// Create 購入馬券をレース×券種単位にまとめ、同じ馬の組み合わせを他の券種で同じ金額だけ買った場合の払戻を求める
+// 馬券は読み込み時にながし・フォーメーションが1点ずつにバラされているので、1枚が1点になる。バラせなかった地方の馬券は対象外
+func (t *ticketRepriceService) Create(
+	ctx context.Context,
+	tickets []*ticket_csv_entity.RaceTicket,
+	races []*data_cache_entity.Race,
+) ([]*analysis_entity.TicketReprice, error) {
+	raceMap := converter.ConvertToMap(races, func(race *data_cache_entity.Race) types.RaceId {
+		return race.RaceId()
+	})
+
+	var purchaseKeys []ticketRepricePurchaseKey
+	purchaseTicketsMap := map[ticketRepricePurchaseKey][]*ticket_csv_entity.Ticket{}
+	for _, raceTicket := range tickets {
+		ticketType := raceTicket.Ticket().TicketType().OriginTicketType()
+		if _, ok := ticketRepriceArityMap[ticketType]; !ok {
+			continue
+		}
+		// 払戻結果がないレースは買い直しの払戻を求められないので対象外
+		if _, ok := raceMap[raceTicket.RaceId()]; !ok {
+			continue
+		}
+		// 1点ずつにバラせなかった地方競馬のまとめ買いは組み合わせを作れないので対象外
+		if raceTicket.Ticket().BetNumber().Grouped() {
+			continue
+		}
+		key := ticketRepricePurchaseKey{
+			raceId:     raceTicket.RaceId(),
+			ticketType: ticketType,
+		}
+		if _, ok := purchaseTicketsMap[key]; !ok {
+			purchaseKeys = append(purchaseKeys, key)
+		}
+		purchaseTicketsMap[key] = append(purchaseTicketsMap[key], raceTicket.Ticket())
+	}
+
+	ticketReprices := make([]*analysis_entity.TicketReprice, 0, len(purchaseKeys))
+	for _, key := range purchaseKeys {
+		purchaseTickets := purchaseTicketsMap[key]
+		race := raceMap[key.raceId]
+
+		var (
+			payment     int
+			payout      int
+			horseSets   [][]int
+			horseUnion  []int
+			horseMarked = map[int]bool{}
+		)
+		for _, ticket := range purchaseTickets {
+			payment += ticket.Payment().Value()
+			payout += ticket.Payout().Value()
+			horseNumbers := ticket.BetNumber().List()
+			horseSets = append(horseSets, horseNumbers)
+			for _, horseNumber := range horseNumbers {
+				if !horseMarked[horseNumber] {
+					horseMarked[horseNumber] = true
+					horseUnion = append(horseUnion, horseNumber)
+				}
+			}
+		}
+		sort.Ints(horseUnion)
+
+		var alternatives []*analysis_entity.TicketRepriceAlternative
+		for _, ticketType := range ticketRepriceTicketTypes {
+			if ticketType == key.ticketType {
+				continue
+			}
+			combinations := t.createCombinations(ticketType, horseSets, horseUnion)
+			if len(combinations) == 0 {
+				continue
+			}
+			// 同じ金額を各点に均等に割り振る。100円未満になる場合は同じ金額では買い直せないので対象外
+			paymentPerPoint := payment / len(combinations) / ticketRepriceUnit * ticketRepriceUnit
+			if paymentPerPoint == 0 {
+				continue
+			}
+			alternativePayout, err := t.calcPayout(race, ticketType, combinations, paymentPerPoint)
+			if err != nil {
+				return nil, fmt.Errorf("raceId %v: %w", key.raceId, err)
+			}
+			alternatives = append(alternatives, analysis_entity.NewTicketRepriceAlternative(
+				ticketType,
+				len(combinations),
+				types.Payment(paymentPerPoint*len(combinations)),
+				types.Payout(alternativePayout),
+			))
+		}
+
+		ticketReprices = append(ticketReprices, analysis_entity.NewTicketReprice(
+			key.raceId,
+			purchaseTickets[0].RaceDate(),
+			key.ticketType,
+			len(purchaseTickets),
+			types.Payment(payment),
+			types.Payout(payout),
+			alternatives,
+		))
+	}
+
+	return ticketReprices, nil
+}
+
+// Convert 全期間・年別・月別に、購入券種ごとの実績と他の券種で買い直した場合の回収率を並べる
+func (t *ticketRepriceService) Convert(
+	ctx context.Context,
+	ticketReprices []*analysis_entity.TicketReprice,
+) []*spreadsheet_entity.AnalysisTicketReprice {
+	yearMap := map[int]bool{}
+	monthMap := map[int]bool{}
+	countMap := map[ticketRepriceGroupKey]*ticketRepriceCount{}
+	alternativeCountMap := map[ticketRepriceGroupKey]map[types.TicketType]*ticketRepriceAlternativeCount{}
+
+	for _, ticketReprice := range ticketReprices {
+		raceDate := ticketReprice.RaceDate()
+		yearMap[raceDate.Year()] = true
+		monthMap[raceDate.Year()*100+raceDate.Month()] = true
+
+		periodNames := []string{
+			ticketRepriceAllPeriod,
+			fmt.Sprintf(ticketRepriceYearFormat, raceDate.Year()),
+			fmt.Sprintf(ticketRepriceMonthFormat, raceDate.Year(), raceDate.Month()),
+		}
+		for _, periodName := range periodNames {
+			key := ticketRepriceGroupKey{
+				periodName: periodName,
+				ticketType: ticketReprice.TicketType(),
+			}
+			if _, ok := countMap[key]; !ok {
+				countMap[key] = &ticketRepriceCount{}
+				alternativeCountMap[key] = map[types.TicketType]*ticketRepriceAlternativeCount{}
+			}
+			count := countMap[key]
+			count.raceCount++
+			count.payment += ticketReprice.Payment().Value()
+			count.payout += ticketReprice.Payout().Value()
+
+			for _, alternative := range ticketReprice.Alternatives() {
+				if _, ok := alternativeCountMap[key][alternative.TicketType()]; !ok {
+					alternativeCountMap[key][alternative.TicketType()] = &ticketRepriceAlternativeCount{}
+				}
+				alternativeCount := alternativeCountMap[key][alternative.TicketType()]
+				alternativeCount.raceCount++
+				alternativeCount.points += alternative.Points()
+				alternativeCount.payment += alternative.Payment().Value()
+				alternativeCount.payout += alternative.Payout().Value()
+				if alternative.Payout() > 0 {
+					alternativeCount.hitCount++
+				}
+			}
+		}
+	}
+
+	periodNames := []string{ticketRepriceAllPeriod}
+	years := make([]int, 0, len(yearMap))
+	for year := range yearMap {
+		years = append(years, year)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(years)))
+	for _, year := range years {
+		periodNames = append(periodNames, fmt.Sprintf(ticketRepriceYearFormat, year))
+	}
+	months := make([]int, 0, len(monthMap))
+	for month := range monthMap {
+		months = append(months, month)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(months)))
+	for _, month := range months {
+		periodNames = append(periodNames, fmt.Sprintf(ticketRepriceMonthFormat, month/100, month%100))
+	}
+
+	var analysisTicketReprices []*spreadsheet_entity.AnalysisTicketReprice
+	for _, periodName := range periodNames {
+		for _, ticketType := range ticketRepriceTicketTypes {
+			key := ticketRepriceGroupKey{
+				periodName: periodName,
+				ticketType: ticketType,
+			}
+			count, ok := countMap[key]
+			if !ok {
+				continue
+			}
+			payoutRate := t.payoutRate(count.payment, count.payout)
+			for _, alternativeTicketType := range ticketRepriceTicketTypes {
+				alternativeCount, ok := alternativeCountMap[key][alternativeTicketType]
+				if !ok {
+					continue
+				}
+				alternativePayoutRate := t.payoutRate(alternativeCount.payment, alternativeCount.payout)
+				analysisTicketReprices = append(analysisTicketReprices, spreadsheet_entity.NewAnalysisTicketReprice(
+					periodName,
+					ticketType.Name(),
+					count.raceCount,
+					count.payment,
+					count.payout,
+					t.formatRate(payoutRate),
+					alternativeTicketType.Name(),
+					alternativeCount.raceCount,
+					alternativeCount.points,
+					alternativeCount.payment,
+					alternativeCount.payout,
+					t.formatRate(float64(alternativeCount.hitCount)/float64(alternativeCount.raceCount)),
+					t.formatRate(alternativePayoutRate),
+					fmt.Sprintf("%+.2f", (alternativePayoutRate-payoutRate)*100),
+					alternativePayoutRate > payoutRate,
+				))
+			}
+		}
+	}
+
+	return analysisTicketReprices
+}
+
+func (t *ticketRepriceService) Write(
+	ctx context.Context,
+	analysisTicketReprices []*spreadsheet_entity.AnalysisTicketReprice,
+) error {
+	return t.spreadSheetRepository.WriteAnalysisTicketReprice(ctx, analysisTicketReprices)
+}
+
+// createCombinations 購入した各点と同じ馬で別の券種の組み合わせを作る
+// 頭数が減る場合は各点の馬から選び、増える場合は同じレースで買った他の馬を加える。馬単・3連単は着順違いもすべて買う
+func (t *ticketRepriceService) createCombinations(
+	ticketType types.TicketType,
+	horseSets [][]int,
+	horseUnion []int,
+) [][]int {
+	arity := ticketRepriceArityMap[ticketType]
+	combinationMap := map[string][]int{}
+	for _, horseSet := range horseSets {
+		sortedHorseSet := make([]int, len(horseSet))
+		copy(sortedHorseSet, horseSet)
+		sort.Ints(sortedHorseSet)
+
+		var sets [][]int
+		if arity <= len(sortedHorseSet) {
+			sets = t.choose(sortedHorseSet, arity)
+		} else {
+			var others []int
+			for _, horseNumber := range horseUnion {
+				if !t.contains(sortedHorseSet, horseNumber) {
+					others = append(others, horseNumber)
+				}
+			}
+			for _, extra := range t.choose(others, arity-len(sortedHorseSet)) {
+				set := append(append([]int{}, sortedHorseSet...), extra...)
+				sort.Ints(set)
+				sets = append(sets, set)
+			}
+		}
+
+		for _, set := range sets {
+			if ticketRepriceOrderedMap[ticketType] {
+				for _, permutation := range t.permute(set) {
+					combinationMap[t.combinationKey(permutation)] = permutation
+				}
+				continue
+			}
+			combinationMap[t.combinationKey(set)] = set
+		}
+	}
+
+	combinations := make([][]int, 0, len(combinationMap))
+	for _, combination := range combinationMap {
+		combinations = append(combinations, combination)
+	}
+
+	return combinations
+}
+
+// calcPayout 払戻結果と突き合わせて払戻額を求める。同着で複数の払戻がある場合はそれぞれ加算する
+func (t *ticketRepriceService) calcPayout(
+	race *data_cache_entity.Race,
+	ticketType types.TicketType,
+	combinations [][]int,
+	paymentPerPoint int,
+) (int, error) {
+	combinationMap := map[string]bool{}
+	for _, combination := range combinations {
+		combinationMap[t.combinationKey(combination)] = true
+	}
+
+	var payout int
+	for _, payoutResult := range race.PayoutResults() {
+		if payoutResult.TicketType() != ticketType {
+			continue
+		}
+		for idx, number := range payoutResult.Numbers() {
+			if idx >= len(payoutResult.Odds()) {
+				break
+			}
+			horseNumbers := number.List()
+			if !ticketRepriceOrderedMap[ticketType] {
+				sort.Ints(horseNumbers)
+			}
+			if !combinationMap[t.combinationKey(horseNumbers)] {
+				continue
+			}
+			odds, err := decimal.NewFromString(payoutResult.Odds()[idx])
+			if err != nil {
+				return 0, err
+			}
+			payout += int(odds.Mul(decimal.NewFromInt(int64(paymentPerPoint))).IntPart())
+		}
+	}
+
+	return payout, nil
+}
+
+func (t *ticketRepriceService) choose(values []int, k int) [][]int {
+	if k == 0 {
+		return [][]int{{}}
+	}
+	if len(values) < k {
+		return nil
+	}
+	var results [][]int
+	for _, rest := range t.choose(values[1:], k-1) {
+		results = append(results, append([]int{values[0]}, rest...))
+	}
+	return append(results, t.choose(values[1:], k)...)
+}
+
+func (t *ticketRepriceService) permute(values []int) [][]int {
+	if len(values) <= 1 {
+		return [][]int{append([]int{}, values...)}
+	}
+	var results [][]int
+	for i := range values {
+		rest := make([]int, 0, len(values)-1)
+		rest = append(rest, values[:i]...)
+		rest = append(rest, values[i+1:]...)
+		for _, permutation := range t.permute(rest) {
+			results = append(results, append([]int{values[i]}, permutation...))
+		}
+	}
+	return results
+}
+
+func (t *ticketRepriceService) contains(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *ticketRepriceService) combinationKey(horseNumbers []int) string {
+	keys := make([]string, 0, len(horseNumbers))
+	for _, horseNumber := range horseNumbers {
+		keys = append(keys, fmt.Sprintf("%02d", horseNumber))
+	}
+	return strings.Join(keys, "-")
+}
+
+func (t *ticketRepriceService) payoutRate(payment, payout int) float64 {
+	if payment == 0 {
+		return 0
+	}
+	return float64(payout) / float64(payment)
+}
+
+func (t *ticketRepriceService) formatRate(rate float64) string {
+	return fmt.Sprintf("%.2f%%", rate*100)
+}
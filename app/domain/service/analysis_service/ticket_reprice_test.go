@@ -0,0 +1,162 @@
+package analysis_service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func TestTicketRepriceCreate(t *testing.T) {
+	// 3-5-8で決着したレース
+	race := data_cache_entity.NewRace("202405040811", 20241020, 11, "05", "テスト", 1, "", "", "15:40", 16, 1600, 0, 0, 0, 0, 0, 0, 0, nil,
+		[]*data_cache_entity.PayoutResult{
+			data_cache_entity.NewPayoutResult(types.Win.Value(), []string{"03"}, []string{"2.5"}, []int{1}),
+			data_cache_entity.NewPayoutResult(types.Place.Value(), []string{"03", "05", "08"}, []string{"1.2", "1.8", "3.0"}, []int{1, 2, 5}),
+			data_cache_entity.NewPayoutResult(types.Quinella.Value(), []string{"03-05"}, []string{"6.0"}, []int{2}),
+			data_cache_entity.NewPayoutResult(types.Exacta.Value(), []string{"03→05"}, []string{"10.0"}, []int{3}),
+			data_cache_entity.NewPayoutResult(types.QuinellaPlace.Value(), []string{"03-05", "03-08", "05-08"}, []string{"2.0", "4.0", "6.0"}, []int{1, 4, 8}),
+			data_cache_entity.NewPayoutResult(types.Trio.Value(), []string{"03-05-08"}, []string{"20.0"}, []int{10}),
+			data_cache_entity.NewPayoutResult(types.Trifecta.Value(), []string{"03→05→08"}, []string{"80.0"}, []int{30}),
+		}, true)
+	races := []*data_cache_entity.Race{race}
+
+	newRaceTicket := func(raceId types.RaceId, betNumber, ticketType, payment, payout string) *ticket_csv_entity.RaceTicket {
+		ticket, err := ticket_csv_entity.NewTicket(types.NewBetNumber(betNumber), "20241020", "東京", "11", ticketType, payout != "0", payment, payout, types.Ipat, types.DefaultAccount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ticket_csv_entity.NewRaceTicket(raceId, ticket)
+	}
+
+	type alternative struct {
+		ticketType types.TicketType
+		points     int
+		payment    types.Payment
+		payout     types.Payout
+	}
+	type reprice struct {
+		ticketType   types.TicketType
+		points       int
+		payment      types.Payment
+		payout       types.Payout
+		alternatives []alternative
+	}
+	tests := []struct {
+		name    string
+		tickets []*ticket_csv_entity.RaceTicket
+		want    []reprice
+	}{
+		{
+			name: "馬券なし",
+			want: []reprice{},
+		},
+		{
+			name: "馬連1点を他の券種で買い直す",
+			tickets: []*ticket_csv_entity.RaceTicket{
+				newRaceTicket("202405040811", "03-05", "馬連", "1000", "6000"),
+			},
+			want: []reprice{
+				{
+					ticketType: types.Quinella, points: 1, payment: 1000, payout: 6000,
+					alternatives: []alternative{
+						{ticketType: types.Win, points: 2, payment: 1000, payout: 1250},
+						{ticketType: types.Place, points: 2, payment: 1000, payout: 1500},
+						{ticketType: types.Exacta, points: 2, payment: 1000, payout: 5000},
+						{ticketType: types.QuinellaPlace, points: 1, payment: 1000, payout: 2000},
+					},
+				},
+			},
+		},
+		{
+			name: "馬連ながしは馬連としてまとめる",
+			tickets: []*ticket_csv_entity.RaceTicket{
+				newRaceTicket("202405040811", "03-05", "馬連ながし", "500", "3000"),
+				newRaceTicket("202405040811", "03-08", "馬連ながし", "500", "0"),
+			},
+			want: []reprice{
+				{
+					ticketType: types.Quinella, points: 2, payment: 1000, payout: 3000,
+					alternatives: []alternative{
+						{ticketType: types.Win, points: 3, payment: 900, payout: 750},
+						{ticketType: types.Place, points: 3, payment: 900, payout: 1800},
+						{ticketType: types.Exacta, points: 4, payment: 800, payout: 2000},
+						{ticketType: types.QuinellaPlace, points: 2, payment: 1000, payout: 3000},
+						{ticketType: types.Trio, points: 1, payment: 1000, payout: 20000},
+						{ticketType: types.Trifecta, points: 6, payment: 600, payout: 8000},
+					},
+				},
+			},
+		},
+		{
+			name: "単勝2点は同じレースで買った他の馬を加えて組み合わせる",
+			tickets: []*ticket_csv_entity.RaceTicket{
+				newRaceTicket("202405040811", "03", "単勝", "100", "250"),
+				newRaceTicket("202405040811", "08", "単勝", "100", "0"),
+			},
+			want: []reprice{
+				{
+					ticketType: types.Win, points: 2, payment: 200, payout: 250,
+					alternatives: []alternative{
+						{ticketType: types.Place, points: 2, payment: 200, payout: 420},
+						{ticketType: types.Quinella, points: 1, payment: 200, payout: 0},
+						{ticketType: types.Exacta, points: 2, payment: 200, payout: 0},
+						{ticketType: types.QuinellaPlace, points: 1, payment: 200, payout: 800},
+					},
+				},
+			},
+		},
+		{
+			name: "1点100円未満になる券種は買い直さない",
+			tickets: []*ticket_csv_entity.RaceTicket{
+				newRaceTicket("202405040811", "03-05-08", "3連複", "100", "2000"),
+			},
+			want: []reprice{
+				{ticketType: types.Trio, points: 1, payment: 100, payout: 2000},
+			},
+		},
+		{
+			name: "払戻結果がないレースと枠連は対象外",
+			tickets: []*ticket_csv_entity.RaceTicket{
+				newRaceTicket("202405040812", "03", "単勝", "100", "0"),
+				newRaceTicket("202405040811", "02-03", "枠連", "100", "0"),
+			},
+			want: []reprice{},
+		},
+	}
+
+	ticketReprice := &ticketRepriceService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticketReprices, err := ticketReprice.Create(context.Background(), tt.tickets, races)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]reprice, 0, len(ticketReprices))
+			for _, r := range ticketReprices {
+				var alternatives []alternative
+				for _, a := range r.Alternatives() {
+					alternatives = append(alternatives, alternative{
+						ticketType: a.TicketType(),
+						points:     a.Points(),
+						payment:    a.Payment(),
+						payout:     a.Payout(),
+					})
+				}
+				got = append(got, reprice{
+					ticketType:   r.TicketType(),
+					points:       r.Points(),
+					payment:      r.Payment(),
+					payout:       r.Payout(),
+					alternatives: alternatives,
+				})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Create() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,416 @@
+package analysis_service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	trainerMinRaceCount      = 20 // 調教師の成績をチェックに使う最低出走数
+	trainerComboMinRaceCount = 5  // 騎手×調教師コンビの成績を使う最低出走数
+)
+
+// trainerPopularRanges 人気別成績の集計区分
+var trainerPopularRanges = []struct {
+	name string
+	from int
+	to   int
+}{
+	{"1人気", 1, 1},
+	{"2-3人気", 2, 3},
+	{"4-6人気", 4, 6},
+	{"7-9人気", 7, 9},
+	{"10人気-", 10, 99},
+}
+
+type Trainer interface {
+	Create(ctx context.Context, races []*data_cache_entity.Race) ([]*analysis_entity.TrainerCalculable, error)
+	GetPerformanceMap(ctx context.Context,
+		calculables []*analysis_entity.TrainerCalculable,
+	) map[types.TrainerId]*analysis_entity.TrainerPerformance
+	GetJockeyTrainerPerformanceMap(ctx context.Context,
+		calculables []*analysis_entity.TrainerCalculable,
+	) map[types.TrainerId]map[types.JockeyId]*analysis_entity.TrainerPerformance
+	Convert(ctx context.Context,
+		calculables []*analysis_entity.TrainerCalculable,
+		trainers []*data_cache_entity.Trainer,
+		jockeys []*data_cache_entity.Jockey,
+	) []*spreadsheet_entity.AnalysisTrainer
+	Write(ctx context.Context, analysisTrainers []*spreadsheet_entity.AnalysisTrainer) error
+}
+
+type trainerService struct {
+	spreadSheetRepository repository.SpreadSheetRepository
+}
+
+func NewTrainer(
+	spreadSheetRepository repository.SpreadSheetRepository,
+) Trainer {
+	return &trainerService{
+		spreadSheetRepository: spreadSheetRepository,
+	}
+}
+
+type trainerCount struct {
+	raceCount   int
+	winCount    int
+	placeCount  int
+	winPayout   decimal.Decimal
+	placePayout decimal.Decimal
+}
+
+func (c *trainerCount) add(calculable *analysis_entity.TrainerCalculable) {
+	c.raceCount++
+	if calculable.OrderNo() == 1 {
+		c.winCount++
+		c.winPayout = c.winPayout.Add(calculable.Odds())
+	}
+	if calculable.OrderNo() >= 1 && calculable.OrderNo() <= 3 {
+		c.placeCount++
+		c.placePayout = c.placePayout.Add(calculable.PlaceOdds())
+	}
+}
+
+func (c *trainerCount) performance() *analysis_entity.TrainerPerformance {
+	return analysis_entity.NewTrainerPerformance(
+		c.raceCount,
+		c.winCount,
+		c.placeCount,
+		c.winPayout,
+		c.placePayout,
+	)
+}
+
+// trainerHistory レース日順に成績を積み上げ、指定日より前のレースだけの成績を返す
+type trainerHistory struct {
+	calculables           []*analysis_entity.TrainerCalculable
+	index                 int
+	trainerCountMap       map[types.TrainerId]*trainerCount
+	jockeyTrainerCountMap map[types.TrainerId]map[types.JockeyId]*trainerCount
+}
+
+func newTrainerHistory(calculables []*analysis_entity.TrainerCalculable) *trainerHistory {
+	sortedCalculables := slices.Clone(calculables)
+	sort.SliceStable(sortedCalculables, func(i, j int) bool {
+		return sortedCalculables[i].RaceDate() < sortedCalculables[j].RaceDate()
+	})
+	return &trainerHistory{
+		calculables:           sortedCalculables,
+		trainerCountMap:       map[types.TrainerId]*trainerCount{},
+		jockeyTrainerCountMap: map[types.TrainerId]map[types.JockeyId]*trainerCount{},
+	}
+}
+
+// advance raceDateより前のレースを成績に加える。raceDateは昇順で渡す
+func (h *trainerHistory) advance(raceDate types.RaceDate) {
+	for ; h.index < len(h.calculables) && h.calculables[h.index].RaceDate() < raceDate; h.index++ {
+		calculable := h.calculables[h.index]
+		if _, ok := h.trainerCountMap[calculable.TrainerId()]; !ok {
+			h.trainerCountMap[calculable.TrainerId()] = &trainerCount{}
+			h.jockeyTrainerCountMap[calculable.TrainerId()] = map[types.JockeyId]*trainerCount{}
+		}
+		if _, ok := h.jockeyTrainerCountMap[calculable.TrainerId()][calculable.JockeyId()]; !ok {
+			h.jockeyTrainerCountMap[calculable.TrainerId()][calculable.JockeyId()] = &trainerCount{}
+		}
+		h.trainerCountMap[calculable.TrainerId()].add(calculable)
+		h.jockeyTrainerCountMap[calculable.TrainerId()][calculable.JockeyId()].add(calculable)
+	}
+}
+
+func (h *trainerHistory) performance(trainerId types.TrainerId) *analysis_entity.TrainerPerformance {
+	count, ok := h.trainerCountMap[trainerId]
+	if !ok {
+		return nil
+	}
+	return count.performance()
+}
+
+func (h *trainerHistory) jockeyTrainerPerformance(trainerId types.TrainerId, jockeyId types.JockeyId) *analysis_entity.TrainerPerformance {
+	count, ok := h.jockeyTrainerCountMap[trainerId][jockeyId]
+	if !ok {
+		return nil
+	}
+	return count.performance()
+}
+
+func (t *trainerService) Create(
+	ctx context.Context,
+	races []*data_cache_entity.Race,
+) ([]*analysis_entity.TrainerCalculable, error) {
+	var calculables []*analysis_entity.TrainerCalculable
+	for _, race := range races {
+		placeOddsMap, err := createPlaceOddsMap(race)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, raceResult := range race.RaceResults() {
+			// 調教師が取得できていない古いキャッシュと取り消し・除外の馬は集計対象外
+			if raceResult.TrainerId() == "" || raceResult.Odds().IsZero() {
+				continue
+			}
+			calculables = append(calculables, analysis_entity.NewTrainerCalculable(
+				race.RaceId(),
+				race.RaceDate(),
+				race.RaceCourseId(),
+				raceResult.TrainerId(),
+				raceResult.JockeyId(),
+				raceResult.OrderNo(),
+				raceResult.PopularNumber(),
+				raceResult.Odds(),
+				placeOddsMap[raceResult.HorseNumber().Value()],
+			))
+		}
+	}
+
+	return calculables, nil
+}
+
+func (t *trainerService) GetPerformanceMap(
+	ctx context.Context,
+	calculables []*analysis_entity.TrainerCalculable,
+) map[types.TrainerId]*analysis_entity.TrainerPerformance {
+	countMap := map[types.TrainerId]*trainerCount{}
+	for _, calculable := range calculables {
+		if _, ok := countMap[calculable.TrainerId()]; !ok {
+			countMap[calculable.TrainerId()] = &trainerCount{}
+		}
+		countMap[calculable.TrainerId()].add(calculable)
+	}
+
+	performanceMap := make(map[types.TrainerId]*analysis_entity.TrainerPerformance, len(countMap))
+	for trainerId, count := range countMap {
+		performanceMap[trainerId] = count.performance()
+	}
+
+	return performanceMap
+}
+
+func (t *trainerService) GetJockeyTrainerPerformanceMap(
+	ctx context.Context,
+	calculables []*analysis_entity.TrainerCalculable,
+) map[types.TrainerId]map[types.JockeyId]*analysis_entity.TrainerPerformance {
+	countMap := map[types.TrainerId]map[types.JockeyId]*trainerCount{}
+	for _, calculable := range calculables {
+		if _, ok := countMap[calculable.TrainerId()]; !ok {
+			countMap[calculable.TrainerId()] = map[types.JockeyId]*trainerCount{}
+		}
+		if _, ok := countMap[calculable.TrainerId()][calculable.JockeyId()]; !ok {
+			countMap[calculable.TrainerId()][calculable.JockeyId()] = &trainerCount{}
+		}
+		countMap[calculable.TrainerId()][calculable.JockeyId()].add(calculable)
+	}
+
+	performanceMap := make(map[types.TrainerId]map[types.JockeyId]*analysis_entity.TrainerPerformance, len(countMap))
+	for trainerId, jockeyCountMap := range countMap {
+		performanceMap[trainerId] = make(map[types.JockeyId]*analysis_entity.TrainerPerformance, len(jockeyCountMap))
+		for jockeyId, count := range jockeyCountMap {
+			performanceMap[trainerId][jockeyId] = count.performance()
+		}
+	}
+
+	return performanceMap
+}
+
+func (t *trainerService) Convert(
+	ctx context.Context,
+	calculables []*analysis_entity.TrainerCalculable,
+	trainers []*data_cache_entity.Trainer,
+	jockeys []*data_cache_entity.Jockey,
+) []*spreadsheet_entity.AnalysisTrainer {
+	trainerMap := converter.ConvertToMap(trainers, func(trainer *data_cache_entity.Trainer) types.TrainerId {
+		return trainer.TrainerId()
+	})
+	jockeyMap := converter.ConvertToMap(jockeys, func(jockey *data_cache_entity.Jockey) types.JockeyId {
+		return jockey.JockeyId()
+	})
+
+	performanceMap := t.GetPerformanceMap(ctx, calculables)
+	jockeyTrainerPerformanceMap := t.GetJockeyTrainerPerformanceMap(ctx, calculables)
+
+	popularCountMap := map[types.TrainerId][]*trainerCount{}
+	for _, calculable := range calculables {
+		if _, ok := popularCountMap[calculable.TrainerId()]; !ok {
+			popularCountMap[calculable.TrainerId()] = make([]*trainerCount, len(trainerPopularRanges))
+			for i := range trainerPopularRanges {
+				popularCountMap[calculable.TrainerId()][i] = &trainerCount{}
+			}
+		}
+		for i, popularRange := range trainerPopularRanges {
+			if calculable.PopularNumber() >= popularRange.from && calculable.PopularNumber() <= popularRange.to {
+				popularCountMap[calculable.TrainerId()][i].add(calculable)
+				break
+			}
+		}
+	}
+
+	trainerIds := make([]types.TrainerId, 0, len(performanceMap))
+	for trainerId := range performanceMap {
+		trainerIds = append(trainerIds, trainerId)
+	}
+	sort.Slice(trainerIds, func(i, j int) bool {
+		countI, countJ := performanceMap[trainerIds[i]].RaceCount(), performanceMap[trainerIds[j]].RaceCount()
+		if countI != countJ {
+			return countI > countJ
+		}
+		return trainerIds[i] < trainerIds[j]
+	})
+
+	analysisTrainers := t.createRegionAnalysisTrainers(calculables, trainerMap)
+	for _, trainerId := range trainerIds {
+		trainerName := trainerId.Value()
+		locationId := types.UnknownLocation
+		if trainer, ok := trainerMap[trainerId]; ok {
+			trainerName = trainer.TrainerName()
+			locationId = types.NewLocationId(trainer.LocationName())
+		}
+
+		analysisTrainers = append(analysisTrainers, t.createAnalysisTrainer(trainerId, trainerName, locationId, "全体", performanceMap[trainerId]))
+		for i, popularRange := range trainerPopularRanges {
+			count := popularCountMap[trainerId][i]
+			if count.raceCount == 0 {
+				continue
+			}
+			analysisTrainers = append(analysisTrainers, t.createAnalysisTrainer(trainerId, trainerName, locationId, popularRange.name, count.performance()))
+		}
+
+		jockeyPerformanceMap := jockeyTrainerPerformanceMap[trainerId]
+		jockeyIds := make([]types.JockeyId, 0, len(jockeyPerformanceMap))
+		for jockeyId, performance := range jockeyPerformanceMap {
+			if performance.RaceCount() < trainerComboMinRaceCount {
+				continue
+			}
+			jockeyIds = append(jockeyIds, jockeyId)
+		}
+		sort.Slice(jockeyIds, func(i, j int) bool {
+			countI, countJ := jockeyPerformanceMap[jockeyIds[i]].RaceCount(), jockeyPerformanceMap[jockeyIds[j]].RaceCount()
+			if countI != countJ {
+				return countI > countJ
+			}
+			return jockeyIds[i] < jockeyIds[j]
+		})
+		for _, jockeyId := range jockeyIds {
+			jockeyName := jockeyId.Value()
+			if jockey, ok := jockeyMap[jockeyId]; ok {
+				jockeyName = jockey.JockeyName()
+			}
+			analysisTrainers = append(analysisTrainers, t.createAnalysisTrainer(trainerId, trainerName, locationId, fmt.Sprintf("騎手:%s", jockeyName), jockeyPerformanceMap[jockeyId]))
+		}
+	}
+
+	return analysisTrainers
+}
+
+func (t *trainerService) Write(
+	ctx context.Context,
+	analysisTrainers []*spreadsheet_entity.AnalysisTrainer,
+) error {
+	return t.spreadSheetRepository.WriteAnalysisTrainer(ctx, analysisTrainers)
+}
+
+// createRegionAnalysisTrainers 所属(東/西)ごとに全体と競馬場別の成績を集計する。所属が美浦・栗東以外の調教師は含めない
+func (t *trainerService) createRegionAnalysisTrainers(
+	calculables []*analysis_entity.TrainerCalculable,
+	trainerMap map[types.TrainerId]*data_cache_entity.Trainer,
+) []*spreadsheet_entity.AnalysisTrainer {
+	regionCountMap := map[types.LocationId]*trainerCount{}
+	regionCourseCountMap := map[types.LocationId]map[types.RaceCourse]*trainerCount{}
+	for _, calculable := range calculables {
+		trainer, ok := trainerMap[calculable.TrainerId()]
+		if !ok {
+			continue
+		}
+		locationId := types.NewLocationId(trainer.LocationName())
+		if locationId != types.Miho && locationId != types.Ritto {
+			continue
+		}
+		if _, ok := regionCountMap[locationId]; !ok {
+			regionCountMap[locationId] = &trainerCount{}
+			regionCourseCountMap[locationId] = map[types.RaceCourse]*trainerCount{}
+		}
+		if _, ok := regionCourseCountMap[locationId][calculable.RaceCourse()]; !ok {
+			regionCourseCountMap[locationId][calculable.RaceCourse()] = &trainerCount{}
+		}
+		regionCountMap[locationId].add(calculable)
+		regionCourseCountMap[locationId][calculable.RaceCourse()].add(calculable)
+	}
+
+	var analysisTrainers []*spreadsheet_entity.AnalysisTrainer
+	for _, locationId := range []types.LocationId{types.Miho, types.Ritto} {
+		count, ok := regionCountMap[locationId]
+		if !ok {
+			continue
+		}
+		regionName := fmt.Sprintf("%s所属", locationId.Region())
+		analysisTrainers = append(analysisTrainers, t.createAnalysisTrainer("", regionName, locationId, "全体", count.performance()))
+
+		raceCourses := make([]types.RaceCourse, 0, len(regionCourseCountMap[locationId]))
+		for raceCourse := range regionCourseCountMap[locationId] {
+			raceCourses = append(raceCourses, raceCourse)
+		}
+		sort.Slice(raceCourses, func(i, j int) bool {
+			return raceCourses[i] < raceCourses[j]
+		})
+		for _, raceCourse := range raceCourses {
+			analysisTrainers = append(analysisTrainers, t.createAnalysisTrainer("", regionName, locationId, fmt.Sprintf("競馬場:%s", raceCourse.Name()), regionCourseCountMap[locationId][raceCourse].performance()))
+		}
+	}
+
+	return analysisTrainers
+}
+
+func (t *trainerService) createAnalysisTrainer(
+	trainerId types.TrainerId,
+	trainerName string,
+	locationId types.LocationId,
+	condition string,
+	performance *analysis_entity.TrainerPerformance,
+) *spreadsheet_entity.AnalysisTrainer {
+	return spreadsheet_entity.NewAnalysisTrainer(
+		trainerId,
+		trainerName,
+		locationId,
+		condition,
+		performance.RaceCount(),
+		performance.WinCount(),
+		performance.PlaceCount(),
+		fmt.Sprintf("%.2f%%", performance.WinRate()*100),
+		fmt.Sprintf("%.2f%%", performance.PlaceRate()*100),
+		fmt.Sprintf("%.2f%%", performance.WinPayoutRate()*100),
+		fmt.Sprintf("%.2f%%", performance.PlacePayoutRate()*100),
+	)
+}
+
+// createPlaceOddsMap 馬番ごとの複勝配当(倍率)を返す
+func createPlaceOddsMap(race *data_cache_entity.Race) (map[int]decimal.Decimal, error) {
+	placeOddsMap := map[int]decimal.Decimal{}
+	for _, payoutResult := range race.PayoutResults() {
+		if payoutResult.TicketType() != types.Place {
+			continue
+		}
+		for i, number := range payoutResult.Numbers() {
+			if i >= len(payoutResult.Odds()) {
+				break
+			}
+			odds, err := decimal.NewFromString(payoutResult.Odds()[i])
+			if err != nil {
+				return nil, err
+			}
+			for _, horseNumber := range number.List() {
+				placeOddsMap[horseNumber] = odds
+			}
+		}
+	}
+
+	return placeOddsMap, nil
+}
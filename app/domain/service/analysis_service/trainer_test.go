@@ -0,0 +1,126 @@
+package analysis_service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/shopspring/decimal"
+)
+
+func newTestTrainerRaceCalculable(raceDate types.RaceDate, raceCourse types.RaceCourse, trainerId types.TrainerId, jockeyId types.JockeyId, orderNo, popularNumber int) *analysis_entity.TrainerCalculable {
+	placeOdds := decimal.Zero
+	if orderNo <= 3 {
+		placeOdds = decimal.RequireFromString("1.5")
+	}
+	return analysis_entity.NewTrainerCalculable("", raceDate, raceCourse, trainerId, jockeyId, orderNo, popularNumber, decimal.RequireFromString("4.0"), placeOdds)
+}
+
+func TestTrainerCreate(t *testing.T) {
+	race := data_cache_entity.NewRace("202405040811", 20241020, 11, types.Tokyo, "テスト", 1, "", "", "15:40", 3, 1600, 0, 0, 0, 0, 0, 0, 0,
+		[]*data_cache_entity.RaceResult{
+			data_cache_entity.NewRaceResult(1, "h1", "", 1, 1, "j1", "2.0", 1, "", 0, 0, "", "", "", "t1", 0),
+			data_cache_entity.NewRaceResult(2, "h2", "", 2, 2, "j2", "5.0", 2, "", 0, 0, "", "", "", "", 0),
+			data_cache_entity.NewRaceResult(0, "h3", "", 3, 3, "j3", "0", 0, "", 0, 0, "", "", "", "t3", 0),
+		},
+		[]*data_cache_entity.PayoutResult{
+			data_cache_entity.NewPayoutResult(types.Place.Value(), []string{"01", "02"}, []string{"1.2", "1.8"}, []int{1, 2}),
+		}, true)
+
+	tr := &trainerService{}
+	calculables, err := tr.Create(context.Background(), []*data_cache_entity.Race{race})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 調教師が無い馬と取り消し・除外の馬は除く
+	if len(calculables) != 1 {
+		t.Fatalf("Create() = %d calculables, want 1", len(calculables))
+	}
+	calculable := calculables[0]
+	if calculable.TrainerId() != "t1" || calculable.RaceCourse() != types.Tokyo || !calculable.PlaceOdds().Equal(decimal.RequireFromString("1.2")) {
+		t.Errorf("Create() = %s %s %s, want t1 %s 1.2", calculable.TrainerId(), calculable.RaceCourse(), calculable.PlaceOdds(), types.Tokyo)
+	}
+}
+
+func TestTrainerConvert(t *testing.T) {
+	trainers := []*data_cache_entity.Trainer{
+		data_cache_entity.NewTrainer("t1", "東の調教師", "美浦"),
+		data_cache_entity.NewTrainer("t2", "西の調教師", "栗東"),
+	}
+
+	type row struct {
+		trainerId   types.TrainerId
+		trainerName string
+		region      string
+		condition   string
+		raceCount   int
+		winRate     string
+		placeRate   string
+	}
+	tests := []struct {
+		name        string
+		calculables []*analysis_entity.TrainerCalculable
+		want        []row
+	}{
+		{
+			name: "データなし",
+			want: []row{},
+		},
+		{
+			name: "所属ごとの行の後に調教師ごとの全体と人気別の行を並べる",
+			calculables: []*analysis_entity.TrainerCalculable{
+				newTestTrainerRaceCalculable(20241020, types.Tokyo, "t1", "j1", 1, 1),
+				newTestTrainerRaceCalculable(20241020, types.Kyoto, "t1", "j1", 4, 2),
+				newTestTrainerRaceCalculable(20241020, types.Kyoto, "t2", "j2", 2, 3),
+				newTestTrainerRaceCalculable(20241020, types.Tokyo, "t3", "j3", 1, 10),
+			},
+			want: []row{
+				{"", "東所属", "東", "全体", 2, "50.00%", "50.00%"},
+				{"", "東所属", "東", "競馬場:東京", 1, "100.00%", "100.00%"},
+				{"", "東所属", "東", "競馬場:京都", 1, "0.00%", "0.00%"},
+				{"", "西所属", "西", "全体", 1, "0.00%", "100.00%"},
+				{"", "西所属", "西", "競馬場:京都", 1, "0.00%", "100.00%"},
+				{"t1", "東の調教師", "東", "全体", 2, "50.00%", "50.00%"},
+				{"t1", "東の調教師", "東", "1人気", 1, "100.00%", "100.00%"},
+				{"t1", "東の調教師", "東", "2-3人気", 1, "0.00%", "0.00%"},
+				{"t2", "西の調教師", "西", "全体", 1, "0.00%", "100.00%"},
+				{"t2", "西の調教師", "西", "2-3人気", 1, "0.00%", "100.00%"},
+				{"t3", "t3", "不明", "全体", 1, "100.00%", "100.00%"},
+				{"t3", "t3", "不明", "10人気-", 1, "100.00%", "100.00%"},
+			},
+		},
+		{
+			name: "騎手とのコンビは最低出走数以上",
+			calculables: func() []*analysis_entity.TrainerCalculable {
+				var calculables []*analysis_entity.TrainerCalculable
+				for i := 0; i < trainerComboMinRaceCount; i++ {
+					calculables = append(calculables, newTestTrainerRaceCalculable(20241020, types.Tokyo, "t1", "j1", 1, 1))
+				}
+				return append(calculables, newTestTrainerRaceCalculable(20241020, types.Tokyo, "t1", "j2", 1, 1))
+			}(),
+			want: []row{
+				{"", "東所属", "東", "全体", 6, "100.00%", "100.00%"},
+				{"", "東所属", "東", "競馬場:東京", 6, "100.00%", "100.00%"},
+				{"t1", "東の調教師", "東", "全体", 6, "100.00%", "100.00%"},
+				{"t1", "東の調教師", "東", "1人気", 6, "100.00%", "100.00%"},
+				{"t1", "東の調教師", "東", "騎手:j1", 5, "100.00%", "100.00%"},
+			},
+		},
+	}
+
+	tr := &trainerService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]row, 0)
+			for _, a := range tr.Convert(context.Background(), tt.calculables, trainers, nil) {
+				got = append(got, row{a.TrainerId(), a.TrainerName(), a.LocationId().Region(), a.Condition(), a.RaceCount(), a.WinRate(), a.PlaceRate()})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Convert() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
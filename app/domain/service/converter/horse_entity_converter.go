@@ -18,6 +18,7 @@ type HorseEntityConverter interface {
 	RawToDataCache(input *raw_entity.Horse) (*data_cache_entity.Horse, error)
 	DataCacheToAnalysis(input *data_cache_entity.Horse) (*analysis_entity.Horse, error)
 	DataCacheToRaw(input *data_cache_entity.Horse) *raw_entity.Horse
+	DataCacheToPrediction(input *data_cache_entity.Horse) (*prediction_entity.Horse, error)
 	PredictionToAnalysis(input *prediction_entity.Horse) (*analysis_entity.Horse, error)
 }
 
@@ -140,7 +141,13 @@ func (h *horseEntityConverter) NetKeibaToDataCache(input *netkeiba_entity.Horse,
 		horseResults = append(horseResults, horseResult)
 	}
 
-	horseBlood := data_cache_entity.NewHorseBlood(input.HorseBlood().SireId(), input.HorseBlood().BroodmareSireId())
+	horseBlood := data_cache_entity.NewHorseBlood(
+		input.HorseBlood().SireId(),
+		input.HorseBlood().SireName(),
+		input.HorseBlood().BroodmareSireId(),
+		input.HorseBlood().BroodmareSireName(),
+		true,
+	)
 
 	return data_cache_entity.NewHorse(
 		input.HorseId(),
@@ -158,7 +165,10 @@ func (h *horseEntityConverter) NetKeibaToDataCache(input *netkeiba_entity.Horse,
 func (h *horseEntityConverter) RawToDataCache(input *raw_entity.Horse) (*data_cache_entity.Horse, error) {
 	horseBlood := data_cache_entity.NewHorseBlood(
 		input.HorseBlood.SireId,
+		input.HorseBlood.SireName,
 		input.HorseBlood.BroodmareSireId,
+		input.HorseBlood.BroodmareSireName,
+		input.HorseBlood.NameFetched,
 	)
 
 	horseResults := make([]*data_cache_entity.HorseResult, 0, len(input.HorseResults))
@@ -251,8 +261,11 @@ func (h *horseEntityConverter) DataCacheToAnalysis(input *data_cache_entity.Hors
 
 func (h *horseEntityConverter) DataCacheToRaw(input *data_cache_entity.Horse) *raw_entity.Horse {
 	horseBlood := &raw_entity.HorseBlood{
-		SireId:          input.HorseBlood().SireId().Value(),
-		BroodmareSireId: input.HorseBlood().BroodmareSireId().Value(),
+		SireId:            input.HorseBlood().SireId().Value(),
+		SireName:          input.HorseBlood().SireName(),
+		BroodmareSireId:   input.HorseBlood().BroodmareSireId().Value(),
+		BroodmareSireName: input.HorseBlood().BroodmareSireName(),
+		NameFetched:       input.HorseBlood().NameFetched(),
 	}
 
 	rawHorseResults := make([]*raw_entity.HorseResult, 0, len(input.HorseResults()))
@@ -292,6 +305,51 @@ func (h *horseEntityConverter) DataCacheToRaw(input *data_cache_entity.Horse) *r
 	}
 }
 
+func (h *horseEntityConverter) DataCacheToPrediction(input *data_cache_entity.Horse) (*prediction_entity.Horse, error) {
+	horseResults := make([]*prediction_entity.HorseResult, 0, len(input.HorseResults()))
+	for _, rawHorseResult := range input.HorseResults() {
+		horseResult, err := prediction_entity.NewHorseResult(
+			rawHorseResult.RaceId().String(),
+			rawHorseResult.RaceDate().Value(),
+			rawHorseResult.RaceName(),
+			rawHorseResult.JockeyId().Value(),
+			rawHorseResult.OrderNo(),
+			rawHorseResult.PopularNumber(),
+			rawHorseResult.HorseNumber().Value(),
+			rawHorseResult.Odds().String(),
+			rawHorseResult.Class().Value(),
+			rawHorseResult.Entries(),
+			rawHorseResult.Distance(),
+			rawHorseResult.RaceCourse().Value(),
+			rawHorseResult.CourseCategory().Value(),
+			rawHorseResult.TrackCondition().Value(),
+			rawHorseResult.HorseWeight(),
+			rawHorseResult.RaceWeight(),
+			rawHorseResult.Comment(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		horseResults = append(horseResults, horseResult)
+	}
+
+	horseBlood := prediction_entity.NewHorseBlood(
+		input.HorseBlood().SireId().Value(),
+		input.HorseBlood().BroodmareSireId().Value(),
+	)
+
+	return prediction_entity.NewHorse(
+		input.HorseId().Value(),
+		input.HorseName(),
+		input.HorseBirthDay().Value(),
+		input.TrainerId().Value(),
+		input.OwnerId().Value(),
+		input.BreederId().Value(),
+		horseBlood,
+		horseResults,
+	), nil
+}
+
 func (h *horseEntityConverter) PredictionToAnalysis(
 	input *prediction_entity.Horse,
 ) (*analysis_entity.Horse, error) {
@@ -0,0 +1,54 @@
+package converter
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+)
+
+type PredictionCheckListEntityConverter interface {
+	SpreadSheetToRaw(input *spreadsheet_entity.PredictionCheckList) *raw_entity.PredictionCheckList
+}
+
+type predictionCheckListEntityConverter struct{}
+
+func NewPredictionCheckListEntityConverter() PredictionCheckListEntityConverter {
+	return &predictionCheckListEntityConverter{}
+}
+
+func (p *predictionCheckListEntityConverter) SpreadSheetToRaw(input *spreadsheet_entity.PredictionCheckList) *raw_entity.PredictionCheckList {
+	return &raw_entity.PredictionCheckList{
+		RaceId:            input.RaceId(),
+		RaceDate:          input.RaceDate(),
+		RaceName:          input.RaceName(),
+		RaceCourse:        input.RaceCourse(),
+		RaceUrl:           input.RaceUrl(),
+		HorseName:         input.HorseName(),
+		HorseUrl:          input.HorseUrl(),
+		JockeyName:        input.JockeyName(),
+		JockeyUrl:         input.JockeyUrl(),
+		TrainerName:       input.TrainerName(),
+		TrainerUrl:        input.TrainerUrl(),
+		LocationName:      input.LocationName(),
+		WinOdds:           input.WinOdds(),
+		Marker:            input.Marker(),
+		FirstPlaceRate:    input.FirstPlaceRate(),
+		SecondPlaceRate:   input.SecondPlaceRate(),
+		ThirdPlaceRate:    input.ThirdPlaceRate(),
+		CheckList:         input.CheckList(),
+		CheckListNames:    input.CheckListNames(),
+		Contributions:     input.Contributions(),
+		PlaceProbability:  input.PlaceProbability(),
+		PositivePoint:     input.PositivePoint(),
+		NegativePoint:     input.NegativePoint(),
+		FavoriteNum:       input.FavoriteNum(),
+		RivalNum:          input.RivalNum(),
+		MarkerNum:         input.MarkerNum(),
+		HighlyRecommended: input.HighlyRecommended(),
+		CommentScore:      input.CommentScore(),
+		TrainingComment:   input.TrainingComment(),
+		ReporterMemo:      input.ReporterMemo(),
+		PaddockComment:    input.PaddockComment(),
+		PaddockEvaluation: input.PaddockEvaluation(),
+		PaperUrl:          input.PaperUrl(),
+	}
+}
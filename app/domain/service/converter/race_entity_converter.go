@@ -2,6 +2,7 @@ package converter
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
@@ -11,6 +12,7 @@ import (
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/tospo_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
 )
 
@@ -30,6 +32,7 @@ type RaceEntityConverter interface {
 		input2 *tospo_entity.TrainingComment,
 		input3 []*tospo_entity.Memo, input4 *tospo_entity.PaddockComment) *prediction_entity.RaceForecast
 	PredictionToAnalysis(input *prediction_entity.Race) *analysis_entity.Race
+	DataCacheToPrediction(input *data_cache_entity.Race) *prediction_entity.Race
 }
 
 type raceEntityConverter struct{}
@@ -42,17 +45,26 @@ func (r *raceEntityConverter) DataCacheToRaw(input *data_cache_entity.Race) *raw
 	raceResults := make([]*raw_entity.RaceResult, 0, len(input.RaceResults()))
 	for _, raceResult := range input.RaceResults() {
 		raceResults = append(raceResults, &raw_entity.RaceResult{
-			OrderNo:        raceResult.OrderNo(),
-			HorseId:        raceResult.HorseId().Value(),
-			HorseName:      raceResult.HorseName(),
-			BracketNumber:  raceResult.BracketNumber(),
-			HorseNumber:    raceResult.HorseNumber().Value(),
-			JockeyId:       raceResult.JockeyId().Value(),
-			Odds:           raceResult.Odds().StringFixed(1),
-			PopularNumber:  raceResult.PopularNumber(),
-			JockeyWeight:   raceResult.JockeyWeight(),
-			HorseWeight:    raceResult.HorseWeight(),
-			HorseWeightAdd: raceResult.HorseWeightAdd(),
+			OrderNo:            raceResult.OrderNo(),
+			HorseId:            raceResult.HorseId().Value(),
+			HorseName:          raceResult.HorseName(),
+			BracketNumber:      raceResult.BracketNumber(),
+			HorseNumber:        raceResult.HorseNumber().Value(),
+			JockeyId:           raceResult.JockeyId().Value(),
+			Odds:               raceResult.Odds().StringFixed(1),
+			PopularNumber:      raceResult.PopularNumber(),
+			JockeyWeight:       raceResult.JockeyWeight(),
+			HorseWeight:        raceResult.HorseWeight(),
+			HorseWeightAdd:     raceResult.HorseWeightAdd(),
+			CornerPassingOrder: raceResult.CornerPassingOrder(),
+			LastThreeFurlong: func() string {
+				if raceResult.LastThreeFurlong().IsZero() {
+					return ""
+				}
+				return raceResult.LastThreeFurlong().StringFixed(1)
+			}(),
+			Margin:    raceResult.Margin(),
+			TrainerId: raceResult.TrainerId().Value(),
 		})
 	}
 	payoutResults := make([]*raw_entity.PayoutResult, 0, len(input.PayoutResults()))
@@ -90,6 +102,7 @@ func (r *raceEntityConverter) DataCacheToRaw(input *data_cache_entity.Race) *raw
 		RaceAgeCondition:      input.RaceAgeCondition().Value(),
 		RaceResults:           raceResults,
 		PayoutResults:         payoutResults,
+		TrainerFetched:        input.TrainerFetched(),
 	}
 }
 
@@ -97,17 +110,21 @@ func (r *raceEntityConverter) NetKeibaToRaw(input *netkeiba_entity.Race) *raw_en
 	raceResults := make([]*raw_entity.RaceResult, 0, len(input.RaceResults()))
 	for _, raceResult := range input.RaceResults() {
 		raceResults = append(raceResults, &raw_entity.RaceResult{
-			OrderNo:        raceResult.OrderNo(),
-			HorseId:        raceResult.HorseId(),
-			HorseName:      raceResult.HorseName(),
-			BracketNumber:  raceResult.BracketNumber(),
-			HorseNumber:    raceResult.HorseNumber(),
-			JockeyId:       raceResult.JockeyId(),
-			Odds:           raceResult.Odds(),
-			PopularNumber:  raceResult.PopularNumber(),
-			JockeyWeight:   raceResult.JockeyWeight(),
-			HorseWeight:    raceResult.HorseWeight(),
-			HorseWeightAdd: raceResult.HorseWeightAdd(),
+			OrderNo:            raceResult.OrderNo(),
+			HorseId:            raceResult.HorseId(),
+			HorseName:          raceResult.HorseName(),
+			BracketNumber:      raceResult.BracketNumber(),
+			HorseNumber:        raceResult.HorseNumber(),
+			JockeyId:           raceResult.JockeyId(),
+			Odds:               raceResult.Odds(),
+			PopularNumber:      raceResult.PopularNumber(),
+			JockeyWeight:       raceResult.JockeyWeight(),
+			HorseWeight:        raceResult.HorseWeight(),
+			HorseWeightAdd:     raceResult.HorseWeightAdd(),
+			CornerPassingOrder: raceResult.CornerPassingOrder(),
+			LastThreeFurlong:   raceResult.LastThreeFurlong(),
+			Margin:             raceResult.Margin(),
+			TrainerId:          raceResult.TrainerId(),
 		})
 	}
 	payoutResults := make([]*raw_entity.PayoutResult, 0, len(input.PayoutResults()))
@@ -145,6 +162,7 @@ func (r *raceEntityConverter) NetKeibaToRaw(input *netkeiba_entity.Race) *raw_en
 		RaceAgeCondition:      input.RaceAgeCondition(),
 		RaceResults:           raceResults,
 		PayoutResults:         payoutResults,
+		TrainerFetched:        true,
 	}
 }
 
@@ -168,6 +186,11 @@ func (r *raceEntityConverter) RawToDataCache(input *raw_entity.Race) *data_cache
 			raceResult.JockeyWeight,
 			raceResult.HorseWeight,
 			raceResult.HorseWeightAdd,
+			raceResult.CornerPassingOrder,
+			raceResult.LastThreeFurlong,
+			raceResult.Margin,
+			raceResult.TrainerId,
+			types.NewRunningStyle(raceResult.CornerPassingOrder, input.Entries).Value(),
 		))
 	}
 	payoutResults := make([]*data_cache_entity.PayoutResult, 0, len(input.PayoutResults))
@@ -201,6 +224,7 @@ func (r *raceEntityConverter) RawToDataCache(input *raw_entity.Race) *data_cache
 		input.RaceAgeCondition,
 		raceResults,
 		payoutResults,
+		input.TrainerFetched,
 	)
 }
 
@@ -319,6 +343,7 @@ func (r *raceEntityConverter) TospoToPrediction(
 		input1.RivalNum(),
 		input1.MarkerNum(),
 		input2.TrainingComment(),
+		input2.PreviousTrainingComment(),
 		input2.IsHighlyRecommended(),
 		reporterMemos,
 		paddockComment,
@@ -348,3 +373,50 @@ func (r *raceEntityConverter) PredictionToAnalysis(
 		input.RaceTimeConditionFilters(),
 	)
 }
+
+func (r *raceEntityConverter) DataCacheToPrediction(input *data_cache_entity.Race) *prediction_entity.Race {
+	raceEntryHorses := make([]*prediction_entity.RaceEntryHorse, 0, len(input.RaceResults()))
+	predictionOdds := make([]*prediction_entity.Odds, 0, len(input.RaceResults()))
+	raceResultHorseNumbers := make([]int, 0, 3)
+	for _, raceResult := range input.RaceResults() {
+		raceWeight, _ := strconv.ParseFloat(raceResult.JockeyWeight(), 64)
+		raceEntryHorses = append(raceEntryHorses, prediction_entity.NewRaceEntryHorse(
+			raceResult.HorseId().Value(),
+			raceResult.HorseName(),
+			raceResult.BracketNumber(),
+			raceResult.HorseNumber().Value(),
+			raceResult.JockeyId().Value(),
+			raceResult.TrainerId().Value(),
+			raceWeight,
+		))
+		predictionOdds = append(predictionOdds, prediction_entity.NewOdds(
+			raceResult.Odds().String(),
+			raceResult.PopularNumber(),
+			raceResult.HorseNumber(),
+		))
+		if raceResult.OrderNo() >= 1 && raceResult.OrderNo() <= 3 {
+			raceResultHorseNumbers = append(raceResultHorseNumbers, raceResult.HorseNumber().Value())
+		}
+	}
+
+	return prediction_entity.NewRace(
+		input.RaceId().String(),
+		input.RaceName(),
+		input.RaceDate().Value(),
+		input.RaceNumber(),
+		input.Entries(),
+		input.Distance(),
+		input.Class().Value(),
+		input.CourseCategory().Value(),
+		input.TrackCondition().Value(),
+		input.RaceSexCondition().Value(),
+		input.RaceWeightCondition().Value(),
+		input.RaceCourseId().Value(),
+		input.Url(),
+		raceEntryHorses,
+		raceResultHorseNumbers,
+		predictionOdds,
+		nil,
+		nil,
+	)
+}
@@ -1,7 +1,10 @@
 package converter
 
 import (
+	"time"
+
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/tospo_entity"
 )
@@ -9,7 +12,8 @@ import (
 type RaceForecastEntityConverter interface {
 	RawToDataCache(input *raw_entity.RaceForecast) *data_cache_entity.RaceForecast
 	DataCacheToRaw(input *data_cache_entity.RaceForecast) *raw_entity.RaceForecast
-	TospoToDataCache(input1 *tospo_entity.Forecast, input2 *tospo_entity.TrainingComment) *data_cache_entity.Forecast
+	TospoToDataCache(input1 *tospo_entity.Forecast, input2 *tospo_entity.TrainingComment, input3 []*tospo_entity.Memo, input4 *tospo_entity.PaddockComment) *data_cache_entity.Forecast
+	DataCacheToPrediction(input *data_cache_entity.Forecast) *prediction_entity.RaceForecast
 }
 
 type raceForecastEntityConverter struct{}
@@ -21,6 +25,14 @@ func NewRaceForecastEntityConverter() RaceForecastEntityConverter {
 func (r *raceForecastEntityConverter) RawToDataCache(input *raw_entity.RaceForecast) *data_cache_entity.RaceForecast {
 	forecasts := make([]*data_cache_entity.Forecast, 0, len(input.Forecasts))
 	for _, forecast := range input.Forecasts {
+		reporterMemos := make([]*data_cache_entity.ReporterMemo, 0, len(forecast.ReporterMemos))
+		for _, rawReporterMemo := range forecast.ReporterMemos {
+			date, err := time.Parse("2006-01-02", rawReporterMemo.Date)
+			if err != nil {
+				continue
+			}
+			reporterMemos = append(reporterMemos, data_cache_entity.NewReporterMemo(rawReporterMemo.Comment, date))
+		}
 		forecasts = append(forecasts, data_cache_entity.NewForecast(
 			forecast.HorseNumber,
 			forecast.TrainingComment,
@@ -29,6 +41,9 @@ func (r *raceForecastEntityConverter) RawToDataCache(input *raw_entity.RaceForec
 			forecast.FavoriteNum,
 			forecast.RivalNum,
 			forecast.MarkerNum,
+			reporterMemos,
+			forecast.PaddockComment,
+			forecast.PaddockEvaluation,
 		))
 	}
 
@@ -36,12 +51,20 @@ func (r *raceForecastEntityConverter) RawToDataCache(input *raw_entity.RaceForec
 		input.RaceId,
 		input.RaceDate,
 		forecasts,
+		input.PaddockFetched,
 	)
 }
 
 func (r *raceForecastEntityConverter) DataCacheToRaw(input *data_cache_entity.RaceForecast) *raw_entity.RaceForecast {
 	rawForecasts := make([]*raw_entity.Forecast, 0, len(input.Forecasts()))
 	for _, rawForecast := range input.Forecasts() {
+		var rawReporterMemos []*raw_entity.ForecastReporterMemo
+		for _, reporterMemo := range rawForecast.ReporterMemos() {
+			rawReporterMemos = append(rawReporterMemos, &raw_entity.ForecastReporterMemo{
+				Date:    reporterMemo.Date().Format("2006-01-02"),
+				Comment: reporterMemo.Comment(),
+			})
+		}
 		rawForecasts = append(rawForecasts, &raw_entity.Forecast{
 			HorseNumber:             rawForecast.HorseNumber().Value(),
 			TrainingComment:         rawForecast.TrainingComment(),
@@ -50,17 +73,40 @@ func (r *raceForecastEntityConverter) DataCacheToRaw(input *data_cache_entity.Ra
 			FavoriteNum:             rawForecast.FavoriteNum(),
 			RivalNum:                rawForecast.RivalNum(),
 			MarkerNum:               rawForecast.MarkerNum(),
+			ReporterMemos:           rawReporterMemos,
+			PaddockComment:          rawForecast.PaddockComment(),
+			PaddockEvaluation:       rawForecast.PaddockEvaluation().Value(),
 		})
 	}
 
 	return &raw_entity.RaceForecast{
-		RaceId:    input.RaceId().String(),
-		RaceDate:  input.RaceDate().Value(),
-		Forecasts: rawForecasts,
+		RaceId:         input.RaceId().String(),
+		RaceDate:       input.RaceDate().Value(),
+		Forecasts:      rawForecasts,
+		PaddockFetched: input.PaddockFetched(),
 	}
 }
 
-func (r *raceForecastEntityConverter) TospoToDataCache(input1 *tospo_entity.Forecast, input2 *tospo_entity.TrainingComment) *data_cache_entity.Forecast {
+func (r *raceForecastEntityConverter) TospoToDataCache(
+	input1 *tospo_entity.Forecast,
+	input2 *tospo_entity.TrainingComment,
+	input3 []*tospo_entity.Memo,
+	input4 *tospo_entity.PaddockComment,
+) *data_cache_entity.Forecast {
+	reporterMemos := make([]*data_cache_entity.ReporterMemo, 0, len(input3))
+	for _, memo := range input3 {
+		reporterMemos = append(reporterMemos, data_cache_entity.NewReporterMemo(memo.Comment(), memo.Date()))
+	}
+
+	var (
+		paddockComment    string
+		paddockEvaluation int
+	)
+	if input4 != nil {
+		paddockComment = input4.Comment()
+		paddockEvaluation = input4.Evaluation()
+	}
+
 	return data_cache_entity.NewForecast(
 		input1.HorseNumber().Value(),
 		input2.TrainingComment(),
@@ -69,5 +115,24 @@ func (r *raceForecastEntityConverter) TospoToDataCache(input1 *tospo_entity.Fore
 		input1.FavoriteNum(),
 		input1.RivalNum(),
 		input1.MarkerNum(),
+		reporterMemos,
+		paddockComment,
+		paddockEvaluation,
+	)
+}
+
+func (r *raceForecastEntityConverter) DataCacheToPrediction(input *data_cache_entity.Forecast) *prediction_entity.RaceForecast {
+	// 記者メモは予想時点から2週間以内のものに絞って使うので、キャッシュからは設定しない
+	return prediction_entity.NewRaceForecast(
+		input.HorseNumber(),
+		input.FavoriteNum(),
+		input.RivalNum(),
+		input.MarkerNum(),
+		input.TrainingComment(),
+		input.PreviousTrainingComment(),
+		input.HighlyRecommended(),
+		nil,
+		input.PaddockComment(),
+		input.PaddockEvaluation().Value(),
 	)
 }
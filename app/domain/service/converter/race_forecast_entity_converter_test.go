@@ -0,0 +1,58 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+)
+
+func TestRaceForecastEntityConverterRoundTrip(t *testing.T) {
+	input := &raw_entity.RaceForecast{
+		RaceId:   "202405040811",
+		RaceDate: 20241020,
+		Forecasts: []*raw_entity.Forecast{
+			{
+				HorseNumber:     1,
+				TrainingComment: "動き軽快",
+				FavoriteNum:     3,
+				ReporterMemos: []*raw_entity.ForecastReporterMemo{
+					{Date: "2024-10-15", Comment: "仕上がり良好"},
+					{Date: "10/16", Comment: "日付が読めないメモは捨てる"},
+				},
+				PaddockComment:    "気配上々",
+				PaddockEvaluation: 1,
+			},
+			{
+				HorseNumber: 2,
+			},
+		},
+		PaddockFetched: true,
+	}
+	want := &raw_entity.RaceForecast{
+		RaceId:   "202405040811",
+		RaceDate: 20241020,
+		Forecasts: []*raw_entity.Forecast{
+			{
+				HorseNumber:     1,
+				TrainingComment: "動き軽快",
+				FavoriteNum:     3,
+				ReporterMemos: []*raw_entity.ForecastReporterMemo{
+					{Date: "2024-10-15", Comment: "仕上がり良好"},
+				},
+				PaddockComment:    "気配上々",
+				PaddockEvaluation: 1,
+			},
+			{
+				HorseNumber: 2,
+			},
+		},
+		PaddockFetched: true,
+	}
+
+	r := NewRaceForecastEntityConverter()
+	got := r.DataCacheToRaw(r.RawToDataCache(input))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DataCacheToRaw(RawToDataCache()) = %+v, want %+v", got, want)
+	}
+}
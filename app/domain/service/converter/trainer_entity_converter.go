@@ -0,0 +1,43 @@
+package converter
+
+import (
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+)
+
+type TrainerEntityConverter interface {
+	DataCacheToRaw(input *data_cache_entity.Trainer) *raw_entity.Trainer
+	RawToDataCache(input *raw_entity.Trainer) *data_cache_entity.Trainer
+	NetKeibaToRaw(input *netkeiba_entity.Trainer) *raw_entity.Trainer
+}
+
+type trainerEntityConverter struct{}
+
+func NewTrainerEntityConverter() TrainerEntityConverter {
+	return &trainerEntityConverter{}
+}
+
+func (t *trainerEntityConverter) DataCacheToRaw(input *data_cache_entity.Trainer) *raw_entity.Trainer {
+	return &raw_entity.Trainer{
+		TrainerId:    input.TrainerId().Value(),
+		TrainerName:  input.TrainerName(),
+		LocationName: input.LocationName(),
+	}
+}
+
+func (t *trainerEntityConverter) RawToDataCache(input *raw_entity.Trainer) *data_cache_entity.Trainer {
+	return data_cache_entity.NewTrainer(
+		input.TrainerId,
+		input.TrainerName,
+		input.LocationName,
+	)
+}
+
+func (t *trainerEntityConverter) NetKeibaToRaw(input *netkeiba_entity.Trainer) *raw_entity.Trainer {
+	return &raw_entity.Trainer{
+		TrainerId:    input.TrainerId(),
+		TrainerName:  input.TrainerName(),
+		LocationName: input.LocationName(),
+	}
+}
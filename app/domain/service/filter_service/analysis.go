@@ -16,6 +16,8 @@ type AnalysisFilter interface {
 	) ([]filter.AttributeId, []filter.MarkerCombinationId)
 	CreateRaceTimeFilters(ctx context.Context, race *data_cache_entity.Race) []filter.AttributeId
 	CreateBetaFilters(ctx context.Context, race *data_cache_entity.Race, markerCombinationIds []types.MarkerCombinationId) []filter.AttributeId
+	CreatePedigreeFilters(ctx context.Context, race *data_cache_entity.Race) []filter.AttributeId
+	CreateMarkerConsensusFilters(ctx context.Context, race *data_cache_entity.Race) []filter.AttributeId
 }
 
 type filterService struct{}
@@ -82,3 +84,27 @@ func (f *filterService) CreateBetaFilters(
 	filterIds = append(filterIds, TrackConditionFilters(race.TrackCondition())...)
 	return filterIds
 }
+
+func (f *filterService) CreatePedigreeFilters(
+	ctx context.Context,
+	race *data_cache_entity.Race,
+) []filter.AttributeId {
+	var filterIds []filter.AttributeId
+	filterIds = append(filterIds, CourseCategoryFilters(race.CourseCategory())...)
+	filterIds = append(filterIds, DistanceFilters(race.Distance())...)
+	filterIds = append(filterIds, TrackConditionFilters(race.TrackCondition())...)
+	return filterIds
+}
+
+func (f *filterService) CreateMarkerConsensusFilters(
+	ctx context.Context,
+	race *data_cache_entity.Race,
+) []filter.AttributeId {
+	var filterIds []filter.AttributeId
+	filterIds = append(filterIds, CourseCategoryFilters(race.CourseCategory())...)
+	filterIds = append(filterIds, DistanceFilters(race.Distance())...)
+	filterIds = append(filterIds, RaceCourseFilters(race.RaceCourseId())...)
+	filterIds = append(filterIds, TrackConditionFilters(race.TrackCondition())...)
+	filterIds = append(filterIds, GradeClassFilters(race.Class())...)
+	return filterIds
+}
@@ -160,6 +160,21 @@ func RaceAgeConditionFilters(raceAgeCondition types.RaceAgeCondition) []filter.A
 	return filterIds
 }
 
+func RunningStyleFilters(runningStyle types.RunningStyle) []filter.AttributeId {
+	var filterIds []filter.AttributeId
+	switch runningStyle {
+	case types.Escape:
+		filterIds = append(filterIds, filter.Escape)
+	case types.Leading:
+		filterIds = append(filterIds, filter.Leading)
+	case types.Stalker:
+		filterIds = append(filterIds, filter.Stalker)
+	case types.Closer:
+		filterIds = append(filterIds, filter.Closer)
+	}
+	return filterIds
+}
+
 func MarkerCombinationFilter(
 	race *data_cache_entity.Race,
 	markerCombinationId types.MarkerCombinationId,
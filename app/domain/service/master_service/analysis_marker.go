@@ -4,29 +4,42 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
 	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
 )
 
 const (
-	analysisMarkerFileName = "analysis_marker.csv"
+	analysisMarkerFileName      = "analysis_marker.csv"
+	syncedMarkerFileName        = "prediction_marker_sync.csv"
+	analysisMarkerVersionLayout = "20060102150405"
 )
 
 type AnalysisMarker interface {
 	Get(ctx context.Context) ([]*marker_csv_entity.AnalysisMarker, error)
+	Stage(ctx context.Context, markers []*marker_csv_entity.AnalysisMarker) error
+	Archive(ctx context.Context, races []*data_cache_entity.Race) error
 }
 
 type analysisMarkerService struct {
 	analysisMarkerRepository repository.AnalysisMarkerRepository
+	logger                   *logrus.Logger
 }
 
 func NewAnalysisMarker(
 	analysisMarkerRepository repository.AnalysisMarkerRepository,
+	logger *logrus.Logger,
 ) AnalysisMarker {
 	return &analysisMarkerService{
 		analysisMarkerRepository: analysisMarkerRepository,
+		logger:                   logger,
 	}
 }
 
@@ -44,3 +57,115 @@ func (m *analysisMarkerService) Get(
 
 	return markers, nil
 }
+
+// Stage 同期した印をレース結果が出るまでprediction_marker_sync.csvに溜めておく。同じレースは最新の印で置き換える
+func (m *analysisMarkerService) Stage(
+	ctx context.Context,
+	markers []*marker_csv_entity.AnalysisMarker,
+) error {
+	path := fmt.Sprintf("%s/%s", config.CsvDir, syncedMarkerFileName)
+	stagedMarkers, err := m.analysisMarkerRepository.Read(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	markerMap := converter.ConvertToMap(stagedMarkers, func(marker *marker_csv_entity.AnalysisMarker) types.RaceId {
+		return marker.RaceId()
+	})
+	for _, marker := range markers {
+		markerMap[marker.RaceId()] = marker
+	}
+
+	newStagedMarkers := make([]*marker_csv_entity.AnalysisMarker, 0, len(markerMap))
+	for _, raceId := range service.SortedRaceIdKeys(markerMap) {
+		newStagedMarkers = append(newStagedMarkers, markerMap[raceId])
+	}
+
+	return m.analysisMarkerRepository.Write(ctx, path, newStagedMarkers)
+}
+
+// Archive レース結果が揃った同期済みの印を検証してanalysis_marker.csvに追記する
+// 追記した行には実行日時をバージョンとして付け、取り込んだ印や検証で弾いた印は同期済みの印から取り除く
+func (m *analysisMarkerService) Archive(
+	ctx context.Context,
+	races []*data_cache_entity.Race,
+) error {
+	syncedPath := fmt.Sprintf("%s/%s", config.CsvDir, syncedMarkerFileName)
+	stagedMarkers, err := m.analysisMarkerRepository.Read(ctx, syncedPath)
+	if err != nil {
+		return err
+	}
+	if len(stagedMarkers) == 0 {
+		return nil
+	}
+
+	analysisPath := fmt.Sprintf("%s/%s", config.CsvDir, analysisMarkerFileName)
+	analysisMarkers, err := m.analysisMarkerRepository.Read(ctx, analysisPath)
+	if err != nil {
+		return err
+	}
+	analysisMarkerMap := converter.ConvertToMap(analysisMarkers, func(marker *marker_csv_entity.AnalysisMarker) types.RaceId {
+		return marker.RaceId()
+	})
+	raceMap := converter.ConvertToMap(races, func(race *data_cache_entity.Race) types.RaceId {
+		return race.RaceId()
+	})
+
+	var archiveMarkers, pendingMarkers []*marker_csv_entity.AnalysisMarker
+	for _, marker := range stagedMarkers {
+		if _, ok := analysisMarkerMap[marker.RaceId()]; ok {
+			m.logger.Infof("skip archived marker: %s", marker.RaceId())
+			continue
+		}
+		race, ok := raceMap[marker.RaceId()]
+		if !ok || len(race.RaceResults()) == 0 {
+			pendingMarkers = append(pendingMarkers, marker)
+			continue
+		}
+		if err = m.validate(marker, race); err != nil {
+			m.logger.Warnf("reject marker %s: %v", marker.RaceId(), err)
+			continue
+		}
+		archiveMarkers = append(archiveMarkers, marker)
+	}
+
+	if len(archiveMarkers) > 0 {
+		version := time.Now().Format(analysisMarkerVersionLayout)
+		if err = m.analysisMarkerRepository.Append(ctx, analysisPath, archiveMarkers, version); err != nil {
+			return err
+		}
+		m.logger.Infof("archived markers: %d, version %s", len(archiveMarkers), version)
+	}
+	if len(pendingMarkers) > 0 {
+		m.logger.Infof("markers waiting for race results: %d", len(pendingMarkers))
+	}
+
+	return m.analysisMarkerRepository.Write(ctx, syncedPath, pendingMarkers)
+}
+
+// validate 印が6頭に重複なく付いていて、取消・除外の馬が含まれていないか確認する
+func (m *analysisMarkerService) validate(
+	marker *marker_csv_entity.AnalysisMarker,
+	race *data_cache_entity.Race,
+) error {
+	raceResultMap := converter.ConvertToMap(race.RaceResults(), func(raceResult *data_cache_entity.RaceResult) types.HorseNumber {
+		return raceResult.HorseNumber()
+	})
+
+	horseNumberMap := map[types.HorseNumber]struct{}{}
+	for _, horseNumber := range marker.MarkerMap() {
+		if horseNumber == 0 {
+			continue
+		}
+		horseNumberMap[horseNumber] = struct{}{}
+		raceResult, ok := raceResultMap[horseNumber]
+		if !ok || raceResult.Odds().IsZero() {
+			return fmt.Errorf("scratched horse: %d", horseNumber)
+		}
+	}
+	if len(horseNumberMap) != 6 {
+		return fmt.Errorf("marked horses must be 6 distinct horses: %d", len(horseNumberMap))
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package master_service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
+)
+
+type memoryAnalysisMarkerRepository struct {
+	files    map[string][]*marker_csv_entity.AnalysisMarker
+	versions []string
+}
+
+func (m *memoryAnalysisMarkerRepository) Read(ctx context.Context, path string) ([]*marker_csv_entity.AnalysisMarker, error) {
+	return m.files[path], nil
+}
+
+func (m *memoryAnalysisMarkerRepository) Write(ctx context.Context, path string, markers []*marker_csv_entity.AnalysisMarker) error {
+	m.files[path] = markers
+	return nil
+}
+
+func (m *memoryAnalysisMarkerRepository) Append(ctx context.Context, path string, markers []*marker_csv_entity.AnalysisMarker, version string) error {
+	m.files[path] = append(m.files[path], markers...)
+	m.versions = append(m.versions, version)
+	return nil
+}
+
+func newTestAnalysisMarker(t *testing.T, raceId string, horseNumbers ...int) *marker_csv_entity.AnalysisMarker {
+	t.Helper()
+	rawHorseNumbers := make([]string, 0, len(horseNumbers))
+	for _, horseNumber := range horseNumbers {
+		rawHorseNumbers = append(rawHorseNumbers, strconv.Itoa(horseNumber))
+	}
+	marker, err := marker_csv_entity.NewAnalysisMarker("20241020", raceId,
+		rawHorseNumbers[0], rawHorseNumbers[1], rawHorseNumbers[2], rawHorseNumbers[3], rawHorseNumbers[4], rawHorseNumbers[5])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return marker
+}
+
+// newTestAnalysisMarkerRace 馬番1から順にオッズを付けたレースを作る。オッズ0は取消・除外
+func newTestAnalysisMarkerRace(raceId string, odds ...string) *data_cache_entity.Race {
+	raceResults := make([]*data_cache_entity.RaceResult, 0, len(odds))
+	for idx, o := range odds {
+		raceResults = append(raceResults, data_cache_entity.NewRaceResult(idx+1, fmt.Sprintf("h%d", idx+1), "", 1, idx+1, "", o, idx+1, "", 0, 0, "", "", "", "", 0))
+	}
+	return data_cache_entity.NewRace(raceId, 20241020, 1, types.Tokyo, "テスト", 1, "", "", "10:00", len(odds), 1600, 0, 0, 0, 0, 0, 0, 0, raceResults, nil, true)
+}
+
+func markerRaceIds(markers []*marker_csv_entity.AnalysisMarker) []types.RaceId {
+	raceIds := make([]types.RaceId, 0, len(markers))
+	for _, marker := range markers {
+		raceIds = append(raceIds, marker.RaceId())
+	}
+	return raceIds
+}
+
+func TestAnalysisMarkerStage(t *testing.T) {
+	syncedPath := fmt.Sprintf("%s/%s", config.CsvDir, syncedMarkerFileName)
+	repository := &memoryAnalysisMarkerRepository{
+		files: map[string][]*marker_csv_entity.AnalysisMarker{
+			syncedPath: {
+				newTestAnalysisMarker(t, "202405040803", 1, 2, 3, 4, 5, 6),
+				newTestAnalysisMarker(t, "202405040801", 1, 2, 3, 4, 5, 6),
+			},
+		},
+	}
+	m := NewAnalysisMarker(repository, logrus.New())
+
+	err := m.Stage(context.Background(), []*marker_csv_entity.AnalysisMarker{
+		newTestAnalysisMarker(t, "202405040801", 6, 5, 4, 3, 2, 1),
+		newTestAnalysisMarker(t, "202405040802", 1, 2, 3, 4, 5, 6),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 同じレースは新しい印で置き換え、レースID順に並べる
+	got := repository.files[syncedPath]
+	if want := []types.RaceId{"202405040801", "202405040802", "202405040803"}; !reflect.DeepEqual(markerRaceIds(got), want) {
+		t.Fatalf("Stage() = %v, want %v", markerRaceIds(got), want)
+	}
+	if got[0].Favorite() != 6 {
+		t.Errorf("Stage() favorite = %d, want 6", got[0].Favorite())
+	}
+}
+
+func TestAnalysisMarkerArchive(t *testing.T) {
+	const raceId = "202405040801"
+	validRace := newTestAnalysisMarkerRace(raceId, "2.0", "3.0", "4.0", "5.0", "6.0", "7.0")
+
+	tests := []struct {
+		name            string
+		staged          []*marker_csv_entity.AnalysisMarker
+		analysisMarkers []*marker_csv_entity.AnalysisMarker
+		races           []*data_cache_entity.Race
+		wantArchived    []types.RaceId
+		wantPending     []types.RaceId
+		wantVersions    int
+	}{
+		{
+			name:         "同期済みの印なし",
+			wantArchived: []types.RaceId{},
+			wantPending:  []types.RaceId{},
+		},
+		{
+			name:         "レース結果が揃った印を追記する",
+			staged:       []*marker_csv_entity.AnalysisMarker{newTestAnalysisMarker(t, raceId, 1, 2, 3, 4, 5, 6)},
+			races:        []*data_cache_entity.Race{validRace},
+			wantArchived: []types.RaceId{raceId},
+			wantPending:  []types.RaceId{},
+			wantVersions: 1,
+		},
+		{
+			name:         "レース結果がまだ無い印は残す",
+			staged:       []*marker_csv_entity.AnalysisMarker{newTestAnalysisMarker(t, raceId, 1, 2, 3, 4, 5, 6)},
+			races:        []*data_cache_entity.Race{newTestAnalysisMarkerRace(raceId)},
+			wantArchived: []types.RaceId{},
+			wantPending:  []types.RaceId{raceId},
+		},
+		{
+			name:         "取消・除外の馬に印がある場合は取り込まずに取り除く",
+			staged:       []*marker_csv_entity.AnalysisMarker{newTestAnalysisMarker(t, raceId, 1, 2, 3, 4, 5, 6)},
+			races:        []*data_cache_entity.Race{newTestAnalysisMarkerRace(raceId, "2.0", "3.0", "4.0", "5.0", "6.0", "0")},
+			wantArchived: []types.RaceId{},
+			wantPending:  []types.RaceId{},
+		},
+		{
+			name:         "同じ馬に重複して印がある場合は取り込まずに取り除く",
+			staged:       []*marker_csv_entity.AnalysisMarker{newTestAnalysisMarker(t, raceId, 1, 1, 2, 3, 4, 5)},
+			races:        []*data_cache_entity.Race{validRace},
+			wantArchived: []types.RaceId{},
+			wantPending:  []types.RaceId{},
+		},
+		{
+			name:            "取り込み済みのレースは追記しない",
+			staged:          []*marker_csv_entity.AnalysisMarker{newTestAnalysisMarker(t, raceId, 6, 5, 4, 3, 2, 1)},
+			analysisMarkers: []*marker_csv_entity.AnalysisMarker{newTestAnalysisMarker(t, raceId, 1, 2, 3, 4, 5, 6)},
+			races:           []*data_cache_entity.Race{validRace},
+			wantArchived:    []types.RaceId{raceId},
+			wantPending:     []types.RaceId{},
+		},
+	}
+
+	syncedPath := fmt.Sprintf("%s/%s", config.CsvDir, syncedMarkerFileName)
+	analysisPath := fmt.Sprintf("%s/%s", config.CsvDir, analysisMarkerFileName)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := &memoryAnalysisMarkerRepository{
+				files: map[string][]*marker_csv_entity.AnalysisMarker{
+					syncedPath:   tt.staged,
+					analysisPath: tt.analysisMarkers,
+				},
+			}
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+			m := NewAnalysisMarker(repository, logger)
+
+			if err := m.Archive(context.Background(), tt.races); err != nil {
+				t.Fatal(err)
+			}
+			if got := markerRaceIds(repository.files[analysisPath]); !reflect.DeepEqual(got, tt.wantArchived) {
+				t.Errorf("archived = %v, want %v", got, tt.wantArchived)
+			}
+			if got := markerRaceIds(repository.files[syncedPath]); !reflect.DeepEqual(got, tt.wantPending) {
+				t.Errorf("pending = %v, want %v", got, tt.wantPending)
+			}
+			if len(repository.versions) != tt.wantVersions {
+				t.Fatalf("versions = %v, want %d", repository.versions, tt.wantVersions)
+			}
+			for _, version := range repository.versions {
+				if len(version) != len(analysisMarkerVersionLayout) {
+					t.Errorf("version = %q, want layout %s", version, analysisMarkerVersionLayout)
+				}
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 )
 
 type BetNumberConverter interface {
+	ToBetNumbers(ctx context.Context, ticketType types.TicketType, rawBetNumber string) ([]types.BetNumber, error)
 	QuinellaWheelToQuinellaBetNumbers(ctx context.Context, rawBetNumber string) ([]types.BetNumber, error)
 	QuinellaPlaceWheelToQuinellaBetNumbers(ctx context.Context, rawBetNumber string) ([]types.BetNumber, error)
 	QuinellaPlaceFormationToQuinellaBetNumbers(ctx context.Context, rawBetNumber string) ([]types.BetNumber, error)
@@ -31,6 +32,44 @@ func NewBetNumberConverter() BetNumberConverter {
 	return &betNumberConverter{}
 }
 
+// ToBetNumbers 式別に応じてながし、フォーメーション、ボックスの馬／組番を1点ずつの買い目にバラす
+func (b *betNumberConverter) ToBetNumbers(
+	ctx context.Context,
+	ticketType types.TicketType,
+	rawBetNumber string,
+) ([]types.BetNumber, error) {
+	switch ticketType {
+	case types.QuinellaWheel:
+		return b.QuinellaWheelToQuinellaBetNumbers(ctx, rawBetNumber)
+	case types.QuinellaPlaceWheel:
+		return b.QuinellaPlaceWheelToQuinellaBetNumbers(ctx, rawBetNumber)
+	case types.QuinellaPlaceFormation:
+		return b.QuinellaPlaceFormationToQuinellaBetNumbers(ctx, rawBetNumber)
+	case types.ExactaWheelOfFirst:
+		return b.ExactaWheelOfFirstToExactaBetNumbers(ctx, rawBetNumber)
+	case types.TrioFormation:
+		return b.TrioFormationToTrioBetNumbers(ctx, rawBetNumber)
+	case types.TrioWheelOfFirst:
+		return b.TrioWheelOfFirstToTrioBetNumbers(ctx, rawBetNumber)
+	case types.TrioWheelOfSecond:
+		return b.TrioWheelOfSecondToTrioBetNumbers(ctx, rawBetNumber)
+	case types.TrioBox:
+		return b.TrioBoxToTrioBetNumbers(ctx, rawBetNumber)
+	case types.TrifectaFormation:
+		return b.TrifectaFormationToTrifectaBetNumbers(ctx, rawBetNumber)
+	case types.TrifectaWheelOfFirst:
+		return b.TrifectaWheelOfFirstToTrifectaBetNumbers(ctx, rawBetNumber)
+	case types.TrifectaWheelOfSecond:
+		return b.TrifectaWheelOfSecondToTrifectaBetNumbers(ctx, rawBetNumber)
+	case types.TrifectaWheelOfFirstMulti, types.TrifectaWheelOfSecondMulti:
+		return b.TrifectaWheelMultiToTrifectaBetNumbers(ctx, rawBetNumber)
+	case types.UnknownTicketType:
+		return nil, fmt.Errorf("unknown ticket type")
+	}
+
+	return []types.BetNumber{types.NewBetNumber(rawBetNumber)}, nil
+}
+
 func (b *betNumberConverter) QuinellaWheelToQuinellaBetNumbers(ctx context.Context, rawBetNumber string) ([]types.BetNumber, error) {
 	// 複数の買い目がまとめられてるものをバラす
 	separator1 := "／"
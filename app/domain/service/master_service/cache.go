@@ -0,0 +1,55 @@
+package master_service
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
+)
+
+type Cache interface {
+	Get(ctx context.Context) ([]*cache_entity.CacheFile, error)
+	Repair(ctx context.Context, cacheFiles []*cache_entity.CacheFile) error
+}
+
+type cacheService struct {
+	cacheRepository repository.CacheRepository
+	logger          *logrus.Logger
+}
+
+func NewCache(
+	cacheRepository repository.CacheRepository,
+	logger *logrus.Logger,
+) Cache {
+	return &cacheService{
+		cacheRepository: cacheRepository,
+		logger:          logger,
+	}
+}
+
+// Get cache配下のマスタのjsonファイルを検証結果付きで返す
+func (c *cacheService) Get(ctx context.Context) ([]*cache_entity.CacheFile, error) {
+	return c.cacheRepository.List(ctx, config.CacheDir)
+}
+
+// Repair 壊れたファイルは<path>.corruptに退避して次のマスタ更新で取り直させ、チェックサムの無いファイルは現在の内容でチェックサムを残す
+func (c *cacheService) Repair(ctx context.Context, cacheFiles []*cache_entity.CacheFile) error {
+	for _, cacheFile := range cacheFiles {
+		switch cacheFile.Status() {
+		case types.CacheFileCorrupt:
+			c.logger.Warnf("quarantine corrupt cache %s: %s", cacheFile.Path(), cacheFile.Reason())
+			if err := c.cacheRepository.Quarantine(ctx, cacheFile.Path()); err != nil {
+				return err
+			}
+		case types.CacheFileNoChecksum:
+			if err := c.cacheRepository.WriteChecksum(ctx, cacheFile.Path()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
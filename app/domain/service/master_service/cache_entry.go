@@ -0,0 +1,523 @@
+package master_service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	raceIdCacheType       = "race_id"
+	raceCacheType         = "races"
+	raceTimeCacheType     = "race_times"
+	raceForecastCacheType = "race_forecast"
+	winOddsCacheType      = "odds/win"
+	placeOddsCacheType    = "odds/place"
+	quinellaOddsCacheType = "odds/quinella"
+	trioOddsCacheType     = "odds/trio"
+)
+
+// oddsCacheTypes 券種ごとのオッズのキャッシュディレクトリと取得URL
+var oddsCacheTypes = []struct {
+	cacheType string
+	url       string
+}{
+	{cacheType: winOddsCacheType, url: winOddsUrl},
+	{cacheType: placeOddsCacheType, url: placeOddsUrl},
+	{cacheType: quinellaOddsCacheType, url: quinellaOddsUrl},
+	{cacheType: trioOddsCacheType, url: trioOddsUrl},
+}
+
+type CacheEntry interface {
+	Stats(ctx context.Context, cacheFiles []*cache_entity.CacheFile) ([]*cache_entity.CacheStat, error)
+	Find(ctx context.Context, raceId types.RaceId, raceDate types.RaceDate) (*raw_entity.CacheRace, error)
+	Refetch(ctx context.Context, raceDateMap map[types.RaceDate][]types.RaceId) error
+	Prune(ctx context.Context, before types.RaceDate) (int, error)
+}
+
+type cacheEntryService struct {
+	raceRepository          repository.RaceRepository
+	oddsRepository          repository.OddsRepository
+	raceTimeRepository      repository.RaceTimeRepository
+	raceIdRepository        repository.RaceIdRepository
+	raceForecastRepository  repository.RaceForecastRepository
+	cacheRepository         repository.CacheRepository
+	raceEntityConverter     converter.RaceEntityConverter
+	oddsEntityConverter     converter.OddsEntityConverter
+	raceTimeEntityConverter converter.RaceTimeEntityConverter
+	logger                  *logrus.Logger
+}
+
+func NewCacheEntry(
+	raceRepository repository.RaceRepository,
+	oddsRepository repository.OddsRepository,
+	raceTimeRepository repository.RaceTimeRepository,
+	raceIdRepository repository.RaceIdRepository,
+	raceForecastRepository repository.RaceForecastRepository,
+	cacheRepository repository.CacheRepository,
+	raceEntityConverter converter.RaceEntityConverter,
+	oddsEntityConverter converter.OddsEntityConverter,
+	raceTimeEntityConverter converter.RaceTimeEntityConverter,
+	logger *logrus.Logger,
+) CacheEntry {
+	return &cacheEntryService{
+		raceRepository:          raceRepository,
+		oddsRepository:          oddsRepository,
+		raceTimeRepository:      raceTimeRepository,
+		raceIdRepository:        raceIdRepository,
+		raceForecastRepository:  raceForecastRepository,
+		cacheRepository:         cacheRepository,
+		raceEntityConverter:     raceEntityConverter,
+		oddsEntityConverter:     oddsEntityConverter,
+		raceTimeEntityConverter: raceTimeEntityConverter,
+		logger:                  logger,
+	}
+}
+
+// Stats キャッシュの種別ごとにファイル数、サイズ、レース数、開催日の範囲を集計する。壊れたファイルは件数に含めない
+func (c *cacheEntryService) Stats(
+	ctx context.Context,
+	cacheFiles []*cache_entity.CacheFile,
+) ([]*cache_entity.CacheStat, error) {
+	cacheTypeFilesMap := map[string][]*cache_entity.CacheFile{}
+	for _, cacheFile := range cacheFiles {
+		cacheType := c.cacheType(cacheFile.Path())
+		cacheTypeFilesMap[cacheType] = append(cacheTypeFilesMap[cacheType], cacheFile)
+	}
+
+	cacheTypes := make([]string, 0, len(cacheTypeFilesMap))
+	for cacheType := range cacheTypeFilesMap {
+		cacheTypes = append(cacheTypes, cacheType)
+	}
+	sort.Strings(cacheTypes)
+
+	cacheStats := make([]*cache_entity.CacheStat, 0, len(cacheTypes))
+	for _, cacheType := range cacheTypes {
+		var (
+			size                int64
+			corruptFiles        int
+			entries             int
+			startDate, endDate  types.RaceDate
+			cacheTypeCacheFiles = cacheTypeFilesMap[cacheType]
+		)
+		for _, cacheFile := range cacheTypeCacheFiles {
+			size += cacheFile.Size()
+			if cacheFile.Status() == types.CacheFileCorrupt {
+				corruptFiles++
+				continue
+			}
+			raceDates, err := c.readRaceDates(ctx, cacheType, cacheFile.Path())
+			if err != nil {
+				return nil, err
+			}
+			entries += len(raceDates)
+			for _, raceDate := range raceDates {
+				if startDate == 0 || raceDate < startDate {
+					startDate = raceDate
+				}
+				if raceDate > endDate {
+					endDate = raceDate
+				}
+			}
+		}
+		cacheStats = append(cacheStats, cache_entity.NewCacheStat(
+			cacheType,
+			len(cacheTypeCacheFiles),
+			corruptFiles,
+			size,
+			entries,
+			startDate,
+			endDate,
+		))
+	}
+
+	return cacheStats, nil
+}
+
+// Find 指定したレースのキャッシュ済みのレース結果、オッズ、レースタイム、予想をまとめて返す。キャッシュに無いものはnil
+func (c *cacheEntryService) Find(
+	ctx context.Context,
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+) (*raw_entity.CacheRace, error) {
+	cacheRace := &raw_entity.CacheRace{
+		RaceId:   raceId.String(),
+		RaceDate: raceDate.Value(),
+	}
+
+	rawRaces, err := c.raceRepository.Read(ctx, c.raceFilePath(raceDate))
+	if err != nil {
+		return nil, err
+	}
+	for _, rawRace := range rawRaces {
+		if rawRace.RaceId == raceId.String() {
+			cacheRace.Race = rawRace
+		}
+	}
+
+	for _, oddsCacheType := range oddsCacheTypes {
+		rawRaceOddsList, err := c.oddsRepository.Read(ctx, c.oddsFilePath(oddsCacheType.cacheType, raceDate))
+		if err != nil {
+			return nil, err
+		}
+		for _, rawRaceOdds := range rawRaceOddsList {
+			if rawRaceOdds.RaceId != raceId.String() {
+				continue
+			}
+			switch oddsCacheType.cacheType {
+			case winOddsCacheType:
+				cacheRace.WinOdds = rawRaceOdds
+			case placeOddsCacheType:
+				cacheRace.PlaceOdds = rawRaceOdds
+			case quinellaOddsCacheType:
+				cacheRace.QuinellaOdds = rawRaceOdds
+			case trioOddsCacheType:
+				cacheRace.TrioOdds = rawRaceOdds
+			}
+		}
+	}
+
+	rawRaceTimes, err := c.raceTimeRepository.Read(ctx, c.raceTimeFilePath(raceDate))
+	if err != nil {
+		return nil, err
+	}
+	for _, rawRaceTime := range rawRaceTimes {
+		if rawRaceTime.RaceId == raceId.String() {
+			cacheRace.RaceTime = rawRaceTime
+		}
+	}
+
+	rawRaceForecastInfo, err := c.raceForecastRepository.Read(ctx, fmt.Sprintf("%s/%s", config.CacheDir, raceForecastFileName))
+	if err != nil {
+		return nil, err
+	}
+	if rawRaceForecastInfo != nil {
+		for _, rawRaceForecast := range rawRaceForecastInfo.RaceForecasts {
+			if rawRaceForecast.RaceId == raceId.String() {
+				cacheRace.RaceForecast = rawRaceForecast
+			}
+		}
+	}
+
+	return cacheRace, nil
+}
+
+// Refetch 指定したレースをキャッシュを使わずに取り直して開催日ごとのファイルを書き換える
+// レース結果は常に取り直し、オッズとレースタイムはキャッシュ済みのものだけを取り直す。未取得のものはマスタ更新で取得する
+func (c *cacheEntryService) Refetch(
+	ctx context.Context,
+	raceDateMap map[types.RaceDate][]types.RaceId,
+) error {
+	for _, raceDate := range converter.SortedRaceDateKeys(raceDateMap) {
+		raceIds := raceDateMap[raceDate]
+		sort.Slice(raceIds, func(i, j int) bool {
+			return raceIds[i] < raceIds[j]
+		})
+
+		rawRaces, err := c.raceRepository.Read(ctx, c.raceFilePath(raceDate))
+		if err != nil {
+			return err
+		}
+		rawRaceTimes, err := c.raceTimeRepository.Read(ctx, c.raceTimeFilePath(raceDate))
+		if err != nil {
+			return err
+		}
+		oddsMap := map[string][]*raw_entity.RaceOdds{}
+		for _, oddsCacheType := range oddsCacheTypes {
+			rawRaceOddsList, err := c.oddsRepository.Read(ctx, c.oddsFilePath(oddsCacheType.cacheType, raceDate))
+			if err != nil {
+				return err
+			}
+			oddsMap[oddsCacheType.cacheType] = rawRaceOddsList
+		}
+
+		for _, raceId := range raceIds {
+			url := raceResultUrl(raceId, raceDate)
+			if url == "" {
+				c.logger.Warnf("skip refetch unknown organizer race: %s", raceId)
+				continue
+			}
+			c.logger.Infof("race refetch: %s", raceId)
+			race, err := c.raceRepository.FetchRace(ctx, fmt.Sprintf("%s&cache=false", url))
+			if err != nil {
+				return err
+			}
+			rawRaces = c.replaceRace(rawRaces, c.raceEntityConverter.NetKeibaToRaw(race))
+
+			for i, rawRaceTime := range rawRaceTimes {
+				if rawRaceTime.RaceId != raceId.String() {
+					continue
+				}
+				raceTime, err := c.raceTimeRepository.Fetch(ctx, fmt.Sprintf("%s?cache=false", fmt.Sprintf(raceDBUrl, raceId)))
+				if err != nil {
+					return err
+				}
+				rawRaceTimes[i] = c.raceTimeEntityConverter.NetKeibaToRaw(raceTime)
+			}
+
+			for _, oddsCacheType := range oddsCacheTypes {
+				for _, rawRaceOdds := range oddsMap[oddsCacheType.cacheType] {
+					if rawRaceOdds.RaceId != raceId.String() {
+						continue
+					}
+					fetchOdds, err := c.oddsRepository.Fetch(ctx, fmt.Sprintf(oddsCacheType.url, raceId))
+					if err != nil {
+						return err
+					}
+					rawOddsList := make([]*raw_entity.Odds, 0, len(fetchOdds))
+					for _, odds := range fetchOdds {
+						rawOddsList = append(rawOddsList, c.oddsEntityConverter.NetKeibaToRaw(odds))
+					}
+					rawRaceOdds.Odds = rawOddsList
+				}
+			}
+		}
+
+		err = c.raceRepository.Write(ctx, c.raceFilePath(raceDate), &raw_entity.RaceInfo{
+			Races: rawRaces,
+		})
+		if err != nil {
+			return err
+		}
+		if len(rawRaceTimes) > 0 {
+			err = c.raceTimeRepository.Write(ctx, c.raceTimeFilePath(raceDate), &raw_entity.RaceTimeInfo{
+				RaceTimes: rawRaceTimes,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, oddsCacheType := range oddsCacheTypes {
+			rawRaceOddsList := oddsMap[oddsCacheType.cacheType]
+			if len(rawRaceOddsList) == 0 {
+				continue
+			}
+			err = c.oddsRepository.Write(ctx, c.oddsFilePath(oddsCacheType.cacheType, raceDate), &raw_entity.RaceOddsInfo{
+				RaceOdds: rawRaceOddsList,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Prune 指定日より前の開催日のファイルを削除し、race_idと予想のキャッシュからも取り除く。削除したファイル数を返す
+func (c *cacheEntryService) Prune(
+	ctx context.Context,
+	before types.RaceDate,
+) (int, error) {
+	var removedFiles int
+
+	cacheTypeFileNamesMap := map[string]func() ([]string, error){
+		raceCacheType: func() ([]string, error) {
+			return c.raceRepository.List(ctx, fmt.Sprintf("%s/%s", config.CacheDir, raceCacheType))
+		},
+		raceTimeCacheType: func() ([]string, error) {
+			return c.raceTimeRepository.List(ctx, fmt.Sprintf("%s/%s", config.CacheDir, raceTimeCacheType))
+		},
+	}
+	for _, oddsCacheType := range oddsCacheTypes {
+		cacheType := oddsCacheType.cacheType
+		cacheTypeFileNamesMap[cacheType] = func() ([]string, error) {
+			return c.oddsRepository.List(ctx, fmt.Sprintf("%s/%s", config.CacheDir, cacheType))
+		}
+	}
+
+	for cacheType, listFileNames := range cacheTypeFileNamesMap {
+		fileNames, err := listFileNames()
+		if err != nil {
+			return 0, err
+		}
+		for _, fileName := range fileNames {
+			raceDate, ok := c.fileRaceDate(cacheType, fileName)
+			if !ok || raceDate >= before {
+				continue
+			}
+			if err = c.cacheRepository.Remove(ctx, fmt.Sprintf("%s/%s/%s", config.CacheDir, cacheType, fileName)); err != nil {
+				return 0, err
+			}
+			removedFiles++
+		}
+	}
+
+	rawRaceIdInfo, err := c.raceIdRepository.Read(ctx, fmt.Sprintf("%s/%s", config.CacheDir, raceIdFileName))
+	if err != nil {
+		return 0, err
+	}
+	if rawRaceIdInfo != nil {
+		rawRaceDates := make([]*raw_entity.RaceDate, 0, len(rawRaceIdInfo.RaceDates))
+		for _, rawRaceDate := range rawRaceIdInfo.RaceDates {
+			if types.RaceDate(rawRaceDate.RaceDate) >= before {
+				rawRaceDates = append(rawRaceDates, rawRaceDate)
+			}
+		}
+		rawExcludeDates := make([]int, 0, len(rawRaceIdInfo.ExcludeDates))
+		for _, rawExcludeDate := range rawRaceIdInfo.ExcludeDates {
+			if types.RaceDate(rawExcludeDate) >= before {
+				rawExcludeDates = append(rawExcludeDates, rawExcludeDate)
+			}
+		}
+		if len(rawRaceDates) != len(rawRaceIdInfo.RaceDates) || len(rawExcludeDates) != len(rawRaceIdInfo.ExcludeDates) {
+			c.logger.Infof("prune race id dates: %d", len(rawRaceIdInfo.RaceDates)-len(rawRaceDates))
+			err = c.raceIdRepository.Write(ctx, fmt.Sprintf("%s/%s", config.CacheDir, raceIdFileName), &raw_entity.RaceIdInfo{
+				RaceDates:    rawRaceDates,
+				ExcludeDates: rawExcludeDates,
+			})
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	rawRaceForecastInfo, err := c.raceForecastRepository.Read(ctx, fmt.Sprintf("%s/%s", config.CacheDir, raceForecastFileName))
+	if err != nil {
+		return 0, err
+	}
+	if rawRaceForecastInfo != nil {
+		rawRaceForecasts := make([]*raw_entity.RaceForecast, 0, len(rawRaceForecastInfo.RaceForecasts))
+		for _, rawRaceForecast := range rawRaceForecastInfo.RaceForecasts {
+			if types.RaceDate(rawRaceForecast.RaceDate) >= before {
+				rawRaceForecasts = append(rawRaceForecasts, rawRaceForecast)
+			}
+		}
+		if len(rawRaceForecasts) != len(rawRaceForecastInfo.RaceForecasts) {
+			c.logger.Infof("prune race forecasts: %d", len(rawRaceForecastInfo.RaceForecasts)-len(rawRaceForecasts))
+			err = c.raceForecastRepository.Write(ctx, fmt.Sprintf("%s/%s", config.CacheDir, raceForecastFileName), &raw_entity.RaceForecastInfo{
+				RaceForecasts: rawRaceForecasts,
+			})
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return removedFiles, nil
+}
+
+// cacheType cache/odds/win/odds_20250504.jsonならodds/win、cache/race_id.jsonならrace_idを返す
+func (c *cacheEntryService) cacheType(filePath string) string {
+	relPath := strings.TrimPrefix(filePath, fmt.Sprintf("%s/", config.CacheDir))
+	if dir := path.Dir(relPath); dir != "." {
+		return dir
+	}
+
+	return strings.TrimSuffix(relPath, path.Ext(relPath))
+}
+
+// readRaceDates ファイルに含まれるレースの開催日をレースごとに返す。レース単位のキャッシュでない場合はnil
+func (c *cacheEntryService) readRaceDates(
+	ctx context.Context,
+	cacheType string,
+	filePath string,
+) ([]types.RaceDate, error) {
+	var raceDates []types.RaceDate
+	switch cacheType {
+	case raceCacheType:
+		rawRaces, err := c.raceRepository.Read(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		for _, rawRace := range rawRaces {
+			raceDates = append(raceDates, types.RaceDate(rawRace.RaceDate))
+		}
+	case raceTimeCacheType:
+		rawRaceTimes, err := c.raceTimeRepository.Read(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		for _, rawRaceTime := range rawRaceTimes {
+			raceDates = append(raceDates, types.RaceDate(rawRaceTime.RaceDate))
+		}
+	case winOddsCacheType, placeOddsCacheType, quinellaOddsCacheType, trioOddsCacheType:
+		rawRaceOddsList, err := c.oddsRepository.Read(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		for _, rawRaceOdds := range rawRaceOddsList {
+			raceDates = append(raceDates, types.RaceDate(rawRaceOdds.RaceDate))
+		}
+	case raceIdCacheType:
+		rawRaceIdInfo, err := c.raceIdRepository.Read(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		if rawRaceIdInfo != nil {
+			for _, rawRaceDate := range rawRaceIdInfo.RaceDates {
+				for range rawRaceDate.RaceIds {
+					raceDates = append(raceDates, types.RaceDate(rawRaceDate.RaceDate))
+				}
+			}
+		}
+	case raceForecastCacheType:
+		rawRaceForecastInfo, err := c.raceForecastRepository.Read(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		if rawRaceForecastInfo != nil {
+			for _, rawRaceForecast := range rawRaceForecastInfo.RaceForecasts {
+				raceDates = append(raceDates, types.RaceDate(rawRaceForecast.RaceDate))
+			}
+		}
+	}
+
+	return raceDates, nil
+}
+
+// fileRaceDate 開催日ごとのファイル名から開催日を取り出す
+func (c *cacheEntryService) fileRaceDate(cacheType string, fileName string) (types.RaceDate, bool) {
+	format := winOddsFileName
+	switch cacheType {
+	case raceCacheType:
+		format = raceFileName
+	case raceTimeCacheType:
+		format = raceTimeFileName
+	}
+
+	var rawRaceDate int
+	if _, err := fmt.Sscanf(fileName, format, &rawRaceDate); err != nil {
+		return 0, false
+	}
+
+	return types.RaceDate(rawRaceDate), true
+}
+
+func (c *cacheEntryService) replaceRace(rawRaces []*raw_entity.Race, newRawRace *raw_entity.Race) []*raw_entity.Race {
+	for i, rawRace := range rawRaces {
+		if rawRace.RaceId == newRawRace.RaceId {
+			rawRaces[i] = newRawRace
+			return rawRaces
+		}
+	}
+
+	rawRaces = append(rawRaces, newRawRace)
+	sort.Slice(rawRaces, func(i, j int) bool {
+		return rawRaces[i].RaceId < rawRaces[j].RaceId
+	})
+
+	return rawRaces
+}
+
+func (c *cacheEntryService) raceFilePath(raceDate types.RaceDate) string {
+	return fmt.Sprintf("%s/%s/%s", config.CacheDir, raceCacheType, fmt.Sprintf(raceFileName, raceDate.Value()))
+}
+
+func (c *cacheEntryService) raceTimeFilePath(raceDate types.RaceDate) string {
+	return fmt.Sprintf("%s/%s/%s", config.CacheDir, raceTimeCacheType, fmt.Sprintf(raceTimeFileName, raceDate.Value()))
+}
+
+func (c *cacheEntryService) oddsFilePath(cacheType string, raceDate types.RaceDate) string {
+	return fmt.Sprintf("%s/%s/%s", config.CacheDir, cacheType, fmt.Sprintf(winOddsFileName, raceDate.Value()))
+}
@@ -0,0 +1,359 @@
+package master_service
+
+import (
+	"context"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/sirupsen/logrus"
+)
+
+// listMemoryFiles dirの直下にあるファイル名を返す
+func listMemoryFiles[T any](files map[string]T, dir string) []string {
+	fileNames := make([]string, 0, len(files))
+	for filePath := range files {
+		if path.Dir(filePath) == dir {
+			fileNames = append(fileNames, path.Base(filePath))
+		}
+	}
+	sort.Strings(fileNames)
+	return fileNames
+}
+
+type memoryRaceRepository struct {
+	repository.RaceRepository
+	files map[string][]*raw_entity.Race
+}
+
+func (m *memoryRaceRepository) List(ctx context.Context, path string) ([]string, error) {
+	return listMemoryFiles(m.files, path), nil
+}
+
+func (m *memoryRaceRepository) Read(ctx context.Context, path string) ([]*raw_entity.Race, error) {
+	return m.files[path], nil
+}
+
+type memoryRaceTimeRepository struct {
+	repository.RaceTimeRepository
+	files map[string][]*raw_entity.RaceTime
+}
+
+func (m *memoryRaceTimeRepository) List(ctx context.Context, path string) ([]string, error) {
+	return listMemoryFiles(m.files, path), nil
+}
+
+func (m *memoryRaceTimeRepository) Read(ctx context.Context, path string) ([]*raw_entity.RaceTime, error) {
+	return m.files[path], nil
+}
+
+type memoryOddsRepository struct {
+	repository.OddsRepository
+	files map[string][]*raw_entity.RaceOdds
+}
+
+func (m *memoryOddsRepository) List(ctx context.Context, path string) ([]string, error) {
+	return listMemoryFiles(m.files, path), nil
+}
+
+func (m *memoryOddsRepository) Read(ctx context.Context, path string) ([]*raw_entity.RaceOdds, error) {
+	return m.files[path], nil
+}
+
+type memoryRaceIdRepository struct {
+	repository.RaceIdRepository
+	raceIdInfo *raw_entity.RaceIdInfo
+	writes     int
+}
+
+func (m *memoryRaceIdRepository) Read(ctx context.Context, path string) (*raw_entity.RaceIdInfo, error) {
+	return m.raceIdInfo, nil
+}
+
+func (m *memoryRaceIdRepository) Write(ctx context.Context, path string, data *raw_entity.RaceIdInfo) error {
+	m.raceIdInfo = data
+	m.writes++
+	return nil
+}
+
+type memoryRaceForecastRepository struct {
+	repository.RaceForecastRepository
+	raceForecastInfo *raw_entity.RaceForecastInfo
+	writes           int
+}
+
+func (m *memoryRaceForecastRepository) Read(ctx context.Context, path string) (*raw_entity.RaceForecastInfo, error) {
+	return m.raceForecastInfo, nil
+}
+
+func (m *memoryRaceForecastRepository) Write(ctx context.Context, path string, forecastInfo *raw_entity.RaceForecastInfo) error {
+	m.raceForecastInfo = forecastInfo
+	m.writes++
+	return nil
+}
+
+type memoryCacheRepository struct {
+	repository.CacheRepository
+	removed []string
+}
+
+func (m *memoryCacheRepository) Remove(ctx context.Context, path string) error {
+	m.removed = append(m.removed, path)
+	return nil
+}
+
+type memoryCacheEntry struct {
+	raceIdRepository       *memoryRaceIdRepository
+	raceForecastRepository *memoryRaceForecastRepository
+	cacheRepository        *memoryCacheRepository
+}
+
+func newTestCacheEntry(
+	races map[string][]*raw_entity.Race,
+	raceTimes map[string][]*raw_entity.RaceTime,
+	odds map[string][]*raw_entity.RaceOdds,
+	raceIdInfo *raw_entity.RaceIdInfo,
+	raceForecastInfo *raw_entity.RaceForecastInfo,
+) (*memoryCacheEntry, CacheEntry) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	repositories := &memoryCacheEntry{
+		raceIdRepository:       &memoryRaceIdRepository{raceIdInfo: raceIdInfo},
+		raceForecastRepository: &memoryRaceForecastRepository{raceForecastInfo: raceForecastInfo},
+		cacheRepository:        &memoryCacheRepository{},
+	}
+	return repositories, NewCacheEntry(
+		&memoryRaceRepository{files: races},
+		&memoryOddsRepository{files: odds},
+		&memoryRaceTimeRepository{files: raceTimes},
+		repositories.raceIdRepository,
+		repositories.raceForecastRepository,
+		repositories.cacheRepository,
+		nil,
+		nil,
+		nil,
+		logger,
+	)
+}
+
+func TestCacheEntryStats(t *testing.T) {
+	_, cacheEntry := newTestCacheEntry(
+		map[string][]*raw_entity.Race{
+			"cache/races/race_20241019.json": {{RaceId: "202405040801", RaceDate: 20241019}, {RaceId: "202405040802", RaceDate: 20241019}},
+			"cache/races/race_20241020.json": {{RaceId: "202405040901", RaceDate: 20241020}},
+		},
+		nil,
+		map[string][]*raw_entity.RaceOdds{
+			"cache/odds/win/odds_20241020.json": {{RaceId: "202405040901", RaceDate: 20241020}},
+		},
+		&raw_entity.RaceIdInfo{
+			RaceDates: []*raw_entity.RaceDate{{RaceDate: 20241019, RaceIds: []string{"202405040801", "202405040802"}}},
+		},
+		nil,
+	)
+	cacheFiles := []*cache_entity.CacheFile{
+		cache_entity.NewCacheFile("cache/races/race_20241019.json", 100, time.Time{}, types.CacheFileValid, ""),
+		cache_entity.NewCacheFile("cache/races/race_20241020.json", 50, time.Time{}, types.CacheFileValid, ""),
+		cache_entity.NewCacheFile("cache/races/race_20241021.json", 10, time.Time{}, types.CacheFileCorrupt, "checksum mismatch"),
+		cache_entity.NewCacheFile("cache/odds/win/odds_20241020.json", 30, time.Time{}, types.CacheFileValid, ""),
+		cache_entity.NewCacheFile("cache/race_id.json", 5, time.Time{}, types.CacheFileValid, ""),
+		cache_entity.NewCacheFile("cache/horse.json", 7, time.Time{}, types.CacheFileValid, ""),
+	}
+
+	got, err := cacheEntry.Stats(context.Background(), cacheFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*cache_entity.CacheStat{
+		cache_entity.NewCacheStat("horse", 1, 0, 7, 0, 0, 0),
+		cache_entity.NewCacheStat("odds/win", 1, 0, 30, 1, 20241020, 20241020),
+		cache_entity.NewCacheStat("race_id", 1, 0, 5, 2, 20241019, 20241019),
+		cache_entity.NewCacheStat("races", 3, 1, 160, 3, 20241019, 20241020),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Stats() = %d stats, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("Stats()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCacheEntryPrune(t *testing.T) {
+	tests := []struct {
+		name                   string
+		before                 types.RaceDate
+		wantRemoved            []string
+		wantRaceDates          []int
+		wantExcludeDates       []int
+		wantRaceIdWrites       int
+		wantRaceForecastDates  []int
+		wantRaceForecastWrites int
+	}{
+		{
+			name:   "指定日より前の開催日を削除する",
+			before: 20241020,
+			wantRemoved: []string{
+				"cache/odds/place/odds_20241018.json",
+				"cache/race_times/race_time_20241019.json",
+				"cache/races/race_20241019.json",
+			},
+			wantRaceDates:          []int{20241020},
+			wantExcludeDates:       []int{20241021},
+			wantRaceIdWrites:       1,
+			wantRaceForecastDates:  []int{20241020},
+			wantRaceForecastWrites: 1,
+		},
+		{
+			name:                   "削除対象がなければ書き換えない",
+			before:                 20241001,
+			wantRemoved:            nil,
+			wantRaceDates:          []int{20241019, 20241020},
+			wantExcludeDates:       []int{20241018, 20241021},
+			wantRaceForecastDates:  []int{20241019, 20241020},
+			wantRaceIdWrites:       0,
+			wantRaceForecastWrites: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositories, cacheEntry := newTestCacheEntry(
+				map[string][]*raw_entity.Race{
+					"cache/races/race_20241019.json": nil,
+					"cache/races/race_20241020.json": nil,
+					"cache/races/readme.txt":         nil,
+				},
+				map[string][]*raw_entity.RaceTime{
+					"cache/race_times/race_time_20241019.json": nil,
+				},
+				map[string][]*raw_entity.RaceOdds{
+					"cache/odds/place/odds_20241018.json": nil,
+					"cache/odds/win/odds_20241020.json":   nil,
+				},
+				&raw_entity.RaceIdInfo{
+					RaceDates:    []*raw_entity.RaceDate{{RaceDate: 20241019}, {RaceDate: 20241020}},
+					ExcludeDates: []int{20241018, 20241021},
+				},
+				&raw_entity.RaceForecastInfo{
+					RaceForecasts: []*raw_entity.RaceForecast{{RaceDate: 20241019}, {RaceDate: 20241020}},
+				},
+			)
+
+			removedFiles, err := cacheEntry.Prune(context.Background(), tt.before)
+			if err != nil {
+				t.Fatal(err)
+			}
+			removed := repositories.cacheRepository.removed
+			sort.Strings(removed)
+			if removedFiles != len(tt.wantRemoved) || !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("Prune() = %d, removed %v, want %v", removedFiles, removed, tt.wantRemoved)
+			}
+
+			raceIdInfo := repositories.raceIdRepository.raceIdInfo
+			var raceDates []int
+			for _, rawRaceDate := range raceIdInfo.RaceDates {
+				raceDates = append(raceDates, rawRaceDate.RaceDate)
+			}
+			if !reflect.DeepEqual(raceDates, tt.wantRaceDates) || !reflect.DeepEqual(raceIdInfo.ExcludeDates, tt.wantExcludeDates) ||
+				repositories.raceIdRepository.writes != tt.wantRaceIdWrites {
+				t.Errorf("race id = %v, %v, writes %d, want %v, %v, writes %d", raceDates, raceIdInfo.ExcludeDates,
+					repositories.raceIdRepository.writes, tt.wantRaceDates, tt.wantExcludeDates, tt.wantRaceIdWrites)
+			}
+
+			var raceForecastDates []int
+			for _, rawRaceForecast := range repositories.raceForecastRepository.raceForecastInfo.RaceForecasts {
+				raceForecastDates = append(raceForecastDates, rawRaceForecast.RaceDate)
+			}
+			if !reflect.DeepEqual(raceForecastDates, tt.wantRaceForecastDates) ||
+				repositories.raceForecastRepository.writes != tt.wantRaceForecastWrites {
+				t.Errorf("race forecast = %v, writes %d, want %v, writes %d", raceForecastDates,
+					repositories.raceForecastRepository.writes, tt.wantRaceForecastDates, tt.wantRaceForecastWrites)
+			}
+		})
+	}
+}
+
+func TestCacheEntryCacheType(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     string
+	}{
+		{
+			name:     "ディレクトリ配下",
+			filePath: "cache/odds/win/odds_20250504.json",
+			want:     "odds/win",
+		},
+		{
+			name:     "cache直下",
+			filePath: "cache/race_id.json",
+			want:     "race_id",
+		},
+	}
+
+	c := &cacheEntryService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.cacheType(tt.filePath); got != tt.want {
+				t.Errorf("cacheType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheEntryFileRaceDate(t *testing.T) {
+	tests := []struct {
+		name      string
+		cacheType string
+		fileName  string
+		want      types.RaceDate
+		wantOk    bool
+	}{
+		{
+			name:      "レース",
+			cacheType: raceCacheType,
+			fileName:  "race_20241020.json",
+			want:      20241020,
+			wantOk:    true,
+		},
+		{
+			name:      "レースタイム",
+			cacheType: raceTimeCacheType,
+			fileName:  "race_time_20241020.json",
+			want:      20241020,
+			wantOk:    true,
+		},
+		{
+			name:      "オッズ",
+			cacheType: trioOddsCacheType,
+			fileName:  "odds_20241020.json",
+			want:      20241020,
+			wantOk:    true,
+		},
+		{
+			name:      "開催日を含まないファイル",
+			cacheType: raceCacheType,
+			fileName:  "readme.txt",
+			wantOk:    false,
+		},
+	}
+
+	c := &cacheEntryService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := c.fileRaceDate(tt.cacheType, tt.fileName)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("fileRaceDate() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
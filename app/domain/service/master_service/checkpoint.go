@@ -0,0 +1,409 @@
+package master_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	checkpointFileName = "checkpoint/%s.jsonl"
+	retryQueueFileName = "checkpoint/retry_queue.json"
+	maxRetryAttempts   = 5 // この回数失敗したURLは取得を諦める。取り直す場合はリトライキューから消す
+)
+
+// マスタ取得のステージ。チェックポイントとリトライキューはステージ単位で管理する
+const (
+	raceIdStage       = "race_id"
+	raceStage         = "race"
+	raceTimeStage     = "race_time"
+	jockeyStage       = "jockey"
+	trainerStage      = "trainer"
+	winOddsStage      = "win_odds"
+	placeOddsStage    = "place_odds"
+	quinellaOddsStage = "quinella_odds"
+	trioOddsStage     = "trio_odds"
+)
+
+type Checkpoint interface {
+	Resume(ctx context.Context, stage string, urls []string) (map[string]json.RawMessage, error)
+	Schedule(ctx context.Context, stage string, urls []string) ([]string, error)
+	Save(ctx context.Context, stage, url string, item any) error
+	Retry(ctx context.Context, stage string, urls, fetchedUrls []string, failedUrls map[string]error) error
+	Done(ctx context.Context, stage string, urls []string) error
+}
+
+type checkpointService struct {
+	checkpointRepository repository.CheckpointRepository
+	logger               *logrus.Logger
+}
+
+func NewCheckpoint(
+	checkpointRepository repository.CheckpointRepository,
+	logger *logrus.Logger,
+) Checkpoint {
+	return &checkpointService{
+		checkpointRepository: checkpointRepository,
+		logger:               logger,
+	}
+}
+
+// Resume 前回途中で止まったときに取得済みだった結果をURLごとに返す。今回の取得対象に無いURLの結果はチェックポイントから消す
+func (c *checkpointService) Resume(
+	ctx context.Context,
+	stage string,
+	urls []string,
+) (map[string]json.RawMessage, error) {
+	path := c.checkpointPath(stage)
+	items, err := c.checkpointRepository.Read(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	urlMap := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		urlMap[url] = true
+	}
+
+	itemMap := make(map[string]json.RawMessage, len(items))
+	remainItems := make([]*raw_entity.CheckpointItem, 0, len(items))
+	for _, item := range items {
+		if !urlMap[item.Url] {
+			continue
+		}
+		itemMap[item.Url] = item.Data
+		remainItems = append(remainItems, item)
+	}
+
+	if len(remainItems) < len(items) {
+		c.logger.Infof("%s checkpoint pruned: %v items are no longer fetched", stage, len(items)-len(remainItems))
+		if len(remainItems) == 0 {
+			err = c.checkpointRepository.Remove(ctx, path)
+		} else {
+			err = c.checkpointRepository.Write(ctx, path, remainItems)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return itemMap, nil
+}
+
+// Schedule 取得するURLを並べる。リトライキューに無いURLを先に、前回までに失敗したURLは試行回数の少ない順に後ろへ回し、上限に達したURLは外す
+func (c *checkpointService) Schedule(
+	ctx context.Context,
+	stage string,
+	urls []string,
+) ([]string, error) {
+	retryQueue, err := c.checkpointRepository.ReadRetryQueue(ctx, c.retryQueuePath())
+	if err != nil {
+		return nil, err
+	}
+	if retryQueue == nil {
+		return urls, nil
+	}
+
+	entryMap := map[string]*raw_entity.RetryEntry{}
+	for _, entry := range retryQueue.Entries {
+		if entry.Stage == stage {
+			entryMap[entry.Url] = entry
+		}
+	}
+
+	scheduledUrls := make([]string, 0, len(urls))
+	var retryEntries []*raw_entity.RetryEntry
+	for _, url := range urls {
+		entry, ok := entryMap[url]
+		if !ok {
+			scheduledUrls = append(scheduledUrls, url)
+			continue
+		}
+		if entry.Attempts >= maxRetryAttempts {
+			c.logger.Warnf("%s fetch skipped after %v failed attempts: %s: %s", stage, entry.Attempts, url, entry.Error)
+			continue
+		}
+		retryEntries = append(retryEntries, entry)
+	}
+	sort.SliceStable(retryEntries, func(i, j int) bool {
+		return retryEntries[i].Attempts < retryEntries[j].Attempts
+	})
+	for _, entry := range retryEntries {
+		scheduledUrls = append(scheduledUrls, entry.Url)
+	}
+
+	return scheduledUrls, nil
+}
+
+// Save 取得できた結果をすぐにチェックポイントへ追記する
+func (c *checkpointService) Save(
+	ctx context.Context,
+	stage, url string,
+	item any,
+) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	return c.checkpointRepository.Append(ctx, c.checkpointPath(stage), &raw_entity.CheckpointItem{
+		Url:  url,
+		Data: data,
+	})
+}
+
+// Retry 取得できたURLと今回の取得対象に無いURLをリトライキューから外し、失敗したURLを試行回数とともに積む
+func (c *checkpointService) Retry(
+	ctx context.Context,
+	stage string,
+	urls []string,
+	fetchedUrls []string,
+	failedUrls map[string]error,
+) error {
+	retryQueue, err := c.checkpointRepository.ReadRetryQueue(ctx, c.retryQueuePath())
+	if err != nil {
+		return err
+	}
+	if retryQueue == nil {
+		if len(failedUrls) == 0 {
+			return nil
+		}
+		retryQueue = &raw_entity.RetryQueue{}
+	}
+
+	urlMap := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		urlMap[url] = true
+	}
+	fetchedUrlMap := make(map[string]bool, len(fetchedUrls))
+	for _, url := range fetchedUrls {
+		fetchedUrlMap[url] = true
+	}
+
+	changed := false
+	entries := make([]*raw_entity.RetryEntry, 0, len(retryQueue.Entries)+len(failedUrls))
+	entryMap := map[string]*raw_entity.RetryEntry{}
+	for _, entry := range retryQueue.Entries {
+		if entry.Stage == stage && (fetchedUrlMap[entry.Url] || !urlMap[entry.Url]) {
+			changed = true
+			continue
+		}
+		if entry.Stage == stage {
+			entryMap[entry.Url] = entry
+		}
+		entries = append(entries, entry)
+	}
+
+	failedAt := time.Now().Format(time.DateTime)
+	for url, failedErr := range failedUrls {
+		changed = true
+		entry, ok := entryMap[url]
+		if !ok {
+			entry = &raw_entity.RetryEntry{
+				Stage: stage,
+				Url:   url,
+			}
+			entries = append(entries, entry)
+		}
+		entry.Error = failedErr.Error()
+		entry.Attempts++
+		entry.FailedAt = failedAt
+	}
+
+	if !changed {
+		return nil
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Stage != entries[j].Stage {
+			return entries[i].Stage < entries[j].Stage
+		}
+		return entries[i].Url < entries[j].Url
+	})
+
+	return c.checkpointRepository.WriteRetryQueue(ctx, c.retryQueuePath(), &raw_entity.RetryQueue{
+		Entries: entries,
+	})
+}
+
+// Done キャッシュに書き込み終わったURLをチェックポイントから外す。同じステージを別の条件で呼ぶ場合があるので、他のURLの結果は残す
+func (c *checkpointService) Done(
+	ctx context.Context,
+	stage string,
+	urls []string,
+) error {
+	path := c.checkpointPath(stage)
+	items, err := c.checkpointRepository.Read(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	urlMap := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		urlMap[url] = true
+	}
+
+	remainItems := make([]*raw_entity.CheckpointItem, 0, len(items))
+	for _, item := range items {
+		if !urlMap[item.Url] {
+			remainItems = append(remainItems, item)
+		}
+	}
+
+	if len(remainItems) == 0 {
+		return c.checkpointRepository.Remove(ctx, path)
+	}
+	if len(remainItems) == len(items) {
+		return nil
+	}
+
+	return c.checkpointRepository.Write(ctx, path, remainItems)
+}
+
+func (c *checkpointService) checkpointPath(stage string) string {
+	return fmt.Sprintf("%s/%s", config.CacheDir, fmt.Sprintf(checkpointFileName, stage))
+}
+
+func (c *checkpointService) retryQueuePath() string {
+	return fmt.Sprintf("%s/%s", config.CacheDir, retryQueueFileName)
+}
+
+// crawl urlsを並列に取得する。取得できた結果はその都度チェックポイントに記録し、途中で止まっても再実行時に続きから取得する
+// 取得に失敗したURLは全体を止めずにリトライキューへ回し、次回以降の実行で試行回数の上限まで取り直す
+func crawl[T any](
+	ctx context.Context,
+	checkpoint Checkpoint,
+	logger *logrus.Logger,
+	stage string,
+	urls []string,
+	parallel int,
+	fetch func(ctx context.Context, url string) (T, error),
+) ([]T, error) {
+	taskCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	checkpointItemMap, err := checkpoint.Resume(ctx, stage, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]T, 0, len(urls))
+	fetchUrls := make([]string, 0, len(urls))
+	restoredUrls := make([]string, 0, len(checkpointItemMap))
+	decodeFailedUrls := map[string]error{}
+	for _, url := range urls {
+		data, ok := checkpointItemMap[url]
+		if !ok {
+			fetchUrls = append(fetchUrls, url)
+			continue
+		}
+		var item T
+		if err := json.Unmarshal(data, &item); err != nil {
+			logger.Warnf("%s checkpoint item is broken, refetching: %s: %v", stage, url, err)
+			decodeFailedUrls[url] = fmt.Errorf("checkpoint decode failed: %w", err)
+			fetchUrls = append(fetchUrls, url)
+			continue
+		}
+		results = append(results, item)
+		restoredUrls = append(restoredUrls, url)
+	}
+	if len(results) > 0 {
+		logger.Infof("%s resumed from checkpoint: %v/%v", stage, len(results), len(urls))
+	}
+	fetchUrls, err = checkpoint.Schedule(ctx, stage, fetchUrls)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		saveErr     error
+		fetchedUrls = make([]string, 0, len(fetchUrls))
+		failedUrls  = map[string]error{}
+	)
+
+	if len(fetchUrls) > 0 {
+		chunkSize := (len(fetchUrls) + parallel - 1) / parallel
+		for i := 0; i < len(fetchUrls); i += chunkSize {
+			end := i + chunkSize
+			if end > len(fetchUrls) {
+				end = len(fetchUrls)
+			}
+
+			wg.Add(1)
+			go func(splitUrls []string) {
+				defer wg.Done()
+				logger.Infof("%s fetch processing: %v/%v", stage, end, len(fetchUrls))
+				for _, url := range splitUrls {
+					time.Sleep(time.Millisecond)
+					select {
+					case <-taskCtx.Done():
+						return
+					default:
+						item, err := fetch(taskCtx, url)
+						mu.Lock()
+						if err != nil {
+							if taskCtx.Err() == nil {
+								logger.Warnf("%s fetch failed, queued for retry: %s: %v", stage, url, err)
+								failedUrls[url] = err
+							}
+							mu.Unlock()
+							continue
+						}
+						// チェックポイントに残せないと再開できないので、書き込みに失敗した場合は全体を止める
+						if err := checkpoint.Save(ctx, stage, url, item); err != nil {
+							if saveErr == nil {
+								saveErr = err
+								cancel()
+							}
+							mu.Unlock()
+							return
+						}
+						results = append(results, item)
+						fetchedUrls = append(fetchedUrls, url)
+						mu.Unlock()
+					}
+				}
+			}(fetchUrls[i:end])
+		}
+
+		wg.Wait()
+	}
+
+	if saveErr != nil {
+		return nil, saveErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// チェックポイントが壊れていて今回も取り直せなかったURLは、取得に失敗したものとしてリトライキューに残す
+	fetchedUrlMap := make(map[string]bool, len(fetchedUrls))
+	for _, url := range fetchedUrls {
+		fetchedUrlMap[url] = true
+	}
+	for url, decodeErr := range decodeFailedUrls {
+		if _, ok := failedUrls[url]; !ok && !fetchedUrlMap[url] {
+			failedUrls[url] = decodeErr
+		}
+	}
+	// 前回の実行でリトライキューに積まれ、今回チェックポイントから復元できたURLもキューから外す
+	fetchedUrls = append(fetchedUrls, restoredUrls...)
+	if err := checkpoint.Retry(ctx, stage, urls, fetchedUrls, failedUrls); err != nil {
+		return nil, err
+	}
+	if len(failedUrls) > 0 {
+		logger.Warnf("%s: %v/%v urls failed and were queued for retry in the next run", stage, len(failedUrls), len(urls))
+	}
+
+	return results, nil
+}
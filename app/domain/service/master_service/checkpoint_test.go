@@ -0,0 +1,252 @@
+package master_service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/sirupsen/logrus"
+)
+
+type memoryCheckpointRepository struct {
+	items      map[string][]*raw_entity.CheckpointItem
+	retryQueue *raw_entity.RetryQueue
+}
+
+func (m *memoryCheckpointRepository) Read(ctx context.Context, path string) ([]*raw_entity.CheckpointItem, error) {
+	return m.items[path], nil
+}
+
+func (m *memoryCheckpointRepository) Write(ctx context.Context, path string, items []*raw_entity.CheckpointItem) error {
+	m.items[path] = items
+	return nil
+}
+
+func (m *memoryCheckpointRepository) Append(ctx context.Context, path string, item *raw_entity.CheckpointItem) error {
+	m.items[path] = append(m.items[path], item)
+	return nil
+}
+
+func (m *memoryCheckpointRepository) Remove(ctx context.Context, path string) error {
+	delete(m.items, path)
+	return nil
+}
+
+func (m *memoryCheckpointRepository) ReadRetryQueue(ctx context.Context, path string) (*raw_entity.RetryQueue, error) {
+	return m.retryQueue, nil
+}
+
+func (m *memoryCheckpointRepository) WriteRetryQueue(ctx context.Context, path string, data *raw_entity.RetryQueue) error {
+	m.retryQueue = data
+	return nil
+}
+
+func newTestCheckpoint(retryQueue *raw_entity.RetryQueue) (*memoryCheckpointRepository, Checkpoint) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	repository := &memoryCheckpointRepository{
+		items:      map[string][]*raw_entity.CheckpointItem{},
+		retryQueue: retryQueue,
+	}
+	return repository, NewCheckpoint(repository, logger)
+}
+
+func TestCheckpointSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryQueue *raw_entity.RetryQueue
+		urls       []string
+		want       []string
+	}{
+		{
+			name: "empty queue keeps order",
+			urls: []string{"a", "b", "c"},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "retries go last in order of attempts and give up at the limit",
+			retryQueue: &raw_entity.RetryQueue{Entries: []*raw_entity.RetryEntry{
+				{Stage: raceStage, Url: "a", Attempts: 3},
+				{Stage: raceStage, Url: "b", Attempts: maxRetryAttempts},
+				{Stage: raceStage, Url: "c", Attempts: 1},
+				{Stage: jockeyStage, Url: "d", Attempts: maxRetryAttempts},
+			}},
+			urls: []string{"a", "b", "c", "d", "e"},
+			want: []string{"d", "e", "c", "a"},
+		},
+		{
+			name: "empty urls",
+			retryQueue: &raw_entity.RetryQueue{Entries: []*raw_entity.RetryEntry{
+				{Stage: raceStage, Url: "a", Attempts: 1},
+			}},
+			urls: []string{},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, checkpoint := newTestCheckpoint(tt.retryQueue)
+			got, err := checkpoint.Schedule(context.Background(), raceStage, tt.urls)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Schedule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckpointRetry(t *testing.T) {
+	repository, checkpoint := newTestCheckpoint(&raw_entity.RetryQueue{Entries: []*raw_entity.RetryEntry{
+		{Stage: raceStage, Url: "fetched", Attempts: 2},
+		{Stage: raceStage, Url: "failed", Attempts: 2},
+		{Stage: raceStage, Url: "removed", Attempts: 1},
+		{Stage: raceStage, Url: "skipped", Attempts: maxRetryAttempts},
+		{Stage: jockeyStage, Url: "removed", Attempts: 1},
+	}})
+
+	err := checkpoint.Retry(context.Background(), raceStage,
+		[]string{"fetched", "failed", "skipped", "new"},
+		[]string{"fetched"},
+		map[string]error{"failed": errors.New("timeout"), "new": errors.New("404")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]int{}
+	for _, entry := range repository.retryQueue.Entries {
+		got[entry.Stage+"/"+entry.Url] = entry.Attempts
+	}
+	want := map[string]int{
+		raceStage + "/failed":    3,
+		raceStage + "/new":       1,
+		raceStage + "/skipped":   maxRetryAttempts,
+		jockeyStage + "/removed": 1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Retry() queue = %v, want %v", got, want)
+	}
+}
+
+func TestCheckpointResumePrunesUnknownUrls(t *testing.T) {
+	repository, checkpoint := newTestCheckpoint(nil)
+	path := checkpoint.(*checkpointService).checkpointPath(raceStage)
+	repository.items[path] = []*raw_entity.CheckpointItem{
+		{Url: "a", Data: []byte(`1`)},
+		{Url: "b", Data: []byte(`2`)},
+	}
+
+	itemMap, err := checkpoint.Resume(context.Background(), raceStage, []string{"a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(itemMap) != 1 || string(itemMap["a"]) != "1" {
+		t.Errorf("Resume() = %v", itemMap)
+	}
+	if len(repository.items[path]) != 1 || repository.items[path][0].Url != "a" {
+		t.Errorf("checkpoint items = %v", repository.items[path])
+	}
+
+	if _, err = checkpoint.Resume(context.Background(), raceStage, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := repository.items[path]; ok {
+		t.Errorf("checkpoint should be removed when no url remains")
+	}
+}
+
+func TestCrawl(t *testing.T) {
+	tests := []struct {
+		name          string
+		retryQueue    *raw_entity.RetryQueue
+		fetchErr      error
+		want          []int
+		wantFetched   []string
+		wantQueue     map[string]int
+		wantQueueNone bool
+	}{
+		{
+			name: "壊れたチェックポイントは取り直してキューから外す",
+			retryQueue: &raw_entity.RetryQueue{Entries: []*raw_entity.RetryEntry{
+				{Stage: raceStage, Url: "broken", Attempts: 1},
+				{Stage: raceStage, Url: "restored", Attempts: 1},
+			}},
+			want:        []int{1, 20},
+			wantFetched: []string{"broken"},
+			wantQueue:   map[string]int{},
+		},
+		{
+			name: "壊れたチェックポイントを取り直せなければキューに残す",
+			retryQueue: &raw_entity.RetryQueue{Entries: []*raw_entity.RetryEntry{
+				{Stage: raceStage, Url: "broken", Attempts: 1},
+			}},
+			fetchErr:    errors.New("timeout"),
+			want:        []int{1},
+			wantFetched: []string{"broken"},
+			wantQueue:   map[string]int{"broken": 2},
+		},
+		{
+			name: "試行回数の上限で取り直さない場合も壊れたチェックポイントは取得済みにしない",
+			retryQueue: &raw_entity.RetryQueue{Entries: []*raw_entity.RetryEntry{
+				{Stage: raceStage, Url: "broken", Attempts: maxRetryAttempts},
+			}},
+			want:      []int{1},
+			wantQueue: map[string]int{"broken": maxRetryAttempts + 1},
+		},
+		{
+			name:          "キューが無ければ壊れたチェックポイントを取り直すだけ",
+			want:          []int{1, 20},
+			wantFetched:   []string{"broken"},
+			wantQueueNone: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, checkpoint := newTestCheckpoint(tt.retryQueue)
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+			repository.items[checkpoint.(*checkpointService).checkpointPath(raceStage)] = []*raw_entity.CheckpointItem{
+				{Url: "restored", Data: []byte(`1`)},
+				{Url: "broken", Data: []byte(`{"broken"`)},
+			}
+
+			var fetched []string
+			got, err := crawl(context.Background(), checkpoint, logger, raceStage, []string{"restored", "broken"}, 1, func(ctx context.Context, url string) (int, error) {
+				fetched = append(fetched, url)
+				if tt.fetchErr != nil {
+					return 0, tt.fetchErr
+				}
+				return 20, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("crawl() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(fetched, tt.wantFetched) {
+				t.Errorf("fetched = %v, want %v", fetched, tt.wantFetched)
+			}
+			if tt.wantQueueNone {
+				if repository.retryQueue != nil {
+					t.Errorf("retry queue = %v, want nil", repository.retryQueue)
+				}
+				return
+			}
+			queue := map[string]int{}
+			for _, entry := range repository.retryQueue.Entries {
+				queue[entry.Url] = entry.Attempts
+			}
+			if !reflect.DeepEqual(queue, tt.wantQueue) {
+				t.Errorf("retry queue = %v, want %v", queue, tt.wantQueue)
+			}
+		})
+	}
+}
@@ -4,11 +4,8 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"sync"
-	"time"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
-	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
@@ -34,17 +31,20 @@ type Jockey interface {
 type jockeyService struct {
 	jockeyRepository      repository.JockeyRepository
 	jockeyEntityConverter converter.JockeyEntityConverter
+	checkpoint            Checkpoint
 	logger                *logrus.Logger
 }
 
 func NewJockey(
 	jockeyRepository repository.JockeyRepository,
 	jockeyEntityConverter converter.JockeyEntityConverter,
+	checkpoint Checkpoint,
 	logger *logrus.Logger,
 ) Jockey {
 	return &jockeyService{
 		jockeyRepository:      jockeyRepository,
 		jockeyEntityConverter: jockeyEntityConverter,
+		checkpoint:            checkpoint,
 		logger:                logger,
 	}
 }
@@ -76,9 +76,6 @@ func (j *jockeyService) CreateOrUpdate(
 	jockeys []*data_cache_entity.Jockey,
 	excludeJockeyIds []types.JockeyId,
 ) error {
-	taskCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
 	urls := j.createJockeyUrls(jockeys, excludeJockeyIds)
 	if len(urls) == 0 {
 		return nil
@@ -89,60 +86,23 @@ func (j *jockeyService) CreateOrUpdate(
 		rawExcludeJockeyIds []string
 	)
 
-	var wg sync.WaitGroup
-	const raceIdParallel = 10
-	errorCh := make(chan error, 1)
-	resultCh := make(chan []*netkeiba_entity.Jockey, raceIdParallel)
-	chunkSize := (len(urls) + raceIdParallel - 1) / raceIdParallel
-
-	for i := 0; i < len(urls); i += chunkSize {
-		end := i + chunkSize
-		if end > len(urls) {
-			end = len(urls)
+	const jockeyParallel = 10
+	fetchedRawJockeys, err := crawl(ctx, j.checkpoint, j.logger, jockeyStage, urls, jockeyParallel, func(ctx context.Context, url string) (*raw_entity.Jockey, error) {
+		jockey, err := j.jockeyRepository.Fetch(ctx, url)
+		if err != nil {
+			return nil, err
 		}
-
-		wg.Add(1)
-		go func(splitUrls []string) {
-			defer wg.Done()
-			localJockeys := make([]*netkeiba_entity.Jockey, 0, len(splitUrls))
-			j.logger.Infof("jockey fetch processing: %v/%v", end, len(urls))
-			for _, url := range splitUrls {
-				time.Sleep(time.Millisecond)
-				select {
-				case <-taskCtx.Done():
-					return
-				default:
-					jockey, err := j.jockeyRepository.Fetch(taskCtx, url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-					localJockeys = append(localJockeys, jockey)
-				}
-			}
-
-			resultCh <- localJockeys
-		}(urls[i:end])
-	}
-
-	wg.Wait()
-	close(errorCh)
-	close(resultCh)
-
-	if err := <-errorCh; err != nil {
+		return j.jockeyEntityConverter.NetKeibaToRaw(jockey), nil
+	})
+	if err != nil {
 		return err
 	}
 
-	for results := range resultCh {
-		for _, jockey := range results {
-			if jockey.Name() == "" {
-				rawExcludeJockeyIds = append(rawExcludeJockeyIds, jockey.Id())
-			} else {
-				rawJockeys = append(rawJockeys, j.jockeyEntityConverter.NetKeibaToRaw(jockey))
-			}
+	for _, rawJockey := range fetchedRawJockeys {
+		if rawJockey.JockeyName == "" {
+			rawExcludeJockeyIds = append(rawExcludeJockeyIds, rawJockey.JockeyId)
+		} else {
+			rawJockeys = append(rawJockeys, rawJockey)
 		}
 	}
 
@@ -160,7 +120,7 @@ func (j *jockeyService) CreateOrUpdate(
 
 	sort.Strings(rawExcludeJockeyIds)
 
-	err := j.jockeyRepository.Write(ctx, fmt.Sprintf("%s/%s", config.CacheDir, jockeyFileName), &raw_entity.JockeyInfo{
+	err = j.jockeyRepository.Write(ctx, fmt.Sprintf("%s/%s", config.CacheDir, jockeyFileName), &raw_entity.JockeyInfo{
 		Jockeys:          rawJockeys,
 		ExcludeJockeyIds: rawExcludeJockeyIds,
 	})
@@ -168,7 +128,7 @@ func (j *jockeyService) CreateOrUpdate(
 		return err
 	}
 
-	return nil
+	return j.checkpoint.Done(ctx, jockeyStage, urls)
 }
 
 func (j *jockeyService) createJockeyUrls(
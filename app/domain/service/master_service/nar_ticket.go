@@ -0,0 +1,200 @@
+package master_service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/shopspring/decimal"
+)
+
+type NarTicket interface {
+	Get(ctx context.Context, races []*data_cache_entity.Race) ([]*ticket_csv_entity.RaceTicket, error)
+}
+
+type narTicketService struct {
+	narTicketRepository repository.NarTicketRepository
+	ticketRepository    repository.TicketRepository
+	betNumberConverter  BetNumberConverter
+}
+
+func NewNarTicket(
+	narTicketRepository repository.NarTicketRepository,
+	ticketRepository repository.TicketRepository,
+	betNumberConverter BetNumberConverter,
+) NarTicket {
+	return &narTicketService{
+		narTicketRepository: narTicketRepository,
+		ticketRepository:    ticketRepository,
+		betNumberConverter:  betNumberConverter,
+	}
+}
+
+// Get SPAT4、楽天競馬、オッズパークの購入履歴を読み込む
+// ながし、ボックス、フォーメーションの馬券はレースの払戻結果と突き合わせて1点ずつにバラす
+func (n *narTicketService) Get(
+	ctx context.Context,
+	races []*data_cache_entity.Race,
+) ([]*ticket_csv_entity.RaceTicket, error) {
+	accountDirs, err := getAccountDirs(ctx, n.ticketRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	raceMap := converter.ConvertToMap(races, func(race *data_cache_entity.Race) types.RaceId {
+		return race.RaceId()
+	})
+
+	raceTickets := make([]*ticket_csv_entity.RaceTicket, 0)
+	for _, accountDir := range accountDirs {
+		files, err := n.narTicketRepository.List(ctx, accountDir.dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			tickets, err := n.narTicketRepository.Read(ctx, fmt.Sprintf("%s/%s", accountDir.dir, file), accountDir.account)
+			if err != nil {
+				return nil, err
+			}
+			for _, ticket := range tickets {
+				// NARはraceデータをキャッシュしてないのでraceIdを自力で構築する
+				raceId := types.NewRaceIdForNAR(
+					ticket.RaceDate().Year(),
+					ticket.RaceDate().Month(),
+					ticket.RaceDate().Day(),
+					ticket.RaceCourse().Value(),
+					ticket.RaceNo(),
+				)
+				splitTickets, err := n.split(ctx, ticket, raceMap[raceId])
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", file, err)
+				}
+				for _, splitTicket := range splitTickets {
+					raceTickets = append(raceTickets, ticket_csv_entity.NewRaceTicket(
+						raceId,
+						splitTicket,
+					))
+				}
+			}
+		}
+	}
+
+	return raceTickets, nil
+}
+
+// split まとめ買いの馬券を1点ずつにバラし、払戻は払戻結果の組番と一致した買い目に付ける
+// 的中しているのにレースの払戻結果が無い、または一致する組番が無い場合は的中した買い目を決められないのでまとめたまま返す
+func (n *narTicketService) split(
+	ctx context.Context,
+	ticket *ticket_csv_entity.Ticket,
+	race *data_cache_entity.Race,
+) ([]*ticket_csv_entity.Ticket, error) {
+	if !ticket.BetNumber().Grouped() {
+		return []*ticket_csv_entity.Ticket{ticket}, nil
+	}
+
+	betNumbers, err := n.betNumberConverter.ToBetNumbers(ctx, ticket.TicketType(), string(ticket.BetNumber()))
+	if err != nil {
+		return nil, err
+	}
+	if len(betNumbers) == 0 || ticket.Payment().Value()%len(betNumbers) != 0 {
+		return nil, fmt.Errorf("payment %d is not divisible by %d bets: %s", ticket.Payment().Value(), len(betNumbers), ticket.BetNumber())
+	}
+	sort.Slice(betNumbers, func(i, j int) bool {
+		return betNumbers[i] < betNumbers[j]
+	})
+
+	payouts := make([]types.Payout, len(betNumbers))
+	if ticket.Payout() > 0 {
+		if race == nil {
+			return []*ticket_csv_entity.Ticket{ticket}, nil
+		}
+		var ok bool
+		if payouts, ok = n.allocatePayout(ticket, betNumbers, race); !ok {
+			return []*ticket_csv_entity.Ticket{ticket}, nil
+		}
+	}
+
+	payment := types.Payment(ticket.Payment().Value() / len(betNumbers))
+	tickets := make([]*ticket_csv_entity.Ticket, 0, len(betNumbers))
+	for idx, betNumber := range betNumbers {
+		tickets = append(tickets, ticket.Split(betNumber, payment, payouts[idx]))
+	}
+
+	return tickets, nil
+}
+
+// allocatePayout 行の払戻を的中した買い目に配る。同着やワイドで複数的中した場合は払戻結果のオッズの比で按分する
+func (n *narTicketService) allocatePayout(
+	ticket *ticket_csv_entity.Ticket,
+	betNumbers []types.BetNumber,
+	race *data_cache_entity.Race,
+) ([]types.Payout, bool) {
+	ticketType := ticket.TicketType().OriginTicketType()
+	ordered := ticketType == types.Exacta || ticketType == types.Trifecta
+
+	oddsList := make([]decimal.Decimal, len(betNumbers))
+	totalOdds := decimal.Zero
+	for _, payoutResult := range race.PayoutResults() {
+		if payoutResult.TicketType() != ticketType {
+			continue
+		}
+		for i, number := range payoutResult.Numbers() {
+			if i >= len(payoutResult.Odds()) {
+				break
+			}
+			odds, err := decimal.NewFromString(payoutResult.Odds()[i])
+			if err != nil {
+				continue
+			}
+			for idx, betNumber := range betNumbers {
+				if n.sameBetNumber(betNumber, number, ordered) {
+					oddsList[idx] = oddsList[idx].Add(odds)
+					totalOdds = totalOdds.Add(odds)
+				}
+			}
+		}
+	}
+	if !totalOdds.IsPositive() {
+		return nil, false
+	}
+
+	payouts := make([]types.Payout, len(betNumbers))
+	rest := ticket.Payout().Value()
+	lastIdx := -1
+	for idx, odds := range oddsList {
+		if !odds.IsPositive() {
+			continue
+		}
+		payout := int(decimal.NewFromInt(int64(ticket.Payout().Value())).Mul(odds).Div(totalOdds).IntPart())
+		payouts[idx] = types.Payout(payout)
+		rest -= payout
+		lastIdx = idx
+	}
+	// 按分の端数は最後に的中した買い目に寄せて行の払戻と合わせる
+	payouts[lastIdx] += types.Payout(rest)
+
+	return payouts, true
+}
+
+func (n *narTicketService) sameBetNumber(betNumber, payoutNumber types.BetNumber, ordered bool) bool {
+	numbers1, numbers2 := betNumber.List(), payoutNumber.List()
+	if len(numbers1) != len(numbers2) {
+		return false
+	}
+	if !ordered {
+		sort.Ints(numbers1)
+		sort.Ints(numbers2)
+	}
+	for i := range numbers1 {
+		if numbers1[i] != numbers2[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package master_service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func TestNarTicketSplit(t *testing.T) {
+	// 2-3-4で決着したレース
+	race := data_cache_entity.NewRace("2024440102", 20240102, 11, "44", "テスト", 1, "", "", "20:10", 12, 1600, 0, 0, 0, 0, 0, 0, 0, nil,
+		[]*data_cache_entity.PayoutResult{
+			data_cache_entity.NewPayoutResult(types.QuinellaPlace.Value(), []string{"02-03", "02-04", "03-04"}, []string{"3.0", "6.0", "9.0"}, []int{1, 3, 5}),
+			data_cache_entity.NewPayoutResult(types.Trio.Value(), []string{"02-03-04"}, []string{"12.3"}, []int{4}),
+		}, true)
+
+	newTicket := func(betNumber, ticketType, payment, payout string) *ticket_csv_entity.Ticket {
+		ticket, err := ticket_csv_entity.NewTicket(types.NewBetNumber(betNumber), "20240102", "大井", "11", ticketType, payout != "0", payment, payout, types.Spat4, types.DefaultAccount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ticket
+	}
+
+	type split struct {
+		betNumber types.BetNumber
+		payment   types.Payment
+		payout    types.Payout
+	}
+	tests := []struct {
+		name    string
+		ticket  *ticket_csv_entity.Ticket
+		race    *data_cache_entity.Race
+		want    []split
+		wantErr bool
+	}{
+		{
+			name:   "1点買いはそのまま",
+			ticket: newTicket("02-03-04", "3連複", "100", "1230"),
+			race:   race,
+			want:   []split{{betNumber: "02-03-04", payment: 100, payout: 1230}},
+		},
+		{
+			name:   "ボックスの払戻は的中した組番に付ける",
+			ticket: newTicket("01；02；03；04", "3連複ＢＯＸ", "400", "1230"),
+			race:   race,
+			want: []split{
+				{betNumber: "01-02-03", payment: 100},
+				{betNumber: "01-02-04", payment: 100},
+				{betNumber: "01-03-04", payment: 100},
+				{betNumber: "02-03-04", payment: 100, payout: 1230},
+			},
+		},
+		{
+			name:   "不的中はバラして払戻0",
+			ticket: newTicket("01／05；06", "馬連ながし", "200", "0"),
+			want: []split{
+				{betNumber: "01-05", payment: 100},
+				{betNumber: "01-06", payment: 100},
+			},
+		},
+		{
+			name:   "ワイドで複数的中した場合はオッズの比で按分",
+			ticket: newTicket("02／03；04；05", "ワイドながし", "300", "900"),
+			race:   race,
+			want: []split{
+				{betNumber: "02-03", payment: 100, payout: 300},
+				{betNumber: "02-04", payment: 100, payout: 600},
+				{betNumber: "02-05", payment: 100},
+			},
+		},
+		{
+			name:   "払戻結果が無ければまとめたまま",
+			ticket: newTicket("01；02；03；04", "3連複ＢＯＸ", "400", "1230"),
+			want:   []split{{betNumber: "01；02；03；04", payment: 400, payout: 1230}},
+		},
+		{
+			name:   "一致する組番が無ければまとめたまま",
+			ticket: newTicket("01；05；06", "3連複ＢＯＸ", "300", "1230"),
+			race:   race,
+			want:   []split{{betNumber: "01；05；06", payment: 300, payout: 1230}},
+		},
+		{
+			name:    "購入金額が点数で割り切れない",
+			ticket:  newTicket("01；02；03；04", "3連複ＢＯＸ", "450", "0"),
+			wantErr: true,
+		},
+	}
+
+	n := &narTicketService{betNumberConverter: NewBetNumberConverter()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tickets, err := n.split(context.Background(), tt.ticket, tt.race)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []split
+			for _, ticket := range tickets {
+				got = append(got, split{betNumber: ticket.BetNumber(), payment: ticket.Payment(), payout: ticket.Payout()})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("split() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -34,17 +34,20 @@ type PlaceOdds interface {
 type placeOddsService struct {
 	oddsRepository      repository.OddsRepository
 	oddsEntityConverter converter.OddsEntityConverter
+	checkpoint          Checkpoint
 	logger              *logrus.Logger
 }
 
 func NewPlaceOdds(
 	oddsRepository repository.OddsRepository,
 	oddsEntityConverter converter.OddsEntityConverter,
+	checkpoint Checkpoint,
 	logger *logrus.Logger,
 ) PlaceOdds {
 	return &placeOddsService{
 		oddsRepository:      oddsRepository,
 		oddsEntityConverter: oddsEntityConverter,
+		checkpoint:          checkpoint,
 		logger:              logger,
 	}
 }
@@ -78,9 +81,6 @@ func (p *placeOddsService) CreateOrUpdateV2(
 	odds []*data_cache_entity.Odds,
 	races []*data_cache_entity.Race,
 ) error {
-	taskCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
 	urls := p.createOddsUrlsV2(odds, races)
 	if len(urls) == 0 {
 		return nil
@@ -88,78 +88,45 @@ func (p *placeOddsService) CreateOrUpdateV2(
 
 	oddsMap := p.createOddsMap(odds)
 
-	var wg sync.WaitGroup
 	const workerParallel = 5
-	errorCh := make(chan error, 1)
-	chunkSize := (len(urls) + workerParallel - 1) / workerParallel
-
-	for i := 0; i < len(urls); i = i + chunkSize {
-		end := i + chunkSize
-		if end > len(urls) {
-			end = len(urls)
+	fetchedRaceOddsList, err := crawl(ctx, p.checkpoint, p.logger, placeOddsStage, urls, workerParallel, func(ctx context.Context, url string) (*raw_entity.RaceOdds, error) {
+		fetchOdds, err := p.oddsRepository.Fetch(ctx, url)
+		if err != nil {
+			return nil, err
 		}
 
-		wg.Add(1)
-		go func(splitUrls []string) {
-			defer wg.Done()
-			p.logger.Infof("place odds fetch processing: %v/%v", end, len(urls))
-			for _, url := range splitUrls {
-				time.Sleep(time.Millisecond)
-				select {
-				case <-taskCtx.Done():
-					return
-				default:
-					fetchOdds, err := p.oddsRepository.Fetch(taskCtx, url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-
-					raceId, err := p.parseUrl(url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-
-					var raceDate types.RaceDate
-					if len(fetchOdds) > 0 {
-						raceDate = fetchOdds[0].RaceDate()
-					}
+		raceId, err := p.parseUrl(url)
+		if err != nil {
+			return nil, err
+		}
 
-					newOdds := make([]*raw_entity.Odds, 0, len(fetchOdds))
-					for _, netKeibaFetchOdds := range fetchOdds {
-						newOdds = append(newOdds, p.oddsEntityConverter.NetKeibaToRaw(netKeibaFetchOdds))
-					}
+		var raceDate types.RaceDate
+		if len(fetchOdds) > 0 {
+			raceDate = fetchOdds[0].RaceDate()
+		}
 
-					if _, ok := oddsMap[raceDate]; !ok {
-						oddsMap[raceDate] = make([]*raw_entity.RaceOdds, 0)
-					}
+		newOdds := make([]*raw_entity.Odds, 0, len(fetchOdds))
+		for _, netKeibaFetchOdds := range fetchOdds {
+			newOdds = append(newOdds, p.oddsEntityConverter.NetKeibaToRaw(netKeibaFetchOdds))
+		}
 
-					sort.Slice(newOdds, func(i, j int) bool {
-						return newOdds[i].Popular < newOdds[j].Popular
-					})
+		sort.Slice(newOdds, func(i, j int) bool {
+			return newOdds[i].Popular < newOdds[j].Popular
+		})
 
-					oddsMap[raceDate] = append(oddsMap[raceDate], &raw_entity.RaceOdds{
-						RaceId:   raceId.String(),
-						RaceDate: raceDate.Value(),
-						Odds:     newOdds,
-					})
-				}
-			}
-		}(urls[i:end])
+		return &raw_entity.RaceOdds{
+			RaceId:   raceId.String(),
+			RaceDate: raceDate.Value(),
+			Odds:     newOdds,
+		}, nil
+	})
+	if err != nil {
+		return err
 	}
 
-	wg.Wait()
-	close(errorCh)
-
-	if err := <-errorCh; err != nil {
-		return err
+	for _, rawRaceOdds := range fetchedRaceOddsList {
+		raceDate := types.RaceDate(rawRaceOdds.RaceDate)
+		oddsMap[raceDate] = append(oddsMap[raceDate], rawRaceOdds)
 	}
 
 	for _, raceDate := range service.SortedRaceDateKeys(oddsMap) {
@@ -176,7 +143,7 @@ func (p *placeOddsService) CreateOrUpdateV2(
 		}
 	}
 
-	return nil
+	return p.checkpoint.Done(ctx, placeOddsStage, urls)
 }
 
 func (p *placeOddsService) CreateOrUpdate(
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	neturl "net/url"
 	"sort"
-	"sync"
-	"time"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
@@ -32,17 +30,20 @@ type QuinellaOdds interface {
 type quinellaOddsService struct {
 	oddsRepository      repository.OddsRepository
 	oddsEntityConverter converter.OddsEntityConverter
+	checkpoint          Checkpoint
 	logger              *logrus.Logger
 }
 
 func NewQuinellaOdds(
 	oddsRepository repository.OddsRepository,
 	oddsEntityConverter converter.OddsEntityConverter,
+	checkpoint Checkpoint,
 	logger *logrus.Logger,
 ) QuinellaOdds {
 	return &quinellaOddsService{
 		oddsRepository:      oddsRepository,
 		oddsEntityConverter: oddsEntityConverter,
+		checkpoint:          checkpoint,
 		logger:              logger,
 	}
 }
@@ -76,9 +77,6 @@ func (q *quinellaOddsService) CreateOrUpdateV2(
 	odds []*data_cache_entity.Odds,
 	races []*data_cache_entity.Race,
 ) error {
-	taskCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
 	urls := q.createOddsUrlsV2(odds, races)
 	if len(urls) == 0 {
 		return nil
@@ -86,80 +84,47 @@ func (q *quinellaOddsService) CreateOrUpdateV2(
 
 	oddsMap := q.createOddsMap(odds)
 
-	var wg sync.WaitGroup
 	const workerParallel = 5
-	errorCh := make(chan error, 1)
-	chunkSize := (len(urls) + workerParallel - 1) / workerParallel
+	fetchedRaceOddsList, err := crawl(ctx, q.checkpoint, q.logger, quinellaOddsStage, urls, workerParallel, func(ctx context.Context, url string) (*raw_entity.RaceOdds, error) {
+		fetchOdds, err := q.oddsRepository.Fetch(ctx, url)
+		if err != nil {
+			return nil, err
+		}
 
-	for i := 0; i < len(urls); i = i + chunkSize {
-		end := i + chunkSize
-		if end > len(urls) {
-			end = len(urls)
+		raceId, err := q.parseUrl(url)
+		if err != nil {
+			return nil, err
 		}
 
-		wg.Add(1)
-		go func(splitUrls []string) {
-			defer wg.Done()
-			q.logger.Infof("quinella odds fetch processing: %v/%v", end, len(urls))
-			for _, url := range splitUrls {
-				time.Sleep(time.Millisecond)
-				select {
-				case <-taskCtx.Done():
-					return
-				default:
-					fetchOdds, err := q.oddsRepository.Fetch(taskCtx, url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-
-					raceId, err := q.parseUrl(url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-
-					var raceDate types.RaceDate
-					if len(fetchOdds) > 0 {
-						raceDate = fetchOdds[0].RaceDate()
-					}
-
-					newOdds := make([]*raw_entity.Odds, 0, len(fetchOdds))
-					for _, netKeibaFetchOdds := range fetchOdds {
-						newOdds = append(newOdds, q.oddsEntityConverter.NetKeibaToRaw(netKeibaFetchOdds))
-					}
-
-					if _, ok := oddsMap[raceDate]; !ok {
-						oddsMap[raceDate] = make([]*raw_entity.RaceOdds, 0)
-					}
-
-					sort.Slice(newOdds, func(i, j int) bool {
-						return newOdds[i].Popular < newOdds[j].Popular
-					})
-
-					oddsMap[raceDate] = append(oddsMap[raceDate], &raw_entity.RaceOdds{
-						RaceId:   raceId.String(),
-						RaceDate: raceDate.Value(),
-						Odds:     newOdds,
-					})
-				}
-			}
-		}(urls[i:end])
-	}
+		var raceDate types.RaceDate
+		if len(fetchOdds) > 0 {
+			raceDate = fetchOdds[0].RaceDate()
+		}
 
-	wg.Wait()
-	close(errorCh)
+		newOdds := make([]*raw_entity.Odds, 0, len(fetchOdds))
+		for _, netKeibaFetchOdds := range fetchOdds {
+			newOdds = append(newOdds, q.oddsEntityConverter.NetKeibaToRaw(netKeibaFetchOdds))
+		}
 
-	if err := <-errorCh; err != nil {
+		sort.Slice(newOdds, func(i, j int) bool {
+			return newOdds[i].Popular < newOdds[j].Popular
+		})
+
+		return &raw_entity.RaceOdds{
+			RaceId:   raceId.String(),
+			RaceDate: raceDate.Value(),
+			Odds:     newOdds,
+		}, nil
+	})
+	if err != nil {
 		return err
 	}
 
+	for _, rawRaceOdds := range fetchedRaceOddsList {
+		raceDate := types.RaceDate(rawRaceOdds.RaceDate)
+		oddsMap[raceDate] = append(oddsMap[raceDate], rawRaceOdds)
+	}
+
 	for _, raceDate := range service.SortedRaceDateKeys(oddsMap) {
 		rawRaceOddsList := oddsMap[raceDate]
 		sort.Slice(rawRaceOddsList, func(i, j int) bool {
@@ -174,7 +139,7 @@ func (q *quinellaOddsService) CreateOrUpdateV2(
 		}
 	}
 
-	return nil
+	return q.checkpoint.Done(ctx, quinellaOddsStage, urls)
 }
 
 func (q *quinellaOddsService) createOddsUrlsV2(
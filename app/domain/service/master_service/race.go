@@ -4,11 +4,8 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"sync"
-	"time"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
-	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
@@ -36,17 +33,20 @@ type Race interface {
 type raceService struct {
 	raceRepository      repository.RaceRepository
 	raceEntityConverter converter.RaceEntityConverter
+	checkpoint          Checkpoint
 	logger              *logrus.Logger
 }
 
 func NewRace(
 	raceRepository repository.RaceRepository,
 	raceEntityConverter converter.RaceEntityConverter,
+	checkpoint Checkpoint,
 	logger *logrus.Logger,
 ) Race {
 	return &raceService{
 		raceRepository:      raceRepository,
 		raceEntityConverter: raceEntityConverter,
+		checkpoint:          checkpoint,
 		logger:              logger,
 	}
 }
@@ -76,70 +76,48 @@ func (r *raceService) CreateOrUpdate(
 	races []*data_cache_entity.Race,
 	raceDateMap map[types.RaceDate][]types.RaceId,
 ) error {
-	taskCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
 	urls := r.createRaceUrls(races, raceDateMap)
 	if len(urls) == 0 {
 		return nil
 	}
 
-	var wg sync.WaitGroup
-	const raceIdParallel = 5
-	errorCh := make(chan error, 1)
-	resultCh := make(chan []*netkeiba_entity.Race, raceIdParallel)
-	chunkSize := (len(urls) + raceIdParallel - 1) / raceIdParallel
-
-	for i := 0; i < len(urls); i += chunkSize {
-		end := i + chunkSize
-		if end > len(urls) {
-			end = len(urls)
+	const raceParallel = 5
+	rawRaces, err := crawl(ctx, r.checkpoint, r.logger, raceStage, urls, raceParallel, func(ctx context.Context, url string) (*raw_entity.Race, error) {
+		race, err := r.raceRepository.FetchRace(ctx, url)
+		if err != nil {
+			return nil, err
 		}
-
-		wg.Add(1)
-		go func(splitUrls []string) {
-			defer wg.Done()
-			localRaces := make([]*netkeiba_entity.Race, 0, len(splitUrls))
-			r.logger.Infof("race fetch processing: %v/%v", end, len(urls))
-			for _, url := range splitUrls {
-				time.Sleep(time.Millisecond)
-				select {
-				case <-taskCtx.Done():
-					return
-				default:
-					race, err := r.raceRepository.FetchRace(taskCtx, url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-					localRaces = append(localRaces, race)
-				}
-			}
-
-			resultCh <- localRaces
-		}(urls[i:end])
+		return r.raceEntityConverter.NetKeibaToRaw(race), nil
+	})
+	if err != nil {
+		return err
 	}
 
-	wg.Wait()
-	close(errorCh)
-	close(resultCh)
-
-	if err := <-errorCh; err != nil {
-		return err
+	raceMap := map[types.RaceDate]map[types.RaceId]*raw_entity.Race{}
+	for _, rawRace := range rawRaces {
+		raceDate := types.RaceDate(rawRace.RaceDate)
+		if _, ok := raceMap[raceDate]; !ok {
+			raceMap[raceDate] = map[types.RaceId]*raw_entity.Race{}
+		}
+		raceMap[raceDate][types.RaceId(rawRace.RaceId)] = rawRace
 	}
 
-	raceMap := map[types.RaceDate][]*raw_entity.Race{}
-	for results := range resultCh {
-		for _, race := range results {
-			rawRace := r.raceEntityConverter.NetKeibaToRaw(race)
-			raceMap[types.RaceDate(rawRace.RaceDate)] = append(raceMap[types.RaceDate(rawRace.RaceDate)], rawRace)
+	// 同日の取得済みレースが消えないように、キャッシュ済みのレースとマージして書き込む
+	for _, race := range races {
+		dateRaceMap, ok := raceMap[race.RaceDate()]
+		if !ok {
+			continue
+		}
+		if _, ok := dateRaceMap[race.RaceId()]; !ok {
+			dateRaceMap[race.RaceId()] = r.raceEntityConverter.DataCacheToRaw(race)
 		}
 	}
 
-	for raceDate, rawRaces := range raceMap {
+	for raceDate, dateRaceMap := range raceMap {
+		rawRaces := make([]*raw_entity.Race, 0, len(dateRaceMap))
+		for _, rawRace := range dateRaceMap {
+			rawRaces = append(rawRaces, rawRace)
+		}
 		sort.Slice(rawRaces, func(i, j int) bool {
 			return rawRaces[i].RaceId < rawRaces[j].RaceId
 		})
@@ -152,7 +130,7 @@ func (r *raceService) CreateOrUpdate(
 		}
 	}
 
-	return nil
+	return r.checkpoint.Done(ctx, raceStage, urls)
 }
 
 func (r *raceService) createRaceUrls(
@@ -174,19 +152,43 @@ func (r *raceService) createRaceUrls(
 	}
 
 	for _, raceId := range converter.SortedRaceIdKeys(raceIdMap) {
-		if _, ok := raceMap[raceId]; !ok {
-			runes := []rune(raceId.String())
-			rawRaceCourseId := string(runes[4:6])
-			raceCourse := types.RaceCourse(rawRaceCourseId)
-			if raceCourse.JRA() {
-				raceUrls = append(raceUrls, fmt.Sprintf(raceResultUrlForJRA, raceId, raceIdMap[raceId]))
-			} else if raceCourse.NAR() {
-				raceUrls = append(raceUrls, fmt.Sprintf(raceResultUrlForNAR, raceId, raceIdMap[raceId]))
-			} else if raceCourse.Oversea() {
-				raceUrls = append(raceUrls, fmt.Sprintf(raceResultUrlForOversea, raceId, raceIdMap[raceId]))
+		if race, ok := raceMap[raceId]; !ok || r.isOutdated(race) {
+			if url := raceResultUrl(raceId, raceIdMap[raceId]); url != "" {
+				raceUrls = append(raceUrls, url)
 			}
 		}
 	}
 
 	return raceUrls
 }
+
+// raceResultUrl 開催場所から主催者を判定してレース結果のURLを返す。判定できない場合は空文字を返す
+func raceResultUrl(raceId types.RaceId, raceDate types.RaceDate) string {
+	runes := []rune(raceId.String())
+	rawRaceCourseId := string(runes[4:6])
+	raceCourse := types.RaceCourse(rawRaceCourseId)
+	if raceCourse.JRA() {
+		return fmt.Sprintf(raceResultUrlForJRA, raceId, raceDate)
+	} else if raceCourse.NAR() {
+		return fmt.Sprintf(raceResultUrlForNAR, raceId, raceDate)
+	} else if raceCourse.Oversea() {
+		return fmt.Sprintf(raceResultUrlForOversea, raceId, raceDate)
+	}
+
+	return ""
+}
+
+// isOutdated 通過順、上り3F、着差、調教師IDを保存する前のキャッシュかどうかを判定する
+// 該当するレースは再取得してキャッシュを移行する。再取得したレースは取得済みになるので調教師IDが取れなくても繰り返し取得しない
+func (r *raceService) isOutdated(race *data_cache_entity.Race) bool {
+	if race.TrainerFetched() || race.Organizer() == types.OverseaOrganizer || len(race.RaceResults()) == 0 {
+		return false
+	}
+	for _, raceResult := range race.RaceResults() {
+		if raceResult.TrainerId() != "" {
+			return false
+		}
+	}
+
+	return true
+}
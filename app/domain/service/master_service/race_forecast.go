@@ -6,6 +6,7 @@ import (
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/tospo_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
@@ -13,11 +14,18 @@ import (
 	"github.com/mapserver2007/ipat-aggregator/config"
 )
 
-const raceForecastFileName = "race_forecast.json"
+const (
+	raceForecastFileName   = "race_forecast.json"
+	raceForecastUrl        = "https://tospo-keiba.jp/race/detail/%s/card"
+	raceTrainingCommentUrl = "https://tospo-keiba.jp/race/detail/%s/comment"
+	raceReporterMemoUrl    = "https://tospo-keiba.jp/race/detail/%s/reporter-memo"
+	racePaddockCommentUrl  = "https://tospo-keiba.jp/race/detail/%s/card"
+)
 
 type RaceForecast interface {
 	Get(ctx context.Context) ([]*data_cache_entity.RaceForecast, error)
 	CreateOrUpdate(ctx context.Context, raceForecasts []*data_cache_entity.RaceForecast) error
+	Fetch(ctx context.Context, raceId types.RaceId, raceDate types.RaceDate) (*data_cache_entity.RaceForecast, error)
 }
 
 type raceForecastService struct {
@@ -88,3 +96,63 @@ func (r *raceForecastService) CreateOrUpdate(
 
 	return nil
 }
+
+// Fetch 予想、調教コメント、記者メモ、パドック情報をまとめて取得する
+func (r *raceForecastService) Fetch(
+	ctx context.Context,
+	raceId types.RaceId,
+	raceDate types.RaceDate,
+) (*data_cache_entity.RaceForecast, error) {
+	rawRaceForecasts, err := r.raceForecastRepository.FetchRaceForecast(ctx, fmt.Sprintf(raceForecastUrl, raceId))
+	if err != nil {
+		return nil, err
+	}
+
+	rawTrainingComments, err := r.raceForecastRepository.FetchTrainingComment(ctx, fmt.Sprintf(raceTrainingCommentUrl, raceId))
+	if err != nil {
+		return nil, err
+	}
+
+	rawReporterMemos, err := r.raceForecastRepository.FetchReporterMemo(ctx, fmt.Sprintf(raceReporterMemoUrl, raceId))
+	if err != nil {
+		return nil, err
+	}
+
+	rawPaddockComments, err := r.raceForecastRepository.FetchPaddockComment(ctx, fmt.Sprintf(racePaddockCommentUrl, raceId))
+	if err != nil {
+		return nil, err
+	}
+
+	trainingCommentMap := converter.ConvertToMap(rawTrainingComments, func(trainingComment *tospo_entity.TrainingComment) types.HorseNumber {
+		return trainingComment.HorseNumber()
+	})
+	paddockCommentMap := converter.ConvertToMap(rawPaddockComments, func(paddockComment *tospo_entity.PaddockComment) types.HorseNumber {
+		return paddockComment.HorseNumber()
+	})
+	reporterMemoMap := map[types.HorseNumber][]*tospo_entity.Memo{}
+	for _, reporterMemo := range rawReporterMemos {
+		reporterMemoMap[reporterMemo.HorseNumber()] = reporterMemo.Memos()
+	}
+
+	forecasts := make([]*data_cache_entity.Forecast, 0, len(rawRaceForecasts))
+	for _, rawRaceForecast := range rawRaceForecasts {
+		horseNumber := rawRaceForecast.HorseNumber()
+		trainingComment, ok := trainingCommentMap[horseNumber]
+		if !ok {
+			trainingComment = tospo_entity.NewTrainingComment(horseNumber.Value(), "", "", "")
+		}
+		forecasts = append(forecasts, r.raceForecastEntityConverter.TospoToDataCache(
+			rawRaceForecast,
+			trainingComment,
+			reporterMemoMap[horseNumber],
+			paddockCommentMap[horseNumber],
+		))
+	}
+
+	return data_cache_entity.NewRaceForecast(
+		raceId.String(),
+		raceDate.Value(),
+		forecasts,
+		true,
+	), nil
+}
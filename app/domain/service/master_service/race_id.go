@@ -5,7 +5,6 @@ import (
 	"fmt"
 	net_url "net/url"
 	"sort"
-	"sync"
 	"time"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
@@ -36,15 +35,18 @@ type RaceId interface {
 
 type raceIdService struct {
 	raceIdRepository repository.RaceIdRepository
+	checkpoint       Checkpoint
 	logger           *logrus.Logger
 }
 
 func NewRaceId(
 	raceIdRepository repository.RaceIdRepository,
+	checkpoint Checkpoint,
 	logger *logrus.Logger,
 ) RaceId {
 	return &raceIdService{
 		raceIdRepository: raceIdRepository,
+		checkpoint:       checkpoint,
 		logger:           logger,
 	}
 }
@@ -88,9 +90,6 @@ func (r *raceIdService) CreateOrUpdate(
 	ctx context.Context,
 	startDate, endDate types.RaceDate,
 ) error {
-	taskCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
 	newRawRaceDates := make([]*raw_entity.RaceDate, 0)
 	newRawExcludeDates := make([]int, 0)
 
@@ -123,90 +122,35 @@ func (r *raceIdService) CreateOrUpdate(
 		newRawExcludeDates = append(newRawExcludeDates, excludeDate.Value())
 	}
 
-	var wg sync.WaitGroup
 	const raceIdParallel = 5
-	errorCh := make(chan error, 1)
-	resultCh1 := make(chan []*raw_entity.RaceDate, raceIdParallel)
-	resultCh2 := make(chan []int, raceIdParallel)
-	chunkSize := (len(urls) + raceIdParallel - 1) / raceIdParallel
-
-	for i := 0; i < len(urls); i += chunkSize {
-		end := i + chunkSize
-		if end > len(urls) {
-			end = len(urls)
+	rawRaceDates, err := crawl(ctx, r.checkpoint, r.logger, raceIdStage, urls, raceIdParallel, func(ctx context.Context, url string) (*raw_entity.RaceDate, error) {
+		u, err := net_url.Parse(url)
+		if err != nil {
+			return nil, err
 		}
-
-		wg.Add(1)
-		go func(splitUrls []string) {
-			defer wg.Done()
-			localNewRawRaceDates := make([]*raw_entity.RaceDate, 0)
-			localNewRawExcludeDates := make([]int, 0)
-
-			r.logger.Infof("raceId fetch processing: %v/%v", end, len(urls))
-			for _, url := range splitUrls {
-				time.Sleep(time.Millisecond)
-				select {
-				case <-taskCtx.Done():
-					return
-				default:
-					u, err := net_url.Parse(url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-					date, err := types.NewRaceDate(u.Query().Get("kaisai_date"))
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-
-					rawRaceIds, err := r.raceIdRepository.Fetch(taskCtx, url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-
-					if len(rawRaceIds) == 0 {
-						localNewRawExcludeDates = append(localNewRawExcludeDates, date.Value())
-					} else {
-						rawRaceDate := raw_entity.RaceDate{
-							RaceDate: date.Value(),
-							RaceIds:  rawRaceIds,
-						}
-						localNewRawRaceDates = append(localNewRawRaceDates, &rawRaceDate)
-					}
-				}
-			}
-
-			resultCh1 <- localNewRawRaceDates
-			resultCh2 <- localNewRawExcludeDates
-		}(urls[i:end])
-	}
-
-	wg.Wait()
-	close(errorCh)
-	close(resultCh1)
-	close(resultCh2)
-
-	if err := <-errorCh; err != nil {
+		date, err := types.NewRaceDate(u.Query().Get("kaisai_date"))
+		if err != nil {
+			return nil, err
+		}
+		rawRaceIds, err := r.raceIdRepository.Fetch(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		return &raw_entity.RaceDate{
+			RaceDate: date.Value(),
+			RaceIds:  rawRaceIds,
+		}, nil
+	})
+	if err != nil {
 		return err
 	}
 
-	for results := range resultCh1 {
-		newRawRaceDates = append(newRawRaceDates, results...)
-	}
-
-	for results := range resultCh2 {
-		newRawExcludeDates = append(newRawExcludeDates, results...)
+	for _, rawRaceDate := range rawRaceDates {
+		if len(rawRaceDate.RaceIds) == 0 {
+			newRawExcludeDates = append(newRawExcludeDates, rawRaceDate.RaceDate)
+		} else {
+			newRawRaceDates = append(newRawRaceDates, rawRaceDate)
+		}
 	}
 
 	sort.Slice(newRawRaceDates, func(i, j int) bool {
@@ -223,7 +167,7 @@ func (r *raceIdService) CreateOrUpdate(
 		return err
 	}
 
-	return nil
+	return r.checkpoint.Done(ctx, raceIdStage, urls)
 }
 
 func (r *raceIdService) Update(ctx context.Context, raceDateMapForNAROrOversea map[types.RaceDate][]types.RaceId) error {
@@ -4,11 +4,8 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"sync"
-	"time"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
-	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
@@ -35,17 +32,20 @@ type RaceTime interface {
 type raceTimeService struct {
 	raceTimeRepository      repository.RaceTimeRepository
 	raceTimeEntityConverter converter.RaceTimeEntityConverter
+	checkpoint              Checkpoint
 	logger                  *logrus.Logger
 }
 
 func NewRaceTime(
 	raceTimeRepository repository.RaceTimeRepository,
 	raceTimeEntityConverter converter.RaceTimeEntityConverter,
+	checkpoint Checkpoint,
 	logger *logrus.Logger,
 ) RaceTime {
 	return &raceTimeService{
 		raceTimeRepository:      raceTimeRepository,
 		raceTimeEntityConverter: raceTimeEntityConverter,
+		checkpoint:              checkpoint,
 		logger:                  logger,
 	}
 }
@@ -78,9 +78,6 @@ func (r *raceTimeService) CreateOrUpdate(
 	races []*data_cache_entity.Race,
 	raceDateMap map[types.RaceDate][]types.RaceId,
 ) error {
-	taskCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
 	// 除外レース
 	excludeRaceIdMap := make(map[types.RaceId]struct{})
 	for _, race := range races {
@@ -95,75 +92,65 @@ func (r *raceTimeService) CreateOrUpdate(
 		return nil
 	}
 
-	var wg sync.WaitGroup
-	const raceIdParallel = 5
-	errorCh := make(chan error, 1)
-	resultCh := make(chan []*netkeiba_entity.RaceTime, raceIdParallel)
-	chunkSize := (len(urls) + raceIdParallel - 1) / raceIdParallel
-
-	for i := 0; i < len(urls); i += chunkSize {
-		end := i + chunkSize
-		if end > len(urls) {
-			end = len(urls)
+	const raceTimeParallel = 5
+	rawRaceTimes, err := crawl(ctx, r.checkpoint, r.logger, raceTimeStage, urls, raceTimeParallel, func(ctx context.Context, url string) (*raw_entity.RaceTime, error) {
+		raceTime, err := r.raceTimeRepository.Fetch(ctx, url)
+		if err != nil {
+			return nil, err
 		}
-
-		wg.Add(1)
-		go func(splitUrls []string) {
-			defer wg.Done()
-			localRaceTimes := make([]*netkeiba_entity.RaceTime, 0, len(splitUrls))
-			r.logger.Infof("race time fetch processing: %v/%v", end, len(urls))
-			for _, url := range splitUrls {
-				time.Sleep(time.Millisecond)
-				select {
-				case <-taskCtx.Done():
-					return
-				default:
-					raceTime, err := r.raceTimeRepository.Fetch(taskCtx, url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-					localRaceTimes = append(localRaceTimes, raceTime)
-				}
-			}
-
-			resultCh <- localRaceTimes
-		}(urls[i:end])
-	}
-
-	wg.Wait()
-	close(errorCh)
-	close(resultCh)
-
-	if err := <-errorCh; err != nil {
+		return r.raceTimeEntityConverter.NetKeibaToRaw(raceTime), nil
+	})
+	if err != nil {
 		return err
 	}
 
 	raceTimeMap := map[types.RaceDate][]*raw_entity.RaceTime{}
-	for results := range resultCh {
-		for _, raceTime := range results {
-			rawRaceTime := r.raceTimeEntityConverter.NetKeibaToRaw(raceTime)
-			raceTimeMap[types.RaceDate(rawRaceTime.RaceDate)] = append(raceTimeMap[types.RaceDate(rawRaceTime.RaceDate)], rawRaceTime)
-		}
+	for _, rawRaceTime := range rawRaceTimes {
+		raceTimeMap[types.RaceDate(rawRaceTime.RaceDate)] = append(raceTimeMap[types.RaceDate(rawRaceTime.RaceDate)], rawRaceTime)
 	}
 
-	for raceDate, rawRaceTimes := range raceTimeMap {
-		sort.Slice(rawRaceTimes, func(i, j int) bool {
-			return rawRaceTimes[i].RaceId < rawRaceTimes[j].RaceId
-		})
+	for raceDate, fetchedRaceTimes := range raceTimeMap {
+		path := fmt.Sprintf("%s/race_times/%s", config.CacheDir, fmt.Sprintf(raceTimeFileName, raceDate.Value()))
+		// 失敗したURLだけを再取得した場合でも、同じ日の取得済みのタイムを消さないようにマージする
+		cachedRaceTimes, err := r.raceTimeRepository.Read(ctx, path)
+		if err != nil {
+			return err
+		}
+		rawRaceTimes := r.mergeRaceTimes(cachedRaceTimes, fetchedRaceTimes)
 		raceTimeInfo := raw_entity.RaceTimeInfo{
 			RaceTimes: rawRaceTimes,
 		}
-		err := r.raceTimeRepository.Write(ctx, fmt.Sprintf("%s/race_times/%s", config.CacheDir, fmt.Sprintf(raceTimeFileName, raceDate.Value())), &raceTimeInfo)
+		err = r.raceTimeRepository.Write(ctx, path, &raceTimeInfo)
 		if err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return r.checkpoint.Done(ctx, raceTimeStage, urls)
+}
+
+// mergeRaceTimes 同じレースは今回取得した方を使う
+func (r *raceTimeService) mergeRaceTimes(
+	cachedRaceTimes []*raw_entity.RaceTime,
+	fetchedRaceTimes []*raw_entity.RaceTime,
+) []*raw_entity.RaceTime {
+	raceTimeMap := make(map[string]*raw_entity.RaceTime, len(cachedRaceTimes)+len(fetchedRaceTimes))
+	for _, raceTime := range cachedRaceTimes {
+		raceTimeMap[raceTime.RaceId] = raceTime
+	}
+	for _, raceTime := range fetchedRaceTimes {
+		raceTimeMap[raceTime.RaceId] = raceTime
+	}
+
+	rawRaceTimes := make([]*raw_entity.RaceTime, 0, len(raceTimeMap))
+	for _, raceTime := range raceTimeMap {
+		rawRaceTimes = append(rawRaceTimes, raceTime)
+	}
+	sort.Slice(rawRaceTimes, func(i, j int) bool {
+		return rawRaceTimes[i].RaceId < rawRaceTimes[j].RaceId
+	})
+
+	return rawRaceTimes
 }
 
 func (r *raceTimeService) createRaceTimeUrls(
@@ -31,7 +31,7 @@ func (t *ticketService) Get(
 	ctx context.Context,
 	races []*data_cache_entity.Race,
 ) ([]*ticket_csv_entity.RaceTicket, error) {
-	files, err := t.ticketRepository.List(ctx, config.CsvDir)
+	accountDirs, err := getAccountDirs(ctx, t.ticketRepository)
 	if err != nil {
 		return nil, err
 	}
@@ -45,50 +45,56 @@ func (t *ticketService) Get(
 	}
 
 	raceTickets := make([]*ticket_csv_entity.RaceTicket, 0)
-	for _, file := range files {
-		tickets, err := t.ticketRepository.Read(ctx, fmt.Sprintf("%s/%s", config.CsvDir, file))
+	for _, accountDir := range accountDirs {
+		files, err := t.ticketRepository.List(ctx, accountDir.dir)
 		if err != nil {
 			return nil, err
 		}
-		for _, ticket := range tickets {
-			if ticket.RaceCourse().NAR() {
-				// NAR,海外はraceデータをキャッシュしてないのでraceIdを自力で構築する
-				raceId := types.NewRaceIdForNAR(
-					ticket.RaceDate().Year(),
-					ticket.RaceDate().Month(),
-					ticket.RaceDate().Day(),
-					ticket.RaceCourse().Value(),
-					ticket.RaceNo(),
-				)
-				raceTickets = append(raceTickets, ticket_csv_entity.NewRaceTicket(
-					raceId,
-					ticket,
-				))
-			} else if ticket.RaceCourse().Oversea() {
-				// NAR,海外はraceデータをキャッシュしてないのでraceIdを自力で構築する
-				raceId := types.NewRaceIdForOverseas(
-					ticket.RaceDate().Year(),
-					ticket.RaceDate().Month(),
-					ticket.RaceDate().Day(),
-					ticket.RaceCourse().Value(),
-					ticket.RaceNo(),
-				)
-				raceTickets = append(raceTickets, ticket_csv_entity.NewRaceTicket(
-					raceId,
-					ticket,
-				))
-			} else if ticket.RaceCourse().JRA() {
-				raceDateRaces, ok := raceDateMap[ticket.RaceDate()]
-				if !ok {
-					continue
-				}
-				for _, race := range raceDateRaces {
-					// racingNumberのように完全に紐付けることはできないので、レースNo、開催場所から特定する
-					if race.RaceNumber() == ticket.RaceNo() && race.RaceCourseId() == ticket.RaceCourse() {
-						raceTickets = append(raceTickets, ticket_csv_entity.NewRaceTicket(
-							race.RaceId(),
-							ticket,
-						))
+		for _, file := range files {
+			tickets, err := t.ticketRepository.Read(ctx, fmt.Sprintf("%s/%s", accountDir.dir, file), accountDir.account)
+			if err != nil {
+				return nil, err
+			}
+			for _, ticket := range tickets {
+				if ticket.RaceCourse().NAR() {
+					// NAR,海外はraceデータをキャッシュしてないのでraceIdを自力で構築する
+					raceId := types.NewRaceIdForNAR(
+						ticket.RaceDate().Year(),
+						ticket.RaceDate().Month(),
+						ticket.RaceDate().Day(),
+						ticket.RaceCourse().Value(),
+						ticket.RaceNo(),
+					)
+					raceTickets = append(raceTickets, ticket_csv_entity.NewRaceTicket(
+						raceId,
+						ticket,
+					))
+				} else if ticket.RaceCourse().Oversea() {
+					// NAR,海外はraceデータをキャッシュしてないのでraceIdを自力で構築する
+					raceId := types.NewRaceIdForOverseas(
+						ticket.RaceDate().Year(),
+						ticket.RaceDate().Month(),
+						ticket.RaceDate().Day(),
+						ticket.RaceCourse().Value(),
+						ticket.RaceNo(),
+					)
+					raceTickets = append(raceTickets, ticket_csv_entity.NewRaceTicket(
+						raceId,
+						ticket,
+					))
+				} else if ticket.RaceCourse().JRA() {
+					raceDateRaces, ok := raceDateMap[ticket.RaceDate()]
+					if !ok {
+						continue
+					}
+					for _, race := range raceDateRaces {
+						// racingNumberのように完全に紐付けることはできないので、レースNo、開催場所から特定する
+						if race.RaceNumber() == ticket.RaceNo() && race.RaceCourseId() == ticket.RaceCourse() {
+							raceTickets = append(raceTickets, ticket_csv_entity.NewRaceTicket(
+								race.RaceId(),
+								ticket,
+							))
+						}
 					}
 				}
 			}
@@ -97,3 +103,33 @@ func (t *ticketService) Get(
 
 	return raceTickets, nil
 }
+
+type accountDir struct {
+	account types.Account
+	dir     string
+}
+
+// getAccountDirs csv直下はdefaultアカウント、csv配下のディレクトリはディレクトリ名をアカウントとして扱う
+func getAccountDirs(
+	ctx context.Context,
+	ticketRepository repository.TicketRepository,
+) ([]*accountDir, error) {
+	accounts, err := ticketRepository.ListAccounts(ctx, config.CsvDir)
+	if err != nil {
+		return nil, err
+	}
+
+	accountDirs := make([]*accountDir, 0, len(accounts)+1)
+	accountDirs = append(accountDirs, &accountDir{
+		account: types.DefaultAccount,
+		dir:     config.CsvDir,
+	})
+	for _, account := range accounts {
+		accountDirs = append(accountDirs, &accountDir{
+			account: account,
+			dir:     fmt.Sprintf("%s/%s", config.CsvDir, account.Value()),
+		})
+	}
+
+	return accountDirs, nil
+}
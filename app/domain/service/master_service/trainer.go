@@ -0,0 +1,130 @@
+package master_service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	trainerUrl      = "https://db.netkeiba.com/trainer/%s/"
+	trainerFileName = "trainer.json"
+)
+
+type Trainer interface {
+	Get(ctx context.Context) ([]*data_cache_entity.Trainer, error)
+	CreateOrUpdate(ctx context.Context, trainers []*data_cache_entity.Trainer, races []*data_cache_entity.Race) error
+}
+
+type trainerService struct {
+	trainerRepository      repository.TrainerRepository
+	trainerEntityConverter converter.TrainerEntityConverter
+	checkpoint             Checkpoint
+	logger                 *logrus.Logger
+}
+
+func NewTrainer(
+	trainerRepository repository.TrainerRepository,
+	trainerEntityConverter converter.TrainerEntityConverter,
+	checkpoint Checkpoint,
+	logger *logrus.Logger,
+) Trainer {
+	return &trainerService{
+		trainerRepository:      trainerRepository,
+		trainerEntityConverter: trainerEntityConverter,
+		checkpoint:             checkpoint,
+		logger:                 logger,
+	}
+}
+
+func (t *trainerService) Get(ctx context.Context) ([]*data_cache_entity.Trainer, error) {
+	rawTrainerInfo, err := t.trainerRepository.Read(ctx, fmt.Sprintf("%s/%s", config.CacheDir, trainerFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var trainers []*data_cache_entity.Trainer
+	if rawTrainerInfo != nil {
+		for _, rawTrainer := range rawTrainerInfo.Trainers {
+			trainers = append(trainers, t.trainerEntityConverter.RawToDataCache(rawTrainer))
+		}
+	}
+
+	return trainers, nil
+}
+
+func (t *trainerService) CreateOrUpdate(
+	ctx context.Context,
+	trainers []*data_cache_entity.Trainer,
+	races []*data_cache_entity.Race,
+) error {
+	urls := t.createTrainerUrls(trainers, races)
+	if len(urls) == 0 {
+		return nil
+	}
+
+	const trainerParallel = 10
+	rawTrainers, err := crawl(ctx, t.checkpoint, t.logger, trainerStage, urls, trainerParallel, func(ctx context.Context, url string) (*raw_entity.Trainer, error) {
+		trainer, err := t.trainerRepository.Fetch(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		return t.trainerEntityConverter.NetKeibaToRaw(trainer), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, trainer := range trainers {
+		rawTrainers = append(rawTrainers, t.trainerEntityConverter.DataCacheToRaw(trainer))
+	}
+
+	sort.Slice(rawTrainers, func(i, j int) bool {
+		return rawTrainers[i].TrainerId < rawTrainers[j].TrainerId
+	})
+
+	err = t.trainerRepository.Write(ctx, fmt.Sprintf("%s/%s", config.CacheDir, trainerFileName), &raw_entity.TrainerInfo{
+		Trainers: rawTrainers,
+	})
+	if err != nil {
+		return err
+	}
+
+	return t.checkpoint.Done(ctx, trainerStage, urls)
+}
+
+func (t *trainerService) createTrainerUrls(
+	trainers []*data_cache_entity.Trainer,
+	races []*data_cache_entity.Race,
+) []string {
+	trainersMap := map[types.TrainerId]bool{}
+	for _, trainer := range trainers {
+		trainersMap[trainer.TrainerId()] = true
+	}
+
+	// レース結果に出現した調教師のうち未取得のものだけ取得する
+	var urls []string
+	for _, race := range races {
+		for _, raceResult := range race.RaceResults() {
+			trainerId := raceResult.TrainerId()
+			if trainerId == "" {
+				continue
+			}
+			if _, ok := trainersMap[trainerId]; ok {
+				continue
+			}
+			trainersMap[trainerId] = true
+			urls = append(urls, fmt.Sprintf(trainerUrl, trainerId.Value()))
+		}
+	}
+
+	return urls
+}
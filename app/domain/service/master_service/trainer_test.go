@@ -0,0 +1,60 @@
+package master_service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+)
+
+func TestTrainerCreateTrainerUrls(t *testing.T) {
+	newRace := func(raceId string, trainerIds ...string) *data_cache_entity.Race {
+		raceResults := make([]*data_cache_entity.RaceResult, 0, len(trainerIds))
+		for i, trainerId := range trainerIds {
+			raceResults = append(raceResults, data_cache_entity.NewRaceResult(i+1, "", "", 1, i+1, "", "2.0", i+1, "", 0, 0, "", "", "", trainerId, 0))
+		}
+		return data_cache_entity.NewRace(raceId, 20241020, 11, "05", "テスト", 1, "", "", "15:40", len(trainerIds), 1600, 0, 0, 0, 0, 0, 0, 0, raceResults, nil, true)
+	}
+	races := []*data_cache_entity.Race{
+		newRace("202405040811", "01001", "01002", ""),
+		newRace("202405040812", "01002", "01003"),
+	}
+
+	tests := []struct {
+		name     string
+		trainers []*data_cache_entity.Trainer
+		races    []*data_cache_entity.Race
+		want     []string
+	}{
+		{
+			name: "レースなし",
+		},
+		{
+			name:  "出現順に重複なく、調教師IDの無い馬は除く",
+			races: races,
+			want: []string{
+				"https://db.netkeiba.com/trainer/01001/",
+				"https://db.netkeiba.com/trainer/01002/",
+				"https://db.netkeiba.com/trainer/01003/",
+			},
+		},
+		{
+			name:     "キャッシュ済みの調教師は取得しない",
+			trainers: []*data_cache_entity.Trainer{data_cache_entity.NewTrainer("01002", "", "美浦")},
+			races:    races,
+			want: []string{
+				"https://db.netkeiba.com/trainer/01001/",
+				"https://db.netkeiba.com/trainer/01003/",
+			},
+		},
+	}
+
+	tr := &trainerService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tr.createTrainerUrls(tt.trainers, tt.races); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("createTrainerUrls() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -35,17 +35,20 @@ type TrioOdds interface {
 type trioOddsService struct {
 	oddsRepository      repository.OddsRepository
 	oddsEntityConverter converter.OddsEntityConverter
+	checkpoint          Checkpoint
 	logger              *logrus.Logger
 }
 
 func NewTrioOdds(
 	oddsRepository repository.OddsRepository,
 	oddsEntityConverter converter.OddsEntityConverter,
+	checkpoint Checkpoint,
 	logger *logrus.Logger,
 ) TrioOdds {
 	return &trioOddsService{
 		oddsRepository:      oddsRepository,
 		oddsEntityConverter: oddsEntityConverter,
+		checkpoint:          checkpoint,
 		logger:              logger,
 	}
 }
@@ -79,9 +82,6 @@ func (o *trioOddsService) CreateOrUpdateV2(
 	odds []*data_cache_entity.Odds,
 	races []*data_cache_entity.Race,
 ) error {
-	taskCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
 	urls := o.createOddsUrlsV2(odds, races)
 	if len(urls) == 0 {
 		return nil
@@ -89,78 +89,45 @@ func (o *trioOddsService) CreateOrUpdateV2(
 
 	oddsMap := o.createOddsMap(odds)
 
-	var wg sync.WaitGroup
 	const workerParallel = 5
-	errorCh := make(chan error, 1)
-	chunkSize := (len(urls) + workerParallel - 1) / workerParallel
-
-	for i := 0; i < len(urls); i = i + chunkSize {
-		end := i + chunkSize
-		if end > len(urls) {
-			end = len(urls)
+	fetchedRaceOddsList, err := crawl(ctx, o.checkpoint, o.logger, trioOddsStage, urls, workerParallel, func(ctx context.Context, url string) (*raw_entity.RaceOdds, error) {
+		fetchOdds, err := o.oddsRepository.Fetch(ctx, url)
+		if err != nil {
+			return nil, err
 		}
 
-		wg.Add(1)
-		go func(splitUrls []string) {
-			defer wg.Done()
-			o.logger.Infof("trio odds fetch processing: %v/%v", end, len(urls))
-			for _, url := range splitUrls {
-				time.Sleep(time.Millisecond)
-				select {
-				case <-taskCtx.Done():
-					return
-				default:
-					fetchOdds, err := o.oddsRepository.Fetch(taskCtx, url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-
-					raceId, err := o.parseUrl(url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-
-					var raceDate types.RaceDate
-					if len(fetchOdds) > 0 {
-						raceDate = fetchOdds[0].RaceDate()
-					}
+		raceId, err := o.parseUrl(url)
+		if err != nil {
+			return nil, err
+		}
 
-					newOdds := make([]*raw_entity.Odds, 0, len(fetchOdds))
-					for _, netKeibaFetchOdds := range fetchOdds {
-						newOdds = append(newOdds, o.oddsEntityConverter.NetKeibaToRaw(netKeibaFetchOdds))
-					}
+		var raceDate types.RaceDate
+		if len(fetchOdds) > 0 {
+			raceDate = fetchOdds[0].RaceDate()
+		}
 
-					if _, ok := oddsMap[raceDate]; !ok {
-						oddsMap[raceDate] = make([]*raw_entity.RaceOdds, 0)
-					}
+		newOdds := make([]*raw_entity.Odds, 0, len(fetchOdds))
+		for _, netKeibaFetchOdds := range fetchOdds {
+			newOdds = append(newOdds, o.oddsEntityConverter.NetKeibaToRaw(netKeibaFetchOdds))
+		}
 
-					sort.Slice(newOdds, func(i, j int) bool {
-						return newOdds[i].Popular < newOdds[j].Popular
-					})
+		sort.Slice(newOdds, func(i, j int) bool {
+			return newOdds[i].Popular < newOdds[j].Popular
+		})
 
-					oddsMap[raceDate] = append(oddsMap[raceDate], &raw_entity.RaceOdds{
-						RaceId:   raceId.String(),
-						RaceDate: raceDate.Value(),
-						Odds:     newOdds,
-					})
-				}
-			}
-		}(urls[i:end])
+		return &raw_entity.RaceOdds{
+			RaceId:   raceId.String(),
+			RaceDate: raceDate.Value(),
+			Odds:     newOdds,
+		}, nil
+	})
+	if err != nil {
+		return err
 	}
 
-	wg.Wait()
-	close(errorCh)
-
-	if err := <-errorCh; err != nil {
-		return err
+	for _, rawRaceOdds := range fetchedRaceOddsList {
+		raceDate := types.RaceDate(rawRaceOdds.RaceDate)
+		oddsMap[raceDate] = append(oddsMap[raceDate], rawRaceOdds)
 	}
 
 	for _, raceDate := range service.SortedRaceDateKeys(oddsMap) {
@@ -177,7 +144,7 @@ func (o *trioOddsService) CreateOrUpdateV2(
 		}
 	}
 
-	return nil
+	return o.checkpoint.Done(ctx, trioOddsStage, urls)
 }
 
 func (o *trioOddsService) CreateOrUpdate(
@@ -61,7 +61,8 @@ func (u *umacaTicketService) Get(
 
 	raceTickets := make([]*ticket_csv_entity.RaceTicket, 0)
 	for _, file := range files {
-		tickets, err := u.ticketRepository.Read(ctx, fmt.Sprintf("%s/%s", config.CsvDir, file))
+		// UMACAはcsv直下のumaca_master.csvからしか作らない
+		tickets, err := u.ticketRepository.Read(ctx, fmt.Sprintf("%s/%s", config.CsvDir, file), types.DefaultAccount)
 		if err != nil {
 			return nil, err
 		}
@@ -113,7 +114,7 @@ func (u *umacaTicketService) CreateOrUpdate(
 		filePath := fmt.Sprintf("%s/%s", config.CsvDir, fileName)
 
 		// ファイルが取得できない場合は処理を続行する
-		tickets, _ := u.ticketRepository.Read(ctx, filePath)
+		tickets, _ := u.ticketRepository.Read(ctx, filePath, types.DefaultAccount)
 
 		// データが取得できた場合は上書きしない
 		// もし上書きしたい場合はファイルを消して対応する
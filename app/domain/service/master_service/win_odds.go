@@ -34,17 +34,20 @@ type WinOdds interface {
 type winOddsService struct {
 	oddsRepository      repository.OddsRepository
 	oddsEntityConverter converter.OddsEntityConverter
+	checkpoint          Checkpoint
 	logger              *logrus.Logger
 }
 
 func NewWinOdds(
 	oddsRepository repository.OddsRepository,
 	oddsEntityConverter converter.OddsEntityConverter,
+	checkpoint Checkpoint,
 	logger *logrus.Logger,
 ) WinOdds {
 	return &winOddsService{
 		oddsRepository:      oddsRepository,
 		oddsEntityConverter: oddsEntityConverter,
+		checkpoint:          checkpoint,
 		logger:              logger,
 	}
 }
@@ -78,9 +81,6 @@ func (w *winOddsService) CreateOrUpdateV2(
 	odds []*data_cache_entity.Odds,
 	races []*data_cache_entity.Race,
 ) error {
-	taskCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
 	urls := w.createOddsUrlsV2(odds, races)
 	if len(urls) == 0 {
 		return nil
@@ -88,78 +88,45 @@ func (w *winOddsService) CreateOrUpdateV2(
 
 	oddsMap := w.createOddsMap(odds)
 
-	var wg sync.WaitGroup
 	const workerParallel = 5
-	errorCh := make(chan error, 1)
-	chunkSize := (len(urls) + workerParallel - 1) / workerParallel
-
-	for i := 0; i < len(urls); i = i + chunkSize {
-		end := i + chunkSize
-		if end > len(urls) {
-			end = len(urls)
+	fetchedRaceOddsList, err := crawl(ctx, w.checkpoint, w.logger, winOddsStage, urls, workerParallel, func(ctx context.Context, url string) (*raw_entity.RaceOdds, error) {
+		fetchOdds, err := w.oddsRepository.Fetch(ctx, url)
+		if err != nil {
+			return nil, err
 		}
 
-		wg.Add(1)
-		go func(splitUrls []string) {
-			defer wg.Done()
-			w.logger.Infof("win odds fetch processing: %v/%v", end, len(urls))
-			for _, url := range splitUrls {
-				time.Sleep(time.Millisecond)
-				select {
-				case <-taskCtx.Done():
-					return
-				default:
-					fetchOdds, err := w.oddsRepository.Fetch(taskCtx, url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-
-					raceId, err := w.parseUrl(url)
-					if err != nil {
-						select {
-						case errorCh <- err:
-							cancel()
-						}
-						return
-					}
-
-					var raceDate types.RaceDate
-					if len(fetchOdds) > 0 {
-						raceDate = fetchOdds[0].RaceDate()
-					}
+		raceId, err := w.parseUrl(url)
+		if err != nil {
+			return nil, err
+		}
 
-					newOdds := make([]*raw_entity.Odds, 0, len(fetchOdds))
-					for _, netKeibaFetchOdds := range fetchOdds {
-						newOdds = append(newOdds, w.oddsEntityConverter.NetKeibaToRaw(netKeibaFetchOdds))
-					}
+		var raceDate types.RaceDate
+		if len(fetchOdds) > 0 {
+			raceDate = fetchOdds[0].RaceDate()
+		}
 
-					if _, ok := oddsMap[raceDate]; !ok {
-						oddsMap[raceDate] = make([]*raw_entity.RaceOdds, 0)
-					}
+		newOdds := make([]*raw_entity.Odds, 0, len(fetchOdds))
+		for _, netKeibaFetchOdds := range fetchOdds {
+			newOdds = append(newOdds, w.oddsEntityConverter.NetKeibaToRaw(netKeibaFetchOdds))
+		}
 
-					sort.Slice(newOdds, func(i, j int) bool {
-						return newOdds[i].Popular < newOdds[j].Popular
-					})
+		sort.Slice(newOdds, func(i, j int) bool {
+			return newOdds[i].Popular < newOdds[j].Popular
+		})
 
-					oddsMap[raceDate] = append(oddsMap[raceDate], &raw_entity.RaceOdds{
-						RaceId:   raceId.String(),
-						RaceDate: raceDate.Value(),
-						Odds:     newOdds,
-					})
-				}
-			}
-		}(urls[i:end])
+		return &raw_entity.RaceOdds{
+			RaceId:   raceId.String(),
+			RaceDate: raceDate.Value(),
+			Odds:     newOdds,
+		}, nil
+	})
+	if err != nil {
+		return err
 	}
 
-	wg.Wait()
-	close(errorCh)
-
-	if err := <-errorCh; err != nil {
-		return err
+	for _, rawRaceOdds := range fetchedRaceOddsList {
+		raceDate := types.RaceDate(rawRaceOdds.RaceDate)
+		oddsMap[raceDate] = append(oddsMap[raceDate], rawRaceOdds)
 	}
 
 	for _, raceDate := range service.SortedRaceDateKeys(oddsMap) {
@@ -176,7 +143,7 @@ func (w *winOddsService) CreateOrUpdateV2(
 		}
 	}
 
-	return nil
+	return w.checkpoint.Done(ctx, winOddsStage, urls)
 }
 
 func (w *winOddsService) CreateOrUpdate(
@@ -0,0 +1,460 @@
+package prediction_service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/master_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/config"
+)
+
+const (
+	betSlipFileName     = "bet_slip_%d.csv"
+	betSlipTextFileName = "bet_slip_%d.txt"
+	betSlipMinPayment   = 100
+)
+
+// betSlipMarkerAliases 印の入力ゆれ
+var betSlipMarkerAliases = map[string]types.Marker{
+	"○": types.Rival,
+	"✔": types.Check,
+}
+
+// betSlipNotationReplacer 区切り文字と全角数字をIPATの馬／組番の書式に揃える
+var betSlipNotationReplacer = strings.NewReplacer(
+	" ", "", "　", "",
+	"/", "／",
+	";", "；", ",", "；", "、", "；",
+	"―", "-", "－", "-", "ー", "-",
+	"->", "→",
+	"０", "0", "１", "1", "２", "2", "３", "3", "４", "4",
+	"５", "5", "６", "6", "７", "7", "８", "8", "９", "9",
+)
+
+type BetSlip interface {
+	GetPlans(ctx context.Context, fileName string) ([]*prediction_entity.BetPlan, error)
+	Validate(ctx context.Context,
+		betPlans []*prediction_entity.BetPlan,
+		predictionMarkers []*marker_csv_entity.PredictionMarker,
+	) []*prediction_entity.BetSlip
+	Print(ctx context.Context, betSlips []*prediction_entity.BetSlip) string
+	Export(ctx context.Context, betSlips []*prediction_entity.BetSlip) ([]string, error)
+}
+
+type betSlipService struct {
+	raceRepository     repository.RaceRepository
+	betSlipRepository  repository.BetSlipRepository
+	betNumberConverter master_service.BetNumberConverter
+}
+
+func NewBetSlip(
+	raceRepository repository.RaceRepository,
+	betSlipRepository repository.BetSlipRepository,
+	betNumberConverter master_service.BetNumberConverter,
+) BetSlip {
+	return &betSlipService{
+		raceRepository:     raceRepository,
+		betSlipRepository:  betSlipRepository,
+		betNumberConverter: betNumberConverter,
+	}
+}
+
+// betSlipNotation 式別ごとの馬／組番の組み立て方
+type betSlipNotation struct {
+	groups    int    // ／で区切るグループ数
+	pivots    int    // 1グループ目(軸)の頭数、0は制限なし
+	minHorses int    // 1グループ目の最低頭数(ボックス用)
+	arity     int    // 1点あたりの頭数(単式のみ)
+	separator string // 単式の区切り
+}
+
+var betSlipNotationMap = map[types.TicketType]betSlipNotation{
+	types.Win:                        {groups: 1, arity: 1},
+	types.Place:                      {groups: 1, arity: 1},
+	types.BracketQuinella:            {groups: 1, arity: 2, separator: types.QuinellaSeparator},
+	types.Quinella:                   {groups: 1, arity: 2, separator: types.QuinellaSeparator},
+	types.Exacta:                     {groups: 1, arity: 2, separator: types.ExactaSeparator},
+	types.QuinellaPlace:              {groups: 1, arity: 2, separator: types.QuinellaSeparator},
+	types.Trio:                       {groups: 1, arity: 3, separator: types.QuinellaSeparator},
+	types.Trifecta:                   {groups: 1, arity: 3, separator: types.ExactaSeparator},
+	types.QuinellaWheel:              {groups: 2, pivots: 1},
+	types.QuinellaPlaceWheel:         {groups: 2, pivots: 1},
+	types.ExactaWheelOfFirst:         {groups: 2, pivots: 1},
+	types.TrioWheelOfFirst:           {groups: 2, pivots: 1},
+	types.TrioWheelOfSecond:          {groups: 2, pivots: 2},
+	types.TrifectaWheelOfFirst:       {groups: 2, pivots: 1},
+	types.TrifectaWheelOfSecond:      {groups: 2, pivots: 1},
+	types.TrifectaWheelOfFirstMulti:  {groups: 2, pivots: 1},
+	types.TrifectaWheelOfSecondMulti: {groups: 2, pivots: 2},
+	types.QuinellaPlaceFormation:     {groups: 2},
+	types.TrioFormation:              {groups: 3},
+	types.TrifectaFormation:          {groups: 3},
+	types.TrioBox:                    {groups: 1, minHorses: 3},
+}
+
+// betSlipArityMap 元の式別ごとの1点あたりの頭数(枠連は枠番)
+var betSlipArityMap = map[types.TicketType]int{
+	types.Win:             1,
+	types.Place:           1,
+	types.BracketQuinella: 2,
+	types.Quinella:        2,
+	types.Exacta:          2,
+	types.QuinellaPlace:   2,
+	types.Trio:            3,
+	types.Trifecta:        3,
+}
+
+func (b *betSlipService) GetPlans(ctx context.Context, fileName string) ([]*prediction_entity.BetPlan, error) {
+	return b.betSlipRepository.ReadPlan(ctx, fmt.Sprintf("%s/%s", config.CsvDir, fileName))
+}
+
+// Validate 印を馬番に置き換えて馬／組番を組み立て、出馬表の馬番と突き合わせる。投票はしない
+func (b *betSlipService) Validate(
+	ctx context.Context,
+	betPlans []*prediction_entity.BetPlan,
+	predictionMarkers []*marker_csv_entity.PredictionMarker,
+) []*prediction_entity.BetSlip {
+	predictionMarkerMap := map[types.RaceId]*marker_csv_entity.PredictionMarker{}
+	for _, marker := range predictionMarkers {
+		predictionMarkerMap[marker.RaceId()] = marker
+	}
+
+	raceCardMap := map[types.RaceId]*netkeiba_entity.Race{}
+	raceCardErrorMap := map[types.RaceId]error{}
+
+	betSlips := make([]*prediction_entity.BetSlip, 0, len(betPlans))
+	for _, betPlan := range betPlans {
+		raceCard, ok := raceCardMap[betPlan.RaceId()]
+		if _, failed := raceCardErrorMap[betPlan.RaceId()]; !ok && !failed {
+			var err error
+			raceCard, err = b.raceRepository.FetchRaceCard(ctx, fmt.Sprintf(raceCardUrl, betPlan.RaceId()))
+			if err != nil {
+				raceCardErrorMap[betPlan.RaceId()] = err
+			} else {
+				raceCardMap[betPlan.RaceId()] = raceCard
+			}
+		}
+
+		var problems []string
+		if err, ok := raceCardErrorMap[betPlan.RaceId()]; ok {
+			problems = append(problems, fmt.Sprintf("出馬表を取得できません: %v", err))
+		}
+		if betPlan.Payment() < betSlipMinPayment || betPlan.Payment()%betSlipMinPayment != 0 {
+			problems = append(problems, fmt.Sprintf("金額は%d円単位で指定してください: %d", betSlipMinPayment, betPlan.Payment()))
+		}
+
+		betNumber, betNumbers, betProblems := b.createBetNumbers(ctx, betPlan, predictionMarkerMap[betPlan.RaceId()], raceCard)
+		problems = append(problems, betProblems...)
+
+		var (
+			raceDate   types.RaceDate
+			raceCourse types.RaceCourse
+			raceNumber int
+			raceName   string
+			startTime  string
+		)
+		if raceCard != nil {
+			raceDate = types.RaceDate(raceCard.RaceDate())
+			raceCourse = types.RaceCourse(raceCard.RaceCourseId())
+			raceNumber = raceCard.RaceNumber()
+			raceName = raceCard.RaceName()
+			startTime = raceCard.StartTime()
+		}
+
+		betSlips = append(betSlips, prediction_entity.NewBetSlip(
+			betPlan,
+			raceDate,
+			raceCourse,
+			raceNumber,
+			raceName,
+			startTime,
+			betNumber,
+			betNumbers,
+			problems,
+		))
+	}
+
+	return betSlips
+}
+
+// Print 印刷用の投票予定票を作る
+func (b *betSlipService) Print(ctx context.Context, betSlips []*prediction_entity.BetSlip) string {
+	sortedBetSlips := b.sortBetSlips(betSlips)
+
+	var (
+		builder      strings.Builder
+		totalPoints  int
+		totalPayment int
+		invalidCount int
+		prevRaceId   types.RaceId
+	)
+	builder.WriteString("投票予定票(このファイルから投票はされません)\n")
+	for _, betSlip := range sortedBetSlips {
+		if betSlip.RaceId() != prevRaceId {
+			prevRaceId = betSlip.RaceId()
+			if betSlip.RaceDate() == 0 {
+				builder.WriteString(fmt.Sprintf("\n%s\n", betSlip.RaceId()))
+			} else {
+				builder.WriteString(fmt.Sprintf("\n%s %s%dR %s %s発走\n",
+					betSlip.RaceDate().Format("2006/01/02"), betSlip.RaceCourse().Name(), betSlip.RaceNumber(), betSlip.RaceName(), betSlip.StartTime()))
+			}
+		}
+
+		ticketTypeName := betSlip.TicketType().Name()
+		if betSlip.TicketType() == types.UnknownTicketType {
+			ticketTypeName = betSlip.Plan().RawTicketType()
+		}
+		betNumber := betSlip.BetNumber()
+		if betNumber == "" {
+			betNumber = betSlip.Plan().RawBetNumber()
+		}
+		builder.WriteString(fmt.Sprintf("  %s %s %d点 × %d円 = %d円\n",
+			ticketTypeName, betNumber, betSlip.Points(), betSlip.Payment(), betSlip.TotalPayment()))
+
+		if !betSlip.Valid() {
+			invalidCount++
+			for _, problem := range betSlip.Problems() {
+				builder.WriteString(fmt.Sprintf("    NG(%d行目): %s\n", betSlip.Plan().RowNum(), problem))
+			}
+			continue
+		}
+		totalPoints += betSlip.Points()
+		totalPayment += betSlip.TotalPayment()
+	}
+	builder.WriteString(fmt.Sprintf("\n合計 %d点 %d円", totalPoints, totalPayment))
+	if invalidCount > 0 {
+		builder.WriteString(fmt.Sprintf(" (NG %d件を除く)", invalidCount))
+	}
+	builder.WriteString("\n")
+
+	return builder.String()
+}
+
+// Export 開催日ごとにPATの投票履歴と同じ形式のCSVと投票予定票を書き出す
+func (b *betSlipService) Export(ctx context.Context, betSlips []*prediction_entity.BetSlip) ([]string, error) {
+	raceDateBetSlipsMap := map[types.RaceDate][]*prediction_entity.BetSlip{}
+	for _, betSlip := range b.sortBetSlips(betSlips) {
+		if !betSlip.Valid() {
+			return nil, fmt.Errorf("invalid bet slip at row %d: %s", betSlip.Plan().RowNum(), strings.Join(betSlip.Problems(), ", "))
+		}
+		raceDateBetSlipsMap[betSlip.RaceDate()] = append(raceDateBetSlipsMap[betSlip.RaceDate()], betSlip)
+	}
+
+	raceDates := make([]types.RaceDate, 0, len(raceDateBetSlipsMap))
+	for raceDate := range raceDateBetSlipsMap {
+		raceDates = append(raceDates, raceDate)
+	}
+	sort.Slice(raceDates, func(i, j int) bool {
+		return raceDates[i] < raceDates[j]
+	})
+
+	var paths []string
+	for _, raceDate := range raceDates {
+		raceDateBetSlips := raceDateBetSlipsMap[raceDate]
+		path := fmt.Sprintf("%s/%s", config.CsvDir, fmt.Sprintf(betSlipFileName, raceDate.Value()))
+		if err := b.betSlipRepository.Write(ctx, path, raceDateBetSlips); err != nil {
+			return nil, err
+		}
+		textPath := fmt.Sprintf("%s/%s", config.CsvDir, fmt.Sprintf(betSlipTextFileName, raceDate.Value()))
+		if err := b.betSlipRepository.WriteText(ctx, textPath, b.Print(ctx, raceDateBetSlips)); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path, textPath)
+	}
+
+	return paths, nil
+}
+
+// createBetNumbers 馬／組番をIPATの書式に組み立ててから1点ずつにバラし、出馬表の馬番(枠連は枠番)と突き合わせる
+func (b *betSlipService) createBetNumbers(
+	ctx context.Context,
+	betPlan *prediction_entity.BetPlan,
+	predictionMarker *marker_csv_entity.PredictionMarker,
+	raceCard *netkeiba_entity.Race,
+) (string, []types.BetNumber, []string) {
+	notation, ok := betSlipNotationMap[betPlan.TicketType()]
+	if !ok {
+		return "", nil, []string{fmt.Sprintf("式別が不明です: %s", betPlan.RawTicketType())}
+	}
+
+	groups, err := b.parseGroups(betPlan.RawBetNumber(), notation, predictionMarker)
+	if err != nil {
+		return "", nil, []string{err.Error()}
+	}
+
+	betNumber := b.formatBetNumber(groups, notation)
+	betNumbers, err := b.betNumberConverter.ToBetNumbers(ctx, betPlan.TicketType(), betNumber)
+	if err != nil {
+		return betNumber, nil, []string{fmt.Sprintf("馬／組番を解釈できません: %s", betNumber)}
+	}
+	if len(betNumbers) == 0 {
+		return betNumber, nil, []string{fmt.Sprintf("買い目が0点です: %s", betNumber)}
+	}
+	sort.Slice(betNumbers, func(i, j int) bool {
+		return betNumbers[i] < betNumbers[j]
+	})
+
+	var problems []string
+	if raceCard == nil {
+		return betNumber, betNumbers, problems
+	}
+
+	useBracket := betPlan.TicketType() == types.BracketQuinella
+	numberMap := map[int]bool{}
+	for _, raceEntryHorse := range raceCard.RaceEntryHorses() {
+		if useBracket {
+			numberMap[raceEntryHorse.BracketNumber()] = true
+		} else {
+			numberMap[raceEntryHorse.HorseNumber()] = true
+		}
+	}
+	delete(numberMap, 0)
+	if len(numberMap) == 0 {
+		return betNumber, betNumbers, []string{"出馬表の枠順が確定していません"}
+	}
+
+	arity := betSlipArityMap[betPlan.TicketType().OriginTicketType()]
+	missingMap := map[int]bool{}
+	for _, bn := range betNumbers {
+		numbers := bn.List()
+		if len(numbers) != arity {
+			problems = append(problems, fmt.Sprintf("%sは%d頭で指定してください: %s", betPlan.TicketType().OriginTicketType().Name(), arity, bn.String()))
+			continue
+		}
+		duplicateMap := map[int]bool{}
+		for _, number := range numbers {
+			if duplicateMap[number] && !useBracket {
+				problems = append(problems, fmt.Sprintf("同じ馬番が重複しています: %s", bn.String()))
+			}
+			duplicateMap[number] = true
+			if !numberMap[number] && !missingMap[number] {
+				missingMap[number] = true
+				problems = append(problems, fmt.Sprintf("%02dは出馬表にありません", number))
+			}
+		}
+	}
+
+	return betNumber, betNumbers, problems
+}
+
+// parseGroups ／でグループ、；(単式は-か→)で馬に分けて、印を馬番に置き換える
+func (b *betSlipService) parseGroups(
+	rawBetNumber string,
+	notation betSlipNotation,
+	predictionMarker *marker_csv_entity.PredictionMarker,
+) ([][]int, error) {
+	normalized := betSlipNotationReplacer.Replace(rawBetNumber)
+	if normalized == "" {
+		return nil, fmt.Errorf("馬／組番が空です")
+	}
+
+	rawGroups := strings.Split(normalized, "／")
+	if len(rawGroups) != notation.groups {
+		return nil, fmt.Errorf("馬／組番は／で%dグループに分けてください: %s", notation.groups, rawBetNumber)
+	}
+
+	groups := make([][]int, 0, len(rawGroups))
+	for idx, rawGroup := range rawGroups {
+		var tokens []string
+		if notation.arity > 0 {
+			tokens = strings.FieldsFunc(rawGroup, func(r rune) bool {
+				return string(r) == types.QuinellaSeparator || string(r) == types.ExactaSeparator || r == '；'
+			})
+			if len(tokens) != notation.arity {
+				return nil, fmt.Errorf("%d頭で指定してください: %s", notation.arity, rawBetNumber)
+			}
+		} else {
+			tokens = strings.Split(rawGroup, "；")
+		}
+
+		group := make([]int, 0, len(tokens))
+		for _, token := range tokens {
+			number, err := b.parseHorseNumber(token, predictionMarker)
+			if err != nil {
+				return nil, err
+			}
+			group = append(group, number)
+		}
+		// ながし・ボックス・フォーメーションはIPATと同じくグループ内を馬番順に並べる。3連複ボックスは並びのまま組み合わせるため
+		if notation.arity == 0 {
+			sort.Ints(group)
+		}
+		if idx == 0 && notation.pivots > 0 && len(group) != notation.pivots {
+			return nil, fmt.Errorf("軸は%d頭で指定してください: %s", notation.pivots, rawBetNumber)
+		}
+		if idx == 0 && len(group) < notation.minHorses {
+			return nil, fmt.Errorf("%d頭以上で指定してください: %s", notation.minHorses, rawBetNumber)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+func (b *betSlipService) parseHorseNumber(
+	token string,
+	predictionMarker *marker_csv_entity.PredictionMarker,
+) (int, error) {
+	if number, err := strconv.Atoi(token); err == nil {
+		if number <= 0 {
+			return 0, fmt.Errorf("馬番が不正です: %s", token)
+		}
+		return number, nil
+	}
+
+	marker, ok := betSlipMarkerAliases[token]
+	if !ok {
+		for _, m := range stakeMarkers {
+			if m.String() == token {
+				marker, ok = m, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return 0, fmt.Errorf("馬番か印で指定してください: %s", token)
+	}
+	if predictionMarker == nil {
+		return 0, fmt.Errorf("印が同期されていないレースです: %s", token)
+	}
+	horseNumber := predictionMarker.MarkerMap()[marker]
+	if horseNumber == 0 {
+		return 0, fmt.Errorf("%sの馬がいません", token)
+	}
+
+	return horseNumber.Value(), nil
+}
+
+func (b *betSlipService) formatBetNumber(groups [][]int, notation betSlipNotation) string {
+	separator := "；"
+	if notation.arity > 0 {
+		separator = notation.separator
+	}
+	formattedGroups := make([]string, 0, len(groups))
+	for _, group := range groups {
+		numbers := make([]string, 0, len(group))
+		for _, number := range group {
+			numbers = append(numbers, fmt.Sprintf("%02d", number))
+		}
+		formattedGroups = append(formattedGroups, strings.Join(numbers, separator))
+	}
+
+	return strings.Join(formattedGroups, "／")
+}
+
+func (b *betSlipService) sortBetSlips(betSlips []*prediction_entity.BetSlip) []*prediction_entity.BetSlip {
+	sortedBetSlips := make([]*prediction_entity.BetSlip, len(betSlips))
+	copy(sortedBetSlips, betSlips)
+	sort.SliceStable(sortedBetSlips, func(i, j int) bool {
+		return sortedBetSlips[i].RaceId() < sortedBetSlips[j].RaceId()
+	})
+	return sortedBetSlips
+}
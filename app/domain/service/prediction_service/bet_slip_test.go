@@ -0,0 +1,158 @@
+package prediction_service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/master_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func TestBetSlipCreateBetNumbers(t *testing.T) {
+	// 8頭立て、◎5 ◯3 ▲8 △1
+	raceEntryHorses := make([]*netkeiba_entity.RaceEntryHorse, 0, 8)
+	for horseNumber := 1; horseNumber <= 8; horseNumber++ {
+		raceEntryHorses = append(raceEntryHorses, netkeiba_entity.NewRaceEntryHorse("", "", (horseNumber+1)/2, horseNumber, "", "", 0))
+	}
+	raceCard := netkeiba_entity.NewRace("202405040811", "05", 11, 20241020, "テスト", 1, "", "", "15:40", 8, 1600, 0, 0, 0, 0, 0, 0, 0, raceEntryHorses, nil, nil)
+	predictionMarker := marker_csv_entity.NewPredictionMarker("202405040811", "5", "3", "8", "1", "", "")
+
+	tests := []struct {
+		name           string
+		rawTicketType  string
+		rawBetNumber   string
+		raceCard       *netkeiba_entity.Race
+		wantBetNumber  string
+		wantBetNumbers []types.BetNumber
+		wantProblems   []string
+	}{
+		{
+			name:           "単勝を印で指定",
+			rawTicketType:  "単勝",
+			rawBetNumber:   "◎",
+			raceCard:       raceCard,
+			wantBetNumber:  "05",
+			wantBetNumbers: []types.BetNumber{"05"},
+		},
+		{
+			name:           "馬単は並びのまま",
+			rawTicketType:  "馬単",
+			rawBetNumber:   "◎→○",
+			raceCard:       raceCard,
+			wantBetNumber:  "05→03",
+			wantBetNumbers: []types.BetNumber{"05→03"},
+		},
+		{
+			name:           "馬連ながし",
+			rawTicketType:  "馬連ながし",
+			rawBetNumber:   "◎／８、1、○",
+			raceCard:       raceCard,
+			wantBetNumber:  "05／01；03；08",
+			wantBetNumbers: []types.BetNumber{"01-05", "03-05", "05-08"},
+		},
+		{
+			name:           "3連複軸1頭ながし",
+			rawTicketType:  "3連複軸1頭ながし",
+			rawBetNumber:   "○／▲,◎,1",
+			raceCard:       raceCard,
+			wantBetNumber:  "03／01；05；08",
+			wantBetNumbers: []types.BetNumber{"01-03-05", "01-03-08", "03-05-08"},
+		},
+		{
+			name:           "3連複ＢＯＸは印の順に関わらず馬番順",
+			rawTicketType:  "3連複ＢＯＸ",
+			rawBetNumber:   "◎,○,▲,△",
+			raceCard:       raceCard,
+			wantBetNumber:  "01；03；05；08",
+			wantBetNumbers: []types.BetNumber{"01-03-05", "01-03-08", "01-05-08", "03-05-08"},
+		},
+		{
+			name:           "3連単フォーメーションはグループの並びを保つ",
+			rawTicketType:  "3連単フォーメーション",
+			rawBetNumber:   "◎／○,▲／○,▲,△",
+			raceCard:       raceCard,
+			wantBetNumber:  "05／03；08／01；03；08",
+			wantBetNumbers: []types.BetNumber{"05→03→01", "05→03→08", "05→08→01", "05→08→03"},
+		},
+		{
+			name:           "出馬表が無い場合は馬番を突き合わせない",
+			rawTicketType:  "複勝",
+			rawBetNumber:   "17",
+			wantBetNumber:  "17",
+			wantBetNumbers: []types.BetNumber{"17"},
+		},
+		{
+			name:           "出馬表に無い馬番",
+			rawTicketType:  "複勝",
+			rawBetNumber:   "17",
+			raceCard:       raceCard,
+			wantBetNumber:  "17",
+			wantBetNumbers: []types.BetNumber{"17"},
+			wantProblems:   []string{"17は出馬表にありません"},
+		},
+		{
+			name:           "同じ馬番の重複",
+			rawTicketType:  "ワイド",
+			rawBetNumber:   "3-3",
+			raceCard:       raceCard,
+			wantBetNumber:  "03-03",
+			wantBetNumbers: []types.BetNumber{"03-03"},
+			wantProblems:   []string{"同じ馬番が重複しています: 03-03"},
+		},
+		{
+			name:          "ながしの軸の頭数",
+			rawTicketType: "馬連ながし",
+			rawBetNumber:  "◎,○／1,8",
+			raceCard:      raceCard,
+			wantProblems:  []string{"軸は1頭で指定してください: ◎,○／1,8"},
+		},
+		{
+			name:          "ボックスは3頭以上",
+			rawTicketType: "3連複ＢＯＸ",
+			rawBetNumber:  "◎,○",
+			raceCard:      raceCard,
+			wantProblems:  []string{"3頭以上で指定してください: ◎,○"},
+		},
+		{
+			name:          "印の馬がいない",
+			rawTicketType: "単勝",
+			rawBetNumber:  "☆",
+			raceCard:      raceCard,
+			wantProblems:  []string{"☆の馬がいません"},
+		},
+		{
+			name:          "馬／組番が空",
+			rawTicketType: "単勝",
+			raceCard:      raceCard,
+			wantProblems:  []string{"馬／組番が空です"},
+		},
+		{
+			name:          "式別が不明",
+			rawTicketType: "WIN5",
+			rawBetNumber:  "1",
+			raceCard:      raceCard,
+			wantProblems:  []string{"式別が不明です: WIN5"},
+		},
+	}
+
+	b := &betSlipService{betNumberConverter: master_service.NewBetNumberConverter()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			betPlan := prediction_entity.NewBetPlan(1, "202405040811", tt.rawTicketType, tt.rawBetNumber, 100)
+			betNumber, betNumbers, problems := b.createBetNumbers(context.Background(), betPlan, predictionMarker, tt.raceCard)
+			if betNumber != tt.wantBetNumber {
+				t.Errorf("betNumber = %q, want %q", betNumber, tt.wantBetNumber)
+			}
+			if !reflect.DeepEqual(betNumbers, tt.wantBetNumbers) {
+				t.Errorf("betNumbers = %v, want %v", betNumbers, tt.wantBetNumbers)
+			}
+			if !reflect.DeepEqual(problems, tt.wantProblems) {
+				t.Errorf("problems = %v, want %v", problems, tt.wantProblems)
+			}
+		})
+	}
+}
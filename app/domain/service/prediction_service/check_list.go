@@ -1,11 +1,50 @@
 package prediction_service
 
-import "context"
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+)
 
 type CheckList interface {
-	GetPositivePoint(ctx context.Context) error
-	GetNegativePoint(ctx context.Context) error
+	GetPositivePoint(ctx context.Context, placeScore *analysis_entity.PlaceScore) float64
+	GetNegativePoint(ctx context.Context, placeScore *analysis_entity.PlaceScore) float64
+}
+
+type checkList struct{}
+
+func NewCheckList() CheckList {
+	return &checkList{}
+}
+
+// GetPositivePoint 複勝圏内確率を押し上げている項目の寄与度の合計
+func (c *checkList) GetPositivePoint(ctx context.Context, placeScore *analysis_entity.PlaceScore) float64 {
+	if placeScore == nil {
+		return 0
+	}
+
+	point := 0.0
+	for _, contribution := range placeScore.Contributions() {
+		if contribution > 0 {
+			point += contribution
+		}
+	}
+
+	return point
 }
 
-type checkList struct {
+// GetNegativePoint 複勝圏内確率を押し下げている項目の寄与度の合計
+func (c *checkList) GetNegativePoint(ctx context.Context, placeScore *analysis_entity.PlaceScore) float64 {
+	if placeScore == nil {
+		return 0
+	}
+
+	point := 0.0
+	for _, contribution := range placeScore.Contributions() {
+		if contribution < 0 {
+			point += contribution
+		}
+	}
+
+	return point
 }
@@ -0,0 +1,54 @@
+package prediction_service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/config"
+)
+
+// CheckListSnapshot スプレッドシートに書き出したチェックリストをキャッシュに保存、参照する
+type CheckListSnapshot interface {
+	Read(ctx context.Context) (*raw_entity.PredictionCheckListInfo, error)
+	Write(ctx context.Context, predictionCheckLists []*spreadsheet_entity.PredictionCheckList) error
+}
+
+type checkListSnapshotService struct {
+	predictionCheckListRepository      repository.PredictionCheckListRepository
+	predictionCheckListEntityConverter converter.PredictionCheckListEntityConverter
+}
+
+func NewCheckListSnapshot(
+	predictionCheckListRepository repository.PredictionCheckListRepository,
+	predictionCheckListEntityConverter converter.PredictionCheckListEntityConverter,
+) CheckListSnapshot {
+	return &checkListSnapshotService{
+		predictionCheckListRepository:      predictionCheckListRepository,
+		predictionCheckListEntityConverter: predictionCheckListEntityConverter,
+	}
+}
+
+// Read チェックリストをまだ作成していない場合はnilを返す
+func (c *checkListSnapshotService) Read(ctx context.Context) (*raw_entity.PredictionCheckListInfo, error) {
+	return c.predictionCheckListRepository.Read(ctx, fmt.Sprintf("%s/%s", config.CacheDir, checkListFileName))
+}
+
+func (c *checkListSnapshotService) Write(
+	ctx context.Context,
+	predictionCheckLists []*spreadsheet_entity.PredictionCheckList,
+) error {
+	rawPredictionCheckLists := make([]*raw_entity.PredictionCheckList, 0, len(predictionCheckLists))
+	for _, predictionCheckList := range predictionCheckLists {
+		rawPredictionCheckLists = append(rawPredictionCheckLists, c.predictionCheckListEntityConverter.SpreadSheetToRaw(predictionCheckList))
+	}
+
+	return c.predictionCheckListRepository.Write(ctx, fmt.Sprintf("%s/%s", config.CacheDir, checkListFileName), &raw_entity.PredictionCheckListInfo{
+		UpdatedAt:            time.Now().Format(time.DateTime),
+		PredictionCheckLists: rawPredictionCheckLists,
+	})
+}
@@ -4,6 +4,8 @@ const (
 	raceCardUrl            = "https://race.netkeiba.com/race/shutuba.html?race_id=%s&cache=false"
 	raceListUrlForJRA      = "https://race.netkeiba.com/top/race_list_sub.html?kaisai_date=%d"
 	oddsUrl                = "https://race.netkeiba.com/api/api_get_jra_odds.html?race_id=%s&type=1&action=update"
+	quinellaOddsUrl        = "https://race.netkeiba.com/api/api_get_jra_odds.html?race_id=%s&type=4&sort=ninki&action=update"
+	trioOddsUrl            = "https://race.netkeiba.com/api/api_get_jra_odds.html?race_id=%s&type=7&sort=ninki&action=update"
 	raceResultUrl          = "https://race.netkeiba.com/race/result.html?race_id=%s&organizer=1&race_date=%s"
 	raceMarkerUrl          = "https://race.netkeiba.com/api/api_post_social_cart.html?race_id=%s"
 	horseUrl               = "https://db.netkeiba.com/horse/%s?cache=false"
@@ -13,4 +15,6 @@ const (
 	raceReporterMemoUrl    = "https://tospo-keiba.jp/race/detail/%s/reporter-memo"
 	racePaddockCommentUrl  = "https://tospo-keiba.jp/race/detail/%s/card"
 	jockeyFileName         = "jockey.json"
+	trainerFileName        = "trainer.json"
+	checkListFileName      = "prediction_check_list.json"
 )
@@ -3,7 +3,9 @@ package prediction_service
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
@@ -15,6 +17,7 @@ type MarkerSync interface {
 	GetRaceIds(ctx context.Context, raceDate types.RaceDate) ([]types.RaceId, error)
 	GetMarkers(ctx context.Context, raceId types.RaceId) ([]*prediction_entity.Marker, error)
 	Convert(ctx context.Context, predictionMarkers []*prediction_entity.Marker) []*spreadsheet_entity.PredictionMarker
+	ConvertForArchive(ctx context.Context, raceDate types.RaceDate, predictionMarkers []*spreadsheet_entity.PredictionMarker) ([]*marker_csv_entity.AnalysisMarker, error)
 	Write(ctx context.Context, predictionMarkers []*spreadsheet_entity.PredictionMarker) error
 }
 
@@ -122,6 +125,33 @@ func (m *markerSync) Convert(
 	return spreadSheetMarkers
 }
 
+// ConvertForArchive 開催日を付けて分析用の印に変換する
+func (m *markerSync) ConvertForArchive(
+	ctx context.Context,
+	raceDate types.RaceDate,
+	predictionMarkers []*spreadsheet_entity.PredictionMarker,
+) ([]*marker_csv_entity.AnalysisMarker, error) {
+	analysisMarkers := make([]*marker_csv_entity.AnalysisMarker, 0, len(predictionMarkers))
+	for _, predictionMarker := range predictionMarkers {
+		analysisMarker, err := marker_csv_entity.NewAnalysisMarker(
+			strconv.Itoa(raceDate.Value()),
+			predictionMarker.RaceId(),
+			strconv.Itoa(predictionMarker.FavoriteHorseNumber()),
+			strconv.Itoa(predictionMarker.RivalHorseNumber()),
+			strconv.Itoa(predictionMarker.BrackTriangleHorseNumber()),
+			strconv.Itoa(predictionMarker.WhiteTriangleHorseNumber()),
+			strconv.Itoa(predictionMarker.StarHorseNumber()),
+			strconv.Itoa(predictionMarker.CheckHorseNumber()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		analysisMarkers = append(analysisMarkers, analysisMarker)
+	}
+
+	return analysisMarkers, nil
+}
+
 func (m *markerSync) Write(
 	ctx context.Context,
 	predictionMarkers []*spreadsheet_entity.PredictionMarker,
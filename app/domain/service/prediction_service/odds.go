@@ -3,13 +3,18 @@ package prediction_service
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/analysis_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/filter_service"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
@@ -18,6 +23,7 @@ import (
 
 type Odds interface {
 	Get(ctx context.Context, raceId types.RaceId) (*prediction_entity.Race, error)
+	GetRaceRiskOdds(ctx context.Context, race *prediction_entity.Race) (*analysis_service.RaceRiskOddsInput, error)
 	Convert(
 		ctx context.Context,
 		race *prediction_entity.Race,
@@ -30,6 +36,7 @@ type Odds interface {
 		predictionMarkers []*marker_csv_entity.PredictionMarker,
 		placeCalculables []*analysis_entity.PlaceCalculable,
 		raceTimeMap map[filter.AttributeId]*spreadsheet_entity.AnalysisRaceTime,
+		raceRiskMap map[types.RaceId]*analysis_entity.RaceRisk,
 	) (
 		map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace,
 		map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace,
@@ -39,6 +46,7 @@ type Odds interface {
 		secondPlaceMap,
 		thirdPlaceMap map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace,
 		raceCourseMap map[types.RaceCourse][]types.RaceId,
+		stakeMap map[types.RaceId][]*spreadsheet_entity.PredictionStake,
 	) error
 }
 
@@ -47,6 +55,7 @@ type oddsService struct {
 	raceRepository        repository.RaceRepository
 	spreadSheetRepository repository.SpreadSheetRepository
 	filterService         filter_service.PredictionFilter
+	oddsEntityConverter   converter.OddsEntityConverter
 }
 
 func NewOdds(
@@ -54,12 +63,14 @@ func NewOdds(
 	raceRepository repository.RaceRepository,
 	spreadSheetRepository repository.SpreadSheetRepository,
 	filterService filter_service.PredictionFilter,
+	oddsEntityConverter converter.OddsEntityConverter,
 ) Odds {
 	return &oddsService{
 		oddRepository:         oddRepository,
 		raceRepository:        raceRepository,
 		spreadSheetRepository: spreadSheetRepository,
 		filterService:         filterService,
+		oddsEntityConverter:   oddsEntityConverter,
 	}
 }
 
@@ -124,6 +135,31 @@ func (p *oddsService) Get(
 	return predictionRace, nil
 }
 
+// GetRaceRiskOdds 荒れ度合いの判定に使う単勝、馬連、三連複の人気順オッズを取得する
+func (p *oddsService) GetRaceRiskOdds(
+	ctx context.Context,
+	race *prediction_entity.Race,
+) (*analysis_service.RaceRiskOddsInput, error) {
+	oddsListMap := map[string][]*data_cache_entity.Odds{}
+	for _, url := range []string{oddsUrl, quinellaOddsUrl, trioOddsUrl} {
+		fetchOdds, err := p.oddRepository.Fetch(ctx, fmt.Sprintf(url, race.RaceId()))
+		if err != nil {
+			return nil, err
+		}
+		oddsList := make([]*data_cache_entity.Odds, 0, len(fetchOdds))
+		for _, odds := range fetchOdds {
+			oddsList = append(oddsList, p.oddsEntityConverter.RawToDataCache(p.oddsEntityConverter.NetKeibaToRaw(odds), race.RaceId(), race.RaceDate()))
+		}
+		oddsListMap[url] = oddsList
+	}
+
+	return &analysis_service.RaceRiskOddsInput{
+		Win:      oddsListMap[oddsUrl],
+		Quinella: oddsListMap[quinellaOddsUrl],
+		Trio:     oddsListMap[trioOddsUrl],
+	}, nil
+}
+
 func (p *oddsService) Convert(
 	ctx context.Context,
 	race *prediction_entity.Race,
@@ -150,6 +186,7 @@ func (p *oddsService) Convert(
 		race.Url(),
 		race.RaceConditionFilters(),
 		nil, // TODO 後ほど足す
+		nil,
 	)
 
 	firstPlaceMap := map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace{}
@@ -482,6 +519,7 @@ func (p *oddsService) ConvertAll(
 	predictionMarkers []*marker_csv_entity.PredictionMarker,
 	placeCalculables []*analysis_entity.PlaceCalculable,
 	raceTimeMap map[filter.AttributeId]*spreadsheet_entity.AnalysisRaceTime,
+	raceRiskMap map[types.RaceId]*analysis_entity.RaceRisk,
 ) (
 	map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace,
 	map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace,
@@ -537,6 +575,7 @@ func (p *oddsService) ConvertAll(
 			race.Url(),
 			race.RaceConditionFilters(),
 			predictionRaceTime,
+			p.createPredictionRaceRisk(raceRiskMap[race.RaceId()]),
 		)
 		horseNumberOddsMap := map[types.HorseNumber]decimal.Decimal{}
 		for _, o := range race.Odds() {
@@ -881,6 +920,20 @@ func (p *oddsService) Write(
 	secondPlaceMap,
 	thirdPlaceMap map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace,
 	raceCourseMap map[types.RaceCourse][]types.RaceId,
+	stakeMap map[types.RaceId][]*spreadsheet_entity.PredictionStake,
 ) error {
-	return p.spreadSheetRepository.WritePredictionOdds(ctx, firstPlaceMap, secondPlaceMap, thirdPlaceMap, raceCourseMap)
+	return p.spreadSheetRepository.WritePredictionOdds(ctx, firstPlaceMap, secondPlaceMap, thirdPlaceMap, raceCourseMap, stakeMap)
+}
+
+func (p *oddsService) createPredictionRaceRisk(raceRisk *analysis_entity.RaceRisk) *spreadsheet_entity.PredictionRaceRisk {
+	if raceRisk == nil {
+		return nil
+	}
+	signals := make([]string, 0, len(raceRisk.Signals()))
+	for _, signal := range raceRisk.Signals() {
+		// オッズ差の浮動小数点の誤差が出ないように小数第1位に丸める
+		value := math.Round(signal.Value()*10) / 10
+		signals = append(signals, fmt.Sprintf("%s:%s", signal.Name(), strconv.FormatFloat(value, 'f', -1, 64)))
+	}
+	return spreadsheet_entity.NewPredictionRaceRisk(raceRisk.Rating(), raceRisk.Probability(), signals)
 }
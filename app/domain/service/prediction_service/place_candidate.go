@@ -22,9 +22,10 @@ type PlaceCandidate interface {
 	GetRaceForecasts(ctx context.Context, raceId types.RaceId) ([]*prediction_entity.RaceForecast, error)
 	GetHorse(ctx context.Context, horseId types.HorseId) (*prediction_entity.Horse, error)
 	GetJockey(ctx context.Context, jockeyId types.JockeyId) (*prediction_entity.Jockey, error)
-	GetTrainer(ctx context.Context, trainerId types.TrainerId) (*prediction_entity.Trainer, error)
-	CreateCheckList(ctx context.Context, race *prediction_entity.Race, horse *prediction_entity.Horse, forecast *prediction_entity.RaceForecast) []bool
-	Convert(ctx context.Context, race *prediction_entity.Race, horse *prediction_entity.Horse, jockey *prediction_entity.Jockey, trainer *prediction_entity.Trainer, forecast *prediction_entity.RaceForecast, calculable []*analysis_entity.PlaceCalculable, horseNumber types.HorseNumber, marker types.Marker, checkList []bool) *spreadsheet_entity.PredictionCheckList
+	GetTrainers(ctx context.Context) (map[types.TrainerId]*prediction_entity.Trainer, error)
+	GetTrainer(ctx context.Context, trainerId types.TrainerId, trainerMap map[types.TrainerId]*prediction_entity.Trainer) (*prediction_entity.Trainer, error)
+	CreateCheckList(ctx context.Context, rules []*analysis_entity.PlaceRule, race *prediction_entity.Race, horse *prediction_entity.Horse, forecast *prediction_entity.RaceForecast, trainerPerformance *analysis_entity.TrainerPerformance, jockeyTrainerPerformance *analysis_entity.TrainerPerformance) []*analysis_entity.PlaceRuleResult
+	Convert(ctx context.Context, race *prediction_entity.Race, horse *prediction_entity.Horse, jockey *prediction_entity.Jockey, trainer *prediction_entity.Trainer, forecast *prediction_entity.RaceForecast, calculable []*analysis_entity.PlaceCalculable, horseNumber types.HorseNumber, marker types.Marker, checkList []*analysis_entity.PlaceRuleResult, placeScore *analysis_entity.PlaceScore, commentScore *analysis_entity.CommentScore) *spreadsheet_entity.PredictionCheckList
 	Write(ctx context.Context, predictionCheckList []*spreadsheet_entity.PredictionCheckList) error
 }
 
@@ -41,6 +42,8 @@ type placeCandidateService struct {
 	filterService          filter_service.PredictionFilter
 	placeCheckListService  analysis_service.PlaceCheckList
 	predictionOddsService  Odds
+	checkListService       CheckList
+	checkListSnapshot      CheckListSnapshot
 }
 
 func NewPlaceCandidate(
@@ -56,6 +59,8 @@ func NewPlaceCandidate(
 	filterService filter_service.PredictionFilter,
 	placeCheckListService analysis_service.PlaceCheckList,
 	predictionOddsService Odds,
+	checkListService CheckList,
+	checkListSnapshot CheckListSnapshot,
 ) PlaceCandidate {
 	return &placeCandidateService{
 		raceRepository:         raceRepository,
@@ -70,6 +75,8 @@ func NewPlaceCandidate(
 		filterService:          filterService,
 		placeCheckListService:  placeCheckListService,
 		predictionOddsService:  predictionOddsService,
+		checkListService:       checkListService,
+		checkListSnapshot:      checkListSnapshot,
 	}
 }
 
@@ -187,10 +194,39 @@ func (p *placeCandidateService) GetJockey(
 	return jockey, nil
 }
 
+// GetTrainers キャッシュ済みの調教師を読み込む。馬ごとに読み直さないように予想の前に1回だけ呼ぶ
+func (p *placeCandidateService) GetTrainers(
+	ctx context.Context,
+) (map[types.TrainerId]*prediction_entity.Trainer, error) {
+	rawTrainerInfo, err := p.trainerRepository.Read(ctx, fmt.Sprintf("%s/%s", config.CacheDir, trainerFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	trainerMap := map[types.TrainerId]*prediction_entity.Trainer{}
+	if rawTrainerInfo != nil {
+		for _, rawTrainer := range rawTrainerInfo.Trainers {
+			trainerMap[types.TrainerId(rawTrainer.TrainerId)] = prediction_entity.NewTrainer(
+				rawTrainer.TrainerId,
+				rawTrainer.TrainerName,
+				rawTrainer.LocationName,
+			)
+		}
+	}
+
+	return trainerMap, nil
+}
+
 func (p *placeCandidateService) GetTrainer(
 	ctx context.Context,
 	trainerId types.TrainerId,
+	trainerMap map[types.TrainerId]*prediction_entity.Trainer,
 ) (*prediction_entity.Trainer, error) {
+	if trainer, ok := trainerMap[trainerId]; ok {
+		return trainer, nil
+	}
+
+	// キャッシュにない調教師のみ取得する
 	rawTrainer, err := p.trainerRepository.Fetch(ctx, fmt.Sprintf(trainerUrl, trainerId))
 	if err != nil {
 		return nil, err
@@ -205,34 +241,22 @@ func (p *placeCandidateService) GetTrainer(
 
 func (p *placeCandidateService) CreateCheckList(
 	ctx context.Context,
+	rules []*analysis_entity.PlaceRule,
 	race *prediction_entity.Race,
 	horse *prediction_entity.Horse,
 	forecast *prediction_entity.RaceForecast,
-) []bool {
+	trainerPerformance *analysis_entity.TrainerPerformance,
+	jockeyTrainerPerformance *analysis_entity.TrainerPerformance,
+) []*analysis_entity.PlaceRuleResult {
 	input := &analysis_service.PlaceCheckListInput{
-		Race:     race,
-		Horse:    horse,
-		Forecast: forecast,
+		Race:                     race,
+		Horse:                    horse,
+		Forecast:                 forecast,
+		TrainerPerformance:       trainerPerformance,
+		JockeyTrainerPerformance: jockeyTrainerPerformance,
 	}
 
-	checkList := make([]bool, 15)
-	checkList[0] = p.placeCheckListService.OkEntries(ctx, input)
-	checkList[1] = p.placeCheckListService.OkWinOdds(ctx, input)
-	checkList[2] = p.placeCheckListService.OkInThirdPlaceRatio(ctx, input)
-	checkList[3] = p.placeCheckListService.OkNotChangeCourseCategory(ctx, input)
-	checkList[4] = p.placeCheckListService.OkSameDistance(ctx, input)
-	checkList[5] = p.placeCheckListService.OkSameCourseCategory(ctx, input)
-	checkList[6] = p.placeCheckListService.OkInThirdPlaceRecent(ctx, input)
-	checkList[7] = p.placeCheckListService.OkTrackConditionExperience(ctx, input)
-	checkList[8] = p.placeCheckListService.OkNotHorseWeightUp(ctx, input)
-	checkList[9] = p.placeCheckListService.OkNotClassUp(ctx, input)
-	checkList[10] = p.placeCheckListService.OkContinueOrEnhancementJockey(ctx, input)
-	checkList[11] = p.placeCheckListService.OkNotSlowStart(ctx, input)
-	checkList[12] = p.placeCheckListService.OkFavoriteRatio(ctx, input)
-	checkList[13] = p.placeCheckListService.OkOnlyFavoriteAndRival(ctx, input)
-	checkList[14] = p.placeCheckListService.OkIsHighlyRecommended(ctx, input)
-
-	return checkList
+	return p.placeCheckListService.CreateCheckList(ctx, rules, input)
 }
 
 func (p *placeCandidateService) Convert(
@@ -245,7 +269,9 @@ func (p *placeCandidateService) Convert(
 	calculable []*analysis_entity.PlaceCalculable,
 	horseNumber types.HorseNumber,
 	marker types.Marker,
-	checkList []bool,
+	checkList []*analysis_entity.PlaceRuleResult,
+	placeScore *analysis_entity.PlaceScore,
+	commentScore *analysis_entity.CommentScore,
 ) *spreadsheet_entity.PredictionCheckList {
 	var odds decimal.Decimal
 	for _, o := range race.Odds() {
@@ -295,6 +321,22 @@ func (p *placeCandidateService) Convert(
 		thirdPlaceRate = predictionPlaces[2].RateData().OddsRange9RateFormat()
 	}
 
+	checks := make([]bool, 0, len(checkList))
+	checkNames := make([]string, 0, len(checkList))
+	for _, result := range checkList {
+		checks = append(checks, result.Ok())
+		checkNames = append(checkNames, result.Name())
+	}
+
+	var (
+		contributions    []float64
+		placeProbability float64
+	)
+	if placeScore != nil {
+		contributions = placeScore.Contributions()
+		placeProbability = placeScore.Probability()
+	}
+
 	return spreadsheet_entity.NewPredictionCheckList(
 		race.RaceId(),
 		race.RaceDate(),
@@ -313,11 +355,18 @@ func (p *placeCandidateService) Convert(
 		firstPlaceRate,
 		secondPlaceRate,
 		thirdPlaceRate,
-		checkList,
+		checks,
+		checkNames,
+		contributions,
+		placeProbability,
+		p.checkListService.GetPositivePoint(ctx, placeScore),
+		p.checkListService.GetNegativePoint(ctx, placeScore),
 		forecast.FavoriteNum(),
 		forecast.RivalNum(),
 		forecast.MarkerNum(),
 		forecast.IsHighlyRecommended(),
+		commentScore.Score(),
+		commentScore.HasComment(),
 		forecast.TrainingComment(),
 		forecast.ReporterMemos(),
 		forecast.PaddockComment(),
@@ -329,5 +378,10 @@ func (p *placeCandidateService) Write(
 	ctx context.Context,
 	predictionCheckList []*spreadsheet_entity.PredictionCheckList,
 ) error {
-	return p.spreadSheetRepository.WritePredictionCheckList(ctx, predictionCheckList)
+	if err := p.spreadSheetRepository.WritePredictionCheckList(ctx, predictionCheckList); err != nil {
+		return err
+	}
+
+	// ダッシュボードでオフライン表示できるように書き出した内容をキャッシュに残す
+	return p.checkListSnapshot.Write(ctx, predictionCheckList)
 }
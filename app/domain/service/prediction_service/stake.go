@@ -0,0 +1,342 @@
+package prediction_service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	stakeRuleFileName = "stake_rule.json"
+	betListFileName   = "bet_list.csv"
+	// stakeTicketType 予想時点で取得しているのは単勝オッズだけなので、賭け金の提案は単勝に限る
+	// 複勝は1着率ではなく3着内率を使う必要があり、現在の複勝オッズも無いため対象外
+	stakeTicketType = types.Win
+)
+
+var stakeMarkers = []types.Marker{
+	types.Favorite,
+	types.Rival,
+	types.BrackTriangle,
+	types.WhiteTriangle,
+	types.Star,
+	types.Check,
+}
+
+type Stake interface {
+	GetRule(ctx context.Context, bankroll int) (*prediction_entity.StakeRule, error)
+	Create(ctx context.Context,
+		rule *prediction_entity.StakeRule,
+		predictionRaces []*prediction_entity.Race,
+		predictionMarkers []*marker_csv_entity.PredictionMarker,
+		placeCalculables []*analysis_entity.PlaceCalculable,
+	) []*prediction_entity.Stake
+	Convert(ctx context.Context, stakes []*prediction_entity.Stake) map[types.RaceId][]*spreadsheet_entity.PredictionStake
+	Export(ctx context.Context, stakes []*prediction_entity.Stake) error
+}
+
+type stakeService struct {
+	stakeRuleRepository repository.StakeRuleRepository
+	betListRepository   repository.BetListRepository
+}
+
+func NewStake(
+	stakeRuleRepository repository.StakeRuleRepository,
+	betListRepository repository.BetListRepository,
+) Stake {
+	return &stakeService{
+		stakeRuleRepository: stakeRuleRepository,
+		betListRepository:   betListRepository,
+	}
+}
+
+// stakeCandidate 上限で調整する前の買い目候補
+type stakeCandidate struct {
+	race        *prediction_entity.Race
+	marker      types.Marker
+	horseNumber types.HorseNumber
+	odds        decimal.Decimal
+	hitRate     float64
+	sampleCount int
+	kelly       float64
+	amount      int
+}
+
+// GetRule ルールファイルを読み込む。bankrollが0より大きければルールファイルの資金を上書きする
+func (s *stakeService) GetRule(ctx context.Context, bankroll int) (*prediction_entity.StakeRule, error) {
+	rawStakeRuleInfo, err := s.stakeRuleRepository.Read(ctx, fmt.Sprintf("%s/%s", config.RuleDir, stakeRuleFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := types.NewStakeMethod(rawStakeRuleInfo.Method)
+	if err != nil {
+		return nil, err
+	}
+	if bankroll <= 0 {
+		bankroll = rawStakeRuleInfo.Bankroll
+	}
+	if bankroll <= 0 {
+		return nil, fmt.Errorf("stake rule bankroll must be positive: %d", bankroll)
+	}
+	if rawStakeRuleInfo.Unit <= 0 {
+		return nil, fmt.Errorf("stake rule unit must be positive: %d", rawStakeRuleInfo.Unit)
+	}
+
+	return prediction_entity.NewStakeRule(
+		bankroll,
+		method,
+		rawStakeRuleInfo.KellyFraction,
+		rawStakeRuleInfo.FixedFraction,
+		rawStakeRuleInfo.FlatStake,
+		rawStakeRuleInfo.RaceCap,
+		rawStakeRuleInfo.DayCap,
+		rawStakeRuleInfo.MinSamples,
+		rawStakeRuleInfo.Unit,
+	), nil
+}
+
+// Create 印の付いた馬の単勝について、過去の同じ印・レース条件・オッズ帯の1着率と現在の単勝オッズから賭け金を決める
+// 複勝やその他の券種の賭け金は提案しない
+// 期待値がプラスでない買い目は方式に関わらず買わない。賭け金はレースごと、日ごとの上限に収まるよう按分する
+func (s *stakeService) Create(
+	ctx context.Context,
+	rule *prediction_entity.StakeRule,
+	predictionRaces []*prediction_entity.Race,
+	predictionMarkers []*marker_csv_entity.PredictionMarker,
+	placeCalculables []*analysis_entity.PlaceCalculable,
+) []*prediction_entity.Stake {
+	predictionMarkerMap := map[types.RaceId]*marker_csv_entity.PredictionMarker{}
+	for _, marker := range predictionMarkers {
+		predictionMarkerMap[marker.RaceId()] = marker
+	}
+
+	sortedRaces := make([]*prediction_entity.Race, len(predictionRaces))
+	copy(sortedRaces, predictionRaces)
+	sort.Slice(sortedRaces, func(i, j int) bool {
+		return sortedRaces[i].RaceId() < sortedRaces[j].RaceId()
+	})
+
+	raceDateCandidatesMap := map[types.RaceDate][]*stakeCandidate{}
+	var raceDates []types.RaceDate
+	for _, race := range sortedRaces {
+		predictionMarker, ok := predictionMarkerMap[race.RaceId()]
+		if !ok {
+			continue
+		}
+
+		var raceConditionFilter filter.AttributeId
+		for _, f := range race.RaceConditionFilters() {
+			raceConditionFilter |= f
+		}
+		horseNumberOddsMap := map[types.HorseNumber]decimal.Decimal{}
+		for _, o := range race.Odds() {
+			horseNumberOddsMap[o.HorseNumber()] = o.Odds()
+		}
+
+		var raceCandidates []*stakeCandidate
+		for _, marker := range stakeMarkers {
+			horseNumber := predictionMarker.MarkerMap()[marker]
+			if horseNumber == 0 {
+				continue
+			}
+			odds := horseNumberOddsMap[horseNumber]
+			oddsRange := s.winOddsRange(odds.InexactFloat64())
+			if oddsRange == types.UnknownOddsRangeType {
+				continue
+			}
+
+			hitCount, sampleCount := s.countHits(placeCalculables, marker, raceConditionFilter, oddsRange)
+			if sampleCount == 0 || sampleCount < rule.MinSamples() {
+				continue
+			}
+			hitRate := float64(hitCount) / float64(sampleCount)
+			kelly := s.kelly(hitRate, odds.InexactFloat64())
+			if kelly <= 0 {
+				continue
+			}
+
+			raceCandidates = append(raceCandidates, &stakeCandidate{
+				race:        race,
+				marker:      marker,
+				horseNumber: horseNumber,
+				odds:        odds,
+				hitRate:     hitRate,
+				sampleCount: sampleCount,
+				kelly:       kelly,
+				amount:      s.floorUnit(s.baseAmount(rule, kelly), rule.Unit()),
+			})
+		}
+		s.applyCap(raceCandidates, rule.RaceCap(), rule.Unit())
+
+		if _, ok := raceDateCandidatesMap[race.RaceDate()]; !ok {
+			raceDates = append(raceDates, race.RaceDate())
+		}
+		raceDateCandidatesMap[race.RaceDate()] = append(raceDateCandidatesMap[race.RaceDate()], raceCandidates...)
+	}
+
+	var stakes []*prediction_entity.Stake
+	for _, raceDate := range raceDates {
+		candidates := raceDateCandidatesMap[raceDate]
+		s.applyCap(candidates, rule.DayCap(), rule.Unit())
+		for _, candidate := range candidates {
+			if candidate.amount <= 0 {
+				continue
+			}
+			stakes = append(stakes, prediction_entity.NewStake(
+				candidate.race.RaceId(),
+				candidate.race.RaceDate(),
+				candidate.race.RaceCourse(),
+				candidate.race.RaceNumber(),
+				candidate.race.RaceName(),
+				stakeTicketType,
+				candidate.marker,
+				candidate.horseNumber,
+				candidate.odds,
+				candidate.hitRate,
+				candidate.sampleCount,
+				candidate.kelly,
+				candidate.amount,
+			))
+		}
+	}
+
+	return stakes
+}
+
+func (s *stakeService) Convert(
+	ctx context.Context,
+	stakes []*prediction_entity.Stake,
+) map[types.RaceId][]*spreadsheet_entity.PredictionStake {
+	stakeMap := map[types.RaceId][]*spreadsheet_entity.PredictionStake{}
+	for _, stake := range stakes {
+		stakeMap[stake.RaceId()] = append(stakeMap[stake.RaceId()], spreadsheet_entity.NewPredictionStake(
+			stake.Marker(),
+			stake.Amount(),
+			stake.ExpectedValue(),
+		))
+	}
+
+	return stakeMap
+}
+
+func (s *stakeService) Export(
+	ctx context.Context,
+	stakes []*prediction_entity.Stake,
+) error {
+	return s.betListRepository.Write(ctx, fmt.Sprintf("%s/%s", config.CsvDir, betListFileName), stakes)
+}
+
+// countHits 予想オッズシートと同じ集計単位(印・レース条件・オッズ帯)で単勝の的中となる1着数と母数を数える
+func (s *stakeService) countHits(
+	placeCalculables []*analysis_entity.PlaceCalculable,
+	marker types.Marker,
+	raceConditionFilter filter.AttributeId,
+	oddsRange types.OddsRangeType,
+) (int, int) {
+	var hitCount, sampleCount int
+	for _, calculable := range placeCalculables {
+		if calculable.Marker() != marker {
+			continue
+		}
+		match := true
+		for _, f := range calculable.Filters() {
+			if f&raceConditionFilter == 0 {
+				match = false
+				break
+			}
+		}
+		if !match || s.winOddsRange(calculable.Odds().InexactFloat64()) != oddsRange {
+			continue
+		}
+		sampleCount++
+		if calculable.OrderNo() == 1 {
+			hitCount++
+		}
+	}
+
+	return hitCount, sampleCount
+}
+
+// kelly 単勝の的中率とオッズからケリー基準の賭け率を求める。マイナスなら期待値がマイナス
+func (s *stakeService) kelly(hitRate, odds float64) float64 {
+	if odds <= 1.0 {
+		return 0
+	}
+	return (hitRate*odds - 1) / (odds - 1)
+}
+
+func (s *stakeService) baseAmount(rule *prediction_entity.StakeRule, kelly float64) float64 {
+	bankroll := float64(rule.Bankroll())
+	switch rule.Method() {
+	case types.KellyStake:
+		return bankroll * kelly
+	case types.FractionalKellyStake:
+		return bankroll * kelly * rule.KellyFraction()
+	case types.FixedFractionStake:
+		return bankroll * rule.FixedFraction()
+	case types.FlatStake:
+		return float64(rule.FlatStake())
+	}
+	return 0
+}
+
+// applyCap 合計が上限を超える場合は賭け金の比率を保ったまま上限に収める。上限0は無制限
+func (s *stakeService) applyCap(candidates []*stakeCandidate, limit, unit int) {
+	if limit <= 0 {
+		return
+	}
+	var total int
+	for _, candidate := range candidates {
+		total += candidate.amount
+	}
+	if total <= limit {
+		return
+	}
+	for _, candidate := range candidates {
+		candidate.amount = s.floorUnit(float64(candidate.amount)*float64(limit)/float64(total), unit)
+	}
+}
+
+// floorUnit 購入単位(通常100円)に切り捨てる
+func (s *stakeService) floorUnit(amount float64, unit int) int {
+	if amount <= 0 {
+		return 0
+	}
+	return int(amount) / unit * unit
+}
+
+// winOddsRange 予想オッズシートのオッズ帯に振り分ける。オッズは小数第1位までなので境界は前の帯の上限+0.1
+func (s *stakeService) winOddsRange(odds float64) types.OddsRangeType {
+	switch {
+	case odds < 1.0:
+		return types.UnknownOddsRangeType
+	case odds < 1.5:
+		return types.WinOddsRange1
+	case odds < 2.0:
+		return types.WinOddsRange2
+	case odds < 2.3:
+		return types.WinOddsRange3
+	case odds < 3.1:
+		return types.WinOddsRange4
+	case odds < 5.0:
+		return types.WinOddsRange5
+	case odds < 10.0:
+		return types.WinOddsRange6
+	case odds < 20.0:
+		return types.WinOddsRange7
+	case odds < 50.0:
+		return types.WinOddsRange8
+	}
+	return types.WinOddsRange9
+}
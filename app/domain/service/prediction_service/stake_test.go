@@ -0,0 +1,301 @@
+package prediction_service
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/shopspring/decimal"
+)
+
+func TestStakeKelly(t *testing.T) {
+	tests := []struct {
+		name    string
+		hitRate float64
+		odds    float64
+		want    float64
+	}{
+		{
+			name:    "期待値がプラス",
+			hitRate: 0.3,
+			odds:    4.0,
+			want:    0.2 / 3,
+		},
+		{
+			name:    "期待値がマイナス",
+			hitRate: 0.2,
+			odds:    4.0,
+			want:    -0.2 / 3,
+		},
+		{
+			name:    "オッズ1.0は賭けない",
+			hitRate: 0.9,
+			odds:    1.0,
+			want:    0,
+		},
+		{
+			name:    "オッズ1.0未満は賭けない",
+			hitRate: 0.9,
+			odds:    0.8,
+			want:    0,
+		},
+		{
+			name:    "的中率0",
+			hitRate: 0,
+			odds:    10.0,
+			want:    -1.0 / 9,
+		},
+	}
+
+	s := &stakeService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.kelly(tt.hitRate, tt.odds); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("kelly() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStakeAmount(t *testing.T) {
+	newRule := func(bankroll int, method types.StakeMethod, kellyFraction, fixedFraction float64, flatStake int) *prediction_entity.StakeRule {
+		return prediction_entity.NewStakeRule(bankroll, method, kellyFraction, fixedFraction, flatStake, 0, 0, 0, 100)
+	}
+
+	tests := []struct {
+		name  string
+		rule  *prediction_entity.StakeRule
+		kelly float64
+		want  int
+	}{
+		{
+			name:  "ケリー",
+			rule:  newRule(100000, types.KellyStake, 0, 0, 0),
+			kelly: 0.2 / 3,
+			want:  6600,
+		},
+		{
+			name:  "分数ケリー",
+			rule:  newRule(100000, types.FractionalKellyStake, 0.25, 0, 0),
+			kelly: 0.2 / 3,
+			want:  1600,
+		},
+		{
+			name:  "定率はケリー基準の値に関わらず資金の割合",
+			rule:  newRule(100000, types.FixedFractionStake, 0, 0.02, 0),
+			kelly: 0.2 / 3,
+			want:  2000,
+		},
+		{
+			name:  "定率で購入単位未満は0",
+			rule:  newRule(4000, types.FixedFractionStake, 0, 0.02, 0),
+			kelly: 0.2 / 3,
+			want:  0,
+		},
+		{
+			name:  "定額は購入単位に切り捨てる",
+			rule:  newRule(100000, types.FlatStake, 0, 0, 550),
+			kelly: 0.2 / 3,
+			want:  500,
+		},
+		{
+			name:  "定額0",
+			rule:  newRule(100000, types.FlatStake, 0, 0, 0),
+			kelly: 0.2 / 3,
+			want:  0,
+		},
+		{
+			name:  "ケリー基準がマイナスなら0",
+			rule:  newRule(100000, types.KellyStake, 0, 0, 0),
+			kelly: -0.2 / 3,
+			want:  0,
+		},
+	}
+
+	s := &stakeService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.floorUnit(s.baseAmount(tt.rule, tt.kelly), tt.rule.Unit()); got != tt.want {
+				t.Errorf("amount = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStakeApplyCap(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts []int
+		limit   int
+		want    []int
+	}{
+		{
+			name:  "買い目なし",
+			limit: 1000,
+		},
+		{
+			name:    "上限0は無制限",
+			amounts: []int{3000, 1000},
+			limit:   0,
+			want:    []int{3000, 1000},
+		},
+		{
+			name:    "上限以内はそのまま",
+			amounts: []int{600, 400},
+			limit:   1000,
+			want:    []int{600, 400},
+		},
+		{
+			name:    "比率を保って上限に収める",
+			amounts: []int{3000, 1000},
+			limit:   2000,
+			want:    []int{1500, 500},
+		},
+		{
+			name:    "按分後は購入単位に切り捨てる",
+			amounts: []int{700, 700, 700},
+			limit:   1000,
+			want:    []int{300, 300, 300},
+		},
+	}
+
+	s := &stakeService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := make([]*stakeCandidate, 0, len(tt.amounts))
+			for _, amount := range tt.amounts {
+				candidates = append(candidates, &stakeCandidate{amount: amount})
+			}
+			s.applyCap(candidates, tt.limit, 100)
+			for i, candidate := range candidates {
+				if candidate.amount != tt.want[i] {
+					t.Errorf("amount[%d] = %d, want %d", i, candidate.amount, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStakeCreate(t *testing.T) {
+	newRace := func(raceId string, raceNumber int, odds ...*prediction_entity.Odds) *prediction_entity.Race {
+		return prediction_entity.NewRace(raceId, "テスト", 20241020, raceNumber, 16, 1600, 0, 0, 0, 0, 0, "05", "", nil, nil, odds, []filter.AttributeId{filter.All}, nil)
+	}
+	// ◎はオッズ4.0で1着率0.3、◯はオッズ1.0、▲はオッズ8.0で1着率0.1(期待値マイナス)
+	newCalculables := func(markerCombinationId types.MarkerCombinationId, odds string, hitCount int) []*analysis_entity.PlaceCalculable {
+		calculables := make([]*analysis_entity.PlaceCalculable, 0, 10)
+		for i := 0; i < 10; i++ {
+			orderNo := 4
+			if i < hitCount {
+				orderNo = 1
+			}
+			calculables = append(calculables, analysis_entity.NewPlaceCalculable(
+				"202305040811", 20231020, markerCombinationId, decimal.RequireFromString(odds), types.BetNumber("01"), 1, orderNo, 16, "", types.UnknownRunningStyle, []filter.AttributeId{filter.All},
+			))
+		}
+		return calculables
+	}
+	var placeCalculables []*analysis_entity.PlaceCalculable
+	placeCalculables = append(placeCalculables, newCalculables(11, "4.0", 3)...)
+	placeCalculables = append(placeCalculables, newCalculables(12, "1.0", 9)...)
+	placeCalculables = append(placeCalculables, newCalculables(13, "8.0", 1)...)
+
+	races := []*prediction_entity.Race{
+		newRace("202405040811", 11,
+			prediction_entity.NewOdds("4.0", 2, 1),
+			prediction_entity.NewOdds("1.0", 1, 2),
+			prediction_entity.NewOdds("8.0", 3, 3),
+		),
+		newRace("202405040812", 12, prediction_entity.NewOdds("4.0", 2, 5)),
+	}
+	markers := []*marker_csv_entity.PredictionMarker{
+		marker_csv_entity.NewPredictionMarker("202405040811", "1", "2", "3", "", "", ""),
+		marker_csv_entity.NewPredictionMarker("202405040812", "5", "", "", "", "", ""),
+	}
+
+	type want struct {
+		raceId      types.RaceId
+		horseNumber types.HorseNumber
+		amount      int
+	}
+	tests := []struct {
+		name    string
+		rule    *prediction_entity.StakeRule
+		races   []*prediction_entity.Race
+		markers []*marker_csv_entity.PredictionMarker
+		want    []want
+	}{
+		{
+			name:    "レースなし",
+			rule:    prediction_entity.NewStakeRule(100000, types.KellyStake, 0, 0, 0, 0, 0, 0, 100),
+			markers: markers,
+		},
+		{
+			name:  "印なし",
+			rule:  prediction_entity.NewStakeRule(100000, types.KellyStake, 0, 0, 0, 0, 0, 0, 100),
+			races: races,
+		},
+		{
+			name:    "ケリー、オッズ1.0以下と期待値マイナスは買わない",
+			rule:    prediction_entity.NewStakeRule(100000, types.KellyStake, 0, 0, 0, 0, 0, 0, 100),
+			races:   races,
+			markers: markers,
+			want: []want{
+				{raceId: "202405040811", horseNumber: 1, amount: 6600},
+				{raceId: "202405040812", horseNumber: 5, amount: 6600},
+			},
+		},
+		{
+			name:    "定率、レースごとの上限",
+			rule:    prediction_entity.NewStakeRule(100000, types.FixedFractionStake, 0, 0.08, 0, 5000, 0, 0, 100),
+			races:   races,
+			markers: markers,
+			want: []want{
+				{raceId: "202405040811", horseNumber: 1, amount: 5000},
+				{raceId: "202405040812", horseNumber: 5, amount: 5000},
+			},
+		},
+		{
+			name:    "ケリー、日ごとの上限で按分",
+			rule:    prediction_entity.NewStakeRule(100000, types.KellyStake, 0, 0, 0, 0, 8000, 0, 100),
+			races:   races,
+			markers: markers,
+			want: []want{
+				{raceId: "202405040811", horseNumber: 1, amount: 4000},
+				{raceId: "202405040812", horseNumber: 5, amount: 4000},
+			},
+		},
+		{
+			name:    "母数が最低サンプル数未満",
+			rule:    prediction_entity.NewStakeRule(100000, types.KellyStake, 0, 0, 0, 0, 0, 20, 100),
+			races:   races,
+			markers: markers,
+		},
+		{
+			name:    "賭け金0は出力しない",
+			rule:    prediction_entity.NewStakeRule(100000, types.FlatStake, 0, 0, 0, 0, 0, 0, 100),
+			races:   races,
+			markers: markers,
+		},
+	}
+
+	s := &stakeService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stakes := s.Create(context.Background(), tt.rule, tt.races, tt.markers, placeCalculables)
+			if len(stakes) != len(tt.want) {
+				t.Fatalf("Create() = %d stakes, want %d", len(stakes), len(tt.want))
+			}
+			for i, stake := range stakes {
+				got := want{raceId: stake.RaceId(), horseNumber: stake.HorseNumber(), amount: stake.Amount()}
+				if got != tt.want[i] {
+					t.Errorf("stake[%d] = %+v, want %+v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package snapshot_service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/snapshot_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/config"
+)
+
+const (
+	diffSecondThreshold = 0.3 // タイムは0.3秒以上動いたものを表示する
+	diffIndexThreshold  = 3.0 // 指数は3以上動いたものを表示する
+)
+
+type DiffInput struct {
+	Sheet         types.SnapshotSheet // 空なら全シート
+	From          string              // スナップショット名か日付(yyyymmdd)。空なら比較先の1つ前
+	To            string              // スナップショット名か日付(yyyymmdd)。空なら最新
+	RateThreshold float64             // 率はこのポイント以上動いたものを表示する
+	MinCount      int                 // 母数がこれ未満の項目はぶれが大きいので比較しない
+}
+
+func (s *snapshotService) Diff(
+	ctx context.Context,
+	input *DiffInput,
+) ([]*snapshot_entity.SheetDiff, error) {
+	sheets := types.SnapshotSheets()
+	if input.Sheet != "" {
+		sheets = []types.SnapshotSheet{input.Sheet}
+	}
+
+	sheetDiffs := make([]*snapshot_entity.SheetDiff, 0, len(sheets))
+	for _, sheet := range sheets {
+		dir := fmt.Sprintf("%s/%s", config.SnapshotDir, sheet.Value())
+		names, err := s.snapshotRepository.List(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		from, to, err := s.getSnapshotNames(names, input.From, input.To)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", sheet.Value(), err)
+		}
+		// 比較できるスナップショットが無いシートは比較元・先を空で返す
+		if from == "" || to == "" {
+			sheetDiffs = append(sheetDiffs, snapshot_entity.NewSheetDiff(sheet, from, to, nil))
+			continue
+		}
+
+		fromSnapshot, err := s.snapshotRepository.Read(ctx, fmt.Sprintf("%s/%s", dir, from))
+		if err != nil {
+			return nil, err
+		}
+		toSnapshot, err := s.snapshotRepository.Read(ctx, fmt.Sprintf("%s/%s", dir, to))
+		if err != nil {
+			return nil, err
+		}
+
+		sheetDiffs = append(sheetDiffs, snapshot_entity.NewSheetDiff(sheet, from, to, s.diffBuckets(fromSnapshot, toSnapshot, input)))
+	}
+
+	return sheetDiffs, nil
+}
+
+// getSnapshotNames 日付や秒までの日時など名前の先頭だけを指定した場合は、その範囲の終わりまでに保存した最新のスナップショットを使う
+func (s *snapshotService) getSnapshotNames(
+	names []string,
+	fromName, toName string,
+) (string, string, error) {
+	find := func(name string, upperNames []string) (string, error) {
+		// 名前をそのまま指定した場合は同じ時刻に保存した連番付きのものより優先する
+		if i := sort.SearchStrings(upperNames, name); i < len(upperNames) && upperNames[i] == name {
+			return name, nil
+		}
+		for i := len(upperNames) - 1; i >= 0; i-- {
+			upperName := upperNames[i]
+			// 指定より細かいミリ秒や連番は比べずに指定した範囲に保存したものを含める
+			if len(upperName) > len(name) {
+				upperName = upperName[:len(name)]
+			}
+			if upperName <= name {
+				return upperNames[i], nil
+			}
+		}
+		return "", fmt.Errorf("snapshot not found: %s", name)
+	}
+
+	if len(names) == 0 {
+		return "", "", nil
+	}
+
+	to := names[len(names)-1]
+	if toName != "" {
+		var err error
+		if to, err = find(toName, names); err != nil {
+			return "", "", err
+		}
+	}
+
+	// 比較元は比較先より前に保存したものから探す
+	toIndex := sort.SearchStrings(names, to)
+	if fromName == "" {
+		if toIndex == 0 {
+			return "", to, nil
+		}
+		return names[toIndex-1], to, nil
+	}
+	from, err := find(fromName, names[:toIndex])
+	if err != nil {
+		return "", "", err
+	}
+
+	return from, to, nil
+}
+
+func (s *snapshotService) diffBuckets(
+	fromSnapshot, toSnapshot *raw_entity.Snapshot,
+	input *DiffInput,
+) []*snapshot_entity.Diff {
+	fromMetricMap := map[string]map[string]*raw_entity.SnapshotMetric{}
+	for _, bucket := range fromSnapshot.Buckets {
+		metricMap := make(map[string]*raw_entity.SnapshotMetric, len(bucket.Metrics))
+		for _, metric := range bucket.Metrics {
+			metricMap[metric.Name] = metric
+		}
+		fromMetricMap[bucket.Key] = metricMap
+	}
+
+	var diffs []*snapshot_entity.Diff
+	for _, bucket := range toSnapshot.Buckets {
+		// 片方にしか無いバケットは動きを判断できないので比較しない
+		metricMap, ok := fromMetricMap[bucket.Key]
+		if !ok {
+			continue
+		}
+		for _, toMetric := range bucket.Metrics {
+			fromMetric, ok := metricMap[toMetric.Name]
+			if !ok || fromMetric.Unit != toMetric.Unit {
+				continue
+			}
+			if fromMetric.Count < input.MinCount || toMetric.Count < input.MinCount {
+				continue
+			}
+			diff := snapshot_entity.NewDiff(bucket.Key, toMetric.Name, toMetric.Unit, fromMetric.Value, toMetric.Value, fromMetric.Count, toMetric.Count)
+			if diff.AbsDelta() < s.getThreshold(toMetric.Unit, input.RateThreshold) {
+				continue
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+
+	// 単位が違っても比べられるように閾値に対する動きの大きさ順に並べる
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return diffs[i].AbsDelta()/s.getThreshold(diffs[i].Unit(), input.RateThreshold) >
+			diffs[j].AbsDelta()/s.getThreshold(diffs[j].Unit(), input.RateThreshold)
+	})
+
+	return diffs
+}
+
+func (s *snapshotService) getThreshold(unit string, rateThreshold float64) float64 {
+	switch unit {
+	case rateUnit:
+		return rateThreshold
+	case secondUnit:
+		return diffSecondThreshold
+	default:
+		return diffIndexThreshold
+	}
+}
+
+func (s *snapshotService) Print(
+	ctx context.Context,
+	sheetDiffs []*snapshot_entity.SheetDiff,
+) string {
+	var builder strings.Builder
+	for _, sheetDiff := range sheetDiffs {
+		builder.WriteString(fmt.Sprintf("■ %s (%s)\n", sheetDiff.Sheet().String(), sheetDiff.Sheet().Value()))
+		if sheetDiff.From() == "" || sheetDiff.To() == "" {
+			builder.WriteString("比較できるスナップショットがありません\n\n")
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("%s → %s\n", sheetDiff.From(), sheetDiff.To()))
+		if len(sheetDiff.Diffs()) == 0 {
+			builder.WriteString("大きく動いた項目はありません\n\n")
+			continue
+		}
+
+		writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "バケット\t項目\t前回\t今回\t差分\t母数")
+		for _, diff := range sheetDiff.Diffs() {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%d→%d\n",
+				diff.Key(),
+				diff.Metric(),
+				s.formatValue(diff.Unit(), diff.FromValue()),
+				s.formatValue(diff.Unit(), diff.ToValue()),
+				s.formatDelta(diff.Unit(), diff.Delta()),
+				diff.FromCount(),
+				diff.ToCount(),
+			)
+		}
+		writer.Flush()
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+func (s *snapshotService) formatValue(unit string, value float64) string {
+	switch unit {
+	case rateUnit:
+		return fmt.Sprintf("%.2f%%", value)
+	case secondUnit:
+		return fmt.Sprintf("%.1f秒", value)
+	default:
+		return fmt.Sprintf("%.0f", value)
+	}
+}
+
+func (s *snapshotService) formatDelta(unit string, delta float64) string {
+	switch unit {
+	case rateUnit:
+		return fmt.Sprintf("%+.2fpt", delta)
+	case secondUnit:
+		return fmt.Sprintf("%+.1f秒", delta)
+	default:
+		return fmt.Sprintf("%+.0f", delta)
+	}
+}
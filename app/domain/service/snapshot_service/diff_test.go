@@ -0,0 +1,118 @@
+package snapshot_service
+
+import "testing"
+
+func TestGetSnapshotNames(t *testing.T) {
+	names := []string{
+		"20241013-090000",
+		"20241013-210000.250",
+		"20241013-210000.250-1",
+		"20241020-120000.000",
+		"20241020-235959.999",
+		"20241027-080000.500",
+	}
+
+	tests := []struct {
+		name     string
+		names    []string
+		fromName string
+		toName   string
+		wantFrom string
+		wantTo   string
+		wantErr  bool
+	}{
+		{
+			name: "スナップショットが無い",
+		},
+		{
+			name:     "スナップショットが1つだけなら比較元は空",
+			names:    names[:1],
+			wantFrom: "",
+			wantTo:   "20241013-090000",
+		},
+		{
+			name:     "省略時は最新とその1つ前",
+			names:    names,
+			wantFrom: "20241020-235959.999",
+			wantTo:   "20241027-080000.500",
+		},
+		{
+			name:     "日付はその日の最後に保存したものを使う",
+			names:    names,
+			fromName: "20241013",
+			toName:   "20241020",
+			wantFrom: "20241013-210000.250-1",
+			wantTo:   "20241020-235959.999",
+		},
+		{
+			name:     "日付に保存したものが無ければそれより前の最新を使う",
+			names:    names,
+			toName:   "20241025",
+			wantFrom: "20241020-120000.000",
+			wantTo:   "20241020-235959.999",
+		},
+		{
+			name:     "スナップショット名の指定",
+			names:    names,
+			fromName: "20241013-210000.250",
+			toName:   "20241020-120000.000",
+			wantFrom: "20241013-210000.250",
+			wantTo:   "20241020-120000.000",
+		},
+		{
+			name:     "秒までの指定はミリ秒や連番に関係なくその秒に保存したものを含める",
+			names:    names,
+			fromName: "20241013-210000",
+			toName:   "20241020-120000",
+			wantFrom: "20241013-210000.250-1",
+			wantTo:   "20241020-120000.000",
+		},
+		{
+			name:     "秒まで指定した時刻に保存したものが無ければそれより前の最新を使う",
+			names:    names,
+			toName:   "20241020-235959",
+			wantFrom: "20241020-120000.000",
+			wantTo:   "20241020-235959.999",
+		},
+		{
+			name:     "秒までの指定でミリ秒の無い古い名前",
+			names:    names,
+			toName:   "20241013-100000",
+			wantFrom: "",
+			wantTo:   "20241013-090000",
+		},
+		{
+			name:     "比較元は比較先より前から探す",
+			names:    names,
+			fromName: "20241020",
+			toName:   "20241020-120000.000",
+			wantFrom: "20241013-210000.250-1",
+			wantTo:   "20241020-120000.000",
+		},
+		{
+			name:    "比較先より前のスナップショットが無い",
+			names:   names,
+			toName:  "20241012",
+			wantErr: true,
+		},
+		{
+			name:     "比較元に指定した日付のスナップショットが無い",
+			names:    names,
+			fromName: "20241001",
+			wantErr:  true,
+		},
+	}
+
+	s := &snapshotService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := s.getSnapshotNames(tt.names, tt.fromName, tt.toName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getSnapshotNames() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if from != tt.wantFrom || to != tt.wantTo {
+				t.Errorf("getSnapshotNames() = (%q, %q), want (%q, %q)", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package snapshot_service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+)
+
+func (s *snapshotService) WriteAnalysisPlace(
+	ctx context.Context,
+	firstPlaceMap, secondPlaceMap, thirdPlaceMap map[types.Marker]map[filter.AttributeId]*spreadsheet_entity.AnalysisPlace,
+	filters []filter.AttributeId,
+) error {
+	var buckets []*raw_entity.SnapshotBucket
+	markers := []types.Marker{types.Favorite, types.Rival, types.BrackTriangle, types.WhiteTriangle, types.Star, types.Check}
+	for _, marker := range markers {
+		for idx, placeMap := range []map[types.Marker]map[filter.AttributeId]*spreadsheet_entity.AnalysisPlace{firstPlaceMap, secondPlaceMap, thirdPlaceMap} {
+			rank := idx + 1
+			for _, analysisFilter := range filters {
+				analysisPlace, ok := placeMap[marker][analysisFilter]
+				if !ok {
+					continue
+				}
+				key := fmt.Sprintf("%s/%d着/%s", marker.String(), rank, attributeFilterName(analysisFilter))
+				buckets = append(buckets, newAnalysisPlaceBucket(key, rank, analysisPlace))
+			}
+		}
+	}
+
+	return s.write(ctx, types.AnalysisPlaceSnapshot, buckets)
+}
+
+// newAnalysisPlaceBucket オッズ帯ごとの率は帯に該当した頭数を母数にする
+func newAnalysisPlaceBucket(key string, rank int, analysisPlace *spreadsheet_entity.AnalysisPlace) *raw_entity.SnapshotBucket {
+	rateData := analysisPlace.RateData()
+	hitCountData := analysisPlace.HitCountData()
+	unHitCountData := analysisPlace.UnHitCountData()
+
+	return newBucket(
+		key,
+		newMetric(fmt.Sprintf("%d着率", rank), rateUnit, rateData.HitRate(), rateData.RaceCount()),
+		newMetric(types.WinOddsRange1.String(), rateUnit, rateData.OddsRange1Rate(), hitCountData.OddsRange1Count()+unHitCountData.OddsRange1Count()),
+		newMetric(types.WinOddsRange2.String(), rateUnit, rateData.OddsRange2Rate(), hitCountData.OddsRange2Count()+unHitCountData.OddsRange2Count()),
+		newMetric(types.WinOddsRange3.String(), rateUnit, rateData.OddsRange3Rate(), hitCountData.OddsRange3Count()+unHitCountData.OddsRange3Count()),
+		newMetric(types.WinOddsRange4.String(), rateUnit, rateData.OddsRange4Rate(), hitCountData.OddsRange4Count()+unHitCountData.OddsRange4Count()),
+		newMetric(types.WinOddsRange5.String(), rateUnit, rateData.OddsRange5Rate(), hitCountData.OddsRange5Count()+unHitCountData.OddsRange5Count()),
+		newMetric(types.WinOddsRange6.String(), rateUnit, rateData.OddsRange6Rate(), hitCountData.OddsRange6Count()+unHitCountData.OddsRange6Count()),
+		newMetric(types.WinOddsRange7.String(), rateUnit, rateData.OddsRange7Rate(), hitCountData.OddsRange7Count()+unHitCountData.OddsRange7Count()),
+		newMetric(types.WinOddsRange8.String(), rateUnit, rateData.OddsRange8Rate(), hitCountData.OddsRange8Count()+unHitCountData.OddsRange8Count()),
+		newMetric(types.WinOddsRange9.String(), rateUnit, rateData.OddsRange9Rate(), hitCountData.OddsRange9Count()+unHitCountData.OddsRange9Count()),
+	)
+}
@@ -0,0 +1,63 @@
+package snapshot_service
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+)
+
+func (s *snapshotService) WriteAnalysisPlaceAllIn(
+	ctx context.Context,
+	placeAllInMap1 map[filter.AttributeId]*spreadsheet_entity.AnalysisPlaceAllIn,
+	placeAllInMap2 map[filter.MarkerCombinationId]*spreadsheet_entity.AnalysisPlaceAllIn,
+	attributeFilters []filter.AttributeId,
+	markerCombinationFilters []filter.MarkerCombinationId,
+) error {
+	buckets := make([]*raw_entity.SnapshotBucket, 0, len(attributeFilters)+len(markerCombinationFilters))
+	for _, attributeFilter := range attributeFilters {
+		placeAllIn, ok := placeAllInMap1[attributeFilter]
+		if !ok {
+			continue
+		}
+		buckets = append(buckets, newAnalysisPlaceAllInBucket(attributeFilterName(attributeFilter), placeAllIn))
+	}
+	for _, markerCombinationFilter := range markerCombinationFilters {
+		placeAllIn, ok := placeAllInMap2[markerCombinationFilter]
+		if !ok {
+			continue
+		}
+		buckets = append(buckets, newAnalysisPlaceAllInBucket("印/"+markerCombinationFilterName(markerCombinationFilter), placeAllIn))
+	}
+
+	return s.write(ctx, types.AnalysisPlaceAllInSnapshot, buckets)
+}
+
+// newAnalysisPlaceAllInBucket 項目名はシートの見出しと同じ単勝オッズにする
+func newAnalysisPlaceAllInBucket(key string, placeAllIn *spreadsheet_entity.AnalysisPlaceAllIn) *raw_entity.SnapshotBucket {
+	rateData := placeAllIn.RateData()
+	hitDataList := []struct {
+		name    string
+		hitData *spreadsheet_entity.PlaceAllInHitData
+	}{
+		{"1.1", rateData.WinOdds11HitData()}, {"1.2", rateData.WinOdds12HitData()}, {"1.3", rateData.WinOdds13HitData()},
+		{"1.4", rateData.WinOdds14HitData()}, {"1.5", rateData.WinOdds15HitData()}, {"1.6", rateData.WinOdds16HitData()},
+		{"1.7", rateData.WinOdds17HitData()}, {"1.8", rateData.WinOdds18HitData()}, {"1.9", rateData.WinOdds19HitData()},
+		{"2.0", rateData.WinOdds20HitData()}, {"2.1", rateData.WinOdds21HitData()}, {"2.2", rateData.WinOdds22HitData()},
+		{"2.3", rateData.WinOdds23HitData()}, {"2.4", rateData.WinOdds24HitData()}, {"2.5", rateData.WinOdds25HitData()},
+		{"2.6", rateData.WinOdds26HitData()}, {"2.7", rateData.WinOdds27HitData()}, {"2.8", rateData.WinOdds28HitData()},
+		{"2.9", rateData.WinOdds29HitData()}, {"3.0", rateData.WinOdds30HitData()}, {"3.1", rateData.WinOdds31HitData()},
+		{"3.2", rateData.WinOdds32HitData()}, {"3.3", rateData.WinOdds33HitData()}, {"3.4", rateData.WinOdds34HitData()},
+		{"3.5", rateData.WinOdds35HitData()}, {"3.6", rateData.WinOdds36HitData()}, {"3.7", rateData.WinOdds37HitData()},
+		{"3.8", rateData.WinOdds38HitData()}, {"3.9", rateData.WinOdds39HitData()},
+	}
+
+	metrics := make([]*raw_entity.SnapshotMetric, 0, len(hitDataList))
+	for _, data := range hitDataList {
+		metrics = append(metrics, newMetric(data.name, rateUnit, data.hitData.HitRate(), data.hitData.HitCount()+data.hitData.UnHitCount()))
+	}
+
+	return newBucket(key, metrics...)
+}
@@ -0,0 +1,60 @@
+package snapshot_service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+)
+
+func (s *snapshotService) WriteAnalysisRaceTime(
+	ctx context.Context,
+	analysisRaceTimeMap map[filter.AttributeId]*spreadsheet_entity.AnalysisRaceTime,
+	attributeFilters []filter.AttributeId,
+) error {
+	buckets := make([]*raw_entity.SnapshotBucket, 0, len(attributeFilters))
+	for _, attributeFilter := range attributeFilters {
+		analysisRaceTime, ok := analysisRaceTimeMap[attributeFilter]
+		if !ok {
+			continue
+		}
+		raceCount := analysisRaceTime.RaceCount()
+		buckets = append(buckets, newBucket(
+			attributeFilterName(attributeFilter),
+			newRaceTimeMetric("タイム", analysisRaceTime.AverageRaceTime(), raceCount),
+			newRaceTimeMetric("前3f", analysisRaceTime.AverageFirst3f(), raceCount),
+			newRaceTimeMetric("前4f", analysisRaceTime.AverageFirst4f(), raceCount),
+			newRaceTimeMetric("5f通過", analysisRaceTime.AverageRap5f(), raceCount),
+			newRaceTimeMetric("後3f", analysisRaceTime.AverageLast3f(), raceCount),
+			newRaceTimeMetric("後4f", analysisRaceTime.AverageLast4f(), raceCount),
+			newMetric("馬場(平均)", indexUnit, float64(analysisRaceTime.AverageTrackIndex()), raceCount),
+			newMetric("タイム指数", indexUnit, float64(analysisRaceTime.AverageTimeIndex()), raceCount),
+		))
+	}
+
+	return s.write(ctx, types.AnalysisRaceTimeSnapshot, buckets)
+}
+
+// newRaceTimeMetric シートに書いた "1:34.5" や "34.5" 形式のタイムを秒にする
+func newRaceTimeMetric(name, raceTime string, raceCount int) *raw_entity.SnapshotMetric {
+	var minutes float64
+	secondsText := raceTime
+	if idx := strings.Index(raceTime, ":"); idx >= 0 {
+		m, err := strconv.Atoi(raceTime[:idx])
+		if err != nil {
+			return nil
+		}
+		minutes = float64(m)
+		secondsText = raceTime[idx+1:]
+	}
+	seconds, err := strconv.ParseFloat(secondsText, 64)
+	if err != nil {
+		return nil
+	}
+
+	return newMetric(name, secondUnit, minutes*60+seconds, raceCount)
+}
@@ -0,0 +1,110 @@
+package snapshot_service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/snapshot_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+	"github.com/mapserver2007/ipat-aggregator/config"
+)
+
+const (
+	snapshotNameFormat = "20060102-150405.000" // 同じ秒に続けて実行しても名前が重ならないようにミリ秒まで付ける
+	rateUnit           = "%"
+	secondUnit         = "秒"
+	indexUnit          = ""
+)
+
+type Snapshot interface {
+	WriteSummary(ctx context.Context, summary *spreadsheet_entity.Summary) error
+	WriteAnalysisPlace(ctx context.Context,
+		firstPlaceMap, secondPlaceMap, thirdPlaceMap map[types.Marker]map[filter.AttributeId]*spreadsheet_entity.AnalysisPlace,
+		filters []filter.AttributeId,
+	) error
+	WriteAnalysisPlaceAllIn(ctx context.Context,
+		placeAllInMap1 map[filter.AttributeId]*spreadsheet_entity.AnalysisPlaceAllIn,
+		placeAllInMap2 map[filter.MarkerCombinationId]*spreadsheet_entity.AnalysisPlaceAllIn,
+		attributeFilters []filter.AttributeId,
+		markerCombinationFilters []filter.MarkerCombinationId,
+	) error
+	WriteAnalysisRaceTime(ctx context.Context,
+		analysisRaceTimeMap map[filter.AttributeId]*spreadsheet_entity.AnalysisRaceTime,
+		attributeFilters []filter.AttributeId,
+	) error
+	Diff(ctx context.Context, input *DiffInput) ([]*snapshot_entity.SheetDiff, error)
+	Print(ctx context.Context, sheetDiffs []*snapshot_entity.SheetDiff) string
+}
+
+type snapshotService struct {
+	snapshotRepository repository.SnapshotRepository
+}
+
+func NewSnapshot(
+	snapshotRepository repository.SnapshotRepository,
+) Snapshot {
+	return &snapshotService{
+		snapshotRepository: snapshotRepository,
+	}
+}
+
+// write シートごとのディレクトリに実行日時の名前で保存する
+func (s *snapshotService) write(
+	ctx context.Context,
+	sheet types.SnapshotSheet,
+	buckets []*raw_entity.SnapshotBucket,
+) error {
+	now := time.Now()
+	path := fmt.Sprintf("%s/%s/%s", config.SnapshotDir, sheet.Value(), now.Format(snapshotNameFormat))
+
+	return s.snapshotRepository.Write(ctx, path, &raw_entity.Snapshot{
+		Sheet:     sheet.Value(),
+		CreatedAt: now.Format(time.RFC3339),
+		Buckets:   buckets,
+	})
+}
+
+// newMetric 母数が無い項目は率がNaNになりjsonに書けないので保存しない
+func newMetric(name, unit string, value float64, count int) *raw_entity.SnapshotMetric {
+	if count == 0 {
+		return nil
+	}
+	return &raw_entity.SnapshotMetric{
+		Name:  name,
+		Unit:  unit,
+		Value: value,
+		Count: count,
+	}
+}
+
+func newBucket(key string, metrics ...*raw_entity.SnapshotMetric) *raw_entity.SnapshotBucket {
+	bucket := &raw_entity.SnapshotBucket{Key: key}
+	for _, metric := range metrics {
+		if metric != nil {
+			bucket.Metrics = append(bucket.Metrics, metric)
+		}
+	}
+	return bucket
+}
+
+// attributeFilterName シートの行見出しと同じくフィルタ名を連結する
+func attributeFilterName(attributeFilter filter.AttributeId) string {
+	var filterName string
+	for _, f := range attributeFilter.OriginFilters() {
+		filterName += f.String()
+	}
+	return filterName
+}
+
+func markerCombinationFilterName(markerCombinationFilter filter.MarkerCombinationId) string {
+	var filterName string
+	for _, f := range markerCombinationFilter.OriginFilters() {
+		filterName += f.String()
+	}
+	return filterName
+}
@@ -0,0 +1,88 @@
+package snapshot_service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func (s *snapshotService) WriteSummary(
+	ctx context.Context,
+	summary *spreadsheet_entity.Summary,
+) error {
+	buckets := []*raw_entity.SnapshotBucket{
+		newTicketResultBucket("累計", summary.AllTermResult()),
+		newTicketResultBucket("年間累計", summary.YearTermResult()),
+		newTicketResultBucket("月間累計", summary.MonthTermResult()),
+		newTicketResultBucket("週間累計", summary.WeekTermResult()),
+	}
+	buckets = append(buckets, newTicketResultBuckets("券種(全)", summary.TicketResultMap(), types.TicketType.Name)...)
+	buckets = append(buckets, newTicketResultBuckets("券種(年)", summary.TicketYearlyResultMap(), types.TicketType.Name)...)
+	buckets = append(buckets, newTicketResultBuckets("券種(月)", summary.TicketMonthlyResultMap(), types.TicketType.Name)...)
+	buckets = append(buckets, newTicketResultBuckets("クラス(全)", summary.GradeClassResultMap(), types.GradeClass.String)...)
+	buckets = append(buckets, newTicketResultBuckets("クラス(年)", summary.GradeClassYearlyResultMap(), types.GradeClass.String)...)
+	buckets = append(buckets, newTicketResultBuckets("クラス(月)", summary.GradeClassMonthlyResultMap(), types.GradeClass.String)...)
+	buckets = append(buckets, newTicketResultBuckets("コース(全)", summary.CourseCategoryResultMap(), types.CourseCategory.String)...)
+	buckets = append(buckets, newTicketResultBuckets("距離(全)", summary.DistanceCategoryResultMap(), types.DistanceCategory.String)...)
+	buckets = append(buckets, newTicketResultBuckets("距離(年)", summary.DistanceCategoryYearlyResultMap(), types.DistanceCategory.String)...)
+	buckets = append(buckets, newTicketResultBuckets("距離(月)", summary.DistanceCategoryMonthlyResultMap(), types.DistanceCategory.String)...)
+	buckets = append(buckets, newTicketResultBuckets("開催(全)", summary.RaceCourseResultMap(), types.RaceCourse.Name)...)
+	buckets = append(buckets, newTicketResultBuckets("開催(年)", summary.RaceCourseYearlyResultMap(), types.RaceCourse.Name)...)
+	buckets = append(buckets, newTicketResultBuckets("開催(月)", summary.RaceCourseMonthlyResultMap(), types.RaceCourse.Name)...)
+	buckets = append(buckets, newTicketResultBuckets("購入元(全)", summary.TicketSourceResultMap(), types.TicketSource.Name)...)
+	buckets = append(buckets, newTicketResultBuckets("購入元(年)", summary.TicketSourceYearlyResultMap(), types.TicketSource.Name)...)
+	buckets = append(buckets, newTicketResultBuckets("購入元(月)", summary.TicketSourceMonthlyResultMap(), types.TicketSource.Name)...)
+	buckets = append(buckets, newTicketResultBuckets("アカウント(全)", summary.AccountResultMap(), types.Account.Value)...)
+	buckets = append(buckets, newTicketResultBuckets("アカウント(年)", summary.AccountYearlyResultMap(), types.Account.Value)...)
+	buckets = append(buckets, newTicketResultBuckets("アカウント(月)", summary.AccountMonthlyResultMap(), types.Account.Value)...)
+	buckets = append(buckets, newTicketResultBuckets("年別", summary.YearlyResults(), timeFormatter("2006年"))...)
+	buckets = append(buckets, newTicketResultBuckets("月別", summary.MonthlyResults(), timeFormatter("2006年01月"))...)
+	buckets = append(buckets, newTicketResultBuckets("週別", summary.WeeklyResults(), timeFormatter("2006/01/02"))...)
+
+	return s.write(ctx, types.SummarySnapshot, buckets)
+}
+
+func newTicketResultBucket(key string, result *spreadsheet_entity.TicketResult) *raw_entity.SnapshotBucket {
+	if result == nil {
+		return newBucket(key)
+	}
+	var hitRate, payoutRate float64
+	if result.BetCount() > 0 {
+		hitRate = float64(result.HitCount()) * 100 / float64(result.BetCount())
+	}
+	if result.Payment() > 0 {
+		payoutRate = float64(result.Payout()) * 100 / float64(result.Payment())
+	}
+	return newBucket(
+		key,
+		newMetric("的中率", rateUnit, hitRate, result.BetCount()),
+		newMetric("回収率", rateUnit, payoutRate, result.BetCount()),
+	)
+}
+
+// newTicketResultBuckets シートの見出しと項目名をキーにする。キーの順に並べて実行ごとの差分を見やすくする
+func newTicketResultBuckets[T comparable](
+	label string,
+	resultMap map[T]*spreadsheet_entity.TicketResult,
+	name func(T) string,
+) []*raw_entity.SnapshotBucket {
+	buckets := make([]*raw_entity.SnapshotBucket, 0, len(resultMap))
+	for key, result := range resultMap {
+		buckets = append(buckets, newTicketResultBucket(label+"/"+name(key), result))
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Key < buckets[j].Key
+	})
+
+	return buckets
+}
+
+func timeFormatter(layout string) func(time.Time) string {
+	return func(t time.Time) string {
+		return t.Format(layout)
+	}
+}
@@ -0,0 +1,17 @@
+package types
+
+// Account 投票データを置いたcsv配下のディレクトリ名をアカウントとして扱う
+type Account string
+
+const (
+	AllAccount     Account = ""        // 全アカウント合算
+	DefaultAccount Account = "default" // csv直下に置いた投票データ
+)
+
+func NewAccount(s string) Account {
+	return Account(s)
+}
+
+func (a Account) Value() string {
+	return string(a)
+}
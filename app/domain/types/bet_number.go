@@ -32,6 +32,11 @@ func (b BetNumber) List() []int {
 	return betNumbers
 }
 
+// Grouped ながし・ボックス・フォーメーションの馬／組番をバラさずにまとめたままか
+func (b BetNumber) Grouped() bool {
+	return strings.ContainsAny(string(b), "／；")
+}
+
 func (b BetNumber) String() string {
 	// 三連複はダッシュなのでハイフンでつなぐ
 	if strings.Contains(string(b), QuinellaSeparator) {
@@ -0,0 +1,19 @@
+package types
+
+type CacheFileStatus int
+
+const (
+	CacheFileValid      CacheFileStatus = iota // チェックサムと一致
+	CacheFileNoChecksum                        // チェックサム導入前に書いたファイル
+	CacheFileCorrupt                           // チェックサム不一致、またはJSONとして読めない
+)
+
+var cacheFileStatusMap = map[CacheFileStatus]string{
+	CacheFileValid:      "valid",
+	CacheFileNoChecksum: "no checksum",
+	CacheFileCorrupt:    "corrupt",
+}
+
+func (c CacheFileStatus) String() string {
+	return cacheFileStatusMap[c]
+}
@@ -1,6 +1,11 @@
 package filter
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
 
 type AttributeId uint64
 
@@ -59,6 +64,11 @@ const (
 	ThreeYearsOld      AttributeId = 0x4
 	ThreeYearsAndOlder AttributeId = 0x2
 	FourYearsAndOlder  AttributeId = 0x1
+	// 脚質は馬単位の属性のため、レース条件(All)の範囲外に割り当てる
+	Escape  AttributeId = 0x100000000000000
+	Leading AttributeId = 0x80000000000000
+	Stalker AttributeId = 0x40000000000000
+	Closer  AttributeId = 0x20000000000000
 )
 
 var originAttributeIdMap = map[AttributeId]string{
@@ -116,6 +126,48 @@ var originAttributeIdMap = map[AttributeId]string{
 	ThreeYearsOld:      "3歳",
 	ThreeYearsAndOlder: "3歳上",
 	FourYearsAndOlder:  "4歳上",
+	Escape:             "逃げ",
+	Leading:            "先行",
+	Stalker:            "差し",
+	Closer:             "追込",
+}
+
+// NewAttributeId 0x付きを含む数値、または"芝,東京"のようなカンマ区切りの属性名から属性を組み立てる
+func NewAttributeId(s string) (AttributeId, error) {
+	if value, err := strconv.ParseUint(s, 0, 64); err == nil {
+		return AttributeId(value), nil
+	}
+
+	var attributeId AttributeId
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for id, originName := range originAttributeIdMap {
+			if originName == name {
+				attributeId |= id
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown attribute: %s", name)
+		}
+	}
+
+	return attributeId, nil
+}
+
+// OriginAttributeIds 名前を持つ単独の属性を値の大きい順に返す
+func OriginAttributeIds() []AttributeId {
+	ids := make([]AttributeId, 0, len(originAttributeIdMap))
+	for id := range originAttributeIdMap {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] > ids[j]
+	})
+
+	return ids
 }
 
 func (a AttributeId) Value() uint64 {
@@ -0,0 +1,62 @@
+package filter
+
+import "testing"
+
+func TestNewAttributeId(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    AttributeId
+		wantErr bool
+	}{
+		{
+			name: "10進数",
+			s:    "536870912",
+			want: Tokyo,
+		},
+		{
+			name: "0x付きの16進数",
+			s:    "0x10000000000000",
+			want: Turf,
+		},
+		{
+			name: "属性名",
+			s:    "芝",
+			want: Turf,
+		},
+		{
+			name: "カンマ区切りの属性名は組み合わせる",
+			s:    "芝, 東京",
+			want: Turf | Tokyo,
+		},
+		{
+			name:    "不明な属性名",
+			s:       "芝,月面",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAttributeId(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAttributeId() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NewAttributeId() = %#x, want %#x", got.Value(), tt.want.Value())
+			}
+		})
+	}
+}
+
+func TestOriginAttributeIds(t *testing.T) {
+	ids := OriginAttributeIds()
+	if len(ids) != len(originAttributeIdMap) {
+		t.Fatalf("OriginAttributeIds() = %d ids, want %d", len(ids), len(originAttributeIdMap))
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1] <= ids[i] {
+			t.Errorf("OriginAttributeIds() not descending at %d: %#x, %#x", i, ids[i-1].Value(), ids[i].Value())
+		}
+	}
+}
@@ -35,3 +35,14 @@ func (l LocationId) Name() string {
 	name, _ := locationMap[l]
 	return name
 }
+
+// Region 美浦は東、栗東は西として返す
+func (l LocationId) Region() string {
+	switch l {
+	case Miho:
+		return "東"
+	case Ritto:
+		return "西"
+	}
+	return l.Name()
+}
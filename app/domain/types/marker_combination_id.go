@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -22,6 +23,46 @@ func NewMarkerCombinationId(rawMarkerCombinationId int) (MarkerCombinationId, er
 	return MarkerCombinationId(rawMarkerCombinationId), nil
 }
 
+// NewMarkerCombinationIdByMarkers 券種と印の並びから組み合わせIDを生成する
+// 着順を問わない券種は印の並びを昇順に揃える
+func NewMarkerCombinationIdByMarkers(ticketType TicketType, markers []Marker) (MarkerCombinationId, error) {
+	var ticketTypeId int
+	switch ticketType.OriginTicketType() {
+	case Win:
+		ticketTypeId = 1
+	case Place:
+		ticketTypeId = 2
+	case QuinellaPlace:
+		ticketTypeId = 3
+	case Quinella:
+		ticketTypeId = 4
+	case Exacta:
+		ticketTypeId = 5
+	case Trio:
+		ticketTypeId = 6
+	case Trifecta:
+		ticketTypeId = 7
+	default:
+		return 0, fmt.Errorf("unsupported ticket type: %s", ticketType.Name())
+	}
+
+	sortedMarkers := make([]Marker, len(markers))
+	copy(sortedMarkers, markers)
+	switch ticketTypeId {
+	case 3, 4, 6:
+		sort.Slice(sortedMarkers, func(i, j int) bool {
+			return sortedMarkers[i] < sortedMarkers[j]
+		})
+	}
+
+	rawMarkerCombinationId := ticketTypeId
+	for _, marker := range sortedMarkers {
+		rawMarkerCombinationId = rawMarkerCombinationId*10 + marker.Value()
+	}
+
+	return NewMarkerCombinationId(rawMarkerCombinationId)
+}
+
 func (m MarkerCombinationId) Value() int {
 	return int(m)
 }
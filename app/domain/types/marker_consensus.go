@@ -0,0 +1,29 @@
+package types
+
+// MarkerConsensus 自分の◎と東スポ予想陣の◎の一致状況
+type MarkerConsensus int
+
+const (
+	UnknownConsensus MarkerConsensus = iota
+	ConsensusAgree
+	ConsensusDisagree
+	ConsensusUnmarked
+)
+
+var markerConsensusMap = map[MarkerConsensus]string{
+	UnknownConsensus:  "不明",
+	ConsensusAgree:    "一致",
+	ConsensusDisagree: "不一致",
+	ConsensusUnmarked: "無印",
+}
+
+func (m MarkerConsensus) Value() int {
+	return int(m)
+}
+
+func (m MarkerConsensus) String() string {
+	if v, ok := markerConsensusMap[m]; ok {
+		return v
+	}
+	return ""
+}
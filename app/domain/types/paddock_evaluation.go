@@ -0,0 +1,31 @@
+package types
+
+// PaddockEvaluation 東スポのパドック評価
+type PaddockEvaluation int
+
+const (
+	NoPaddockEvaluation PaddockEvaluation = iota
+	PaddockEvaluationS
+	PaddockEvaluationA
+	PaddockEvaluationB
+	PaddockEvaluationDoubt
+)
+
+var paddockEvaluationMap = map[PaddockEvaluation]string{
+	NoPaddockEvaluation:    "なし",
+	PaddockEvaluationS:     "S",
+	PaddockEvaluationA:     "A",
+	PaddockEvaluationB:     "B",
+	PaddockEvaluationDoubt: "疑",
+}
+
+func (p PaddockEvaluation) Value() int {
+	return int(p)
+}
+
+func (p PaddockEvaluation) String() string {
+	if v, ok := paddockEvaluationMap[p]; ok {
+		return v
+	}
+	return ""
+}
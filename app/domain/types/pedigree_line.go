@@ -0,0 +1,24 @@
+package types
+
+type PedigreeLine int
+
+const (
+	UnknownPedigreeLine PedigreeLine = iota
+	SireLine
+	BroodmareSireLine
+)
+
+var pedigreeLineMap = map[PedigreeLine]string{
+	UnknownPedigreeLine: "不明",
+	SireLine:            "父",
+	BroodmareSireLine:   "母父",
+}
+
+func (p PedigreeLine) Value() int {
+	return int(p)
+}
+
+func (p PedigreeLine) String() string {
+	pedigreeLineName, _ := pedigreeLineMap[p]
+	return pedigreeLineName
+}
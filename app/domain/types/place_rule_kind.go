@@ -0,0 +1,84 @@
+package types
+
+import "fmt"
+
+// PlaceRuleKind ルールファイルで指定する判定の種類
+type PlaceRuleKind string
+
+const (
+	// レース条件
+	EntriesMaxRule             PlaceRuleKind = "entries_max"              // 頭数がmax以下
+	EntriesMinRule             PlaceRuleKind = "entries_min"              // 頭数がmin以上
+	CourseCategoryDistanceRule PlaceRuleKind = "course_category_distance" // valuesのコースかつ距離がmin~max
+	TrackConditionRule         PlaceRuleKind = "track_condition"          // 今走の馬場状態がvaluesのいずれか
+	// 今走の馬
+	WinOddsUnderRule PlaceRuleKind = "win_odds_under" // 単勝オッズがmax未満
+	// 戦績
+	PlaceRatioRule                    PlaceRuleKind = "place_ratio"                      // 3着以内率がmin以上
+	SameCourseCategoryPreviousRule    PlaceRuleKind = "same_course_category_previous"    // 前走と同じ芝ダート
+	SameDistanceRecentRule            PlaceRuleKind = "same_distance_recent"             // 近runs走に同距離がある
+	SameRaceCourseRecentRule          PlaceRuleKind = "same_race_course_recent"          // 近runs走に同競馬場がある
+	PlaceRecentRule                   PlaceRuleKind = "place_recent"                     // 近runs走にorder着以内がある
+	TrackConditionPlaceExperienceRule PlaceRuleKind = "track_condition_place_experience" // 同じ馬場状態で3着以内がある
+	RaceWeightUpRule                  PlaceRuleKind = "race_weight_up"                   // 前走から斤量増
+	ClassUpRule                       PlaceRuleKind = "class_up"                         // 昇級初戦
+	ContinueOrTopJockeyRule           PlaceRuleKind = "continue_or_top_jockey"           // 継続騎乗もしくはvaluesの騎手
+	ChangeJockeyRule                  PlaceRuleKind = "change_jockey"                    // 前走から騎手乗り替わり
+	SlowStartRecentRule               PlaceRuleKind = "slow_start_recent"                // 近runs走に出遅れがある
+	PreviousOrderRule                 PlaceRuleKind = "previous_order"                   // 前走min着以下
+	RestDaysRule                      PlaceRuleKind = "rest_days"                        // 前走からmin日以上の間隔
+	DistanceChangeRule                PlaceRuleKind = "distance_change"                  // 前走から距離がminメートル以上変更
+	// 予想・厩舎
+	TospoFavoriteRatioRule        PlaceRuleKind = "tospo_favorite_ratio"          // 東スポ印◎の割合がmin以上
+	TospoOnlyFavoriteAndRivalRule PlaceRuleKind = "tospo_only_favorite_and_rival" // 東スポ印が◎◯のみ
+	HighlyRecommendedRule         PlaceRuleKind = "highly_recommended"            // 調教イチ押し
+	TrainerPlaceRateRule          PlaceRuleKind = "trainer_place_rate"            // 調教師の複勝率がmin以上(min_races走以上)
+	JockeyTrainerPlaceRateRule    PlaceRuleKind = "jockey_trainer_place_rate"     // 騎手×調教師の複勝率がmin以上(min_races走以上)
+)
+
+// placeRuleKindParams 種類ごとに必須のパラメータ
+var placeRuleKindParams = map[PlaceRuleKind][]string{
+	EntriesMaxRule:                    {"max"},
+	EntriesMinRule:                    {"min"},
+	CourseCategoryDistanceRule:        {"min", "max"},
+	TrackConditionRule:                {},
+	WinOddsUnderRule:                  {"max"},
+	PlaceRatioRule:                    {"min"},
+	SameCourseCategoryPreviousRule:    {},
+	SameDistanceRecentRule:            {"runs"},
+	SameRaceCourseRecentRule:          {"runs"},
+	PlaceRecentRule:                   {"runs", "order"},
+	TrackConditionPlaceExperienceRule: {},
+	RaceWeightUpRule:                  {},
+	ClassUpRule:                       {},
+	ContinueOrTopJockeyRule:           {},
+	ChangeJockeyRule:                  {},
+	SlowStartRecentRule:               {"runs"},
+	PreviousOrderRule:                 {"min"},
+	RestDaysRule:                      {"min"},
+	DistanceChangeRule:                {"min"},
+	TospoFavoriteRatioRule:            {"min"},
+	TospoOnlyFavoriteAndRivalRule:     {},
+	HighlyRecommendedRule:             {},
+	TrainerPlaceRateRule:              {"min", "min_races"},
+	JockeyTrainerPlaceRateRule:        {"min", "min_races"},
+}
+
+func NewPlaceRuleKind(name string, params map[string]float64) (PlaceRuleKind, error) {
+	kind := PlaceRuleKind(name)
+	requiredParams, ok := placeRuleKindParams[kind]
+	if !ok {
+		return "", fmt.Errorf("unknown place rule kind: %s", name)
+	}
+	for _, param := range requiredParams {
+		if _, ok := params[param]; !ok {
+			return "", fmt.Errorf("place rule kind %s requires param: %s", name, param)
+		}
+	}
+
+	return kind, nil
+}
+
+func (p PlaceRuleKind) String() string {
+	return string(p)
+}
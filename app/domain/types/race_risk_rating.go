@@ -0,0 +1,26 @@
+package types
+
+type RaceRiskRating int
+
+const (
+	UnknownRaceRiskRating RaceRiskRating = iota
+	SolidRace
+	RoughRace
+)
+
+var raceRiskRatingMap = map[RaceRiskRating]string{
+	UnknownRaceRiskRating: "不明",
+	SolidRace:             "堅い",
+	RoughRace:             "荒れる",
+}
+
+func (r RaceRiskRating) Value() int {
+	return int(r)
+}
+
+func (r RaceRiskRating) String() string {
+	if v, ok := raceRiskRatingMap[r]; ok {
+		return v
+	}
+	return ""
+}
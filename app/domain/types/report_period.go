@@ -0,0 +1,32 @@
+package types
+
+import "fmt"
+
+// ReportPeriod レポートの集計期間
+type ReportPeriod string
+
+const (
+	WeekReport  ReportPeriod = "week"
+	MonthReport ReportPeriod = "month"
+)
+
+var reportPeriodMap = map[ReportPeriod]string{
+	WeekReport:  "週間",
+	MonthReport: "月間",
+}
+
+func NewReportPeriod(name string) (ReportPeriod, error) {
+	reportPeriod := ReportPeriod(name)
+	if _, ok := reportPeriodMap[reportPeriod]; !ok {
+		return "", fmt.Errorf("unknown report period: %s", name)
+	}
+	return reportPeriod, nil
+}
+
+func (r ReportPeriod) Value() string {
+	return string(r)
+}
+
+func (r ReportPeriod) String() string {
+	return reportPeriodMap[r]
+}
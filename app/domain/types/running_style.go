@@ -0,0 +1,85 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+)
+
+type RunningStyle int
+
+const (
+	UnknownRunningStyle RunningStyle = iota
+	Escape
+	Leading
+	Stalker
+	Closer
+)
+
+var runningStyleMap = map[RunningStyle]string{
+	UnknownRunningStyle: "不明",
+	Escape:              "逃げ",
+	Leading:             "先行",
+	Stalker:             "差し",
+	Closer:              "追込",
+}
+
+// NewRunningStyle コーナー通過順(例: 3-3-2-1)と頭数から脚質を判定する
+// 逃げ: 最終コーナー以外のいずれかで先頭(1コーナーしかない場合はそのコーナーで先頭)
+// 先行: 最終コーナーで4番手以内
+// 差し: 最終コーナーで頭数の2/3以内
+// 追込: それ以外
+func NewRunningStyle(cornerPassingOrder string, entries int) RunningStyle {
+	positions := CornerPositions(cornerPassingOrder)
+	if len(positions) == 0 || entries == 0 {
+		return UnknownRunningStyle
+	}
+
+	lastPosition := positions[len(positions)-1]
+	if len(positions) == 1 {
+		if lastPosition == 1 {
+			return Escape
+		}
+	} else {
+		for _, position := range positions[:len(positions)-1] {
+			if position == 1 {
+				return Escape
+			}
+		}
+	}
+
+	if lastPosition <= 4 {
+		return Leading
+	}
+	if lastPosition*3 <= entries*2 {
+		return Stalker
+	}
+
+	return Closer
+}
+
+// CornerPositions コーナー通過順の文字列を各コーナーの順位に分解する
+func CornerPositions(cornerPassingOrder string) []int {
+	if cornerPassingOrder == "" {
+		return nil
+	}
+	segments := strings.Split(cornerPassingOrder, "-")
+	positions := make([]int, 0, len(segments))
+	for _, segment := range segments {
+		position, err := strconv.Atoi(strings.TrimSpace(segment))
+		if err != nil {
+			return nil
+		}
+		positions = append(positions, position)
+	}
+
+	return positions
+}
+
+func (r RunningStyle) Value() int {
+	return int(r)
+}
+
+func (r RunningStyle) String() string {
+	runningStyleName, _ := runningStyleMap[r]
+	return runningStyleName
+}
@@ -0,0 +1,46 @@
+package types
+
+import "fmt"
+
+// SnapshotSheet 実行ごとの結果を保存する集計シート
+type SnapshotSheet string
+
+const (
+	SummarySnapshot            SnapshotSheet = "summary"
+	AnalysisPlaceSnapshot      SnapshotSheet = "analysis_place"
+	AnalysisPlaceAllInSnapshot SnapshotSheet = "analysis_place_all_in"
+	AnalysisRaceTimeSnapshot   SnapshotSheet = "analysis_race_time"
+)
+
+var snapshotSheetMap = map[SnapshotSheet]string{
+	SummarySnapshot:            "集計",
+	AnalysisPlaceSnapshot:      "印別着順率",
+	AnalysisPlaceAllInSnapshot: "単勝オッズ別複勝率",
+	AnalysisRaceTimeSnapshot:   "レースタイム",
+}
+
+func NewSnapshotSheet(name string) (SnapshotSheet, error) {
+	snapshotSheet := SnapshotSheet(name)
+	if _, ok := snapshotSheetMap[snapshotSheet]; !ok {
+		return "", fmt.Errorf("unknown snapshot sheet: %s", name)
+	}
+	return snapshotSheet, nil
+}
+
+// SnapshotSheets 差分を表示する順
+func SnapshotSheets() []SnapshotSheet {
+	return []SnapshotSheet{
+		SummarySnapshot,
+		AnalysisPlaceSnapshot,
+		AnalysisPlaceAllInSnapshot,
+		AnalysisRaceTimeSnapshot,
+	}
+}
+
+func (s SnapshotSheet) Value() string {
+	return string(s)
+}
+
+func (s SnapshotSheet) String() string {
+	return snapshotSheetMap[s]
+}
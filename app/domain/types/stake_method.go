@@ -0,0 +1,36 @@
+package types
+
+import "fmt"
+
+// StakeMethod ルールファイルで指定する賭け金の決め方
+type StakeMethod string
+
+const (
+	KellyStake           StakeMethod = "kelly"            // ケリー基準
+	FractionalKellyStake StakeMethod = "fractional_kelly" // ケリー基準にkelly_fractionを掛ける
+	FixedFractionStake   StakeMethod = "fixed_fraction"   // 資金のfixed_fractionの割合
+	FlatStake            StakeMethod = "flat"             // flat_stakeの定額
+)
+
+var stakeMethodMap = map[StakeMethod]string{
+	KellyStake:           "ケリー",
+	FractionalKellyStake: "分数ケリー",
+	FixedFractionStake:   "定率",
+	FlatStake:            "定額",
+}
+
+func NewStakeMethod(name string) (StakeMethod, error) {
+	stakeMethod := StakeMethod(name)
+	if _, ok := stakeMethodMap[stakeMethod]; !ok {
+		return "", fmt.Errorf("unknown stake method: %s", name)
+	}
+	return stakeMethod, nil
+}
+
+func (s StakeMethod) Value() string {
+	return string(s)
+}
+
+func (s StakeMethod) String() string {
+	return stakeMethodMap[s]
+}
@@ -0,0 +1,32 @@
+package types
+
+type TicketSource int
+
+const (
+	UnknownTicketSource TicketSource = iota
+	Ipat
+	Umaca
+	Spat4
+	RakutenKeiba
+	Oddspark
+)
+
+var ticketSourceMap = map[TicketSource]string{
+	UnknownTicketSource: "不明",
+	Ipat:                "IPAT",
+	Umaca:               "UMACA",
+	Spat4:               "SPAT4",
+	RakutenKeiba:        "楽天競馬",
+	Oddspark:            "オッズパーク",
+}
+
+func (t TicketSource) Value() int {
+	return int(t)
+}
+
+func (t TicketSource) Name() string {
+	if v, ok := ticketSourceMap[t]; ok {
+		return v
+	}
+	return ""
+}
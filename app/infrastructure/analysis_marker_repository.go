@@ -1,18 +1,26 @@
 package infrastructure
 
 import (
+	"bytes"
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
 )
 
+const analysisMarkerColumns = 8
+
+var analysisMarkerHeader = []string{"日付", "レースID", "◎", "◯", "▲", "△", "☆", "✓"}
+
 type analysisMarkerRepository struct {
 	pathOptimizer file_gateway.PathOptimizer
 }
@@ -29,16 +37,16 @@ func (a *analysisMarkerRepository) Read(
 	ctx context.Context,
 	path string,
 ) ([]*marker_csv_entity.AnalysisMarker, error) {
-	rootPath, err := a.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := a.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
 
+	// 印のアーカイブで作られるまでは存在しないのでエラーは返さず処理を継続する
 	f, err := os.Open(absPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -46,17 +54,25 @@ func (a *analysisMarkerRepository) Read(
 
 	var markers []*marker_csv_entity.AnalysisMarker
 	reader := csv.NewReader(f)
+	// アーカイブで追記した行は末尾にバージョンの列があるので列数は揃えない
+	reader.FieldsPerRecord = -1
 	rowNum := 0
 	for {
 		record, err := reader.Read()
 		if err == io.EOF {
 			break
 		}
+		if err != nil {
+			return nil, err
+		}
 
 		if rowNum == 0 {
 			rowNum++
 			continue
 		}
+		if len(record) < analysisMarkerColumns {
+			return nil, fmt.Errorf("invalid analysis marker row %d: %v", rowNum+1, record)
+		}
 
 		marker, err := marker_csv_entity.NewAnalysisMarker(
 			record[0],
@@ -78,3 +94,93 @@ func (a *analysisMarkerRepository) Read(
 
 	return markers, nil
 }
+
+// Write ヘッダ付きでファイル全体を書き換える
+func (a *analysisMarkerRepository) Write(
+	ctx context.Context,
+	path string,
+	markers []*marker_csv_entity.AnalysisMarker,
+) error {
+	absPath, err := a.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err = writer.Write(analysisMarkerHeader); err != nil {
+		return err
+	}
+	for _, marker := range markers {
+		if err = writer.Write(a.toRecord(marker)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(absPath), 0755); err != nil {
+		return err
+	}
+
+	return file_gateway.WriteFileAtomic(absPath, buffer.Bytes())
+}
+
+// Append 既存の行はそのままに、末尾にバージョンの列を付けて追記する。ファイルが無い場合はヘッダから作る
+func (a *analysisMarkerRepository) Append(
+	ctx context.Context,
+	path string,
+	markers []*marker_csv_entity.AnalysisMarker,
+	version string,
+) error {
+	absPath, err := a.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(absPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	var buffer bytes.Buffer
+	buffer.Write(data)
+	writer := csv.NewWriter(&buffer)
+	if len(data) == 0 {
+		if err = writer.Write(analysisMarkerHeader); err != nil {
+			return err
+		}
+	} else if data[len(data)-1] != '\n' {
+		buffer.WriteString("\n")
+	}
+	for _, marker := range markers {
+		if err = writer.Write(append(a.toRecord(marker), version)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(absPath), 0755); err != nil {
+		return err
+	}
+
+	return file_gateway.WriteFileAtomic(absPath, buffer.Bytes())
+}
+
+func (a *analysisMarkerRepository) toRecord(marker *marker_csv_entity.AnalysisMarker) []string {
+	return []string{
+		strconv.Itoa(marker.RaceDate().Value()),
+		marker.RaceId().String(),
+		strconv.Itoa(marker.Favorite().Value()),
+		strconv.Itoa(marker.Rival().Value()),
+		strconv.Itoa(marker.BrackTriangle().Value()),
+		strconv.Itoa(marker.WhiteTriangle().Value()),
+		strconv.Itoa(marker.Star().Value()),
+		strconv.Itoa(marker.Check().Value()),
+	}
+}
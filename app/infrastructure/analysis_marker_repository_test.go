@@ -0,0 +1,79 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+func TestAnalysisMarkerRepositoryAppend(t *testing.T) {
+	newMarker := func(raceId string) *marker_csv_entity.AnalysisMarker {
+		marker, err := marker_csv_entity.NewAnalysisMarker("20241020", raceId, "1", "2", "3", "4", "5", "6")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return marker
+	}
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name: "ファイルが無い場合はヘッダから作る",
+			want: "日付,レースID,◎,◯,▲,△,☆,✓\n" +
+				"20241020,202405040801,1,2,3,4,5,6,20241021090000\n",
+		},
+		{
+			name: "既存の行はバージョンの列を付けずに残す",
+			existing: "日付,レースID,◎,◯,▲,△,☆,✓\n" +
+				"20241019,202405040701,6,5,4,3,2,1",
+			want: "日付,レースID,◎,◯,▲,△,☆,✓\n" +
+				"20241019,202405040701,6,5,4,3,2,1\n" +
+				"20241020,202405040801,1,2,3,4,5,6,20241021090000\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataDir := t.TempDir()
+			absPath := filepath.Join(dataDir, "csv", "analysis_marker.csv")
+			if tt.existing != "" {
+				if err := os.MkdirAll(filepath.Dir(absPath), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(absPath, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			repository := NewAnalysisMarkerRepository(file_gateway.NewPathOptimizer(&file_gateway.PathConfig{DataDir: dataDir}))
+
+			err := repository.Append(context.Background(), "csv/analysis_marker.csv", []*marker_csv_entity.AnalysisMarker{newMarker("202405040801")}, "20241021090000")
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := os.ReadFile(absPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Append() wrote %q, want %q", data, tt.want)
+			}
+
+			// バージョンの列の有無にかかわらず読み込める
+			markers, err := repository.Read(context.Background(), "csv/analysis_marker.csv")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := markers[len(markers)-1]; !reflect.DeepEqual(got, newMarker("202405040801")) {
+				t.Errorf("Read() = %+v, want appended marker", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+var betListHeader = []string{"日付", "レースID", "開催", "R", "レース名", "券種", "印", "馬番", "オッズ", "1着率", "サンプル数", "期待値", "ケリー", "金額"}
+
+type betListRepository struct {
+	pathOptimizer file_gateway.PathOptimizer
+}
+
+func NewBetListRepository(
+	pathOptimizer file_gateway.PathOptimizer,
+) repository.BetListRepository {
+	return &betListRepository{
+		pathOptimizer: pathOptimizer,
+	}
+}
+
+// Write ヘッダ付きでファイル全体を書き換える
+func (b *betListRepository) Write(
+	ctx context.Context,
+	path string,
+	stakes []*prediction_entity.Stake,
+) error {
+	absPath, err := b.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err = writer.Write(betListHeader); err != nil {
+		return err
+	}
+	for _, stake := range stakes {
+		if err = writer.Write(b.toRecord(stake)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(absPath), 0755); err != nil {
+		return err
+	}
+
+	return file_gateway.WriteFileAtomic(absPath, buffer.Bytes())
+}
+
+func (b *betListRepository) toRecord(stake *prediction_entity.Stake) []string {
+	return []string{
+		strconv.Itoa(stake.RaceDate().Value()),
+		stake.RaceId().String(),
+		stake.RaceCourse().Name(),
+		strconv.Itoa(stake.RaceNumber()),
+		stake.RaceName(),
+		stake.TicketType().Name(),
+		stake.Marker().String(),
+		strconv.Itoa(stake.HorseNumber().Value()),
+		stake.Odds().String(),
+		fmt.Sprintf("%.4f", stake.HitRate()),
+		strconv.Itoa(stake.SampleCount()),
+		fmt.Sprintf("%.3f", stake.ExpectedValue()),
+		fmt.Sprintf("%.4f", stake.Kelly()),
+		strconv.Itoa(stake.Amount()),
+	}
+}
@@ -0,0 +1,192 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+var (
+	// 買い目ファイルは列名で読むので、賭け金の提案で書き出したbet_list.csvもそのまま使える
+	betPlanRaceIdColumns     = []string{"レースID"}
+	betPlanTicketTypeColumns = []string{"式別", "券種"}
+	betPlanBetNumberColumns  = []string{"馬／組番", "買い目", "馬番"}
+	betPlanPaymentColumns    = []string{"金額", "購入金額"}
+	betSlipHeader            = []string{"日付", "受付番号", "通番", "場名", "曜日", "レース", "式別", "馬／組番", "購入金額", "的中／返還", "払戻単価", "払戻／返還金額"}
+	betSlipWeekdayNames      = []string{"日", "月", "火", "水", "木", "金", "土"}
+)
+
+type betSlipRepository struct {
+	pathOptimizer file_gateway.PathOptimizer
+}
+
+func NewBetSlipRepository(
+	pathOptimizer file_gateway.PathOptimizer,
+) repository.BetSlipRepository {
+	return &betSlipRepository{
+		pathOptimizer: pathOptimizer,
+	}
+}
+
+func (b *betSlipRepository) ReadPlan(
+	ctx context.Context,
+	path string,
+) ([]*prediction_entity.BetPlan, error) {
+	absPath, err := b.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, err
+	}
+	// Excel等で保存したUTF-8のBOMを取り除く
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columnIndex := func(names []string) int {
+		for idx, column := range header {
+			for _, name := range names {
+				if strings.TrimSpace(column) == name {
+					return idx
+				}
+			}
+		}
+		return -1
+	}
+	raceIdIndex := columnIndex(betPlanRaceIdColumns)
+	ticketTypeIndex := columnIndex(betPlanTicketTypeColumns)
+	betNumberIndex := columnIndex(betPlanBetNumberColumns)
+	paymentIndex := columnIndex(betPlanPaymentColumns)
+	if raceIdIndex < 0 || ticketTypeIndex < 0 || betNumberIndex < 0 || paymentIndex < 0 {
+		return nil, fmt.Errorf("bet plan header must have race id, ticket type, bet number and payment columns: %v", header)
+	}
+
+	var betPlans []*prediction_entity.BetPlan
+	rowNum := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rowNum++
+
+		if len(record) <= max(raceIdIndex, ticketTypeIndex, betNumberIndex, paymentIndex) || strings.TrimSpace(record[raceIdIndex]) == "" {
+			continue
+		}
+		payment, err := strconv.Atoi(strings.ReplaceAll(strings.TrimSpace(record[paymentIndex]), ",", ""))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bet plan payment at row %d: %v", rowNum, record[paymentIndex])
+		}
+
+		betPlans = append(betPlans, prediction_entity.NewBetPlan(
+			rowNum,
+			types.RaceId(strings.TrimSpace(record[raceIdIndex])),
+			strings.TrimSpace(record[ticketTypeIndex]),
+			strings.TrimSpace(record[betNumberIndex]),
+			payment,
+		))
+	}
+
+	return betPlans, nil
+}
+
+// Write PATの投票履歴CSVと同じ列、文字コード(Shift_JIS)で書き出す。受付番号と結果の列は空にする
+func (b *betSlipRepository) Write(
+	ctx context.Context,
+	path string,
+	betSlips []*prediction_entity.BetSlip,
+) error {
+	absPath, err := b.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(transform.NewWriter(&buffer, japanese.ShiftJIS.NewEncoder()))
+	if err = writer.Write(betSlipHeader); err != nil {
+		return err
+	}
+	var totalPayment int
+	for idx, betSlip := range betSlips {
+		if err = writer.Write(b.toRecord(idx, betSlip)); err != nil {
+			return err
+		}
+		totalPayment += betSlip.TotalPayment()
+	}
+	if err = writer.Write([]string{"", "", "合計", "", "", "", "", "", strconv.Itoa(totalPayment), "", "", ""}); err != nil {
+		return err
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(absPath), 0755); err != nil {
+		return err
+	}
+
+	return file_gateway.WriteFileAtomic(absPath, buffer.Bytes())
+}
+
+func (b *betSlipRepository) WriteText(
+	ctx context.Context,
+	path string,
+	text string,
+) error {
+	absPath, err := b.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(absPath), 0755); err != nil {
+		return err
+	}
+
+	return file_gateway.WriteFileAtomic(absPath, []byte(text))
+}
+
+func (b *betSlipRepository) toRecord(idx int, betSlip *prediction_entity.BetSlip) []string {
+	// ながし、フォーメーション、ボックスは投票履歴と同じく(1点あたりの購入金額)／(合計金額)にする
+	payment := strconv.Itoa(betSlip.TotalPayment())
+	if betSlip.TicketType().OriginTicketType() != betSlip.TicketType() {
+		payment = fmt.Sprintf("%d／%d", betSlip.Payment(), betSlip.TotalPayment())
+	}
+
+	return []string{
+		strconv.Itoa(betSlip.RaceDate().Value()),
+		"",
+		fmt.Sprintf("%02d", idx+1),
+		betSlip.RaceCourse().Name(),
+		betSlipWeekdayNames[betSlip.RaceDate().Date().Weekday()],
+		strconv.Itoa(betSlip.RaceNumber()),
+		betSlip.TicketType().Name(),
+		betSlip.BetNumber(),
+		payment,
+		"",
+		"",
+		"",
+	}
+}
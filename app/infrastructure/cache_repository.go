@@ -0,0 +1,140 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/gateway"
+)
+
+type cacheRepository struct {
+	pathOptimizer file_gateway.PathOptimizer
+}
+
+func NewCacheRepository(
+	pathOptimizer file_gateway.PathOptimizer,
+) repository.CacheRepository {
+	return &cacheRepository{
+		pathOptimizer: pathOptimizer,
+	}
+}
+
+// List path配下のjsonファイルを再帰的に探してチェックサムを検証する。collyのHTTPキャッシュは対象外
+func (c *cacheRepository) List(
+	ctx context.Context,
+	path string,
+) ([]*cache_entity.CacheFile, error) {
+	absPath, err := c.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cacheFiles []*cache_entity.CacheFile
+	err = filepath.WalkDir(absPath, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if filePath != absPath && entry.Name() == gateway.CollyCacheDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(absPath, filePath)
+		if err != nil {
+			return err
+		}
+		status, reason := c.verify(filePath)
+		cacheFiles = append(cacheFiles, cache_entity.NewCacheFile(
+			filepath.ToSlash(filepath.Join(path, relPath)),
+			info.Size(),
+			info.ModTime(),
+			status,
+			reason,
+		))
+
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return cacheFiles, nil
+}
+
+func (c *cacheRepository) verify(filePath string) (types.CacheFileStatus, string) {
+	err := file_gateway.VerifyFile(filePath)
+	if err == nil {
+		return types.CacheFileValid, ""
+	}
+	if !errors.Is(err, file_gateway.ErrChecksumNotFound) {
+		return types.CacheFileCorrupt, err.Error()
+	}
+
+	// チェックサムが無いファイルは最低限JSONとして読めるかを確認する
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return types.CacheFileCorrupt, err.Error()
+	}
+	if !json.Valid(data) {
+		return types.CacheFileCorrupt, "invalid json"
+	}
+
+	return types.CacheFileNoChecksum, ""
+}
+
+func (c *cacheRepository) WriteChecksum(
+	ctx context.Context,
+	path string,
+) error {
+	absPath, err := c.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+
+	return file_gateway.WriteChecksum(absPath)
+}
+
+func (c *cacheRepository) Remove(
+	ctx context.Context,
+	path string,
+) error {
+	absPath, err := c.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+
+	return file_gateway.RemoveFile(absPath)
+}
+
+func (c *cacheRepository) Quarantine(
+	ctx context.Context,
+	path string,
+) error {
+	absPath, err := c.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+
+	return file_gateway.QuarantineFile(absPath)
+}
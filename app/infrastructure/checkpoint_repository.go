@@ -0,0 +1,177 @@
+package infrastructure
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+type checkpointRepository struct {
+	pathOptimizer file_gateway.PathOptimizer
+}
+
+func NewCheckpointRepository(
+	pathOptimizer file_gateway.PathOptimizer,
+) repository.CheckpointRepository {
+	return &checkpointRepository{
+		pathOptimizer: pathOptimizer,
+	}
+}
+
+func (c *checkpointRepository) Read(
+	ctx context.Context,
+	path string,
+) ([]*raw_entity.CheckpointItem, error) {
+	filePath, err := c.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// チェックポイントが無い場合は最初から取得する
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil
+	}
+
+	var items []*raw_entity.CheckpointItem
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var item raw_entity.CheckpointItem
+		// 追記中に落ちた最後の行は壊れているので読み飛ばして再取得させる
+		if err := json.Unmarshal(line, &item); err != nil {
+			continue
+		}
+		items = append(items, &item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (c *checkpointRepository) Write(
+	ctx context.Context,
+	path string,
+	items []*raw_entity.CheckpointItem,
+) error {
+	var buf bytes.Buffer
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	filePath, err := c.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+
+	return file_gateway.WriteFileAtomic(filePath, buf.Bytes())
+}
+
+func (c *checkpointRepository) Append(
+	ctx context.Context,
+	path string,
+	item *raw_entity.CheckpointItem,
+) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	filePath, err := c.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func (c *checkpointRepository) Remove(
+	ctx context.Context,
+	path string,
+) error {
+	filePath, err := c.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (c *checkpointRepository) ReadRetryQueue(
+	ctx context.Context,
+	path string,
+) (*raw_entity.RetryQueue, error) {
+	filePath, err := c.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// ファイルが存在しない場合はエラーは返さず処理を継続する
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil
+	}
+
+	var retryQueue *raw_entity.RetryQueue
+	if err := json.Unmarshal(data, &retryQueue); err != nil {
+		return nil, err
+	}
+
+	return retryQueue, nil
+}
+
+func (c *checkpointRepository) WriteRetryQueue(
+	ctx context.Context,
+	path string,
+	data *raw_entity.RetryQueue,
+) error {
+	bytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	filePath, err := c.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+
+	return file_gateway.WriteFile(filePath, bytes)
+}
@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+type commentLexiconRepository struct {
+	pathOptimizer file_gateway.PathOptimizer
+}
+
+func NewCommentLexiconRepository(
+	pathOptimizer file_gateway.PathOptimizer,
+) repository.CommentLexiconRepository {
+	return &commentLexiconRepository{
+		pathOptimizer: pathOptimizer,
+	}
+}
+
+func (c *commentLexiconRepository) Read(
+	ctx context.Context,
+	path string,
+) (*raw_entity.CommentLexiconInfo, error) {
+	// 辞書が無いとスコアが全て0になり分析を誤るので、既定の辞書も読めなければルールと同様にエラーにする
+	bytes, err := readRuleFile(c.pathOptimizer, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var commentLexiconInfo *raw_entity.CommentLexiconInfo
+	if err := json.Unmarshal(bytes, &commentLexiconInfo); err != nil {
+		return nil, err
+	}
+
+	return commentLexiconInfo, nil
+}
@@ -0,0 +1,174 @@
+package file_gateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	checksumSuffix        = ".sha256"
+	pendingChecksumSuffix = ".sha256.pending"
+	quarantineSuffix      = ".corrupt"
+)
+
+var (
+	ErrChecksumNotFound = errors.New("checksum not found")
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// WriteFile 一時ファイルに書いてからrenameで置き換えるので、途中で落ちても書きかけのファイルは残らない
+// 書き込んだ内容のsha256を<path>.sha256に残し、VerifyFileで壊れていないか確認できるようにする
+// チェックサムは先に<path>.sha256.pendingへ書いておき、本体を置き換えてから確定させる。
+// どこで落ちても本体は確定済みのチェックサムかpendingのどちらかと一致するので、VerifyFileで復旧できる
+func WriteFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(data)
+	if err := WriteFileAtomic(pendingChecksumPath(path), []byte(hex.EncodeToString(sum[:])+"\n")); err != nil {
+		return err
+	}
+	if err := WriteFileAtomic(path, data); err != nil {
+		return err
+	}
+
+	return os.Rename(pendingChecksumPath(path), ChecksumPath(path))
+}
+
+// VerifyFile 書き込み時のチェックサムと一致するか確認する。チェックサムが無い場合はErrChecksumNotFoundを返す
+// WriteFileの途中で落ちてpendingのチェックサムが残っている場合は、本体と一致する方を確定させる
+func VerifyFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	checksum, err := readChecksum(ChecksumPath(path))
+	if err != nil {
+		return err
+	}
+	pendingChecksum, err := readChecksum(pendingChecksumPath(path))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case checksum == digest:
+		// 本体を置き換える前に落ちた場合は本体も古いままなのでpendingを捨てる
+		if pendingChecksum != "" {
+			return removeIfExists(pendingChecksumPath(path))
+		}
+		return nil
+	case pendingChecksum == digest:
+		// 本体を置き換えた後、チェックサムを確定させる前に落ちた場合
+		return os.Rename(pendingChecksumPath(path), ChecksumPath(path))
+	case checksum == "" && pendingChecksum == "":
+		return ErrChecksumNotFound
+	}
+
+	return fmt.Errorf("%w: %s", ErrChecksumMismatch, path)
+}
+
+// readChecksum チェックサムファイルが無い場合は空文字を返す
+func readChecksum(path string) (string, error) {
+	checksum, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return strings.TrimSpace(string(checksum)), nil
+}
+
+// WriteChecksum チェックサム導入前に書いたファイルの現在の内容を正としてチェックサムを残す
+func WriteChecksum(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	return WriteFileAtomic(ChecksumPath(path), []byte(hex.EncodeToString(sum[:])+"\n"))
+}
+
+// RemoveFile ファイルとチェックサムを削除する
+func RemoveFile(path string) error {
+	for _, p := range []string{path, ChecksumPath(path), pendingChecksumPath(path)} {
+		if err := removeIfExists(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QuarantineFile 壊れたファイルを削除せず<path>.corruptに退避する。チェックサムは<path>.corrupt.sha256に移す
+// 元のパスが空くので次のマスタ更新で取り直され、退避したファイルは手で確認できる
+func QuarantineFile(path string) error {
+	quarantinePath := QuarantinePath(path)
+	if err := os.Rename(path, quarantinePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Rename(ChecksumPath(path), ChecksumPath(quarantinePath)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Rename(pendingChecksumPath(path), pendingChecksumPath(quarantinePath)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func ChecksumPath(path string) string {
+	return path + checksumSuffix
+}
+
+func QuarantinePath(path string) string {
+	return path + quarantineSuffix
+}
+
+func pendingChecksumPath(path string) string {
+	return path + pendingChecksumSuffix
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// WriteFileAtomic チェックサムを残さずに一時ファイル経由で書き込む。csvなどキャッシュ以外のファイル向け
+func WriteFileAtomic(path string, data []byte) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), fmt.Sprintf(".%s.*.tmp", filepath.Base(path)))
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	// renameまで進まなかった場合は一時ファイルを残さない
+	defer os.Remove(tmpPath)
+
+	if _, err = tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmpPath, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
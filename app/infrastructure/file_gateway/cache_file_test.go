@@ -0,0 +1,129 @@
+package file_gateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyFile(t *testing.T) {
+	digest := func(data string) string {
+		sum := sha256.Sum256([]byte(data))
+		return hex.EncodeToString(sum[:]) + "\n"
+	}
+
+	tests := []struct {
+		name            string
+		data            string
+		checksum        string
+		pendingChecksum string
+		wantErr         error
+		wantChecksum    string
+	}{
+		{
+			name:         "書き込みが完了している",
+			data:         "new",
+			checksum:     digest("new"),
+			wantChecksum: digest("new"),
+		},
+		{
+			name:            "本体を置き換える前に落ちた場合は古いチェックサムのまま",
+			data:            "old",
+			checksum:        digest("old"),
+			pendingChecksum: digest("new"),
+			wantChecksum:    digest("old"),
+		},
+		{
+			name:            "本体を置き換えた後に落ちた場合はpendingを確定させる",
+			data:            "new",
+			checksum:        digest("old"),
+			pendingChecksum: digest("new"),
+			wantChecksum:    digest("new"),
+		},
+		{
+			name:            "初回の書き込みで本体を置き換えた後に落ちた",
+			data:            "new",
+			pendingChecksum: digest("new"),
+			wantChecksum:    digest("new"),
+		},
+		{
+			name:    "チェックサムが無い",
+			data:    "new",
+			wantErr: ErrChecksumNotFound,
+		},
+		{
+			name:            "どちらのチェックサムとも一致しない",
+			data:            "broken",
+			checksum:        digest("old"),
+			pendingChecksum: digest("new"),
+			wantErr:         ErrChecksumMismatch,
+			wantChecksum:    digest("old"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "race.json")
+			writeTestFile(t, path, tt.data)
+			writeTestFile(t, ChecksumPath(path), tt.checksum)
+			writeTestFile(t, pendingChecksumPath(path), tt.pendingChecksum)
+
+			err := VerifyFile(path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyFile() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && !errors.Is(tt.wantErr, ErrChecksumMismatch) {
+				return
+			}
+
+			checksum, err := os.ReadFile(ChecksumPath(path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(checksum) != tt.wantChecksum {
+				t.Errorf("checksum = %q, want %q", checksum, tt.wantChecksum)
+			}
+			if tt.wantErr == nil {
+				if _, err = os.Stat(pendingChecksumPath(path)); !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("pending checksum remains: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestQuarantineFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "race.json")
+	writeTestFile(t, path, "broken")
+	writeTestFile(t, ChecksumPath(path), "checksum\n")
+
+	if err := QuarantineFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file remains: %v", err)
+	}
+	data, err := os.ReadFile(QuarantinePath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "broken" {
+		t.Errorf("quarantined data = %q, want %q", data, "broken")
+	}
+	if _, err = os.Stat(ChecksumPath(QuarantinePath(path))); err != nil {
+		t.Errorf("quarantined checksum: %v", err)
+	}
+}
+
+func writeTestFile(t *testing.T, path, data string) {
+	t.Helper()
+	if data == "" {
+		return
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,70 @@
+package file_gateway
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const cacheLockFileName = ".lock"
+
+// CacheLock 複数のコマンドが同時にキャッシュを書き換えないようにcache配下のロックファイルを排他ロックする
+type CacheLock struct {
+	file *os.File
+}
+
+// LockCache 他のプロセスがロックしている場合は解放されるまで待つ
+func LockCache(pathConfig *PathConfig, logger *logrus.Logger) (*CacheLock, error) {
+	if err := os.MkdirAll(pathConfig.CacheDir, 0755); err != nil {
+		return nil, err
+	}
+	lockPath := filepath.Join(pathConfig.CacheDir, cacheLockFileName)
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := tryLockFile(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if !ok {
+		pid, _ := os.ReadFile(lockPath)
+		logger.Warnf("waiting for cache lock held by pid %s: %s", strings.TrimSpace(string(pid)), lockPath)
+		if err = lockFile(file); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	// どのプロセスが持っているか分かるようにpidを書いておく
+	if err = file.Truncate(0); err == nil {
+		_, err = file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+	if err != nil {
+		unlockFile(file)
+		file.Close()
+		return nil, err
+	}
+
+	return &CacheLock{file: file}, nil
+}
+
+// Unlock 解放済みの場合は何もしない
+func (c *CacheLock) Unlock() error {
+	if c == nil || c.file == nil {
+		return nil
+	}
+	defer func() {
+		c.file = nil
+	}()
+	if err := unlockFile(c.file); err != nil {
+		c.file.Close()
+		return err
+	}
+	return c.file.Close()
+}
@@ -0,0 +1,20 @@
+//go:build windows
+
+package file_gateway
+
+import (
+	"os"
+)
+
+// windowsはflockが無いのでロックせずに処理を続ける
+func tryLockFile(file *os.File) (bool, error) {
+	return true, nil
+}
+
+func lockFile(file *os.File) error {
+	return nil
+}
+
+func unlockFile(file *os.File) error {
+	return nil
+}
@@ -3,49 +3,209 @@ package file_gateway
 import (
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/config"
 )
 
 type PathOptimizer interface {
-	GetProjectRoot() (string, error)
-	// TODO 相対パス渡したら絶対パス返すメソッド
+	GetAbsPath(path string) (string, error)
 }
 
 const (
 	targetFileName = "go.mod"
+	moduleName     = "github.com/mapserver2007/ipat-aggregator"
+	appName        = "ipat-aggregator"
+	logFileName    = "ipat-aggregator.log"
+	metricsName    = "ipat-aggregator-metrics.jsonl"
 )
 
-func NewPathOptimizer() PathOptimizer {
-	return &pathOptimizer{}
+// PathConfig 投票データ、キャッシュ、秘密情報、ログ、メトリクスの置き場所
+type PathConfig struct {
+	DataDir     string // csv、ruleを置くディレクトリ
+	CacheDir    string // cache配下に置いていたキャッシュのディレクトリ
+	SecretDir   string // secret配下に置いていた認証情報のディレクトリ
+	LogFile     string
+	MetricsFile string // 実行ごとの集計結果を追記するファイル
+}
+
+// NewPathConfig 指定がない場所はソースのチェックアウト内で動いていればリポジトリ直下、
+// インストールしたバイナリとして動いていればXDG Base Directoryの場所を使う
+func NewPathConfig(
+	dataDir string,
+	cacheDir string,
+	secretDir string,
+	logFile string,
+	metricsFile string,
+) (*PathConfig, error) {
+	rootPath, ok, err := getProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultConfig *PathConfig
+	if ok {
+		defaultConfig = &PathConfig{
+			DataDir:   rootPath,
+			CacheDir:  filepath.Join(rootPath, config.CacheDir),
+			SecretDir: filepath.Join(rootPath, config.SecretDir),
+			LogFile:   filepath.Join(os.TempDir(), logFileName),
+		}
+	} else {
+		defaultConfig, err = getXdgPathConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pathConfig := &PathConfig{
+		DataDir:   dataDir,
+		CacheDir:  cacheDir,
+		SecretDir: secretDir,
+		LogFile:   logFile,
+	}
+	if pathConfig.DataDir == "" {
+		pathConfig.DataDir = defaultConfig.DataDir
+	}
+	if pathConfig.CacheDir == "" {
+		pathConfig.CacheDir = defaultConfig.CacheDir
+	}
+	if pathConfig.SecretDir == "" {
+		pathConfig.SecretDir = defaultConfig.SecretDir
+	}
+	if pathConfig.LogFile == "" {
+		pathConfig.LogFile = defaultConfig.LogFile
+	}
+	if metricsFile == "" {
+		// ログと同じ場所に置く
+		metricsFile = filepath.Join(filepath.Dir(pathConfig.LogFile), metricsName)
+	}
+	pathConfig.MetricsFile = metricsFile
+
+	for _, dir := range []string{pathConfig.DataDir, pathConfig.CacheDir, pathConfig.SecretDir, filepath.Dir(pathConfig.LogFile), filepath.Dir(pathConfig.MetricsFile)} {
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	return pathConfig, nil
+}
+
+func NewPathOptimizer(
+	pathConfig *PathConfig,
+) PathOptimizer {
+	return &pathOptimizer{
+		pathConfig: pathConfig,
+	}
+}
+
+type pathOptimizer struct {
+	pathConfig *PathConfig
 }
 
-type pathOptimizer struct{}
+// GetAbsPath csv/xxx、cache/xxx、secret/xxxのようなチェックアウト内の相対パスを設定されたディレクトリの絶対パスにする
+func (p *pathOptimizer) GetAbsPath(path string) (string, error) {
+	path = filepath.ToSlash(path)
+	segments := strings.SplitN(path, "/", 2)
+	rest := ""
+	if len(segments) == 2 {
+		rest = segments[1]
+	}
+
+	switch segments[0] {
+	case config.CacheDir:
+		return filepath.Abs(filepath.Join(p.pathConfig.CacheDir, rest))
+	case config.SecretDir:
+		return filepath.Abs(filepath.Join(p.pathConfig.SecretDir, rest))
+	}
 
-func (p *pathOptimizer) GetProjectRoot() (string, error) {
+	return filepath.Abs(filepath.Join(p.pathConfig.DataDir, path))
+}
+
+// getProjectRoot 実行ファイル、カレントディレクトリの順にこのモジュールのgo.modを探してチェックアウトのルートを求める
+// go.modが無くてもカレントディレクトリにcsvがあれば従来の配置とみなす
+func getProjectRoot() (string, bool, error) {
 	execPath, err := os.Executable()
 	if err != nil {
-		return "", err
+		return "", false, err
+	}
+	if rootPath, ok := findModRoot(filepath.Dir(execPath)); ok {
+		return rootPath, true, nil
 	}
 
-	execDir := filepath.Dir(execPath)
+	workDir, err := os.Getwd()
+	if err != nil {
+		return "", false, err
+	}
+	if rootPath, ok := findModRoot(workDir); ok {
+		return rootPath, true, nil
+	}
+	if info, err := os.Stat(filepath.Join(workDir, config.CsvDir)); err == nil && info.IsDir() {
+		return workDir, true, nil
+	}
 
+	return "", false, nil
+}
+
+// findModRoot 親ディレクトリを辿ってgo.modを探す。別のモジュールのgo.modは無視して上を探す
+func findModRoot(dir string) (string, bool) {
 	for {
-		modPath := filepath.Join(execDir, targetFileName)
-		if _, err := os.Stat(modPath); err == nil {
-			return execDir, nil
+		if isProjectModFile(filepath.Join(dir, targetFileName)) {
+			return dir, true
 		}
 
-		parentDir := filepath.Dir(execDir)
-		if parentDir == execDir {
-			break
+		parentDir := filepath.Dir(dir)
+		if parentDir == dir {
+			return "", false
 		}
 
-		execDir = parentDir
+		dir = parentDir
 	}
+}
 
-	rootPath, err := os.Getwd()
+// isProjectModFile go.modのmodule行がこのリポジトリのモジュール名か
+func isProjectModFile(modPath string) bool {
+	bytes, err := os.ReadFile(modPath)
 	if err != nil {
-		return "", err
+		return false
+	}
+	for _, line := range strings.Split(string(bytes), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`) == moduleName
+		}
+	}
+
+	return false
+}
+
+func getXdgPathConfig() (*PathConfig, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(homeDir, ".local", "share")
+	}
+	cacheHome := os.Getenv("XDG_CACHE_HOME")
+	if cacheHome == "" {
+		cacheHome = filepath.Join(homeDir, ".cache")
+	}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(homeDir, ".config")
+	}
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		stateHome = filepath.Join(homeDir, ".local", "state")
 	}
 
-	return rootPath, nil
+	return &PathConfig{
+		DataDir:   filepath.Join(dataHome, appName),
+		CacheDir:  filepath.Join(cacheHome, appName),
+		SecretDir: filepath.Join(configHome, appName),
+		LogFile:   filepath.Join(stateHome, appName, logFileName),
+	}, nil
 }
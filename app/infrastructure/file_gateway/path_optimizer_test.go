@@ -0,0 +1,102 @@
+package file_gateway
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindModRoot(t *testing.T) {
+	tests := []struct {
+		name      string
+		modFiles  map[string]string
+		startDir  string
+		wantDir   string
+		wantFound bool
+	}{
+		{
+			name:      "このモジュールのgo.mod",
+			modFiles:  map[string]string{"repo": "module github.com/mapserver2007/ipat-aggregator\n\ngo 1.22\n"},
+			startDir:  "repo/cmd",
+			wantDir:   "repo",
+			wantFound: true,
+		},
+		{
+			name:     "別のモジュールのgo.modは使わない",
+			modFiles: map[string]string{"other": "module example.com/other\n"},
+			startDir: "other/bin",
+		},
+		{
+			name: "別のモジュールの上にあるこのモジュールのgo.mod",
+			modFiles: map[string]string{
+				"repo":       "// comment\nmodule \"github.com/mapserver2007/ipat-aggregator\"\n",
+				"repo/tools": "module github.com/mapserver2007/ipat-aggregator/tools\n",
+			},
+			startDir:  "repo/tools/bin",
+			wantDir:   "repo",
+			wantFound: true,
+		},
+		{
+			name:     "go.modが無い",
+			startDir: "bin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for dir, data := range tt.modFiles {
+				if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+					t.Fatal(err)
+				}
+				writeTestFile(t, filepath.Join(root, dir, targetFileName), data)
+			}
+			startDir := filepath.Join(root, tt.startDir)
+			if err := os.MkdirAll(startDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			dir, found := findModRoot(startDir)
+			// TempDirより上にこのモジュールのgo.modは無い前提
+			if found != tt.wantFound {
+				t.Fatalf("findModRoot() found = %v, want %v", found, tt.wantFound)
+			}
+			if found && dir != filepath.Join(root, tt.wantDir) {
+				t.Errorf("findModRoot() = %q, want %q", dir, filepath.Join(root, tt.wantDir))
+			}
+		})
+	}
+}
+
+func TestGetAbsPath(t *testing.T) {
+	pathConfig := &PathConfig{
+		DataDir:   "/data",
+		CacheDir:  "/cache",
+		SecretDir: "/secret",
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "csv", path: "csv/ticket.csv", want: "/data/csv/ticket.csv"},
+		{name: "rule", path: "rule/place_rule.json", want: "/data/rule/place_rule.json"},
+		{name: "cache", path: "cache/race/race.json", want: "/cache/race/race.json"},
+		{name: "secret", path: "secret/token.json", want: "/secret/token.json"},
+		{name: "cacheディレクトリ自体", path: "cache", want: "/cache"},
+	}
+
+	p := &pathOptimizer{pathConfig: pathConfig}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.GetAbsPath(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != filepath.FromSlash(tt.want) {
+				t.Errorf("GetAbsPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package gateway
+
+import (
+	neturl "net/url"
+	"regexp"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
+)
+
+var raceIdPathRegex = regexp.MustCompile(`/race/(?:detail/)?(\d+)`)
+
+// logFetch ページの取得結果をurl、race_id、所要時間、ステータスのフィールド付きで記録する
+func logFetch(
+	logger *logrus.Logger,
+	page string,
+	url string,
+	startTime time.Time,
+	cached bool,
+	err error,
+) {
+	fields := logrus.Fields{
+		config.LogFieldPage:     page,
+		config.LogFieldUrl:      url,
+		config.LogFieldDuration: time.Since(startTime).Milliseconds(),
+		config.LogFieldStatus:   fetchStatus(cached, err),
+	}
+	if raceId := getRaceIdFromUrl(url); raceId != "" {
+		fields[config.LogFieldRaceId] = raceId
+	}
+
+	entry := logger.WithFields(fields)
+	if err != nil {
+		entry.Errorf("failed to fetch %s: %v", page, err)
+		return
+	}
+	entry.Infof("fetched %s", page)
+}
+
+// fetchStatus 取得エラーの有無とキャッシュ利用からステータスを決める
+func fetchStatus(cached bool, err error) string {
+	if err != nil {
+		return config.FetchStatusError
+	}
+	if cached {
+		return config.FetchStatusCache
+	}
+	return config.FetchStatusFetched
+}
+
+// ignoreUnreachable 存在しないページへのアクセスで返るEOFは取得エラーとして数えない
+func ignoreUnreachable(err error) error {
+	if err != nil && err.Error() == "EOF" {
+		return nil
+	}
+	return err
+}
+
+func getRaceIdFromUrl(url string) string {
+	parsedUrl, err := neturl.Parse(url)
+	if err != nil {
+		return ""
+	}
+	if raceId := parsedUrl.Query().Get("race_id"); raceId != "" {
+		return raceId
+	}
+	if matches := raceIdPathRegex.FindStringSubmatch(parsedUrl.Path); len(matches) == 2 {
+		return matches[1]
+	}
+
+	return ""
+}
@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/config"
+)
+
+func TestGetRaceIdFromUrl(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "クエリのrace_id",
+			url:  "https://race.netkeiba.com/race/result.html?race_id=202405040811&rf=race_list",
+			want: "202405040811",
+		},
+		{
+			name: "パスのレースID",
+			url:  "https://db.netkeiba.com/race/202405040811/",
+			want: "202405040811",
+		},
+		{
+			name: "detail付きのパス",
+			url:  "https://example.com/race/detail/202405040811",
+			want: "202405040811",
+		},
+		{
+			name: "レースIDを含まない",
+			url:  "https://db.netkeiba.com/horse/2020100001/",
+			want: "",
+		},
+		{
+			name: "URLとして不正",
+			url:  "://race_id=1",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getRaceIdFromUrl(tt.url); got != tt.want {
+				t.Errorf("getRaceIdFromUrl() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		cached bool
+		err    error
+		want   string
+	}{
+		{
+			name: "取得",
+			want: config.FetchStatusFetched,
+		},
+		{
+			name:   "キャッシュ",
+			cached: true,
+			want:   config.FetchStatusCache,
+		},
+		{
+			name:   "エラーはキャッシュより優先",
+			cached: true,
+			err:    errors.New("timeout"),
+			want:   config.FetchStatusError,
+		},
+		{
+			name: "存在しないページのEOFはエラーにしない",
+			err:  ignoreUnreachable(errors.New("EOF")),
+			want: config.FetchStatusFetched,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fetchStatus(tt.cached, tt.err); got != tt.want {
+				t.Errorf("fetchStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,21 +2,26 @@ package gateway
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"path/filepath"
 
 	"github.com/gocolly/colly"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+	"github.com/mapserver2007/ipat-aggregator/config"
 )
 
 type NetKeibaCollector interface {
 	Client() *colly.Collector
 	Cookies(ctx context.Context) ([]*http.Cookie, error)
 	Cache(c bool) bool
+	IsCached(url string) bool
 	Login(ctx context.Context) error
 }
 
@@ -28,7 +33,7 @@ type netKeibaCollector struct {
 const (
 	netKeibaBaseUrl     = "https://www.netkeiba.com"
 	collectorConfigName = "netkeiba_collector_config.json"
-	collyCacheDir       = "colly"
+	CollyCacheDir       = "colly"
 )
 
 func NewNetKeibaCollector(
@@ -52,11 +57,10 @@ func (n *netKeibaCollector) Client() *colly.Collector {
 
 func (n *netKeibaCollector) Cache(c bool) bool {
 	if c {
-		rootPath, err := n.pathOptimizer.GetProjectRoot()
+		cachePath, err := n.pathOptimizer.GetAbsPath(fmt.Sprintf("%s/%s", config.CacheDir, CollyCacheDir))
 		if err != nil {
 			return false
 		}
-		cachePath, _ := filepath.Abs(fmt.Sprintf("%s/cache/%s", rootPath, collyCacheDir))
 		n.client.CacheDir = cachePath
 	} else {
 		n.client.CacheDir = ""
@@ -65,13 +69,26 @@ func (n *netKeibaCollector) Cache(c bool) bool {
 	return true
 }
 
-func (n *netKeibaCollector) Cookies(ctx context.Context) ([]*http.Cookie, error) {
-	rootPath, err := n.pathOptimizer.GetProjectRoot()
+// IsCached collyと同じ規則でキャッシュファイルの場所を求め、Visit前にキャッシュ済みか判定する
+func (n *netKeibaCollector) IsCached(url string) bool {
+	if n.client.CacheDir == "" {
+		return false
+	}
+	parsedUrl, err := neturl.Parse(url)
 	if err != nil {
-		return nil, err
+		return false
+	}
+	sum := sha1.Sum([]byte(parsedUrl.String()))
+	hash := hex.EncodeToString(sum[:])
+	if _, err = os.Stat(filepath.Join(n.client.CacheDir, hash[:2], hash)); err != nil {
+		return false
 	}
 
-	secretFilePath, err := filepath.Abs(fmt.Sprintf("%s/secret/%s", rootPath, collectorConfigName))
+	return true
+}
+
+func (n *netKeibaCollector) Cookies(ctx context.Context) ([]*http.Cookie, error) {
+	secretFilePath, err := n.pathOptimizer.GetAbsPath(fmt.Sprintf("%s/%s", config.SecretDir, collectorConfigName))
 	if err != nil {
 		return nil, err
 	}
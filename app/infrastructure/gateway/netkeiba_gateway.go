@@ -67,8 +67,10 @@ func (n *netKeibaGateway) FetchRaceId(
 		rawRaceIds = append(rawRaceIds, raceId)
 	})
 
-	n.logger.Infof("fetching race id from %s", url)
+	cached := n.collector.IsCached(url)
+	fetchStartTime := time.Now()
 	err := n.collector.Client().Visit(url)
+	logFetch(n.logger, "race id", url, fetchStartTime, cached, ignoreUnreachable(err))
 	if err != nil {
 		if err.Error() == "EOF" { // unreachable url
 			return nil, nil
@@ -105,159 +107,19 @@ func (n *netKeibaGateway) FetchRace(
 	n.collector.Client().OnHTML("#All_Result_Table", func(e *colly.HTMLElement) {
 		raceTime = e.DOM.Find(".Time > .RaceTime").Eq(0).Text()
 		e.ForEach("tr.HorseList", func(i int, ce *colly.HTMLElement) {
-			var numbers []int
-			var oddsList []string
 			query := ce.Request.URL.Query()
 			rawCurrentOrganizer, _ := strconv.Atoi(query.Get("organizer"))
 			currentOrganizer := types.NewOrganizer(rawCurrentOrganizer)
-
-			if currentOrganizer == types.JRA {
-				ce.ForEach(".Num > div", func(j int, ce2 *colly.HTMLElement) {
-					num, _ := strconv.Atoi(ce2.DOM.Text())
-					numbers = append(numbers, num)
-				})
-				ce.ForEach(".Odds span", func(j int, ce2 *colly.HTMLElement) {
-					oddsList = append(oddsList, ce2.DOM.Text())
-				})
-
-				popularNumber, _ := strconv.Atoi(oddsList[0])
-				linkUrl, _ := ce.DOM.Find(".Jockey > a").Attr("href")
-				regex := regexp.MustCompile(`(\d{5})`)
-				result := regex.FindStringSubmatch(linkUrl)
-				// 一部の騎手で引っかからないjockeyIdの場合があるが、ダミーIDで不明扱いしておく
-				jockeyId := "00000"
-				if result != nil {
-					jockeyId = result[1]
-				}
-				horseName := Trim(ce.DOM.Find(".Horse_Name > a").Text())
-				linkUrl, _ = ce.DOM.Find(".Horse_Name > a").Attr("href")
-				segments := strings.Split(linkUrl, "/")
-				horseId := segments[4]
-				orderNo, _ := strconv.Atoi(ce.DOM.Find(".Rank").Text())
-
-				jockeyWeight := ce.DOM.Find(".JockeyWeight").Text()
-				regex = regexp.MustCompile(`(\d+)\s*\(([-+]\d+|.+)\)`)
-				matches := regex.FindStringSubmatch(Trim(ce.DOM.Find(".Weight").Text()))
-
-				var horseWeight, horseWeightAdd int
-				if len(matches) == 3 {
-					horseWeight, _ = strconv.Atoi(matches[1])
-					if matches[2] != "前計不" { // 前走海外の例が少ないので、前走海外は0として扱う
-						horseWeightAdd, _ = strconv.Atoi(matches[2])
-					}
-				}
-
-				raceResults = append(raceResults, netkeiba_entity.NewRaceResult(
-					orderNo,
-					horseId,
-					horseName,
-					numbers[0],
-					numbers[1],
-					jockeyId,
-					oddsList[1],
-					popularNumber,
-					jockeyWeight,
-					horseWeight,
-					horseWeightAdd,
-				))
-			} else if currentOrganizer == types.OverseaOrganizer {
-				ce.ForEach(".Num > div", func(j int, ce2 *colly.HTMLElement) {
-					num, _ := strconv.Atoi(ce2.DOM.Text())
-					numbers = append(numbers, num)
-				})
-				ce.ForEach(".Odds span", func(j int, ce2 *colly.HTMLElement) {
-					oddsList = append(oddsList, ce2.DOM.Text())
-				})
-				popularNumber, _ := strconv.Atoi(oddsList[0])
-				linkUrl, _ := ce.DOM.Find(".Jockey > a").Attr("href")
-				regex := regexp.MustCompile(`(\d{5})`)
-				result := regex.FindStringSubmatch(linkUrl)
-				// 一部の騎手で引っかからないjockeyIdの場合があるが、ダミーIDで不明扱いしておく
-				jockeyId := "00000"
-				if result != nil {
-					jockeyId = result[1]
-				}
-				horseName := Trim(ce.DOM.Find(".Horse_Name > a").Text())
-				linkUrl, _ = ce.DOM.Find(".Horse_Name > a").Attr("href")
-				segments := strings.Split(linkUrl, "/")
-				horseId := segments[4]
-				orderNo, _ := strconv.Atoi(ce.DOM.Find(".Rank").Text())
-
-				jockeyWeight := ce.DOM.Find(".JockeyWeight").Text()
-
-				raceResults = append(raceResults, netkeiba_entity.NewRaceResult(
-					orderNo,
-					horseId,
-					horseName,
-					numbers[0],
-					numbers[1],
-					jockeyId,
-					oddsList[1],
-					popularNumber,
-					jockeyWeight,
-					0,
-					0,
-				))
+			if currentOrganizer == types.JRA || currentOrganizer == types.OverseaOrganizer {
+				raceResults = append(raceResults, n.parseRaceResult(ce, currentOrganizer))
 			}
 		})
 		e.ForEach("#All_Result_Table > tbody > tr", func(i int, ce *colly.HTMLElement) {
-			var numbers []int
-			var oddsList []string
 			query := ce.Request.URL.Query()
 			rawCurrentOrganizer, _ := strconv.Atoi(query.Get("organizer"))
 			currentOrganizer := types.NewOrganizer(rawCurrentOrganizer)
-
 			if currentOrganizer == types.NAR {
-				ce.ForEach(".Num > div", func(j int, ce2 *colly.HTMLElement) {
-					num, _ := strconv.Atoi(ce2.DOM.Text())
-					numbers = append(numbers, num)
-				})
-				ce.ForEach(".Odds span", func(j int, ce2 *colly.HTMLElement) {
-					oddsList = append(oddsList, ce2.DOM.Text())
-				})
-				popularNumber, _ := strconv.Atoi(oddsList[0])
-				linkUrl, _ := ce.DOM.Find(".Jockey > a").Attr("href")
-				regex := regexp.MustCompile(`(\d{5})`)
-				result := regex.FindStringSubmatch(linkUrl)
-				// 一部の騎手で引っかからないjockeyIdの場合があるが、ダミーIDで不明扱いしておく
-				jockeyId := "00000"
-				if result != nil {
-					jockeyId = result[1]
-				}
-				horseName := Trim(ce.DOM.Find(".Horse_Name > a").Text())
-				linkUrl, _ = ce.DOM.Find(".Horse_Name > a").Attr("href")
-				segments := strings.Split(linkUrl, "/")
-				horseId := segments[4]
-				orderNo, _ := strconv.Atoi(ce.DOM.Find(".Rank").Text())
-
-				jockeyWeight := ce.DOM.Find(".JockeyWeight").Text()
-				regex = regexp.MustCompile(`(\d+)\s*\(([-+]\d+|.+)\)`)
-				matches := regex.FindStringSubmatch(Trim(ce.DOM.Find(".Weight").Text()))
-
-				var horseWeight, horseWeightAdd int
-				if len(matches) == 3 {
-					if matches[2] != "前計不" { // 前計不の場合はhorseWeightAddを0に設定
-						horseWeight, _ = strconv.Atoi(matches[1])
-						horseWeightAdd, _ = strconv.Atoi(matches[2])
-					} else {
-						horseWeight = 0 // 前計不の場合はhorseWeightを0に設定
-						horseWeightAdd = 0
-					}
-				}
-
-				raceResults = append(raceResults, netkeiba_entity.NewRaceResult(
-					orderNo,
-					horseId,
-					horseName,
-					numbers[0],
-					numbers[1],
-					jockeyId,
-					oddsList[1],
-					popularNumber,
-					jockeyWeight,
-					horseWeight,
-					horseWeightAdd,
-				))
+				raceResults = append(raceResults, n.parseRaceResult(ce, currentOrganizer))
 			}
 		})
 	})
@@ -742,8 +604,16 @@ func (n *netKeibaGateway) FetchRace(
 		return nil, err
 	}
 
-	n.logger.Infof("fetching race from %s", url)
+	cache := true
+	if queryParams.Get("cache") == "false" {
+		cache = false
+	}
+	n.collector.Cache(cache)
+
+	cached := n.collector.IsCached(url)
+	fetchStartTime := time.Now()
 	err = n.collector.Client().Visit(url)
+	logFetch(n.logger, "race", url, fetchStartTime, cached, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to visit url: %s, %v", url, err)
 	}
@@ -773,6 +643,83 @@ func (n *netKeibaGateway) FetchRace(
 	), nil
 }
 
+// parseRaceResult レース結果の1行を読む。JRA、地方、海外で列の並びは同じで馬体重の扱いだけが異なる
+func (n *netKeibaGateway) parseRaceResult(
+	ce *colly.HTMLElement,
+	organizer types.Organizer,
+) *netkeiba_entity.RaceResult {
+	var numbers []int
+	var oddsList []string
+	ce.ForEach(".Num > div", func(j int, ce2 *colly.HTMLElement) {
+		num, _ := strconv.Atoi(ce2.DOM.Text())
+		numbers = append(numbers, num)
+	})
+	ce.ForEach(".Odds span", func(j int, ce2 *colly.HTMLElement) {
+		oddsList = append(oddsList, ce2.DOM.Text())
+	})
+
+	popularNumber, _ := strconv.Atoi(oddsList[0])
+	linkUrl, _ := ce.DOM.Find(".Jockey > a").Attr("href")
+	regex := regexp.MustCompile(`(\d{5})`)
+	result := regex.FindStringSubmatch(linkUrl)
+	// 一部の騎手で引っかからないjockeyIdの場合があるが、ダミーIDで不明扱いしておく
+	jockeyId := "00000"
+	if result != nil {
+		jockeyId = result[1]
+	}
+	horseName := Trim(ce.DOM.Find(".Horse_Name > a").Text())
+	linkUrl, _ = ce.DOM.Find(".Horse_Name > a").Attr("href")
+	segments := strings.Split(linkUrl, "/")
+	horseId := segments[4]
+	orderNo, _ := strconv.Atoi(ce.DOM.Find(".Rank").Text())
+
+	jockeyWeight := ce.DOM.Find(".JockeyWeight").Text()
+	// タイム、着差、上り3Fはいずれも.Timeのセルに並んでいる
+	margin := Trim(ce.DOM.Find("td.Time").Eq(1).Text())
+	lastThreeFurlong := Trim(ce.DOM.Find("td.Time").Eq(2).Text())
+	cornerPassingOrder := Trim(ce.DOM.Find(".PassageRate").Text())
+	linkUrl, _ = ce.DOM.Find(".Trainer > a").Attr("href")
+	regex = regexp.MustCompile(`trainer/(?:result/recent/)?([0-9a-z]+)`)
+	trainerId := ""
+	if result = regex.FindStringSubmatch(linkUrl); result != nil {
+		trainerId = result[1]
+	}
+
+	// 海外は馬体重を扱わない
+	var horseWeight, horseWeightAdd int
+	if organizer != types.OverseaOrganizer {
+		regex = regexp.MustCompile(`(\d+)\s*\(([-+]\d+|.+)\)`)
+		matches := regex.FindStringSubmatch(Trim(ce.DOM.Find(".Weight").Text()))
+		if len(matches) == 3 {
+			if matches[2] != "前計不" {
+				horseWeight, _ = strconv.Atoi(matches[1])
+				horseWeightAdd, _ = strconv.Atoi(matches[2])
+			} else if organizer == types.JRA {
+				// 前走海外の例が少ないので、前走海外は増減0として扱う。地方の前計不は馬体重も0にする
+				horseWeight, _ = strconv.Atoi(matches[1])
+			}
+		}
+	}
+
+	return netkeiba_entity.NewRaceResult(
+		orderNo,
+		horseId,
+		horseName,
+		numbers[0],
+		numbers[1],
+		jockeyId,
+		oddsList[1],
+		popularNumber,
+		jockeyWeight,
+		horseWeight,
+		horseWeightAdd,
+		cornerPassingOrder,
+		lastThreeFurlong,
+		margin,
+		trainerId,
+	)
+}
+
 func (n *netKeibaGateway) FetchRaceCard(
 	ctx context.Context,
 	url string,
@@ -1096,8 +1043,10 @@ func (n *netKeibaGateway) FetchRaceCard(
 		})
 	})
 
-	n.logger.Infof("fetching race card from %s", url)
+	cached := n.collector.IsCached(url)
+	fetchStartTime := time.Now()
 	err = n.collector.Client().Visit(url)
+	logFetch(n.logger, "race card", url, fetchStartTime, cached, err)
 	if err != nil {
 		return nil, err
 	}
@@ -1146,9 +1095,10 @@ func (n *netKeibaGateway) FetchJockey(
 	regex := regexp.MustCompile(`\/jockey\/([0-9a-z]+)\/`)
 	result := regex.FindStringSubmatch(url)
 
-	n.logger.Infof("fetching jockey from %s", url)
-
+	cached := n.collector.IsCached(url)
+	fetchStartTime := time.Now()
 	err := n.collector.Client().Visit(url)
+	logFetch(n.logger, "jockey", url, fetchStartTime, cached, ignoreUnreachable(err))
 	if err != nil {
 		if err.Error() == "EOF" { // unreachable url
 			return netkeiba_entity.NewJockey(result[1], ""), nil
@@ -1170,6 +1120,7 @@ func (n *netKeibaGateway) FetchHorse(
 		horseId, horseName            string
 		trainerId, ownerId, breederId string
 		sireId, broodmareSireId       string
+		sireName, broodmareSireName   string
 		birthDay                      int
 		horseBlood                    *netkeiba_entity.HorseBlood
 		horseResults                  []*netkeiba_entity.HorseResult
@@ -1237,13 +1188,15 @@ func (n *netKeibaGateway) FetchHorse(
 				path, _ := ce.DOM.Find("td:nth-child(1) a").Attr("href")
 				segments = strings.Split(path, "/")
 				sireId = segments[3]
+				sireName = Trim(ce.DOM.Find("td:nth-child(1) a").First().Text())
 			case 2:
 				path, _ := ce.DOM.Find("td:nth-child(2) a").Attr("href")
 				segments = strings.Split(path, "/")
 				broodmareSireId = segments[3]
+				broodmareSireName = Trim(ce.DOM.Find("td:nth-child(2) a").First().Text())
 			}
 		})
-		horseBlood = netkeiba_entity.NewHorseBlood(sireId, broodmareSireId)
+		horseBlood = netkeiba_entity.NewHorseBlood(sireId, sireName, broodmareSireId, broodmareSireName)
 	})
 
 	n.collector.Client().OnHTML("table.db_h_race_results tbody", func(e *colly.HTMLElement) {
@@ -1357,9 +1310,10 @@ func (n *netKeibaGateway) FetchHorse(
 		n.logger.Errorf("GetHorse error: %v", err)
 	})
 
-	n.logger.Infof("fetching horse from %s", url)
-
+	cached := n.collector.IsCached(url)
+	fetchStartTime := time.Now()
 	err = n.collector.Client().Visit(url)
+	logFetch(n.logger, "horse", url, fetchStartTime, cached, err)
 	if err != nil {
 		return nil, err
 	}
@@ -1398,9 +1352,10 @@ func (n *netKeibaGateway) FetchTrainer(
 		locationName = Trim(segments[3])
 	})
 
-	n.logger.Infof("fetching trainer from %s", url)
-
+	cached := n.collector.IsCached(url)
+	fetchStartTime := time.Now()
 	err := n.collector.Client().Visit(url)
+	logFetch(n.logger, "trainer", url, fetchStartTime, cached, err)
 	if err != nil {
 		return nil, err
 	}
@@ -1450,13 +1405,16 @@ func (n *netKeibaGateway) FetchMarker(
 	}
 
 	client := &http.Client{}
+	fetchStartTime := time.Now()
 	res, err := client.Do(req)
 	if err != nil {
+		logFetch(n.logger, "marker", url, fetchStartTime, false, err)
 		return nil, err
 	}
 	defer res.Body.Close()
 
 	body, err := io.ReadAll(res.Body)
+	logFetch(n.logger, "marker", url, fetchStartTime, false, err)
 	if err != nil {
 		return nil, err
 	}
@@ -1484,8 +1442,6 @@ func (n *netKeibaGateway) FetchMarker(
 		markers = append(markers, marker)
 	}
 
-	n.logger.Infof("fetching marker from %s", url)
-
 	return markers, nil
 }
 
@@ -1496,13 +1452,15 @@ func (n *netKeibaGateway) FetchWinOdds(
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
-	n.logger.Infof("fetching win odds from %s", url)
+	fetchStartTime := time.Now()
 	res, err := http.Get(url)
 	if err != nil {
+		logFetch(n.logger, "win odds", url, fetchStartTime, false, err)
 		return nil, err
 	}
 	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
+	logFetch(n.logger, "win odds", url, fetchStartTime, false, err)
 	if err != nil {
 		return nil, err
 	}
@@ -1550,13 +1508,15 @@ func (n *netKeibaGateway) FetchPlaceOdds(
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
-	n.logger.Infof("fetching place odds from %s", url)
+	fetchStartTime := time.Now()
 	res, err := http.Get(url)
 	if err != nil {
+		logFetch(n.logger, "place odds", url, fetchStartTime, false, err)
 		return nil, err
 	}
 	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
+	logFetch(n.logger, "place odds", url, fetchStartTime, false, err)
 	if err != nil {
 		return nil, err
 	}
@@ -1604,13 +1564,15 @@ func (n *netKeibaGateway) FetchQuinellaOdds(
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
-	n.logger.Infof("fetching quinella odds from %s", url)
+	fetchStartTime := time.Now()
 	res, err := http.Get(url)
 	if err != nil {
+		logFetch(n.logger, "quinella odds", url, fetchStartTime, false, err)
 		return nil, err
 	}
 	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
+	logFetch(n.logger, "quinella odds", url, fetchStartTime, false, err)
 	if err != nil {
 		return nil, err
 	}
@@ -1652,13 +1614,15 @@ func (n *netKeibaGateway) FetchTrioOdds(
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
-	n.logger.Infof("fetching trio odds from %s", url)
+	fetchStartTime := time.Now()
 	res, err := http.Get(url)
 	if err != nil {
+		logFetch(n.logger, "trio odds", url, fetchStartTime, false, err)
 		return nil, err
 	}
 	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
+	logFetch(n.logger, "trio odds", url, fetchStartTime, false, err)
 	if err != nil {
 		return nil, err
 	}
@@ -1775,9 +1739,10 @@ func (n *netKeibaGateway) FetchRaceTime(
 		n.logger.Errorf("FetchRaceTime error: %v", err)
 	})
 
-	n.logger.Infof("fetching race time from %s", url)
-
+	cached := n.collector.IsCached(url)
+	fetchStartTime := time.Now()
 	err = n.collector.Client().Visit(url)
+	logFetch(n.logger, "race time", url, fetchStartTime, cached, err)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,238 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	spreadSheetAnalysisMarkerConsensusFileName = "spreadsheet_analysis_marker_consensus.json"
+)
+
+type SpreadSheetAnalysisMarkerConsensusGateway interface {
+	Write(ctx context.Context, analysisMarkerConsensuses []*spreadsheet_entity.AnalysisMarkerConsensus) error
+	Style(ctx context.Context, analysisMarkerConsensuses []*spreadsheet_entity.AnalysisMarkerConsensus) error
+	Clear(ctx context.Context) error
+}
+
+type spreadSheetAnalysisMarkerConsensusGateway struct {
+	spreadSheetConfigGateway SpreadSheetConfigGateway
+	logger                   *logrus.Logger
+}
+
+func NewSpreadSheetAnalysisMarkerConsensusGateway(
+	spreadSheetConfigGateway SpreadSheetConfigGateway,
+	logger *logrus.Logger,
+) SpreadSheetAnalysisMarkerConsensusGateway {
+	return &spreadSheetAnalysisMarkerConsensusGateway{
+		spreadSheetConfigGateway: spreadSheetConfigGateway,
+		logger:                   logger,
+	}
+}
+
+func (s *spreadSheetAnalysisMarkerConsensusGateway) Write(
+	ctx context.Context,
+	analysisMarkerConsensuses []*spreadsheet_entity.AnalysisMarkerConsensus,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisMarkerConsensusFileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis marker consensus start")
+	writeRange := fmt.Sprintf("%s!%s", config.SheetName(), "A1")
+	values := [][]any{
+		{
+			"条件",
+			"一致状況",
+			"レース数",
+			"割合",
+			"1着",
+			"複勝圏",
+			"勝率",
+			"複勝率",
+			"単回収率",
+			"複回収率",
+		},
+	}
+
+	for _, analysisMarkerConsensus := range analysisMarkerConsensuses {
+		var conditionNames []string
+		for _, originFilter := range analysisMarkerConsensus.AttributeId().OriginFilters() {
+			conditionNames = append(conditionNames, originFilter.String())
+		}
+
+		values = append(values, []any{
+			strings.Join(conditionNames, "・"),
+			analysisMarkerConsensus.Consensus().String(),
+			analysisMarkerConsensus.RaceCount(),
+			analysisMarkerConsensus.RaceRate(),
+			analysisMarkerConsensus.WinCount(),
+			analysisMarkerConsensus.PlaceCount(),
+			analysisMarkerConsensus.WinRate(),
+			analysisMarkerConsensus.PlaceRate(),
+			analysisMarkerConsensus.WinPayoutRate(),
+			analysisMarkerConsensus.PlacePayoutRate(),
+		})
+	}
+
+	_, err = client.Spreadsheets.Values.Update(config.SpreadSheetId(), writeRange, &sheets.ValueRange{
+		Values: values,
+	}).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis marker consensus end")
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisMarkerConsensusGateway) Style(
+	ctx context.Context,
+	analysisMarkerConsensuses []*spreadsheet_entity.AnalysisMarkerConsensus,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisMarkerConsensusFileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis marker consensus style start")
+	requests := make([]*sheets.Request, 0)
+	requests = append(requests, s.createBackgroundColorRequest(
+		config.SheetId(),
+		0, 0, 10, 1,
+		1.0, 1.0, 0.0,
+	))
+	requests = append(requests, s.createTextBoldRequest(
+		config.SheetId(),
+		0, 0, 10, 1,
+		true,
+	))
+
+	for idx, analysisMarkerConsensus := range analysisMarkerConsensuses {
+		rowNum := 1 + idx
+		// 回収率が100%を超えた行を強調し、それ以外は条件の先頭行を区切りとして色付けする
+		if analysisMarkerConsensus.IsProfitable() {
+			requests = append(requests, s.createBackgroundColorRequest(
+				config.SheetId(),
+				0, rowNum, 10, rowNum+1,
+				1.0, 0.8, 0.8,
+			))
+			continue
+		}
+		if idx == 0 || analysisMarkerConsensus.AttributeId() == analysisMarkerConsensuses[idx-1].AttributeId() {
+			continue
+		}
+		requests = append(requests, s.createBackgroundColorRequest(
+			config.SheetId(),
+			0, rowNum, 10, rowNum+1,
+			0.85, 0.85, 0.85,
+		))
+	}
+
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis marker consensus style end")
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisMarkerConsensusGateway) Clear(ctx context.Context) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisMarkerConsensusFileName)
+	if err != nil {
+		return err
+	}
+
+	requests := []*sheets.Request{
+		{
+			RepeatCell: &sheets.RepeatCellRequest{
+				Fields: "*",
+				Range: &sheets.GridRange{
+					SheetId:          config.SheetId(),
+					StartColumnIndex: 0,
+					StartRowIndex:    0,
+					EndColumnIndex:   10,
+					EndRowIndex:      9999,
+				},
+				Cell: &sheets.CellData{},
+			},
+		},
+	}
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisMarkerConsensusGateway) createTextBoldRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	bold bool,
+) *sheets.Request {
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.textFormat.bold",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: &sheets.CellFormat{
+					TextFormat: &sheets.TextFormat{
+						Bold: bold,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *spreadSheetAnalysisMarkerConsensusGateway) createBackgroundColorRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	red, green, blue float64,
+) *sheets.Request {
+	cellFormat := &sheets.CellFormat{
+		BackgroundColor: &sheets.Color{
+			Red:   red,
+			Green: green,
+			Blue:  blue,
+		},
+	}
+
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.backgroundColor,userEnteredFormat.numberFormat,userEnteredFormat.textFormat",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: cellFormat,
+			},
+		},
+	}
+}
@@ -0,0 +1,222 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	spreadSheetAnalysisMarkerTicketFileName = "spreadsheet_analysis_marker_ticket.json"
+)
+
+type SpreadSheetAnalysisMarkerTicketGateway interface {
+	Write(ctx context.Context, analysisMarkerTickets []*spreadsheet_entity.AnalysisMarkerTicket) error
+	Style(ctx context.Context, analysisMarkerTickets []*spreadsheet_entity.AnalysisMarkerTicket) error
+	Clear(ctx context.Context) error
+}
+
+type spreadSheetAnalysisMarkerTicketGateway struct {
+	spreadSheetConfigGateway SpreadSheetConfigGateway
+	logger                   *logrus.Logger
+}
+
+func NewSpreadSheetAnalysisMarkerTicketGateway(
+	spreadSheetConfigGateway SpreadSheetConfigGateway,
+	logger *logrus.Logger,
+) SpreadSheetAnalysisMarkerTicketGateway {
+	return &spreadSheetAnalysisMarkerTicketGateway{
+		spreadSheetConfigGateway: spreadSheetConfigGateway,
+		logger:                   logger,
+	}
+}
+
+func (s *spreadSheetAnalysisMarkerTicketGateway) Write(
+	ctx context.Context,
+	analysisMarkerTickets []*spreadsheet_entity.AnalysisMarkerTicket,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisMarkerTicketFileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis marker ticket start")
+	writeRange := fmt.Sprintf("%s!%s", config.SheetName(), "A1")
+	values := [][]any{
+		{
+			"区分",
+			"券種",
+			"印",
+			"購入数",
+			"的中数",
+			"的中率",
+			"投資",
+			"回収",
+			"回収率",
+		},
+	}
+
+	for _, analysisMarkerTicket := range analysisMarkerTickets {
+		values = append(values, []any{
+			analysisMarkerTicket.Category(),
+			analysisMarkerTicket.TicketTypeName(),
+			analysisMarkerTicket.MarkerName(),
+			analysisMarkerTicket.BetCount(),
+			analysisMarkerTicket.HitCount(),
+			analysisMarkerTicket.HitRate(),
+			analysisMarkerTicket.Payment(),
+			analysisMarkerTicket.Payout(),
+			analysisMarkerTicket.PayoutRate(),
+		})
+	}
+
+	_, err = client.Spreadsheets.Values.Update(config.SpreadSheetId(), writeRange, &sheets.ValueRange{
+		Values: values,
+	}).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis marker ticket end")
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisMarkerTicketGateway) Style(
+	ctx context.Context,
+	analysisMarkerTickets []*spreadsheet_entity.AnalysisMarkerTicket,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisMarkerTicketFileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis marker ticket style start")
+	requests := make([]*sheets.Request, 0)
+	requests = append(requests, s.createBackgroundColorRequest(
+		config.SheetId(),
+		0, 0, 9, 1,
+		1.0, 1.0, 0.0,
+	))
+	requests = append(requests, s.createTextBoldRequest(
+		config.SheetId(),
+		0, 0, 9, 1,
+		true,
+	))
+
+	for idx, analysisMarkerTicket := range analysisMarkerTickets {
+		// 買い方の区分の先頭行を区切りとして色付けする
+		if idx == 0 || analysisMarkerTicket.Category() == analysisMarkerTickets[idx-1].Category() {
+			continue
+		}
+		rowNum := 1 + idx
+		requests = append(requests, s.createBackgroundColorRequest(
+			config.SheetId(),
+			0, rowNum, 9, rowNum+1,
+			0.85, 0.85, 0.85,
+		))
+	}
+
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis marker ticket style end")
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisMarkerTicketGateway) Clear(ctx context.Context) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisMarkerTicketFileName)
+	if err != nil {
+		return err
+	}
+
+	requests := []*sheets.Request{
+		{
+			RepeatCell: &sheets.RepeatCellRequest{
+				Fields: "*",
+				Range: &sheets.GridRange{
+					SheetId:          config.SheetId(),
+					StartColumnIndex: 0,
+					StartRowIndex:    0,
+					EndColumnIndex:   9,
+					EndRowIndex:      9999,
+				},
+				Cell: &sheets.CellData{},
+			},
+		},
+	}
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisMarkerTicketGateway) createTextBoldRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	bold bool,
+) *sheets.Request {
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.textFormat.bold",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: &sheets.CellFormat{
+					TextFormat: &sheets.TextFormat{
+						Bold: bold,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *spreadSheetAnalysisMarkerTicketGateway) createBackgroundColorRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	red, green, blue float64,
+) *sheets.Request {
+	cellFormat := &sheets.CellFormat{
+		BackgroundColor: &sheets.Color{
+			Red:   red,
+			Green: green,
+			Blue:  blue,
+		},
+	}
+
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.backgroundColor,userEnteredFormat.numberFormat,userEnteredFormat.textFormat",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: cellFormat,
+			},
+		},
+	}
+}
@@ -0,0 +1,241 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	spreadSheetAnalysisPedigreeFileName = "spreadsheet_analysis_pedigree.json"
+	sireUrl                             = "https://db.netkeiba.com/horse/%s/"
+)
+
+type SpreadSheetAnalysisPedigreeGateway interface {
+	Write(ctx context.Context, analysisPedigrees []*spreadsheet_entity.AnalysisPedigree) error
+	Style(ctx context.Context, analysisPedigrees []*spreadsheet_entity.AnalysisPedigree) error
+	Clear(ctx context.Context) error
+}
+
+type spreadSheetAnalysisPedigreeGateway struct {
+	spreadSheetConfigGateway SpreadSheetConfigGateway
+	logger                   *logrus.Logger
+}
+
+func NewSpreadSheetAnalysisPedigreeGateway(
+	spreadSheetConfigGateway SpreadSheetConfigGateway,
+	logger *logrus.Logger,
+) SpreadSheetAnalysisPedigreeGateway {
+	return &spreadSheetAnalysisPedigreeGateway{
+		spreadSheetConfigGateway: spreadSheetConfigGateway,
+		logger:                   logger,
+	}
+}
+
+func (s *spreadSheetAnalysisPedigreeGateway) Write(
+	ctx context.Context,
+	analysisPedigrees []*spreadsheet_entity.AnalysisPedigree,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisPedigreeFileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis pedigree start")
+	writeRange := fmt.Sprintf("%s!%s", config.SheetName(), "A1")
+	values := [][]any{
+		{
+			"系統",
+			"種牡馬",
+			"条件",
+			"出走",
+			"1着",
+			"複勝",
+			"勝率",
+			"複勝率",
+			"単回収率",
+			"複回収率",
+			"得意",
+			"集計対象は印の付いた馬のみ(無印の馬は含まない)",
+		},
+	}
+
+	for _, analysisPedigree := range analysisPedigrees {
+		var conditionNames []string
+		for _, originFilter := range analysisPedigree.AttributeId().OriginFilters() {
+			conditionNames = append(conditionNames, originFilter.String())
+		}
+		condition := strings.Join(conditionNames, "・")
+		if analysisPedigree.IsFirstDirt() {
+			condition = "初ダート"
+		}
+		strong := ""
+		if analysisPedigree.IsStrong() {
+			strong = "◎"
+		}
+
+		values = append(values, []any{
+			analysisPedigree.PedigreeLine().String(),
+			fmt.Sprintf("=HYPERLINK(\"%s\",\"%s\")", fmt.Sprintf(sireUrl, analysisPedigree.SireId()), analysisPedigree.SireName()),
+			condition,
+			analysisPedigree.RaceCount(),
+			analysisPedigree.WinCount(),
+			analysisPedigree.PlaceCount(),
+			analysisPedigree.WinRate(),
+			analysisPedigree.PlaceRate(),
+			analysisPedigree.WinPayoutRate(),
+			analysisPedigree.PlacePayoutRate(),
+			strong,
+		})
+	}
+
+	_, err = client.Spreadsheets.Values.Update(config.SpreadSheetId(), writeRange, &sheets.ValueRange{
+		Values: values,
+	}).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis pedigree end")
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisPedigreeGateway) Style(
+	ctx context.Context,
+	analysisPedigrees []*spreadsheet_entity.AnalysisPedigree,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisPedigreeFileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis pedigree style start")
+	requests := make([]*sheets.Request, 0)
+	requests = append(requests, s.createBackgroundColorRequest(
+		config.SheetId(),
+		0, 0, 11, 1,
+		1.0, 1.0, 0.0,
+	))
+	requests = append(requests, s.createTextBoldRequest(
+		config.SheetId(),
+		0, 0, 11, 1,
+		true,
+	))
+
+	for idx, analysisPedigree := range analysisPedigrees {
+		if !analysisPedigree.IsStrong() {
+			continue
+		}
+		rowNum := 1 + idx
+		requests = append(requests, s.createBackgroundColorRequest(
+			config.SheetId(),
+			0, rowNum, 11, rowNum+1,
+			1.0, 0.8, 0.8,
+		))
+	}
+
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis pedigree style end")
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisPedigreeGateway) Clear(ctx context.Context) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisPedigreeFileName)
+	if err != nil {
+		return err
+	}
+
+	requests := []*sheets.Request{
+		{
+			RepeatCell: &sheets.RepeatCellRequest{
+				Fields: "*",
+				Range: &sheets.GridRange{
+					SheetId:          config.SheetId(),
+					StartColumnIndex: 0,
+					StartRowIndex:    0,
+					EndColumnIndex:   11,
+					EndRowIndex:      9999,
+				},
+				Cell: &sheets.CellData{},
+			},
+		},
+	}
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisPedigreeGateway) createTextBoldRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	bold bool,
+) *sheets.Request {
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.textFormat.bold",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: &sheets.CellFormat{
+					TextFormat: &sheets.TextFormat{
+						Bold: bold,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *spreadSheetAnalysisPedigreeGateway) createBackgroundColorRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	red, green, blue float64,
+) *sheets.Request {
+	cellFormat := &sheets.CellFormat{
+		BackgroundColor: &sheets.Color{
+			Red:   red,
+			Green: green,
+			Blue:  blue,
+		},
+	}
+
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.backgroundColor,userEnteredFormat.numberFormat,userEnteredFormat.textFormat",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: cellFormat,
+			},
+		},
+	}
+}
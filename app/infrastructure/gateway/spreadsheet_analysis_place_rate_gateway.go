@@ -0,0 +1,224 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	SpreadSheetAnalysisPlacePaddockFileName = "spreadsheet_analysis_place_paddock.json"
+	SpreadSheetAnalysisCommentScoreFileName = "spreadsheet_analysis_comment_score.json"
+)
+
+// SpreadSheetAnalysisPlaceRateGateway 区分×値×印の勝率・複勝率のシートを書く。書き込み先は設定ファイル名で選ぶ
+type SpreadSheetAnalysisPlaceRateGateway interface {
+	Write(ctx context.Context, fileName string, analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate) error
+	Style(ctx context.Context, fileName string, analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate) error
+	Clear(ctx context.Context, fileName string) error
+}
+
+type spreadSheetAnalysisPlaceRateGateway struct {
+	spreadSheetConfigGateway SpreadSheetConfigGateway
+	logger                   *logrus.Logger
+}
+
+func NewSpreadSheetAnalysisPlaceRateGateway(
+	spreadSheetConfigGateway SpreadSheetConfigGateway,
+	logger *logrus.Logger,
+) SpreadSheetAnalysisPlaceRateGateway {
+	return &spreadSheetAnalysisPlaceRateGateway{
+		spreadSheetConfigGateway: spreadSheetConfigGateway,
+		logger:                   logger,
+	}
+}
+
+func (s *spreadSheetAnalysisPlaceRateGateway) Write(
+	ctx context.Context,
+	fileName string,
+	analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, fileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis place rate %s start", config.SheetName())
+	writeRange := fmt.Sprintf("%s!%s", config.SheetName(), "A1")
+	values := [][]any{
+		{
+			"区分",
+			"値",
+			"印",
+			"頭数",
+			"1着",
+			"複勝圏",
+			"勝率",
+			"複勝率",
+		},
+	}
+
+	for _, analysisPlaceRate := range analysisPlaceRates {
+		values = append(values, []any{
+			analysisPlaceRate.Category(),
+			analysisPlaceRate.Value(),
+			analysisPlaceRate.MarkerName(),
+			analysisPlaceRate.RaceCount(),
+			analysisPlaceRate.WinCount(),
+			analysisPlaceRate.PlaceCount(),
+			analysisPlaceRate.WinRate(),
+			analysisPlaceRate.PlaceRate(),
+		})
+	}
+
+	_, err = client.Spreadsheets.Values.Update(config.SpreadSheetId(), writeRange, &sheets.ValueRange{
+		Values: values,
+	}).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis place rate %s end", config.SheetName())
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisPlaceRateGateway) Style(
+	ctx context.Context,
+	fileName string,
+	analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, fileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis place rate %s style start", config.SheetName())
+	requests := make([]*sheets.Request, 0)
+	requests = append(requests, s.createBackgroundColorRequest(
+		config.SheetId(),
+		0, 0, 8, 1,
+		1.0, 1.0, 0.0,
+	))
+	requests = append(requests, s.createTextBoldRequest(
+		config.SheetId(),
+		0, 0, 8, 1,
+		true,
+	))
+
+	for idx, analysisPlaceRate := range analysisPlaceRates {
+		// パドック評価と記者メモ、スコア帯とキーワードのように区分が変わる行を区切りとして色付けする
+		if idx == 0 || analysisPlaceRate.Category() == analysisPlaceRates[idx-1].Category() {
+			continue
+		}
+		rowNum := 1 + idx
+		requests = append(requests, s.createBackgroundColorRequest(
+			config.SheetId(),
+			0, rowNum, 8, rowNum+1,
+			0.85, 0.85, 0.85,
+		))
+	}
+
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis place rate %s style end", config.SheetName())
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisPlaceRateGateway) Clear(ctx context.Context, fileName string) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, fileName)
+	if err != nil {
+		return err
+	}
+
+	requests := []*sheets.Request{
+		{
+			RepeatCell: &sheets.RepeatCellRequest{
+				Fields: "*",
+				Range: &sheets.GridRange{
+					SheetId:          config.SheetId(),
+					StartColumnIndex: 0,
+					StartRowIndex:    0,
+					EndColumnIndex:   8,
+					EndRowIndex:      9999,
+				},
+				Cell: &sheets.CellData{},
+			},
+		},
+	}
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisPlaceRateGateway) createTextBoldRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	bold bool,
+) *sheets.Request {
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.textFormat.bold",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: &sheets.CellFormat{
+					TextFormat: &sheets.TextFormat{
+						Bold: bold,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *spreadSheetAnalysisPlaceRateGateway) createBackgroundColorRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	red, green, blue float64,
+) *sheets.Request {
+	cellFormat := &sheets.CellFormat{
+		BackgroundColor: &sheets.Color{
+			Red:   red,
+			Green: green,
+			Blue:  blue,
+		},
+	}
+
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.backgroundColor,userEnteredFormat.numberFormat,userEnteredFormat.textFormat",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: cellFormat,
+			},
+		},
+	}
+}
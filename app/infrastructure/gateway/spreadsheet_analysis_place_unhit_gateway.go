@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
 	"github.com/shopspring/decimal"
@@ -72,6 +73,9 @@ func (g *spreadSheetAnalysisPlaceUnhitGateway) Write(
 			"馬連続",
 			"連平均",
 			"軸出現",
+			"脚質",
+			"危険度",
+			"危険項目",
 		},
 	}
 
@@ -107,6 +111,9 @@ func (g *spreadSheetAnalysisPlaceUnhitGateway) Write(
 			analysisPlaceUnhit.QuinellaConsecutiveNumber(),
 			analysisPlaceUnhit.QuinellaWheelAverageOdds().Round(1).String(),
 			analysisPlaceUnhit.TrioFavoriteCount(),
+			analysisPlaceUnhit.RunningStyle().String(),
+			analysisPlaceUnhit.DangerPoint(),
+			strings.Join(analysisPlaceUnhit.DangerItems(), ","),
 		})
 	}
 
@@ -0,0 +1,239 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	spreadSheetAnalysisTicketRepriceFileName = "spreadsheet_analysis_ticket_reprice.json"
+)
+
+type SpreadSheetAnalysisTicketRepriceGateway interface {
+	Write(ctx context.Context, analysisTicketReprices []*spreadsheet_entity.AnalysisTicketReprice) error
+	Style(ctx context.Context, analysisTicketReprices []*spreadsheet_entity.AnalysisTicketReprice) error
+	Clear(ctx context.Context) error
+}
+
+type spreadSheetAnalysisTicketRepriceGateway struct {
+	spreadSheetConfigGateway SpreadSheetConfigGateway
+	logger                   *logrus.Logger
+}
+
+func NewSpreadSheetAnalysisTicketRepriceGateway(
+	spreadSheetConfigGateway SpreadSheetConfigGateway,
+	logger *logrus.Logger,
+) SpreadSheetAnalysisTicketRepriceGateway {
+	return &spreadSheetAnalysisTicketRepriceGateway{
+		spreadSheetConfigGateway: spreadSheetConfigGateway,
+		logger:                   logger,
+	}
+}
+
+func (s *spreadSheetAnalysisTicketRepriceGateway) Write(
+	ctx context.Context,
+	analysisTicketReprices []*spreadsheet_entity.AnalysisTicketReprice,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisTicketRepriceFileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis ticket reprice start")
+	writeRange := fmt.Sprintf("%s!%s", config.SheetName(), "A1")
+	values := [][]any{
+		{
+			"期間",
+			"購入券種",
+			"購入レース数",
+			"投資",
+			"回収",
+			"回収率",
+			"買い直し券種",
+			"対象レース数",
+			"点数",
+			"買い直し投資",
+			"買い直し回収",
+			"的中率",
+			"買い直し回収率",
+			"差",
+		},
+	}
+
+	for _, analysisTicketReprice := range analysisTicketReprices {
+		values = append(values, []any{
+			analysisTicketReprice.PeriodName(),
+			analysisTicketReprice.TicketTypeName(),
+			analysisTicketReprice.RaceCount(),
+			analysisTicketReprice.Payment(),
+			analysisTicketReprice.Payout(),
+			analysisTicketReprice.PayoutRate(),
+			analysisTicketReprice.AlternativeTicketName(),
+			analysisTicketReprice.AlternativeRaceCount(),
+			analysisTicketReprice.AlternativePoints(),
+			analysisTicketReprice.AlternativePayment(),
+			analysisTicketReprice.AlternativePayout(),
+			analysisTicketReprice.AlternativeHitRate(),
+			analysisTicketReprice.AlternativePayoutRate(),
+			analysisTicketReprice.PayoutRateDiff(),
+		})
+	}
+
+	_, err = client.Spreadsheets.Values.Update(config.SpreadSheetId(), writeRange, &sheets.ValueRange{
+		Values: values,
+	}).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis ticket reprice end")
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisTicketRepriceGateway) Style(
+	ctx context.Context,
+	analysisTicketReprices []*spreadsheet_entity.AnalysisTicketReprice,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisTicketRepriceFileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis ticket reprice style start")
+	requests := make([]*sheets.Request, 0)
+	requests = append(requests, s.createBackgroundColorRequest(
+		config.SheetId(),
+		0, 0, 14, 1,
+		1.0, 1.0, 0.0,
+	))
+	requests = append(requests, s.createTextBoldRequest(
+		config.SheetId(),
+		0, 0, 14, 1,
+		true,
+	))
+
+	for idx, analysisTicketReprice := range analysisTicketReprices {
+		rowNum := 1 + idx
+		// 期間の先頭行を区切りとして色付けする
+		if idx > 0 && analysisTicketReprice.PeriodName() != analysisTicketReprices[idx-1].PeriodName() {
+			requests = append(requests, s.createBackgroundColorRequest(
+				config.SheetId(),
+				0, rowNum, 14, rowNum+1,
+				0.85, 0.85, 0.85,
+			))
+		}
+		// 実際の買い方より回収率が良い買い直しを色付けする
+		if analysisTicketReprice.IsImproved() {
+			requests = append(requests, s.createBackgroundColorRequest(
+				config.SheetId(),
+				6, rowNum, 14, rowNum+1,
+				1.0, 0.8, 0.8,
+			))
+		}
+	}
+
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis ticket reprice style end")
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisTicketRepriceGateway) Clear(ctx context.Context) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisTicketRepriceFileName)
+	if err != nil {
+		return err
+	}
+
+	requests := []*sheets.Request{
+		{
+			RepeatCell: &sheets.RepeatCellRequest{
+				Fields: "*",
+				Range: &sheets.GridRange{
+					SheetId:          config.SheetId(),
+					StartColumnIndex: 0,
+					StartRowIndex:    0,
+					EndColumnIndex:   14,
+					EndRowIndex:      9999,
+				},
+				Cell: &sheets.CellData{},
+			},
+		},
+	}
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisTicketRepriceGateway) createTextBoldRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	bold bool,
+) *sheets.Request {
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.textFormat.bold",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: &sheets.CellFormat{
+					TextFormat: &sheets.TextFormat{
+						Bold: bold,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *spreadSheetAnalysisTicketRepriceGateway) createBackgroundColorRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	red, green, blue float64,
+) *sheets.Request {
+	cellFormat := &sheets.CellFormat{
+		BackgroundColor: &sheets.Color{
+			Red:   red,
+			Green: green,
+			Blue:  blue,
+		},
+	}
+
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.backgroundColor,userEnteredFormat.numberFormat,userEnteredFormat.textFormat",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: cellFormat,
+			},
+		},
+	}
+}
@@ -0,0 +1,230 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	spreadSheetAnalysisTrainerFileName = "spreadsheet_analysis_trainer.json"
+	trainerUrl                         = "https://db.netkeiba.com/trainer/%s/"
+)
+
+type SpreadSheetAnalysisTrainerGateway interface {
+	Write(ctx context.Context, analysisTrainers []*spreadsheet_entity.AnalysisTrainer) error
+	Style(ctx context.Context, analysisTrainers []*spreadsheet_entity.AnalysisTrainer) error
+	Clear(ctx context.Context) error
+}
+
+type spreadSheetAnalysisTrainerGateway struct {
+	spreadSheetConfigGateway SpreadSheetConfigGateway
+	logger                   *logrus.Logger
+}
+
+func NewSpreadSheetAnalysisTrainerGateway(
+	spreadSheetConfigGateway SpreadSheetConfigGateway,
+	logger *logrus.Logger,
+) SpreadSheetAnalysisTrainerGateway {
+	return &spreadSheetAnalysisTrainerGateway{
+		spreadSheetConfigGateway: spreadSheetConfigGateway,
+		logger:                   logger,
+	}
+}
+
+func (s *spreadSheetAnalysisTrainerGateway) Write(
+	ctx context.Context,
+	analysisTrainers []*spreadsheet_entity.AnalysisTrainer,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisTrainerFileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis trainer start")
+	writeRange := fmt.Sprintf("%s!%s", config.SheetName(), "A1")
+	values := [][]any{
+		{
+			"調教師",
+			"所属",
+			"条件",
+			"出走",
+			"1着",
+			"複勝",
+			"勝率",
+			"複勝率",
+			"単回収率",
+			"複回収率",
+		},
+	}
+
+	for _, analysisTrainer := range analysisTrainers {
+		// 所属ごとの集計行は調教師IDが無いのでリンクにしない
+		trainerName := analysisTrainer.TrainerName()
+		if analysisTrainer.TrainerId() != "" {
+			trainerName = fmt.Sprintf("=HYPERLINK(\"%s\",\"%s\")", fmt.Sprintf(trainerUrl, analysisTrainer.TrainerId()), analysisTrainer.TrainerName())
+		}
+		values = append(values, []any{
+			trainerName,
+			analysisTrainer.LocationId().Region(),
+			analysisTrainer.Condition(),
+			analysisTrainer.RaceCount(),
+			analysisTrainer.WinCount(),
+			analysisTrainer.PlaceCount(),
+			analysisTrainer.WinRate(),
+			analysisTrainer.PlaceRate(),
+			analysisTrainer.WinPayoutRate(),
+			analysisTrainer.PlacePayoutRate(),
+		})
+	}
+
+	_, err = client.Spreadsheets.Values.Update(config.SpreadSheetId(), writeRange, &sheets.ValueRange{
+		Values: values,
+	}).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis trainer end")
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisTrainerGateway) Style(
+	ctx context.Context,
+	analysisTrainers []*spreadsheet_entity.AnalysisTrainer,
+) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisTrainerFileName)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis trainer style start")
+	requests := make([]*sheets.Request, 0)
+	requests = append(requests, s.createBackgroundColorRequest(
+		config.SheetId(),
+		0, 0, 10, 1,
+		1.0, 1.0, 0.0,
+	))
+	requests = append(requests, s.createTextBoldRequest(
+		config.SheetId(),
+		0, 0, 10, 1,
+		true,
+	))
+
+	for idx, analysisTrainer := range analysisTrainers {
+		// 調教師ごとの全体成績の行を区切りとして色付けする
+		if analysisTrainer.Condition() != "全体" {
+			continue
+		}
+		rowNum := 1 + idx
+		requests = append(requests, s.createBackgroundColorRequest(
+			config.SheetId(),
+			0, rowNum, 10, rowNum+1,
+			0.85, 0.85, 0.85,
+		))
+	}
+
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("write analysis trainer style end")
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisTrainerGateway) Clear(ctx context.Context) error {
+	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetAnalysisTrainerFileName)
+	if err != nil {
+		return err
+	}
+
+	requests := []*sheets.Request{
+		{
+			RepeatCell: &sheets.RepeatCellRequest{
+				Fields: "*",
+				Range: &sheets.GridRange{
+					SheetId:          config.SheetId(),
+					StartColumnIndex: 0,
+					StartRowIndex:    0,
+					EndColumnIndex:   10,
+					EndRowIndex:      9999,
+				},
+				Cell: &sheets.CellData{},
+			},
+		},
+	}
+	_, err = client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetAnalysisTrainerGateway) createTextBoldRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	bold bool,
+) *sheets.Request {
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.textFormat.bold",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: &sheets.CellFormat{
+					TextFormat: &sheets.TextFormat{
+						Bold: bold,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *spreadSheetAnalysisTrainerGateway) createBackgroundColorRequest(
+	sheetId int64,
+	startCol, startRow, endCol, endRow int,
+	red, green, blue float64,
+) *sheets.Request {
+	cellFormat := &sheets.CellFormat{
+		BackgroundColor: &sheets.Color{
+			Red:   red,
+			Green: green,
+			Blue:  blue,
+		},
+	}
+
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Fields: "userEnteredFormat.backgroundColor,userEnteredFormat.numberFormat,userEnteredFormat.textFormat",
+			Range: &sheets.GridRange{
+				SheetId:          sheetId,
+				StartColumnIndex: int64(startCol),
+				StartRowIndex:    int64(startRow),
+				EndColumnIndex:   int64(endCol),
+				EndRowIndex:      int64(endRow),
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: cellFormat,
+			},
+		},
+	}
+}
@@ -5,11 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+	"github.com/mapserver2007/ipat-aggregator/config"
 	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 )
@@ -41,16 +41,11 @@ func (s *spreadSheetConfigGateway) GetConfig(
 	ctx context.Context,
 	spreadSheetConfigFileName string,
 ) (*sheets.Service, *spreadsheet_entity.SpreadSheetConfig, error) {
-	rootPath, err := s.pathOptimizer.GetProjectRoot()
+	secretFilePath, err := s.pathOptimizer.GetAbsPath(fmt.Sprintf("%s/%s", config.SecretDir, secretFileName))
 	if err != nil {
 		return nil, nil, err
 	}
-
-	secretFilePath, err := filepath.Abs(fmt.Sprintf("%s/secret/%s", rootPath, secretFileName))
-	if err != nil {
-		return nil, nil, err
-	}
-	spreadSheetConfigFilePath, err := filepath.Abs(fmt.Sprintf("%s/secret/%s", rootPath, spreadSheetConfigFileName))
+	spreadSheetConfigFilePath, err := s.pathOptimizer.GetAbsPath(fmt.Sprintf("%s/%s", config.SecretDir, spreadSheetConfigFileName))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -90,16 +85,11 @@ func (s *spreadSheetConfigGateway) GetConfigs(
 	ctx context.Context,
 	spreadSheetConfigFileName string,
 ) (*sheets.Service, []*spreadsheet_entity.SpreadSheetConfig, error) {
-	rootPath, err := s.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, nil, err
-	}
-
-	secretFilePath, err := filepath.Abs(fmt.Sprintf("%s/secret/%s", rootPath, secretFileName))
+	secretFilePath, err := s.pathOptimizer.GetAbsPath(fmt.Sprintf("%s/%s", config.SecretDir, secretFileName))
 	if err != nil {
 		return nil, nil, err
 	}
-	spreadSheetConfigFilePath, err := filepath.Abs(fmt.Sprintf("%s/secret/%s", rootPath, spreadSheetConfigFileName))
+	spreadSheetConfigFilePath, err := s.pathOptimizer.GetAbsPath(fmt.Sprintf("%s/%s", config.SecretDir, spreadSheetConfigFileName))
 	if err != nil {
 		return nil, nil, err
 	}
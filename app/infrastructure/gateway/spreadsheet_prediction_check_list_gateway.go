@@ -13,57 +13,14 @@ const (
 	spreadSheetPredictionCheckListFileName = "spreadsheet_prediction_check_list.json"
 )
 
-// 重要度高リスト(2点)
-// var checkListItems1 = []string{
-// 	"芝ダート替わりでないこと",
-// 	"継続騎乗であること",
-// 	"昇級初戦でないこと",
-// }
-
-// // 重要度標準リスト(1点)
-// var checkListItems2 = []string{}
-
-// // 危険レースチェックリスト：合計点数が高いほど危険
-// // https://www.youtube.com/watch?v=zfbJv2arw28&t=425s
-// var dangerCheckListItems = []string{
-// 	"15頭立て以上の多頭数戦であること:2",
-// 	"ダート1000~1200m戦であること:3", // かなり紛れる
-// 	"今走の馬場が不良であること:4",       // 相当危険
-// 	"今走の馬場が重であること:2",
-// 	"前走から騎手乗り替わりであること:1", // 意外と乗り替わりでも成績は悪くない
-// 	"前走3着以下であること:2",
-// 	"前走4着以下であること:2", // 4着以下は更に成績が悪い
-// 	"3ヶ月以上の休み明けであること:1",
-// 	"前走から2F以上距離が変更されていること:2", // 1Fの場合は危険度が低い
-// 	"昇級初戦であること:1",            // そこまで危険度は高くない
-// }
-
-// var dangerHorseCheckListItems = []string{
-// 	"13頭立て以下であること",
-// 	"単勝1倍台であること",
-// 	"3着以内率80%であること",
-// }
-
-var checkListItems = []string{
-	"13頭立て以下であること",
-	"単勝1倍台であること",
-	"3着以内率80%であること",
-	"芝ダート替わりでないこと",
-	"前走または2走前と今走の距離が同じなこと",
-	"前走または2走前と今走のコースが同じなこと",
-	"前走または2走前に馬券内なこと",
-	// "今走と同距離で馬券内経験があること",
-	// "今走と同コースで馬券内経験があること",
-	"今走の馬場状態と同じ馬場状態で馬券内経験があること",
-	// "同一季節内で馬券内経験があること",
-	"斤量増でないこと",
-	"昇級初戦でないこと",
-	"継続騎乗もしくは鞍上強化であること",
-	"近2走出遅れがないこと",
-	"東スポ印◎が50%以上であること",
-	"東スポ印が◎◯のみで構成されていること",
-	"調教イチ押しであること",
-}
+const (
+	// predictionCheckListColumnStart チェック項目列の開始位置、項目数はルールファイルの定義に従う
+	predictionCheckListColumnStart = 12
+	// predictionCheckListTailColumns チェック項目より後ろの列数(計〜新聞)
+	predictionCheckListTailColumns = 14
+	// predictionCheckListClearEndColumn 項目数が減った場合も古い列が残らないように広めに消す
+	predictionCheckListClearEndColumn = 100
+)
 
 type SpreadSheetPredictionCheckListGateway interface {
 	Write(ctx context.Context, rows []*spreadsheet_entity.PredictionCheckList) error
@@ -98,50 +55,44 @@ func (s *spreadSheetPredictionCheckListGateway) Write(
 	s.logger.Infof("write prediction check list start")
 
 	writeRange := fmt.Sprintf("%s!%s", config.SheetName(), "A1")
-	values := [][]interface{}{
-		{
-			"日付",
-			"場所",
-			"レース名",
-			"馬名",
-			"騎手",
-			"調教師",
-			"所属",
-			"単勝",
-			"印",
-			"1着率",
-			"2着率",
-			"3着率",
-			"1",
-			"2",
-			"3",
-			"4",
-			"5",
-			"6",
-			"7",
-			"8",
-			"9",
-			"10",
-			"11",
-			"12",
-			"13",
-			"14",
-			"15",
-			"計",
-			"◎",
-			"◯",
-			"印数",
-			"推",
-			"厩舎コメント",
-			"記者メモ",
-			"パドックコメント",
-			"評価",
-			"新聞",
-		},
+	checkListNames := s.getCheckListNames(rows)
+	header := []interface{}{
+		"日付",
+		"場所",
+		"レース名",
+		"馬名",
+		"騎手",
+		"調教師",
+		"所属",
+		"単勝",
+		"印",
+		"1着率",
+		"2着率",
+		"3着率",
 	}
+	for i := range checkListNames {
+		header = append(header, fmt.Sprintf("%d", i+1))
+	}
+	header = append(header,
+		"計",
+		"複勝予測",
+		"加点",
+		"減点",
+		"◎",
+		"◯",
+		"印数",
+		"推",
+		"コメント評価",
+		"厩舎コメント",
+		"記者メモ",
+		"パドックコメント",
+		"評価",
+		"新聞",
+	)
+	values := [][]interface{}{header}
 
 	for _, row := range rows {
-		values = append(values, []interface{}{
+		value := []interface{}{
 			row.RaceDate(),
 			row.RaceCourse(),
 			fmt.Sprintf("=HYPERLINK(\"%s\",\"%s\")", row.RaceUrl(), row.RaceName()),
@@ -154,21 +105,11 @@ func (s *spreadSheetPredictionCheckListGateway) Write(
 			row.FirstPlaceRate(),
 			row.SecondPlaceRate(),
 			row.ThirdPlaceRate(),
-			row.CheckList()[0],
-			row.CheckList()[1],
-			row.CheckList()[2],
-			row.CheckList()[3],
-			row.CheckList()[4],
-			row.CheckList()[5],
-			row.CheckList()[6],
-			row.CheckList()[7],
-			row.CheckList()[8],
-			row.CheckList()[9],
-			row.CheckList()[10],
-			row.CheckList()[11],
-			row.CheckList()[12],
-			row.CheckList()[13],
-			row.CheckList()[14],
+		}
+		for idx := range row.CheckList() {
+			value = append(value, s.formatCheck(row, idx))
+		}
+		value = append(value,
 			func() int {
 				count := 0
 				for _, check := range row.CheckList() {
@@ -178,16 +119,21 @@ func (s *spreadSheetPredictionCheckListGateway) Write(
 				}
 				return count
 			}(),
+			row.PlaceProbability(),
+			row.PositivePoint(),
+			row.NegativePoint(),
 			row.FavoriteNum(),
 			row.RivalNum(),
 			row.MarkerNum(),
 			row.HighlyRecommended(),
+			row.CommentScore(),
 			row.TrainingComment(),
 			row.ReporterMemo(),
 			row.PaddockComment(),
 			row.PaddockEvaluation(),
 			fmt.Sprintf("=HYPERLINK(\"%s\",\"%s\")", row.PaperUrl(), "LINK"),
-		})
+		)
+		values = append(values, value)
 	}
 
 	_, err = client.Spreadsheets.Values.Update(config.SpreadSheetId(), writeRange, &sheets.ValueRange{
@@ -213,6 +159,13 @@ func (s *spreadSheetPredictionCheckListGateway) Style(
 
 	s.logger.Infof("write prediction check list style start")
 
+	checkListNames := s.getCheckListNames(rows)
+	checkListEnd := int64(predictionCheckListColumnStart + len(checkListNames))
+	endColumn := checkListEnd + predictionCheckListTailColumns
+	// ◎以降はヘッダの色を変え、厩舎コメント〜パドックコメントは折り返す
+	markerColumn := checkListEnd + 4
+	commentColumn := checkListEnd + 9
+
 	var requests []*sheets.Request
 	requests = append(requests, []*sheets.Request{
 		{
@@ -222,7 +175,7 @@ func (s *spreadSheetPredictionCheckListGateway) Style(
 					SheetId:          config.SheetId(),
 					StartColumnIndex: 0,
 					StartRowIndex:    0,
-					EndColumnIndex:   37,
+					EndColumnIndex:   endColumn,
 					EndRowIndex:      1,
 				},
 				Cell: &sheets.CellData{
@@ -241,9 +194,9 @@ func (s *spreadSheetPredictionCheckListGateway) Style(
 				Fields: "userEnteredFormat.backgroundColor",
 				Range: &sheets.GridRange{
 					SheetId:          config.SheetId(),
-					StartColumnIndex: 28,
+					StartColumnIndex: markerColumn,
 					StartRowIndex:    0,
-					EndColumnIndex:   37,
+					EndColumnIndex:   endColumn,
 					EndRowIndex:      1,
 				},
 				Cell: &sheets.CellData{
@@ -264,7 +217,7 @@ func (s *spreadSheetPredictionCheckListGateway) Style(
 					SheetId:          config.SheetId(),
 					StartColumnIndex: 0,
 					StartRowIndex:    0,
-					EndColumnIndex:   37,
+					EndColumnIndex:   endColumn,
 					EndRowIndex:      1,
 				},
 				Cell: &sheets.CellData{
@@ -281,9 +234,9 @@ func (s *spreadSheetPredictionCheckListGateway) Style(
 				Fields: "userEnteredFormat.textFormat.foregroundColor",
 				Range: &sheets.GridRange{
 					SheetId:          config.SheetId(),
-					StartColumnIndex: 28,
+					StartColumnIndex: markerColumn,
 					StartRowIndex:    0,
-					EndColumnIndex:   37,
+					EndColumnIndex:   endColumn,
 					EndRowIndex:      1,
 				},
 				Cell: &sheets.CellData{
@@ -306,7 +259,7 @@ func (s *spreadSheetPredictionCheckListGateway) Style(
 					SheetId:          config.SheetId(),
 					StartColumnIndex: 0,
 					StartRowIndex:    1,
-					EndColumnIndex:   37,
+					EndColumnIndex:   endColumn,
 					EndRowIndex:      999,
 				},
 				Cell: &sheets.CellData{
@@ -321,9 +274,9 @@ func (s *spreadSheetPredictionCheckListGateway) Style(
 				Fields: "userEnteredFormat(horizontalAlignment,wrapStrategy)",
 				Range: &sheets.GridRange{
 					SheetId:          config.SheetId(),
-					StartColumnIndex: 32,
+					StartColumnIndex: commentColumn,
 					StartRowIndex:    1,
-					EndColumnIndex:   35,
+					EndColumnIndex:   commentColumn + 3,
 					EndRowIndex:      999,
 				},
 				Cell: &sheets.CellData{
@@ -335,19 +288,19 @@ func (s *spreadSheetPredictionCheckListGateway) Style(
 		},
 	}...)
 
-	for i := int64(0); i < int64(15); i++ {
+	for i, name := range checkListNames {
 		requests = append(requests, &sheets.Request{
 			RepeatCell: &sheets.RepeatCellRequest{
 				Fields: "note",
 				Range: &sheets.GridRange{
 					SheetId:          config.SheetId(),
-					StartColumnIndex: i + 12,
+					StartColumnIndex: int64(predictionCheckListColumnStart + i),
 					StartRowIndex:    0,
-					EndColumnIndex:   i + 13,
+					EndColumnIndex:   int64(predictionCheckListColumnStart + i + 1),
 					EndRowIndex:      1,
 				},
 				Cell: &sheets.CellData{
-					Note: checkListItems[i],
+					Note: name,
 				},
 			},
 		})
@@ -372,7 +325,7 @@ func (s *spreadSheetPredictionCheckListGateway) Style(
 						SheetId:          config.SheetId(),
 						StartColumnIndex: 0,
 						StartRowIndex:    int64(idx) + 1,
-						EndColumnIndex:   37,
+						EndColumnIndex:   endColumn,
 						EndRowIndex:      int64(idx) + 2,
 					},
 					Cell: &sheets.CellData{
@@ -401,6 +354,27 @@ func (s *spreadSheetPredictionCheckListGateway) Style(
 	return nil
 }
 
+// getCheckListNames 全行同じルールで判定しているので先頭行の項目名を使う
+func (s *spreadSheetPredictionCheckListGateway) getCheckListNames(
+	rows []*spreadsheet_entity.PredictionCheckList,
+) []string {
+	if len(rows) == 0 {
+		return nil
+	}
+	return rows[0].CheckListNames()
+}
+
+// formatCheck チェック結果に寄与度を添える
+func (s *spreadSheetPredictionCheckListGateway) formatCheck(
+	row *spreadsheet_entity.PredictionCheckList,
+	idx int,
+) string {
+	if row.Contributions()[idx] == "" {
+		return row.CheckList()[idx]
+	}
+	return fmt.Sprintf("%s %s", row.CheckList()[idx], row.Contributions()[idx])
+}
+
 func (s *spreadSheetPredictionCheckListGateway) Clear(ctx context.Context) error {
 	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetPredictionCheckListFileName)
 	if err != nil {
@@ -415,7 +389,7 @@ func (s *spreadSheetPredictionCheckListGateway) Clear(ctx context.Context) error
 					SheetId:          config.SheetId(),
 					StartColumnIndex: 0,
 					StartRowIndex:    0,
-					EndColumnIndex:   40,
+					EndColumnIndex:   predictionCheckListClearEndColumn,
 					EndRowIndex:      9999,
 				},
 				Cell: &sheets.CellData{},
@@ -19,6 +19,7 @@ type SpreadSheetPredictionOddsGateway interface {
 	Write(ctx context.Context,
 		firstPlaceMap, secondPlaceMap, thirdPlaceMap map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace,
 		raceCourseMap map[types.RaceCourse][]types.RaceId,
+		stakeMap map[types.RaceId][]*spreadsheet_entity.PredictionStake,
 	) error
 	Style(ctx context.Context,
 		firstPlaceMap, secondPlaceMap, thirdPlaceMap map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace,
@@ -48,6 +49,7 @@ func (s *spreadSheetPredictionOddsGateway) Write(
 	secondPlaceMap,
 	thirdPlaceMap map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace,
 	raceCourseMap map[types.RaceCourse][]types.RaceId,
+	stakeMap map[types.RaceId][]*spreadsheet_entity.PredictionStake,
 ) error {
 	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetPredictionOddsFileName)
 	if err != nil {
@@ -74,7 +76,7 @@ func (s *spreadSheetPredictionOddsGateway) Write(
 		raceIds := raceCourseMap[types.RaceCourse(raceCourseId)]
 		var valuesList [][]any
 		for _, raceId := range raceIds {
-			values := make([][][]any, 4)
+			values := make([][][]any, 5)
 			values[0] = [][]any{
 				{
 					"",
@@ -150,7 +152,10 @@ func (s *spreadSheetPredictionOddsGateway) Write(
 						title := fmt.Sprintf("%s%dR %s %s", predictionRace.RaceCourseId().Name(), predictionRace.RaceNumber(), predictionRace.RaceName(), predictionRace.FilterName())
 						raceCount := markerPlaceMap[types.Favorite].RateData().RaceCount()
 						raceTime := fmt.Sprintf("【基準時計】 %s, %s, %s, %s, %s, %s", predictionRace.RaceTime().AverageRaceTime(), predictionRace.RaceTime().AverageFirst3f(), predictionRace.RaceTime().AverageFirst4f(), predictionRace.RaceTime().AverageRap5f(), predictionRace.RaceTime().AverageLast4f(), predictionRace.RaceTime().AverageLast3f())
-						values[0][0][1] = fmt.Sprintf("=HYPERLINK(\"%s\",\"%s(%d) %s\")", predictionRace.Url(), title, raceCount, raceTime)
+						values[0][0][1] = fmt.Sprintf("=HYPERLINK(\"%s\",\"%s(%d) %s %s\")", predictionRace.Url(), title, raceCount, raceTime, predictionRace.RaceRisk().Format())
+						if raceRisk := predictionRace.RaceRisk(); raceRisk != nil {
+							values[0][0][0] = raceRisk.Rating().String()
+						}
 					}
 
 					values[idx+1] = append(values[idx+1], [][]any{
@@ -245,6 +250,7 @@ func (s *spreadSheetPredictionOddsGateway) Write(
 					}...)
 				}
 			}
+			values[4] = [][]any{s.createStakeRow(stakeMap[raceId])}
 			for _, value := range values {
 				valuesList = append(valuesList, value...)
 			}
@@ -331,9 +337,9 @@ func (s *spreadSheetPredictionOddsGateway) Style(
 									Range: &sheets.GridRange{
 										SheetId:          config.SheetId(),
 										StartColumnIndex: 2 + int64(oddsRangeIndex) + int64(raceCourseCount*11),
-										StartRowIndex:    2 + int64(raceIndex*22+markerIndex) + int64(placeIndex*7),
+										StartRowIndex:    2 + int64(raceIndex*23+markerIndex) + int64(placeIndex*7),
 										EndColumnIndex:   3 + int64(oddsRangeIndex) + int64(raceCourseCount*11),
-										EndRowIndex:      3 + int64(raceIndex*22+markerIndex) + int64(placeIndex*7),
+										EndRowIndex:      3 + int64(raceIndex*23+markerIndex) + int64(placeIndex*7),
 									},
 									Cell: &sheets.CellData{
 										UserEnteredFormat: &sheets.CellFormat{
@@ -352,9 +358,9 @@ func (s *spreadSheetPredictionOddsGateway) Style(
 									Range: &sheets.GridRange{
 										SheetId:          config.SheetId(),
 										StartColumnIndex: 2 + int64(oddsRangeIndex) + int64(raceCourseCount*11),
-										StartRowIndex:    2 + int64(raceIndex*22+markerIndex) + int64(placeIndex*7),
+										StartRowIndex:    2 + int64(raceIndex*23+markerIndex) + int64(placeIndex*7),
 										EndColumnIndex:   3 + int64(oddsRangeIndex) + int64(raceCourseCount*11),
-										EndRowIndex:      3 + int64(raceIndex*22+markerIndex) + int64(placeIndex*7),
+										EndRowIndex:      3 + int64(raceIndex*23+markerIndex) + int64(placeIndex*7),
 									},
 									Cell: &sheets.CellData{
 										UserEnteredFormat: &sheets.CellFormat{
@@ -369,9 +375,9 @@ func (s *spreadSheetPredictionOddsGateway) Style(
 									Range: &sheets.GridRange{
 										SheetId:          config.SheetId(),
 										StartColumnIndex: 2 + int64(oddsRangeIndex) + int64(raceCourseCount*11),
-										StartRowIndex:    2 + int64(raceIndex*22+markerIndex) + int64(placeIndex*7),
+										StartRowIndex:    2 + int64(raceIndex*23+markerIndex) + int64(placeIndex*7),
 										EndColumnIndex:   3 + int64(oddsRangeIndex) + int64(raceCourseCount*11),
-										EndRowIndex:      3 + int64(raceIndex*22+markerIndex) + int64(placeIndex*7),
+										EndRowIndex:      3 + int64(raceIndex*23+markerIndex) + int64(placeIndex*7),
 									},
 									Cell: &sheets.CellData{
 										UserEnteredFormat: &sheets.CellFormat{
@@ -391,9 +397,9 @@ func (s *spreadSheetPredictionOddsGateway) Style(
 								Range: &sheets.GridRange{
 									SheetId:          config.SheetId(),
 									StartColumnIndex: 1 + int64(raceCourseCount*11),
-									StartRowIndex:    1 + int64(placeIndex*7) + int64(raceIndex*22),
+									StartRowIndex:    1 + int64(placeIndex*7) + int64(raceIndex*23),
 									EndColumnIndex:   2 + int64(raceCourseCount*11),
-									EndRowIndex:      2 + int64(placeIndex*7) + int64(raceIndex*22),
+									EndRowIndex:      2 + int64(placeIndex*7) + int64(raceIndex*23),
 								},
 								Cell: &sheets.CellData{
 									UserEnteredFormat: &sheets.CellFormat{
@@ -412,9 +418,9 @@ func (s *spreadSheetPredictionOddsGateway) Style(
 								Range: &sheets.GridRange{
 									SheetId:          config.SheetId(),
 									StartColumnIndex: 1 + int64(raceCourseCount*11),
-									StartRowIndex:    1 + int64(placeIndex*7) + int64(raceIndex*22),
+									StartRowIndex:    1 + int64(placeIndex*7) + int64(raceIndex*23),
 									EndColumnIndex:   11 + int64(raceCourseCount*11),
-									EndRowIndex:      2 + int64(placeIndex*7) + int64(raceIndex*22),
+									EndRowIndex:      2 + int64(placeIndex*7) + int64(raceIndex*23),
 								},
 								Cell: &sheets.CellData{
 									UserEnteredFormat: &sheets.CellFormat{
@@ -431,9 +437,9 @@ func (s *spreadSheetPredictionOddsGateway) Style(
 								Range: &sheets.GridRange{
 									SheetId:          config.SheetId(),
 									StartColumnIndex: 2 + int64(raceCourseCount*11),
-									StartRowIndex:    1 + int64(placeIndex*7) + int64(raceIndex*22),
+									StartRowIndex:    1 + int64(placeIndex*7) + int64(raceIndex*23),
 									EndColumnIndex:   11 + int64(raceCourseCount*11),
-									EndRowIndex:      2 + int64(placeIndex*7) + int64(raceIndex*22),
+									EndRowIndex:      2 + int64(placeIndex*7) + int64(raceIndex*23),
 								},
 								Cell: &sheets.CellData{
 									UserEnteredFormat: &sheets.CellFormat{
@@ -452,9 +458,9 @@ func (s *spreadSheetPredictionOddsGateway) Style(
 								Range: &sheets.GridRange{
 									SheetId:          config.SheetId(),
 									StartColumnIndex: 2 + int64(raceCourseCount*11),
-									StartRowIndex:    1 + int64(placeIndex*7) + int64(raceIndex*22),
+									StartRowIndex:    1 + int64(placeIndex*7) + int64(raceIndex*23),
 									EndColumnIndex:   11 + int64(raceCourseCount*11),
-									EndRowIndex:      2 + int64(placeIndex*7) + int64(raceIndex*22),
+									EndRowIndex:      2 + int64(placeIndex*7) + int64(raceIndex*23),
 								},
 								Cell: &sheets.CellData{
 									UserEnteredFormat: &sheets.CellFormat{
@@ -479,9 +485,9 @@ func (s *spreadSheetPredictionOddsGateway) Style(
 						Range: &sheets.GridRange{
 							SheetId:          config.SheetId(),
 							StartColumnIndex: 1 + int64(raceCourseCount*11),
-							StartRowIndex:    int64(raceIndex * 22),
+							StartRowIndex:    int64(raceIndex * 23),
 							EndColumnIndex:   11 + int64(raceCourseCount*11),
-							EndRowIndex:      1 + int64(raceIndex*22),
+							EndRowIndex:      1 + int64(raceIndex*23),
 						},
 						Cell: &sheets.CellData{
 							UserEnteredFormat: &sheets.CellFormat{
@@ -500,9 +506,9 @@ func (s *spreadSheetPredictionOddsGateway) Style(
 						Range: &sheets.GridRange{
 							SheetId:          config.SheetId(),
 							StartColumnIndex: 1 + int64(raceCourseCount*11),
-							StartRowIndex:    int64(raceIndex * 22),
+							StartRowIndex:    int64(raceIndex * 23),
 							EndColumnIndex:   11 + int64(raceCourseCount*11),
-							EndRowIndex:      1 + int64(raceIndex*22),
+							EndRowIndex:      1 + int64(raceIndex*23),
 						},
 						Cell: &sheets.CellData{
 							UserEnteredFormat: &sheets.CellFormat{
@@ -523,9 +529,9 @@ func (s *spreadSheetPredictionOddsGateway) Style(
 						Range: &sheets.GridRange{
 							SheetId:          config.SheetId(),
 							StartColumnIndex: 1 + int64(raceCourseCount*11),
-							StartRowIndex:    int64(raceIndex * 22),
+							StartRowIndex:    int64(raceIndex * 23),
 							EndColumnIndex:   11 + int64(raceCourseCount*11),
-							EndRowIndex:      1 + int64(raceIndex*22),
+							EndRowIndex:      1 + int64(raceIndex*23),
 						},
 						Cell: &sheets.CellData{
 							UserEnteredFormat: &sheets.CellFormat{
@@ -553,6 +559,30 @@ func (s *spreadSheetPredictionOddsGateway) Style(
 	return nil
 }
 
+// createStakeRow 各レースの最終行に印ごとの推奨賭け金(単勝)を並べる
+func (s *spreadSheetPredictionOddsGateway) createStakeRow(predictionStakes []*spreadsheet_entity.PredictionStake) []any {
+	row := make([]any, 11)
+	for i := range row {
+		row[i] = ""
+	}
+	row[0] = "推奨(単勝)"
+	if len(predictionStakes) == 0 {
+		row[1] = "見送り"
+		return row
+	}
+
+	var total int
+	for idx, predictionStake := range predictionStakes {
+		total += predictionStake.Amount()
+		if idx+2 < len(row) {
+			row[idx+2] = fmt.Sprintf("%s単 %d円(EV%.2f)", predictionStake.Marker().String(), predictionStake.Amount(), predictionStake.ExpectedValue())
+		}
+	}
+	row[1] = fmt.Sprintf("計%d円", total)
+
+	return row
+}
+
 func (s *spreadSheetPredictionOddsGateway) Clear(ctx context.Context) error {
 	client, config, err := s.spreadSheetConfigGateway.GetConfig(ctx, spreadSheetPredictionOddsFileName)
 	if err != nil {
@@ -211,6 +211,72 @@ func (s *spreadSheetSummaryGateway) WriteV2(
 		return err
 	}
 
+	if err = s.writeTicketSourceResultV2(
+		summary.TicketSourceResultMap(),
+		client,
+		config,
+		"AB",
+		1,
+		"購入元(全)",
+	); err != nil {
+		return err
+	}
+
+	if err = s.writeTicketSourceResultV2(
+		summary.TicketSourceYearlyResultMap(),
+		client,
+		config,
+		"AB",
+		2+len(summary.TicketSourceResultMap()),
+		"購入元(年)",
+	); err != nil {
+		return err
+	}
+
+	if err = s.writeTicketSourceResultV2(
+		summary.TicketSourceMonthlyResultMap(),
+		client,
+		config,
+		"AB",
+		3+len(summary.TicketSourceResultMap())+len(summary.TicketSourceYearlyResultMap()),
+		"購入元(月)",
+	); err != nil {
+		return err
+	}
+
+	if err = s.writeAccountResultV2(
+		summary.AccountResultMap(),
+		client,
+		config,
+		"AG",
+		1,
+		"アカウント(全)",
+	); err != nil {
+		return err
+	}
+
+	if err = s.writeAccountResultV2(
+		summary.AccountYearlyResultMap(),
+		client,
+		config,
+		"AG",
+		2+len(summary.AccountResultMap()),
+		"アカウント(年)",
+	); err != nil {
+		return err
+	}
+
+	if err = s.writeAccountResultV2(
+		summary.AccountMonthlyResultMap(),
+		client,
+		config,
+		"AG",
+		3+len(summary.AccountResultMap())+len(summary.AccountYearlyResultMap()),
+		"アカウント(月)",
+	); err != nil {
+		return err
+	}
+
 	s.logger.Infof("write summary v2 end")
 	return nil
 }
@@ -605,6 +671,104 @@ func (s *spreadSheetSummaryGateway) writeRaceCourseResultV2(
 	return nil
 }
 
+func (s *spreadSheetSummaryGateway) writeTicketSourceResultV2(
+	results map[types.TicketSource]*spreadsheet_entity.TicketResult,
+	client *sheets.Service,
+	config *spreadsheet_entity.SpreadSheetConfig,
+	colCell string,
+	rowCell int,
+	title string,
+) error {
+	cell := fmt.Sprintf("%s%d", colCell, rowCell)
+	s.logger.Infof("writing spreadsheet writeTicketSourceResultV2 %s", cell)
+	writeRange := fmt.Sprintf("%s!%s", config.SheetName(), cell)
+	values := [][]any{
+		{
+			title,
+			"的中率",
+			"投資額",
+			"回収額",
+			"回収率",
+		},
+	}
+
+	keys := make([]int, 0, len(results))
+	for k := range results {
+		keys = append(keys, k.Value())
+	}
+	sort.Ints(keys)
+
+	for _, k := range keys {
+		ticketSource := types.TicketSource(k)
+		result := results[ticketSource]
+		values = append(values, []any{
+			ticketSource.Name(),
+			result.HitRate(),
+			result.Payment(),
+			result.Payout(),
+			result.PayoutRate(),
+		})
+	}
+
+	_, err := client.Spreadsheets.Values.Update(config.SpreadSheetId(), writeRange, &sheets.ValueRange{
+		Values: values,
+	}).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetSummaryGateway) writeAccountResultV2(
+	results map[types.Account]*spreadsheet_entity.TicketResult,
+	client *sheets.Service,
+	config *spreadsheet_entity.SpreadSheetConfig,
+	colCell string,
+	rowCell int,
+	title string,
+) error {
+	cell := fmt.Sprintf("%s%d", colCell, rowCell)
+	s.logger.Infof("writing spreadsheet writeAccountResultV2 %s", cell)
+	writeRange := fmt.Sprintf("%s!%s", config.SheetName(), cell)
+	values := [][]any{
+		{
+			title,
+			"的中率",
+			"投資額",
+			"回収額",
+			"回収率",
+		},
+	}
+
+	keys := make([]string, 0, len(results))
+	for k := range results {
+		keys = append(keys, k.Value())
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		account := types.Account(k)
+		result := results[account]
+		values = append(values, []any{
+			account.Value(),
+			result.HitRate(),
+			result.Payment(),
+			result.Payout(),
+			result.PayoutRate(),
+		})
+	}
+
+	_, err := client.Spreadsheets.Values.Update(config.SpreadSheetId(), writeRange, &sheets.ValueRange{
+		Values: values,
+	}).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *spreadSheetSummaryGateway) StyleV2(
 	ctx context.Context,
 	summary *spreadsheet_entity.Summary,
@@ -669,6 +833,28 @@ func (s *spreadSheetSummaryGateway) StyleV2(
 	if err = s.writeStyleRaceCourseResultV2(client, config, summary.RaceCourseMonthlyResultMap(), rowPosition); err != nil {
 		return err
 	}
+	if err = s.writeStyleTicketSourceResultV2(client, config, summary.TicketSourceResultMap(), 0); err != nil {
+		return err
+	}
+	rowPosition = len(summary.TicketSourceResultMap()) + 1
+	if err = s.writeStyleTicketSourceResultV2(client, config, summary.TicketSourceYearlyResultMap(), rowPosition); err != nil {
+		return err
+	}
+	rowPosition = len(summary.TicketSourceResultMap()) + len(summary.TicketSourceYearlyResultMap()) + 2
+	if err = s.writeStyleTicketSourceResultV2(client, config, summary.TicketSourceMonthlyResultMap(), rowPosition); err != nil {
+		return err
+	}
+	if err = s.writeStyleAccountResultV2(client, config, summary.AccountResultMap(), 0); err != nil {
+		return err
+	}
+	rowPosition = len(summary.AccountResultMap()) + 1
+	if err = s.writeStyleAccountResultV2(client, config, summary.AccountYearlyResultMap(), rowPosition); err != nil {
+		return err
+	}
+	rowPosition = len(summary.AccountResultMap()) + len(summary.AccountYearlyResultMap()) + 2
+	if err = s.writeStyleAccountResultV2(client, config, summary.AccountMonthlyResultMap(), rowPosition); err != nil {
+		return err
+	}
 
 	s.logger.Infof("write spreadsheet style v2 end")
 	return nil
@@ -858,6 +1044,60 @@ func (s *spreadSheetSummaryGateway) writeStyleRaceCourseResultV2(
 	return nil
 }
 
+func (s *spreadSheetSummaryGateway) writeStyleTicketSourceResultV2(
+	client *sheets.Service,
+	config *spreadsheet_entity.SpreadSheetConfig,
+	ticketSourceResults map[types.TicketSource]*spreadsheet_entity.TicketResult,
+	rowPosition int,
+) error {
+	s.logger.Infof("writing spreadsheet writeStyleTicketSourceResultV2 %d", rowPosition)
+	requests := []*sheets.Request{
+		s.createBackgroundColorRequest(config.SheetId(), 27, rowPosition, 32, 1+rowPosition, 1.0, 1.0, 0),
+		s.createBackgroundColorRequest(config.SheetId(), 27, 1+rowPosition, 28, 1+rowPosition+len(ticketSourceResults), 1.0, 0.937, 0.498),
+		s.createTextFormatRequest(config.SheetId(), 27, rowPosition, 32, 1+rowPosition, "TEXT", true),
+		s.createTextFormatRequest(config.SheetId(), 27, 1+rowPosition, 28, 1+rowPosition+len(ticketSourceResults), "TEXT", true),
+		s.createTextFormatRequest(config.SheetId(), 28, 1+rowPosition, 29, 1+rowPosition+len(ticketSourceResults), "PERCENT", false),
+		s.createTextFormatRequest(config.SheetId(), 29, 1+rowPosition, 31, 1+rowPosition+len(ticketSourceResults), "TEXT", false),
+		s.createTextFormatRequest(config.SheetId(), 31, 1+rowPosition, 32, 1+rowPosition+len(ticketSourceResults), "PERCENT", false),
+	}
+	_, err := client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetSummaryGateway) writeStyleAccountResultV2(
+	client *sheets.Service,
+	config *spreadsheet_entity.SpreadSheetConfig,
+	accountResults map[types.Account]*spreadsheet_entity.TicketResult,
+	rowPosition int,
+) error {
+	s.logger.Infof("writing spreadsheet writeStyleAccountResultV2 %d", rowPosition)
+	requests := []*sheets.Request{
+		s.createBackgroundColorRequest(config.SheetId(), 32, rowPosition, 37, 1+rowPosition, 1.0, 1.0, 0),
+		s.createBackgroundColorRequest(config.SheetId(), 32, 1+rowPosition, 33, 1+rowPosition+len(accountResults), 1.0, 0.937, 0.498),
+		s.createTextFormatRequest(config.SheetId(), 32, rowPosition, 37, 1+rowPosition, "TEXT", true),
+		s.createTextFormatRequest(config.SheetId(), 32, 1+rowPosition, 33, 1+rowPosition+len(accountResults), "TEXT", true),
+		s.createTextFormatRequest(config.SheetId(), 33, 1+rowPosition, 34, 1+rowPosition+len(accountResults), "PERCENT", false),
+		s.createTextFormatRequest(config.SheetId(), 34, 1+rowPosition, 36, 1+rowPosition+len(accountResults), "TEXT", false),
+		s.createTextFormatRequest(config.SheetId(), 36, 1+rowPosition, 37, 1+rowPosition+len(accountResults), "PERCENT", false),
+	}
+	_, err := client.Spreadsheets.BatchUpdate(config.SpreadSheetId(), &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *spreadSheetSummaryGateway) createTextFormatRequest(
 	sheetId int64,
 	startCol, startRow, endCol, endRow int,
@@ -9,6 +9,7 @@ import (
 	net_url "net/url"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/tospo_entity"
@@ -69,13 +70,15 @@ func (t *tospoGateway) FetchForecast(
 	}
 	jar.SetCookies(u, cookies)
 
-	t.logger.Infof("fetching forecast from %s", url)
+	fetchStartTime := time.Now()
 	res, err := client.Get(url)
 	if err != nil {
+		logFetch(t.logger, "forecast", url, fetchStartTime, false, err)
 		return nil, err
 	}
 	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
+	logFetch(t.logger, "forecast", url, fetchStartTime, false, err)
 	if err != nil {
 		return nil, err
 	}
@@ -140,13 +143,15 @@ func (t *tospoGateway) FetchTrainingComment(
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	t.logger.Infof("fetching training comment from %s", url)
+	fetchStartTime := time.Now()
 	res, err := http.Get(url)
 	if err != nil {
+		logFetch(t.logger, "training comment", url, fetchStartTime, false, err)
 		return nil, err
 	}
 	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
+	logFetch(t.logger, "training comment", url, fetchStartTime, false, err)
 	if err != nil {
 		return nil, err
 	}
@@ -190,13 +195,15 @@ func (t *tospoGateway) FetchReporterMemo(
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	t.logger.Infof("fetching reporter memo from %s", url)
+	fetchStartTime := time.Now()
 	res, err := http.Get(url)
 	if err != nil {
+		logFetch(t.logger, "reporter memo", url, fetchStartTime, false, err)
 		return nil, err
 	}
 	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
+	logFetch(t.logger, "reporter memo", url, fetchStartTime, false, err)
 	if err != nil {
 		return nil, err
 	}
@@ -239,13 +246,15 @@ func (t *tospoGateway) FetchPaddockComment(
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	t.logger.Infof("fetching paddock comment from %s", url)
+	fetchStartTime := time.Now()
 	res, err := http.Get(url)
 	if err != nil {
+		logFetch(t.logger, "paddock comment", url, fetchStartTime, false, err)
 		return nil, err
 	}
 	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
+	logFetch(t.logger, "paddock comment", url, fetchStartTime, false, err)
 	if err != nil {
 		return nil, err
 	}
@@ -3,9 +3,7 @@ package infrastructure
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
@@ -33,12 +31,7 @@ func (h *horseRepository) Read(
 	ctx context.Context,
 	path string,
 ) (*raw_entity.HorseInfo, error) {
-	rootPath, err := h.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := h.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -67,17 +60,11 @@ func (h *horseRepository) Write(
 		return err
 	}
 
-	rootPath, err := h.pathOptimizer.GetProjectRoot()
+	filePath, err := h.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return err
 	}
-
-	filePath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
-	if err != nil {
-		return err
-	}
-
-	err = os.WriteFile(filePath, bytes, 0644)
+	err = file_gateway.WriteFile(filePath, bytes)
 	if err != nil {
 		return err
 	}
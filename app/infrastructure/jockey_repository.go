@@ -3,9 +3,7 @@ package infrastructure
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
@@ -33,12 +31,7 @@ func (j *jockeyRepository) Read(
 	ctx context.Context,
 	path string,
 ) (*raw_entity.JockeyInfo, error) {
-	rootPath, err := j.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := j.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -67,17 +60,11 @@ func (j *jockeyRepository) Write(
 		return err
 	}
 
-	rootPath, err := j.pathOptimizer.GetProjectRoot()
+	filePath, err := j.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return err
 	}
-
-	filePath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
-	if err != nil {
-		return err
-	}
-
-	err = os.WriteFile(filePath, bytes, 0644)
+	err = file_gateway.WriteFile(filePath, bytes)
 	if err != nil {
 		return err
 	}
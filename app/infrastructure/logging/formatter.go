@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TextFormat = "text"
+	JsonFormat = "json"
+
+	timestampFormat = "2006-01-02 15:04:05"
+)
+
+// NewFormatter --log-formatで指定された形式のフォーマッタを返す
+func NewFormatter(format string) (logrus.Formatter, error) {
+	switch format {
+	case "", TextFormat:
+		return &SLF4JFormatter{}, nil
+	case JsonFormat:
+		return &logrus.JSONFormatter{
+			TimestampFormat: timestampFormat,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown log format: %s", format)
+}
+
+type SLF4JFormatter struct{}
+
+func (f *SLF4JFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	timestamp := entry.Time.Format(timestampFormat)
+
+	level := entry.Level.String()
+	level = fmt.Sprintf("%-5s", level) // SLF4J形式に合わせてレベルを整列
+
+	var buf bytes.Buffer
+	goroutineId := getGoroutineID()
+	buf.WriteString(fmt.Sprintf("%s [%s] thread%d - %s", timestamp, level, goroutineId, entry.Message))
+
+	// フィールドはキー順にkey=valueで末尾に付ける
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		buf.WriteString(fmt.Sprintf(" %s=%v", key, entry.Data[key]))
+	}
+	buf.WriteString("\n")
+
+	return buf.Bytes(), nil
+}
+
+func getGoroutineID() int {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	idField := bytes.Fields(buf[:n])[1]
+	id, err := strconv.Atoi(string(idField))
+	if err != nil {
+		return -1
+	}
+	return id
+}
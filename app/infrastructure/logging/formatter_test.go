@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestNewFormatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		want    logrus.Formatter
+		wantErr bool
+	}{
+		{
+			name:   "未指定はtext",
+			format: "",
+			want:   &SLF4JFormatter{},
+		},
+		{
+			name:   "text",
+			format: TextFormat,
+			want:   &SLF4JFormatter{},
+		},
+		{
+			name:   "json",
+			format: JsonFormat,
+			want:   &logrus.JSONFormatter{TimestampFormat: timestampFormat},
+		},
+		{
+			name:    "不明な形式",
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFormatter(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewFormatter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			switch want := tt.want.(type) {
+			case *SLF4JFormatter:
+				if _, ok := got.(*SLF4JFormatter); !ok {
+					t.Errorf("NewFormatter() = %T, want %T", got, want)
+				}
+			case *logrus.JSONFormatter:
+				jsonFormatter, ok := got.(*logrus.JSONFormatter)
+				if !ok || jsonFormatter.TimestampFormat != want.TimestampFormat {
+					t.Errorf("NewFormatter() = %#v, want %#v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSLF4JFormatterFormat(t *testing.T) {
+	entryTime := time.Date(2024, 10, 20, 15, 40, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		level   logrus.Level
+		message string
+		fields  logrus.Fields
+		want    string
+	}{
+		{
+			name:    "フィールドなし",
+			level:   logrus.InfoLevel,
+			message: "master start",
+			want:    `^2024-10-20 15:40:00 \[info \] thread\d+ - master start\n$`,
+		},
+		{
+			name:    "フィールドはキー順に末尾に付ける",
+			level:   logrus.ErrorLevel,
+			message: "failed to fetch race",
+			fields:  logrus.Fields{"url": "https://example.com", "duration": 12, "command": "m"},
+			want:    `^2024-10-20 15:40:00 \[error\] thread\d+ - failed to fetch race command=m duration=12 url=https://example.com\n$`,
+		},
+	}
+
+	f := &SLF4JFormatter{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &logrus.Entry{Time: entryTime, Level: tt.level, Message: tt.message, Data: tt.fields}
+			got, err := f.Format(entry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !regexp.MustCompile(tt.want).Match(got) {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
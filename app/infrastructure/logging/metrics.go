@@ -0,0 +1,183 @@
+package logging
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
+)
+
+// RunSummary 1回の実行で取得したページ数、キャッシュ利用数、エラー数、ステージごとの所要時間
+type RunSummary struct {
+	Command      string                  `json:"command"`
+	StartedAt    string                  `json:"started_at"`
+	FinishedAt   string                  `json:"finished_at"`
+	DurationMs   int64                   `json:"duration_ms"`
+	PagesFetched int                     `json:"pages_fetched"`
+	CacheHits    int                     `json:"cache_hits"`
+	FetchErrors  int                     `json:"fetch_errors"`
+	Errors       int                     `json:"errors"`
+	Stages       []*StageSummary         `json:"stages"`
+	Pages        map[string]*PageSummary `json:"pages"`
+}
+
+type StageSummary struct {
+	Name       string `json:"name"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+type PageSummary struct {
+	Fetched    int   `json:"fetched"`
+	CacheHits  int   `json:"cache_hits"`
+	Errors     int   `json:"errors"`
+	DurationMs int64 `json:"duration_ms"`
+}
+
+// MetricsHook ログのフィールドから実行結果を集計するhook
+// 全エントリにcommandフィールドを付ける
+type MetricsHook struct {
+	command   string
+	startTime time.Time
+	mu        sync.Mutex
+	summary   *RunSummary
+}
+
+func NewMetricsHook(command string) *MetricsHook {
+	return &MetricsHook{
+		command:   command,
+		startTime: time.Now(),
+		summary: &RunSummary{
+			Command: command,
+			Stages:  []*StageSummary{},
+			Pages:   map[string]*PageSummary{},
+		},
+	}
+}
+
+func (m *MetricsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (m *MetricsHook) Fire(entry *logrus.Entry) error {
+	if m.command != "" {
+		entry.Data[config.LogFieldCommand] = m.command
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if entry.Level <= logrus.ErrorLevel {
+		m.summary.Errors++
+	}
+
+	durationMs := getDurationMs(entry.Data[config.LogFieldDuration])
+	if stage, ok := entry.Data[config.LogFieldStage].(string); ok {
+		m.summary.Stages = append(m.summary.Stages, &StageSummary{
+			Name:       stage,
+			DurationMs: durationMs,
+		})
+	}
+
+	status, ok := entry.Data[config.LogFieldStatus].(string)
+	if !ok {
+		return nil
+	}
+	page, _ := entry.Data[config.LogFieldPage].(string)
+	pageSummary, ok := m.summary.Pages[page]
+	if !ok {
+		pageSummary = &PageSummary{}
+		m.summary.Pages[page] = pageSummary
+	}
+	pageSummary.DurationMs += durationMs
+
+	switch status {
+	case config.FetchStatusFetched:
+		m.summary.PagesFetched++
+		pageSummary.Fetched++
+	case config.FetchStatusCache:
+		m.summary.CacheHits++
+		pageSummary.CacheHits++
+	case config.FetchStatusError:
+		m.summary.FetchErrors++
+		pageSummary.Errors++
+	}
+
+	return nil
+}
+
+// Summary 終了時点の集計結果を返す
+func (m *MetricsHook) Summary() *RunSummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	finishTime := time.Now()
+	summary := *m.summary
+	summary.StartedAt = m.startTime.Format(time.RFC3339)
+	summary.FinishedAt = finishTime.Format(time.RFC3339)
+	summary.DurationMs = finishTime.Sub(m.startTime).Milliseconds()
+	summary.Stages = append([]*StageSummary{}, m.summary.Stages...)
+	summary.Pages = make(map[string]*PageSummary, len(m.summary.Pages))
+	for page, pageSummary := range m.summary.Pages {
+		copied := *pageSummary
+		summary.Pages[page] = &copied
+	}
+
+	return &summary
+}
+
+// SlowestStages 所要時間の長い順にステージを返す
+func (s *RunSummary) SlowestStages(n int) []*StageSummary {
+	stages := append([]*StageSummary{}, s.Stages...)
+	sort.SliceStable(stages, func(i, j int) bool {
+		return stages[i].DurationMs > stages[j].DurationMs
+	})
+	if len(stages) > n {
+		stages = stages[:n]
+	}
+
+	return stages
+}
+
+// WriteMetrics 実行ごとの集計結果を1行のJSONとしてメトリクスファイルに追記する
+func WriteMetrics(path string, summary *RunSummary) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err = file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func getDurationMs(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case time.Duration:
+		return v.Milliseconds()
+	}
+
+	return 0
+}
@@ -0,0 +1,178 @@
+package logging
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMetricsHookFire(t *testing.T) {
+	type entry struct {
+		level  logrus.Level
+		fields logrus.Fields
+	}
+	tests := []struct {
+		name             string
+		entries          []entry
+		wantPagesFetched int
+		wantCacheHits    int
+		wantFetchErrors  int
+		wantErrors       int
+		wantStages       []StageSummary
+		wantPages        map[string]PageSummary
+	}{
+		{
+			name:       "ログなし",
+			wantStages: []StageSummary{},
+			wantPages:  map[string]PageSummary{},
+		},
+		{
+			name: "ステータス付きのログをページごとに数える",
+			entries: []entry{
+				{logrus.InfoLevel, logrus.Fields{config.LogFieldPage: "race", config.LogFieldStatus: config.FetchStatusFetched, config.LogFieldDuration: int64(120)}},
+				{logrus.InfoLevel, logrus.Fields{config.LogFieldPage: "race", config.LogFieldStatus: config.FetchStatusCache, config.LogFieldDuration: int64(1)}},
+				{logrus.ErrorLevel, logrus.Fields{config.LogFieldPage: "odds", config.LogFieldStatus: config.FetchStatusError, config.LogFieldDuration: 30}},
+				{logrus.InfoLevel, logrus.Fields{config.LogFieldPage: "odds"}},
+			},
+			wantPagesFetched: 1,
+			wantCacheHits:    1,
+			wantFetchErrors:  1,
+			wantErrors:       1,
+			wantStages:       []StageSummary{},
+			wantPages: map[string]PageSummary{
+				"race": {Fetched: 1, CacheHits: 1, DurationMs: 121},
+				"odds": {Errors: 1, DurationMs: 30},
+			},
+		},
+		{
+			name: "ステージの所要時間を記録し、取得以外のエラーも数える",
+			entries: []entry{
+				{logrus.InfoLevel, logrus.Fields{config.LogFieldStage: "race_id", config.LogFieldDuration: 2 * time.Second}},
+				{logrus.InfoLevel, logrus.Fields{config.LogFieldStage: "race", config.LogFieldDuration: float64(500)}},
+				{logrus.ErrorLevel, logrus.Fields{}},
+				{logrus.WarnLevel, logrus.Fields{}},
+			},
+			wantErrors: 1,
+			wantStages: []StageSummary{{Name: "race_id", DurationMs: 2000}, {Name: "race", DurationMs: 500}},
+			wantPages:  map[string]PageSummary{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := NewMetricsHook("m")
+			for _, e := range tt.entries {
+				entry := &logrus.Entry{Level: e.level, Data: e.fields}
+				if err := hook.Fire(entry); err != nil {
+					t.Fatal(err)
+				}
+				if entry.Data[config.LogFieldCommand] != "m" {
+					t.Errorf("command = %v, want m", entry.Data[config.LogFieldCommand])
+				}
+			}
+
+			summary := hook.Summary()
+			if summary.Command != "m" || summary.PagesFetched != tt.wantPagesFetched || summary.CacheHits != tt.wantCacheHits ||
+				summary.FetchErrors != tt.wantFetchErrors || summary.Errors != tt.wantErrors {
+				t.Errorf("Summary() = %+v, want fetched %d cache %d fetch errors %d errors %d",
+					summary, tt.wantPagesFetched, tt.wantCacheHits, tt.wantFetchErrors, tt.wantErrors)
+			}
+			stages := make([]StageSummary, 0, len(summary.Stages))
+			for _, stage := range summary.Stages {
+				stages = append(stages, *stage)
+			}
+			if !reflect.DeepEqual(stages, tt.wantStages) {
+				t.Errorf("Stages = %+v, want %+v", stages, tt.wantStages)
+			}
+			pages := make(map[string]PageSummary, len(summary.Pages))
+			for page, pageSummary := range summary.Pages {
+				pages[page] = *pageSummary
+			}
+			if !reflect.DeepEqual(pages, tt.wantPages) {
+				t.Errorf("Pages = %+v, want %+v", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestRunSummarySlowestStages(t *testing.T) {
+	summary := &RunSummary{
+		Stages: []*StageSummary{
+			{Name: "race_id", DurationMs: 100},
+			{Name: "race", DurationMs: 900},
+			{Name: "win_odds", DurationMs: 300},
+			{Name: "jockey", DurationMs: 300},
+		},
+	}
+
+	tests := []struct {
+		name string
+		n    int
+		want []string
+	}{
+		{
+			name: "所要時間の長い順、同じ時間は元の順",
+			n:    3,
+			want: []string{"race", "win_odds", "jockey"},
+		},
+		{
+			name: "ステージ数より多い",
+			n:    10,
+			want: []string{"race", "win_odds", "jockey", "race_id"},
+		},
+		{
+			name: "0件",
+			n:    0,
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, stage := range summary.SlowestStages(tt.n) {
+				got = append(got, stage.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SlowestStages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if summary.Stages[0].Name != "race_id" {
+		t.Errorf("SlowestStages() changed Stages order: %s", summary.Stages[0].Name)
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log", "metrics.jsonl")
+	for _, command := range []string{"m", "a"} {
+		if err := WriteMetrics(path, &RunSummary{Command: command, PagesFetched: 3}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("WriteMetrics() wrote %d lines, want 2", len(lines))
+	}
+	for i, want := range []string{"m", "a"} {
+		var summary RunSummary
+		if err := json.Unmarshal([]byte(lines[i]), &summary); err != nil {
+			t.Fatal(err)
+		}
+		if summary.Command != want || summary.PagesFetched != 3 {
+			t.Errorf("line %d = %+v, want command %s", i, summary, want)
+		}
+	}
+}
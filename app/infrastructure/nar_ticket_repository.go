@@ -0,0 +1,480 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/master_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/width"
+)
+
+const (
+	ticketSpat4DataSuffix        = "_spat4"
+	ticketRakutenKeibaDataSuffix = "_rakuten"
+	ticketOddsparkDataSuffix     = "_oddspark"
+)
+
+type narTicketColumn int
+
+const (
+	narTicketRaceDate narTicketColumn = iota
+	narTicketRaceCourse
+	narTicketRaceNo
+	narTicketTicketType
+	narTicketBetNumber
+	narTicketPayment
+	narTicketPayout
+	narTicketStatus
+)
+
+// 各サイトの購入履歴はヘッダ名が微妙に異なるので列名から位置を割り出す
+var narTicketColumnNames = map[narTicketColumn][]string{
+	narTicketRaceDate:   {"日付", "開催日", "開催年月日", "レース日"},
+	narTicketRaceCourse: {"場名", "競馬場", "開催場", "場"},
+	narTicketRaceNo:     {"レース", "レース番号", "レースNo", "R"},
+	narTicketTicketType: {"式別", "賭式", "勝式", "券種"},
+	narTicketBetNumber:  {"組番", "買い目", "馬番", "馬／組番"},
+	narTicketPayment:    {"購入金額", "投票金額", "金額"},
+	narTicketPayout:     {"払戻金額", "払戻／返還金額", "払戻金", "払戻"},
+	narTicketStatus:     {"結果", "的中／返還", "状態"},
+}
+
+// 地方競馬の式別名をpatの式別名に寄せる、枠単は集計対象の式別にないので扱わない
+var narTicketTypeNames = map[string]types.TicketType{
+	"単勝":   types.Win,
+	"複勝":   types.Place,
+	"枠複":   types.BracketQuinella,
+	"枠番連複": types.BracketQuinella,
+	"枠連":   types.BracketQuinella,
+	"馬複":   types.Quinella,
+	"馬番連複": types.Quinella,
+	"馬連":   types.Quinella,
+	"馬単":   types.Exacta,
+	"馬番連単": types.Exacta,
+	"ワイド":  types.QuinellaPlace,
+	"三連複":  types.Trio,
+	"3連複":  types.Trio,
+	"三連単":  types.Trifecta,
+	"3連単":  types.Trifecta,
+}
+
+type narBetLayout int
+
+const (
+	narBetSingle narBetLayout = iota
+	narBetBox
+	narBetWheel
+	narBetFormation
+)
+
+// 式別名や組番に付くまとめ買いの表記
+var narBetLayoutKeywords = []struct {
+	keyword string
+	layout  narBetLayout
+}{
+	{"BOX", narBetBox},
+	{"ボックス", narBetBox},
+	{"ながし", narBetWheel},
+	{"流し", narBetWheel},
+	{"フォーメーション", narBetFormation},
+}
+
+// 1点あたりの馬番の数
+var narBetNumberSizes = map[types.TicketType]int{
+	types.Win:             1,
+	types.Place:           1,
+	types.BracketQuinella: 2,
+	types.Quinella:        2,
+	types.Exacta:          2,
+	types.QuinellaPlace:   2,
+	types.Trio:            3,
+	types.Trifecta:        3,
+}
+
+// まとめ買いをpatのどの式別の表記に寄せて1点ずつにバラすか、ここに無い組み合わせは読み込めない
+var narSubTicketTypes = map[types.TicketType]map[narBetLayout]types.TicketType{
+	types.Quinella:      {narBetWheel: types.QuinellaWheel},
+	types.Exacta:        {narBetWheel: types.ExactaWheelOfFirst},
+	types.QuinellaPlace: {narBetWheel: types.QuinellaPlaceWheel, narBetFormation: types.QuinellaPlaceFormation},
+	types.Trio:          {narBetWheel: types.TrioWheelOfFirst, narBetFormation: types.TrioFormation, narBetBox: types.TrioBox},
+	types.Trifecta:      {narBetWheel: types.TrifectaWheelOfFirst, narBetFormation: types.TrifectaFormation},
+}
+
+var narRaceDateLayouts = []string{
+	"2006/01/02",
+	"2006/1/2",
+	"2006-01-02",
+	"2006-1-2",
+	"20060102",
+	"2006年01月02日",
+	"2006年1月2日",
+}
+
+type narTicketRepository struct {
+	betNumberConverter master_service.BetNumberConverter
+	pathOptimizer      file_gateway.PathOptimizer
+}
+
+func NewNarTicketRepository(
+	betNumberConverter master_service.BetNumberConverter,
+	pathOptimizer file_gateway.PathOptimizer,
+) repository.NarTicketRepository {
+	return &narTicketRepository{
+		betNumberConverter: betNumberConverter,
+		pathOptimizer:      pathOptimizer,
+	}
+}
+
+func (n *narTicketRepository) List(ctx context.Context, path string) ([]string, error) {
+	absPath, err := n.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := filepath.Join(absPath, "*.csv")
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	fileNames := make([]string, 0, len(files))
+	for _, file := range files {
+		if n.getSource(file) == types.UnknownTicketSource {
+			continue
+		}
+		fileNames = append(fileNames, filepath.Base(file))
+	}
+
+	return fileNames, nil
+}
+
+func (n *narTicketRepository) Read(
+	ctx context.Context,
+	path string,
+	account types.Account,
+) ([]*ticket_csv_entity.Ticket, error) {
+	source := n.getSource(path)
+	if source == types.UnknownTicketSource {
+		return nil, fmt.Errorf("unknown nar ticket source: %s", path)
+	}
+
+	absPath, err := n.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// SPAT4、オッズパークはShift_JIS、楽天競馬はUTF-8で出力されるので中身で判定する
+	var r io.Reader = bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if !utf8.Valid(data) {
+		r = transform.NewReader(bytes.NewReader(data), japanese.ShiftJIS.NewDecoder())
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var (
+		tickets   []*ticket_csv_entity.Ticket
+		columnMap map[narTicketColumn]int
+	)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// ヘッダ行より前にある口座情報などの行は読み飛ばす
+		if columnMap == nil {
+			columnMap = n.getColumnMap(record)
+			continue
+		}
+
+		ticket, err := n.createTicket(ctx, record, columnMap, source, account)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if ticket != nil {
+			tickets = append(tickets, ticket)
+		}
+	}
+
+	if columnMap == nil {
+		return nil, fmt.Errorf("header not found: %s", path)
+	}
+
+	return tickets, nil
+}
+
+// createTicket 1行を1枚の馬券にする。ながし、ボックス、フォーメーションの行はpatの表記にまとめたまま返す
+// 的中した組番は出力されないので、1点ずつへのバラしはレースの払戻結果と突き合わせられるNarTicketで行う
+func (n *narTicketRepository) createTicket(
+	ctx context.Context,
+	record []string,
+	columnMap map[narTicketColumn]int,
+	source types.TicketSource,
+	account types.Account,
+) (*ticket_csv_entity.Ticket, error) {
+	value := func(column narTicketColumn) string {
+		idx, ok := columnMap[column]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(width.Fold.String(record[idx]))
+	}
+
+	// 合計行、空行
+	if value(narTicketRaceDate) == "" || value(narTicketTicketType) == "" {
+		return nil, nil
+	}
+	// 返還、取消は集計しない
+	if status := value(narTicketStatus); strings.Contains(status, "返還") || strings.Contains(status, "取消") {
+		return nil, nil
+	}
+
+	raceDate, err := n.parseRaceDate(value(narTicketRaceDate))
+	if err != nil {
+		return nil, err
+	}
+
+	raceCourseName := strings.TrimSuffix(strings.TrimSuffix(value(narTicketRaceCourse), "競馬場"), "競馬")
+	if !types.NewRaceCourse(raceCourseName).NAR() {
+		return nil, fmt.Errorf("not nar race course: %s", value(narTicketRaceCourse))
+	}
+
+	raceNo := strings.TrimSuffix(strings.TrimSuffix(value(narTicketRaceNo), "レース"), "R")
+
+	ticketTypeName, layout := n.extractLayout(value(narTicketTicketType), narBetSingle)
+	ticketType, ok := narTicketTypeNames[ticketTypeName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported ticket type: %s", value(narTicketTicketType))
+	}
+
+	subTicketType, betNumber, points, err := n.parseBetNumber(ctx, value(narTicketBetNumber), ticketType, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	// 購入金額は行の合計なので、まとめ買いは1点あたりに割り切れるかだけ確認しておく
+	payment, err := strconv.Atoi(n.trimAmount(value(narTicketPayment)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment: %s", value(narTicketPayment))
+	}
+	if payment%points != 0 {
+		return nil, fmt.Errorf("payment %d is not divisible by %d bets: %s", payment, points, value(narTicketBetNumber))
+	}
+	payout := n.trimAmount(value(narTicketPayout))
+
+	return ticket_csv_entity.NewTicket(
+		betNumber,
+		raceDate,
+		raceCourseName,
+		raceNo,
+		subTicketType.Name(),
+		payout != "" && payout != "0",
+		strconv.Itoa(payment),
+		payout,
+		source,
+		account,
+	)
+}
+
+func (n *narTicketRepository) getSource(path string) types.TicketSource {
+	fileName := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	switch {
+	case strings.HasSuffix(fileName, ticketSpat4DataSuffix):
+		return types.Spat4
+	case strings.HasSuffix(fileName, ticketRakutenKeibaDataSuffix):
+		return types.RakutenKeiba
+	case strings.HasSuffix(fileName, ticketOddsparkDataSuffix):
+		return types.Oddspark
+	}
+	return types.UnknownTicketSource
+}
+
+func (n *narTicketRepository) getColumnMap(record []string) map[narTicketColumn]int {
+	columnMap := map[narTicketColumn]int{}
+	for column, names := range narTicketColumnNames {
+		for _, name := range names {
+			for idx, header := range record {
+				if strings.TrimSpace(width.Fold.String(header)) == width.Fold.String(name) {
+					columnMap[column] = idx
+					break
+				}
+			}
+			if _, ok := columnMap[column]; ok {
+				break
+			}
+		}
+	}
+
+	for _, column := range []narTicketColumn{narTicketRaceDate, narTicketRaceCourse, narTicketRaceNo, narTicketTicketType, narTicketBetNumber, narTicketPayment} {
+		if _, ok := columnMap[column]; !ok {
+			return nil
+		}
+	}
+
+	return columnMap
+}
+
+func (n *narTicketRepository) parseRaceDate(rawRaceDate string) (string, error) {
+	// 時刻付きで出力される場合は日付部分だけ使う
+	rawRaceDate = strings.Fields(rawRaceDate)[0]
+	for _, layout := range narRaceDateLayouts {
+		date, err := time.Parse(layout, rawRaceDate)
+		if err == nil {
+			return date.Format("20060102"), nil
+		}
+	}
+	return "", fmt.Errorf("invalid race date: %s", rawRaceDate)
+}
+
+// extractLayout 式別名や組番からまとめ買いの表記を取り除き、どのまとめ買いかを返す
+func (n *narTicketRepository) extractLayout(rawValue string, layout narBetLayout) (string, narBetLayout) {
+	for _, layoutKeyword := range narBetLayoutKeywords {
+		if strings.Contains(rawValue, layoutKeyword.keyword) {
+			rawValue = strings.TrimSpace(strings.ReplaceAll(rawValue, layoutKeyword.keyword, ""))
+			layout = layoutKeyword.layout
+		}
+	}
+	return rawValue, layout
+}
+
+// parseBetNumber 組番をpatの表記(2桁ゼロ埋め、単系は→、複系は-区切り)に揃えて点数と返す
+// まとめ買いは軸・相手や着順ごとの馬番を/で区切った表記をpatのながし等の表記に直し、バラせるかを確認する
+func (n *narTicketRepository) parseBetNumber(
+	ctx context.Context,
+	rawBetNumber string,
+	ticketType types.TicketType,
+	layout narBetLayout,
+) (types.TicketType, types.BetNumber, int, error) {
+	betNumberText, layout := n.extractLayout(rawBetNumber, layout)
+
+	var groups [][]int
+	for _, rawGroup := range strings.Split(betNumberText, "/") {
+		numbers := regexp.MustCompile(`\d+`).FindAllString(rawGroup, -1)
+		if len(numbers) == 0 {
+			return 0, "", 0, fmt.Errorf("invalid bet number: %s", rawBetNumber)
+		}
+		group := make([]int, 0, len(numbers))
+		for _, number := range numbers {
+			num, err := strconv.Atoi(number)
+			if err != nil {
+				return 0, "", 0, err
+			}
+			group = append(group, num)
+		}
+		groups = append(groups, group)
+	}
+
+	size := narBetNumberSizes[ticketType]
+	if layout == narBetSingle {
+		// 着順ごとに1頭ずつ/で区切った表記は1点買いとして扱う
+		numbers := make([]int, 0, size)
+		for _, group := range groups {
+			numbers = append(numbers, group...)
+		}
+		if len(groups) > 1 && len(numbers) != len(groups) {
+			layout = narBetFormation
+		} else {
+			if len(numbers) != size {
+				return 0, "", 0, fmt.Errorf("invalid bet number: %s", rawBetNumber)
+			}
+			return ticketType, n.joinBetNumber(numbers, ticketType), 1, nil
+		}
+	}
+
+	subTicketType, ok := narSubTicketTypes[ticketType][layout]
+	if !ok {
+		return 0, "", 0, fmt.Errorf("unsupported bet number layout: %s %s", ticketType.Name(), rawBetNumber)
+	}
+	switch layout {
+	case narBetBox:
+		if len(groups) != 1 {
+			return 0, "", 0, fmt.Errorf("invalid box bet number: %s", rawBetNumber)
+		}
+	case narBetWheel:
+		if len(groups) != 2 {
+			return 0, "", 0, fmt.Errorf("invalid wheel bet number: %s", rawBetNumber)
+		}
+		switch {
+		case len(groups[0]) == 1:
+		case len(groups[0]) == 2 && subTicketType == types.TrioWheelOfFirst:
+			subTicketType = types.TrioWheelOfSecond
+		default:
+			return 0, "", 0, fmt.Errorf("unsupported wheel pivot count: %s", rawBetNumber)
+		}
+	case narBetFormation:
+		if len(groups) != size {
+			return 0, "", 0, fmt.Errorf("invalid formation bet number: %s", rawBetNumber)
+		}
+	}
+
+	// patの表記は着順・軸ごとを／、同じ着順・軸の馬番を；で昇順に区切る
+	patGroups := make([]string, 0, len(groups))
+	for _, group := range groups {
+		sort.Ints(group)
+		numbers := make([]string, 0, len(group))
+		for _, number := range group {
+			numbers = append(numbers, fmt.Sprintf("%02d", number))
+		}
+		patGroups = append(patGroups, strings.Join(numbers, "；"))
+	}
+	betNumber := types.BetNumber(strings.Join(patGroups, "／"))
+	betNumbers, err := n.betNumberConverter.ToBetNumbers(ctx, subTicketType, string(betNumber))
+	if err != nil {
+		return 0, "", 0, err
+	}
+	if len(betNumbers) == 0 {
+		return 0, "", 0, fmt.Errorf("no bet number: %s", rawBetNumber)
+	}
+
+	return subTicketType, betNumber, len(betNumbers), nil
+}
+
+func (n *narTicketRepository) joinBetNumber(numbers []int, ticketType types.TicketType) types.BetNumber {
+	paddedNumbers := make([]string, 0, len(numbers))
+	for _, number := range numbers {
+		paddedNumbers = append(paddedNumbers, fmt.Sprintf("%02d", number))
+	}
+
+	separator := types.QuinellaSeparator
+	if ticketType == types.Exacta || ticketType == types.Trifecta {
+		separator = types.ExactaSeparator
+	}
+
+	return types.NewBetNumber(strings.Join(paddedNumbers, separator))
+}
+
+func (n *narTicketRepository) trimAmount(rawAmount string) string {
+	// 払戻なしは空欄や-で出力される
+	amount := strings.NewReplacer(",", "", "円", "", " ", "").Replace(rawAmount)
+	if amount == "-" {
+		return ""
+	}
+	return amount
+}
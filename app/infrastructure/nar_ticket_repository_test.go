@@ -0,0 +1,121 @@
+package infrastructure
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/master_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"golang.org/x/text/width"
+)
+
+func TestNarTicketRepositoryParseBetNumber(t *testing.T) {
+	tests := []struct {
+		name           string
+		ticketTypeName string
+		rawBetNumber   string
+		wantTicketType types.TicketType
+		wantBetNumber  types.BetNumber
+		wantPoints     int
+		wantErr        bool
+	}{
+		{name: "単勝", ticketTypeName: "単勝", rawBetNumber: "3", wantTicketType: types.Win, wantBetNumber: "03", wantPoints: 1},
+		{name: "馬連1点", ticketTypeName: "馬連", rawBetNumber: "3-12", wantTicketType: types.Quinella, wantBetNumber: "03-12", wantPoints: 1},
+		{name: "三連単1点", ticketTypeName: "三連単", rawBetNumber: "5→1→2", wantTicketType: types.Trifecta, wantBetNumber: "05→01→02", wantPoints: 1},
+		{name: "三連単を着順ごとに区切った1点", ticketTypeName: "三連単", rawBetNumber: "5/1/2", wantTicketType: types.Trifecta, wantBetNumber: "05→01→02", wantPoints: 1},
+		{name: "馬連ながし", ticketTypeName: "馬連", rawBetNumber: "5/1,8 ながし", wantTicketType: types.QuinellaWheel, wantBetNumber: "05／01；08", wantPoints: 2},
+		{name: "式別名にながし", ticketTypeName: "ワイドながし", rawBetNumber: "5/1,8", wantTicketType: types.QuinellaPlaceWheel, wantBetNumber: "05／01；08", wantPoints: 2},
+		{name: "三連複軸2頭ながし", ticketTypeName: "三連複", rawBetNumber: "1,2/3,4 流し", wantTicketType: types.TrioWheelOfSecond, wantBetNumber: "01；02／03；04", wantPoints: 2},
+		{name: "三連複BOX", ticketTypeName: "三連複", rawBetNumber: "4,7,11,2 ＢＯＸ", wantTicketType: types.TrioBox, wantBetNumber: "02；04；07；11", wantPoints: 4},
+		{name: "三連単フォーメーション", ticketTypeName: "三連単フォーメーション", rawBetNumber: "1/2,3/2,3,4", wantTicketType: types.TrifectaFormation, wantBetNumber: "01／02；03／02；03；04", wantPoints: 4},
+		{name: "着順ごとの区切りに複数頭はフォーメーション", ticketTypeName: "三連複", rawBetNumber: "1/2,3/4", wantTicketType: types.TrioFormation, wantBetNumber: "01／02；03／04", wantPoints: 2},
+		{name: "馬連BOXは読み込めない", ticketTypeName: "馬連", rawBetNumber: "1,2,3 BOX", wantErr: true},
+		{name: "区切りの無い複数頭", ticketTypeName: "三連複", rawBetNumber: "1,2,3,4", wantErr: true},
+		{name: "ながしの軸と相手が無い", ticketTypeName: "三連単", rawBetNumber: "1,2,3 ながし", wantErr: true},
+		{name: "組番が空", ticketTypeName: "馬単", rawBetNumber: "", wantErr: true},
+	}
+
+	repository := &narTicketRepository{betNumberConverter: master_service.NewBetNumberConverter()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticketTypeName, layout := repository.extractLayout(tt.ticketTypeName, narBetSingle)
+			ticketType, ok := narTicketTypeNames[ticketTypeName]
+			if !ok {
+				t.Fatalf("unknown ticket type: %s", tt.ticketTypeName)
+			}
+			gotTicketType, gotBetNumber, gotPoints, err := repository.parseBetNumber(context.Background(), strings.TrimSpace(width.Fold.String(tt.rawBetNumber)), ticketType, layout)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseBetNumber() = %v, want error", gotBetNumber)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseBetNumber() error = %v", err)
+			}
+			if gotTicketType != tt.wantTicketType {
+				t.Errorf("ticket type = %s, want %s", gotTicketType.Name(), tt.wantTicketType.Name())
+			}
+			if gotBetNumber != tt.wantBetNumber || gotPoints != tt.wantPoints {
+				t.Errorf("bet number = %v (%d), want %v (%d)", gotBetNumber, gotPoints, tt.wantBetNumber, tt.wantPoints)
+			}
+		})
+	}
+}
+
+func TestNarTicketRepositoryCreateTicket(t *testing.T) {
+	columnMap := map[narTicketColumn]int{
+		narTicketRaceDate:   0,
+		narTicketRaceCourse: 1,
+		narTicketRaceNo:     2,
+		narTicketTicketType: 3,
+		narTicketBetNumber:  4,
+		narTicketPayment:    5,
+		narTicketPayout:     6,
+		narTicketStatus:     7,
+	}
+	tests := []struct {
+		name           string
+		record         []string
+		wantNil        bool
+		wantTicketType types.TicketType
+		wantBetNumber  types.BetNumber
+		wantPayment    int
+		wantPayout     int
+		wantErr        bool
+	}{
+		{name: "1点", record: []string{"2024/01/02", "大井", "11R", "単勝", "3", "100", "350", "的中"}, wantTicketType: types.Win, wantBetNumber: "03", wantPayment: 100, wantPayout: 350},
+		{name: "ボックスは行のまままとめておく", record: []string{"2024/01/02", "大井", "11R", "三連複", "1,2,3,4 BOX", "400", "1,230", "的中"}, wantTicketType: types.TrioBox, wantBetNumber: "01；02；03；04", wantPayment: 400, wantPayout: 1230},
+		{name: "返還は集計しない", record: []string{"2024/01/02", "大井", "11R", "単勝", "3", "100", "100", "返還"}, wantNil: true},
+		{name: "購入金額が点数で割り切れない", record: []string{"2024/01/02", "大井", "11R", "三連複", "1,2,3,4 BOX", "450", "", ""}, wantErr: true},
+	}
+
+	repository := &narTicketRepository{betNumberConverter: master_service.NewBetNumberConverter()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket, err := repository.createTicket(context.Background(), tt.record, columnMap, types.Spat4, types.Account(""))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("createTicket() = %v, want error", ticket)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("createTicket() error = %v", err)
+			}
+			if tt.wantNil {
+				if ticket != nil {
+					t.Fatalf("createTicket() = %v, want nil", ticket)
+				}
+				return
+			}
+			if ticket.TicketType() != tt.wantTicketType || ticket.BetNumber() != tt.wantBetNumber {
+				t.Errorf("ticket %s %s, want %s %s", ticket.TicketType().Name(), ticket.BetNumber(), tt.wantTicketType.Name(), tt.wantBetNumber)
+			}
+			if ticket.Payment().Value() != tt.wantPayment || ticket.Payout().Value() != tt.wantPayout {
+				t.Errorf("ticket payment %d payout %d, want %d %d", ticket.Payment().Value(), ticket.Payout().Value(), tt.wantPayment, tt.wantPayout)
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	neturl "net/url"
 	"os"
 	"path/filepath"
@@ -35,12 +34,7 @@ func (o *oddsRepository) List(
 	ctx context.Context,
 	path string,
 ) ([]string, error) {
-	rootPath, err := o.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := o.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -64,12 +58,7 @@ func (o *oddsRepository) Read(
 	path string,
 ) ([]*raw_entity.RaceOdds, error) {
 	raceOdds := make([]*raw_entity.RaceOdds, 0)
-	rootPath, err := o.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := o.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -102,17 +91,11 @@ func (o *oddsRepository) Write(
 		return err
 	}
 
-	rootPath, err := o.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return err
-	}
-
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := o.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return err
 	}
-
-	err = os.WriteFile(absPath, buffer.Bytes(), 0644)
+	err = file_gateway.WriteFile(absPath, buffer.Bytes())
 	if err != nil {
 		return err
 	}
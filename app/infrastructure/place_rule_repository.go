@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+type placeRuleRepository struct {
+	pathOptimizer file_gateway.PathOptimizer
+}
+
+func NewPlaceRuleRepository(
+	pathOptimizer file_gateway.PathOptimizer,
+) repository.PlaceRuleRepository {
+	return &placeRuleRepository{
+		pathOptimizer: pathOptimizer,
+	}
+}
+
+func (p *placeRuleRepository) Read(
+	ctx context.Context,
+	path string,
+) (*raw_entity.PlaceRuleInfo, error) {
+	// ルールが無いとチェックリストが作れないので、上書きも既定のルールも読めなければキャッシュと違いエラーにする
+	bytes, err := readRuleFile(p.pathOptimizer, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var placeRuleInfo *raw_entity.PlaceRuleInfo
+	if err := json.Unmarshal(bytes, &placeRuleInfo); err != nil {
+		return nil, err
+	}
+
+	return placeRuleInfo, nil
+}
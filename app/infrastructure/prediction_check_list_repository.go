@@ -0,0 +1,69 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+type predictionCheckListRepository struct {
+	pathOptimizer file_gateway.PathOptimizer
+}
+
+func NewPredictionCheckListRepository(
+	pathOptimizer file_gateway.PathOptimizer,
+) repository.PredictionCheckListRepository {
+	return &predictionCheckListRepository{
+		pathOptimizer: pathOptimizer,
+	}
+}
+
+func (p *predictionCheckListRepository) Read(
+	ctx context.Context,
+	path string,
+) (*raw_entity.PredictionCheckListInfo, error) {
+	absPath, err := p.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// チェックリストをまだ作成していない場合はエラーは返さず処理を継続する
+	bytes, err := os.ReadFile(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var predictionCheckListInfo *raw_entity.PredictionCheckListInfo
+	if err := json.Unmarshal(bytes, &predictionCheckListInfo); err != nil {
+		return nil, err
+	}
+
+	return predictionCheckListInfo, nil
+}
+
+func (p *predictionCheckListRepository) Write(
+	ctx context.Context,
+	path string,
+	predictionCheckListInfo *raw_entity.PredictionCheckListInfo,
+) error {
+	var buffer bytes.Buffer
+	enc := json.NewEncoder(&buffer)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(predictionCheckListInfo); err != nil {
+		return err
+	}
+
+	filePath, err := p.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+	return file_gateway.WriteFile(filePath, buffer.Bytes())
+}
@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+func TestPredictionCheckListRepositoryRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *raw_entity.PredictionCheckListInfo
+		wantErr bool
+	}{
+		{
+			name: "未作成の場合はnil",
+		},
+		{
+			name:    "JSONとして不正な場合はエラー",
+			content: "{",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheDir := t.TempDir()
+			if tt.content != "" {
+				if err := os.WriteFile(filepath.Join(cacheDir, "check_list.json"), []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			repository := NewPredictionCheckListRepository(file_gateway.NewPathOptimizer(&file_gateway.PathConfig{DataDir: t.TempDir(), CacheDir: cacheDir}))
+
+			got, err := repository.Read(context.Background(), "cache/check_list.json")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPredictionCheckListRepositoryWrite(t *testing.T) {
+	repository := NewPredictionCheckListRepository(file_gateway.NewPathOptimizer(&file_gateway.PathConfig{DataDir: t.TempDir(), CacheDir: t.TempDir()}))
+	want := &raw_entity.PredictionCheckListInfo{
+		UpdatedAt: "2024-10-20 09:00:00",
+		PredictionCheckLists: []*raw_entity.PredictionCheckList{
+			{
+				RaceId:    "202405040811",
+				HorseName: "テストホース",
+				RaceUrl:   "https://race.netkeiba.com/race/shutuba.html?race_id=202405040811&rf=race_list",
+				CheckList: []string{"◯", "×"},
+			},
+		},
+	}
+
+	if err := repository.Write(context.Background(), "cache/check_list.json", want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := repository.Read(context.Background(), "cache/check_list.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Read() = %+v, want %+v", got, want)
+	}
+}
@@ -3,10 +3,8 @@ package infrastructure
 import (
 	"context"
 	"encoding/csv"
-	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
@@ -33,11 +31,7 @@ func (p *predictionMarkerRepository) Read(
 	ctx context.Context,
 	path string,
 ) ([]*marker_csv_entity.PredictionMarker, error) {
-	rootPath, err := p.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := p.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
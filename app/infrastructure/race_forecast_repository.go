@@ -6,13 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/tospo_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/gateway"
+	"github.com/mapserver2007/ipat-aggregator/config"
 )
 
 const (
@@ -38,12 +38,7 @@ func (r *raceForecastRepository) Read(
 	ctx context.Context,
 	path string,
 ) (*raw_entity.RaceForecastInfo, error) {
-	rootPath, err := r.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := r.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -75,17 +70,11 @@ func (r *raceForecastRepository) Write(
 		return err
 	}
 
-	rootPath, err := r.pathOptimizer.GetProjectRoot()
+	filePath, err := r.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return err
 	}
-
-	filePath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
-	if err != nil {
-		return err
-	}
-
-	err = os.WriteFile(filePath, buffer.Bytes(), 0644)
+	err = file_gateway.WriteFile(filePath, buffer.Bytes())
 	if err != nil {
 		return err
 	}
@@ -97,12 +86,7 @@ func (r *raceForecastRepository) FetchRaceForecast(
 	ctx context.Context,
 	url string,
 ) ([]*tospo_entity.Forecast, error) {
-	rootPath, err := r.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/secret/%s", rootPath, cookieFileName))
+	absPath, err := r.pathOptimizer.GetAbsPath(fmt.Sprintf("%s/%s", config.SecretDir, cookieFileName))
 	if err != nil {
 		return nil, err
 	}
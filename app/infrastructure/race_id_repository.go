@@ -3,9 +3,7 @@ package infrastructure
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
@@ -32,12 +30,7 @@ func (r *raceIdRepository) Read(
 	ctx context.Context,
 	path string,
 ) (*raw_entity.RaceIdInfo, error) {
-	rootPath, err := r.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	filePath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	filePath, err := r.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -66,17 +59,11 @@ func (r *raceIdRepository) Write(
 		return err
 	}
 
-	rootPath, err := r.pathOptimizer.GetProjectRoot()
+	filePath, err := r.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return err
 	}
-
-	filePath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
-	if err != nil {
-		return err
-	}
-
-	err = os.WriteFile(filePath, bytes, 0644)
+	err = file_gateway.WriteFile(filePath, bytes)
 	if err != nil {
 		return err
 	}
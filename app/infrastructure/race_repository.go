@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"os"
 	"path/filepath"
 
@@ -34,12 +33,7 @@ func (r *raceRepository) List(
 	ctx context.Context,
 	path string,
 ) ([]string, error) {
-	rootPath, err := r.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := r.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -63,12 +57,7 @@ func (r *raceRepository) Read(
 	path string,
 ) ([]*raw_entity.Race, error) {
 	races := make([]*raw_entity.Race, 0)
-	rootPath, err := r.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	filePath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	filePath, err := r.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -101,17 +90,11 @@ func (r *raceRepository) Write(
 		return err
 	}
 
-	rootPath, err := r.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return err
-	}
-
-	filePath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	filePath, err := r.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return err
 	}
-
-	err = os.WriteFile(filePath, buffer.Bytes(), 0644)
+	err = file_gateway.WriteFile(filePath, buffer.Bytes())
 	if err != nil {
 		return err
 	}
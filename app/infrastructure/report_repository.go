@@ -0,0 +1,139 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/report_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+// 添付やCDNなしでメールに貼ったりブラウザで開いたりできるように、テンプレートはバイナリに埋め込みCSSもHTMLに書く
+//
+//go:embed report_template
+var reportTemplateFS embed.FS
+
+const (
+	reportHtmlFileName     = "report.html"
+	reportMarkdownFileName = "report.md"
+)
+
+var reportTemplateFuncs = map[string]any{
+	"yen": formatYen,
+	"signedYen": func(value int) string {
+		if value > 0 {
+			return "+" + formatYen(value)
+		}
+		return formatYen(value)
+	},
+	// dict 共通部品のテンプレートに見出しと結果を渡す
+	"dict": func(label string, value any) map[string]any {
+		return map[string]any{label: value}
+	},
+	"sub": func(a, b int) int {
+		return a - b
+	},
+	"date": func(t time.Time) string {
+		return t.Format("2006/01/02")
+	},
+	"dateTime": func(t time.Time) string {
+		return t.Format("2006/01/02 15:04:05")
+	},
+}
+
+type reportRepository struct {
+	pathOptimizer file_gateway.PathOptimizer
+}
+
+func NewReportRepository(
+	pathOptimizer file_gateway.PathOptimizer,
+) repository.ReportRepository {
+	return &reportRepository{
+		pathOptimizer: pathOptimizer,
+	}
+}
+
+// Write 一時ディレクトリにHTMLとMarkdownを書き出してからディレクトリ名を付ける。ディレクトリが既にある場合は上書きせずエラーにする
+func (r *reportRepository) Write(
+	ctx context.Context,
+	dir string,
+	report *report_entity.Report,
+) error {
+	absDir, err := r.pathOptimizer.GetAbsPath(dir)
+	if err != nil {
+		return err
+	}
+
+	htmlTemplate, err := htmltemplate.New(reportHtmlFileName).Funcs(reportTemplateFuncs).ParseFS(reportTemplateFS, "report_template/"+reportHtmlFileName)
+	if err != nil {
+		return err
+	}
+	markdownTemplate, err := template.New(reportMarkdownFileName).Funcs(reportTemplateFuncs).ParseFS(reportTemplateFS, "report_template/"+reportMarkdownFileName)
+	if err != nil {
+		return err
+	}
+
+	var htmlBuffer, markdownBuffer bytes.Buffer
+	if err = htmlTemplate.Execute(&htmlBuffer, report); err != nil {
+		return err
+	}
+	if err = markdownTemplate.Execute(&markdownBuffer, report); err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(absDir), 0755); err != nil {
+		return err
+	}
+	if _, err = os.Stat(absDir); err == nil {
+		return fmt.Errorf("report already exists: %s", absDir)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	// 一時ディレクトリに書き出してからrenameするので、途中で落ちても書きかけのディレクトリは残らない
+	tmpDir, err := os.MkdirTemp(filepath.Dir(absDir), fmt.Sprintf(".%s.*.tmp", filepath.Base(absDir)))
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = file_gateway.WriteFileAtomic(filepath.Join(tmpDir, reportHtmlFileName), htmlBuffer.Bytes()); err != nil {
+		return err
+	}
+	if err = file_gateway.WriteFileAtomic(filepath.Join(tmpDir, reportMarkdownFileName), markdownBuffer.Bytes()); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmpDir, 0755); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpDir, absDir)
+}
+
+// formatYen 3桁区切りにする
+func formatYen(value int) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	digits := strconv.Itoa(value)
+	var builder strings.Builder
+	for idx, digit := range digits {
+		if idx > 0 && (len(digits)-idx)%3 == 0 {
+			builder.WriteRune(',')
+		}
+		builder.WriteRune(digit)
+	}
+	return sign + builder.String() + "円"
+}
@@ -0,0 +1,99 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/report_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+func TestFormatYen(t *testing.T) {
+	tests := []struct {
+		name  string
+		value int
+		want  string
+	}{
+		{
+			name:  "0円",
+			value: 0,
+			want:  "0円",
+		},
+		{
+			name:  "3桁以下は区切らない",
+			value: 999,
+			want:  "999円",
+		},
+		{
+			name:  "3桁区切り",
+			value: 1234567,
+			want:  "1,234,567円",
+		},
+		{
+			name:  "マイナス",
+			value: -100000,
+			want:  "-100,000円",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatYen(tt.value); got != tt.want {
+				t.Errorf("formatYen() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportRepositoryWrite(t *testing.T) {
+	dataDir := t.TempDir()
+	repository := NewReportRepository(file_gateway.NewPathOptimizer(&file_gateway.PathConfig{DataDir: dataDir}))
+	result := spreadsheet_entity.NewTicketResult(2, 3, 1, 3000, 12345, 12345, 12345, 12345)
+	report := report_entity.NewReport(
+		types.WeekReport,
+		time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 10, 21, 0, 0, 0, 0, time.UTC),
+		types.AllAccount,
+		time.Date(2024, 10, 21, 9, 0, 0, 0, time.UTC),
+		result,
+		spreadsheet_entity.NewTicketResult(0, 0, 0, 0, 0, 0, 0, 0),
+		[]*report_entity.Result{report_entity.NewResult(types.Win.Name(), result)},
+		nil,
+		nil,
+		nil,
+		nil,
+		report_entity.NewBankroll(10000, []*report_entity.BankrollDay{report_entity.NewBankrollDay(20241020, 3000, 12345, 19345)}),
+	)
+	dir := "report/week_20241014/20241021-090000"
+
+	if err := repository.Write(context.Background(), dir, report); err != nil {
+		t.Fatal(err)
+	}
+	for _, fileName := range []string{reportHtmlFileName, reportMarkdownFileName} {
+		data, err := os.ReadFile(filepath.Join(dataDir, dir, fileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "12,345円") {
+			t.Errorf("%s does not contain payout", fileName)
+		}
+	}
+
+	// 過去のレポートは上書きせず、一時ディレクトリも残さない
+	if err := repository.Write(context.Background(), dir, report); err == nil {
+		t.Errorf("Write() to existing dir error = nil, want error")
+	}
+	entries, err := os.ReadDir(filepath.Join(dataDir, filepath.Dir(dir)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != filepath.Base(dir) {
+		t.Errorf("report dir entries = %v, want only %s", entries, filepath.Base(dir))
+	}
+}
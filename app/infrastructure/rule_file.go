@@ -0,0 +1,32 @@
+package infrastructure
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+	"github.com/mapserver2007/ipat-aggregator/rule"
+)
+
+// readRuleFile データディレクトリのruleにあるファイルを読み、無ければバイナリに埋め込んだ既定のファイルを読む
+func readRuleFile(
+	pathOptimizer file_gateway.PathOptimizer,
+	path string,
+) ([]byte, error) {
+	absPath, err := pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := os.ReadFile(absPath)
+	if err == nil {
+		return bytes, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return fs.ReadFile(rule.DefaultFS, filepath.Base(absPath))
+}
@@ -0,0 +1,67 @@
+package infrastructure
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+	"github.com/mapserver2007/ipat-aggregator/rule"
+)
+
+func TestReadRuleFile(t *testing.T) {
+	defaultStakeRule, err := fs.ReadFile(rule.DefaultFS, "stake_rule.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		path     string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "上書きが無ければ埋め込みの既定値",
+			path: "rule/stake_rule.json",
+			want: string(defaultStakeRule),
+		},
+		{
+			name:     "データディレクトリのファイルを優先",
+			override: `{"bankroll": 1}`,
+			path:     "rule/stake_rule.json",
+			want:     `{"bankroll": 1}`,
+		},
+		{
+			name:    "既定値にも無いファイル",
+			path:    "rule/unknown.json",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataDir := t.TempDir()
+			if tt.override != "" {
+				path := filepath.Join(dataDir, filepath.FromSlash(tt.path))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(tt.override), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			pathOptimizer := file_gateway.NewPathOptimizer(&file_gateway.PathConfig{DataDir: dataDir})
+
+			got, err := readRuleFile(pathOptimizer, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readRuleFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("readRuleFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
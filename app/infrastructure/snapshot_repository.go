@@ -0,0 +1,113 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+const snapshotFileExt = ".json"
+
+type snapshotRepository struct {
+	pathOptimizer file_gateway.PathOptimizer
+}
+
+func NewSnapshotRepository(
+	pathOptimizer file_gateway.PathOptimizer,
+) repository.SnapshotRepository {
+	return &snapshotRepository{
+		pathOptimizer: pathOptimizer,
+	}
+}
+
+// List ディレクトリ内のスナップショット名を古い順に返す
+func (s *snapshotRepository) List(
+	ctx context.Context,
+	dir string,
+) ([]string, error) {
+	absDir, err := s.pathOptimizer.GetAbsPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// まだ一度も書き出していないシートはスナップショット無しとして扱う
+	entries, err := os.ReadDir(absDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), snapshotFileExt) {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), snapshotFileExt))
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (s *snapshotRepository) Read(
+	ctx context.Context,
+	path string,
+) (*raw_entity.Snapshot, error) {
+	absPath, err := s.pathOptimizer.GetAbsPath(path + snapshotFileExt)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot raw_entity.Snapshot
+	if err = json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// Write 過去のスナップショットは上書きしない。同じ名前がある場合は末尾に-1、-2と連番を付けて保存する
+func (s *snapshotRepository) Write(
+	ctx context.Context,
+	path string,
+	snapshot *raw_entity.Snapshot,
+) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	absPath, err := s.pathOptimizer.GetAbsPath(path + snapshotFileExt)
+	if err != nil {
+		return err
+	}
+	basePath := strings.TrimSuffix(absPath, snapshotFileExt)
+	for seq := 1; ; seq++ {
+		if _, err = os.Stat(absPath); os.IsNotExist(err) {
+			break
+		} else if err != nil {
+			return err
+		}
+		absPath = fmt.Sprintf("%s-%d%s", basePath, seq, snapshotFileExt)
+	}
+	if err = os.MkdirAll(filepath.Dir(absPath), 0755); err != nil {
+		return err
+	}
+
+	return file_gateway.WriteFileAtomic(absPath, data)
+}
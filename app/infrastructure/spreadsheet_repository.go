@@ -11,16 +11,22 @@ import (
 )
 
 type spreadSheetRepository struct {
-	summaryGateway             gateway.SpreadSheetSummaryGateway
-	ticketSummaryGateway       gateway.SpreadSheetTicketSummaryGateway
-	listGateway                gateway.SpreadSheetListGateway
-	analysisPlaceGateway       gateway.SpreadSheetAnalysisPlaceGateway
-	analysisPlaceAllInGateway  gateway.SpreadSheetAnalysisPlaceAllInGateway
-	analysisPlaceUnhitGateway  gateway.SpreadSheetAnalysisPlaceUnhitGateway
-	analysisRaceTimeGateway    gateway.SpreadSheetAnalysisRaceTimeGateway
-	predictionOddsGateway      gateway.SpreadSheetPredictionOddsGateway
-	predictionCheckListGateway gateway.SpreadSheetPredictionCheckListGateway
-	predictionMarkerGateway    gateway.SpreadSheetPredictionMarkerGateway
+	summaryGateway                 gateway.SpreadSheetSummaryGateway
+	ticketSummaryGateway           gateway.SpreadSheetTicketSummaryGateway
+	listGateway                    gateway.SpreadSheetListGateway
+	analysisPlaceGateway           gateway.SpreadSheetAnalysisPlaceGateway
+	analysisPlaceAllInGateway      gateway.SpreadSheetAnalysisPlaceAllInGateway
+	analysisPlaceUnhitGateway      gateway.SpreadSheetAnalysisPlaceUnhitGateway
+	analysisRaceTimeGateway        gateway.SpreadSheetAnalysisRaceTimeGateway
+	analysisPedigreeGateway        gateway.SpreadSheetAnalysisPedigreeGateway
+	analysisTrainerGateway         gateway.SpreadSheetAnalysisTrainerGateway
+	analysisMarkerTicketGateway    gateway.SpreadSheetAnalysisMarkerTicketGateway
+	analysisPlaceRateGateway       gateway.SpreadSheetAnalysisPlaceRateGateway
+	analysisMarkerConsensusGateway gateway.SpreadSheetAnalysisMarkerConsensusGateway
+	analysisTicketRepriceGateway   gateway.SpreadSheetAnalysisTicketRepriceGateway
+	predictionOddsGateway          gateway.SpreadSheetPredictionOddsGateway
+	predictionCheckListGateway     gateway.SpreadSheetPredictionCheckListGateway
+	predictionMarkerGateway        gateway.SpreadSheetPredictionMarkerGateway
 }
 
 func NewSpreadSheetRepository(
@@ -31,21 +37,33 @@ func NewSpreadSheetRepository(
 	analysisPlaceAllInGateway gateway.SpreadSheetAnalysisPlaceAllInGateway,
 	analysisPlaceUnhitGateway gateway.SpreadSheetAnalysisPlaceUnhitGateway,
 	analysisRaceTimeGateway gateway.SpreadSheetAnalysisRaceTimeGateway,
+	analysisPedigreeGateway gateway.SpreadSheetAnalysisPedigreeGateway,
+	analysisTrainerGateway gateway.SpreadSheetAnalysisTrainerGateway,
+	analysisMarkerTicketGateway gateway.SpreadSheetAnalysisMarkerTicketGateway,
+	analysisPlaceRateGateway gateway.SpreadSheetAnalysisPlaceRateGateway,
+	analysisMarkerConsensusGateway gateway.SpreadSheetAnalysisMarkerConsensusGateway,
+	analysisTicketRepriceGateway gateway.SpreadSheetAnalysisTicketRepriceGateway,
 	predictionOddsGateway gateway.SpreadSheetPredictionOddsGateway,
 	predictionCheckListGateway gateway.SpreadSheetPredictionCheckListGateway,
 	predictionMarkerGateway gateway.SpreadSheetPredictionMarkerGateway,
 ) repository.SpreadSheetRepository {
 	return &spreadSheetRepository{
-		summaryGateway:             summaryGateway,
-		ticketSummaryGateway:       ticketSummaryGateway,
-		listGateway:                listGateway,
-		analysisPlaceGateway:       analysisPlaceGateway,
-		analysisPlaceAllInGateway:  analysisPlaceAllInGateway,
-		analysisPlaceUnhitGateway:  analysisPlaceUnhitGateway,
-		analysisRaceTimeGateway:    analysisRaceTimeGateway,
-		predictionOddsGateway:      predictionOddsGateway,
-		predictionCheckListGateway: predictionCheckListGateway,
-		predictionMarkerGateway:    predictionMarkerGateway,
+		summaryGateway:                 summaryGateway,
+		ticketSummaryGateway:           ticketSummaryGateway,
+		listGateway:                    listGateway,
+		analysisPlaceGateway:           analysisPlaceGateway,
+		analysisPlaceAllInGateway:      analysisPlaceAllInGateway,
+		analysisPlaceUnhitGateway:      analysisPlaceUnhitGateway,
+		analysisRaceTimeGateway:        analysisRaceTimeGateway,
+		analysisPedigreeGateway:        analysisPedigreeGateway,
+		analysisTrainerGateway:         analysisTrainerGateway,
+		analysisMarkerTicketGateway:    analysisMarkerTicketGateway,
+		analysisPlaceRateGateway:       analysisPlaceRateGateway,
+		analysisMarkerConsensusGateway: analysisMarkerConsensusGateway,
+		analysisTicketRepriceGateway:   analysisTicketRepriceGateway,
+		predictionOddsGateway:          predictionOddsGateway,
+		predictionCheckListGateway:     predictionCheckListGateway,
+		predictionMarkerGateway:        predictionMarkerGateway,
 	}
 }
 
@@ -204,19 +222,167 @@ func (s *spreadSheetRepository) WriteAnalysisRaceTime(
 	return nil
 }
 
+func (s *spreadSheetRepository) WriteAnalysisPedigree(
+	ctx context.Context,
+	analysisPedigrees []*spreadsheet_entity.AnalysisPedigree,
+) error {
+	err := s.analysisPedigreeGateway.Clear(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisPedigreeGateway.Write(ctx, analysisPedigrees)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisPedigreeGateway.Style(ctx, analysisPedigrees)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetRepository) WriteAnalysisTrainer(
+	ctx context.Context,
+	analysisTrainers []*spreadsheet_entity.AnalysisTrainer,
+) error {
+	err := s.analysisTrainerGateway.Clear(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisTrainerGateway.Write(ctx, analysisTrainers)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisTrainerGateway.Style(ctx, analysisTrainers)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetRepository) WriteAnalysisMarkerTicket(
+	ctx context.Context,
+	analysisMarkerTickets []*spreadsheet_entity.AnalysisMarkerTicket,
+) error {
+	err := s.analysisMarkerTicketGateway.Clear(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisMarkerTicketGateway.Write(ctx, analysisMarkerTickets)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisMarkerTicketGateway.Style(ctx, analysisMarkerTickets)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetRepository) WriteAnalysisPlacePaddock(
+	ctx context.Context,
+	analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate,
+) error {
+	return s.writeAnalysisPlaceRate(ctx, gateway.SpreadSheetAnalysisPlacePaddockFileName, analysisPlaceRates)
+}
+
+func (s *spreadSheetRepository) WriteAnalysisCommentScore(
+	ctx context.Context,
+	analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate,
+) error {
+	return s.writeAnalysisPlaceRate(ctx, gateway.SpreadSheetAnalysisCommentScoreFileName, analysisPlaceRates)
+}
+
+func (s *spreadSheetRepository) writeAnalysisPlaceRate(
+	ctx context.Context,
+	fileName string,
+	analysisPlaceRates []*spreadsheet_entity.AnalysisPlaceRate,
+) error {
+	err := s.analysisPlaceRateGateway.Clear(ctx, fileName)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisPlaceRateGateway.Write(ctx, fileName, analysisPlaceRates)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisPlaceRateGateway.Style(ctx, fileName, analysisPlaceRates)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetRepository) WriteAnalysisMarkerConsensus(
+	ctx context.Context,
+	analysisMarkerConsensuses []*spreadsheet_entity.AnalysisMarkerConsensus,
+) error {
+	err := s.analysisMarkerConsensusGateway.Clear(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisMarkerConsensusGateway.Write(ctx, analysisMarkerConsensuses)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisMarkerConsensusGateway.Style(ctx, analysisMarkerConsensuses)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *spreadSheetRepository) WriteAnalysisTicketReprice(
+	ctx context.Context,
+	analysisTicketReprices []*spreadsheet_entity.AnalysisTicketReprice,
+) error {
+	err := s.analysisTicketRepriceGateway.Clear(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisTicketRepriceGateway.Write(ctx, analysisTicketReprices)
+	if err != nil {
+		return err
+	}
+
+	err = s.analysisTicketRepriceGateway.Style(ctx, analysisTicketReprices)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *spreadSheetRepository) WritePredictionOdds(
 	ctx context.Context,
 	firstPlaceMap,
 	secondPlaceMap,
 	thirdPlaceMap map[spreadsheet_entity.PredictionRace]map[types.Marker]*spreadsheet_entity.PredictionPlace,
 	raceCourseMap map[types.RaceCourse][]types.RaceId,
+	stakeMap map[types.RaceId][]*spreadsheet_entity.PredictionStake,
 ) error {
 	err := s.predictionOddsGateway.Clear(ctx)
 	if err != nil {
 		return err
 	}
 
-	err = s.predictionOddsGateway.Write(ctx, firstPlaceMap, secondPlaceMap, thirdPlaceMap, raceCourseMap)
+	err = s.predictionOddsGateway.Write(ctx, firstPlaceMap, secondPlaceMap, thirdPlaceMap, raceCourseMap, stakeMap)
 	if err != nil {
 		return err
 	}
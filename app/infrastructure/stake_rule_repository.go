@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+type stakeRuleRepository struct {
+	pathOptimizer file_gateway.PathOptimizer
+}
+
+func NewStakeRuleRepository(
+	pathOptimizer file_gateway.PathOptimizer,
+) repository.StakeRuleRepository {
+	return &stakeRuleRepository{
+		pathOptimizer: pathOptimizer,
+	}
+}
+
+func (s *stakeRuleRepository) Read(
+	ctx context.Context,
+	path string,
+) (*raw_entity.StakeRuleInfo, error) {
+	// 資金や上限が分からないまま賭け金を出すと危ないので、既定のルールも読めなければエラーにする
+	bytes, err := readRuleFile(s.pathOptimizer, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var stakeRuleInfo *raw_entity.StakeRuleInfo
+	if err := json.Unmarshal(bytes, &stakeRuleInfo); err != nil {
+		return nil, err
+	}
+
+	return stakeRuleInfo, nil
+}
@@ -3,7 +3,6 @@ package infrastructure
 import (
 	"context"
 	"encoding/csv"
-	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -38,12 +37,7 @@ func NewTicketRepository(
 }
 
 func (t *ticketRepository) List(ctx context.Context, path string) ([]string, error) {
-	rootPath, err := t.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := t.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -65,16 +59,35 @@ func (t *ticketRepository) List(ctx context.Context, path string) ([]string, err
 	return fileNames, nil
 }
 
-func (t *ticketRepository) Read(
-	ctx context.Context,
-	path string,
-) ([]*ticket_csv_entity.Ticket, error) {
-	rootPath, err := t.pathOptimizer.GetProjectRoot()
+// ListAccounts csv配下のディレクトリをアカウントとして返す
+func (t *ticketRepository) ListAccounts(ctx context.Context, path string) ([]types.Account, error) {
+	absPath, err := t.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
 
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	entries, err := os.ReadDir(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]types.Account, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		accounts = append(accounts, types.NewAccount(entry.Name()))
+	}
+
+	return accounts, nil
+}
+
+func (t *ticketRepository) Read(
+	ctx context.Context,
+	path string,
+	account types.Account,
+) ([]*ticket_csv_entity.Ticket, error) {
+	absPath, err := t.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +98,12 @@ func (t *ticketRepository) Read(
 	}
 	defer f.Close()
 
+	// umacaの投票データはpatと同じフォーマットで書き出しているのでファイル名で区別する
+	source := types.Ipat
+	if strings.Contains(path, ticketUmacaDataSuffix) {
+		source = types.Umaca
+	}
+
 	var tickets []*ticket_csv_entity.Ticket
 	reader := csv.NewReader(transform.NewReader(f, japanese.ShiftJIS.NewDecoder()))
 	for {
@@ -133,6 +152,8 @@ func (t *ticketRepository) Read(
 				rawTicketResult,
 				rawPayment,
 				rawPayout,
+				source,
+				account,
 			)
 			if err != nil {
 				return nil, err
@@ -150,37 +171,7 @@ func (t *ticketRepository) convertToSubTicketTypeBetNumbers(
 	rawTicketType,
 	rawBetNumber string,
 ) ([]types.BetNumber, error) {
-	ticketType := types.NewTicketType(rawTicketType)
-	switch ticketType {
-	case types.QuinellaWheel:
-		return t.betNumberConverter.QuinellaWheelToQuinellaBetNumbers(ctx, rawBetNumber)
-	case types.QuinellaPlaceWheel:
-		return t.betNumberConverter.QuinellaPlaceWheelToQuinellaBetNumbers(ctx, rawBetNumber)
-	case types.QuinellaPlaceFormation:
-		return t.betNumberConverter.QuinellaPlaceFormationToQuinellaBetNumbers(ctx, rawBetNumber)
-	case types.ExactaWheelOfFirst:
-		return t.betNumberConverter.ExactaWheelOfFirstToExactaBetNumbers(ctx, rawBetNumber)
-	case types.TrioFormation:
-		return t.betNumberConverter.TrioFormationToTrioBetNumbers(ctx, rawBetNumber)
-	case types.TrioWheelOfFirst:
-		return t.betNumberConverter.TrioWheelOfFirstToTrioBetNumbers(ctx, rawBetNumber)
-	case types.TrioWheelOfSecond:
-		return t.betNumberConverter.TrioWheelOfSecondToTrioBetNumbers(ctx, rawBetNumber)
-	case types.TrioBox:
-		return t.betNumberConverter.TrioBoxToTrioBetNumbers(ctx, rawBetNumber)
-	case types.TrifectaFormation:
-		return t.betNumberConverter.TrifectaFormationToTrifectaBetNumbers(ctx, rawBetNumber)
-	case types.TrifectaWheelOfFirst:
-		return t.betNumberConverter.TrifectaWheelOfFirstToTrifectaBetNumbers(ctx, rawBetNumber)
-	case types.TrifectaWheelOfSecond:
-		return t.betNumberConverter.TrifectaWheelOfSecondToTrifectaBetNumbers(ctx, rawBetNumber)
-	case types.TrifectaWheelOfFirstMulti, types.TrifectaWheelOfSecondMulti:
-		return t.betNumberConverter.TrifectaWheelMultiToTrifectaBetNumbers(ctx, rawBetNumber)
-	case types.UnknownTicketType:
-		return nil, fmt.Errorf("unknown ticket type")
-	}
-
-	return []types.BetNumber{types.NewBetNumber(rawBetNumber)}, nil
+	return t.betNumberConverter.ToBetNumbers(ctx, types.NewTicketType(rawTicketType), rawBetNumber)
 }
 
 func (t *ticketRepository) extractPayment(
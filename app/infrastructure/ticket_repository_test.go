@@ -0,0 +1,68 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+)
+
+func TestTicketRepositoryListAccounts(t *testing.T) {
+	tests := []struct {
+		name  string
+		dirs  []string
+		files []string
+		want  []types.Account
+	}{
+		{
+			name: "csv直下のみ",
+			want: []types.Account{},
+		},
+		{
+			name:  "ディレクトリ名をアカウントにする",
+			dirs:  []string{"team-b", "team-a"},
+			files: []string{"20241020_tohyo.csv"},
+			want:  []types.Account{"team-a", "team-b"},
+		},
+		{
+			name: "隠しディレクトリは除く",
+			dirs: []string{".git", "team-a"},
+			want: []types.Account{"team-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataDir := t.TempDir()
+			csvDir := filepath.Join(dataDir, "csv")
+			if err := os.MkdirAll(csvDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for _, dir := range tt.dirs {
+				if err := os.Mkdir(filepath.Join(csvDir, dir), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, file := range tt.files {
+				if err := os.WriteFile(filepath.Join(csvDir, file), nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			repository := &ticketRepository{
+				pathOptimizer: file_gateway.NewPathOptimizer(&file_gateway.PathConfig{DataDir: dataDir}),
+			}
+
+			got, err := repository.ListAccounts(context.Background(), "csv")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListAccounts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
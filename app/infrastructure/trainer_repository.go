@@ -2,24 +2,76 @@ package infrastructure
 
 import (
 	"context"
+	"encoding/json"
+	"os"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/repository"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/gateway"
 )
 
 type trainerRepository struct {
 	netKeibaGateway gateway.NetKeibaGateway
+	pathOptimizer   file_gateway.PathOptimizer
 }
 
 func NewTrainerRepository(
 	netKeibaGateway gateway.NetKeibaGateway,
+	pathOptimizer file_gateway.PathOptimizer,
 ) repository.TrainerRepository {
 	return &trainerRepository{
 		netKeibaGateway: netKeibaGateway,
+		pathOptimizer:   pathOptimizer,
 	}
 }
 
+func (t *trainerRepository) Read(
+	ctx context.Context,
+	path string,
+) (*raw_entity.TrainerInfo, error) {
+	absPath, err := t.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// ファイルが存在しない場合はエラーは返さず処理を継続する
+	bytes, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, nil
+	}
+
+	var trainerInfo *raw_entity.TrainerInfo
+	if err := json.Unmarshal(bytes, &trainerInfo); err != nil {
+		return nil, err
+	}
+
+	return trainerInfo, nil
+}
+
+func (t *trainerRepository) Write(
+	ctx context.Context,
+	path string,
+	data *raw_entity.TrainerInfo,
+) error {
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	filePath, err := t.pathOptimizer.GetAbsPath(path)
+	if err != nil {
+		return err
+	}
+	err = file_gateway.WriteFile(filePath, bytes)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (t *trainerRepository) Fetch(
 	ctx context.Context,
 	url string,
@@ -3,7 +3,6 @@ package infrastructure
 import (
 	"context"
 	"encoding/csv"
-	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -36,11 +35,7 @@ func (u *umacaTicketRepository) GetMaster(
 	ctx context.Context,
 	path string,
 ) ([]*umaca_csv_entity.UmacaMaster, error) {
-	rootPath, err := u.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := u.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -84,12 +79,7 @@ func (u *umacaTicketRepository) GetMaster(
 }
 
 func (u *umacaTicketRepository) List(ctx context.Context, path string) ([]string, error) {
-	rootPath, err := u.pathOptimizer.GetProjectRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	absPath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
+	absPath, err := u.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -116,13 +106,11 @@ func (u *umacaTicketRepository) Write(
 	path string,
 	data [][]string,
 ) error {
-	rootPath, err := u.pathOptimizer.GetProjectRoot()
+	filePath, err := u.pathOptimizer.GetAbsPath(path)
 	if err != nil {
 		return err
 	}
-
-	filePath, err := filepath.Abs(fmt.Sprintf("%s/%s", rootPath, path))
-	if err != nil {
+	if err = os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
 		return err
 	}
 
@@ -6,6 +6,7 @@ import (
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/aggregation_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
 )
 
 type List interface {
@@ -16,6 +17,7 @@ type ListInput struct {
 	Tickets []*ticket_csv_entity.RaceTicket
 	Races   []*data_cache_entity.Race
 	Jockeys []*data_cache_entity.Jockey
+	Account types.Account
 }
 
 type list struct {
@@ -31,7 +33,7 @@ func NewList(
 }
 
 func (l *list) Execute(ctx context.Context, input *ListInput) error {
-	listRows, err := l.listService.Create(ctx, input.Tickets, input.Races, input.Jockeys)
+	listRows, err := l.listService.Create(ctx, input.Tickets, input.Races, input.Jockeys, input.Account)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,58 @@
+package aggregation_usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/aggregation_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+type Report interface {
+	Execute(ctx context.Context, input *ReportInput) (string, error)
+}
+
+type ReportInput struct {
+	Tickets  []*ticket_csv_entity.RaceTicket
+	Races    []*data_cache_entity.Race
+	Jockeys  []*data_cache_entity.Jockey
+	Markers  []*marker_csv_entity.AnalysisMarker
+	Account  types.Account
+	Period   types.ReportPeriod
+	BaseDate time.Time
+	Bankroll int
+}
+
+type report struct {
+	reportService aggregation_service.Report
+}
+
+func NewReport(
+	reportService aggregation_service.Report,
+) Report {
+	return &report{
+		reportService: reportService,
+	}
+}
+
+// Execute レポートを書き出し、書き出したディレクトリを返す
+func (r *report) Execute(ctx context.Context, input *ReportInput) (string, error) {
+	entity, err := r.reportService.Create(ctx, &aggregation_service.ReportInput{
+		Tickets:  input.Tickets,
+		Races:    input.Races,
+		Jockeys:  input.Jockeys,
+		Markers:  input.Markers,
+		Account:  input.Account,
+		Period:   input.Period,
+		BaseDate: input.BaseDate,
+		Bankroll: input.Bankroll,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return r.reportService.Write(ctx, entity)
+}
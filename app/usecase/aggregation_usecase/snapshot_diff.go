@@ -0,0 +1,48 @@
+package aggregation_usecase
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/snapshot_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+type SnapshotDiff interface {
+	Execute(ctx context.Context, input *SnapshotDiffInput) (string, error)
+}
+
+type SnapshotDiffInput struct {
+	Sheet         types.SnapshotSheet
+	From          string
+	To            string
+	RateThreshold float64
+	MinCount      int
+}
+
+type snapshotDiff struct {
+	snapshotService snapshot_service.Snapshot
+}
+
+func NewSnapshotDiff(
+	snapshotService snapshot_service.Snapshot,
+) SnapshotDiff {
+	return &snapshotDiff{
+		snapshotService: snapshotService,
+	}
+}
+
+// Execute 2つのスナップショットで大きく動いた項目を表示用の文字列で返す
+func (s *snapshotDiff) Execute(ctx context.Context, input *SnapshotDiffInput) (string, error) {
+	sheetDiffs, err := s.snapshotService.Diff(ctx, &snapshot_service.DiffInput{
+		Sheet:         input.Sheet,
+		From:          input.From,
+		To:            input.To,
+		RateThreshold: input.RateThreshold,
+		MinCount:      input.MinCount,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.snapshotService.Print(ctx, sheetDiffs), nil
+}
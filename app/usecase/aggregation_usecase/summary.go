@@ -6,6 +6,9 @@ import (
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/aggregation_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/snapshot_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/sirupsen/logrus"
 )
 
 type Summary interface {
@@ -15,26 +18,39 @@ type Summary interface {
 type SummaryInput struct {
 	Tickets []*ticket_csv_entity.RaceTicket
 	Races   []*data_cache_entity.Race
+	Account types.Account
 }
 
 type summary struct {
-	summaryService aggregation_service.Summary
+	summaryService  aggregation_service.Summary
+	snapshotService snapshot_service.Snapshot
+	logger          *logrus.Logger
 }
 
 func NewSummary(
 	summaryService aggregation_service.Summary,
+	snapshotService snapshot_service.Snapshot,
+	logger *logrus.Logger,
 ) Summary {
 	return &summary{
-		summaryService: summaryService,
+		summaryService:  summaryService,
+		snapshotService: snapshotService,
+		logger:          logger,
 	}
 }
 
 func (a *summary) ExecuteV2(ctx context.Context, input *SummaryInput) error {
-	entity := a.summaryService.Create(ctx, input.Tickets, input.Races)
+	entity := a.summaryService.Create(ctx, input.Tickets, input.Races, input.Account)
 	err := a.summaryService.WriteV2(ctx, entity)
 	if err != nil {
 		return err
 	}
 
+	// シートは毎回クリアされるので、書き出した結果を実行ごとに残しておく
+	// スナップショットは比較用の控えなので、保存に失敗しても集計は成功として扱う
+	if err = a.snapshotService.WriteSummary(ctx, entity); err != nil {
+		a.logger.Warnf("write snapshot: %v", err)
+	}
+
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/aggregation_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
 )
 
 type TicketSummary interface {
@@ -13,6 +14,7 @@ type TicketSummary interface {
 
 type TicketSummaryInput struct {
 	Tickets []*ticket_csv_entity.RaceTicket
+	Account types.Account
 }
 
 type ticketSummary struct {
@@ -28,7 +30,7 @@ func NewTicketSummary(
 }
 
 func (m *ticketSummary) Execute(ctx context.Context, input *TicketSummaryInput) error {
-	ticketSummaryMap := m.ticketSummaryService.Create(ctx, input.Tickets)
+	ticketSummaryMap := m.ticketSummaryService.Create(ctx, input.Tickets, input.Account)
 	err := m.ticketSummaryService.Write(ctx, ticketSummaryMap)
 	if err != nil {
 		return err
@@ -5,9 +5,12 @@ import (
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/analysis_service"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/master_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/snapshot_service"
+	"github.com/sirupsen/logrus"
 )
 
 type Analysis interface {
@@ -17,6 +20,13 @@ type Analysis interface {
 	PlaceJockey(ctx context.Context, input *AnalysisInput) error
 	RaceTime(ctx context.Context, input *AnalysisInput) error
 	Beta(ctx context.Context, input *AnalysisInput) error
+	Pedigree(ctx context.Context, input *AnalysisInput) error
+	Trainer(ctx context.Context, input *AnalysisInput) error
+	MarkerTicket(ctx context.Context, input *AnalysisInput) error
+	PlacePaddock(ctx context.Context, input *AnalysisInput) error
+	CommentScore(ctx context.Context, input *AnalysisInput) error
+	MarkerConsensus(ctx context.Context, input *AnalysisInput) error
+	TicketReprice(ctx context.Context, input *AnalysisInput) error
 }
 
 type AnalysisInput struct {
@@ -25,6 +35,8 @@ type AnalysisInput struct {
 	RaceTimes []*data_cache_entity.RaceTime
 	Odds      *AnalysisOddsInput
 	Jockeys   []*data_cache_entity.Jockey
+	Trainers  []*data_cache_entity.Trainer
+	Tickets   []*ticket_csv_entity.RaceTicket
 }
 
 type AnalysisOddsInput struct {
@@ -41,11 +53,21 @@ type analysis struct {
 	placeJockeyService          analysis_service.PlaceJockey
 	betaWinService              analysis_service.BetaWin
 	placeCheckPointService      analysis_service.PlaceCheckPoint
+	placeRuleService            analysis_service.PlaceRule
 	raceTimeService             analysis_service.RaceTime
+	pedigreeService             analysis_service.Pedigree
+	trainerService              analysis_service.Trainer
+	markerTicketService         analysis_service.MarkerTicket
+	placePaddockService         analysis_service.PlacePaddock
+	commentScoreService         analysis_service.CommentScore
+	markerConsensusService      analysis_service.MarkerConsensus
+	ticketRepriceService        analysis_service.TicketReprice
+	snapshotService             snapshot_service.Snapshot
 	horseMasterService          master_service.Horse
 	raceForecastService         master_service.RaceForecast
 	raceForecastEntityConverter converter.RaceForecastEntityConverter
 	horseEntityConverter        converter.HorseEntityConverter
+	logger                      *logrus.Logger
 }
 
 func NewAnalysis(
@@ -55,11 +77,21 @@ func NewAnalysis(
 	placeJockeyService analysis_service.PlaceJockey,
 	betaWinService analysis_service.BetaWin,
 	placeCheckPointService analysis_service.PlaceCheckPoint,
+	placeRuleService analysis_service.PlaceRule,
 	raceTimeService analysis_service.RaceTime,
+	pedigreeService analysis_service.Pedigree,
+	trainerService analysis_service.Trainer,
+	markerTicketService analysis_service.MarkerTicket,
+	placePaddockService analysis_service.PlacePaddock,
+	commentScoreService analysis_service.CommentScore,
+	markerConsensusService analysis_service.MarkerConsensus,
+	ticketRepriceService analysis_service.TicketReprice,
+	snapshotService snapshot_service.Snapshot,
 	horseMasterService master_service.Horse,
 	raceForecastService master_service.RaceForecast,
 	raceForecastEntityConverter converter.RaceForecastEntityConverter,
 	horseEntityConverter converter.HorseEntityConverter,
+	logger *logrus.Logger,
 ) Analysis {
 	return &analysis{
 		placeService:                placeService,
@@ -68,10 +100,20 @@ func NewAnalysis(
 		placeJockeyService:          placeJockeyService,
 		betaWinService:              betaWinService,
 		placeCheckPointService:      placeCheckPointService,
+		placeRuleService:            placeRuleService,
 		horseMasterService:          horseMasterService,
 		raceForecastService:         raceForecastService,
 		raceTimeService:             raceTimeService,
+		pedigreeService:             pedigreeService,
+		trainerService:              trainerService,
+		markerTicketService:         markerTicketService,
+		placePaddockService:         placePaddockService,
+		commentScoreService:         commentScoreService,
+		markerConsensusService:      markerConsensusService,
+		ticketRepriceService:        ticketRepriceService,
+		snapshotService:             snapshotService,
 		raceForecastEntityConverter: raceForecastEntityConverter,
 		horseEntityConverter:        horseEntityConverter,
+		logger:                      logger,
 	}
 }
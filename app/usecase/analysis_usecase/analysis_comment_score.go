@@ -0,0 +1,29 @@
+package analysis_usecase
+
+import (
+	"context"
+)
+
+func (a *analysis) CommentScore(
+	ctx context.Context,
+	input *AnalysisInput,
+) error {
+	lexicon, err := a.commentScoreService.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	raceForecasts, err := a.syncRaceForecasts(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	calculables := a.commentScoreService.Create(ctx, lexicon, input.Markers, input.Races, raceForecasts)
+	analysisCommentScores := a.commentScoreService.Convert(ctx, lexicon, calculables)
+	err = a.commentScoreService.Write(ctx, analysisCommentScores)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
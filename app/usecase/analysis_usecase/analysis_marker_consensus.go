@@ -0,0 +1,28 @@
+package analysis_usecase
+
+import (
+	"context"
+)
+
+func (a *analysis) MarkerConsensus(
+	ctx context.Context,
+	input *AnalysisInput,
+) error {
+	raceForecasts, err := a.syncRaceForecasts(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	calculables, err := a.markerConsensusService.Create(ctx, input.Markers, input.Races, raceForecasts)
+	if err != nil {
+		return err
+	}
+
+	analysisMarkerConsensuses := a.markerConsensusService.Convert(ctx, calculables)
+	err = a.markerConsensusService.Write(ctx, analysisMarkerConsensuses)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
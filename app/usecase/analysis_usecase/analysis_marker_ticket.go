@@ -0,0 +1,20 @@
+package analysis_usecase
+
+import (
+	"context"
+)
+
+func (a *analysis) MarkerTicket(ctx context.Context, input *AnalysisInput) error {
+	markerTickets, err := a.markerTicketService.Create(ctx, input.Tickets, input.Markers)
+	if err != nil {
+		return err
+	}
+
+	analysisMarkerTickets := a.markerTicketService.Convert(ctx, markerTickets)
+	err = a.markerTicketService.Write(ctx, analysisMarkerTickets)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package analysis_usecase
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func (a *analysis) Pedigree(ctx context.Context, input *AnalysisInput) error {
+	horses, err := a.horseMasterService.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	cacheHorseMap := converter.ConvertToMap(horses, func(horse *data_cache_entity.Horse) types.HorseId {
+		return horse.HorseId()
+	})
+
+	markerRaceIdMap := map[types.RaceId]map[types.HorseNumber]struct{}{}
+	for _, marker := range input.Markers {
+		horseNumbers := map[types.HorseNumber]struct{}{}
+		for _, horseNumber := range marker.MarkerMap() {
+			horseNumbers[horseNumber] = struct{}{}
+		}
+		markerRaceIdMap[marker.RaceId()] = horseNumbers
+	}
+
+	// 印のついた馬ごとに、集計対象となる最新のレース日を求める
+	horseRaceDateMap := map[types.HorseId]types.RaceDate{}
+	for _, race := range input.Races {
+		horseNumbers, ok := markerRaceIdMap[race.RaceId()]
+		if !ok || race.CourseCategory() == types.Jump {
+			continue
+		}
+		for _, raceResult := range race.RaceResults() {
+			if _, ok := horseNumbers[raceResult.HorseNumber()]; !ok {
+				continue
+			}
+			if race.RaceDate() > horseRaceDateMap[raceResult.HorseId()] {
+				horseRaceDateMap[raceResult.HorseId()] = race.RaceDate()
+			}
+		}
+	}
+
+	// 未取得の馬、出走時点の戦績がない馬、種牡馬名を保存する前のキャッシュの馬を取得し直す
+	// 種牡馬名を保存するようになってから取得した馬は、名前が取れなかった場合でも取得し直さない
+	fetchHorseMap := map[types.HorseId]*netkeiba_entity.Horse{}
+	for _, horseId := range service.SortedHorseIdKeys(horseRaceDateMap) {
+		raceDate := horseRaceDateMap[horseId]
+		cachedHorse, ok := cacheHorseMap[horseId]
+		if ok && raceDate <= cachedHorse.LatestRaceDate() && (cachedHorse.HorseBlood().NameFetched() || cachedHorse.HorseBlood().SireName() != "") {
+			continue
+		}
+		fetchHorse, err := a.pedigreeService.FetchHorse(ctx, horseId)
+		if err != nil {
+			return err
+		}
+		fetchHorseMap[horseId] = fetchHorse
+	}
+
+	if len(fetchHorseMap) > 0 {
+		cacheHorses := make([]*data_cache_entity.Horse, 0, len(fetchHorseMap))
+		for horseId, fetchHorse := range fetchHorseMap {
+			cacheHorse, err := a.horseEntityConverter.NetKeibaToDataCache(fetchHorse, horseRaceDateMap[horseId])
+			if err != nil {
+				return err
+			}
+			cacheHorses = append(cacheHorses, cacheHorse)
+		}
+		if err = a.horseMasterService.CreateOrUpdate(ctx, cacheHorses); err != nil {
+			return err
+		}
+
+		horses, err = a.horseMasterService.Get(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	horseMap := converter.ConvertToMap(horses, func(horse *data_cache_entity.Horse) types.HorseId {
+		return horse.HorseId()
+	})
+
+	calculables, err := a.pedigreeService.Create(ctx, input.Markers, input.Races, horseMap)
+	if err != nil {
+		return err
+	}
+
+	analysisPedigrees := a.pedigreeService.Convert(ctx, calculables)
+	err = a.pedigreeService.Write(ctx, analysisPedigrees)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -15,5 +15,10 @@ func (a *analysis) Place(ctx context.Context, input *AnalysisInput) error {
 		return err
 	}
 
+	// スナップショットは比較用の控えなので、保存に失敗してもシートへの書き出しは成功として扱う
+	if err = a.snapshotService.WriteAnalysisPlace(ctx, firstPlaceMap, secondPlaceMap, thirdPlaceMap, filters); err != nil {
+		a.logger.Warnf("write snapshot: %v", err)
+	}
+
 	return nil
 }
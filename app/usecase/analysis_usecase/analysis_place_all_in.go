@@ -12,6 +12,10 @@ func (a *analysis) PlaceAllIn(ctx context.Context, input *AnalysisInput) error {
 	if err != nil {
 		return err
 	}
+	// スナップショットは比較用の控えなので、保存に失敗してもシートへの書き出しは成功として扱う
+	if err = a.snapshotService.WriteAnalysisPlaceAllIn(ctx, placeAllInMap1, placeAllInMap2, attributeFilters, markerCombinationFilters); err != nil {
+		a.logger.Warnf("write snapshot: %v", err)
+	}
 
 	return nil
 }
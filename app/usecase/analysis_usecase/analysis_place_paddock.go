@@ -0,0 +1,93 @@
+package analysis_usecase
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func (a *analysis) PlacePaddock(
+	ctx context.Context,
+	input *AnalysisInput,
+) error {
+	raceForecasts, err := a.syncRaceForecasts(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	calculables := a.placePaddockService.Create(ctx, input.Markers, input.Races, raceForecasts)
+	analysisPlacePaddocks := a.placePaddockService.Convert(ctx, calculables)
+	err = a.placePaddockService.Write(ctx, analysisPlacePaddocks)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// syncRaceForecasts 印を付けた結果確定済みのJRAレースで、予想キャッシュが無い、または記者メモとパドック情報を持っていないものを取り直して返す
+func (a *analysis) syncRaceForecasts(
+	ctx context.Context,
+	input *AnalysisInput,
+) ([]*data_cache_entity.RaceForecast, error) {
+	raceForecasts, err := a.raceForecastService.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	markerMap := converter.ConvertToMap(input.Markers, func(marker *marker_csv_entity.AnalysisMarker) types.RaceId {
+		return marker.RaceId()
+	})
+	cacheRaceForecastMap := converter.ConvertToMap(raceForecasts, func(forecast *data_cache_entity.RaceForecast) types.RaceId {
+		return forecast.RaceId()
+	})
+
+	cacheRaceForecasts := make([]*data_cache_entity.RaceForecast, 0)
+	for _, race := range input.Races {
+		if race.Organizer() != types.JRA || len(race.RaceResults()) == 0 {
+			continue
+		}
+		if _, ok := markerMap[race.RaceId()]; !ok {
+			continue
+		}
+		if cachedRaceForecast, ok := cacheRaceForecastMap[race.RaceId()]; ok && cachedRaceForecast.PaddockFetched() {
+			continue
+		}
+
+		fetchRaceForecast, err := a.raceForecastService.Fetch(ctx, race.RaceId(), race.RaceDate())
+		if err != nil {
+			return nil, err
+		}
+
+		horseNumberMap := converter.ConvertToMap(race.RaceResults(), func(raceResult *data_cache_entity.RaceResult) types.HorseNumber {
+			return raceResult.HorseNumber()
+		})
+		forecasts := make([]*data_cache_entity.Forecast, 0, len(fetchRaceForecast.Forecasts()))
+		for _, forecast := range fetchRaceForecast.Forecasts() {
+			if _, ok := horseNumberMap[forecast.HorseNumber()]; ok {
+				forecasts = append(forecasts, forecast)
+			}
+		}
+		cacheRaceForecasts = append(cacheRaceForecasts, data_cache_entity.NewRaceForecast(
+			race.RaceId().String(),
+			race.RaceDate().Value(),
+			forecasts,
+			fetchRaceForecast.PaddockFetched(),
+		))
+	}
+
+	if len(cacheRaceForecasts) > 0 {
+		if err = a.raceForecastService.CreateOrUpdate(ctx, cacheRaceForecasts); err != nil {
+			return nil, err
+		}
+		raceForecasts, err = a.raceForecastService.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return raceForecasts, nil
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/netkeiba_entity"
-	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/tospo_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
 	"github.com/shopspring/decimal"
@@ -122,28 +121,10 @@ func (a *analysis) PlaceUnHit(
 		return odds.RaceId()
 	})
 
-	quinellaCombinationOddsMap := converter.ConvertToSliceMap(quinellaOddsWheelCombinations, func(odds *analysis_entity.Odds) types.RaceId {
-		return odds.RaceId()
-	})
-
-	quinellaConsecutiveNumberMap := make(map[types.RaceId]int)
-	quinellaCombinationTotalOddsMap := make(map[types.RaceId]decimal.Decimal)
-	for raceId, oddsList := range quinellaCombinationOddsMap {
-		quinellaConsecutiveNumberMap[raceId] = 0
-		quinellaCombinationTotalOddsMap[raceId] = decimal.Zero
-		for i, odds := range oddsList {
-			quinellaCombinationTotalOddsMap[raceId] = quinellaCombinationTotalOddsMap[raceId].Add(odds.Odds())
-			if i+1 == odds.PopularNumber() {
-				quinellaConsecutiveNumberMap[raceId]++
-			} else {
-				continue
-			}
-		}
-	}
+	quinellaConsecutiveNumberMap, quinellaCombinationTotalOddsMap := a.placeUnHitService.GetQuinellaWheelSummaries(ctx, quinellaOddsWheelCombinations)
 
 	fetchHorseMap := map[types.RaceDate][]*netkeiba_entity.Horse{}
-	fetchRaceForecastMap := map[types.RaceId][]*tospo_entity.Forecast{}
-	fetchTrainingCommentMap := map[types.RaceId][]*tospo_entity.TrainingComment{}
+	fetchRaceForecastMap := map[types.RaceId]*data_cache_entity.RaceForecast{}
 	unHitRaceRateMap := map[types.RaceId]map[types.HorseId][]float64{}
 
 	for _, race := range unHitRaces {
@@ -164,17 +145,11 @@ func (a *analysis) PlaceUnHit(
 		}
 
 		if _, ok := cacheRaceForecastMap[race.RaceId()]; !ok {
-			fetchRaceForecasts, err := a.placeUnHitService.FetchRaceForecasts(ctx, race.RaceId())
-			if err != nil {
-				return err
-			}
-			fetchRaceForecastMap[race.RaceId()] = fetchRaceForecasts
-
-			fetchTrainingComments, err := a.placeUnHitService.FetchTrainingComments(ctx, race.RaceId())
+			fetchRaceForecast, err := a.raceForecastService.Fetch(ctx, race.RaceId(), race.RaceDate())
 			if err != nil {
 				return err
 			}
-			fetchTrainingCommentMap[race.RaceId()] = fetchTrainingComments
+			fetchRaceForecastMap[race.RaceId()] = fetchRaceForecast
 		}
 	}
 
@@ -194,14 +169,10 @@ func (a *analysis) PlaceUnHit(
 		}
 	}
 
-	if len(fetchRaceForecastMap) > 0 && len(fetchTrainingCommentMap) > 0 {
+	if len(fetchRaceForecastMap) > 0 {
 		cacheRaceForecasts := make([]*data_cache_entity.RaceForecast, 0, len(fetchRaceForecastMap))
 		for _, race := range unHitRaces {
-			fetchRaceForecasts, ok := fetchRaceForecastMap[race.RaceId()]
-			if !ok {
-				continue
-			}
-			fetchTrainingComments, ok := fetchTrainingCommentMap[race.RaceId()]
+			fetchRaceForecast, ok := fetchRaceForecastMap[race.RaceId()]
 			if !ok {
 				continue
 			}
@@ -210,16 +181,17 @@ func (a *analysis) PlaceUnHit(
 				return raceResult.HorseNumber()
 			})
 
-			forecasts := make([]*data_cache_entity.Forecast, 0, len(fetchRaceForecasts))
-			for i, forecast := range fetchRaceForecasts {
+			forecasts := make([]*data_cache_entity.Forecast, 0, len(fetchRaceForecast.Forecasts()))
+			for _, forecast := range fetchRaceForecast.Forecasts() {
 				if _, ok = horseNumberMap[forecast.HorseNumber()]; ok {
-					forecasts = append(forecasts, a.raceForecastEntityConverter.TospoToDataCache(forecast, fetchTrainingComments[i]))
+					forecasts = append(forecasts, forecast)
 				}
 			}
 			cacheRaceForecasts = append(cacheRaceForecasts, data_cache_entity.NewRaceForecast(
 				race.RaceId().String(),
 				race.RaceDate().Value(),
 				forecasts,
+				fetchRaceForecast.PaddockFetched(),
 			))
 		}
 
@@ -249,6 +221,11 @@ func (a *analysis) PlaceUnHit(
 		return horse.HorseId()
 	})
 
+	placeRuleSet, err := a.placeRuleService.Get(ctx)
+	if err != nil {
+		return err
+	}
+
 	analysisPlaceUnhits, err := a.placeUnHitService.CreateUnhitRaces(
 		ctx,
 		unHitRaces,
@@ -261,16 +238,12 @@ func (a *analysis) PlaceUnHit(
 		quinellaConsecutiveNumberMap,
 		quinellaCombinationTotalOddsMap,
 		trioFavoriteContains,
+		placeRuleSet.Danger(),
 	)
 	if err != nil {
 		return err
 	}
 
-	for _, analysisPlaceUnhit := range analysisPlaceUnhits {
-		race := unHitRaceMap[analysisPlaceUnhit.RaceId()]
-		a.placeUnHitService.CreateCheckPoints(ctx, race)
-	}
-
 	err = a.placeUnHitService.Write(ctx, analysisPlaceUnhits)
 	if err != nil {
 		return err
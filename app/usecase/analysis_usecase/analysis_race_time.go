@@ -14,6 +14,10 @@ func (a *analysis) RaceTime(ctx context.Context, input *AnalysisInput) error {
 	if err != nil {
 		return err
 	}
+	// スナップショットは比較用の控えなので、保存に失敗してもシートへの書き出しは成功として扱う
+	if err = a.snapshotService.WriteAnalysisRaceTime(ctx, analysisRaceTimeMap, attributeFilters); err != nil {
+		a.logger.Warnf("write snapshot: %v", err)
+	}
 
 	return nil
 }
@@ -0,0 +1,20 @@
+package analysis_usecase
+
+import (
+	"context"
+)
+
+func (a *analysis) TicketReprice(ctx context.Context, input *AnalysisInput) error {
+	ticketReprices, err := a.ticketRepriceService.Create(ctx, input.Tickets, input.Races)
+	if err != nil {
+		return err
+	}
+
+	analysisTicketReprices := a.ticketRepriceService.Convert(ctx, ticketReprices)
+	err = a.ticketRepriceService.Write(ctx, analysisTicketReprices)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package analysis_usecase
+
+import (
+	"context"
+)
+
+func (a *analysis) Trainer(ctx context.Context, input *AnalysisInput) error {
+	calculables, err := a.trainerService.Create(ctx, input.Races)
+	if err != nil {
+		return err
+	}
+
+	analysisTrainers := a.trainerService.Convert(ctx, calculables, input.Trainers, input.Jockeys)
+	err = a.trainerService.Write(ctx, analysisTrainers)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
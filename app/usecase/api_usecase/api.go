@@ -0,0 +1,186 @@
+package api_usecase
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/api_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/aggregation_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/analysis_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/converter"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/filter_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+)
+
+type Api interface {
+	Races(ctx context.Context, input *ApiInput) []*raw_entity.Race
+	Odds(ctx context.Context, input *ApiInput) []*raw_entity.RaceOdds
+	Place(ctx context.Context, input *ApiInput) ([]*api_entity.Place, error)
+	PlaceAllIn(ctx context.Context, input *ApiInput) ([]*api_entity.PlaceAllIn, error)
+	RaceTime(ctx context.Context, input *ApiInput) ([]*api_entity.RaceTime, error)
+	Summary(ctx context.Context, input *ApiInput) *api_entity.Summary
+	Attributes(ctx context.Context) []*api_entity.Attribute
+}
+
+type ApiInput struct {
+	Markers   []*marker_csv_entity.AnalysisMarker
+	Races     []*data_cache_entity.Race
+	RaceTimes []*data_cache_entity.RaceTime
+	Odds      *ApiOddsInput
+	Tickets   []*ticket_csv_entity.RaceTicket
+	Filter    *ApiFilter
+}
+
+type ApiOddsInput struct {
+	Win      []*data_cache_entity.Odds
+	Place    []*data_cache_entity.Odds
+	Trio     []*data_cache_entity.Odds
+	Quinella []*data_cache_entity.Odds
+}
+
+// ApiFilter ゼロ値の項目は絞り込まない
+type ApiFilter struct {
+	StartDate   types.RaceDate
+	EndDate     types.RaceDate
+	RaceCourse  types.RaceCourse
+	AttributeId filter.AttributeId
+	Account     types.Account
+}
+
+type api struct {
+	placeService        analysis_service.Place
+	placeAllInService   analysis_service.PlaceAllIn
+	raceTimeService     analysis_service.RaceTime
+	summaryService      aggregation_service.Summary
+	filterService       filter_service.AnalysisFilter
+	raceEntityConverter converter.RaceEntityConverter
+	oddsEntityConverter converter.OddsEntityConverter
+}
+
+func NewApi(
+	placeService analysis_service.Place,
+	placeAllInService analysis_service.PlaceAllIn,
+	raceTimeService analysis_service.RaceTime,
+	summaryService aggregation_service.Summary,
+	filterService filter_service.AnalysisFilter,
+	raceEntityConverter converter.RaceEntityConverter,
+	oddsEntityConverter converter.OddsEntityConverter,
+) Api {
+	return &api{
+		placeService:        placeService,
+		placeAllInService:   placeAllInService,
+		raceTimeService:     raceTimeService,
+		summaryService:      summaryService,
+		filterService:       filterService,
+		raceEntityConverter: raceEntityConverter,
+		oddsEntityConverter: oddsEntityConverter,
+	}
+}
+
+func (a *api) Attributes(ctx context.Context) []*api_entity.Attribute {
+	attributeIds := filter.OriginAttributeIds()
+	attributes := make([]*api_entity.Attribute, 0, len(attributeIds))
+	for _, attributeId := range attributeIds {
+		attributes = append(attributes, newAttribute(attributeId))
+	}
+
+	return attributes
+}
+
+// filterRaces 開催日、開催場所で絞り込む。withAttributeの場合はレース条件の属性でも絞り込む
+func (a *api) filterRaces(
+	ctx context.Context,
+	races []*data_cache_entity.Race,
+	apiFilter *ApiFilter,
+	withAttribute bool,
+) []*data_cache_entity.Race {
+	filteredRaces := make([]*data_cache_entity.Race, 0, len(races))
+	for _, race := range races {
+		if !apiFilter.matchRace(race.RaceDate(), race.RaceCourseId()) {
+			continue
+		}
+		if withAttribute && !a.matchRaceAttribute(ctx, race, apiFilter) {
+			continue
+		}
+		filteredRaces = append(filteredRaces, race)
+	}
+
+	return filteredRaces
+}
+
+func (a *api) matchRaceAttribute(
+	ctx context.Context,
+	race *data_cache_entity.Race,
+	apiFilter *ApiFilter,
+) bool {
+	if apiFilter.AttributeId == 0 || apiFilter.AttributeId == filter.All {
+		return true
+	}
+
+	var raceAttributeId filter.AttributeId
+	for _, attributeId := range a.filterService.CreateRaceTimeFilters(ctx, race) {
+		raceAttributeId |= attributeId
+	}
+
+	return raceAttributeId&apiFilter.AttributeId == apiFilter.AttributeId
+}
+
+func (f *ApiFilter) matchRace(raceDate types.RaceDate, raceCourse types.RaceCourse) bool {
+	if f.StartDate != 0 && raceDate < f.StartDate {
+		return false
+	}
+	if f.EndDate != 0 && raceDate > f.EndDate {
+		return false
+	}
+	if f.RaceCourse != "" && raceCourse != f.RaceCourse {
+		return false
+	}
+
+	return true
+}
+
+// matchAttribute 集計結果の属性が指定された属性をすべて含むか
+func (f *ApiFilter) matchAttribute(attributeId filter.AttributeId) bool {
+	if f.AttributeId == 0 {
+		return true
+	}
+	if attributeId == filter.All {
+		return f.AttributeId == filter.All
+	}
+
+	return attributeId&f.AttributeId == f.AttributeId
+}
+
+func newAttribute(attributeId filter.AttributeId) *api_entity.Attribute {
+	return &api_entity.Attribute{
+		Id:   attributeId.Value(),
+		Hex:  "0x" + strings.ToUpper(strconv.FormatUint(attributeId.Value(), 16)),
+		Name: attributeName(attributeId),
+	}
+}
+
+func attributeName(attributeId filter.AttributeId) string {
+	originAttributeIds := attributeId.OriginFilters()
+	names := make([]string, 0, len(originAttributeIds))
+	for _, originAttributeId := range originAttributeIds {
+		names = append(names, originAttributeId.String())
+	}
+
+	return strings.Join(names, ",")
+}
+
+// rate 0件で割ったNaNはJSONにできないのでnullにする
+func rate(value float64) *float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	rounded := math.Round(value*100) / 100
+	return &rounded
+}
@@ -0,0 +1,188 @@
+package api_usecase
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/api_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+)
+
+var placeAllInWinOddsLabels = []string{
+	"1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "1.8", "1.9",
+	"2.0", "2.1", "2.2", "2.3", "2.4", "2.5", "2.6", "2.7", "2.8", "2.9",
+	"3.0", "3.1", "3.2", "3.3", "3.4", "3.5", "3.6", "3.7", "3.8", "3.9",
+}
+
+func (a *api) Place(ctx context.Context, input *ApiInput) ([]*api_entity.Place, error) {
+	races := a.filterRaces(ctx, input.Races, input.Filter, false)
+	calculables, err := a.placeService.Create(ctx, input.Markers, races)
+	if err != nil {
+		return nil, err
+	}
+	firstPlaceMap, secondPlaceMap, thirdPlaceMap, attributeFilters := a.placeService.Convert(ctx, calculables)
+
+	markers := []types.Marker{
+		types.Favorite, types.Rival, types.BrackTriangle, types.WhiteTriangle, types.Star, types.Check,
+	}
+	placeMaps := []map[types.Marker]map[filter.AttributeId]*spreadsheet_entity.AnalysisPlace{
+		firstPlaceMap, secondPlaceMap, thirdPlaceMap,
+	}
+	oddsRanges := []types.OddsRangeType{
+		types.WinOddsRange1, types.WinOddsRange2, types.WinOddsRange3,
+		types.WinOddsRange4, types.WinOddsRange5, types.WinOddsRange6,
+		types.WinOddsRange7, types.WinOddsRange8, types.WinOddsRange9,
+	}
+
+	var places []*api_entity.Place
+	for _, marker := range markers {
+		for idx, placeMap := range placeMaps {
+			for _, attributeFilter := range attributeFilters {
+				if !input.Filter.matchAttribute(attributeFilter) {
+					continue
+				}
+				analysisPlace, ok := placeMap[marker][attributeFilter]
+				if !ok {
+					continue
+				}
+				rateData := analysisPlace.RateData()
+				oddsRangeRates := []float64{
+					rateData.OddsRange1Rate(), rateData.OddsRange2Rate(), rateData.OddsRange3Rate(),
+					rateData.OddsRange4Rate(), rateData.OddsRange5Rate(), rateData.OddsRange6Rate(),
+					rateData.OddsRange7Rate(), rateData.OddsRange8Rate(), rateData.OddsRange9Rate(),
+				}
+				placeOddsRanges := make([]*api_entity.PlaceOddsRange, 0, len(oddsRanges))
+				for i, oddsRange := range oddsRanges {
+					placeOddsRanges = append(placeOddsRanges, &api_entity.PlaceOddsRange{
+						WinOddsRange: oddsRange.String(),
+						HitRate:      rate(oddsRangeRates[i]),
+					})
+				}
+				places = append(places, &api_entity.Place{
+					Marker:      marker.String(),
+					OrderNo:     idx + 1,
+					AttributeId: attributeFilter.Value(),
+					Attribute:   attributeName(attributeFilter),
+					RaceCount:   rateData.RaceCount(),
+					HitRate:     rate(rateData.HitRate()),
+					OddsRanges:  placeOddsRanges,
+				})
+			}
+		}
+	}
+
+	return places, nil
+}
+
+func (a *api) PlaceAllIn(ctx context.Context, input *ApiInput) ([]*api_entity.PlaceAllIn, error) {
+	races := a.filterRaces(ctx, input.Races, input.Filter, false)
+	calculables, err := a.placeAllInService.Create(ctx, input.Markers, races, input.Odds.Win, input.Odds.Place)
+	if err != nil {
+		return nil, err
+	}
+	placeAllInMap1, placeAllInMap2, attributeFilters, markerCombinationFilters := a.placeAllInService.Convert(ctx, calculables)
+
+	var placeAllIns []*api_entity.PlaceAllIn
+	for _, attributeFilter := range attributeFilters {
+		if !input.Filter.matchAttribute(attributeFilter) {
+			continue
+		}
+		placeAllIn, ok := placeAllInMap1[attributeFilter]
+		if !ok {
+			continue
+		}
+		placeAllIns = append(placeAllIns, &api_entity.PlaceAllIn{
+			AttributeId: attributeFilter.Value(),
+			Attribute:   attributeName(attributeFilter),
+			WinOdds:     a.createPlaceAllInWinOdds(placeAllIn),
+		})
+	}
+
+	// 印の組み合わせ別は属性を持たないので、属性を指定していない場合だけ返す
+	if input.Filter.AttributeId == 0 {
+		for _, markerCombinationFilter := range markerCombinationFilters {
+			placeAllIn, ok := placeAllInMap2[markerCombinationFilter]
+			if !ok {
+				continue
+			}
+			placeAllIns = append(placeAllIns, &api_entity.PlaceAllIn{
+				MarkerCombination: markerCombinationFilter.String(),
+				WinOdds:           a.createPlaceAllInWinOdds(placeAllIn),
+			})
+		}
+	}
+
+	return placeAllIns, nil
+}
+
+func (a *api) createPlaceAllInWinOdds(placeAllIn *spreadsheet_entity.AnalysisPlaceAllIn) []*api_entity.PlaceAllInWinOdds {
+	rateData := placeAllIn.RateData()
+	hitDataList := []*spreadsheet_entity.PlaceAllInHitData{
+		rateData.WinOdds11HitData(), rateData.WinOdds12HitData(), rateData.WinOdds13HitData(),
+		rateData.WinOdds14HitData(), rateData.WinOdds15HitData(), rateData.WinOdds16HitData(),
+		rateData.WinOdds17HitData(), rateData.WinOdds18HitData(), rateData.WinOdds19HitData(),
+		rateData.WinOdds20HitData(), rateData.WinOdds21HitData(), rateData.WinOdds22HitData(),
+		rateData.WinOdds23HitData(), rateData.WinOdds24HitData(), rateData.WinOdds25HitData(),
+		rateData.WinOdds26HitData(), rateData.WinOdds27HitData(), rateData.WinOdds28HitData(),
+		rateData.WinOdds29HitData(), rateData.WinOdds30HitData(), rateData.WinOdds31HitData(),
+		rateData.WinOdds32HitData(), rateData.WinOdds33HitData(), rateData.WinOdds34HitData(),
+		rateData.WinOdds35HitData(), rateData.WinOdds36HitData(), rateData.WinOdds37HitData(),
+		rateData.WinOdds38HitData(), rateData.WinOdds39HitData(),
+	}
+
+	winOddsList := make([]*api_entity.PlaceAllInWinOdds, 0, len(hitDataList))
+	for i, hitData := range hitDataList {
+		winOddsList = append(winOddsList, &api_entity.PlaceAllInWinOdds{
+			WinOdds:    placeAllInWinOddsLabels[i],
+			HitCount:   hitData.HitCount(),
+			UnHitCount: hitData.UnHitCount(),
+			HitRate:    rate(hitData.HitRate()),
+		})
+	}
+
+	return winOddsList
+}
+
+func (a *api) RaceTime(ctx context.Context, input *ApiInput) ([]*api_entity.RaceTime, error) {
+	races := a.filterRaces(ctx, input.Races, input.Filter, false)
+	calculables, err := a.raceTimeService.Create(ctx, races, input.RaceTimes)
+	if err != nil {
+		return nil, err
+	}
+	analysisRaceTimeMap, attributeFilters, conditionFilters := a.raceTimeService.Convert(ctx, calculables)
+
+	var raceTimes []*api_entity.RaceTime
+	for _, attributeFilter := range append(attributeFilters, conditionFilters...) {
+		if !input.Filter.matchAttribute(attributeFilter) {
+			continue
+		}
+		analysisRaceTime, ok := analysisRaceTimeMap[attributeFilter]
+		if !ok {
+			continue
+		}
+		raceTimes = append(raceTimes, &api_entity.RaceTime{
+			AttributeId:       attributeFilter.Value(),
+			Attribute:         attributeName(attributeFilter),
+			RaceCount:         analysisRaceTime.RaceCount(),
+			AverageRaceTime:   analysisRaceTime.AverageRaceTime(),
+			MedianRaceTime:    analysisRaceTime.MedianRaceTime(),
+			AverageFirst3f:    analysisRaceTime.AverageFirst3f(),
+			MedianFirst3f:     analysisRaceTime.MedianFirst3f(),
+			AverageFirst4f:    analysisRaceTime.AverageFirst4f(),
+			MedianFirst4f:     analysisRaceTime.MedianFirst4f(),
+			AverageLast3f:     analysisRaceTime.AverageLast3f(),
+			MedianLast3f:      analysisRaceTime.MedianLast3f(),
+			AverageLast4f:     analysisRaceTime.AverageLast4f(),
+			MedianLast4f:      analysisRaceTime.MedianLast4f(),
+			AverageRap5f:      analysisRaceTime.AverageRap5f(),
+			MedianRap5f:       analysisRaceTime.MedianRap5f(),
+			AverageTrackIndex: analysisRaceTime.AverageTrackIndex(),
+			MaxTrackIndex:     analysisRaceTime.MaxTrackIndex(),
+			MinTrackIndex:     analysisRaceTime.MinTrackIndex(),
+			AverageTimeIndex:  analysisRaceTime.AverageTimeIndex(),
+		})
+	}
+
+	return raceTimes, nil
+}
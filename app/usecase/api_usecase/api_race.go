@@ -0,0 +1,53 @@
+package api_usecase
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func (a *api) Races(ctx context.Context, input *ApiInput) []*raw_entity.Race {
+	races := a.filterRaces(ctx, input.Races, input.Filter, true)
+	rawRaces := make([]*raw_entity.Race, 0, len(races))
+	for _, race := range races {
+		rawRaces = append(rawRaces, a.raceEntityConverter.DataCacheToRaw(race))
+	}
+
+	return rawRaces
+}
+
+func (a *api) Odds(ctx context.Context, input *ApiInput) []*raw_entity.RaceOdds {
+	races := a.filterRaces(ctx, input.Races, input.Filter, true)
+	raceOddsMap := make(map[types.RaceId]*raw_entity.RaceOdds, len(races))
+	raceIds := make([]types.RaceId, 0, len(races))
+	for _, race := range races {
+		raceOddsMap[race.RaceId()] = &raw_entity.RaceOdds{
+			RaceId:   race.RaceId().String(),
+			RaceDate: race.RaceDate().Value(),
+			Odds:     []*raw_entity.Odds{},
+		}
+		raceIds = append(raceIds, race.RaceId())
+	}
+
+	for _, oddsList := range [][]*data_cache_entity.Odds{input.Odds.Win, input.Odds.Place, input.Odds.Quinella, input.Odds.Trio} {
+		for _, odds := range oddsList {
+			raceOdds, ok := raceOddsMap[odds.RaceId()]
+			if !ok {
+				continue
+			}
+			raceOdds.Odds = append(raceOdds.Odds, a.oddsEntityConverter.DataCacheToRaw(odds))
+		}
+	}
+
+	// オッズのないレースは返さない
+	raceOddsList := make([]*raw_entity.RaceOdds, 0, len(raceIds))
+	for _, raceId := range raceIds {
+		if raceOdds := raceOddsMap[raceId]; len(raceOdds.Odds) > 0 {
+			raceOddsList = append(raceOddsList, raceOdds)
+		}
+	}
+
+	return raceOddsList
+}
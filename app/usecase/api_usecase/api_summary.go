@@ -0,0 +1,80 @@
+package api_usecase
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/api_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+func (a *api) Summary(ctx context.Context, input *ApiInput) *api_entity.Summary {
+	races := a.filterRaces(ctx, input.Races, input.Filter, true)
+	raceIdMap := make(map[types.RaceId]bool, len(races))
+	for _, race := range races {
+		raceIdMap[race.RaceId()] = true
+	}
+
+	tickets := make([]*ticket_csv_entity.RaceTicket, 0, len(input.Tickets))
+	for _, ticket := range input.Tickets {
+		if !input.Filter.matchRace(ticket.Ticket().RaceDate(), ticket.Ticket().RaceCourse()) {
+			continue
+		}
+		// 属性はレース条件から判定するので、レース情報のない馬券は属性指定時に除外する
+		if input.Filter.AttributeId != 0 && !raceIdMap[ticket.RaceId()] {
+			continue
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	summary := a.summaryService.Create(ctx, tickets, races, input.Filter.Account)
+
+	return &api_entity.Summary{
+		Term: map[string]*api_entity.TicketResult{
+			"all":   newTicketResult(summary.AllTermResult()),
+			"year":  newTicketResult(summary.YearTermResult()),
+			"month": newTicketResult(summary.MonthTermResult()),
+			"week":  newTicketResult(summary.WeekTermResult()),
+		},
+		TicketType:       newTicketResultMap(summary.TicketResultMap(), types.TicketType.Name),
+		GradeClass:       newTicketResultMap(summary.GradeClassResultMap(), types.GradeClass.String),
+		CourseCategory:   newTicketResultMap(summary.CourseCategoryResultMap(), types.CourseCategory.String),
+		DistanceCategory: newTicketResultMap(summary.DistanceCategoryResultMap(), types.DistanceCategory.String),
+		RaceCourse:       newTicketResultMap(summary.RaceCourseResultMap(), types.RaceCourse.Name),
+		TicketSource:     newTicketResultMap(summary.TicketSourceResultMap(), types.TicketSource.Name),
+		Account:          newTicketResultMap(summary.AccountResultMap(), types.Account.Value),
+	}
+}
+
+func newTicketResultMap[T comparable](
+	resultMap map[T]*spreadsheet_entity.TicketResult,
+	name func(T) string,
+) map[string]*api_entity.TicketResult {
+	ticketResultMap := make(map[string]*api_entity.TicketResult, len(resultMap))
+	for key, result := range resultMap {
+		ticketResultMap[name(key)] = newTicketResult(result)
+	}
+
+	return ticketResultMap
+}
+
+func newTicketResult(result *spreadsheet_entity.TicketResult) *api_entity.TicketResult {
+	if result == nil {
+		return nil
+	}
+
+	return &api_entity.TicketResult{
+		RaceCount:     result.RaceCount(),
+		BetCount:      result.BetCount(),
+		HitCount:      result.HitCount(),
+		HitRate:       result.HitRate(),
+		Payment:       result.Payment(),
+		Payout:        result.Payout(),
+		Profit:        result.Profit(),
+		AveragePayout: result.AveragePayout(),
+		MaxPayout:     result.MaxPayout(),
+		MinPayout:     result.MinPayout(),
+		PayoutRate:    result.PayoutRate(),
+	}
+}
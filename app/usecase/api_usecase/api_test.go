@@ -0,0 +1,158 @@
+package api_usecase
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+)
+
+func TestApiFilterMatchRace(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     *ApiFilter
+		raceDate   types.RaceDate
+		raceCourse types.RaceCourse
+		want       bool
+	}{
+		{
+			name:       "絞り込みなし",
+			filter:     &ApiFilter{},
+			raceDate:   20241020,
+			raceCourse: types.Tokyo,
+			want:       true,
+		},
+		{
+			name:       "期間の開始日と終了日を含む",
+			filter:     &ApiFilter{StartDate: 20241020, EndDate: 20241020},
+			raceDate:   20241020,
+			raceCourse: types.Tokyo,
+			want:       true,
+		},
+		{
+			name:       "開始日より前",
+			filter:     &ApiFilter{StartDate: 20241021},
+			raceDate:   20241020,
+			raceCourse: types.Tokyo,
+			want:       false,
+		},
+		{
+			name:       "終了日より後",
+			filter:     &ApiFilter{EndDate: 20241019},
+			raceDate:   20241020,
+			raceCourse: types.Tokyo,
+			want:       false,
+		},
+		{
+			name:       "開催場所が違う",
+			filter:     &ApiFilter{RaceCourse: types.Kyoto},
+			raceDate:   20241020,
+			raceCourse: types.Tokyo,
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matchRace(tt.raceDate, tt.raceCourse); got != tt.want {
+				t.Errorf("matchRace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApiFilterMatchAttribute(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      *ApiFilter
+		attributeId filter.AttributeId
+		want        bool
+	}{
+		{
+			name:        "絞り込みなし",
+			filter:      &ApiFilter{},
+			attributeId: filter.Turf | filter.Tokyo,
+			want:        true,
+		},
+		{
+			name:        "指定した属性をすべて含む",
+			filter:      &ApiFilter{AttributeId: filter.Turf},
+			attributeId: filter.Turf | filter.Tokyo,
+			want:        true,
+		},
+		{
+			name:        "指定した属性の一部しか含まない",
+			filter:      &ApiFilter{AttributeId: filter.Turf | filter.Tokyo},
+			attributeId: filter.Turf,
+			want:        false,
+		},
+		{
+			name:        "全体の集計は全体を指定した場合だけ",
+			filter:      &ApiFilter{AttributeId: filter.Turf},
+			attributeId: filter.All,
+			want:        false,
+		},
+		{
+			name:        "全体を指定",
+			filter:      &ApiFilter{AttributeId: filter.All},
+			attributeId: filter.All,
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matchAttribute(tt.attributeId); got != tt.want {
+				t.Errorf("matchAttribute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAttribute(t *testing.T) {
+	attribute := newAttribute(filter.Turf | filter.Tokyo)
+	if attribute.Id != (filter.Turf|filter.Tokyo).Value() || attribute.Hex != "0x10000020000000" || attribute.Name != "芝,東京" {
+		t.Errorf("newAttribute() = %+v", attribute)
+	}
+}
+
+func TestRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   float64
+		want    float64
+		wantNil bool
+	}{
+		{
+			name:  "小数第2位に丸める",
+			value: 0.12345,
+			want:  0.12,
+		},
+		{
+			name:    "NaN",
+			value:   math.NaN(),
+			wantNil: true,
+		},
+		{
+			name:    "無限大",
+			value:   math.Inf(1),
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rate(tt.value)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("rate() = %v, want nil", *got)
+				}
+				return
+			}
+			if got == nil || *got != tt.want {
+				t.Errorf("rate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
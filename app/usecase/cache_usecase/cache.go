@@ -0,0 +1,138 @@
+package cache_usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/master_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+)
+
+type Cache interface {
+	Verify(ctx context.Context) (*CacheVerifyOutput, error)
+	Stats(ctx context.Context) ([]*cache_entity.CacheStat, error)
+	Show(ctx context.Context, raceId types.RaceId) (*raw_entity.CacheRace, error)
+	Refetch(ctx context.Context, input *CacheRefetchInput) (int, error)
+	Prune(ctx context.Context, before types.RaceDate) (int, error)
+}
+
+type CacheRefetchInput struct {
+	RaceDate types.RaceDate
+	RaceId   types.RaceId
+}
+
+type CacheVerifyOutput struct {
+	Valid      []*cache_entity.CacheFile
+	NoChecksum []*cache_entity.CacheFile
+	Corrupt    []*cache_entity.CacheFile
+}
+
+type cache struct {
+	cacheService      master_service.Cache
+	cacheEntryService master_service.CacheEntry
+	raceIdService     master_service.RaceId
+}
+
+func NewCache(
+	cacheService master_service.Cache,
+	cacheEntryService master_service.CacheEntry,
+	raceIdService master_service.RaceId,
+) Cache {
+	return &cache{
+		cacheService:      cacheService,
+		cacheEntryService: cacheEntryService,
+		raceIdService:     raceIdService,
+	}
+}
+
+// Verify 壊れたキャッシュを削除する。削除したファイルはマスタ更新で取り直す
+func (c *cache) Verify(ctx context.Context) (*CacheVerifyOutput, error) {
+	cacheFiles, err := c.cacheService.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &CacheVerifyOutput{}
+	for _, cacheFile := range cacheFiles {
+		switch cacheFile.Status() {
+		case types.CacheFileValid:
+			output.Valid = append(output.Valid, cacheFile)
+		case types.CacheFileNoChecksum:
+			output.NoChecksum = append(output.NoChecksum, cacheFile)
+		case types.CacheFileCorrupt:
+			output.Corrupt = append(output.Corrupt, cacheFile)
+		}
+	}
+
+	if err = c.cacheService.Repair(ctx, cacheFiles); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (c *cache) Stats(ctx context.Context) ([]*cache_entity.CacheStat, error) {
+	cacheFiles, err := c.cacheService.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.cacheEntryService.Stats(ctx, cacheFiles)
+}
+
+func (c *cache) Show(ctx context.Context, raceId types.RaceId) (*raw_entity.CacheRace, error) {
+	raceDateMap, _, err := c.raceIdService.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for raceDate, raceIds := range raceDateMap {
+		if slices.Contains(raceIds, raceId) {
+			return c.cacheEntryService.Find(ctx, raceId, raceDate)
+		}
+	}
+
+	return nil, fmt.Errorf("race id not found in race_id cache: %s", raceId)
+}
+
+// Refetch 開催日またはレースを指定して取り直す。取り直したレース数を返す
+func (c *cache) Refetch(ctx context.Context, input *CacheRefetchInput) (int, error) {
+	raceDateMap, _, err := c.raceIdService.Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	targetRaceDateMap := map[types.RaceDate][]types.RaceId{}
+	for raceDate, raceIds := range raceDateMap {
+		if input.RaceDate != 0 && raceDate != input.RaceDate {
+			continue
+		}
+		for _, raceId := range raceIds {
+			if input.RaceId != "" && raceId != input.RaceId {
+				continue
+			}
+			targetRaceDateMap[raceDate] = append(targetRaceDateMap[raceDate], raceId)
+		}
+	}
+
+	var refetchCount int
+	for _, raceIds := range targetRaceDateMap {
+		refetchCount += len(raceIds)
+	}
+	if refetchCount == 0 {
+		return 0, fmt.Errorf("race not found in race_id cache: date %d, race id %s", input.RaceDate, input.RaceId)
+	}
+
+	if err = c.cacheEntryService.Refetch(ctx, targetRaceDateMap); err != nil {
+		return 0, err
+	}
+
+	return refetchCount, nil
+}
+
+func (c *cache) Prune(ctx context.Context, before types.RaceDate) (int, error) {
+	return c.cacheEntryService.Prune(ctx, before)
+}
@@ -0,0 +1,88 @@
+package dashboard_usecase
+
+import (
+	"context"
+
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/raw_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/spreadsheet_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/aggregation_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/analysis_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/prediction_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types/filter"
+)
+
+// Dashboard スプレッドシートに書き出す内容をHTMLダッシュボード向けに返す
+type Dashboard interface {
+	Summary(ctx context.Context, input *DashboardInput) *spreadsheet_entity.Summary
+	List(ctx context.Context, input *DashboardInput) ([]*spreadsheet_entity.ListRow, error)
+	Place(ctx context.Context, input *DashboardInput) (*DashboardPlace, error)
+	CheckList(ctx context.Context) (*raw_entity.PredictionCheckListInfo, error)
+}
+
+type DashboardInput struct {
+	Markers []*marker_csv_entity.AnalysisMarker
+	Races   []*data_cache_entity.Race
+	Jockeys []*data_cache_entity.Jockey
+	Tickets []*ticket_csv_entity.RaceTicket
+	Account types.Account
+}
+
+type DashboardPlace struct {
+	FirstPlaceMap    map[types.Marker]map[filter.AttributeId]*spreadsheet_entity.AnalysisPlace
+	SecondPlaceMap   map[types.Marker]map[filter.AttributeId]*spreadsheet_entity.AnalysisPlace
+	ThirdPlaceMap    map[types.Marker]map[filter.AttributeId]*spreadsheet_entity.AnalysisPlace
+	AttributeFilters []filter.AttributeId
+}
+
+type dashboard struct {
+	summaryService           aggregation_service.Summary
+	listService              aggregation_service.List
+	placeService             analysis_service.Place
+	checkListSnapshotService prediction_service.CheckListSnapshot
+}
+
+func NewDashboard(
+	summaryService aggregation_service.Summary,
+	listService aggregation_service.List,
+	placeService analysis_service.Place,
+	checkListSnapshotService prediction_service.CheckListSnapshot,
+) Dashboard {
+	return &dashboard{
+		summaryService:           summaryService,
+		listService:              listService,
+		placeService:             placeService,
+		checkListSnapshotService: checkListSnapshotService,
+	}
+}
+
+func (d *dashboard) Summary(ctx context.Context, input *DashboardInput) *spreadsheet_entity.Summary {
+	return d.summaryService.Create(ctx, input.Tickets, input.Races, input.Account)
+}
+
+func (d *dashboard) List(ctx context.Context, input *DashboardInput) ([]*spreadsheet_entity.ListRow, error) {
+	return d.listService.Create(ctx, input.Tickets, input.Races, input.Jockeys, input.Account)
+}
+
+func (d *dashboard) Place(ctx context.Context, input *DashboardInput) (*DashboardPlace, error) {
+	calculables, err := d.placeService.Create(ctx, input.Markers, input.Races)
+	if err != nil {
+		return nil, err
+	}
+	firstPlaceMap, secondPlaceMap, thirdPlaceMap, attributeFilters := d.placeService.Convert(ctx, calculables)
+
+	return &DashboardPlace{
+		FirstPlaceMap:    firstPlaceMap,
+		SecondPlaceMap:   secondPlaceMap,
+		ThirdPlaceMap:    thirdPlaceMap,
+		AttributeFilters: attributeFilters,
+	}, nil
+}
+
+// CheckList 予想コマンドで最後に書き出したチェックリストを返す。未作成の場合はnil
+func (d *dashboard) CheckList(ctx context.Context) (*raw_entity.PredictionCheckListInfo, error) {
+	return d.checkListSnapshotService.Read(ctx)
+}
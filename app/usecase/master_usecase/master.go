@@ -2,12 +2,15 @@ package master_usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/ticket_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/master_service"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/config"
+	"github.com/sirupsen/logrus"
 )
 
 type Master interface {
@@ -25,6 +28,7 @@ type MasterOutput struct {
 	Races             []*data_cache_entity.Race
 	RaceTimes         []*data_cache_entity.RaceTime
 	Jockeys           []*data_cache_entity.Jockey
+	Trainers          []*data_cache_entity.Trainer
 	WinOdds           []*data_cache_entity.Odds
 	PlaceOdds         []*data_cache_entity.Odds
 	TrioOdds          []*data_cache_entity.Odds
@@ -40,6 +44,7 @@ type master struct {
 	raceTimeService         master_service.RaceTime
 	raceForecastService     master_service.RaceForecast
 	jockeyService           master_service.Jockey
+	trainerService          master_service.Trainer
 	winOddsService          master_service.WinOdds
 	placeOddsService        master_service.PlaceOdds
 	quinellaOddsService     master_service.QuinellaOdds
@@ -47,6 +52,8 @@ type master struct {
 	analysisMarkerService   master_service.AnalysisMarker
 	predictionMarkerService master_service.PredictionMarker
 	umacaTicketService      master_service.UmacaTicket
+	narTicketService        master_service.NarTicket
+	logger                  *logrus.Logger
 }
 
 func NewMaster(
@@ -56,6 +63,7 @@ func NewMaster(
 	raceTimeService master_service.RaceTime,
 	raceForecastService master_service.RaceForecast,
 	jockeyService master_service.Jockey,
+	trainerService master_service.Trainer,
 	winOddsService master_service.WinOdds,
 	placeOddsService master_service.PlaceOdds,
 	quinellaOddsService master_service.QuinellaOdds,
@@ -63,6 +71,8 @@ func NewMaster(
 	analysisMarkerService master_service.AnalysisMarker,
 	predictionMarkerService master_service.PredictionMarker,
 	umacaTicketService master_service.UmacaTicket,
+	narTicketService master_service.NarTicket,
+	logger *logrus.Logger,
 ) Master {
 	return &master{
 		ticketService:           ticketService,
@@ -71,6 +81,7 @@ func NewMaster(
 		raceTimeService:         raceTimeService,
 		raceForecastService:     raceForecastService,
 		jockeyService:           jockeyService,
+		trainerService:          trainerService,
 		winOddsService:          winOddsService,
 		placeOddsService:        placeOddsService,
 		quinellaOddsService:     quinellaOddsService,
@@ -78,6 +89,8 @@ func NewMaster(
 		analysisMarkerService:   analysisMarkerService,
 		predictionMarkerService: predictionMarkerService,
 		umacaTicketService:      umacaTicketService,
+		narTicketService:        narTicketService,
+		logger:                  logger,
 	}
 }
 
@@ -92,6 +105,12 @@ func (m *master) Get(ctx context.Context) (*MasterOutput, error) {
 		return nil, err
 	}
 
+	narRaceTickets, err := m.narTicketService.Get(ctx, races)
+	if err != nil {
+		return nil, err
+	}
+	raceTickets = append(raceTickets, narRaceTickets...)
+
 	raceTimes, err := m.raceTimeService.Get(ctx)
 	if err != nil {
 		return nil, err
@@ -102,6 +121,11 @@ func (m *master) Get(ctx context.Context) (*MasterOutput, error) {
 		return nil, err
 	}
 
+	trainers, err := m.trainerService.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	winOdds, err := m.winOddsService.Get(ctx)
 	if err != nil {
 		return nil, err
@@ -137,6 +161,7 @@ func (m *master) Get(ctx context.Context) (*MasterOutput, error) {
 		Races:             races,
 		RaceTimes:         raceTimes,
 		Jockeys:           jockeys,
+		Trainers:          trainers,
 		WinOdds:           winOdds,
 		PlaceOdds:         placeOdds,
 		TrioOdds:          trioOdds,
@@ -147,6 +172,7 @@ func (m *master) Get(ctx context.Context) (*MasterOutput, error) {
 }
 
 func (m *master) CreateOrUpdate(ctx context.Context, input *MasterInput) error {
+	stageStartTime := time.Now()
 	err := m.raceIdService.CreateOrUpdate(ctx, input.StartDate, input.EndDate)
 	if err != nil {
 		return err
@@ -156,6 +182,7 @@ func (m *master) CreateOrUpdate(ctx context.Context, input *MasterInput) error {
 	if err != nil {
 		return err
 	}
+	stageStartTime = m.logStage("race_id", stageStartTime)
 
 	races, err := m.raceService.Get(ctx)
 	if err != nil {
@@ -166,6 +193,7 @@ func (m *master) CreateOrUpdate(ctx context.Context, input *MasterInput) error {
 	if err != nil {
 		return err
 	}
+	stageStartTime = m.logStage("race", stageStartTime)
 
 	raceTimes, err := m.raceTimeService.Get(ctx)
 	if err != nil {
@@ -181,6 +209,7 @@ func (m *master) CreateOrUpdate(ctx context.Context, input *MasterInput) error {
 	if err != nil {
 		return err
 	}
+	stageStartTime = m.logStage("race_time", stageStartTime)
 
 	umacaMasters, err := m.umacaTicketService.GetMaster(ctx)
 	if err != nil {
@@ -228,6 +257,7 @@ func (m *master) CreateOrUpdate(ctx context.Context, input *MasterInput) error {
 	if err != nil {
 		return err
 	}
+	stageStartTime = m.logStage("umaca", stageStartTime)
 
 	// 地方・海外のレースデータを取得するために馬券情報を取得する
 	// 中央は期間から自動計算するが、地方・海外は馬券情報からRaceIdを割り出して取得する
@@ -236,8 +266,14 @@ func (m *master) CreateOrUpdate(ctx context.Context, input *MasterInput) error {
 		return err
 	}
 
-	// patとumacaのデータを統合
+	narRaceTickets, err := m.narTicketService.Get(ctx, races)
+	if err != nil {
+		return err
+	}
+
+	// pat、umaca、地方競馬の投票サイトのデータを統合
 	raceTickets = append(raceTickets, umacaRaceTickets...)
+	raceTickets = append(raceTickets, narRaceTickets...)
 
 	raceDateMapForNAROrOversea := map[types.RaceDate][]types.RaceId{}
 	for _, raceTicket := range raceTickets {
@@ -280,6 +316,14 @@ func (m *master) CreateOrUpdate(ctx context.Context, input *MasterInput) error {
 	if err != nil {
 		return err
 	}
+	stageStartTime = m.logStage("nar_oversea_race", stageStartTime)
+
+	// 同期済みの印のうちレース結果が揃ったものを分析用の印に取り込む
+	err = m.analysisMarkerService.Archive(ctx, races)
+	if err != nil {
+		return err
+	}
+	stageStartTime = m.logStage("marker_archive", stageStartTime)
 
 	jockeys, excludeJockeyIds, err := m.jockeyService.Get(ctx)
 	if err != nil {
@@ -290,6 +334,18 @@ func (m *master) CreateOrUpdate(ctx context.Context, input *MasterInput) error {
 	if err != nil {
 		return err
 	}
+	stageStartTime = m.logStage("jockey", stageStartTime)
+
+	trainers, err := m.trainerService.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = m.trainerService.CreateOrUpdate(ctx, trainers, races)
+	if err != nil {
+		return err
+	}
+	stageStartTime = m.logStage("trainer", stageStartTime)
 
 	winOdds, err := m.winOddsService.Get(ctx)
 	if err != nil {
@@ -310,30 +366,45 @@ func (m *master) CreateOrUpdate(ctx context.Context, input *MasterInput) error {
 	if err != nil {
 		return err
 	}
+	stageStartTime = time.Now()
 
 	err = m.winOddsService.CreateOrUpdateV2(ctx, winOdds, races)
 	if err != nil {
 		return err
 	}
+	stageStartTime = m.logStage("win_odds", stageStartTime)
 
 	err = m.placeOddsService.CreateOrUpdateV2(ctx, placeOdds, races)
 	if err != nil {
 		return err
 	}
+	stageStartTime = m.logStage("place_odds", stageStartTime)
 
 	err = m.quinellaOddsService.CreateOrUpdateV2(ctx, quinellaOdds, races)
 	if err != nil {
 		return err
 	}
+	stageStartTime = m.logStage("quinella_odds", stageStartTime)
 
 	err = m.trioOddsService.CreateOrUpdateV2(ctx, trioOdds, races)
 	if err != nil {
 		return err
 	}
+	m.logStage("trio_odds", stageStartTime)
 
 	return nil
 }
 
+// logStage マスタ更新の各ステージの所要時間を記録し、次のステージの開始時刻を返す
+func (m *master) logStage(stage string, startTime time.Time) time.Time {
+	m.logger.WithFields(logrus.Fields{
+		config.LogFieldStage:    stage,
+		config.LogFieldDuration: time.Since(startTime).Milliseconds(),
+	}).Infof("master %s updated", stage)
+
+	return time.Now()
+}
+
 func (m *master) uniqueSlice(slice *[]types.RaceId) {
 	seen := make(map[types.RaceId]bool)
 	j := 0
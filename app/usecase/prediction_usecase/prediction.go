@@ -6,6 +6,7 @@ import (
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/data_cache_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/analysis_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/master_service"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/prediction_service"
 	"github.com/sirupsen/logrus"
 )
@@ -14,6 +15,7 @@ type Prediction interface {
 	Odds(ctx context.Context, input *PredictionInput) error
 	CheckList(ctx context.Context, input *PredictionInput) error
 	Sync(ctx context.Context) error
+	BetSlip(ctx context.Context, input *BetSlipInput) (string, error)
 }
 
 type PredictionInput struct {
@@ -21,14 +23,38 @@ type PredictionInput struct {
 	PredictionMarkers []*marker_csv_entity.PredictionMarker
 	Races             []*data_cache_entity.Race
 	RaceTimes         []*data_cache_entity.RaceTime
+	Odds              *PredictionOddsInput
+	Bankroll          int
+}
+
+type BetSlipInput struct {
+	PredictionMarkers []*marker_csv_entity.PredictionMarker
+	PlanFileName      string
+	DryRun            bool
+}
+
+type PredictionOddsInput struct {
+	Win      []*data_cache_entity.Odds
+	Trio     []*data_cache_entity.Odds
+	Quinella []*data_cache_entity.Odds
 }
 
 type prediction struct {
 	predictionOddsService           prediction_service.Odds
 	predictionPlaceCandidateService prediction_service.PlaceCandidate
 	predictionMarkerSyncService     prediction_service.MarkerSync
+	stakeService                    prediction_service.Stake
+	betSlipService                  prediction_service.BetSlip
 	placeService                    analysis_service.Place
 	raceTimeService                 analysis_service.RaceTime
+	trainerService                  analysis_service.Trainer
+	placeScoreService               analysis_service.PlaceScore
+	placeRuleService                analysis_service.PlaceRule
+	commentScoreService             analysis_service.CommentScore
+	raceRiskService                 analysis_service.RaceRisk
+	horseMasterService              master_service.Horse
+	raceForecastService             master_service.RaceForecast
+	analysisMarkerService           master_service.AnalysisMarker
 	logger                          *logrus.Logger
 }
 
@@ -36,16 +62,36 @@ func NewPrediction(
 	predictionOddsService prediction_service.Odds,
 	predictionPlaceCandidateService prediction_service.PlaceCandidate,
 	predictionMarkerSyncService prediction_service.MarkerSync,
+	stakeService prediction_service.Stake,
+	betSlipService prediction_service.BetSlip,
 	placeService analysis_service.Place,
 	raceTimeService analysis_service.RaceTime,
+	trainerService analysis_service.Trainer,
+	placeScoreService analysis_service.PlaceScore,
+	placeRuleService analysis_service.PlaceRule,
+	commentScoreService analysis_service.CommentScore,
+	raceRiskService analysis_service.RaceRisk,
+	horseMasterService master_service.Horse,
+	raceForecastService master_service.RaceForecast,
+	analysisMarkerService master_service.AnalysisMarker,
 	logger *logrus.Logger,
 ) Prediction {
 	return &prediction{
 		predictionOddsService:           predictionOddsService,
 		predictionPlaceCandidateService: predictionPlaceCandidateService,
 		predictionMarkerSyncService:     predictionMarkerSyncService,
+		stakeService:                    stakeService,
+		betSlipService:                  betSlipService,
 		placeService:                    placeService,
 		raceTimeService:                 raceTimeService,
+		trainerService:                  trainerService,
+		placeScoreService:               placeScoreService,
+		placeRuleService:                placeRuleService,
+		commentScoreService:             commentScoreService,
+		raceRiskService:                 raceRiskService,
+		horseMasterService:              horseMasterService,
+		raceForecastService:             raceForecastService,
+		analysisMarkerService:           analysisMarkerService,
 		logger:                          logger,
 	}
 }
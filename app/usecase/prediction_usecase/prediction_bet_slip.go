@@ -0,0 +1,44 @@
+package prediction_usecase
+
+import (
+	"context"
+	"fmt"
+)
+
+// BetSlip 買い目の計画から投票用のファイルと投票予定票を作る。投票はしない
+func (p *prediction) BetSlip(ctx context.Context, input *BetSlipInput) (string, error) {
+	betPlans, err := p.betSlipService.GetPlans(ctx, input.PlanFileName)
+	if err != nil {
+		return "", err
+	}
+
+	betSlips := p.betSlipService.Validate(ctx, betPlans, input.PredictionMarkers)
+	slip := p.betSlipService.Print(ctx, betSlips)
+
+	var invalidCount int
+	for _, betSlip := range betSlips {
+		if !betSlip.Valid() {
+			invalidCount++
+		}
+	}
+
+	if input.DryRun {
+		if invalidCount > 0 {
+			p.logger.Warnf("bet slip dry run: %d/%d invalid", invalidCount, len(betSlips))
+		}
+		return slip, nil
+	}
+	if invalidCount > 0 {
+		return slip, fmt.Errorf("bet slip has %d invalid bets, fix %s and retry", invalidCount, input.PlanFileName)
+	}
+
+	paths, err := p.betSlipService.Export(ctx, betSlips)
+	if err != nil {
+		return slip, err
+	}
+	for _, path := range paths {
+		p.logger.Infof("bet slip written: %s", path)
+	}
+
+	return slip, nil
+}
@@ -29,6 +29,34 @@ func (p *prediction) CheckList(ctx context.Context, input *PredictionInput) erro
 		return err
 	}
 
+	trainerCalculables, err := p.trainerService.Create(ctx, input.Races)
+	if err != nil {
+		return err
+	}
+	trainerPerformanceMap := p.trainerService.GetPerformanceMap(ctx, trainerCalculables)
+	jockeyTrainerPerformanceMap := p.trainerService.GetJockeyTrainerPerformanceMap(ctx, trainerCalculables)
+
+	placeRuleSet, err := p.placeRuleService.Get(ctx)
+	if err != nil {
+		return err
+	}
+	checkListRules := placeRuleSet.CheckList()
+
+	commentLexicon, err := p.commentScoreService.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	placeScoreModel, err := p.createPlaceScoreModel(ctx, input, checkListRules, trainerCalculables)
+	if err != nil {
+		return err
+	}
+
+	trainerMap, err := p.predictionPlaceCandidateService.GetTrainers(ctx)
+	if err != nil {
+		return err
+	}
+
 	var wg sync.WaitGroup
 	errorCh := make(chan error, 1)
 	resultCh := make(chan []*spreadsheet_entity.PredictionCheckList, checkListParallel)
@@ -56,7 +84,7 @@ func (p *prediction) CheckList(ctx context.Context, input *PredictionInput) erro
 					if localError != nil {
 						continue
 					}
-					checkLists, err := p.createCheckList(taskCtx, calculables, marker)
+					checkLists, err := p.createCheckList(taskCtx, calculables, checkListRules, trainerMap, trainerPerformanceMap, jockeyTrainerPerformanceMap, placeScoreModel, commentLexicon, marker)
 					if err != nil {
 						localError = err
 						select {
@@ -101,6 +129,12 @@ func (p *prediction) CheckList(ctx context.Context, input *PredictionInput) erro
 func (p *prediction) createCheckList(
 	taskCtx context.Context,
 	calculables []*analysis_entity.PlaceCalculable,
+	checkListRules []*analysis_entity.PlaceRule,
+	trainerMap map[types.TrainerId]*prediction_entity.Trainer,
+	trainerPerformanceMap map[types.TrainerId]*analysis_entity.TrainerPerformance,
+	jockeyTrainerPerformanceMap map[types.TrainerId]map[types.JockeyId]*analysis_entity.TrainerPerformance,
+	placeScoreModel *analysis_entity.PlaceScoreModel,
+	commentLexicon *analysis_entity.CommentLexicon,
 	marker *marker_csv_entity.PredictionMarker,
 ) ([]*spreadsheet_entity.PredictionCheckList, error) {
 	predictionRace, err := p.predictionPlaceCandidateService.GetRaceCard(taskCtx, marker.RaceId())
@@ -165,11 +199,21 @@ func (p *prediction) createCheckList(
 			return nil, err
 		}
 
-		predictionTrainer, err := p.predictionPlaceCandidateService.GetTrainer(taskCtx, predictionHorse.TrainerId())
+		predictionTrainer, err := p.predictionPlaceCandidateService.GetTrainer(taskCtx, predictionHorse.TrainerId(), trainerMap)
 		if err != nil {
 			return nil, err
 		}
 
+		checkList := p.predictionPlaceCandidateService.CreateCheckList(
+			taskCtx,
+			checkListRules,
+			predictionRace,
+			predictionHorse,
+			raceForecast,
+			trainerPerformanceMap[predictionTrainer.TrainerId()],
+			jockeyTrainerPerformanceMap[predictionTrainer.TrainerId()][horse.JockeyId()],
+		)
+
 		predictionCheckList := p.predictionPlaceCandidateService.Convert(
 			taskCtx,
 			predictionRace,
@@ -180,7 +224,9 @@ func (p *prediction) createCheckList(
 			calculables,
 			horseNumber,
 			newMarker,
-			p.predictionPlaceCandidateService.CreateCheckList(taskCtx, predictionRace, predictionHorse, raceForecast),
+			checkList,
+			p.placeScoreService.Calculate(taskCtx, placeScoreModel, checkList),
+			p.commentScoreService.Score(taskCtx, commentLexicon, raceForecast.TrainingComment(), raceForecast.PreviousTrainingComment()),
 		)
 
 		predictionCheckLists = append(predictionCheckLists, predictionCheckList)
@@ -188,3 +234,36 @@ func (p *prediction) createCheckList(
 
 	return predictionCheckLists, nil
 }
+
+// createPlaceScoreModel キャッシュ済みの過去レースからチェックリストの重みを学習する
+func (p *prediction) createPlaceScoreModel(
+	ctx context.Context,
+	input *PredictionInput,
+	checkListRules []*analysis_entity.PlaceRule,
+	trainerCalculables []*analysis_entity.TrainerCalculable,
+) (*analysis_entity.PlaceScoreModel, error) {
+	horses, err := p.horseMasterService.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	raceForecasts, err := p.raceForecastService.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	samples, err := p.placeScoreService.CreateSamples(ctx, checkListRules, input.Races, horses, raceForecasts, trainerCalculables)
+	if err != nil {
+		return nil, err
+	}
+
+	placeScoreModel := p.placeScoreService.Fit(ctx, samples)
+	if placeScoreModel == nil {
+		p.logger.Warnf("place score model skipped: not enough samples %d", len(samples))
+		return nil, nil
+	}
+	p.logger.Infof("place score model fitted: samples %d, place %d, bias %.3f, weights %.3f, validation: %s",
+		placeScoreModel.SampleCount(), placeScoreModel.PlaceCount(), placeScoreModel.Bias(), placeScoreModel.Weights(), placeScoreModel.Validation().String())
+
+	return placeScoreModel, nil
+}
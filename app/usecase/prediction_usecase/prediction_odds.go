@@ -5,8 +5,11 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/analysis_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/marker_csv_entity"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/entity/prediction_entity"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/analysis_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
 )
 
 const oddsParallel = 5
@@ -17,6 +20,8 @@ func (p *prediction) Odds(ctx context.Context, input *PredictionInput) error {
 
 	predictionMarkers := input.PredictionMarkers
 	predictionRaces := make([]*prediction_entity.Race, 0, len(predictionMarkers))
+	raceRiskOddsMap := map[types.RaceId]*analysis_service.RaceRiskOddsInput{}
+	var raceRiskOddsMutex sync.Mutex
 
 	var wg sync.WaitGroup
 	errorCh := make(chan error, 1)
@@ -55,6 +60,16 @@ func (p *prediction) Odds(ctx context.Context, input *PredictionInput) error {
 						continue
 					}
 					localPredictionRaces = append(localPredictionRaces, predictionRace)
+
+					// 荒れ度合いは参考情報なので、オッズが取れなくてもそのレースの判定を空にして続ける
+					raceRiskOdds, err := p.predictionOddsService.GetRaceRiskOdds(taskCtx, predictionRace)
+					if err != nil {
+						p.logger.Warnf("race risk odds skipped %s: %v", predictionRace.RaceId(), err)
+						continue
+					}
+					raceRiskOddsMutex.Lock()
+					raceRiskOddsMap[predictionRace.RaceId()] = raceRiskOdds
+					raceRiskOddsMutex.Unlock()
 				}
 			}
 
@@ -89,11 +104,72 @@ func (p *prediction) Odds(ctx context.Context, input *PredictionInput) error {
 		return predictionRaces[i].RaceId() < predictionRaces[j].RaceId()
 	})
 
-	firstPlaceMap, secondPlaceMap, thirdPlaceMap, raceCourseMap := p.predictionOddsService.ConvertAll(ctx, predictionRaces, predictionMarkers, placeCalculables, analysisRaceTimeMap)
-	err = p.predictionOddsService.Write(ctx, firstPlaceMap, secondPlaceMap, thirdPlaceMap, raceCourseMap)
+	raceRiskMap, err := p.createRaceRiskMap(ctx, input, predictionRaces, raceRiskOddsMap)
+	if err != nil {
+		return err
+	}
+
+	firstPlaceMap, secondPlaceMap, thirdPlaceMap, raceCourseMap := p.predictionOddsService.ConvertAll(ctx, predictionRaces, predictionMarkers, placeCalculables, analysisRaceTimeMap, raceRiskMap)
+
+	stakeRule, err := p.stakeService.GetRule(ctx, input.Bankroll)
+	if err != nil {
+		return err
+	}
+	stakes := p.stakeService.Create(ctx, stakeRule, predictionRaces, predictionMarkers, placeCalculables)
+	p.logger.Infof("prediction stake method: %s, bankroll: %d, bets: %d", stakeRule.Method().String(), stakeRule.Bankroll(), len(stakes))
+
+	err = p.predictionOddsService.Write(ctx, firstPlaceMap, secondPlaceMap, thirdPlaceMap, raceCourseMap, p.stakeService.Convert(ctx, stakes))
+	if err != nil {
+		return err
+	}
+
+	err = p.stakeService.Export(ctx, stakes)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// createRaceRiskMap キャッシュ済みの過去レースで荒れ度合いを学習し、対象レースを堅い/荒れるに判定する
+func (p *prediction) createRaceRiskMap(
+	ctx context.Context,
+	input *PredictionInput,
+	predictionRaces []*prediction_entity.Race,
+	raceRiskOddsMap map[types.RaceId]*analysis_service.RaceRiskOddsInput,
+) (map[types.RaceId]*analysis_entity.RaceRisk, error) {
+	samples, err := p.raceRiskService.CreateSamples(ctx, input.Races, &analysis_service.RaceRiskOddsInput{
+		Win:      input.Odds.Win,
+		Trio:     input.Odds.Trio,
+		Quinella: input.Odds.Quinella,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	model := p.raceRiskService.Fit(ctx, samples)
+	if model == nil {
+		p.logger.Warnf("race risk model skipped, not enough samples: %d", len(samples))
+		return map[types.RaceId]*analysis_entity.RaceRisk{}, nil
+	}
+	p.logger.Infof("race risk model samples: %d, favorite unhit rate: %.3f, validation: %s",
+		model.SampleCount(), model.Threshold(), model.Validation().String())
+
+	raceRiskMap := make(map[types.RaceId]*analysis_entity.RaceRisk, len(predictionRaces))
+	for _, race := range predictionRaces {
+		raceRiskOdds, ok := raceRiskOddsMap[race.RaceId()]
+		if !ok {
+			continue
+		}
+		features, err := p.raceRiskService.CreateFeatures(ctx, raceRiskOdds)
+		if err != nil {
+			return nil, err
+		}
+		if features == nil {
+			continue
+		}
+		raceRiskMap[race.RaceId()] = p.raceRiskService.Calculate(ctx, model, features)
+	}
+
+	return raceRiskMap, nil
+}
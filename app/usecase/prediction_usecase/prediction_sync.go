@@ -83,5 +83,15 @@ func (p *prediction) Sync(ctx context.Context) error {
 		return err
 	}
 
+	// レース後にマスタ更新でanalysis_marker.csvへ取り込めるように同期した印を溜めておく
+	analysisMarkers, err := p.predictionMarkerSyncService.ConvertForArchive(ctx, raceDate, spreadSheetPredictionMarkers)
+	if err != nil {
+		return err
+	}
+	err = p.analysisMarkerService.Stage(ctx, analysisMarkers)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
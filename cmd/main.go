@@ -1,19 +1,18 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
-	"runtime"
-	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/mapserver2007/ipat-aggregator/app/controller"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/types"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
+	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/logging"
 	"github.com/mapserver2007/ipat-aggregator/config"
 	"github.com/mapserver2007/ipat-aggregator/di"
 	"github.com/sirupsen/logrus"
@@ -31,65 +30,286 @@ func main() {
 
 	logger := logrus.New()
 	logger.SetLevel(logrus.InfoLevel)
-	logger.SetFormatter(&SLF4JFormatter{})
+	logger.SetFormatter(&logging.SLF4JFormatter{})
 
-	logFile, err := os.OpenFile("/tmp/ipat-aggregator.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	if err != nil {
-		logger.Fatalf("Failed to open log file: %v", err)
-		return
+	var (
+		pathConfig       *file_gateway.PathConfig
+		cacheLock        *file_gateway.CacheLock
+		master           *controller.MasterOutput
+		metricsHook      *logging.MetricsHook
+		commandStartTime time.Time
+	)
+
+	app := cli.NewApp()
+	app.Name = "ipat-aggregator-cli"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:   "data-dir",
+			Usage:  "directory containing csv and rule (default: checkout root or $XDG_DATA_HOME/ipat-aggregator)",
+			EnvVar: "IPAT_AGGREGATOR_DATA_DIR",
+		},
+		cli.StringFlag{
+			Name:   "cache-dir",
+			Usage:  "cache directory (default: checkout cache or $XDG_CACHE_HOME/ipat-aggregator)",
+			EnvVar: "IPAT_AGGREGATOR_CACHE_DIR",
+		},
+		cli.StringFlag{
+			Name:   "secret-dir",
+			Usage:  "secrets directory (default: checkout secret or $XDG_CONFIG_HOME/ipat-aggregator)",
+			EnvVar: "IPAT_AGGREGATOR_SECRET_DIR",
+		},
+		cli.StringFlag{
+			Name:   "log-file",
+			Usage:  "log file path (default: /tmp/ipat-aggregator.log or $XDG_STATE_HOME/ipat-aggregator/ipat-aggregator.log)",
+			EnvVar: "IPAT_AGGREGATOR_LOG_FILE",
+		},
+		cli.StringFlag{
+			Name:   "log-format",
+			Value:  logging.TextFormat,
+			Usage:  "log format, text or json",
+			EnvVar: "IPAT_AGGREGATOR_LOG_FORMAT",
+		},
+		cli.StringFlag{
+			Name:   "metrics-file",
+			Usage:  "file to append the run summary to as json lines (default: ipat-aggregator-metrics.jsonl next to the log file)",
+			EnvVar: "IPAT_AGGREGATOR_METRICS_FILE",
+		},
 	}
-	defer logFile.Close()
 
-	logger.SetOutput(io.MultiWriter(os.Stdout, logFile))
+	var logFile *os.File
+	defer func() {
+		if logFile != nil {
+			logFile.Close()
+		}
+	}()
 
-	masterCtrl := di.NewMaster(logger)
-	startDate, err := types.NewRaceDate(config.RaceStartDate)
-	if err != nil {
-		logger.Errorf("failed to create race date: %v", err)
-		return
-	}
+	runMaster := func() error {
+		masterCtrl := di.NewMaster(logger, pathConfig)
+		startDate, err := types.NewRaceDate(config.RaceStartDate)
+		if err != nil {
+			logger.Errorf("failed to create race date: %v", err)
+			return err
+		}
 
-	endDate, err := types.NewRaceDate(config.RaceEndDate)
-	if err != nil {
-		logger.Errorf("failed to create race date: %v", err)
-		return
+		endDate, err := types.NewRaceDate(config.RaceEndDate)
+		if err != nil {
+			logger.Errorf("failed to create race date: %v", err)
+			return err
+		}
+
+		masterStartTime := time.Now()
+		master, err = masterCtrl.Execute(ctx, &controller.MasterInput{
+			StartDate: startDate,
+			EndDate:   endDate,
+		})
+		if err != nil {
+			logger.Errorf("master raed error: %v", err)
+			return err
+		}
+		logStage(logger, "master", masterStartTime)
+
+		return nil
 	}
 
-	master, err := masterCtrl.Execute(ctx, &controller.MasterInput{
-		StartDate: startDate,
-		EndDate:   endDate,
-	})
+	app.Before = func(c *cli.Context) error {
+		formatter, err := logging.NewFormatter(c.GlobalString("log-format"))
+		if err != nil {
+			logger.Errorf("failed to create log formatter: %v", err)
+			return err
+		}
+		logger.SetFormatter(formatter)
 
-	if err != nil {
-		logger.Errorf("master raed error: %v", err)
-		return
+		commandName := c.Args().First()
+		if command := c.App.Command(commandName); command != nil {
+			commandName = command.Name
+		}
+		metricsHook = logging.NewMetricsHook(commandName)
+		logger.AddHook(metricsHook)
+
+		pathConfig, err = file_gateway.NewPathConfig(
+			c.GlobalString("data-dir"),
+			c.GlobalString("cache-dir"),
+			c.GlobalString("secret-dir"),
+			c.GlobalString("log-file"),
+			c.GlobalString("metrics-file"),
+		)
+		if err != nil {
+			logger.Errorf("failed to resolve paths: %v", err)
+			return err
+		}
+
+		logFile, err = os.OpenFile(pathConfig.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		if err != nil {
+			logger.Errorf("failed to open log file: %v", err)
+			return err
+		}
+		logger.SetOutput(io.MultiWriter(os.Stdout, logFile))
+		logger.Infof("data dir: %s, cache dir: %s, secret dir: %s", pathConfig.DataDir, pathConfig.CacheDir, pathConfig.SecretDir)
+
+		// マスタ更新中に別のコマンドがキャッシュを書き換えないようにコマンドの終了までロックする
+		cacheLock, err = file_gateway.LockCache(pathConfig, logger)
+		if err != nil {
+			logger.Errorf("failed to lock cache: %v", err)
+			return err
+		}
+
+		// cacheコマンドはキャッシュを検証してから必要に応じてマスタを更新する
+		// diffコマンドは保存済みのスナップショットしか読まないのでマスタは不要
+		if commandName != "cache" && commandName != "diff" {
+			if err = runMaster(); err != nil {
+				return err
+			}
+		}
+		commandStartTime = time.Now()
+
+		return nil
 	}
 
-	app := cli.NewApp()
-	app.Name = "ipat-aggregator-cli"
+	app.After = func(c *cli.Context) error {
+		if !commandStartTime.IsZero() {
+			logStage(logger, metricsHook.Summary().Command, commandStartTime)
+		}
+		return nil
+	}
 
 	app.Commands = []cli.Command{
 		{
 			Name:    "aggregation",
 			Aliases: []string{"g"},
 			Usage:   "aggregation",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "account",
+					Usage: "aggregate only the given account (csv sub directory name, \"default\" for csv root)",
+				},
+			},
 			Action: func(c *cli.Context) error {
 				logger.Infof("aggregation start")
-				aggregationCtrl := di.NewAggregation(logger)
+				aggregationCtrl := di.NewAggregation(logger, pathConfig)
 				aggregationCtrl.Execute(ctx, &controller.AggregationInput{
-					Master: master,
+					Master:  master,
+					Account: types.NewAccount(c.String("account")),
 				})
 				logger.Infof("aggregation end")
 				return nil
 			},
 		},
+		{
+			Name:    "report",
+			Aliases: []string{"r"},
+			Usage:   "write weekly or monthly report as html and markdown",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "period",
+					Value: types.WeekReport.Value(),
+					Usage: "week or month",
+				},
+				cli.StringFlag{
+					Name:  "date",
+					Usage: "any date in the period (yyyymmdd). defaults to last week or this month",
+				},
+				cli.StringFlag{
+					Name:  "account",
+					Usage: "report only the given account (csv sub directory name, \"default\" for csv root)",
+				},
+				cli.IntFlag{
+					Name:  "bankroll",
+					Usage: "starting bankroll added to the cumulative profit",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				logger.Infof("report start")
+				period, err := types.NewReportPeriod(c.String("period"))
+				if err != nil {
+					logger.Errorf("report error: %v", err)
+					return err
+				}
+				var baseDate time.Time
+				if c.String("date") != "" {
+					baseDate, err = time.Parse("20060102", c.String("date"))
+					if err != nil {
+						logger.Errorf("report error: %v", err)
+						return err
+					}
+				}
+				aggregationCtrl := di.NewAggregation(logger, pathConfig)
+				if err = aggregationCtrl.Report(ctx, &controller.ReportInput{
+					Master:   master,
+					Account:  types.NewAccount(c.String("account")),
+					Period:   period,
+					BaseDate: baseDate,
+					Bankroll: c.Int("bankroll"),
+				}); err != nil {
+					logger.Errorf("report error: %v", err)
+					return err
+				}
+				logger.Infof("report end")
+				return nil
+			},
+		},
+		{
+			Name:  "diff",
+			Usage: "show buckets that moved significantly between two aggregation snapshots",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "sheet",
+					Usage: "summary, analysis_place, analysis_place_all_in or analysis_race_time. defaults to all sheets",
+				},
+				cli.StringFlag{
+					Name:  "from",
+					Usage: "snapshot name (yyyymmdd-hhmmss.000) or its prefix such as yyyymmdd or yyyymmdd-hhmmss. defaults to the one before --to",
+				},
+				cli.StringFlag{
+					Name:  "to",
+					Usage: "snapshot name (yyyymmdd-hhmmss.000) or its prefix such as yyyymmdd or yyyymmdd-hhmmss. defaults to the latest",
+				},
+				cli.Float64Flag{
+					Name:  "threshold",
+					Value: 5.0,
+					Usage: "minimum change of rates in percentage points",
+				},
+				cli.IntFlag{
+					Name:  "min-count",
+					Value: 10,
+					Usage: "skip rates whose sample size is smaller than this",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				var sheet types.SnapshotSheet
+				if c.String("sheet") != "" {
+					var err error
+					sheet, err = types.NewSnapshotSheet(c.String("sheet"))
+					if err != nil {
+						logger.Errorf("diff error: %v", err)
+						return err
+					}
+				}
+				if c.Float64("threshold") <= 0 {
+					err := fmt.Errorf("threshold must be positive: %v", c.Float64("threshold"))
+					logger.Errorf("diff error: %v", err)
+					return err
+				}
+				aggregationCtrl := di.NewAggregation(logger, pathConfig)
+				if err := aggregationCtrl.SnapshotDiff(ctx, &controller.SnapshotDiffInput{
+					Sheet:         sheet,
+					From:          c.String("from"),
+					To:            c.String("to"),
+					RateThreshold: c.Float64("threshold"),
+					MinCount:      c.Int("min-count"),
+				}); err != nil {
+					logger.Errorf("diff error: %v", err)
+					return err
+				}
+				return nil
+			},
+		},
 		{
 			Name:    "analysis-place",
 			Aliases: []string{"ap1"},
 			Usage:   "analysis-place",
 			Action: func(c *cli.Context) error {
 				logger.Infof("analysis place start")
-				analysisCtrl := di.NewAnalysis(logger)
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
 				analysisCtrl.Place(ctx, &controller.AnalysisInput{
 					Master: master,
 				})
@@ -103,7 +323,7 @@ func main() {
 			Usage:   "analysis-place-all-in",
 			Action: func(c *cli.Context) error {
 				logger.Infof("analysis place all in start")
-				analysisCtrl := di.NewAnalysis(logger)
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
 				analysisCtrl.PlaceAllIn(ctx, &controller.AnalysisInput{
 					Master: master,
 				})
@@ -117,7 +337,7 @@ func main() {
 			Usage:   "analysis-place-un-hit",
 			Action: func(c *cli.Context) error {
 				logger.Infof("analysis place un hit start")
-				analysisCtrl := di.NewAnalysis(logger)
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
 				analysisCtrl.PlaceUnHit(ctx, &controller.AnalysisInput{
 					Master: master,
 				})
@@ -131,7 +351,7 @@ func main() {
 			Usage:   "analysis-place-jockey",
 			Action: func(c *cli.Context) error {
 				logger.Infof("analysis place jockey start")
-				analysisCtrl := di.NewAnalysis(logger)
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
 				analysisCtrl.PlaceJockey(ctx, &controller.AnalysisInput{
 					Master: master,
 				})
@@ -145,7 +365,7 @@ func main() {
 			Usage:   "analysis-race",
 			Action: func(c *cli.Context) error {
 				logger.Infof("analysis race time start")
-				analysisCtrl := di.NewAnalysis(logger)
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
 				analysisCtrl.RaceTime(ctx, &controller.AnalysisInput{
 					Master: master,
 				})
@@ -153,13 +373,111 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:    "analysis-pedigree",
+			Aliases: []string{"ap6"},
+			Usage:   "analysis-pedigree",
+			Action: func(c *cli.Context) error {
+				logger.Infof("analysis pedigree start")
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
+				analysisCtrl.Pedigree(ctx, &controller.AnalysisInput{
+					Master: master,
+				})
+				logger.Infof("analysis pedigree end")
+				return nil
+			},
+		},
+		{
+			Name:    "analysis-trainer",
+			Aliases: []string{"ap7"},
+			Usage:   "analysis-trainer",
+			Action: func(c *cli.Context) error {
+				logger.Infof("analysis trainer start")
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
+				analysisCtrl.Trainer(ctx, &controller.AnalysisInput{
+					Master: master,
+				})
+				logger.Infof("analysis trainer end")
+				return nil
+			},
+		},
+		{
+			Name:    "analysis-marker-ticket",
+			Aliases: []string{"ap8"},
+			Usage:   "analysis-marker-ticket",
+			Action: func(c *cli.Context) error {
+				logger.Infof("analysis marker ticket start")
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
+				analysisCtrl.MarkerTicket(ctx, &controller.AnalysisInput{
+					Master: master,
+				})
+				logger.Infof("analysis marker ticket end")
+				return nil
+			},
+		},
+		{
+			Name:    "analysis-place-paddock",
+			Aliases: []string{"ap9"},
+			Usage:   "analysis-place-paddock",
+			Action: func(c *cli.Context) error {
+				logger.Infof("analysis place paddock start")
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
+				analysisCtrl.PlacePaddock(ctx, &controller.AnalysisInput{
+					Master: master,
+				})
+				logger.Infof("analysis place paddock end")
+				return nil
+			},
+		},
+		{
+			Name:    "analysis-comment-score",
+			Aliases: []string{"ap10"},
+			Usage:   "analysis-comment-score",
+			Action: func(c *cli.Context) error {
+				logger.Infof("analysis comment score start")
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
+				analysisCtrl.CommentScore(ctx, &controller.AnalysisInput{
+					Master: master,
+				})
+				logger.Infof("analysis comment score end")
+				return nil
+			},
+		},
+		{
+			Name:    "analysis-marker-consensus",
+			Aliases: []string{"ap11"},
+			Usage:   "analysis-marker-consensus",
+			Action: func(c *cli.Context) error {
+				logger.Infof("analysis marker consensus start")
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
+				analysisCtrl.MarkerConsensus(ctx, &controller.AnalysisInput{
+					Master: master,
+				})
+				logger.Infof("analysis marker consensus end")
+				return nil
+			},
+		},
+		{
+			Name:    "analysis-ticket-reprice",
+			Aliases: []string{"ap12"},
+			Usage:   "analysis-ticket-reprice",
+			Action: func(c *cli.Context) error {
+				logger.Infof("analysis ticket reprice start")
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
+				analysisCtrl.TicketReprice(ctx, &controller.AnalysisInput{
+					Master: master,
+				})
+				logger.Infof("analysis ticket reprice end")
+				return nil
+			},
+		},
 		{
 			Name:    "analysis-beta",
 			Aliases: []string{"ap5"},
 			Usage:   "analysis-beta",
 			Action: func(c *cli.Context) error {
 				logger.Infof("analysis beta in start")
-				analysisCtrl := di.NewAnalysis(logger)
+				analysisCtrl := di.NewAnalysis(logger, pathConfig)
 				analysisCtrl.Beta(ctx, &controller.AnalysisInput{
 					Master: master,
 				})
@@ -171,11 +489,18 @@ func main() {
 			Name:    "prediction",
 			Aliases: []string{"p1"},
 			Usage:   "prediction",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "bankroll",
+					Usage: "bankroll for stake sizing (overrides rule/stake_rule.json)",
+				},
+			},
 			Action: func(c *cli.Context) error {
 				logger.Infof("prediction start")
-				predictionCtrl := di.NewPrediction(logger)
+				predictionCtrl := di.NewPrediction(logger, pathConfig)
 				predictionCtrl.Prediction(ctx, &controller.PredictionInput{
-					Master: master,
+					Master:   master,
+					Bankroll: c.Int("bankroll"),
 				})
 				logger.Infof("prediction end")
 				return nil
@@ -187,15 +512,198 @@ func main() {
 			Usage:   "sync marker",
 			Action: func(c *cli.Context) error {
 				logger.Infof("sync marker start")
-				predictionCtrl := di.NewPrediction(logger)
+				predictionCtrl := di.NewPrediction(logger, pathConfig)
 				predictionCtrl.SyncMarker(ctx)
 				logger.Infof("sync marker end")
 				return nil
 			},
 		},
+		{
+			Name:    "bet-slip",
+			Aliases: []string{"p3"},
+			Usage:   "create ipat-format bet slip from bet plan (never submits)",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "plan",
+					Value: "bet_list.csv",
+					Usage: "bet plan file in csv dir",
+				},
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "validate and print only, write no files",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				logger.Infof("bet slip start")
+				predictionCtrl := di.NewPrediction(logger, pathConfig)
+				predictionCtrl.BetSlip(ctx, &controller.BetSlipInput{
+					Master:       master,
+					PlanFileName: c.String("plan"),
+					DryRun:       c.Bool("dry-run"),
+				})
+				logger.Infof("bet slip end")
+				return nil
+			},
+		},
+		{
+			Name:  "serve",
+			Usage: "serve master data and analysis results as http/json api",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "addr",
+					Value: "127.0.0.1:8080",
+					Usage: "listen address",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				logger.Infof("serve start")
+				// マスタは読み込み済みなので、起動中も他のコマンドがキャッシュを更新できるようにロックを外す
+				if err := cacheLock.Unlock(); err != nil {
+					logger.Errorf("failed to unlock cache: %v", err)
+				}
+				serverCtrl := di.NewServer(logger, pathConfig)
+				err := serverCtrl.Serve(ctx, &controller.ServerInput{
+					Master: master,
+					Addr:   c.String("addr"),
+				})
+				if err != nil {
+					logger.Errorf("serve error: %v", err)
+				}
+				logger.Infof("serve end")
+				return nil
+			},
+		},
+		{
+			Name:  "cache",
+			Usage: "manage master data cache",
+			Subcommands: []cli.Command{
+				{
+					Name:  "verify",
+					Usage: "verify cache checksums and refetch corrupt entries",
+					Action: func(c *cli.Context) error {
+						logger.Infof("cache verify start")
+						cacheCtrl := di.NewCache(logger, pathConfig)
+						output, err := cacheCtrl.Verify(ctx)
+						if err != nil {
+							logger.Errorf("cache verify error: %v", err)
+							return err
+						}
+						// 削除したキャッシュはマスタ更新で取り直す
+						if output.RemovedCount > 0 {
+							if err = runMaster(); err != nil {
+								return err
+							}
+						}
+						logger.Infof("cache verify end")
+						return nil
+					},
+				},
+				{
+					Name:  "stats",
+					Usage: "show cache file counts, sizes and date ranges per type",
+					Action: func(c *cli.Context) error {
+						cacheCtrl := di.NewCache(logger, pathConfig)
+						if err := cacheCtrl.Stats(ctx); err != nil {
+							logger.Errorf("cache stats error: %v", err)
+							return err
+						}
+						return nil
+					},
+				},
+				{
+					Name:      "show",
+					Usage:     "dump cached race, odds, race time and forecast of a race as json",
+					ArgsUsage: "<race_id>",
+					Action: func(c *cli.Context) error {
+						raceId := c.Args().First()
+						if raceId == "" {
+							return fmt.Errorf("race id is required")
+						}
+						cacheCtrl := di.NewCache(logger, pathConfig)
+						if err := cacheCtrl.Show(ctx, types.RaceId(raceId)); err != nil {
+							logger.Errorf("cache show error: %v", err)
+							return err
+						}
+						return nil
+					},
+				},
+				{
+					Name:  "refetch",
+					Usage: "re-download cached races without using the html cache",
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "date",
+							Usage: "race date to refetch (yyyymmdd)",
+						},
+						cli.StringFlag{
+							Name:  "race",
+							Usage: "race id to refetch",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						if c.String("date") == "" && c.String("race") == "" {
+							return fmt.Errorf("--date or --race is required")
+						}
+						var raceDate types.RaceDate
+						if c.String("date") != "" {
+							var err error
+							raceDate, err = types.NewRaceDate(c.String("date"))
+							if err != nil {
+								logger.Errorf("failed to create race date: %v", err)
+								return err
+							}
+						}
+						logger.Infof("cache refetch start")
+						cacheCtrl := di.NewCache(logger, pathConfig)
+						err := cacheCtrl.Refetch(ctx, &controller.CacheRefetchInput{
+							RaceDate: raceDate,
+							RaceId:   types.RaceId(c.String("race")),
+						})
+						if err != nil {
+							logger.Errorf("cache refetch error: %v", err)
+							return err
+						}
+						logger.Infof("cache refetch end")
+						return nil
+					},
+				},
+				{
+					Name:  "prune",
+					Usage: "remove cached data of races before the given date",
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "before",
+							Usage: "remove races before this date (yyyymmdd)",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						if c.String("before") == "" {
+							return fmt.Errorf("--before is required")
+						}
+						before, err := types.NewRaceDate(c.String("before"))
+						if err != nil {
+							logger.Errorf("failed to create race date: %v", err)
+							return err
+						}
+						logger.Infof("cache prune start")
+						cacheCtrl := di.NewCache(logger, pathConfig)
+						if err = cacheCtrl.Prune(ctx, before); err != nil {
+							logger.Errorf("cache prune error: %v", err)
+							return err
+						}
+						logger.Infof("cache prune end")
+						return nil
+					},
+				},
+			},
+		},
 	}
 
 	app.Run(os.Args)
+	if err := cacheLock.Unlock(); err != nil {
+		logger.Errorf("failed to unlock cache: %v", err)
+	}
+	writeMetrics(logger, pathConfig, metricsHook)
 
 	//scheduler, err := func() (gocron.Scheduler, error) {
 	//	jst, err := time.LoadLocation("Asia/Tokyo")
@@ -266,28 +774,35 @@ func main() {
 	//}
 }
 
-type SLF4JFormatter struct{}
-
-func (f *SLF4JFormatter) Format(entry *logrus.Entry) ([]byte, error) {
-	timestamp := entry.Time.Format("2006-01-02 15:04:05")
-
-	level := entry.Level.String()
-	level = fmt.Sprintf("%-5s", level) // SLF4J形式に合わせてレベルを整列
+// logStage ステージの所要時間をstage、durationフィールド付きで記録する
+func logStage(logger *logrus.Logger, stage string, startTime time.Time) {
+	logger.WithFields(logrus.Fields{
+		config.LogFieldStage:    stage,
+		config.LogFieldDuration: time.Since(startTime).Milliseconds(),
+	}).Infof("%s finished", stage)
+}
 
-	var buf bytes.Buffer
-	goroutineId := getGoroutineID()
-	buf.WriteString(fmt.Sprintf("%s [%s] thread%d - %s\n", timestamp, level, goroutineId, entry.Message))
+// writeMetrics 取得ページ数、キャッシュ利用数、エラー数、ステージごとの所要時間をメトリクスファイルに残す
+func writeMetrics(logger *logrus.Logger, pathConfig *file_gateway.PathConfig, metricsHook *logging.MetricsHook) {
+	if pathConfig == nil || metricsHook == nil {
+		return
+	}
 
-	return buf.Bytes(), nil
-}
+	summary := metricsHook.Summary()
+	logger.WithFields(logrus.Fields{
+		config.LogFieldDuration: summary.DurationMs,
+		"pages_fetched":         summary.PagesFetched,
+		"cache_hits":            summary.CacheHits,
+		"fetch_errors":          summary.FetchErrors,
+		"errors":                summary.Errors,
+	}).Infof("run summary")
+	for _, stage := range summary.SlowestStages(3) {
+		logger.Infof("slow stage: %s %dms", stage.Name, stage.DurationMs)
+	}
 
-func getGoroutineID() int {
-	var buf [64]byte
-	n := runtime.Stack(buf[:], false)
-	idField := bytes.Fields(buf[:n])[1]
-	id, err := strconv.Atoi(string(idField))
-	if err != nil {
-		return -1
+	if err := logging.WriteMetrics(pathConfig.MetricsFile, summary); err != nil {
+		logger.Errorf("failed to write metrics: %v", err)
+		return
 	}
-	return id
+	logger.Infof("metrics written to %s", pathConfig.MetricsFile)
 }
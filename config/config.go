@@ -1,8 +1,12 @@
 package config
 
 const (
-	CsvDir   = "csv"
-	CacheDir = "cache"
+	CsvDir      = "csv"
+	CacheDir    = "cache"
+	RuleDir     = "rule"
+	SecretDir   = "secret"
+	ReportDir   = "report"
+	SnapshotDir = "snapshot"
 	// race_idマスタ、各oddsマスタ
 	RaceStartDate = "20230729"
 	RaceEndDate   = "20250427"
@@ -0,0 +1,19 @@
+package config
+
+// 構造化ログのフィールド名
+const (
+	LogFieldCommand  = "command"
+	LogFieldStage    = "stage"
+	LogFieldPage     = "page"
+	LogFieldRaceId   = "race_id"
+	LogFieldUrl      = "url"
+	LogFieldDuration = "duration"
+	LogFieldStatus   = "status"
+)
+
+// 取得結果のステータス
+const (
+	FetchStatusFetched = "fetched"
+	FetchStatusCache   = "cache"
+	FetchStatusError   = "error"
+)
@@ -12,12 +12,16 @@ import (
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/filter_service"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/master_service"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/prediction_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/snapshot_service"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/summary_service"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/gateway"
 	"github.com/mapserver2007/ipat-aggregator/app/usecase/aggregation_usecase"
 	"github.com/mapserver2007/ipat-aggregator/app/usecase/analysis_usecase"
+	"github.com/mapserver2007/ipat-aggregator/app/usecase/api_usecase"
+	"github.com/mapserver2007/ipat-aggregator/app/usecase/cache_usecase"
+	"github.com/mapserver2007/ipat-aggregator/app/usecase/dashboard_usecase"
 	"github.com/mapserver2007/ipat-aggregator/app/usecase/master_usecase"
 	"github.com/mapserver2007/ipat-aggregator/app/usecase/prediction_usecase"
 	"github.com/sirupsen/logrus"
@@ -29,6 +33,7 @@ var MasterSet = wire.NewSet(
 	master_service.NewRaceId,
 	master_service.NewRace,
 	master_service.NewJockey,
+	master_service.NewTrainer,
 	master_service.NewWinOdds,
 	master_service.NewPlaceOdds,
 	master_service.NewQuinellaOdds,
@@ -37,10 +42,13 @@ var MasterSet = wire.NewSet(
 	master_service.NewPredictionMarker,
 	master_service.NewBetNumberConverter,
 	master_service.NewUmacaTicket,
+	master_service.NewNarTicket,
 	master_service.NewRaceForecast,
 	master_service.NewRaceTime,
+	master_service.NewCheckpoint,
 	converter.NewRaceEntityConverter,
 	converter.NewJockeyEntityConverter,
+	converter.NewTrainerEntityConverter,
 	converter.NewOddsEntityConverter,
 	converter.NewRaceForecastEntityConverter,
 	converter.NewRaceTimeEntityConverter,
@@ -49,11 +57,14 @@ var MasterSet = wire.NewSet(
 	infrastructure.NewRaceRepository,
 	infrastructure.NewRaceForecastRepository,
 	infrastructure.NewJockeyRepository,
+	infrastructure.NewTrainerRepository,
 	infrastructure.NewOddsRepository,
 	infrastructure.NewAnalysisMarkerRepository,
 	infrastructure.NewPredictionMarkerRepository,
 	infrastructure.NewUmacaTicketRepository,
+	infrastructure.NewNarTicketRepository,
 	infrastructure.NewRaceTimeRepository,
+	infrastructure.NewCheckpointRepository,
 	gateway.NewNetKeibaGateway,
 	gateway.NewNetKeibaCollector,
 	gateway.NewTospoGateway,
@@ -64,9 +75,13 @@ var AggregationSet = wire.NewSet(
 	aggregation_usecase.NewSummary,
 	aggregation_usecase.NewTicketSummary,
 	aggregation_usecase.NewList,
+	aggregation_usecase.NewReport,
+	aggregation_usecase.NewSnapshotDiff,
 	aggregation_service.NewSummary,
 	aggregation_service.NewTicketSummary,
 	aggregation_service.NewList,
+	aggregation_service.NewReport,
+	snapshot_service.NewSnapshot,
 	summary_service.NewTerm,
 	summary_service.NewTicket,
 	summary_service.NewClass,
@@ -76,6 +91,8 @@ var AggregationSet = wire.NewSet(
 	infrastructure.NewSpreadSheetRepository,
 	converter.NewRaceEntityConverter,
 	converter.NewJockeyEntityConverter,
+	infrastructure.NewReportRepository,
+	infrastructure.NewSnapshotRepository,
 )
 
 var AnalysisSet = wire.NewSet(
@@ -87,13 +104,25 @@ var AnalysisSet = wire.NewSet(
 	analysis_service.NewPlaceCheckList,
 	analysis_service.NewBetaWin,
 	analysis_service.NewPlaceCheckPoint,
-	analysis_service.NewPlaceNegativeCheck,
+	analysis_service.NewPlaceRule,
 	analysis_service.NewRaceTime,
+	analysis_service.NewPedigree,
+	analysis_service.NewTrainer,
+	analysis_service.NewPlaceScore,
+	analysis_service.NewMarkerTicket,
+	analysis_service.NewPlacePaddock,
+	analysis_service.NewCommentScore,
+	analysis_service.NewMarkerConsensus,
+	analysis_service.NewTicketReprice,
+	snapshot_service.NewSnapshot,
 	master_service.NewHorse,
 	master_service.NewRaceForecast,
 	filter_service.NewAnalysisFilter,
 	infrastructure.NewHorseRepository,
 	infrastructure.NewRaceForecastRepository,
+	infrastructure.NewPlaceRuleRepository,
+	infrastructure.NewCommentLexiconRepository,
+	infrastructure.NewSnapshotRepository,
 	infrastructure.NewSpreadSheetRepository,
 	gateway.NewNetKeibaGateway,
 	gateway.NewNetKeibaCollector,
@@ -107,13 +136,68 @@ var PredictionSet = wire.NewSet(
 	prediction_service.NewOdds,
 	prediction_service.NewPlaceCandidate,
 	prediction_service.NewMarkerSync,
+	prediction_service.NewStake,
+	prediction_service.NewBetSlip,
+	prediction_service.NewCheckList,
+	prediction_service.NewCheckListSnapshot,
+	analysis_service.NewRaceRisk,
+	master_service.NewAnalysisMarker,
+	infrastructure.NewAnalysisMarkerRepository,
+	converter.NewPredictionCheckListEntityConverter,
+	converter.NewOddsEntityConverter,
 	filter_service.NewPredictionFilter,
 	infrastructure.NewOddsRepository,
 	infrastructure.NewRaceRepository,
 	infrastructure.NewJockeyRepository,
 	infrastructure.NewTrainerRepository,
 	infrastructure.NewRaceIdRepository,
+	infrastructure.NewPredictionCheckListRepository,
+	infrastructure.NewStakeRuleRepository,
+	infrastructure.NewBetListRepository,
+	infrastructure.NewBetSlipRepository,
+	master_service.NewBetNumberConverter,
+	converter.NewRaceEntityConverter,
+)
+
+var ServerSet = wire.NewSet(
+	api_usecase.NewApi,
+	dashboard_usecase.NewDashboard,
+	aggregation_service.NewSummary,
+	aggregation_service.NewList,
+	prediction_service.NewCheckListSnapshot,
+	summary_service.NewTerm,
+	summary_service.NewTicket,
+	summary_service.NewClass,
+	summary_service.NewCourseCategory,
+	summary_service.NewDistanceCategory,
+	summary_service.NewRaceCourse,
 	converter.NewRaceEntityConverter,
+	converter.NewOddsEntityConverter,
+	converter.NewJockeyEntityConverter,
+	converter.NewPredictionCheckListEntityConverter,
+	infrastructure.NewPredictionCheckListRepository,
+)
+
+var CacheSet = wire.NewSet(
+	cache_usecase.NewCache,
+	master_service.NewCache,
+	master_service.NewCacheEntry,
+	master_service.NewRaceId,
+	master_service.NewCheckpoint,
+	converter.NewRaceEntityConverter,
+	converter.NewOddsEntityConverter,
+	converter.NewRaceTimeEntityConverter,
+	infrastructure.NewCacheRepository,
+	infrastructure.NewRaceRepository,
+	infrastructure.NewOddsRepository,
+	infrastructure.NewRaceTimeRepository,
+	infrastructure.NewRaceIdRepository,
+	infrastructure.NewRaceForecastRepository,
+	infrastructure.NewCheckpointRepository,
+	gateway.NewNetKeibaGateway,
+	gateway.NewNetKeibaCollector,
+	gateway.NewTospoGateway,
+	file_gateway.NewPathOptimizer,
 )
 
 var SpreadSheetGatewaySet = wire.NewSet(
@@ -124,6 +208,12 @@ var SpreadSheetGatewaySet = wire.NewSet(
 	gateway.NewSpreadSheetAnalysisPlaceAllInGateway,
 	gateway.NewSpreadSheetAnalysisPlaceUnhitGateway,
 	gateway.NewSpreadSheetAnalysisRaceTimeGateway,
+	gateway.NewSpreadSheetAnalysisPedigreeGateway,
+	gateway.NewSpreadSheetAnalysisTrainerGateway,
+	gateway.NewSpreadSheetAnalysisMarkerTicketGateway,
+	gateway.NewSpreadSheetAnalysisPlaceRateGateway,
+	gateway.NewSpreadSheetAnalysisMarkerConsensusGateway,
+	gateway.NewSpreadSheetAnalysisTicketRepriceGateway,
 	gateway.NewSpreadSheetPredictionOddsGateway,
 	gateway.NewSpreadSheetPredictionCheckListGateway,
 	gateway.NewSpreadSheetPredictionMarkerGateway,
@@ -133,6 +223,7 @@ var SpreadSheetGatewaySet = wire.NewSet(
 
 func NewMaster(
 	logger *logrus.Logger,
+	pathConfig *file_gateway.PathConfig,
 ) *controller.Master {
 	wire.Build(
 		MasterSet,
@@ -143,6 +234,7 @@ func NewMaster(
 
 func NewAggregation(
 	logger *logrus.Logger,
+	pathConfig *file_gateway.PathConfig,
 ) *controller.Aggregation {
 	wire.Build(
 		AggregationSet,
@@ -154,6 +246,7 @@ func NewAggregation(
 
 func NewAnalysis(
 	logger *logrus.Logger,
+	pathConfig *file_gateway.PathConfig,
 ) *controller.Analysis {
 	wire.Build(
 		AnalysisSet,
@@ -165,6 +258,7 @@ func NewAnalysis(
 
 func NewPrediction(
 	logger *logrus.Logger,
+	pathConfig *file_gateway.PathConfig,
 ) *controller.Prediction {
 	wire.Build(
 		PredictionSet,
@@ -174,3 +268,27 @@ func NewPrediction(
 	)
 	return nil
 }
+
+func NewServer(
+	logger *logrus.Logger,
+	pathConfig *file_gateway.PathConfig,
+) *controller.Server {
+	wire.Build(
+		ServerSet,
+		AnalysisSet,
+		SpreadSheetGatewaySet,
+		controller.NewServer,
+	)
+	return nil
+}
+
+func NewCache(
+	logger *logrus.Logger,
+	pathConfig *file_gateway.PathConfig,
+) *controller.Cache {
+	wire.Build(
+		CacheSet,
+		controller.NewCache,
+	)
+	return nil
+}
@@ -15,12 +15,16 @@ import (
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/filter_service"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/master_service"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/prediction_service"
+	"github.com/mapserver2007/ipat-aggregator/app/domain/service/snapshot_service"
 	"github.com/mapserver2007/ipat-aggregator/app/domain/service/summary_service"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/file_gateway"
 	"github.com/mapserver2007/ipat-aggregator/app/infrastructure/gateway"
 	"github.com/mapserver2007/ipat-aggregator/app/usecase/aggregation_usecase"
 	"github.com/mapserver2007/ipat-aggregator/app/usecase/analysis_usecase"
+	"github.com/mapserver2007/ipat-aggregator/app/usecase/api_usecase"
+	"github.com/mapserver2007/ipat-aggregator/app/usecase/cache_usecase"
+	"github.com/mapserver2007/ipat-aggregator/app/usecase/dashboard_usecase"
 	"github.com/mapserver2007/ipat-aggregator/app/usecase/master_usecase"
 	"github.com/mapserver2007/ipat-aggregator/app/usecase/prediction_usecase"
 	"github.com/sirupsen/logrus"
@@ -28,53 +32,60 @@ import (
 
 // Injectors from wire.go:
 
-func NewMaster(logger *logrus.Logger) *controller.Master {
+func NewMaster(logger *logrus.Logger, pathConfig *file_gateway.PathConfig) *controller.Master {
 	betNumberConverter := master_service.NewBetNumberConverter()
-	pathOptimizer := file_gateway.NewPathOptimizer()
+	pathOptimizer := file_gateway.NewPathOptimizer(pathConfig)
 	ticketRepository := infrastructure.NewTicketRepository(betNumberConverter, pathOptimizer)
 	ticket := master_service.NewTicket(ticketRepository)
 	netKeibaCollector := gateway.NewNetKeibaCollector(pathOptimizer)
 	netKeibaGateway := gateway.NewNetKeibaGateway(netKeibaCollector, logger)
 	raceIdRepository := infrastructure.NewRaceIdRepository(netKeibaGateway, pathOptimizer)
-	raceId := master_service.NewRaceId(raceIdRepository, logger)
+	checkpointRepository := infrastructure.NewCheckpointRepository(pathOptimizer)
+	checkpoint := master_service.NewCheckpoint(checkpointRepository, logger)
+	raceId := master_service.NewRaceId(raceIdRepository, checkpoint, logger)
 	raceRepository := infrastructure.NewRaceRepository(netKeibaGateway, pathOptimizer)
 	raceEntityConverter := converter.NewRaceEntityConverter()
-	race := master_service.NewRace(raceRepository, raceEntityConverter, logger)
+	race := master_service.NewRace(raceRepository, raceEntityConverter, checkpoint, logger)
 	raceTimeRepository := infrastructure.NewRaceTimeRepository(netKeibaGateway, pathOptimizer)
 	raceTimeEntityConverter := converter.NewRaceTimeEntityConverter()
-	raceTime := master_service.NewRaceTime(raceTimeRepository, raceTimeEntityConverter, logger)
+	raceTime := master_service.NewRaceTime(raceTimeRepository, raceTimeEntityConverter, checkpoint, logger)
 	tospoGateway := gateway.NewTospoGateway(logger)
 	raceForecastRepository := infrastructure.NewRaceForecastRepository(tospoGateway, pathOptimizer)
 	raceForecastEntityConverter := converter.NewRaceForecastEntityConverter()
 	raceForecast := master_service.NewRaceForecast(raceForecastRepository, raceForecastEntityConverter)
 	jockeyRepository := infrastructure.NewJockeyRepository(netKeibaGateway, pathOptimizer)
 	jockeyEntityConverter := converter.NewJockeyEntityConverter()
-	jockey := master_service.NewJockey(jockeyRepository, jockeyEntityConverter, logger)
+	jockey := master_service.NewJockey(jockeyRepository, jockeyEntityConverter, checkpoint, logger)
+	trainerRepository := infrastructure.NewTrainerRepository(netKeibaGateway, pathOptimizer)
+	trainerEntityConverter := converter.NewTrainerEntityConverter()
+	trainer := master_service.NewTrainer(trainerRepository, trainerEntityConverter, checkpoint, logger)
 	oddsRepository := infrastructure.NewOddsRepository(netKeibaGateway, pathOptimizer)
 	oddsEntityConverter := converter.NewOddsEntityConverter()
-	winOdds := master_service.NewWinOdds(oddsRepository, oddsEntityConverter, logger)
-	placeOdds := master_service.NewPlaceOdds(oddsRepository, oddsEntityConverter, logger)
-	quinellaOdds := master_service.NewQuinellaOdds(oddsRepository, oddsEntityConverter, logger)
-	trioOdds := master_service.NewTrioOdds(oddsRepository, oddsEntityConverter, logger)
+	winOdds := master_service.NewWinOdds(oddsRepository, oddsEntityConverter, checkpoint, logger)
+	placeOdds := master_service.NewPlaceOdds(oddsRepository, oddsEntityConverter, checkpoint, logger)
+	quinellaOdds := master_service.NewQuinellaOdds(oddsRepository, oddsEntityConverter, checkpoint, logger)
+	trioOdds := master_service.NewTrioOdds(oddsRepository, oddsEntityConverter, checkpoint, logger)
 	analysisMarkerRepository := infrastructure.NewAnalysisMarkerRepository(pathOptimizer)
-	analysisMarker := master_service.NewAnalysisMarker(analysisMarkerRepository)
+	analysisMarker := master_service.NewAnalysisMarker(analysisMarkerRepository, logger)
 	predictionMarkerRepository := infrastructure.NewPredictionMarkerRepository(netKeibaGateway, pathOptimizer)
 	predictionMarker := master_service.NewPredictionMarker(predictionMarkerRepository)
 	umacaTicketRepository := infrastructure.NewUmacaTicketRepository(pathOptimizer)
 	umacaTicket := master_service.NewUmacaTicket(umacaTicketRepository, ticketRepository)
-	master := master_usecase.NewMaster(ticket, raceId, race, raceTime, raceForecast, jockey, winOdds, placeOdds, quinellaOdds, trioOdds, analysisMarker, predictionMarker, umacaTicket)
+	narTicketRepository := infrastructure.NewNarTicketRepository(betNumberConverter, pathOptimizer)
+	narTicket := master_service.NewNarTicket(narTicketRepository, ticketRepository, betNumberConverter)
+	master := master_usecase.NewMaster(ticket, raceId, race, raceTime, raceForecast, jockey, trainer, winOdds, placeOdds, quinellaOdds, trioOdds, analysisMarker, predictionMarker, umacaTicket, narTicket, logger)
 	controllerMaster := controller.NewMaster(master)
 	return controllerMaster
 }
 
-func NewAggregation(logger *logrus.Logger) *controller.Aggregation {
+func NewAggregation(logger *logrus.Logger, pathConfig *file_gateway.PathConfig) *controller.Aggregation {
 	term := summary_service.NewTerm()
 	ticket := summary_service.NewTicket()
 	class := summary_service.NewClass()
 	courseCategory := summary_service.NewCourseCategory()
 	distanceCategory := summary_service.NewDistanceCategory()
 	raceCourse := summary_service.NewRaceCourse()
-	pathOptimizer := file_gateway.NewPathOptimizer()
+	pathOptimizer := file_gateway.NewPathOptimizer(pathConfig)
 	spreadSheetConfigGateway := gateway.NewSpreadSheetConfigGateway(pathOptimizer)
 	spreadSheetSummaryGateway := gateway.NewSpreadSheetSummaryGateway(logger, spreadSheetConfigGateway)
 	spreadSheetTicketSummaryGateway := gateway.NewSpreadSheetTicketSummaryGateway(logger, spreadSheetConfigGateway)
@@ -83,25 +94,37 @@ func NewAggregation(logger *logrus.Logger) *controller.Aggregation {
 	spreadSheetAnalysisPlaceAllInGateway := gateway.NewSpreadSheetAnalysisPlaceAllInGateway(logger, spreadSheetConfigGateway)
 	spreadSheetAnalysisPlaceUnhitGateway := gateway.NewSpreadSheetAnalysisPlaceUnhitGateway(spreadSheetConfigGateway, logger)
 	spreadSheetAnalysisRaceTimeGateway := gateway.NewSpreadSheetAnalysisRaceTimeGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisPedigreeGateway := gateway.NewSpreadSheetAnalysisPedigreeGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisTrainerGateway := gateway.NewSpreadSheetAnalysisTrainerGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisMarkerTicketGateway := gateway.NewSpreadSheetAnalysisMarkerTicketGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisPlaceRateGateway := gateway.NewSpreadSheetAnalysisPlaceRateGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisMarkerConsensusGateway := gateway.NewSpreadSheetAnalysisMarkerConsensusGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisTicketRepriceGateway := gateway.NewSpreadSheetAnalysisTicketRepriceGateway(spreadSheetConfigGateway, logger)
 	spreadSheetPredictionOddsGateway := gateway.NewSpreadSheetPredictionOddsGateway(spreadSheetConfigGateway, logger)
 	spreadSheetPredictionCheckListGateway := gateway.NewSpreadSheetPredictionCheckListGateway(logger, spreadSheetConfigGateway)
 	spreadSheetPredictionMarkerGateway := gateway.NewSpreadSheetPredictionMarkerGateway(logger, spreadSheetConfigGateway)
-	spreadSheetRepository := infrastructure.NewSpreadSheetRepository(spreadSheetSummaryGateway, spreadSheetTicketSummaryGateway, spreadSheetListGateway, spreadSheetAnalysisPlaceGateway, spreadSheetAnalysisPlaceAllInGateway, spreadSheetAnalysisPlaceUnhitGateway, spreadSheetAnalysisRaceTimeGateway, spreadSheetPredictionOddsGateway, spreadSheetPredictionCheckListGateway, spreadSheetPredictionMarkerGateway)
+	spreadSheetRepository := infrastructure.NewSpreadSheetRepository(spreadSheetSummaryGateway, spreadSheetTicketSummaryGateway, spreadSheetListGateway, spreadSheetAnalysisPlaceGateway, spreadSheetAnalysisPlaceAllInGateway, spreadSheetAnalysisPlaceUnhitGateway, spreadSheetAnalysisRaceTimeGateway, spreadSheetAnalysisPedigreeGateway, spreadSheetAnalysisTrainerGateway, spreadSheetAnalysisMarkerTicketGateway, spreadSheetAnalysisPlaceRateGateway, spreadSheetAnalysisMarkerConsensusGateway, spreadSheetAnalysisTicketRepriceGateway, spreadSheetPredictionOddsGateway, spreadSheetPredictionCheckListGateway, spreadSheetPredictionMarkerGateway)
 	summary := aggregation_service.NewSummary(term, ticket, class, courseCategory, distanceCategory, raceCourse, spreadSheetRepository)
-	aggregation_usecaseSummary := aggregation_usecase.NewSummary(summary)
+	snapshotRepository := infrastructure.NewSnapshotRepository(pathOptimizer)
+	snapshot := snapshot_service.NewSnapshot(snapshotRepository)
+	aggregation_usecaseSummary := aggregation_usecase.NewSummary(summary, snapshot, logger)
 	ticketSummary := aggregation_service.NewTicketSummary(term, spreadSheetRepository, logger)
 	aggregation_usecaseTicketSummary := aggregation_usecase.NewTicketSummary(ticketSummary)
 	raceEntityConverter := converter.NewRaceEntityConverter()
 	jockeyEntityConverter := converter.NewJockeyEntityConverter()
 	list := aggregation_service.NewList(raceEntityConverter, jockeyEntityConverter, spreadSheetRepository)
 	aggregation_usecaseList := aggregation_usecase.NewList(list)
-	aggregation := controller.NewAggregation(aggregation_usecaseSummary, aggregation_usecaseTicketSummary, aggregation_usecaseList)
+	reportRepository := infrastructure.NewReportRepository(pathOptimizer)
+	report := aggregation_service.NewReport(term, summary, list, reportRepository)
+	aggregation_usecaseReport := aggregation_usecase.NewReport(report)
+	snapshotDiff := aggregation_usecase.NewSnapshotDiff(snapshot)
+	aggregation := controller.NewAggregation(aggregation_usecaseSummary, aggregation_usecaseTicketSummary, aggregation_usecaseList, aggregation_usecaseReport, snapshotDiff, logger)
 	return aggregation
 }
 
-func NewAnalysis(logger *logrus.Logger) *controller.Analysis {
+func NewAnalysis(logger *logrus.Logger, pathConfig *file_gateway.PathConfig) *controller.Analysis {
 	analysisFilter := filter_service.NewAnalysisFilter()
-	pathOptimizer := file_gateway.NewPathOptimizer()
+	pathOptimizer := file_gateway.NewPathOptimizer(pathConfig)
 	spreadSheetConfigGateway := gateway.NewSpreadSheetConfigGateway(pathOptimizer)
 	spreadSheetSummaryGateway := gateway.NewSpreadSheetSummaryGateway(logger, spreadSheetConfigGateway)
 	spreadSheetTicketSummaryGateway := gateway.NewSpreadSheetTicketSummaryGateway(logger, spreadSheetConfigGateway)
@@ -110,35 +133,51 @@ func NewAnalysis(logger *logrus.Logger) *controller.Analysis {
 	spreadSheetAnalysisPlaceAllInGateway := gateway.NewSpreadSheetAnalysisPlaceAllInGateway(logger, spreadSheetConfigGateway)
 	spreadSheetAnalysisPlaceUnhitGateway := gateway.NewSpreadSheetAnalysisPlaceUnhitGateway(spreadSheetConfigGateway, logger)
 	spreadSheetAnalysisRaceTimeGateway := gateway.NewSpreadSheetAnalysisRaceTimeGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisPedigreeGateway := gateway.NewSpreadSheetAnalysisPedigreeGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisTrainerGateway := gateway.NewSpreadSheetAnalysisTrainerGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisMarkerTicketGateway := gateway.NewSpreadSheetAnalysisMarkerTicketGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisPlaceRateGateway := gateway.NewSpreadSheetAnalysisPlaceRateGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisMarkerConsensusGateway := gateway.NewSpreadSheetAnalysisMarkerConsensusGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisTicketRepriceGateway := gateway.NewSpreadSheetAnalysisTicketRepriceGateway(spreadSheetConfigGateway, logger)
 	spreadSheetPredictionOddsGateway := gateway.NewSpreadSheetPredictionOddsGateway(spreadSheetConfigGateway, logger)
 	spreadSheetPredictionCheckListGateway := gateway.NewSpreadSheetPredictionCheckListGateway(logger, spreadSheetConfigGateway)
 	spreadSheetPredictionMarkerGateway := gateway.NewSpreadSheetPredictionMarkerGateway(logger, spreadSheetConfigGateway)
-	spreadSheetRepository := infrastructure.NewSpreadSheetRepository(spreadSheetSummaryGateway, spreadSheetTicketSummaryGateway, spreadSheetListGateway, spreadSheetAnalysisPlaceGateway, spreadSheetAnalysisPlaceAllInGateway, spreadSheetAnalysisPlaceUnhitGateway, spreadSheetAnalysisRaceTimeGateway, spreadSheetPredictionOddsGateway, spreadSheetPredictionCheckListGateway, spreadSheetPredictionMarkerGateway)
+	spreadSheetRepository := infrastructure.NewSpreadSheetRepository(spreadSheetSummaryGateway, spreadSheetTicketSummaryGateway, spreadSheetListGateway, spreadSheetAnalysisPlaceGateway, spreadSheetAnalysisPlaceAllInGateway, spreadSheetAnalysisPlaceUnhitGateway, spreadSheetAnalysisRaceTimeGateway, spreadSheetAnalysisPedigreeGateway, spreadSheetAnalysisTrainerGateway, spreadSheetAnalysisMarkerTicketGateway, spreadSheetAnalysisPlaceRateGateway, spreadSheetAnalysisMarkerConsensusGateway, spreadSheetAnalysisTicketRepriceGateway, spreadSheetPredictionOddsGateway, spreadSheetPredictionCheckListGateway, spreadSheetPredictionMarkerGateway)
 	place := analysis_service.NewPlace(analysisFilter, spreadSheetRepository)
 	placeAllIn := analysis_service.NewPlaceAllIn(analysisFilter, spreadSheetRepository)
 	netKeibaCollector := gateway.NewNetKeibaCollector(pathOptimizer)
 	netKeibaGateway := gateway.NewNetKeibaGateway(netKeibaCollector, logger)
 	horseRepository := infrastructure.NewHorseRepository(netKeibaGateway, pathOptimizer)
-	tospoGateway := gateway.NewTospoGateway(logger)
-	raceForecastRepository := infrastructure.NewRaceForecastRepository(tospoGateway, pathOptimizer)
 	horseEntityConverter := converter.NewHorseEntityConverter()
-	placeCheckList := analysis_service.NewPlaceCheckList()
-	placeNegativeCheck := analysis_service.NewPlaceNegativeCheck()
-	placeCheckPoint := analysis_service.NewPlaceCheckPoint(placeNegativeCheck)
-	placeUnHit := analysis_service.NewPlaceUnHit(horseRepository, raceForecastRepository, spreadSheetRepository, horseEntityConverter, analysisFilter, placeCheckList, placeCheckPoint)
+	placeRuleRepository := infrastructure.NewPlaceRuleRepository(pathOptimizer)
+	placeRule := analysis_service.NewPlaceRule(placeRuleRepository)
+	placeCheckPoint := analysis_service.NewPlaceCheckPoint(placeRule)
+	placeUnHit := analysis_service.NewPlaceUnHit(horseRepository, spreadSheetRepository, horseEntityConverter, analysisFilter, placeRule, placeCheckPoint)
 	placeJockey := analysis_service.NewPlaceJockey()
 	betaWin := analysis_service.NewBetaWin(analysisFilter)
 	raceTime := analysis_service.NewRaceTime(analysisFilter, spreadSheetRepository)
+	pedigree := analysis_service.NewPedigree(horseRepository, spreadSheetRepository, analysisFilter)
+	trainer := analysis_service.NewTrainer(spreadSheetRepository)
+	markerTicket := analysis_service.NewMarkerTicket(spreadSheetRepository)
+	placePaddock := analysis_service.NewPlacePaddock(spreadSheetRepository)
+	commentLexiconRepository := infrastructure.NewCommentLexiconRepository(pathOptimizer)
+	commentScore := analysis_service.NewCommentScore(commentLexiconRepository, spreadSheetRepository)
+	markerConsensus := analysis_service.NewMarkerConsensus(spreadSheetRepository, analysisFilter)
+	ticketReprice := analysis_service.NewTicketReprice(spreadSheetRepository)
+	snapshotRepository := infrastructure.NewSnapshotRepository(pathOptimizer)
+	snapshot := snapshot_service.NewSnapshot(snapshotRepository)
 	horse := master_service.NewHorse(horseRepository, horseEntityConverter)
+	tospoGateway := gateway.NewTospoGateway(logger)
+	raceForecastRepository := infrastructure.NewRaceForecastRepository(tospoGateway, pathOptimizer)
 	raceForecastEntityConverter := converter.NewRaceForecastEntityConverter()
 	raceForecast := master_service.NewRaceForecast(raceForecastRepository, raceForecastEntityConverter)
-	analysis := analysis_usecase.NewAnalysis(place, placeAllIn, placeUnHit, placeJockey, betaWin, placeCheckPoint, raceTime, horse, raceForecast, raceForecastEntityConverter, horseEntityConverter)
+	analysis := analysis_usecase.NewAnalysis(place, placeAllIn, placeUnHit, placeJockey, betaWin, placeCheckPoint, placeRule, raceTime, pedigree, trainer, markerTicket, placePaddock, commentScore, markerConsensus, ticketReprice, snapshot, horse, raceForecast, raceForecastEntityConverter, horseEntityConverter, logger)
 	controllerAnalysis := controller.NewAnalysis(analysis, logger)
 	return controllerAnalysis
 }
 
-func NewPrediction(logger *logrus.Logger) *controller.Prediction {
-	pathOptimizer := file_gateway.NewPathOptimizer()
+func NewPrediction(logger *logrus.Logger, pathConfig *file_gateway.PathConfig) *controller.Prediction {
+	pathOptimizer := file_gateway.NewPathOptimizer(pathConfig)
 	netKeibaCollector := gateway.NewNetKeibaCollector(pathOptimizer)
 	netKeibaGateway := gateway.NewNetKeibaGateway(netKeibaCollector, logger)
 	oddsRepository := infrastructure.NewOddsRepository(netKeibaGateway, pathOptimizer)
@@ -151,39 +190,142 @@ func NewPrediction(logger *logrus.Logger) *controller.Prediction {
 	spreadSheetAnalysisPlaceAllInGateway := gateway.NewSpreadSheetAnalysisPlaceAllInGateway(logger, spreadSheetConfigGateway)
 	spreadSheetAnalysisPlaceUnhitGateway := gateway.NewSpreadSheetAnalysisPlaceUnhitGateway(spreadSheetConfigGateway, logger)
 	spreadSheetAnalysisRaceTimeGateway := gateway.NewSpreadSheetAnalysisRaceTimeGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisPedigreeGateway := gateway.NewSpreadSheetAnalysisPedigreeGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisTrainerGateway := gateway.NewSpreadSheetAnalysisTrainerGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisMarkerTicketGateway := gateway.NewSpreadSheetAnalysisMarkerTicketGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisPlaceRateGateway := gateway.NewSpreadSheetAnalysisPlaceRateGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisMarkerConsensusGateway := gateway.NewSpreadSheetAnalysisMarkerConsensusGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisTicketRepriceGateway := gateway.NewSpreadSheetAnalysisTicketRepriceGateway(spreadSheetConfigGateway, logger)
 	spreadSheetPredictionOddsGateway := gateway.NewSpreadSheetPredictionOddsGateway(spreadSheetConfigGateway, logger)
 	spreadSheetPredictionCheckListGateway := gateway.NewSpreadSheetPredictionCheckListGateway(logger, spreadSheetConfigGateway)
 	spreadSheetPredictionMarkerGateway := gateway.NewSpreadSheetPredictionMarkerGateway(logger, spreadSheetConfigGateway)
-	spreadSheetRepository := infrastructure.NewSpreadSheetRepository(spreadSheetSummaryGateway, spreadSheetTicketSummaryGateway, spreadSheetListGateway, spreadSheetAnalysisPlaceGateway, spreadSheetAnalysisPlaceAllInGateway, spreadSheetAnalysisPlaceUnhitGateway, spreadSheetAnalysisRaceTimeGateway, spreadSheetPredictionOddsGateway, spreadSheetPredictionCheckListGateway, spreadSheetPredictionMarkerGateway)
+	spreadSheetRepository := infrastructure.NewSpreadSheetRepository(spreadSheetSummaryGateway, spreadSheetTicketSummaryGateway, spreadSheetListGateway, spreadSheetAnalysisPlaceGateway, spreadSheetAnalysisPlaceAllInGateway, spreadSheetAnalysisPlaceUnhitGateway, spreadSheetAnalysisRaceTimeGateway, spreadSheetAnalysisPedigreeGateway, spreadSheetAnalysisTrainerGateway, spreadSheetAnalysisMarkerTicketGateway, spreadSheetAnalysisPlaceRateGateway, spreadSheetAnalysisMarkerConsensusGateway, spreadSheetAnalysisTicketRepriceGateway, spreadSheetPredictionOddsGateway, spreadSheetPredictionCheckListGateway, spreadSheetPredictionMarkerGateway)
 	predictionFilter := filter_service.NewPredictionFilter()
-	odds := prediction_service.NewOdds(oddsRepository, raceRepository, spreadSheetRepository, predictionFilter)
+	oddsEntityConverter := converter.NewOddsEntityConverter()
+	odds := prediction_service.NewOdds(oddsRepository, raceRepository, spreadSheetRepository, predictionFilter, oddsEntityConverter)
 	tospoGateway := gateway.NewTospoGateway(logger)
 	raceForecastRepository := infrastructure.NewRaceForecastRepository(tospoGateway, pathOptimizer)
 	horseRepository := infrastructure.NewHorseRepository(netKeibaGateway, pathOptimizer)
 	jockeyRepository := infrastructure.NewJockeyRepository(netKeibaGateway, pathOptimizer)
-	trainerRepository := infrastructure.NewTrainerRepository(netKeibaGateway)
+	trainerRepository := infrastructure.NewTrainerRepository(netKeibaGateway, pathOptimizer)
 	raceEntityConverter := converter.NewRaceEntityConverter()
 	horseEntityConverter := converter.NewHorseEntityConverter()
-	placeCheckList := analysis_service.NewPlaceCheckList()
-	placeCandidate := prediction_service.NewPlaceCandidate(raceRepository, raceForecastRepository, horseRepository, jockeyRepository, trainerRepository, oddsRepository, spreadSheetRepository, raceEntityConverter, horseEntityConverter, predictionFilter, placeCheckList, odds)
+	placeRuleRepository := infrastructure.NewPlaceRuleRepository(pathOptimizer)
+	placeRule := analysis_service.NewPlaceRule(placeRuleRepository)
+	placeCheckList := analysis_service.NewPlaceCheckList(placeRule)
+	checkList := prediction_service.NewCheckList()
+	predictionCheckListRepository := infrastructure.NewPredictionCheckListRepository(pathOptimizer)
+	predictionCheckListEntityConverter := converter.NewPredictionCheckListEntityConverter()
+	checkListSnapshot := prediction_service.NewCheckListSnapshot(predictionCheckListRepository, predictionCheckListEntityConverter)
+	placeCandidate := prediction_service.NewPlaceCandidate(raceRepository, raceForecastRepository, horseRepository, jockeyRepository, trainerRepository, oddsRepository, spreadSheetRepository, raceEntityConverter, horseEntityConverter, predictionFilter, placeCheckList, odds, checkList, checkListSnapshot)
 	raceIdRepository := infrastructure.NewRaceIdRepository(netKeibaGateway, pathOptimizer)
 	markerSync := prediction_service.NewMarkerSync(raceIdRepository, raceRepository, spreadSheetRepository)
+	stakeRuleRepository := infrastructure.NewStakeRuleRepository(pathOptimizer)
+	betListRepository := infrastructure.NewBetListRepository(pathOptimizer)
+	stake := prediction_service.NewStake(stakeRuleRepository, betListRepository)
+	betSlipRepository := infrastructure.NewBetSlipRepository(pathOptimizer)
+	betNumberConverter := master_service.NewBetNumberConverter()
+	betSlip := prediction_service.NewBetSlip(raceRepository, betSlipRepository, betNumberConverter)
 	analysisFilter := filter_service.NewAnalysisFilter()
 	place := analysis_service.NewPlace(analysisFilter, spreadSheetRepository)
 	raceTime := analysis_service.NewRaceTime(analysisFilter, spreadSheetRepository)
-	prediction := prediction_usecase.NewPrediction(odds, placeCandidate, markerSync, place, raceTime, logger)
+	trainer := analysis_service.NewTrainer(spreadSheetRepository)
+	raceForecastEntityConverter := converter.NewRaceForecastEntityConverter()
+	placeScore := analysis_service.NewPlaceScore(placeCheckList, raceEntityConverter, horseEntityConverter, raceForecastEntityConverter)
+	commentLexiconRepository := infrastructure.NewCommentLexiconRepository(pathOptimizer)
+	commentScore := analysis_service.NewCommentScore(commentLexiconRepository, spreadSheetRepository)
+	placeCheckPoint := analysis_service.NewPlaceCheckPoint(placeRule)
+	placeUnHit := analysis_service.NewPlaceUnHit(horseRepository, spreadSheetRepository, horseEntityConverter, analysisFilter, placeRule, placeCheckPoint)
+	raceRisk := analysis_service.NewRaceRisk(placeUnHit)
+	horse := master_service.NewHorse(horseRepository, horseEntityConverter)
+	raceForecast := master_service.NewRaceForecast(raceForecastRepository, raceForecastEntityConverter)
+	analysisMarkerRepository := infrastructure.NewAnalysisMarkerRepository(pathOptimizer)
+	analysisMarker := master_service.NewAnalysisMarker(analysisMarkerRepository, logger)
+	prediction := prediction_usecase.NewPrediction(odds, placeCandidate, markerSync, stake, betSlip, place, raceTime, trainer, placeScore, placeRule, commentScore, raceRisk, horse, raceForecast, analysisMarker, logger)
 	controllerPrediction := controller.NewPrediction(prediction, logger)
 	return controllerPrediction
 }
 
+func NewServer(logger *logrus.Logger, pathConfig *file_gateway.PathConfig) *controller.Server {
+	analysisFilter := filter_service.NewAnalysisFilter()
+	pathOptimizer := file_gateway.NewPathOptimizer(pathConfig)
+	spreadSheetConfigGateway := gateway.NewSpreadSheetConfigGateway(pathOptimizer)
+	spreadSheetSummaryGateway := gateway.NewSpreadSheetSummaryGateway(logger, spreadSheetConfigGateway)
+	spreadSheetTicketSummaryGateway := gateway.NewSpreadSheetTicketSummaryGateway(logger, spreadSheetConfigGateway)
+	spreadSheetListGateway := gateway.NewSpreadSheetListGateway(logger, spreadSheetConfigGateway)
+	spreadSheetAnalysisPlaceGateway := gateway.NewSpreadSheetAnalysisPlaceGateway(logger, spreadSheetConfigGateway)
+	spreadSheetAnalysisPlaceAllInGateway := gateway.NewSpreadSheetAnalysisPlaceAllInGateway(logger, spreadSheetConfigGateway)
+	spreadSheetAnalysisPlaceUnhitGateway := gateway.NewSpreadSheetAnalysisPlaceUnhitGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisRaceTimeGateway := gateway.NewSpreadSheetAnalysisRaceTimeGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisPedigreeGateway := gateway.NewSpreadSheetAnalysisPedigreeGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisTrainerGateway := gateway.NewSpreadSheetAnalysisTrainerGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisMarkerTicketGateway := gateway.NewSpreadSheetAnalysisMarkerTicketGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisPlaceRateGateway := gateway.NewSpreadSheetAnalysisPlaceRateGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisMarkerConsensusGateway := gateway.NewSpreadSheetAnalysisMarkerConsensusGateway(spreadSheetConfigGateway, logger)
+	spreadSheetAnalysisTicketRepriceGateway := gateway.NewSpreadSheetAnalysisTicketRepriceGateway(spreadSheetConfigGateway, logger)
+	spreadSheetPredictionOddsGateway := gateway.NewSpreadSheetPredictionOddsGateway(spreadSheetConfigGateway, logger)
+	spreadSheetPredictionCheckListGateway := gateway.NewSpreadSheetPredictionCheckListGateway(logger, spreadSheetConfigGateway)
+	spreadSheetPredictionMarkerGateway := gateway.NewSpreadSheetPredictionMarkerGateway(logger, spreadSheetConfigGateway)
+	spreadSheetRepository := infrastructure.NewSpreadSheetRepository(spreadSheetSummaryGateway, spreadSheetTicketSummaryGateway, spreadSheetListGateway, spreadSheetAnalysisPlaceGateway, spreadSheetAnalysisPlaceAllInGateway, spreadSheetAnalysisPlaceUnhitGateway, spreadSheetAnalysisRaceTimeGateway, spreadSheetAnalysisPedigreeGateway, spreadSheetAnalysisTrainerGateway, spreadSheetAnalysisMarkerTicketGateway, spreadSheetAnalysisPlaceRateGateway, spreadSheetAnalysisMarkerConsensusGateway, spreadSheetAnalysisTicketRepriceGateway, spreadSheetPredictionOddsGateway, spreadSheetPredictionCheckListGateway, spreadSheetPredictionMarkerGateway)
+	place := analysis_service.NewPlace(analysisFilter, spreadSheetRepository)
+	placeAllIn := analysis_service.NewPlaceAllIn(analysisFilter, spreadSheetRepository)
+	raceTime := analysis_service.NewRaceTime(analysisFilter, spreadSheetRepository)
+	term := summary_service.NewTerm()
+	ticket := summary_service.NewTicket()
+	class := summary_service.NewClass()
+	courseCategory := summary_service.NewCourseCategory()
+	distanceCategory := summary_service.NewDistanceCategory()
+	raceCourse := summary_service.NewRaceCourse()
+	summary := aggregation_service.NewSummary(term, ticket, class, courseCategory, distanceCategory, raceCourse, spreadSheetRepository)
+	raceEntityConverter := converter.NewRaceEntityConverter()
+	oddsEntityConverter := converter.NewOddsEntityConverter()
+	api := api_usecase.NewApi(place, placeAllIn, raceTime, summary, analysisFilter, raceEntityConverter, oddsEntityConverter)
+	jockeyEntityConverter := converter.NewJockeyEntityConverter()
+	list := aggregation_service.NewList(raceEntityConverter, jockeyEntityConverter, spreadSheetRepository)
+	predictionCheckListRepository := infrastructure.NewPredictionCheckListRepository(pathOptimizer)
+	predictionCheckListEntityConverter := converter.NewPredictionCheckListEntityConverter()
+	checkListSnapshot := prediction_service.NewCheckListSnapshot(predictionCheckListRepository, predictionCheckListEntityConverter)
+	dashboard := dashboard_usecase.NewDashboard(summary, list, place, checkListSnapshot)
+	server := controller.NewServer(api, dashboard, logger)
+	return server
+}
+
+func NewCache(logger *logrus.Logger, pathConfig *file_gateway.PathConfig) *controller.Cache {
+	pathOptimizer := file_gateway.NewPathOptimizer(pathConfig)
+	cacheRepository := infrastructure.NewCacheRepository(pathOptimizer)
+	cache := master_service.NewCache(cacheRepository, logger)
+	netKeibaCollector := gateway.NewNetKeibaCollector(pathOptimizer)
+	netKeibaGateway := gateway.NewNetKeibaGateway(netKeibaCollector, logger)
+	raceRepository := infrastructure.NewRaceRepository(netKeibaGateway, pathOptimizer)
+	oddsRepository := infrastructure.NewOddsRepository(netKeibaGateway, pathOptimizer)
+	raceTimeRepository := infrastructure.NewRaceTimeRepository(netKeibaGateway, pathOptimizer)
+	raceIdRepository := infrastructure.NewRaceIdRepository(netKeibaGateway, pathOptimizer)
+	tospoGateway := gateway.NewTospoGateway(logger)
+	raceForecastRepository := infrastructure.NewRaceForecastRepository(tospoGateway, pathOptimizer)
+	raceEntityConverter := converter.NewRaceEntityConverter()
+	oddsEntityConverter := converter.NewOddsEntityConverter()
+	raceTimeEntityConverter := converter.NewRaceTimeEntityConverter()
+	cacheEntry := master_service.NewCacheEntry(raceRepository, oddsRepository, raceTimeRepository, raceIdRepository, raceForecastRepository, cacheRepository, raceEntityConverter, oddsEntityConverter, raceTimeEntityConverter, logger)
+	checkpointRepository := infrastructure.NewCheckpointRepository(pathOptimizer)
+	checkpoint := master_service.NewCheckpoint(checkpointRepository, logger)
+	raceId := master_service.NewRaceId(raceIdRepository, checkpoint, logger)
+	cache_usecaseCache := cache_usecase.NewCache(cache, cacheEntry, raceId)
+	controllerCache := controller.NewCache(cache_usecaseCache, logger)
+	return controllerCache
+}
+
 // wire.go:
 
-var MasterSet = wire.NewSet(master_usecase.NewMaster, master_service.NewTicket, master_service.NewRaceId, master_service.NewRace, master_service.NewJockey, master_service.NewWinOdds, master_service.NewPlaceOdds, master_service.NewQuinellaOdds, master_service.NewTrioOdds, master_service.NewAnalysisMarker, master_service.NewPredictionMarker, master_service.NewBetNumberConverter, master_service.NewUmacaTicket, master_service.NewRaceForecast, master_service.NewRaceTime, converter.NewRaceEntityConverter, converter.NewJockeyEntityConverter, converter.NewOddsEntityConverter, converter.NewRaceForecastEntityConverter, converter.NewRaceTimeEntityConverter, infrastructure.NewTicketRepository, infrastructure.NewRaceIdRepository, infrastructure.NewRaceRepository, infrastructure.NewRaceForecastRepository, infrastructure.NewJockeyRepository, infrastructure.NewOddsRepository, infrastructure.NewAnalysisMarkerRepository, infrastructure.NewPredictionMarkerRepository, infrastructure.NewUmacaTicketRepository, infrastructure.NewRaceTimeRepository, gateway.NewNetKeibaGateway, gateway.NewNetKeibaCollector, gateway.NewTospoGateway, file_gateway.NewPathOptimizer)
+var MasterSet = wire.NewSet(master_usecase.NewMaster, master_service.NewTicket, master_service.NewRaceId, master_service.NewRace, master_service.NewJockey, master_service.NewTrainer, master_service.NewWinOdds, master_service.NewPlaceOdds, master_service.NewQuinellaOdds, master_service.NewTrioOdds, master_service.NewAnalysisMarker, master_service.NewPredictionMarker, master_service.NewBetNumberConverter, master_service.NewUmacaTicket, master_service.NewNarTicket, master_service.NewRaceForecast, master_service.NewRaceTime, master_service.NewCheckpoint, converter.NewRaceEntityConverter, converter.NewJockeyEntityConverter, converter.NewTrainerEntityConverter, converter.NewOddsEntityConverter, converter.NewRaceForecastEntityConverter, converter.NewRaceTimeEntityConverter, infrastructure.NewTicketRepository, infrastructure.NewRaceIdRepository, infrastructure.NewRaceRepository, infrastructure.NewRaceForecastRepository, infrastructure.NewJockeyRepository, infrastructure.NewTrainerRepository, infrastructure.NewOddsRepository, infrastructure.NewAnalysisMarkerRepository, infrastructure.NewPredictionMarkerRepository, infrastructure.NewUmacaTicketRepository, infrastructure.NewNarTicketRepository, infrastructure.NewRaceTimeRepository, infrastructure.NewCheckpointRepository, gateway.NewNetKeibaGateway, gateway.NewNetKeibaCollector, gateway.NewTospoGateway, file_gateway.NewPathOptimizer)
+
+var AggregationSet = wire.NewSet(aggregation_usecase.NewSummary, aggregation_usecase.NewTicketSummary, aggregation_usecase.NewList, aggregation_usecase.NewReport, aggregation_usecase.NewSnapshotDiff, aggregation_service.NewSummary, aggregation_service.NewTicketSummary, aggregation_service.NewList, aggregation_service.NewReport, snapshot_service.NewSnapshot, summary_service.NewTerm, summary_service.NewTicket, summary_service.NewClass, summary_service.NewCourseCategory, summary_service.NewDistanceCategory, summary_service.NewRaceCourse, infrastructure.NewSpreadSheetRepository, converter.NewRaceEntityConverter, converter.NewJockeyEntityConverter, infrastructure.NewReportRepository, infrastructure.NewSnapshotRepository)
+
+var AnalysisSet = wire.NewSet(analysis_usecase.NewAnalysis, analysis_service.NewPlace, analysis_service.NewPlaceAllIn, analysis_service.NewPlaceUnHit, analysis_service.NewPlaceJockey, analysis_service.NewPlaceCheckList, analysis_service.NewBetaWin, analysis_service.NewPlaceCheckPoint, analysis_service.NewPlaceRule, analysis_service.NewRaceTime, analysis_service.NewPedigree, analysis_service.NewTrainer, analysis_service.NewPlaceScore, analysis_service.NewMarkerTicket, analysis_service.NewPlacePaddock, analysis_service.NewCommentScore, analysis_service.NewMarkerConsensus, analysis_service.NewTicketReprice, snapshot_service.NewSnapshot, master_service.NewHorse, master_service.NewRaceForecast, filter_service.NewAnalysisFilter, infrastructure.NewHorseRepository, infrastructure.NewRaceForecastRepository, infrastructure.NewPlaceRuleRepository, infrastructure.NewCommentLexiconRepository, infrastructure.NewSnapshotRepository, infrastructure.NewSpreadSheetRepository, gateway.NewNetKeibaGateway, gateway.NewNetKeibaCollector, gateway.NewTospoGateway, converter.NewHorseEntityConverter, converter.NewRaceForecastEntityConverter)
 
-var AggregationSet = wire.NewSet(aggregation_usecase.NewSummary, aggregation_usecase.NewTicketSummary, aggregation_usecase.NewList, aggregation_service.NewSummary, aggregation_service.NewTicketSummary, aggregation_service.NewList, summary_service.NewTerm, summary_service.NewTicket, summary_service.NewClass, summary_service.NewCourseCategory, summary_service.NewDistanceCategory, summary_service.NewRaceCourse, infrastructure.NewSpreadSheetRepository, converter.NewRaceEntityConverter, converter.NewJockeyEntityConverter)
+var PredictionSet = wire.NewSet(prediction_usecase.NewPrediction, prediction_service.NewOdds, prediction_service.NewPlaceCandidate, prediction_service.NewMarkerSync, prediction_service.NewStake, prediction_service.NewBetSlip, prediction_service.NewCheckList, prediction_service.NewCheckListSnapshot, analysis_service.NewRaceRisk, master_service.NewAnalysisMarker, infrastructure.NewAnalysisMarkerRepository, converter.NewPredictionCheckListEntityConverter, converter.NewOddsEntityConverter, filter_service.NewPredictionFilter, infrastructure.NewOddsRepository, infrastructure.NewRaceRepository, infrastructure.NewJockeyRepository, infrastructure.NewTrainerRepository, infrastructure.NewRaceIdRepository, infrastructure.NewPredictionCheckListRepository, infrastructure.NewStakeRuleRepository, infrastructure.NewBetListRepository, infrastructure.NewBetSlipRepository, master_service.NewBetNumberConverter, converter.NewRaceEntityConverter)
 
-var AnalysisSet = wire.NewSet(analysis_usecase.NewAnalysis, analysis_service.NewPlace, analysis_service.NewPlaceAllIn, analysis_service.NewPlaceUnHit, analysis_service.NewPlaceJockey, analysis_service.NewPlaceCheckList, analysis_service.NewBetaWin, analysis_service.NewPlaceCheckPoint, analysis_service.NewPlaceNegativeCheck, analysis_service.NewRaceTime, master_service.NewHorse, master_service.NewRaceForecast, filter_service.NewAnalysisFilter, infrastructure.NewHorseRepository, infrastructure.NewRaceForecastRepository, infrastructure.NewSpreadSheetRepository, gateway.NewNetKeibaGateway, gateway.NewNetKeibaCollector, gateway.NewTospoGateway, converter.NewHorseEntityConverter, converter.NewRaceForecastEntityConverter)
+var ServerSet = wire.NewSet(api_usecase.NewApi, dashboard_usecase.NewDashboard, aggregation_service.NewSummary, aggregation_service.NewList, prediction_service.NewCheckListSnapshot, summary_service.NewTerm, summary_service.NewTicket, summary_service.NewClass, summary_service.NewCourseCategory, summary_service.NewDistanceCategory, summary_service.NewRaceCourse, converter.NewRaceEntityConverter, converter.NewOddsEntityConverter, converter.NewJockeyEntityConverter, converter.NewPredictionCheckListEntityConverter, infrastructure.NewPredictionCheckListRepository)
 
-var PredictionSet = wire.NewSet(prediction_usecase.NewPrediction, prediction_service.NewOdds, prediction_service.NewPlaceCandidate, prediction_service.NewMarkerSync, filter_service.NewPredictionFilter, infrastructure.NewOddsRepository, infrastructure.NewRaceRepository, infrastructure.NewJockeyRepository, infrastructure.NewTrainerRepository, infrastructure.NewRaceIdRepository, converter.NewRaceEntityConverter)
+var CacheSet = wire.NewSet(cache_usecase.NewCache, master_service.NewCache, master_service.NewCacheEntry, master_service.NewRaceId, master_service.NewCheckpoint, converter.NewRaceEntityConverter, converter.NewOddsEntityConverter, converter.NewRaceTimeEntityConverter, infrastructure.NewCacheRepository, infrastructure.NewRaceRepository, infrastructure.NewOddsRepository, infrastructure.NewRaceTimeRepository, infrastructure.NewRaceIdRepository, infrastructure.NewRaceForecastRepository, infrastructure.NewCheckpointRepository, gateway.NewNetKeibaGateway, gateway.NewNetKeibaCollector, gateway.NewTospoGateway, file_gateway.NewPathOptimizer)
 
-var SpreadSheetGatewaySet = wire.NewSet(gateway.NewSpreadSheetSummaryGateway, gateway.NewSpreadSheetTicketSummaryGateway, gateway.NewSpreadSheetListGateway, gateway.NewSpreadSheetAnalysisPlaceGateway, gateway.NewSpreadSheetAnalysisPlaceAllInGateway, gateway.NewSpreadSheetAnalysisPlaceUnhitGateway, gateway.NewSpreadSheetAnalysisRaceTimeGateway, gateway.NewSpreadSheetPredictionOddsGateway, gateway.NewSpreadSheetPredictionCheckListGateway, gateway.NewSpreadSheetPredictionMarkerGateway, gateway.NewSpreadSheetConfigGateway, file_gateway.NewPathOptimizer)
+var SpreadSheetGatewaySet = wire.NewSet(gateway.NewSpreadSheetSummaryGateway, gateway.NewSpreadSheetTicketSummaryGateway, gateway.NewSpreadSheetListGateway, gateway.NewSpreadSheetAnalysisPlaceGateway, gateway.NewSpreadSheetAnalysisPlaceAllInGateway, gateway.NewSpreadSheetAnalysisPlaceUnhitGateway, gateway.NewSpreadSheetAnalysisRaceTimeGateway, gateway.NewSpreadSheetAnalysisPedigreeGateway, gateway.NewSpreadSheetAnalysisTrainerGateway, gateway.NewSpreadSheetAnalysisMarkerTicketGateway, gateway.NewSpreadSheetAnalysisPlaceRateGateway, gateway.NewSpreadSheetAnalysisMarkerConsensusGateway, gateway.NewSpreadSheetAnalysisTicketRepriceGateway, gateway.NewSpreadSheetPredictionOddsGateway, gateway.NewSpreadSheetPredictionCheckListGateway, gateway.NewSpreadSheetPredictionMarkerGateway, gateway.NewSpreadSheetConfigGateway, file_gateway.NewPathOptimizer)
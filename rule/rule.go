@@ -0,0 +1,8 @@
+package rule
+
+import "embed"
+
+// DefaultFS 既定のルールファイル。データディレクトリのruleに同名のファイルがあればそちらを優先する
+//
+//go:embed *.json
+var DefaultFS embed.FS